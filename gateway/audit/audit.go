@@ -0,0 +1,169 @@
+// Package audit writes an append-only JSONL trail of session activity for
+// compliance review. Entries record who practiced when, from where and how
+// much data was processed — never transcript or audio content.
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Audit event names.
+const (
+	// EventConnect is a successful WebSocket session start.
+	EventConnect = "connect"
+	// EventConnectRejected is an upgrade refused before a session started,
+	// e.g. invalid connect-time options.
+	EventConnectRejected = "connect_rejected"
+	// EventSessionEnd is a session closing, with its byte counts.
+	EventSessionEnd = "session_end"
+	// EventRateLimited is a request refused by the per-IP rate limit.
+	EventRateLimited = "rate_limited"
+	// EventQuotaExceeded is audio refused because a daily usage budget is
+	// spent.
+	EventQuotaExceeded = "quota_exceeded"
+)
+
+// Entry is one audit record. Fields are metadata only; transcript text must
+// never be placed in Detail.
+type Entry struct {
+	Time       time.Time `json:"ts"`
+	Event      string    `json:"event"`
+	SessionID  string    `json:"session_id,omitempty"`
+	UserID     string    `json:"user_id,omitempty"`
+	SourceIP   string    `json:"source_ip,omitempty"`
+	UserAgent  string    `json:"user_agent,omitempty"`
+	BytesIn    int64     `json:"bytes_in,omitempty"`
+	BytesOut   int64     `json:"bytes_out,omitempty"`
+	DurationMs int64     `json:"duration_ms,omitempty"`
+	// Detail is a short machine-readable reason, e.g. the validation error
+	// for a rejected connect.
+	Detail string `json:"detail,omitempty"`
+}
+
+// AuditLogger appends entries to daily JSONL files, rotating within a day
+// once a file exceeds the size budget. A nil *AuditLogger is a valid no-op,
+// so callers don't guard every Record call.
+type AuditLogger struct {
+	dir      string
+	maxBytes int64
+	logger   *logrus.Logger
+
+	mu   sync.Mutex
+	file *os.File
+	day  string
+	seq  int
+	size int64
+}
+
+// NewAuditLogger creates the logger writing under dir. An empty dir disables
+// auditing and returns nil.
+func NewAuditLogger(dir string, maxSizeMB int, logger *logrus.Logger) (*AuditLogger, error) {
+	if dir == "" {
+		return nil, nil
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create audit dir: %w", err)
+	}
+	return &AuditLogger{
+		dir:      dir,
+		maxBytes: int64(maxSizeMB) * 1024 * 1024,
+		logger:   logger,
+	}, nil
+}
+
+// Record appends one entry. Write failures are logged rather than propagated:
+// an audit outage must not take sessions down with it.
+func (a *AuditLogger) Record(entry Entry) {
+	if a == nil {
+		return
+	}
+	if entry.Time.IsZero() {
+		entry.Time = time.Now()
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		a.logger.WithError(err).Warn("Failed to encode audit entry")
+		return
+	}
+	line = append(line, '\n')
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if err := a.rotate(entry.Time); err != nil {
+		a.logger.WithError(err).Warn("Failed to rotate audit log")
+		return
+	}
+	n, err := a.file.Write(line)
+	a.size += int64(n)
+	if err != nil {
+		a.logger.WithError(err).Warn("Failed to write audit entry")
+	}
+}
+
+// Close flushes and closes the current file.
+func (a *AuditLogger) Close() error {
+	if a == nil {
+		return nil
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.file == nil {
+		return nil
+	}
+	err := a.file.Close()
+	a.file = nil
+	return err
+}
+
+// rotate opens the file for the entry's day, moving to the next sequence
+// number once the current file exceeds the size budget. Called under a.mu.
+func (a *AuditLogger) rotate(now time.Time) error {
+	day := now.Format("2006-01-02")
+	if a.file != nil && day == a.day && a.size < a.maxBytes {
+		return nil
+	}
+	if a.file != nil {
+		a.file.Close()
+		a.file = nil
+	}
+	if day != a.day {
+		a.day = day
+		a.seq = 0
+	}
+
+	for {
+		file, err := os.OpenFile(a.fileName(), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return err
+		}
+		info, err := file.Stat()
+		if err != nil {
+			file.Close()
+			return err
+		}
+		if info.Size() >= a.maxBytes {
+			file.Close()
+			a.seq++
+			continue
+		}
+		a.file = file
+		a.size = info.Size()
+		return nil
+	}
+}
+
+// fileName is the path of the current day/sequence file.
+func (a *AuditLogger) fileName() string {
+	name := fmt.Sprintf("audit-%s.jsonl", a.day)
+	if a.seq > 0 {
+		name = fmt.Sprintf("audit-%s.%d.jsonl", a.day, a.seq)
+	}
+	return filepath.Join(a.dir, name)
+}
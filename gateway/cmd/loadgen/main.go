@@ -0,0 +1,255 @@
+// loadgen drives many concurrent practice sessions against a gateway to
+// measure how far one gateway+speech-service pair scales. Each session
+// replays a recorded utterance on a fixed cadence via the client SDK and
+// reports per-stage latency percentiles, error rates and dropped frames.
+//
+// Usage:
+//
+//	loadgen -url ws://localhost:8080/ws -sessions 50 -turns 10 -audio utterance.webm
+//	loadgen -mock-providers -sessions 200 -turns 5
+//
+// With -mock-providers an in-process mock gateway is started so the numbers
+// isolate our own overhead from provider latency.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/gateway/pkg/client"
+)
+
+func main() {
+	var (
+		url       = flag.String("url", "ws://localhost:8080/ws", "gateway WebSocket URL")
+		sessions  = flag.Int("sessions", 10, "number of concurrent sessions")
+		turns     = flag.Int("turns", 5, "utterances per session")
+		cadence   = flag.Duration("cadence", 3*time.Second, "delay between utterances")
+		rampUp    = flag.Duration("ramp-up", 0, "time over which sessions are started")
+		audioPath = flag.String("audio", "", "recorded utterance to replay (webm)")
+		mock      = flag.Bool("mock-providers", false, "run against an in-process mock gateway")
+		jsonOut   = flag.String("json", "", "write the machine-readable report to this file")
+	)
+	flag.Parse()
+
+	audio := []byte("loadgen-dummy-utterance")
+	if *audioPath != "" {
+		data, err := os.ReadFile(*audioPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "loadgen: read audio: %v\n", err)
+			os.Exit(1)
+		}
+		audio = data
+	}
+
+	target := *url
+	if *mock {
+		server, err := startMockGateway()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "loadgen: start mock gateway: %v\n", err)
+			os.Exit(1)
+		}
+		defer server.Close()
+		target = server.URL()
+	}
+
+	report := run(target, *sessions, *turns, *cadence, *rampUp, audio)
+
+	raw, _ := json.MarshalIndent(report, "", "  ")
+	if *jsonOut != "" {
+		if err := os.WriteFile(*jsonOut, raw, 0o644); err != nil {
+			fmt.Fprintf(os.Stderr, "loadgen: write report: %v\n", err)
+		}
+	} else {
+		fmt.Println(string(raw))
+	}
+	report.printSummary(os.Stderr)
+}
+
+// Report is the machine-readable result of one run.
+type Report struct {
+	Sessions      int                    `json:"sessions"`
+	TurnsTotal    int64                  `json:"turns_total"`
+	Errors        int64                  `json:"errors"`
+	DroppedFrames int64                  `json:"dropped_frames"`
+	Stages        map[string]Percentiles `json:"stages"`
+}
+
+// Percentiles summarizes one latency distribution in milliseconds.
+type Percentiles struct {
+	Count int     `json:"count"`
+	P50   float64 `json:"p50_ms"`
+	P90   float64 `json:"p90_ms"`
+	P99   float64 `json:"p99_ms"`
+	Max   float64 `json:"max_ms"`
+}
+
+func (r *Report) printSummary(w *os.File) {
+	fmt.Fprintf(w, "\n%d sessions, %d turns, %d errors, %d dropped frames\n",
+		r.Sessions, r.TurnsTotal, r.Errors, r.DroppedFrames)
+	for _, stage := range []string{"asr", "llm", "tts"} {
+		p, ok := r.Stages[stage]
+		if !ok {
+			continue
+		}
+		fmt.Fprintf(w, "  %-4s p50=%6.0fms  p90=%6.0fms  p99=%6.0fms  max=%6.0fms  (n=%d)\n",
+			stage, p.P50, p.P90, p.P99, p.Max, p.Count)
+	}
+}
+
+// collector gathers latency samples from all sessions.
+type collector struct {
+	mu     sync.Mutex
+	stages map[string][]time.Duration
+
+	turns         int64
+	errors        int64
+	droppedFrames int64
+}
+
+func (c *collector) record(stage string, d time.Duration) {
+	c.mu.Lock()
+	c.stages[stage] = append(c.stages[stage], d)
+	c.mu.Unlock()
+}
+
+func (c *collector) report(sessions int) *Report {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	report := &Report{
+		Sessions:      sessions,
+		TurnsTotal:    atomic.LoadInt64(&c.turns),
+		Errors:        atomic.LoadInt64(&c.errors),
+		DroppedFrames: atomic.LoadInt64(&c.droppedFrames),
+		Stages:        make(map[string]Percentiles),
+	}
+	for stage, samples := range c.stages {
+		report.Stages[stage] = percentiles(samples)
+	}
+	return report
+}
+
+func percentiles(samples []time.Duration) Percentiles {
+	if len(samples) == 0 {
+		return Percentiles{}
+	}
+	sorted := make([]time.Duration, len(samples))
+	copy(sorted, samples)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	at := func(q float64) float64 {
+		idx := int(q * float64(len(sorted)-1))
+		return float64(sorted[idx].Milliseconds())
+	}
+	return Percentiles{
+		Count: len(sorted),
+		P50:   at(0.50),
+		P90:   at(0.90),
+		P99:   at(0.99),
+		Max:   float64(sorted[len(sorted)-1].Milliseconds()),
+	}
+}
+
+// run starts the configured sessions (spread over rampUp) and waits for all
+// of them to finish their turns.
+func run(url string, sessions, turns int, cadence, rampUp time.Duration, audio []byte) *Report {
+	c := &collector{stages: make(map[string][]time.Duration)}
+
+	var startDelay time.Duration
+	if sessions > 1 && rampUp > 0 {
+		startDelay = rampUp / time.Duration(sessions)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < sessions; i++ {
+		wg.Add(1)
+		go func(idx int) {
+			defer wg.Done()
+			time.Sleep(time.Duration(idx) * startDelay)
+			runSession(url, fmt.Sprintf("loadgen-%d", idx), turns, cadence, audio, c)
+		}(i)
+	}
+	wg.Wait()
+	return c.report(sessions)
+}
+
+// runSession replays the utterance for the requested number of turns,
+// timestamping each pipeline stage as results arrive.
+func runSession(url, sessionID string, turns int, cadence time.Duration, audio []byte, c *collector) {
+	var mu sync.Mutex
+	var turnStart time.Time
+	turnDone := make(chan struct{}, 1)
+
+	stageOnce := make(map[string]bool)
+	mark := func(stage string) {
+		mu.Lock()
+		defer mu.Unlock()
+		if stageOnce[stage] || turnStart.IsZero() {
+			return
+		}
+		stageOnce[stage] = true
+		c.record(stage, time.Since(turnStart))
+	}
+
+	conn, err := client.Dial(url, client.Options{
+		SessionID: sessionID,
+		Handlers: client.Handlers{
+			OnASRResult: func(client.ASRResult) { mark("asr") },
+			OnLLMResponse: func(r client.LLMResponse) {
+				if !r.IsWelcome {
+					mark("llm")
+				}
+			},
+			OnTTSAudio: func(r client.TTSAudio) {
+				if len(r.Data) == 0 {
+					atomic.AddInt64(&c.droppedFrames, 1)
+				}
+				mark("tts")
+				select {
+				case turnDone <- struct{}{}:
+				default:
+				}
+			},
+			OnError: func(string) { atomic.AddInt64(&c.errors, 1) },
+		},
+	})
+	if err != nil {
+		atomic.AddInt64(&c.errors, 1)
+		return
+	}
+	defer conn.Close()
+
+	for turn := 0; turn < turns; turn++ {
+		mu.Lock()
+		turnStart = time.Now()
+		stageOnce = make(map[string]bool)
+		mu.Unlock()
+
+		if err := conn.StartRecording(); err != nil {
+			atomic.AddInt64(&c.errors, 1)
+			return
+		}
+		if err := conn.SendAudio(audio, client.AudioMeta{Format: "webm", SampleRate: 16000}); err != nil {
+			atomic.AddInt64(&c.errors, 1)
+			return
+		}
+		if err := conn.StopRecording(); err != nil {
+			atomic.AddInt64(&c.errors, 1)
+			return
+		}
+		atomic.AddInt64(&c.turns, 1)
+
+		select {
+		case <-turnDone:
+		case <-time.After(30 * time.Second):
+			atomic.AddInt64(&c.errors, 1)
+		}
+		time.Sleep(cadence)
+	}
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/gateway/model"
+)
+
+// mockGateway is an in-process stand-in for a gateway backed by mock
+// providers. It answers every stop_recording with a canned result sequence
+// after a tiny constant delay, so loadgen measurements isolate transport and
+// harness overhead from real provider latency.
+type mockGateway struct {
+	server   *http.Server
+	listener net.Listener
+}
+
+var mockUpgrader = websocket.Upgrader{
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+func startMockGateway() (*mockGateway, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ws", serveMockSession)
+	server := &http.Server{Handler: mux}
+	go server.Serve(listener)
+
+	return &mockGateway{server: server, listener: listener}, nil
+}
+
+func (m *mockGateway) URL() string {
+	return fmt.Sprintf("ws://%s/ws", m.listener.Addr())
+}
+
+func (m *mockGateway) Close() {
+	m.server.Close()
+}
+
+func serveMockSession(w http.ResponseWriter, r *http.Request) {
+	conn, err := mockUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	sessionID := r.URL.Query().Get("session_id")
+	conn.WriteJSON(&model.WebSocketMessage{
+		Type: model.MessageTypeStatus,
+		Data: map[string]interface{}{"status": "connected", "session_id": sessionID},
+	})
+
+	for {
+		msgType, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		if msgType != websocket.TextMessage || !strings.Contains(string(data), model.ActionStopRecording) {
+			continue
+		}
+
+		// A constant, small latency per stage keeps the mock deterministic.
+		time.Sleep(time.Millisecond)
+		conn.WriteJSON(&model.WebSocketMessage{
+			Type: model.MessageTypeASRResult,
+			Data: map[string]interface{}{"text": "mock transcription", "confidence": 0.9, "is_final": true},
+		})
+		time.Sleep(time.Millisecond)
+		conn.WriteJSON(&model.WebSocketMessage{
+			Type: model.MessageTypeLLMResponse,
+			Data: map[string]interface{}{"text": "mock tutor reply"},
+		})
+		time.Sleep(time.Millisecond)
+		conn.WriteMessage(websocket.BinaryMessage, []byte("mock-mp3"))
+		conn.WriteJSON(&model.WebSocketMessage{
+			Type: model.MessageTypeTTSReady,
+			Data: map[string]interface{}{"format": "mp3", "bytes": 8},
+		})
+	}
+}
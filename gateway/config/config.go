@@ -0,0 +1,186 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultGRPCServiceConfig is the gRPC service config applied to the
+// speech-service connection unless GRPC_SERVICE_CONFIG_JSON overrides it:
+// transient per-RPC failures are retried with exponential backoff so a
+// speech-service restart doesn't fail every call made during the blip.
+const DefaultGRPCServiceConfig = `{
+  "methodConfig": [{
+    "name": [{"service": "speech.v1.SpeechService"}],
+    "retryPolicy": {
+      "maxAttempts": 3,
+      "initialBackoff": "0.5s",
+      "maxBackoff": "5s",
+      "backoffMultiplier": 1.5,
+      "retryableStatusCodes": ["UNAVAILABLE", "RESOURCE_EXHAUSTED"]
+    }
+  }]
+}`
+
+// Config holds gateway runtime settings, loaded from the environment.
+type Config struct {
+	Port              string
+	SpeechServiceAddr string
+	// SpeechServiceAddresses optionally lists several speech-service
+	// backends, comma-separated; each session's stream is pinned to one of
+	// them by session ID. Empty uses the single SpeechServiceAddr.
+	SpeechServiceAddresses string
+	LogLevel               string
+	// GRPCMaxMsgSizeMB bounds individual messages on the speech service
+	// connection; it must match the service's own limit.
+	GRPCMaxMsgSizeMB int
+	// GRPCKeepaliveTime / GRPCKeepaliveTimeout send pings on idle connections
+	// so NAT timeouts don't silently kill long-lived streams.
+	GRPCKeepaliveTime    time.Duration
+	GRPCKeepaliveTimeout time.Duration
+	// GRPCServiceConfigJSON is the gRPC service config JSON for the
+	// speech-service connection, controlling the per-RPC retry policy.
+	GRPCServiceConfigJSON string
+	// GRPCDialTimeout bounds each blocking startup dial attempt, so an
+	// unreachable speech service is detected at startup rather than on the
+	// first session.
+	GRPCDialTimeout time.Duration
+	// TLSEnabled turns on TLS for the speech-service connection. When
+	// TLSCACert is empty the system roots are used.
+	TLSEnabled bool
+	TLSCACert  string
+	// AuditLogDir is where the compliance audit trail is written; empty
+	// disables it. AuditMaxSizeMB caps a single audit file before rotation.
+	AuditLogDir    string
+	AuditMaxSizeMB int
+	// IPRateLimitRPS / IPRateLimitBurst bound requests per source IP across
+	// all endpoints; 0 RPS disables the limit.
+	IPRateLimitRPS   float64
+	IPRateLimitBurst int
+	// QuotaAudioSecondsPerDay / QuotaLLMTokensPerDay are the per-user daily
+	// usage budgets; 0 disables that cap.
+	QuotaAudioSecondsPerDay float64
+	QuotaLLMTokensPerDay    int64
+	// RedisAddr backs quota accounting with Redis so it survives restarts;
+	// empty keeps counters in memory.
+	RedisAddr string
+	// AdminToken gates the pprof and runtime diagnostics endpoints under
+	// /admin/debug; empty (the default) disables them.
+	AdminToken string
+	// ConnectAuthToken is the bearer token required on the Connect RPC
+	// endpoints; empty leaves them open.
+	ConnectAuthToken string
+	// StaticDir overrides the embedded frontend with an on-disk directory
+	// for development; empty serves the compiled-in assets.
+	StaticDir string
+	// WSCompressMinBytes is the smallest text payload the gateway gzips for
+	// clients that negotiated the gzip envelope at connect time; 0 disables
+	// the envelope even for clients that ask for it.
+	WSCompressMinBytes int
+	// WSReadBufferBytes / WSWriteBufferBytes size the WebSocket upgrader's
+	// connection buffers. Clients upload utterances as 100-500 KB binary
+	// frames, so the 32 KB default trades a little memory per connection for
+	// far fewer syscalls than gorilla's 1 KB default.
+	WSReadBufferBytes  int
+	WSWriteBufferBytes int
+	// WSReadLimitBytes caps a single incoming client frame; an oversized
+	// frame closes the connection instead of buffering without bound. 0
+	// leaves frames uncapped.
+	WSReadLimitBytes int64
+	// WSEnableCompression negotiates permessage-deflate with clients that
+	// offer it.
+	WSEnableCompression bool
+}
+
+// Load reads the gateway configuration from environment variables.
+func Load() *Config {
+	return &Config{
+		Port:                   getEnv("GATEWAY_PORT", "8080"),
+		SpeechServiceAddr:      getEnv("SPEECH_SERVICE_ADDR", "localhost:50051"),
+		SpeechServiceAddresses: getEnv("SPEECH_SERVICE_ADDRESSES", ""),
+		LogLevel:               getEnv("LOG_LEVEL", "info"),
+		GRPCMaxMsgSizeMB:       getEnvInt("GRPC_MAX_MSG_SIZE_MB", 16),
+		GRPCKeepaliveTime:      getEnvDuration("GRPC_KEEPALIVE_TIME", 2*time.Minute),
+		GRPCKeepaliveTimeout:   getEnvDuration("GRPC_KEEPALIVE_TIMEOUT", 20*time.Second),
+		GRPCServiceConfigJSON:  getEnv("GRPC_SERVICE_CONFIG_JSON", DefaultGRPCServiceConfig),
+		GRPCDialTimeout:        getEnvDuration("GRPC_DIAL_TIMEOUT", 10*time.Second),
+		TLSEnabled:             getEnvBool("SPEECH_SERVICE_TLS", false),
+		TLSCACert:              getEnv("SPEECH_SERVICE_TLS_CA", ""),
+		AuditLogDir:            getEnv("AUDIT_LOG_DIR", "data/audit"),
+		AuditMaxSizeMB:         getEnvInt("AUDIT_MAX_SIZE_MB", 64),
+		IPRateLimitRPS:         getEnvFloat("GATEWAY_IP_RATE_LIMIT_RPS", 10),
+		IPRateLimitBurst:       getEnvInt("GATEWAY_IP_RATE_LIMIT_BURST", 20),
+
+		QuotaAudioSecondsPerDay: getEnvFloat("GATEWAY_QUOTA_AUDIO_SECONDS", 1800),
+		QuotaLLMTokensPerDay:    int64(getEnvInt("GATEWAY_QUOTA_LLM_TOKENS", 50000)),
+		RedisAddr:               getEnv("GATEWAY_REDIS_ADDR", ""),
+		AdminToken:              getEnv("GATEWAY_ADMIN_TOKEN", ""),
+		ConnectAuthToken:        getEnv("GATEWAY_CONNECT_AUTH_TOKEN", ""),
+		StaticDir:               getEnv("STATIC_DIR", ""),
+		WSCompressMinBytes:      getEnvInt("WS_COMPRESS_MIN_BYTES", 8192),
+		WSReadBufferBytes:       getEnvInt("WS_READ_BUFFER_BYTES", 32*1024),
+		WSWriteBufferBytes:      getEnvInt("WS_WRITE_BUFFER_BYTES", 32*1024),
+		WSReadLimitBytes:        int64(getEnvInt("WS_READ_LIMIT_BYTES", 1<<20)),
+		WSEnableCompression:     getEnvBool("WS_ENABLE_COMPRESSION", true),
+	}
+}
+
+// SpeechServiceBackends returns the backend address list: the entries of
+// SPEECH_SERVICE_ADDRESSES when set, otherwise the single SpeechServiceAddr.
+func (c *Config) SpeechServiceBackends() []string {
+	var addrs []string
+	for _, addr := range strings.Split(c.SpeechServiceAddresses, ",") {
+		if addr = strings.TrimSpace(addr); addr != "" {
+			addrs = append(addrs, addr)
+		}
+	}
+	if len(addrs) == 0 {
+		return []string{c.SpeechServiceAddr}
+	}
+	return addrs
+}
+
+func getEnv(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+func getEnvInt(key string, fallback int) int {
+	if v := os.Getenv(key); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			return n
+		}
+	}
+	return fallback
+}
+
+func getEnvBool(key string, fallback bool) bool {
+	if v := os.Getenv(key); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b
+		}
+	}
+	return fallback
+}
+
+func getEnvFloat(key string, fallback float64) float64 {
+	if v := os.Getenv(key); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return f
+		}
+	}
+	return fallback
+}
+
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	if v := os.Getenv(key); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			return d
+		}
+	}
+	return fallback
+}
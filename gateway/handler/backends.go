@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	speechv1 "github.com/CurvatureX/ai-tutor-monorepo/shared/proto/speech"
+)
+
+// backendCooldown is how long a failed backend is excluded from new session
+// pinning before it is eligible again.
+const backendCooldown = 30 * time.Second
+
+// backend pairs one speech-service instance with its health state.
+type backend struct {
+	addr   string
+	client speechv1.SpeechServiceClient
+	// downUntil excludes the backend from new pinning until it passes.
+	// Guarded by the pool mutex.
+	downUntil time.Time
+}
+
+// BackendPool holds one client per speech-service instance and pins each
+// session to one of them by rendezvous hash of the session ID, so the
+// stateful VoiceSession stays on a single instance for as long as that
+// instance is healthy. A reconnecting client with the same session ID lands
+// on the same backend unless it was marked unhealthy in the meantime.
+type BackendPool struct {
+	mu       sync.RWMutex
+	backends []*backend
+	cooldown time.Duration
+	logger   *logrus.Logger
+}
+
+// NewBackendPool creates an empty pool.
+func NewBackendPool(logger *logrus.Logger) *BackendPool {
+	return &BackendPool{cooldown: backendCooldown, logger: logger}
+}
+
+// Add registers a backend under its address.
+func (p *BackendPool) Add(addr string, client speechv1.SpeechServiceClient) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.backends = append(p.backends, &backend{addr: addr, client: client})
+}
+
+// Len returns the number of registered backends.
+func (p *BackendPool) Len() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return len(p.backends)
+}
+
+// Pick returns the backend a session pins to: the healthy backend with the
+// highest rendezvous score for the session ID. When every backend is marked
+// down the full set is considered, since a possibly-dead backend still beats
+// refusing the session outright.
+func (p *BackendPool) Pick(sessionID string) (speechv1.SpeechServiceClient, string, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if len(p.backends) == 0 {
+		return nil, "", fmt.Errorf("no speech-service backends configured")
+	}
+	now := time.Now()
+	best := p.best(sessionID, now, true)
+	if best == nil {
+		best = p.best(sessionID, now, false)
+	}
+	return best.client, best.addr, nil
+}
+
+func (p *BackendPool) best(sessionID string, now time.Time, healthyOnly bool) *backend {
+	var best *backend
+	var bestScore uint64
+	for _, b := range p.backends {
+		if healthyOnly && now.Before(b.downUntil) {
+			continue
+		}
+		if score := rendezvousScore(sessionID, b.addr); best == nil || score > bestScore {
+			best, bestScore = b, score
+		}
+	}
+	return best
+}
+
+// rendezvousScore ranks a backend for a session. Each session orders the
+// backends differently, so load spreads while any one session's choice stays
+// stable as backends come and go.
+func rendezvousScore(sessionID, addr string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(sessionID))
+	h.Write([]byte{0})
+	h.Write([]byte(addr))
+	return h.Sum64()
+}
+
+// MarkUnhealthy excludes a backend from new pinning for the cooldown period,
+// after which it is retried automatically.
+func (p *BackendPool) MarkUnhealthy(addr string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, b := range p.backends {
+		if b.addr == addr {
+			b.downUntil = time.Now().Add(p.cooldown)
+			p.logger.Warnf("Speech backend %s marked unhealthy for %s", addr, p.cooldown)
+			return
+		}
+	}
+}
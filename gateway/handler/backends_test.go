@@ -0,0 +1,198 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/gateway/manager"
+	speechv1 "github.com/CurvatureX/ai-tutor-monorepo/shared/proto/speech"
+	"github.com/CurvatureX/ai-tutor-monorepo/shared/speechtest"
+)
+
+// recordingBackend is a speech-service stub that records the session IDs of
+// the conversation streams it serves.
+type recordingBackend struct {
+	speechv1.UnimplementedSpeechServiceServer
+	mu       sync.Mutex
+	sessions []string
+}
+
+func (s *recordingBackend) ProcessVoiceConversation(stream speechv1.SpeechService_ProcessVoiceConversationServer) error {
+	for {
+		req, err := stream.Recv()
+		if err != nil {
+			return nil
+		}
+		s.mu.Lock()
+		s.sessions = append(s.sessions, req.GetSessionId())
+		s.mu.Unlock()
+	}
+}
+
+func (s *recordingBackend) seen(sessionID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, id := range s.sessions {
+		if id == sessionID {
+			return true
+		}
+	}
+	return false
+}
+
+func quietLogger() *logrus.Logger {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	return logger
+}
+
+// sessionPinnedTo scans generated session IDs for one the pool pins to addr.
+func sessionPinnedTo(t *testing.T, pool *BackendPool, addr string) string {
+	t.Helper()
+	for i := 0; i < 100; i++ {
+		id := fmt.Sprintf("session-%03d", i)
+		if _, picked, err := pool.Pick(id); err != nil {
+			t.Fatalf("Pick: %v", err)
+		} else if picked == addr {
+			return id
+		}
+	}
+	t.Fatalf("no session ID pinned to %s in 100 tries", addr)
+	return ""
+}
+
+// deadBackendClient returns a client whose backend can never be reached, so
+// opening a stream on it fails.
+func deadBackendClient(t *testing.T) speechv1.SpeechServiceClient {
+	t.Helper()
+	listener := bufconn.Listen(1024)
+	listener.Close()
+	conn, err := grpc.Dial("bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("dial bufconn: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return speechv1.NewSpeechServiceClient(conn)
+}
+
+func TestPickIsStickyPerSession(t *testing.T) {
+	pool := NewBackendPool(quietLogger())
+	pool.Add("backend-a", nil)
+	pool.Add("backend-b", nil)
+
+	_, first, err := pool.Pick("sticky-session")
+	if err != nil {
+		t.Fatalf("Pick: %v", err)
+	}
+	for i := 0; i < 10; i++ {
+		if _, addr, _ := pool.Pick("sticky-session"); addr != first {
+			t.Fatalf("Pick moved a pinned session from %s to %s", first, addr)
+		}
+	}
+
+	// Both backends receive some share of sessions.
+	sessionPinnedTo(t, pool, "backend-a")
+	sessionPinnedTo(t, pool, "backend-b")
+}
+
+func TestUnhealthyBackendExcludedUntilCooldown(t *testing.T) {
+	pool := NewBackendPool(quietLogger())
+	pool.cooldown = 50 * time.Millisecond
+	pool.Add("backend-a", nil)
+	pool.Add("backend-b", nil)
+
+	sid := sessionPinnedTo(t, pool, "backend-a")
+	pool.MarkUnhealthy("backend-a")
+	if _, addr, _ := pool.Pick(sid); addr != "backend-b" {
+		t.Fatalf("Pick = %s, want the healthy backend-b", addr)
+	}
+
+	time.Sleep(80 * time.Millisecond)
+	if _, addr, _ := pool.Pick(sid); addr != "backend-a" {
+		t.Errorf("Pick = %s, want backend-a back after the cooldown", addr)
+	}
+}
+
+func TestSessionsPinAcrossBackends(t *testing.T) {
+	var stubA, stubB recordingBackend
+	pool := NewBackendPool(quietLogger())
+	pool.Add("backend-a", speechtest.NewBufconnClient(t, &stubA))
+	pool.Add("backend-b", speechtest.NewBufconnClient(t, &stubB))
+
+	logger := quietLogger()
+	sessions := manager.NewSessionManager(logger)
+	h := NewEnhancedWebSocketHandler(nil, sessions, nil, nil, nil, 0, logger)
+	h.SetBackendPool(pool)
+
+	sidA := sessionPinnedTo(t, pool, "backend-a")
+	sidB := sessionPinnedTo(t, pool, "backend-b")
+	for _, sid := range []string{sidA, sidB} {
+		session := sessions.Add(sid, "user-1", nil)
+		stream, err := h.initGRPCStream(session)
+		if err != nil {
+			t.Fatalf("initGRPCStream(%s): %v", sid, err)
+		}
+		if err := stream.Send(&speechv1.VoiceRequest{
+			SessionId:   sid,
+			RequestType: &speechv1.VoiceRequest_TextInput{TextInput: &speechv1.TextInput{Text: "hi"}},
+		}); err != nil {
+			t.Fatalf("send on %s: %v", sid, err)
+		}
+		stream.CloseSend()
+	}
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if stubA.seen(sidA) && stubB.seen(sidB) {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if !stubA.seen(sidA) || stubA.seen(sidB) {
+		t.Errorf("backend-a saw %v, want only %s", stubA.sessions, sidA)
+	}
+	if !stubB.seen(sidB) || stubB.seen(sidA) {
+		t.Errorf("backend-b saw %v, want only %s", stubB.sessions, sidB)
+	}
+}
+
+func TestStreamFailoverRepinsSession(t *testing.T) {
+	var alive recordingBackend
+	pool := NewBackendPool(quietLogger())
+	pool.Add("alive", speechtest.NewBufconnClient(t, &alive))
+	pool.Add("dead", deadBackendClient(t))
+
+	logger := quietLogger()
+	sessions := manager.NewSessionManager(logger)
+	h := NewEnhancedWebSocketHandler(nil, sessions, nil, nil, nil, 0, logger)
+	h.SetBackendPool(pool)
+
+	sid := sessionPinnedTo(t, pool, "dead")
+	session := sessions.Add(sid, "user-1", nil)
+	stream, err := h.initGRPCStream(session)
+	if err != nil {
+		t.Fatalf("initGRPCStream should fail over, got: %v", err)
+	}
+	defer stream.CloseSend()
+	if session.Backend != "alive" {
+		t.Errorf("session pinned to %q, want the alive backend", session.Backend)
+	}
+
+	// The dead backend stays out of new pinning while it cools down.
+	if _, addr, _ := pool.Pick(sid); addr != "alive" {
+		t.Errorf("Pick = %s, want alive while dead is cooling down", addr)
+	}
+}
@@ -0,0 +1,212 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	speechv1 "github.com/CurvatureX/ai-tutor-monorepo/shared/proto/speech"
+)
+
+// ConnectHandler exposes the speech-service's unary RPCs over the Connect
+// protocol, so connect-web clients call Transcribe, Synthesize and
+// EvaluatePronunciation with typed protobuf messages instead of the
+// hand-rolled WebSocket JSON. Only the JSON codec is served: the binary
+// protobuf codec needs generated reflection the hand-written bindings do not
+// carry, and clients must serialize with proto field names (snake_case).
+// The streaming conversation stays on the WebSocket bridge.
+type ConnectHandler struct {
+	speechClient speechv1.SpeechServiceClient
+	logger       *logrus.Logger
+}
+
+// NewConnectHandler creates the Connect unary proxy.
+func NewConnectHandler(client speechv1.SpeechServiceClient, logger *logrus.Logger) *ConnectHandler {
+	return &ConnectHandler{speechClient: client, logger: logger}
+}
+
+// RegisterRoutes mounts the RPC methods on the group, which the caller
+// mounts at the Connect path /speech.v1.SpeechService.
+func (h *ConnectHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.POST("/Transcribe", h.Transcribe)
+	rg.POST("/Synthesize", h.Synthesize)
+	rg.POST("/EvaluatePronunciation", h.EvaluatePronunciation)
+	rg.OPTIONS("/:method", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+}
+
+// ConnectCORS answers browser preflights and marks the Connect endpoints
+// cross-origin callable; connect-web sends the Connect-Protocol-Version
+// header, which must be explicitly allowed.
+func ConnectCORS() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Access-Control-Allow-Origin", "*")
+		c.Header("Access-Control-Allow-Methods", "POST, OPTIONS")
+		c.Header("Access-Control-Allow-Headers", "Content-Type, Authorization, Connect-Protocol-Version, Connect-Timeout-Ms")
+		c.Header("Access-Control-Max-Age", "86400")
+		if c.Request.Method == http.MethodOptions {
+			c.AbortWithStatus(http.StatusNoContent)
+			return
+		}
+		c.Next()
+	}
+}
+
+// BearerTokenAuth rejects requests without the expected Authorization bearer
+// token, reporting the failure as a Connect unauthenticated error.
+func BearerTokenAuth(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		got := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+				"code":    "unauthenticated",
+				"message": "missing or invalid bearer token",
+			})
+			return
+		}
+		c.Next()
+	}
+}
+
+// Transcribe proxies the unary ASR RPC.
+func (h *ConnectHandler) Transcribe(c *gin.Context) {
+	var req speechv1.TranscribeRequest
+	if !h.readRequest(c, &req) {
+		return
+	}
+	resp, err := h.speechClient.Transcribe(c.Request.Context(), &req)
+	h.writeResponse(c, resp, err)
+}
+
+// Synthesize proxies the unary TTS RPC.
+func (h *ConnectHandler) Synthesize(c *gin.Context) {
+	var req speechv1.SynthesizeRequest
+	if !h.readRequest(c, &req) {
+		return
+	}
+	resp, err := h.speechClient.Synthesize(c.Request.Context(), &req)
+	h.writeResponse(c, resp, err)
+}
+
+// EvaluatePronunciation proxies the unary ISE RPC.
+func (h *ConnectHandler) EvaluatePronunciation(c *gin.Context) {
+	var req speechv1.EvaluateRequest
+	if !h.readRequest(c, &req) {
+		return
+	}
+	resp, err := h.speechClient.EvaluatePronunciation(c.Request.Context(), &req)
+	h.writeResponse(c, resp, err)
+}
+
+// readRequest decodes the JSON request body into the proto message, writing
+// the Connect error itself when the codec or body is unusable.
+func (h *ConnectHandler) readRequest(c *gin.Context, req interface{}) bool {
+	if ct := c.ContentType(); ct != "" && ct != "application/json" {
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{
+			"code":    "unimplemented",
+			"message": "only the JSON codec is supported; set Content-Type: application/json",
+		})
+		return false
+	}
+	body, err := io.ReadAll(c.Request.Body)
+	if err == nil && len(body) > 0 {
+		err = json.Unmarshal(body, req)
+	}
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{
+			"code":    "invalid_argument",
+			"message": "malformed request body: " + err.Error(),
+		})
+		return false
+	}
+	return true
+}
+
+// writeResponse renders the RPC result, translating gRPC status codes to
+// Connect error codes and HTTP statuses.
+func (h *ConnectHandler) writeResponse(c *gin.Context, resp interface{}, err error) {
+	if err != nil {
+		st := status.Convert(err)
+		h.logger.WithFields(logrus.Fields{
+			"path": c.Request.URL.Path,
+			"code": st.Code().String(),
+		}).Warn("Connect RPC failed")
+		c.JSON(connectHTTPStatus(st.Code()), gin.H{
+			"code":    connectCode(st.Code()),
+			"message": st.Message(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// connectCode is the Connect wire name for a gRPC status code.
+func connectCode(code codes.Code) string {
+	switch code {
+	case codes.Canceled:
+		return "canceled"
+	case codes.InvalidArgument:
+		return "invalid_argument"
+	case codes.DeadlineExceeded:
+		return "deadline_exceeded"
+	case codes.NotFound:
+		return "not_found"
+	case codes.AlreadyExists:
+		return "already_exists"
+	case codes.PermissionDenied:
+		return "permission_denied"
+	case codes.ResourceExhausted:
+		return "resource_exhausted"
+	case codes.FailedPrecondition:
+		return "failed_precondition"
+	case codes.Aborted:
+		return "aborted"
+	case codes.OutOfRange:
+		return "out_of_range"
+	case codes.Unimplemented:
+		return "unimplemented"
+	case codes.Internal:
+		return "internal"
+	case codes.Unavailable:
+		return "unavailable"
+	case codes.DataLoss:
+		return "data_loss"
+	case codes.Unauthenticated:
+		return "unauthenticated"
+	}
+	return "unknown"
+}
+
+// connectHTTPStatus maps a gRPC code onto the HTTP status the Connect
+// protocol specifies for it.
+func connectHTTPStatus(code codes.Code) int {
+	switch code {
+	case codes.InvalidArgument, codes.OutOfRange, codes.FailedPrecondition:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Canceled:
+		return 499
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,178 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	speechv1 "github.com/CurvatureX/ai-tutor-monorepo/shared/proto/speech"
+	"github.com/CurvatureX/ai-tutor-monorepo/shared/speechtest"
+)
+
+// stubSpeechServer answers the unary RPCs with canned results so the Connect
+// proxy can be exercised against a real gRPC server over bufconn.
+type stubSpeechServer struct {
+	speechv1.UnimplementedSpeechServiceServer
+}
+
+func (stubSpeechServer) Synthesize(_ context.Context, req *speechv1.SynthesizeRequest) (*speechv1.SynthesizeResponse, error) {
+	if req.Text == "" {
+		return nil, status.Error(codes.InvalidArgument, "text is required")
+	}
+	return &speechv1.SynthesizeResponse{
+		AudioData:  []byte("mp3-for:" + req.Text),
+		Format:     "mp3",
+		DurationMs: 420,
+	}, nil
+}
+
+func (stubSpeechServer) Transcribe(_ context.Context, req *speechv1.TranscribeRequest) (*speechv1.TranscribeResponse, error) {
+	return &speechv1.TranscribeResponse{Text: "transcribed", Confidence: 0.9, Language: req.Language}, nil
+}
+
+func (stubSpeechServer) EvaluatePronunciation(_ context.Context, req *speechv1.EvaluateRequest) (*speechv1.EvaluateResponse, error) {
+	return &speechv1.EvaluateResponse{Result: &speechv1.ISEResult{OverallScore: 88}}, nil
+}
+
+// newConnectGateway serves the Connect proxy backed by the stub server.
+// token gates the endpoints when non-empty.
+func newConnectGateway(t *testing.T, token string) string {
+	t.Helper()
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	client := speechtest.NewBufconnClient(t, stubSpeechServer{})
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	group := router.Group("/speech.v1.SpeechService", ConnectCORS())
+	if token != "" {
+		group.Use(BearerTokenAuth(token))
+	}
+	NewConnectHandler(client, logger).RegisterRoutes(group)
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func TestConnectSynthesizeRoundTrip(t *testing.T) {
+	base := newConnectGateway(t, "")
+
+	resp, err := http.Post(base+"/speech.v1.SpeechService/Synthesize", "application/json",
+		strings.NewReader(`{"text":"Hello learner"}`))
+	if err != nil {
+		t.Fatalf("POST Synthesize: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	if got := resp.Header.Get("Access-Control-Allow-Origin"); got != "*" {
+		t.Errorf("Access-Control-Allow-Origin = %q, want *", got)
+	}
+
+	var synth speechv1.SynthesizeResponse
+	if err := json.NewDecoder(resp.Body).Decode(&synth); err != nil {
+		t.Fatalf("decode response: %v", err)
+	}
+	if string(synth.AudioData) != "mp3-for:Hello learner" || synth.Format != "mp3" || synth.DurationMs != 420 {
+		t.Errorf("response = %+v, want the stub's synthesis", &synth)
+	}
+}
+
+func TestConnectErrorsUseConnectCodes(t *testing.T) {
+	base := newConnectGateway(t, "")
+
+	resp, err := http.Post(base+"/speech.v1.SpeechService/Synthesize", "application/json",
+		strings.NewReader(`{}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("status = %d, want 400", resp.StatusCode)
+	}
+	var connectErr struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&connectErr); err != nil {
+		t.Fatalf("decode error body: %v", err)
+	}
+	if connectErr.Code != "invalid_argument" || connectErr.Message != "text is required" {
+		t.Errorf("error = %+v, want invalid_argument from the server", connectErr)
+	}
+}
+
+func TestConnectPreflightAllowed(t *testing.T) {
+	base := newConnectGateway(t, "s3cret")
+
+	req, _ := http.NewRequest(http.MethodOptions, base+"/speech.v1.SpeechService/Synthesize", nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("preflight status = %d, want 204", resp.StatusCode)
+	}
+	if got := resp.Header.Get("Access-Control-Allow-Headers"); !strings.Contains(got, "Connect-Protocol-Version") {
+		t.Errorf("allowed headers = %q, want Connect-Protocol-Version included", got)
+	}
+}
+
+func TestConnectBearerTokenEnforced(t *testing.T) {
+	base := newConnectGateway(t, "s3cret")
+
+	resp, err := http.Post(base+"/speech.v1.SpeechService/Transcribe", "application/json",
+		strings.NewReader(`{"language":"en-US"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("status without token = %d, want 401", resp.StatusCode)
+	}
+
+	req, _ := http.NewRequest(http.MethodPost, base+"/speech.v1.SpeechService/Transcribe",
+		strings.NewReader(`{"language":"en-US"}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer s3cret")
+	resp, err = http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status with token = %d, want 200", resp.StatusCode)
+	}
+	var trans speechv1.TranscribeResponse
+	if err := json.NewDecoder(resp.Body).Decode(&trans); err != nil {
+		t.Fatal(err)
+	}
+	if trans.Text != "transcribed" || trans.Language != "en-US" {
+		t.Errorf("response = %+v, want the stub transcription", &trans)
+	}
+}
+
+func TestConnectRejectsBinaryCodec(t *testing.T) {
+	base := newConnectGateway(t, "")
+
+	resp, err := http.Post(base+"/speech.v1.SpeechService/Synthesize", "application/proto",
+		strings.NewReader("not-json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusUnsupportedMediaType {
+		t.Errorf("status = %d, want 415", resp.StatusCode)
+	}
+}
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/gateway/manager"
+)
+
+// AdminTokenAuth rejects requests that do not carry the configured admin
+// token in the X-Admin-Token header. The comparison is constant-time so the
+// token cannot be recovered byte by byte.
+func AdminTokenAuth(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		got := c.GetHeader("X-Admin-Token")
+		if subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "admin token required"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// RegisterDiagRoutes mounts net/http/pprof and a runtime summary under the
+// given route group, which the caller is expected to gate with AdminTokenAuth.
+func RegisterDiagRoutes(rg *gin.RouterGroup, sessions *manager.SessionManager) {
+	rg.GET("/pprof/*profile", func(c *gin.Context) {
+		profile := strings.TrimPrefix(c.Param("profile"), "/")
+		switch profile {
+		case "cmdline":
+			pprof.Cmdline(c.Writer, c.Request)
+		case "profile":
+			pprof.Profile(c.Writer, c.Request)
+		case "symbol":
+			pprof.Symbol(c.Writer, c.Request)
+		case "trace":
+			pprof.Trace(c.Writer, c.Request)
+		default:
+			// Index serves the named profiles (goroutine, heap, ...) from the
+			// request path, so rewrite it to the prefix pprof expects.
+			c.Request.URL.Path = "/debug/pprof/" + profile
+			pprof.Index(c.Writer, c.Request)
+		}
+	})
+	rg.GET("/runtime", func(c *gin.Context) {
+		var mem runtime.MemStats
+		runtime.ReadMemStats(&mem)
+		c.JSON(http.StatusOK, gin.H{
+			"goroutines":       runtime.NumGoroutine(),
+			"heap_alloc_bytes": mem.HeapAlloc,
+			"heap_sys_bytes":   mem.HeapSys,
+			"heap_objects":     mem.HeapObjects,
+			"num_gc":           mem.NumGC,
+			"active_sessions":  sessions.Count(),
+		})
+	})
+}
@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/gateway/manager"
+)
+
+func newDiagRouter(t *testing.T, token string) *gin.Engine {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	router := gin.New()
+	diag := router.Group("/admin/debug", AdminTokenAuth(token))
+	RegisterDiagRoutes(diag, manager.NewSessionManager(logger))
+	return router
+}
+
+func TestDiagRejectedWithoutAdminToken(t *testing.T) {
+	router := newDiagRouter(t, "s3cret")
+
+	for _, path := range []string{"/admin/debug/runtime", "/admin/debug/pprof/goroutine"} {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("GET %s without token = %d, want 401", path, rec.Code)
+		}
+
+		rec = httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("X-Admin-Token", "wrong")
+		router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusUnauthorized {
+			t.Errorf("GET %s with wrong token = %d, want 401", path, rec.Code)
+		}
+	}
+}
+
+func TestDiagEndpointsRespondWithToken(t *testing.T) {
+	router := newDiagRouter(t, "s3cret")
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/admin/debug/runtime", nil)
+	req.Header.Set("X-Admin-Token", "s3cret")
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /admin/debug/runtime = %d, want 200", rec.Code)
+	}
+	var info map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &info); err != nil {
+		t.Fatalf("parse runtime response: %v", err)
+	}
+	if info["goroutines"].(float64) < 1 {
+		t.Errorf("goroutines = %v, want at least 1", info["goroutines"])
+	}
+	if _, ok := info["active_sessions"]; !ok {
+		t.Error("runtime response missing active_sessions")
+	}
+
+	rec = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/admin/debug/pprof/goroutine?debug=1", nil)
+	req.Header.Set("X-Admin-Token", "s3cret")
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET goroutine profile = %d, want 200", rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), "goroutine") {
+		t.Error("goroutine profile body looks empty")
+	}
+}
@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/gateway/model"
+	shared "github.com/CurvatureX/ai-tutor-monorepo/shared/models"
+	speechv1 "github.com/CurvatureX/ai-tutor-monorepo/shared/proto/speech"
+)
+
+// The ise_result payload is defined once in shared/models; this test decodes
+// the gateway's message through those structs so any divergence from the
+// speech-service's direct endpoint shows up here instead of in a frontend.
+func TestISEResultForwardsSharedWordBreakdown(t *testing.T) {
+	url, fake, _ := newTestGateway(t)
+	conn := dialTestGateway(t, url+"?session_id=ise-shape-test")
+
+	fake.EnqueueResponse(&speechv1.VoiceResponse{
+		ResponseType: &speechv1.VoiceResponse_IseResult{
+			IseResult: &speechv1.ISEResult{
+				OverallScore:   82.5,
+				AccuracyScore:  80,
+				FluencyScore:   88,
+				IntegrityScore: 100,
+				ReferenceText:  "good morning",
+				Words: []*speechv1.WordScore{
+					{
+						Word: "good", Score: 91, ErrorType: "correct",
+						Phones: []*speechv1.PhoneScore{
+							{Phone: "g", Score: 95, ErrorType: "correct"},
+							{Phone: "uh", Score: 60, ErrorType: "substitution"},
+						},
+					},
+					{Word: "morning", Score: 74, ErrorType: "omission"},
+				},
+			},
+		},
+	})
+
+	msg := readJSON(t, conn)
+	if msg.Type != model.MessageTypeISEResult {
+		t.Fatalf("message type = %q, want ise_result", msg.Type)
+	}
+	raw, err := json.Marshal(msg.Data)
+	if err != nil {
+		t.Fatalf("re-marshal payload: %v", err)
+	}
+	var data shared.ISEResultData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		t.Fatalf("payload does not fit the shared shape: %v", err)
+	}
+
+	if data.OverallScore != 82.5 || data.ReferenceText != "good morning" {
+		t.Errorf("scores = %+v, want the enqueued evaluation", data)
+	}
+	if len(data.Words) != 2 {
+		t.Fatalf("words = %+v, want both evaluated words", data.Words)
+	}
+	if w := data.Words[0]; w.Word != "good" || w.Score != 91 || w.ErrorType != "correct" {
+		t.Errorf("words[0] = %+v, want the scored word", w)
+	}
+	if len(data.Words[0].Phones) != 2 || data.Words[0].Phones[1].ErrorType != "substitution" {
+		t.Errorf("words[0].phones = %+v, want the phone breakdown", data.Words[0].Phones)
+	}
+	if w := data.Words[1]; w.ErrorType != "omission" || len(w.Phones) != 0 {
+		t.Errorf("words[1] = %+v, want an omission with no phones", w)
+	}
+}
@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	gmetadata "google.golang.org/grpc/metadata"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/gateway/manager"
+	"github.com/CurvatureX/ai-tutor-monorepo/gateway/model"
+)
+
+func TestClientHeadersForwardedAsGRPCMetadata(t *testing.T) {
+	url, fake, _ := newTestGateway(t)
+
+	conn, _, err := websocket.DefaultDialer.Dial(url+"?user_id=user-7", http.Header{
+		"X-Client-Version":     {"1.2.3"},
+		"X-Preferred-Language": {"zh-CN"},
+	})
+	if err != nil {
+		t.Fatalf("dial gateway: %v", err)
+	}
+	defer conn.Close()
+	// The connected status only goes out once the stream is open, so the
+	// stream context is recorded by the time it arrives.
+	if status := readJSON(t, conn); status.Type != model.MessageTypeStatus {
+		t.Fatalf("first message type = %q, want status", status.Type)
+	}
+
+	md, ok := gmetadata.FromOutgoingContext(fake.LastStreamContext())
+	if !ok {
+		t.Fatal("stream opened without outgoing metadata")
+	}
+	want := map[string]string{
+		metadataKeyUserID:            "user-7",
+		metadataKeyClientVersion:     "1.2.3",
+		metadataKeyPreferredLanguage: "zh-CN",
+		metadataKeySessionType:       "websocket",
+	}
+	for key, value := range want {
+		if got := md.Get(key); len(got) != 1 || got[0] != value {
+			t.Errorf("metadata %s = %v, want %q", key, got, value)
+		}
+	}
+}
+
+// TestSessionMetadataTransportLabel: fallback sessions are labelled sse, so
+// the speech-service can segment by transport.
+func TestSessionMetadataTransportLabel(t *testing.T) {
+	ws := &manager.Session{ID: "ws-session"}
+	if got := sessionMetadata(ws).Get(metadataKeySessionType); len(got) != 1 || got[0] != "websocket" {
+		t.Errorf("websocket transport label = %v, want websocket", got)
+	}
+
+	sse := &manager.Session{ID: "sse-session", Events: make(chan *model.WebSocketMessage, 1)}
+	if got := sessionMetadata(sse).Get(metadataKeySessionType); len(got) != 1 || got[0] != "sse" {
+		t.Errorf("sse transport label = %v, want sse", got)
+	}
+}
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	speechv1 "github.com/CurvatureX/ai-tutor-monorepo/shared/proto/speech"
+)
+
+// fieldNumber returns the wire field number from one generated struct field's
+// protobuf tag, or -1 if the field has none (oneof wrappers).
+func fieldNumber(t *testing.T, field reflect.StructField) int {
+	t.Helper()
+	tag, ok := field.Tag.Lookup("protobuf")
+	if !ok {
+		return -1
+	}
+	parts := strings.Split(tag, ",")
+	if len(parts) < 2 {
+		t.Fatalf("%s: malformed protobuf tag %q", field.Name, tag)
+	}
+	n, err := strconv.Atoi(parts[1])
+	if err != nil {
+		t.Fatalf("%s: non-numeric field number in tag %q", field.Name, tag)
+	}
+	return n
+}
+
+// TestSpeechProtoWireNumbers pins the field numbers of the shared proto
+// messages the gateway marshals and relays. Both services now generate from
+// shared/proto/speech; a renumbering there would break wire compatibility
+// with deployed speech services, so catch it in the test suite.
+func TestSpeechProtoWireNumbers(t *testing.T) {
+	expect := map[string]map[string]int{
+		"VoiceRequest":            {"SessionId": 1, "UserId": 2},
+		"VoiceRequest_AudioData":  {"AudioData": 3},
+		"VoiceRequest_Control":    {"Control": 4},
+		"VoiceRequest_TextInput":  {"TextInput": 5},
+		"TextInput":               {"Text": 1},
+		"AudioChunk":              {"Data": 1, "Format": 2, "SampleRate": 3, "Sequence": 4, "IsFinal": 5},
+		"ControlMessage":          {"Action": 1, "Parameters": 2},
+		"VoiceResponse":           {"SessionId": 1},
+		"VoiceResponse_AsrResult": {"AsrResult": 2},
+		"VoiceResponse_LlmResult": {"LlmResult": 3},
+		"VoiceResponse_TtsResult": {"TtsResult": 4},
+		"VoiceResponse_IseResult": {"IseResult": 5},
+		"VoiceResponse_Status":    {"Status": 6},
+		"VoiceResponse_Error":     {"Error": 7},
+		"ISEResult":               {"OverallScore": 1, "AccuracyScore": 2, "FluencyScore": 3, "IntegrityScore": 4, "ReferenceText": 5, "Words": 6, "NormalizedScore": 7, "Mode": 8, "SegmentIndex": 9, "SegmentCount": 10},
+	}
+	messages := []interface{}{
+		&speechv1.VoiceRequest{},
+		&speechv1.VoiceRequest_AudioData{},
+		&speechv1.VoiceRequest_Control{},
+		&speechv1.VoiceRequest_TextInput{},
+		&speechv1.TextInput{},
+		&speechv1.AudioChunk{},
+		&speechv1.ControlMessage{},
+		&speechv1.VoiceResponse{},
+		&speechv1.VoiceResponse_AsrResult{},
+		&speechv1.VoiceResponse_LlmResult{},
+		&speechv1.VoiceResponse_TtsResult{},
+		&speechv1.VoiceResponse_IseResult{},
+		&speechv1.VoiceResponse_Status{},
+		&speechv1.VoiceResponse_Error{},
+		&speechv1.ISEResult{},
+	}
+	for _, msg := range messages {
+		typ := reflect.TypeOf(msg).Elem()
+		want, ok := expect[typ.Name()]
+		if !ok {
+			t.Fatalf("no expected numbers recorded for %s", typ.Name())
+		}
+		got := make(map[string]int)
+		for i := 0; i < typ.NumField(); i++ {
+			if n := fieldNumber(t, typ.Field(i)); n >= 0 {
+				got[typ.Field(i).Name] = n
+			}
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s field numbers = %v, want %v", typ.Name(), got, want)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/gateway/model"
+)
+
+// expectClose reads until the connection is closed and returns the close
+// error.
+func expectClose(t *testing.T, conn *websocket.Conn) *websocket.CloseError {
+	t.Helper()
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	for {
+		_, _, err := conn.ReadMessage()
+		if err == nil {
+			continue
+		}
+		var closeErr *websocket.CloseError
+		if !errors.As(err, &closeErr) {
+			t.Fatalf("read error = %v, want a close error", err)
+		}
+		return closeErr
+	}
+}
+
+func TestUnsupportedVersionQueryParamClosesWithCode(t *testing.T) {
+	url, _, _ := newTestGateway(t)
+
+	conn, _, err := websocket.DefaultDialer.Dial(url+"?v=2", nil)
+	if err != nil {
+		t.Fatalf("dial gateway: %v", err)
+	}
+	defer conn.Close()
+
+	closeErr := expectClose(t, conn)
+	if closeErr.Code != model.CloseUnsupportedProtocolVersion {
+		t.Errorf("close code = %d, want %d", closeErr.Code, model.CloseUnsupportedProtocolVersion)
+	}
+	if !strings.Contains(closeErr.Text, "unsupported protocol version") {
+		t.Errorf("close text = %q, want an unsupported-version explanation", closeErr.Text)
+	}
+}
+
+func TestUnsupportedSubprotocolClosesWithCode(t *testing.T) {
+	url, _, _ := newTestGateway(t)
+
+	dialer := websocket.Dialer{Subprotocols: []string{model.ProtocolSubprotocolPrefix + "2"}}
+	conn, _, err := dialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("dial gateway: %v", err)
+	}
+	defer conn.Close()
+
+	if got := expectClose(t, conn).Code; got != model.CloseUnsupportedProtocolVersion {
+		t.Errorf("close code = %d, want %d", got, model.CloseUnsupportedProtocolVersion)
+	}
+}
+
+func TestSubprotocolNegotiationEchoedAndReported(t *testing.T) {
+	url, _, _ := newTestGateway(t)
+
+	want := fmt.Sprintf("%s%d", model.ProtocolSubprotocolPrefix, model.ProtocolMajorVersion)
+	dialer := websocket.Dialer{Subprotocols: []string{want}}
+	conn, resp, err := dialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("dial gateway: %v", err)
+	}
+	defer conn.Close()
+
+	if got := resp.Header.Get("Sec-WebSocket-Protocol"); got != want {
+		t.Errorf("echoed subprotocol = %q, want %q", got, want)
+	}
+	status := readJSON(t, conn)
+	if status.Type != model.MessageTypeStatus {
+		t.Fatalf("first message type = %q, want status", status.Type)
+	}
+	if v, _ := status.Data["v"].(float64); int(v) != model.ProtocolMajorVersion {
+		t.Errorf("connected status v = %v, want %d", status.Data["v"], model.ProtocolMajorVersion)
+	}
+}
+
+func TestVersionedMessageRejectedWithFieldDetail(t *testing.T) {
+	url, _, _ := newTestGateway(t)
+	conn := dialTestGateway(t, url)
+
+	conn.WriteJSON(&model.WebSocketMessage{
+		Type: model.MessageTypeText,
+		V:    2,
+		Data: map[string]interface{}{"text": "hello"},
+	})
+
+	msg := readJSON(t, conn)
+	if msg.Type != model.MessageTypeError {
+		t.Fatalf("message type = %q, want error", msg.Type)
+	}
+	details, _ := msg.Data["details"].([]interface{})
+	if len(details) != 1 {
+		t.Fatalf("details = %v, want one entry", msg.Data["details"])
+	}
+	detail, _ := details[0].(map[string]interface{})
+	if field, _ := detail["field"].(string); field != "v" {
+		t.Errorf("detail field = %q, want v", field)
+	}
+}
+
+func TestUnknownControlActionRejectedWithFieldDetail(t *testing.T) {
+	url, fake, _ := newTestGateway(t)
+	conn := dialTestGateway(t, url)
+
+	conn.WriteJSON(&model.WebSocketMessage{
+		Type: model.MessageTypeControl,
+		Data: map[string]interface{}{"action": "reboot"},
+	})
+
+	msg := readJSON(t, conn)
+	if msg.Type != model.MessageTypeError {
+		t.Fatalf("message type = %q, want error", msg.Type)
+	}
+	if details, _ := msg.Data["details"].([]interface{}); len(details) == 0 {
+		t.Errorf("error has no field details: %v", msg.Data)
+	}
+	if got := len(fake.SentRequests()); got != 0 {
+		t.Errorf("forwarded requests = %d, want 0 (invalid action dropped)", got)
+	}
+}
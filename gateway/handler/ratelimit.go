@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/gateway/audit"
+)
+
+var gatewayRateLimited = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "gateway_rate_limited_total",
+	Help: "Requests rejected by the per-IP gateway rate limit, by endpoint.",
+}, []string{"endpoint"})
+
+// ipIdleTTL is how long an IP's token bucket survives without traffic before
+// the cleanup routine evicts it.
+const ipIdleTTL = 5 * time.Minute
+
+// ipLimiterEntry pairs an IP's token bucket with its last activity for
+// eviction.
+type ipLimiterEntry struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// IPRateLimiter enforces a global per-IP request budget in front of every
+// HTTP endpoint, so one client cannot open thousands of WebSocket
+// connections. Per-session pipeline budgets live in the speech-service; this
+// guards the gateway itself.
+type IPRateLimiter struct {
+	requestsPerSecond float64
+	burst             int
+	auditor           *audit.AuditLogger
+	logger            *logrus.Logger
+
+	mu    sync.Mutex
+	store map[string]*ipLimiterEntry
+}
+
+// NewIPRateLimiter creates the limiter. A zero requestsPerSecond disables it.
+func NewIPRateLimiter(requestsPerSecond float64, burst int, auditor *audit.AuditLogger, logger *logrus.Logger) *IPRateLimiter {
+	return &IPRateLimiter{
+		requestsPerSecond: requestsPerSecond,
+		burst:             burst,
+		auditor:           auditor,
+		logger:            logger,
+		store:             make(map[string]*ipLimiterEntry),
+	}
+}
+
+// Middleware is the gin handler enforcing the limit. CORS preflight requests
+// do not consume tokens: browsers issue them automatically and throttling
+// them breaks clients that are otherwise within budget.
+func (l *IPRateLimiter) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if l.requestsPerSecond == 0 || c.Request.Method == http.MethodOptions {
+			c.Next()
+			return
+		}
+
+		ip := c.ClientIP()
+		if !l.allow(ip) {
+			endpoint := c.FullPath()
+			if endpoint == "" {
+				endpoint = c.Request.URL.Path
+			}
+			gatewayRateLimited.WithLabelValues(endpoint).Inc()
+			l.logger.WithFields(logrus.Fields{
+				"source_ip": ip,
+				"endpoint":  endpoint,
+			}).Warn("Request rate limited")
+			l.auditor.Record(audit.Entry{
+				Event:     audit.EventRateLimited,
+				SourceIP:  ip,
+				UserAgent: c.Request.UserAgent(),
+				Detail:    endpoint,
+			})
+			c.Header("Retry-After", "1")
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "rate limit exceeded"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// allow consumes one token from the IP's bucket, creating it on first sight.
+func (l *IPRateLimiter) allow(ip string) bool {
+	l.mu.Lock()
+	entry, ok := l.store[ip]
+	if !ok {
+		entry = &ipLimiterEntry{limiter: rate.NewLimiter(rate.Limit(l.requestsPerSecond), l.burst)}
+		l.store[ip] = entry
+	}
+	entry.lastSeen = time.Now()
+	l.mu.Unlock()
+	return entry.limiter.Allow()
+}
+
+// StartCleanupRoutine evicts buckets for idle IPs until the process exits.
+func (l *IPRateLimiter) StartCleanupRoutine(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			l.evictIdle()
+		}
+	}()
+}
+
+func (l *IPRateLimiter) evictIdle() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	cutoff := time.Now().Add(-ipIdleTTL)
+	for ip, entry := range l.store {
+		if entry.lastSeen.Before(cutoff) {
+			delete(l.store, ip)
+		}
+	}
+}
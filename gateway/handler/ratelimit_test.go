@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+func newRateLimitedRouter(t *testing.T, rps float64, burst int) *gin.Engine {
+	t.Helper()
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	limiter := NewIPRateLimiter(rps, burst, nil, logger)
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(limiter.Middleware())
+	router.GET("/health", func(c *gin.Context) { c.Status(http.StatusOK) })
+	return router
+}
+
+func doRequest(router *gin.Engine, method string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, "/health", nil)
+	req.RemoteAddr = "203.0.113.7:4242"
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestIPRateLimitRejectsBeyondBurst(t *testing.T) {
+	router := newRateLimitedRouter(t, 1, 3)
+
+	for i := 0; i < 3; i++ {
+		if w := doRequest(router, http.MethodGet); w.Code != http.StatusOK {
+			t.Fatalf("request %d status = %d, want 200", i, w.Code)
+		}
+	}
+	w := doRequest(router, http.MethodGet)
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("status after burst = %d, want 429", w.Code)
+	}
+	if got := w.Header().Get("Retry-After"); got == "" {
+		t.Error("429 response missing Retry-After header")
+	}
+}
+
+func TestIPRateLimitConcurrentRequestsSameIP(t *testing.T) {
+	const burst = 5
+	router := newRateLimitedRouter(t, 1, burst)
+
+	var ok, limited atomic.Int64
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			switch w := doRequest(router, http.MethodGet); w.Code {
+			case http.StatusOK:
+				ok.Add(1)
+			case http.StatusTooManyRequests:
+				limited.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if ok.Load() != burst {
+		t.Errorf("allowed = %d, want exactly the burst of %d", ok.Load(), burst)
+	}
+	if limited.Load() != 20-burst {
+		t.Errorf("limited = %d, want %d", limited.Load(), 20-burst)
+	}
+}
+
+func TestIPRateLimitDistinguishesIPs(t *testing.T) {
+	router := newRateLimitedRouter(t, 1, 1)
+
+	for i, addr := range []string{"203.0.113.1:1", "203.0.113.2:1", "203.0.113.3:1"} {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/health", nil)
+		req.RemoteAddr = addr
+		router.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			t.Errorf("request %d from %s status = %d, want 200", i, addr, w.Code)
+		}
+	}
+}
+
+func TestIPRateLimitSkipsPreflight(t *testing.T) {
+	router := newRateLimitedRouter(t, 1, 1)
+
+	doRequest(router, http.MethodGet) // exhaust the burst
+	for i := 0; i < 5; i++ {
+		if w := doRequest(router, http.MethodOptions); w.Code == http.StatusTooManyRequests {
+			t.Fatalf("OPTIONS request %d rate limited", i)
+		}
+	}
+}
+
+func TestIPRateLimitEvictsIdleEntries(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	limiter := NewIPRateLimiter(1, 1, nil, logger)
+
+	limiter.allow("203.0.113.7")
+	limiter.mu.Lock()
+	limiter.store["203.0.113.7"].lastSeen = time.Now().Add(-ipIdleTTL - time.Second)
+	limiter.mu.Unlock()
+
+	limiter.evictIdle()
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+	if _, ok := limiter.store["203.0.113.7"]; ok {
+		t.Error("idle IP entry not evicted")
+	}
+}
@@ -0,0 +1,38 @@
+package handler
+
+import (
+	"runtime/debug"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/gateway/manager"
+)
+
+var gatewayPanics = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "gateway_panics_total",
+	Help: "Panics recovered in per-session gateway goroutines, by stage.",
+}, []string{"stage"})
+
+// safeGo runs a per-session goroutine with panic containment: a panic in the
+// response pump must not crash the gateway process and every other session
+// with it. The panic is logged with its stack, counted, and reported to the
+// affected client as an error message.
+func (h *EnhancedWebSocketHandler) safeGo(session *manager.Session, stage string, fn func()) {
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				gatewayPanics.WithLabelValues(stage).Inc()
+				h.logger.WithFields(logrus.Fields{
+					"session_id": session.ID,
+					"stage":      stage,
+					"panic":      r,
+					"stack":      string(debug.Stack()),
+				}).Error("Session goroutine panicked")
+				h.sendError(session, "internal error")
+			}
+		}()
+		fn()
+	}()
+}
@@ -0,0 +1,260 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/gateway/audit"
+	"github.com/CurvatureX/ai-tutor-monorepo/gateway/manager"
+	"github.com/CurvatureX/ai-tutor-monorepo/gateway/model"
+	speechv1 "github.com/CurvatureX/ai-tutor-monorepo/shared/proto/speech"
+)
+
+// SSETransportHandler serves the REST + Server-Sent-Events fallback transport
+// for networks that block WebSocket upgrades. Audio and messages arrive as
+// plain POSTs and results stream back as SSE events mirroring the WebSocket
+// message types; the session registry, validation, quota enforcement and
+// gRPC bridging are shared with the WebSocket path, only the framing differs.
+type SSETransportHandler struct {
+	ws *EnhancedWebSocketHandler
+
+	mu      sync.Mutex
+	streams map[string]speechv1.SpeechService_ProcessVoiceConversationClient
+}
+
+// NewSSETransportHandler creates the fallback transport on top of the
+// WebSocket handler's plumbing.
+func NewSSETransportHandler(ws *EnhancedWebSocketHandler) *SSETransportHandler {
+	return &SSETransportHandler{
+		ws:      ws,
+		streams: make(map[string]speechv1.SpeechService_ProcessVoiceConversationClient),
+	}
+}
+
+// RegisterRoutes mounts the fallback endpoints on the given group.
+func (h *SSETransportHandler) RegisterRoutes(rg *gin.RouterGroup) {
+	rg.POST("/sessions", h.CreateSession)
+	rg.POST("/sessions/:id/audio", h.PostAudio)
+	rg.POST("/sessions/:id/messages", h.PostMessage)
+	rg.GET("/sessions/:id/events", h.StreamEvents)
+}
+
+// CreateSession opens a fallback session: it registers the session, starts
+// the per-session gRPC stream and queues the same connected status the
+// WebSocket path sends. Session options and the protocol version use the
+// same query parameters as /ws.
+func (h *SSETransportHandler) CreateSession(c *gin.Context) {
+	options, optionsExplicit, err := parseSessionOptions(c)
+	if err != nil {
+		h.recordRejected(c, err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	version, _, verr := negotiateProtocolVersion(c)
+	if verr != nil {
+		h.recordRejected(c, verr)
+		c.JSON(http.StatusBadRequest, gin.H{"error": verr.Error()})
+		return
+	}
+
+	sessionID := c.Query("session_id")
+	if sessionID == "" {
+		sessionID = uuid.NewString()
+	}
+	userID := c.Query("user_id")
+
+	session := h.ws.sessions.AddSSE(sessionID, userID)
+	session.Options = options
+	session.ProtocolVersion = version
+	session.ClientMeta = clientMetaFromHeaders(c.Request.Header)
+
+	stream, err := h.ws.initGRPCStream(session)
+	if err != nil {
+		h.ws.logger.Errorf("Failed to open speech stream for session %s: %v", sessionID, err)
+		h.ws.sessions.Remove(sessionID)
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "speech service unavailable"})
+		return
+	}
+	h.mu.Lock()
+	h.streams[sessionID] = stream
+	h.mu.Unlock()
+
+	h.ws.auditor.Record(audit.Entry{
+		Event:     audit.EventConnect,
+		SessionID: sessionID,
+		UserID:    userID,
+		SourceIP:  c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	})
+
+	if optionsExplicit {
+		h.ws.sendSessionConfig(session, stream)
+	}
+
+	session.WriteJSON(&model.WebSocketMessage{
+		Type: model.MessageTypeStatus,
+		Data: map[string]interface{}{
+			"status":     "connected",
+			"session_id": sessionID,
+			"version":    model.ProtocolVersion,
+			"v":          version,
+		},
+	})
+
+	h.ws.safeGo(session, "grpc_responses", func() {
+		defer h.teardown(session)
+		h.ws.handleGRPCResponses(session, stream)
+	})
+
+	c.JSON(http.StatusCreated, gin.H{
+		"session_id":   sessionID,
+		"events_url":   "/api/sessions/" + sessionID + "/events",
+		"audio_url":    "/api/sessions/" + sessionID + "/audio",
+		"messages_url": "/api/sessions/" + sessionID + "/messages",
+		"v":            version,
+	})
+}
+
+// PostAudio feeds one audio blob into the session's gRPC stream, with the
+// container format declared via the format query parameter. Quota
+// enforcement matches the WebSocket binary-frame path.
+func (h *SSETransportHandler) PostAudio(c *gin.Context) {
+	session, stream, ok := h.sseSession(c)
+	if !ok {
+		return
+	}
+	data, err := io.ReadAll(c.Request.Body)
+	if err != nil || len(data) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "empty audio body"})
+		return
+	}
+	if format := c.Query("format"); format != "" {
+		session.AudioFormat = format
+	}
+	session.BytesIn.Add(int64(len(data)))
+	session.Touch()
+	h.ws.handleAudioFrame(session, stream, data)
+	c.JSON(http.StatusAccepted, gin.H{"bytes": len(data)})
+}
+
+// PostMessage accepts the same JSON envelopes as WebSocket text frames, so
+// control and text messages go through the shared validator and dispatch.
+// Validation errors are delivered on the event stream, exactly as the
+// WebSocket path reports them.
+func (h *SSETransportHandler) PostMessage(c *gin.Context) {
+	session, stream, ok := h.sseSession(c)
+	if !ok {
+		return
+	}
+	data, err := io.ReadAll(c.Request.Body)
+	if err != nil || len(data) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "empty message body"})
+		return
+	}
+	session.BytesIn.Add(int64(len(data)))
+	session.Touch()
+	h.ws.handleTextFrame(session, stream, data)
+	c.JSON(http.StatusAccepted, gin.H{"status": "accepted"})
+}
+
+// StreamEvents streams the session's queued messages as Server-Sent Events
+// until the client disconnects or the session ends. A final "end" event tells
+// the client not to reconnect.
+func (h *SSETransportHandler) StreamEvents(c *gin.Context) {
+	session, ok := h.ws.sessions.Get(c.Param("id"))
+	if !ok || session.Events == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "session not found"})
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Writer.Flush()
+
+	for {
+		select {
+		case msg := <-session.Events:
+			h.writeEvent(c, msg)
+		case <-session.Closed():
+			// Flush anything queued before the session was removed, then
+			// signal the end of the stream.
+			for {
+				select {
+				case msg := <-session.Events:
+					h.writeEvent(c, msg)
+				default:
+					fmt.Fprint(c.Writer, "event: end\ndata: {}\n\n")
+					c.Writer.Flush()
+					return
+				}
+			}
+		case <-c.Request.Context().Done():
+			return
+		}
+	}
+}
+
+// writeEvent frames one message as an SSE event named after its type.
+func (h *SSETransportHandler) writeEvent(c *gin.Context, msg *model.WebSocketMessage) {
+	raw, err := json.Marshal(msg)
+	if err != nil {
+		h.ws.logger.Errorf("Failed to marshal SSE event %s: %v", msg.Type, err)
+		return
+	}
+	fmt.Fprintf(c.Writer, "event: %s\ndata: %s\n\n", msg.Type, raw)
+	c.Writer.Flush()
+}
+
+// sseSession resolves the path session and its gRPC stream, answering 404
+// itself when either is gone or the session belongs to the WebSocket path.
+func (h *SSETransportHandler) sseSession(c *gin.Context) (*manager.Session, speechv1.SpeechService_ProcessVoiceConversationClient, bool) {
+	id := c.Param("id")
+	session, ok := h.ws.sessions.Get(id)
+	if !ok || session.Events == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "session not found"})
+		return nil, nil, false
+	}
+	h.mu.Lock()
+	stream := h.streams[id]
+	h.mu.Unlock()
+	if stream == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "session not found"})
+		return nil, nil, false
+	}
+	return session, stream, true
+}
+
+// teardown runs when the gRPC stream ends: the conversation is over, so the
+// session is removed, which lets an attached event stream drain and close.
+func (h *SSETransportHandler) teardown(session *manager.Session) {
+	h.mu.Lock()
+	delete(h.streams, session.ID)
+	h.mu.Unlock()
+	h.ws.auditor.Record(audit.Entry{
+		Event:      audit.EventSessionEnd,
+		SessionID:  session.ID,
+		UserID:     session.UserID,
+		BytesIn:    session.BytesIn.Load(),
+		BytesOut:   session.BytesOut.Load(),
+		DurationMs: time.Since(session.CreatedAt).Milliseconds(),
+	})
+	h.ws.sessions.Remove(session.ID)
+}
+
+func (h *SSETransportHandler) recordRejected(c *gin.Context, err error) {
+	h.ws.auditor.Record(audit.Entry{
+		Event:     audit.EventConnectRejected,
+		UserID:    c.Query("user_id"),
+		SourceIP:  c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+		Detail:    err.Error(),
+	})
+}
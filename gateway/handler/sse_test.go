@@ -0,0 +1,262 @@
+package handler
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/gateway/manager"
+	"github.com/CurvatureX/ai-tutor-monorepo/gateway/model"
+	"github.com/CurvatureX/ai-tutor-monorepo/gateway/validation"
+	speechv1 "github.com/CurvatureX/ai-tutor-monorepo/shared/proto/speech"
+	"github.com/CurvatureX/ai-tutor-monorepo/shared/speechtest"
+)
+
+// newTestSSEGateway serves the REST+SSE fallback backed by a scripted fake
+// speech client and returns the server's base URL.
+func newTestSSEGateway(t *testing.T) (string, *speechtest.FakeSpeechClient, *manager.SessionManager) {
+	t.Helper()
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	validator, err := validation.NewMessageValidator()
+	if err != nil {
+		t.Fatalf("NewMessageValidator failed: %v", err)
+	}
+
+	fake := speechtest.NewFakeSpeechClient()
+	sessions := manager.NewSessionManager(logger)
+	ws := NewEnhancedWebSocketHandler(fake, sessions, validator, nil, nil, 0, logger)
+	sse := NewSSETransportHandler(ws)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	sse.RegisterRoutes(router.Group("/api"))
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	return server.URL, fake, sessions
+}
+
+// createdSession is the POST /api/sessions response body.
+type createdSession struct {
+	SessionID   string `json:"session_id"`
+	EventsURL   string `json:"events_url"`
+	AudioURL    string `json:"audio_url"`
+	MessagesURL string `json:"messages_url"`
+}
+
+func createSSESession(t *testing.T, base, query string) createdSession {
+	t.Helper()
+	resp, err := http.Post(base+"/api/sessions"+query, "application/json", nil)
+	if err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusCreated {
+		t.Fatalf("create session status = %d, want 201", resp.StatusCode)
+	}
+	var created createdSession
+	if err := json.NewDecoder(resp.Body).Decode(&created); err != nil {
+		t.Fatalf("parse create response: %v", err)
+	}
+	return created
+}
+
+type sseEvent struct {
+	name string
+	msg  *model.WebSocketMessage
+}
+
+// openEventStream attaches to the session's SSE endpoint and parses events
+// onto a channel, closed when the stream ends.
+func openEventStream(t *testing.T, url string) <-chan sseEvent {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("open event stream: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("event stream status = %d, want 200", resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/event-stream") {
+		t.Errorf("event stream content type = %q", ct)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+
+	events := make(chan sseEvent, 32)
+	go func() {
+		defer close(events)
+		scanner := bufio.NewScanner(resp.Body)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+		var name string
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case strings.HasPrefix(line, "event: "):
+				name = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				var msg model.WebSocketMessage
+				if err := json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &msg); err != nil {
+					continue
+				}
+				events <- sseEvent{name: name, msg: &msg}
+			}
+		}
+	}()
+	return events
+}
+
+func nextEvent(t *testing.T, events <-chan sseEvent, want string) *model.WebSocketMessage {
+	t.Helper()
+	select {
+	case ev, ok := <-events:
+		if !ok {
+			t.Fatalf("event stream closed while waiting for %q", want)
+		}
+		if ev.name != want {
+			t.Fatalf("event = %q (%+v), want %q", ev.name, ev.msg, want)
+		}
+		return ev.msg
+	case <-time.After(2 * time.Second):
+		t.Fatalf("timed out waiting for %q event", want)
+	}
+	return nil
+}
+
+func TestFullTurnOverSSEFallback(t *testing.T) {
+	base, fake, sessions := newTestSSEGateway(t)
+
+	created := createSSESession(t, base, "?session_id=sse-test&user_id=sse-user")
+	if created.SessionID != "sse-test" {
+		t.Fatalf("session id = %q, want sse-test", created.SessionID)
+	}
+
+	events := openEventStream(t, base+created.EventsURL)
+	connected := nextEvent(t, events, model.MessageTypeStatus)
+	if got, _ := connected.Data["status"].(string); got != "connected" {
+		t.Errorf("first status = %q, want connected", got)
+	}
+	// The client speaks by POSTing the same envelope a WS text frame carries.
+	body := strings.NewReader(`{"type":"text","data":{"text":"I visited the museum"}}`)
+	resp, err := http.Post(base+created.MessagesURL, "application/json", body)
+	if err != nil {
+		t.Fatalf("post message: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("post message status = %d, want 202", resp.StatusCode)
+	}
+	reqs := waitForRequests(t, fake, 1)
+	if text := reqs[0].GetTextInput(); text == nil || text.Text != "I visited the museum" {
+		t.Fatalf("forwarded request = %+v, want the text input", reqs[0])
+	}
+
+	fake.EnqueueResponse(&speechv1.VoiceResponse{
+		ResponseType: &speechv1.VoiceResponse_LlmResult{
+			LlmResult: &speechv1.LLMResult{Text: "Tell me more about it!"},
+		},
+	})
+	fake.EnqueueResponse(&speechv1.VoiceResponse{
+		ResponseType: &speechv1.VoiceResponse_TtsResult{
+			TtsResult: &speechv1.TTSResult{AudioData: []byte("tts-bytes"), Format: "mp3"},
+		},
+	})
+	fake.EnqueueResponse(&speechv1.VoiceResponse{
+		ResponseType: &speechv1.VoiceResponse_Status{
+			Status: &speechv1.StatusResult{Status: "complete"},
+		},
+	})
+
+	llm := nextEvent(t, events, model.MessageTypeLLMResponse)
+	if got, _ := llm.Data["text"].(string); got != "Tell me more about it!" {
+		t.Errorf("llm_response text = %q", got)
+	}
+	header := nextEvent(t, events, model.MessageTypeTTSChunkHeader)
+	if got, _ := header.Data["bytes"].(float64); int(got) != len("tts-bytes") {
+		t.Errorf("tts_chunk_header bytes = %v, want %d", header.Data["bytes"], len("tts-bytes"))
+	}
+	audio := nextEvent(t, events, model.MessageTypeTTSAudio)
+	encoded, _ := audio.Data["audio_base64"].(string)
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || string(decoded) != "tts-bytes" {
+		t.Errorf("tts_audio payload = %q (err %v), want tts-bytes", decoded, err)
+	}
+	ready := nextEvent(t, events, model.MessageTypeTTSReady)
+	if got, _ := ready.Data["bytes"].(float64); int(got) != len("tts-bytes") {
+		t.Errorf("tts_ready bytes = %v, want %d", ready.Data["bytes"], len("tts-bytes"))
+	}
+	complete := nextEvent(t, events, model.MessageTypeStatus)
+	if got, _ := complete.Data["status"].(string); got != "complete" {
+		t.Errorf("status = %q, want complete", got)
+	}
+
+	// When the speech-service closes the conversation the session is torn
+	// down and the stream finishes with an end event.
+	fake.CloseStream(nil)
+	nextEvent(t, events, "end")
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) && sessions.Count() > 0 {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if got := sessions.Count(); got != 0 {
+		t.Errorf("session count after stream end = %d, want 0", got)
+	}
+}
+
+func TestSSEAudioPostFeedsGRPCStream(t *testing.T) {
+	base, fake, _ := newTestSSEGateway(t)
+	created := createSSESession(t, base, "?session_id=sse-audio-test")
+
+	resp, err := http.Post(base+created.AudioURL+"?format=ogg", "application/octet-stream",
+		bytes.NewReader([]byte("audio-bytes")))
+	if err != nil {
+		t.Fatalf("post audio: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		t.Fatalf("post audio status = %d, want 202", resp.StatusCode)
+	}
+
+	reqs := waitForRequests(t, fake, 1)
+	chunk := reqs[0].GetAudioData()
+	if chunk == nil {
+		t.Fatalf("forwarded request = %+v, want an AudioChunk", reqs[0])
+	}
+	if chunk.Format != "ogg" || string(chunk.Data) != "audio-bytes" {
+		t.Errorf("chunk = %s/%q, want ogg/audio-bytes", chunk.Format, chunk.Data)
+	}
+}
+
+func TestSSEUnknownSessionAnswers404(t *testing.T) {
+	base, _, _ := newTestSSEGateway(t)
+
+	resp, err := http.Post(base+"/api/sessions/nope/audio", "application/octet-stream",
+		bytes.NewReader([]byte("audio")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("post audio status = %d, want 404", resp.StatusCode)
+	}
+
+	resp, err = http.Get(base + "/api/sessions/nope/events")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Errorf("events status = %d, want 404", resp.StatusCode)
+	}
+}
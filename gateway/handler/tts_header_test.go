@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/gateway/model"
+	speechv1 "github.com/CurvatureX/ai-tutor-monorepo/shared/proto/speech"
+)
+
+// readFrame reads one raw frame so tests can tell text from binary.
+func readFrame(t *testing.T, conn *websocket.Conn) (int, []byte) {
+	t.Helper()
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	msgType, data, err := conn.ReadMessage()
+	if err != nil {
+		t.Fatalf("read frame: %v", err)
+	}
+	return msgType, data
+}
+
+func ttsResponse(turnID int32, audio []byte) *speechv1.VoiceResponse {
+	return &speechv1.VoiceResponse{
+		ResponseType: &speechv1.VoiceResponse_TtsResult{
+			TtsResult: &speechv1.TTSResult{
+				AudioData: audio,
+				Format:    "mp3",
+				TurnId:    turnID,
+				IsFinal:   true,
+			},
+		},
+	}
+}
+
+func TestTTSChunkHeaderPrecedesBinary(t *testing.T) {
+	url, fake, _ := newTestGateway(t)
+	conn := dialTestGateway(t, url+"?session_id=tts-header-test")
+
+	fake.EnqueueResponse(ttsResponse(3, []byte("mp3-bytes")))
+
+	msgType, data := readFrame(t, conn)
+	if msgType != websocket.TextMessage {
+		t.Fatalf("first frame type = %d, want a text header", msgType)
+	}
+	var header model.WebSocketMessage
+	if err := json.Unmarshal(data, &header); err != nil {
+		t.Fatalf("unmarshal header: %v", err)
+	}
+	if header.Type != model.MessageTypeTTSChunkHeader {
+		t.Fatalf("header type = %q, want tts_chunk_header", header.Type)
+	}
+	if got, _ := header.Data["turn_id"].(float64); got != 3 {
+		t.Errorf("header turn_id = %v, want 3", header.Data["turn_id"])
+	}
+	if got, _ := header.Data["bytes"].(float64); got != 9 {
+		t.Errorf("header bytes = %v, want 9", header.Data["bytes"])
+	}
+	if got, _ := header.Data["format"].(string); got != "mp3" {
+		t.Errorf("header format = %v, want mp3", header.Data["format"])
+	}
+	if isFinal, _ := header.Data["is_final"].(bool); !isFinal {
+		t.Error("header is_final = false, want true")
+	}
+
+	msgType, data = readFrame(t, conn)
+	if msgType != websocket.BinaryMessage {
+		t.Fatalf("frame after header type = %d, want binary", msgType)
+	}
+	if string(data) != "mp3-bytes" {
+		t.Errorf("binary frame = %q, want the synthesized audio", data)
+	}
+
+	msgType, data = readFrame(t, conn)
+	if msgType != websocket.TextMessage {
+		t.Fatalf("frame after binary type = %d, want tts_ready", msgType)
+	}
+	var ready model.WebSocketMessage
+	if err := json.Unmarshal(data, &ready); err != nil {
+		t.Fatalf("unmarshal tts_ready: %v", err)
+	}
+	if ready.Type != model.MessageTypeTTSReady {
+		t.Errorf("trailing message type = %q, want tts_ready", ready.Type)
+	}
+}
+
+// TestTTSHeaderBinaryPairingUnderConcurrentWrites floods the session with
+// unrelated JSON from other goroutines while audio is delivered, and asserts
+// no message lands between a header and its binary frame.
+func TestTTSHeaderBinaryPairingUnderConcurrentWrites(t *testing.T) {
+	url, fake, sessions := newTestGateway(t)
+	conn := dialTestGateway(t, url+"?session_id=tts-pairing-test")
+
+	session, ok := sessions.Get("tts-pairing-test")
+	if !ok {
+		t.Fatal("session not registered")
+	}
+
+	const turns = 5
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					session.WriteJSON(&model.WebSocketMessage{
+						Type: model.MessageTypeStatus,
+						Data: map[string]interface{}{"status": "processing"},
+					})
+				}
+			}
+		}()
+	}
+	for turn := 1; turn <= turns; turn++ {
+		fake.EnqueueResponse(ttsResponse(int32(turn), []byte(fmt.Sprintf("audio-for-turn-%d", turn))))
+	}
+
+	seen := 0
+	deadline := time.Now().Add(2 * time.Second)
+	for seen < turns && time.Now().Before(deadline) {
+		msgType, data := readFrame(t, conn)
+		if msgType == websocket.BinaryMessage {
+			t.Fatalf("binary frame %q arrived without a preceding header", data)
+		}
+		var msg model.WebSocketMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			t.Fatalf("unmarshal message: %v", err)
+		}
+		if msg.Type != model.MessageTypeTTSChunkHeader {
+			continue
+		}
+		turnID, _ := msg.Data["turn_id"].(float64)
+		bytes, _ := msg.Data["bytes"].(float64)
+
+		msgType, data = readFrame(t, conn)
+		if msgType != websocket.BinaryMessage {
+			t.Fatalf("frame after header for turn %v type = %d, want binary", turnID, msgType)
+		}
+		want := fmt.Sprintf("audio-for-turn-%d", int(turnID))
+		if string(data) != want || len(data) != int(bytes) {
+			t.Fatalf("binary after header for turn %v = %q (%d bytes), want %q (%v bytes)",
+				turnID, data, len(data), want, bytes)
+		}
+		seen++
+	}
+	close(stop)
+	wg.Wait()
+	if seen != turns {
+		t.Errorf("paired %d header/binary deliveries, want %d", seen, turns)
+	}
+}
@@ -0,0 +1,782 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/gateway/audit"
+	"github.com/CurvatureX/ai-tutor-monorepo/gateway/manager"
+	"github.com/CurvatureX/ai-tutor-monorepo/gateway/model"
+	"github.com/CurvatureX/ai-tutor-monorepo/gateway/quota"
+	"github.com/CurvatureX/ai-tutor-monorepo/gateway/validation"
+	shared "github.com/CurvatureX/ai-tutor-monorepo/shared/models"
+	speechv1 "github.com/CurvatureX/ai-tutor-monorepo/shared/proto/speech"
+)
+
+// upgrader accepts client WebSocket connections. Clients upload utterances
+// as 100-500 KB binary frames, so the buffers default well above gorilla's
+// 1 KB; deployments override the tuning via ConfigureUpgrader.
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  32 * 1024,
+	WriteBufferSize: 32 * 1024,
+	// Negotiate permessage-deflate with clients that offer it; large ISE
+	// results are mostly repetitive JSON and compress well.
+	EnableCompression: true,
+	CheckOrigin: func(r *http.Request) bool {
+		return true
+	},
+}
+
+// wsReadLimit caps a single incoming client frame; 0 leaves frames uncapped.
+var wsReadLimit int64 = 1 << 20
+
+// ConfigureUpgrader applies the deployment's WebSocket tuning to the shared
+// upgrader and the per-connection read limit. Call once at startup, before
+// any connection is accepted.
+func ConfigureUpgrader(readBufferBytes, writeBufferBytes int, readLimitBytes int64, enableCompression bool) {
+	upgrader.ReadBufferSize = readBufferBytes
+	upgrader.WriteBufferSize = writeBufferBytes
+	upgrader.EnableCompression = enableCompression
+	wsReadLimit = readLimitBytes
+}
+
+// EnhancedWebSocketHandler bridges browser WebSocket sessions onto the
+// speech-service's bidirectional gRPC conversation stream: client JSON/binary
+// frames become VoiceRequests, and VoiceResponses are translated back into
+// the WebSocket message protocol.
+type EnhancedWebSocketHandler struct {
+	backends  *BackendPool
+	sessions  *manager.SessionManager
+	validator *validation.MessageValidator
+	auditor   *audit.AuditLogger
+	quota     *quota.Service
+	logger    *logrus.Logger
+
+	// compressMinBytes is the gzip envelope threshold applied to sessions
+	// that negotiate compress=gzip; 0 disables the envelope.
+	compressMinBytes int
+}
+
+// NewEnhancedWebSocketHandler creates the WS-to-gRPC bridge handler. auditor
+// and quotas may be nil to disable the audit trail and quota enforcement;
+// compressMinBytes is the gzip envelope threshold for sessions that ask for
+// it, 0 to disable.
+func NewEnhancedWebSocketHandler(client speechv1.SpeechServiceClient, sessions *manager.SessionManager, validator *validation.MessageValidator, auditor *audit.AuditLogger, quotas *quota.Service, compressMinBytes int, logger *logrus.Logger) *EnhancedWebSocketHandler {
+	backends := NewBackendPool(logger)
+	backends.Add("default", client)
+	return &EnhancedWebSocketHandler{
+		backends:         backends,
+		sessions:         sessions,
+		validator:        validator,
+		auditor:          auditor,
+		quota:            quotas,
+		compressMinBytes: compressMinBytes,
+		logger:           logger,
+	}
+}
+
+// HandleWebSocket upgrades the connection, opens the gRPC stream and runs the
+// client read loop until either side closes.
+func (h *EnhancedWebSocketHandler) HandleWebSocket(c *gin.Context) {
+	// Options are validated before the upgrade so bad values fail with a
+	// plain 400 instead of being silently ignored on an open socket.
+	options, optionsExplicit, err := parseSessionOptions(c)
+	if err != nil {
+		h.auditor.Record(audit.Entry{
+			Event:     audit.EventConnectRejected,
+			UserID:    c.Query("user_id"),
+			SourceIP:  c.ClientIP(),
+			UserAgent: c.Request.UserAgent(),
+			Detail:    err.Error(),
+		})
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	version, subprotocol, verr := negotiateProtocolVersion(c)
+	if verr != nil {
+		h.auditor.Record(audit.Entry{
+			Event:     audit.EventConnectRejected,
+			UserID:    c.Query("user_id"),
+			SourceIP:  c.ClientIP(),
+			UserAgent: c.Request.UserAgent(),
+			Detail:    verr.Error(),
+		})
+		// Upgrade anyway so the rejection arrives as a close code the
+		// client's onclose handler can read, rather than an opaque
+		// handshake failure.
+		conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+		if err != nil {
+			h.logger.Errorf("WebSocket upgrade failed: %v", err)
+			return
+		}
+		conn.WriteControl(websocket.CloseMessage,
+			websocket.FormatCloseMessage(model.CloseUnsupportedProtocolVersion, verr.Error()),
+			time.Now().Add(time.Second))
+		conn.Close()
+		return
+	}
+
+	var respHeader http.Header
+	if subprotocol != "" {
+		respHeader = http.Header{"Sec-WebSocket-Protocol": []string{subprotocol}}
+	}
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, respHeader)
+	if err != nil {
+		h.logger.Errorf("WebSocket upgrade failed: %v", err)
+		return
+	}
+	if wsReadLimit > 0 {
+		conn.SetReadLimit(wsReadLimit)
+	}
+
+	sessionID := c.Query("session_id")
+	if sessionID == "" {
+		sessionID = uuid.NewString()
+	}
+	userID := c.Query("user_id")
+
+	session := h.sessions.Add(sessionID, userID, conn)
+	session.Options = options
+	session.ProtocolVersion = version
+	session.ClientMeta = clientMetaFromHeaders(c.Request.Header)
+	if options.GzipEnvelope {
+		session.CompressMinBytes = h.compressMinBytes
+	}
+	defer h.sessions.Remove(sessionID)
+
+	h.auditor.Record(audit.Entry{
+		Event:     audit.EventConnect,
+		SessionID: sessionID,
+		UserID:    userID,
+		SourceIP:  c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	})
+	defer func() {
+		h.auditor.Record(audit.Entry{
+			Event:      audit.EventSessionEnd,
+			SessionID:  sessionID,
+			UserID:     userID,
+			SourceIP:   c.ClientIP(),
+			BytesIn:    session.BytesIn.Load(),
+			BytesOut:   session.BytesOut.Load(),
+			DurationMs: time.Since(session.CreatedAt).Milliseconds(),
+		})
+	}()
+
+	stream, err := h.initGRPCStream(session)
+	if err != nil {
+		h.logger.Errorf("Failed to open speech stream for session %s: %v", sessionID, err)
+		h.sendError(session, "speech service unavailable")
+		return
+	}
+	if optionsExplicit {
+		h.sendSessionConfig(session, stream)
+	}
+
+	session.WriteJSON(&model.WebSocketMessage{
+		Type: model.MessageTypeStatus,
+		Data: map[string]interface{}{
+			"status":     "connected",
+			"session_id": sessionID,
+			"version":    model.ProtocolVersion,
+			"v":          version,
+		},
+	})
+
+	h.safeGo(session, "grpc_responses", func() { h.handleGRPCResponses(session, stream) })
+
+	for {
+		msgType, data, err := conn.ReadMessage()
+		if err != nil {
+			h.logger.Infof("WebSocket closed for session %s: %v", sessionID, err)
+			break
+		}
+		session.BytesIn.Add(int64(len(data)))
+		session.Touch()
+		switch msgType {
+		case websocket.TextMessage:
+			h.handleTextFrame(session, stream, data)
+		case websocket.BinaryMessage:
+			h.handleAudioFrame(session, stream, data)
+		}
+	}
+	stream.CloseSend()
+}
+
+// negotiateProtocolVersion picks the protocol major version for a connection
+// from the "v" query parameter or an "aitutor.vN" entry in the
+// Sec-WebSocket-Protocol header. Clients that send neither get v1, so
+// existing clients keep working. The returned subprotocol, when non-empty,
+// must be echoed in the upgrade response.
+func negotiateProtocolVersion(c *gin.Context) (int, string, error) {
+	if raw := c.Query("v"); raw != "" {
+		v, err := strconv.Atoi(raw)
+		if err != nil || v != model.ProtocolMajorVersion {
+			return 0, "", fmt.Errorf("unsupported protocol version %q: this gateway speaks v%d", raw, model.ProtocolMajorVersion)
+		}
+		return v, "", nil
+	}
+	for _, proto := range websocket.Subprotocols(c.Request) {
+		if !strings.HasPrefix(proto, model.ProtocolSubprotocolPrefix) {
+			continue
+		}
+		v, err := strconv.Atoi(strings.TrimPrefix(proto, model.ProtocolSubprotocolPrefix))
+		if err != nil || v != model.ProtocolMajorVersion {
+			return 0, "", fmt.Errorf("unsupported subprotocol %q: this gateway speaks %s%d", proto, model.ProtocolSubprotocolPrefix, model.ProtocolMajorVersion)
+		}
+		return v, proto, nil
+	}
+	return model.ProtocolMajorVersion, "", nil
+}
+
+// parseSessionOptions reads the per-session processing options from the
+// connect URL's query parameters. The second return value reports whether the
+// client supplied any option at all; when it did not, the speech-service
+// defaults already apply and no configure message is needed.
+func parseSessionOptions(c *gin.Context) (model.SessionOptions, bool, error) {
+	options := model.DefaultSessionOptions()
+	explicit := false
+
+	if lang := c.Query("lang"); lang != "" {
+		if err := model.ValidateLanguageTag(lang); err != nil {
+			return options, false, err
+		}
+		options.Language = lang
+		explicit = true
+	}
+	if voice := c.Query("voice"); voice != "" {
+		if err := model.ValidateVoiceName(voice); err != nil {
+			return options, false, err
+		}
+		options.Voice = voice
+		explicit = true
+	}
+	switch ise := c.Query("ise"); ise {
+	case "":
+	case "on":
+		options.ISEEnabled = true
+		explicit = true
+	case "off":
+		options.ISEEnabled = false
+		explicit = true
+	default:
+		return options, false, fmt.Errorf("invalid ise %q: want on or off", ise)
+	}
+	if difficulty := c.Query("difficulty"); difficulty != "" {
+		if err := model.ValidateDifficulty(difficulty); err != nil {
+			return options, false, err
+		}
+		options.Difficulty = difficulty
+		explicit = true
+	}
+	switch tutorStarts := c.Query("tutor_starts"); tutorStarts {
+	case "":
+	case "true":
+		options.TutorStarts = true
+		explicit = true
+	case "false":
+		options.TutorStarts = false
+		explicit = true
+	default:
+		return options, false, fmt.Errorf("invalid tutor_starts %q: want true or false", tutorStarts)
+	}
+	if resume := c.Query("resume"); resume != "" {
+		options.ResumeSessionID = resume
+		explicit = true
+	}
+	// A transport option, not a processing one: it never reaches the
+	// speech-service, so it does not make the options explicit.
+	switch compress := c.Query("compress"); compress {
+	case "":
+	case "gzip":
+		options.GzipEnvelope = true
+	default:
+		return options, false, fmt.Errorf("invalid compress %q: want gzip", compress)
+	}
+
+	return options, explicit, nil
+}
+
+// sendSessionConfig forwards the connect-time options as the first message on
+// the stream so the VoiceSession is configured before the first utterance.
+func (h *EnhancedWebSocketHandler) sendSessionConfig(session *manager.Session, stream speechv1.SpeechService_ProcessVoiceConversationClient) {
+	params := map[string]string{
+		"language": session.Options.Language,
+	}
+	if session.Options.Voice != "" {
+		params["voice"] = session.Options.Voice
+	}
+	if !session.Options.ISEEnabled {
+		params["ise"] = "off"
+	}
+	if session.Options.Difficulty != "" {
+		params["difficulty"] = session.Options.Difficulty
+	}
+	if !session.Options.TutorStarts {
+		params["tutor_starts"] = "false"
+	}
+	if session.Options.ResumeSessionID != "" {
+		params["resume"] = session.Options.ResumeSessionID
+	}
+
+	err := stream.Send(&speechv1.VoiceRequest{
+		SessionId: session.ID,
+		UserId:    session.UserID,
+		RequestType: &speechv1.VoiceRequest_Control{
+			Control: &speechv1.ControlMessage{
+				Action:     speechv1.ControlAction_CONTROL_ACTION_CONFIGURE_SESSION,
+				Parameters: params,
+			},
+		},
+	})
+	if err != nil {
+		h.logger.Errorf("Failed to send session config for session %s: %v", session.ID, err)
+	}
+}
+
+// SetBackendPool replaces the default single-backend pool, for deployments
+// running several speech-service instances behind the gateway.
+func (h *EnhancedWebSocketHandler) SetBackendPool(p *BackendPool) {
+	h.backends = p
+}
+
+// gRPC metadata keys sent to the speech-service with every conversation
+// stream, mirroring the speech-service handler's MetadataKey* constants. They
+// ride the stream's metadata so no proto change is needed.
+const (
+	metadataKeyUserID            = "x-user-id"
+	metadataKeyClientVersion     = "x-client-version"
+	metadataKeyPreferredLanguage = "x-preferred-language"
+	metadataKeySessionType       = "x-session-type"
+)
+
+// clientMetaFromHeaders collects the client metadata headers from a connect
+// request. Clients that want version segmentation or language-aware routing
+// set X-Client-Version and X-Preferred-Language; nil when neither is present.
+func clientMetaFromHeaders(header http.Header) map[string]string {
+	var meta map[string]string
+	set := func(key, value string) {
+		if value == "" {
+			return
+		}
+		if meta == nil {
+			meta = make(map[string]string, 2)
+		}
+		meta[key] = value
+	}
+	set(metadataKeyClientVersion, header.Get("X-Client-Version"))
+	set(metadataKeyPreferredLanguage, header.Get("X-Preferred-Language"))
+	return meta
+}
+
+// sessionMetadata assembles the outgoing gRPC metadata for a session's
+// stream: who the session belongs to, what client connected it, and over
+// which transport.
+func sessionMetadata(session *manager.Session) metadata.MD {
+	md := metadata.MD{}
+	if session.UserID != "" {
+		md.Set(metadataKeyUserID, session.UserID)
+	}
+	for key, value := range session.ClientMeta {
+		md.Set(key, value)
+	}
+	transport := "websocket"
+	if session.Events != nil {
+		transport = "sse"
+	}
+	md.Set(metadataKeySessionType, transport)
+	return md
+}
+
+// initGRPCStream opens the per-session conversation stream on the backend the
+// session ID pins to, failing over to the next-best backend when the pinned
+// one cannot open a stream. The session's client metadata goes out as gRPC
+// metadata on the stream.
+func (h *EnhancedWebSocketHandler) initGRPCStream(session *manager.Session) (speechv1.SpeechService_ProcessVoiceConversationClient, error) {
+	ctx := metadata.NewOutgoingContext(context.Background(), sessionMetadata(session))
+	var lastErr error
+	for attempt := 0; attempt < h.backends.Len(); attempt++ {
+		client, addr, err := h.backends.Pick(session.ID)
+		if err != nil {
+			return nil, err
+		}
+		stream, err := client.ProcessVoiceConversation(ctx)
+		if err == nil {
+			session.Backend = addr
+			return stream, nil
+		}
+		lastErr = err
+		h.logger.Warnf("Backend %s refused a stream for session %s: %v", addr, session.ID, err)
+		h.backends.MarkUnhealthy(addr)
+	}
+	return nil, lastErr
+}
+
+// handleTextFrame validates a JSON envelope and dispatches on its type.
+func (h *EnhancedWebSocketHandler) handleTextFrame(session *manager.Session, stream speechv1.SpeechService_ProcessVoiceConversationClient, data []byte) {
+	msg, err := h.validator.Validate(data)
+	if err != nil {
+		h.sendValidationError(session, err)
+		return
+	}
+
+	switch msg.Type {
+	case model.MessageTypeControl:
+		action, _ := msg.Data["action"].(string)
+		h.handleControlAction(session, stream, action, msg.Data)
+	case model.MessageTypeText:
+		h.handleUserTextMessage(session, stream, msg.Data)
+	default:
+		h.sendError(session, "Unknown message type: "+msg.Type)
+	}
+}
+
+// handleControlAction translates a client control command into a gRPC control
+// message, handling gateway-local actions in place.
+func (h *EnhancedWebSocketHandler) handleControlAction(session *manager.Session, stream speechv1.SpeechService_ProcessVoiceConversationClient, action string, data map[string]interface{}) {
+	params := map[string]string{}
+	if text, ok := data["text"].(string); ok {
+		params["text"] = text
+	}
+	if target, ok := data["target_text"].(string); ok {
+		params["target_text"] = target
+	}
+	if turnID, ok := data["turn_id"].(float64); ok {
+		params["turn_id"] = strconv.Itoa(int(turnID))
+	}
+	if stage, ok := data["stage"].(string); ok {
+		params["stage"] = stage
+	}
+
+	switch action {
+	case model.ActionSetAudioFormat:
+		// Local to the gateway: remembered and stamped on audio chunks.
+		if format, ok := data["format"].(string); ok && format != "" {
+			session.AudioFormat = format
+		}
+		return
+	}
+
+	h.forwardControlToGRPC(session, stream, action, params)
+}
+
+// handleUserTextMessage forwards typed text from the client as a first-class
+// TextInput request, which skips ASR on the speech-service side.
+func (h *EnhancedWebSocketHandler) handleUserTextMessage(session *manager.Session, stream speechv1.SpeechService_ProcessVoiceConversationClient, data map[string]interface{}) {
+	text, _ := data["text"].(string)
+	if text == "" {
+		return
+	}
+	err := stream.Send(&speechv1.VoiceRequest{
+		SessionId: session.ID,
+		UserId:    session.UserID,
+		RequestType: &speechv1.VoiceRequest_TextInput{
+			TextInput: &speechv1.TextInput{Text: text},
+		},
+	})
+	if err != nil {
+		h.logger.Errorf("Failed to forward text input for session %s: %v", session.ID, err)
+	}
+}
+
+// forwardControlToGRPC maps a WebSocket control action name onto the proto
+// enum and sends it on the stream.
+func (h *EnhancedWebSocketHandler) forwardControlToGRPC(session *manager.Session, stream speechv1.SpeechService_ProcessVoiceConversationClient, action string, params map[string]string) {
+	var protoAction speechv1.ControlAction
+	switch action {
+	case model.ActionStartRecording:
+		protoAction = speechv1.ControlAction_CONTROL_ACTION_START_RECORDING
+	case model.ActionStopRecording:
+		protoAction = speechv1.ControlAction_CONTROL_ACTION_STOP_RECORDING
+	case model.ActionSetReferenceText:
+		protoAction = speechv1.ControlAction_CONTROL_ACTION_SET_REFERENCE_TEXT
+	case model.ActionEndSession:
+		protoAction = speechv1.ControlAction_CONTROL_ACTION_END_SESSION
+	case model.ActionStartShadowing:
+		protoAction = speechv1.ControlAction_CONTROL_ACTION_START_SHADOWING
+	case model.ActionRetry:
+		protoAction = speechv1.ControlAction_CONTROL_ACTION_RETRY_TURN
+	case model.ActionRequestCorrection:
+		protoAction = speechv1.ControlAction_CONTROL_ACTION_REQUEST_CORRECTION
+	default:
+		protoAction = speechv1.ControlAction_CONTROL_ACTION_UNSPECIFIED
+	}
+
+	err := stream.Send(&speechv1.VoiceRequest{
+		SessionId: session.ID,
+		UserId:    session.UserID,
+		RequestType: &speechv1.VoiceRequest_Control{
+			Control: &speechv1.ControlMessage{
+				Action:     protoAction,
+				Parameters: params,
+			},
+		},
+	})
+	if err != nil {
+		h.logger.Errorf("Failed to forward control %q for session %s: %v", action, session.ID, err)
+	}
+}
+
+// handleAudioFrame enforces the user's daily quotas before relaying audio.
+// Once a budget is spent the frame is dropped and the client told when the
+// quota resets; forwarding resumes after the daily rollover.
+func (h *EnhancedWebSocketHandler) handleAudioFrame(session *manager.Session, stream speechv1.SpeechService_ProcessVoiceConversationClient, data []byte) {
+	if err := h.quota.Check(session.UserID); err != nil {
+		h.sendQuotaExceeded(session, err)
+		return
+	}
+	h.forwardAudioToGRPC(session, stream, data)
+	// Without decoding the container the duration is estimated as 16 kHz
+	// 16-bit mono PCM; compressed formats over-count, which errs on the
+	// conservative side for a quota.
+	h.quota.RecordAudio(session.UserID, float64(len(data))/32000.0)
+}
+
+// sendQuotaExceeded reports a spent budget, including when it resets.
+func (h *EnhancedWebSocketHandler) sendQuotaExceeded(session *manager.Session, err error) {
+	data := map[string]interface{}{"message": err.Error(), "code": "quota_exceeded"}
+	var qerr *quota.ErrQuotaExceeded
+	if errors.As(err, &qerr) {
+		data["resource"] = qerr.Resource
+		data["reset_at"] = qerr.ResetAt.Format(time.RFC3339)
+	}
+	session.WriteJSON(&model.WebSocketMessage{
+		Type: model.MessageTypeError,
+		Data: data,
+	})
+	h.auditor.Record(audit.Entry{
+		Event:     audit.EventQuotaExceeded,
+		SessionID: session.ID,
+		UserID:    session.UserID,
+		Detail:    err.Error(),
+	})
+}
+
+// forwardAudioToGRPC wraps a binary frame in an AudioChunk. The final-chunk
+// marker comes from the stop_recording control, so every frame here is
+// non-final except when the client uses chunked mode (not yet supported).
+func (h *EnhancedWebSocketHandler) forwardAudioToGRPC(session *manager.Session, stream speechv1.SpeechService_ProcessVoiceConversationClient, data []byte) {
+	err := stream.Send(&speechv1.VoiceRequest{
+		SessionId: session.ID,
+		UserId:    session.UserID,
+		RequestType: &speechv1.VoiceRequest_AudioData{
+			AudioData: &speechv1.AudioChunk{
+				Data:       data,
+				Format:     session.AudioFormat,
+				SampleRate: 16000,
+			},
+		},
+	})
+	if err != nil {
+		h.logger.Errorf("Failed to forward audio for session %s: %v", session.ID, err)
+	}
+}
+
+// handleGRPCResponses pumps speech-service responses back to the client until
+// the stream ends.
+func (h *EnhancedWebSocketHandler) handleGRPCResponses(session *manager.Session, stream speechv1.SpeechService_ProcessVoiceConversationClient) {
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			// An Unavailable backend died mid-stream; keep new pinning away
+			// from it so the client's reconnect lands on a live instance.
+			if status.Code(err) == codes.Unavailable && session.Backend != "" {
+				h.backends.MarkUnhealthy(session.Backend)
+			}
+			h.logger.Infof("Speech stream ended for session %s: %v", session.ID, err)
+			return
+		}
+
+		switch r := resp.GetResponseType().(type) {
+		case *speechv1.VoiceResponse_AsrResult:
+			h.handleASRResult(session, r.AsrResult)
+		case *speechv1.VoiceResponse_LlmResult:
+			h.handleLLMResult(session, r.LlmResult)
+		case *speechv1.VoiceResponse_TtsResult:
+			h.handleTTSResult(session, r.TtsResult)
+		case *speechv1.VoiceResponse_IseResult:
+			h.handleISEResult(session, r.IseResult)
+		case *speechv1.VoiceResponse_PronunciationComparison:
+			session.WriteJSON(&model.WebSocketMessage{
+				Type: model.MessageTypePronunciationComparison,
+				Data: map[string]interface{}{
+					"pitch_correlation":  r.PronunciationComparison.PitchCorrelation,
+					"rhythm_correlation": r.PronunciationComparison.RhythmCorrelation,
+					"stress_correlation": r.PronunciationComparison.StressCorrelation,
+					"reference_text":     r.PronunciationComparison.ReferenceText,
+				},
+			})
+		case *speechv1.VoiceResponse_ShadowingResult:
+			h.handleShadowingResult(session, r.ShadowingResult)
+		case *speechv1.VoiceResponse_Status:
+			session.WriteJSON(&model.WebSocketMessage{
+				Type: model.MessageTypeStatus,
+				Data: map[string]interface{}{"status": r.Status.Status, "message": r.Status.Message},
+			})
+		case *speechv1.VoiceResponse_Error:
+			session.WriteJSON(&model.WebSocketMessage{
+				Type: model.MessageTypeError,
+				Data: map[string]interface{}{"message": r.Error.Message, "code": r.Error.Code.String()},
+			})
+		}
+	}
+}
+
+func (h *EnhancedWebSocketHandler) handleASRResult(session *manager.Session, result *speechv1.ASRResult) {
+	data := map[string]interface{}{
+		"text":       result.Text,
+		"confidence": result.Confidence,
+		"is_final":   result.IsFinal,
+	}
+	if result.AudioQualityWarning != "" {
+		data["audio_quality_warning"] = result.AudioQualityWarning
+	}
+	addSegmentFields(data, result.SegmentIndex, result.SegmentCount)
+	session.WriteJSON(&model.WebSocketMessage{
+		Type: model.MessageTypeASRResult,
+		Data: data,
+	})
+}
+
+// addSegmentFields attaches the split-turn segment position to a result
+// payload; unsplit results carry a zero count and stay unchanged.
+func addSegmentFields(data map[string]interface{}, index, count int32) {
+	if count == 0 {
+		return
+	}
+	data["segment_index"] = index
+	data["segment_count"] = count
+}
+
+func (h *EnhancedWebSocketHandler) handleShadowingResult(session *manager.Session, result *speechv1.ShadowingResult) {
+	words := make([]map[string]interface{}, 0, len(result.Words))
+	for _, word := range result.Words {
+		words = append(words, map[string]interface{}{
+			"target_word": word.TargetWord,
+			"heard_word":  word.HeardWord,
+			"op":          word.Op,
+		})
+	}
+	session.WriteJSON(&model.WebSocketMessage{
+		Type: model.MessageTypeShadowingResult,
+		Data: map[string]interface{}{
+			"target_text": result.TargetText,
+			"transcript":  result.Transcript,
+			"words":       words,
+		},
+	})
+}
+
+func (h *EnhancedWebSocketHandler) handleLLMResult(session *manager.Session, result *speechv1.LLMResult) {
+	h.quota.RecordTokens(session.UserID, int64(result.TokensUsed))
+	if result.Type == speechv1.LLMResultType_LLM_RESULT_TYPE_CLARIFICATION {
+		session.WriteJSON(&model.WebSocketMessage{
+			Type: model.MessageTypeClarificationRequest,
+			Data: map[string]interface{}{"text": result.Text},
+		})
+		return
+	}
+	if result.Type == speechv1.LLMResultType_LLM_RESULT_TYPE_CORRECTION {
+		session.WriteJSON(&model.WebSocketMessage{
+			Type: model.MessageTypeCorrection,
+			Data: map[string]interface{}{"text": result.Text},
+		})
+		return
+	}
+	data := map[string]interface{}{"text": result.Text}
+	if result.Type == speechv1.LLMResultType_LLM_RESULT_TYPE_STARTER {
+		// Keep the field older clients used to detect the canned welcome.
+		data["is_welcome"] = true
+	}
+	addSegmentFields(data, result.SegmentIndex, result.SegmentCount)
+	session.WriteJSON(&model.WebSocketMessage{
+		Type: model.MessageTypeLLMResponse,
+		Data: data,
+	})
+}
+
+// handleTTSResult announces audio with a tts_chunk_header so the client can
+// attribute the binary frame that follows to the right turn; the session's
+// write lock keeps the pair adjacent. The trailing tts_ready stays for clients
+// predating the header.
+func (h *EnhancedWebSocketHandler) handleTTSResult(session *manager.Session, result *speechv1.TTSResult) {
+	session.WriteBinaryWithHeader(&model.WebSocketMessage{
+		Type: model.MessageTypeTTSChunkHeader,
+		Data: map[string]interface{}{
+			"turn_id":     result.TurnId,
+			"chunk_index": result.ChunkIndex,
+			"bytes":       len(result.AudioData),
+			"format":      result.Format,
+			"is_final":    result.IsFinal,
+		},
+	}, result.AudioData)
+	session.WriteJSON(&model.WebSocketMessage{
+		Type: model.MessageTypeTTSReady,
+		Data: map[string]interface{}{
+			"format": result.Format,
+			"bytes":  len(result.AudioData),
+		},
+	})
+}
+
+// handleISEResult converts the evaluation to the shared ise_result payload —
+// the same shape the speech-service's direct endpoint emits — including the
+// word and phone breakdowns clients use for per-word feedback.
+func (h *EnhancedWebSocketHandler) handleISEResult(session *manager.Session, result *speechv1.ISEResult) {
+	words := make([]shared.ISEWord, 0, len(result.Words))
+	for _, w := range result.Words {
+		word := shared.ISEWord{Word: w.Word, Score: w.Score, ErrorType: w.ErrorType}
+		for _, p := range w.Phones {
+			word.Phones = append(word.Phones, shared.ISEPhone{Phone: p.Phone, Score: p.Score, ErrorType: p.ErrorType})
+		}
+		words = append(words, word)
+	}
+	data := map[string]interface{}{
+		"overall_score":   result.OverallScore,
+		"accuracy_score":  result.AccuracyScore,
+		"fluency_score":   result.FluencyScore,
+		"integrity_score": result.IntegrityScore,
+		"reference_text":  result.ReferenceText,
+		"words":           words,
+	}
+	addSegmentFields(data, result.SegmentIndex, result.SegmentCount)
+	session.WriteJSON(&model.WebSocketMessage{
+		Type: model.MessageTypeISEResult,
+		Data: data,
+	})
+}
+
+// sendValidationError reports schema violations with per-field details so
+// clients can fix the malformed message.
+func (h *EnhancedWebSocketHandler) sendValidationError(session *manager.Session, err error) {
+	data := map[string]interface{}{"message": err.Error()}
+	var verr *validation.ValidationError
+	if errors.As(err, &verr) {
+		data["details"] = verr.Details
+	}
+	session.WriteJSON(&model.WebSocketMessage{
+		Type: model.MessageTypeError,
+		Data: data,
+	})
+}
+
+func (h *EnhancedWebSocketHandler) sendError(session *manager.Session, message string) {
+	session.WriteJSON(&model.WebSocketMessage{
+		Type: model.MessageTypeError,
+		Data: map[string]interface{}{"message": message},
+	})
+}
@@ -0,0 +1,401 @@
+package handler
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/gateway/audit"
+	"github.com/CurvatureX/ai-tutor-monorepo/gateway/manager"
+	"github.com/CurvatureX/ai-tutor-monorepo/gateway/model"
+	"github.com/CurvatureX/ai-tutor-monorepo/gateway/quota"
+	"github.com/CurvatureX/ai-tutor-monorepo/gateway/validation"
+	speechv1 "github.com/CurvatureX/ai-tutor-monorepo/shared/proto/speech"
+	"github.com/CurvatureX/ai-tutor-monorepo/shared/speechtest"
+)
+
+// newTestGateway serves the WS bridge backed by a scripted fake speech
+// client and returns everything a test needs to drive it.
+func newTestGateway(t *testing.T) (string, *speechtest.FakeSpeechClient, *manager.SessionManager) {
+	t.Helper()
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	validator, err := validation.NewMessageValidator()
+	if err != nil {
+		t.Fatalf("NewMessageValidator failed: %v", err)
+	}
+
+	fake := speechtest.NewFakeSpeechClient()
+	sessions := manager.NewSessionManager(logger)
+	h := NewEnhancedWebSocketHandler(fake, sessions, validator, nil, nil, 0, logger)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/ws", h.HandleWebSocket)
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+
+	return "ws" + strings.TrimPrefix(server.URL, "http") + "/ws", fake, sessions
+}
+
+// dialTestGateway connects and consumes the connected status every session
+// starts with.
+func dialTestGateway(t *testing.T, url string) *websocket.Conn {
+	t.Helper()
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("dial gateway: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	status := readJSON(t, conn)
+	if status.Type != model.MessageTypeStatus {
+		t.Fatalf("first message type = %q, want status", status.Type)
+	}
+	return conn
+}
+
+func readJSON(t *testing.T, conn *websocket.Conn) *model.WebSocketMessage {
+	t.Helper()
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	var msg model.WebSocketMessage
+	if err := conn.ReadJSON(&msg); err != nil {
+		t.Fatalf("read message: %v", err)
+	}
+	return &msg
+}
+
+// waitForRequests polls the fake until it has seen n requests.
+func waitForRequests(t *testing.T, fake *speechtest.FakeSpeechClient, n int) []*speechv1.VoiceRequest {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if reqs := fake.SentRequests(); len(reqs) >= n {
+			return reqs
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d forwarded requests, have %d", n, len(fake.SentRequests()))
+	return nil
+}
+
+func TestAudioForwardedWithDeclaredFormat(t *testing.T) {
+	url, fake, _ := newTestGateway(t)
+	conn := dialTestGateway(t, url+"?session_id=fmt-test")
+
+	conn.WriteJSON(&model.WebSocketMessage{
+		Type: model.MessageTypeControl,
+		Data: map[string]interface{}{"action": model.ActionSetAudioFormat, "format": "ogg"},
+	})
+	// set_audio_format is gateway-local, so the audio frame is the first
+	// thing the speech-service sees.
+	conn.WriteMessage(websocket.BinaryMessage, []byte("audio-bytes"))
+
+	reqs := waitForRequests(t, fake, 1)
+	chunk := reqs[0].GetAudioData()
+	if chunk == nil {
+		t.Fatalf("first request is not an AudioChunk: %+v", reqs[0])
+	}
+	if chunk.Format != "ogg" {
+		t.Errorf("chunk format = %q, want %q", chunk.Format, "ogg")
+	}
+	if string(chunk.Data) != "audio-bytes" {
+		t.Errorf("chunk data = %q, want %q", chunk.Data, "audio-bytes")
+	}
+	if reqs[0].SessionId != "fmt-test" {
+		t.Errorf("session id = %q, want %q", reqs[0].SessionId, "fmt-test")
+	}
+}
+
+func TestSessionOptionsForwardedAsConfigure(t *testing.T) {
+	url, fake, sessions := newTestGateway(t)
+	dialTestGateway(t, url+"?session_id=opts-test&lang=en-GB&voice=en_us_002&ise=off&difficulty=beginner")
+
+	reqs := waitForRequests(t, fake, 1)
+	ctrl := reqs[0].GetControl()
+	if ctrl == nil {
+		t.Fatalf("first request is not a control: %+v", reqs[0])
+	}
+	if ctrl.Action != speechv1.ControlAction_CONTROL_ACTION_CONFIGURE_SESSION {
+		t.Errorf("action = %v, want CONFIGURE_SESSION", ctrl.Action)
+	}
+	want := map[string]string{
+		"language":   "en-GB",
+		"voice":      "en_us_002",
+		"ise":        "off",
+		"difficulty": "beginner",
+	}
+	for key, value := range want {
+		if got := ctrl.Parameters[key]; got != value {
+			t.Errorf("parameter %q = %q, want %q", key, got, value)
+		}
+	}
+
+	session, ok := sessions.Get("opts-test")
+	if !ok {
+		t.Fatal("session not registered")
+	}
+	if session.Options.Language != "en-GB" || session.Options.ISEEnabled {
+		t.Errorf("session options = %+v, want the query values", session.Options)
+	}
+}
+
+func TestNoConfigureSentWithoutOptionParams(t *testing.T) {
+	url, fake, _ := newTestGateway(t)
+	conn := dialTestGateway(t, url+"?session_id=default-opts-test")
+
+	// With no option params the speech-service defaults apply; the first
+	// forwarded request is whatever the client sends.
+	conn.WriteMessage(websocket.BinaryMessage, []byte("audio"))
+	reqs := waitForRequests(t, fake, 1)
+	if reqs[0].GetAudioData() == nil {
+		t.Fatalf("first request is not an AudioChunk: %+v", reqs[0])
+	}
+}
+
+func TestInvalidSessionOptionsFailUpgrade(t *testing.T) {
+	url, _, _ := newTestGateway(t)
+
+	for _, query := range []string{
+		"?ise=maybe",
+		"?lang=English",
+		"?difficulty=expert",
+		"?voice=Loud-Voice",
+	} {
+		conn, resp, err := websocket.DefaultDialer.Dial(url+query, nil)
+		if err == nil {
+			conn.Close()
+			t.Fatalf("dial with %q succeeded, want a rejected upgrade", query)
+		}
+		if resp == nil || resp.StatusCode != 400 {
+			t.Fatalf("dial with %q: status = %v, want 400", query, resp)
+		}
+		if got := resp.Header.Get("Content-Type"); !strings.Contains(got, "application/json") {
+			t.Errorf("dial with %q: content type = %q, want JSON", query, got)
+		}
+	}
+}
+
+func TestControlActionTranslation(t *testing.T) {
+	url, fake, _ := newTestGateway(t)
+	conn := dialTestGateway(t, url)
+
+	for _, action := range []string{
+		model.ActionStartRecording,
+		model.ActionSetReferenceText,
+		model.ActionStopRecording,
+	} {
+		conn.WriteJSON(&model.WebSocketMessage{
+			Type: model.MessageTypeControl,
+			Data: map[string]interface{}{"action": action, "text": "reference"},
+		})
+	}
+
+	reqs := waitForRequests(t, fake, 3)
+	want := []speechv1.ControlAction{
+		speechv1.ControlAction_CONTROL_ACTION_START_RECORDING,
+		speechv1.ControlAction_CONTROL_ACTION_SET_REFERENCE_TEXT,
+		speechv1.ControlAction_CONTROL_ACTION_STOP_RECORDING,
+	}
+	for i, w := range want {
+		ctrl := reqs[i].GetControl()
+		if ctrl == nil {
+			t.Fatalf("request %d is not a control: %+v", i, reqs[i])
+		}
+		if ctrl.Action != w {
+			t.Errorf("request %d action = %v, want %v", i, ctrl.Action, w)
+		}
+	}
+	if got := reqs[1].GetControl().Parameters["text"]; got != "reference" {
+		t.Errorf("set_reference_text parameter = %q, want %q", got, "reference")
+	}
+}
+
+func TestGRPCErrorTranslatedToWSError(t *testing.T) {
+	url, fake, _ := newTestGateway(t)
+	conn := dialTestGateway(t, url)
+
+	fake.EnqueueResponse(&speechv1.VoiceResponse{
+		ResponseType: &speechv1.VoiceResponse_Error{
+			Error: &speechv1.ErrorResult{
+				Code:    speechv1.ErrorCode_ERROR_CODE_ASR_FAILED,
+				Message: "speech recognition failed",
+			},
+		},
+	})
+
+	msg := readJSON(t, conn)
+	if msg.Type != model.MessageTypeError {
+		t.Fatalf("message type = %q, want error", msg.Type)
+	}
+	if got, _ := msg.Data["message"].(string); got != "speech recognition failed" {
+		t.Errorf("error message = %q, want %q", got, "speech recognition failed")
+	}
+	if got, _ := msg.Data["code"].(string); got != "ERROR_CODE_ASR_FAILED" {
+		t.Errorf("error code = %q, want ERROR_CODE_ASR_FAILED", got)
+	}
+}
+
+func TestPanicInResponsePumpContainedToSession(t *testing.T) {
+	url, fake, _ := newTestGateway(t)
+
+	fake.PanicOnNextRecv("injected fault")
+	connA := dialTestGateway(t, url+"?session_id=panic-test")
+
+	// The panicking pump must surface an error on its own session only.
+	msg := readJSON(t, connA)
+	if msg.Type != model.MessageTypeError {
+		t.Fatalf("message type = %q, want error", msg.Type)
+	}
+
+	// The gateway keeps serving: a second session connects and still receives
+	// responses from the shared stream.
+	connB := dialTestGateway(t, url+"?session_id=healthy-test")
+	fake.EnqueueResponse(&speechv1.VoiceResponse{
+		ResponseType: &speechv1.VoiceResponse_Status{
+			Status: &speechv1.StatusResult{Status: "processing"},
+		},
+	})
+	status := readJSON(t, connB)
+	if status.Type != model.MessageTypeStatus {
+		t.Fatalf("second session message type = %q, want status", status.Type)
+	}
+	if got, _ := status.Data["status"].(string); got != "processing" {
+		t.Errorf("second session status = %q, want processing", got)
+	}
+}
+
+func TestQuotaExceededStopsAudioForwarding(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	validator, err := validation.NewMessageValidator()
+	if err != nil {
+		t.Fatalf("NewMessageValidator failed: %v", err)
+	}
+	quotas := quota.NewService(quota.Limits{AudioSecondsPerDay: 1}, quota.NewMemoryStore(), logger)
+
+	fake := speechtest.NewFakeSpeechClient()
+	sessions := manager.NewSessionManager(logger)
+	h := NewEnhancedWebSocketHandler(fake, sessions, validator, nil, quotas, 0, logger)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/ws", h.HandleWebSocket)
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws"
+
+	conn := dialTestGateway(t, url+"?session_id=quota-test&user_id=quota-user")
+
+	// 64000 bytes is two seconds at the PCM16/16kHz estimate, so the first
+	// frame is forwarded and spends the one-second budget.
+	conn.WriteMessage(websocket.BinaryMessage, make([]byte, 64000))
+	waitForRequests(t, fake, 1)
+
+	conn.WriteMessage(websocket.BinaryMessage, make([]byte, 64000))
+	msg := readJSON(t, conn)
+	if msg.Type != model.MessageTypeError {
+		t.Fatalf("message type = %q, want error", msg.Type)
+	}
+	if got, _ := msg.Data["code"].(string); got != "quota_exceeded" {
+		t.Errorf("error code = %q, want quota_exceeded", got)
+	}
+	if _, ok := msg.Data["reset_at"].(string); !ok {
+		t.Error("quota error missing reset_at")
+	}
+	if got := len(fake.SentRequests()); got != 1 {
+		t.Errorf("forwarded requests = %d, want 1 (second frame dropped)", got)
+	}
+}
+
+func TestAuditTrailOmitsTranscriptContent(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+
+	validator, err := validation.NewMessageValidator()
+	if err != nil {
+		t.Fatalf("NewMessageValidator failed: %v", err)
+	}
+	auditDir := t.TempDir()
+	auditor, err := audit.NewAuditLogger(auditDir, 64, logger)
+	if err != nil {
+		t.Fatalf("NewAuditLogger failed: %v", err)
+	}
+	defer auditor.Close()
+
+	fake := speechtest.NewFakeSpeechClient()
+	sessions := manager.NewSessionManager(logger)
+	h := NewEnhancedWebSocketHandler(fake, sessions, validator, auditor, nil, 0, logger)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/ws", h.HandleWebSocket)
+	server := httptest.NewServer(router)
+	t.Cleanup(server.Close)
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws"
+
+	const transcript = "yesterday visited doctor regarding private diagnosis"
+	conn := dialTestGateway(t, url+"?session_id=audit-test&user_id=audit-user")
+	conn.WriteJSON(&model.WebSocketMessage{
+		Type: model.MessageTypeText,
+		Data: map[string]interface{}{"text": transcript},
+	})
+	waitForRequests(t, fake, 1)
+	conn.Close()
+
+	// The session_end entry is written when the read loop exits.
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) && sessions.Count() > 0 {
+		time.Sleep(5 * time.Millisecond)
+	}
+	auditor.Close()
+
+	files, err := filepath.Glob(filepath.Join(auditDir, "audit-*.jsonl"))
+	if err != nil || len(files) == 0 {
+		t.Fatalf("audit files = %v (err %v), want one", files, err)
+	}
+	raw, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	trail := string(raw)
+	for _, want := range []string{audit.EventConnect, audit.EventSessionEnd, "audit-test", "audit-user"} {
+		if !strings.Contains(trail, want) {
+			t.Errorf("audit trail missing %q:\n%s", want, trail)
+		}
+	}
+	for _, word := range strings.Fields(transcript) {
+		if strings.Contains(trail, word) {
+			t.Fatalf("audit trail contains transcript word %q:\n%s", word, trail)
+		}
+	}
+}
+
+func TestSessionRemovedWhenClientDisconnects(t *testing.T) {
+	url, _, sessions := newTestGateway(t)
+	conn := dialTestGateway(t, url+"?session_id=cleanup-test")
+
+	if got := sessions.Count(); got != 1 {
+		t.Fatalf("session count after connect = %d, want 1", got)
+	}
+
+	conn.Close()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if sessions.Count() == 0 {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("session not cleaned up after disconnect, count = %d", sessions.Count())
+}
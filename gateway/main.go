@@ -0,0 +1,267 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/gateway/audit"
+	"github.com/CurvatureX/ai-tutor-monorepo/gateway/config"
+	"github.com/CurvatureX/ai-tutor-monorepo/gateway/handler"
+	"github.com/CurvatureX/ai-tutor-monorepo/gateway/manager"
+	"github.com/CurvatureX/ai-tutor-monorepo/gateway/quota"
+	"github.com/CurvatureX/ai-tutor-monorepo/gateway/validation"
+	speechv1 "github.com/CurvatureX/ai-tutor-monorepo/shared/proto/speech"
+	"github.com/CurvatureX/ai-tutor-monorepo/shared/web"
+)
+
+// buildDialOptions assembles the speech-service connection options: message
+// size limits matching the service, keepalive pings so idle streams survive
+// NAT timeouts, the retry service config, and TLS when configured for
+// cross-node deployments.
+func buildDialOptions(cfg *config.Config) ([]grpc.DialOption, error) {
+	creds := insecure.NewCredentials()
+	if cfg.TLSEnabled {
+		if cfg.TLSCACert != "" {
+			tlsCreds, err := credentials.NewClientTLSFromFile(cfg.TLSCACert, "")
+			if err != nil {
+				return nil, err
+			}
+			creds = tlsCreds
+		} else {
+			creds = credentials.NewTLS(&tls.Config{})
+		}
+	}
+	return []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithDefaultCallOptions(
+			grpc.MaxCallRecvMsgSize(cfg.GRPCMaxMsgSizeMB*1024*1024),
+			grpc.MaxCallSendMsgSize(cfg.GRPCMaxMsgSizeMB*1024*1024),
+			// Queue RPCs while the channel reconnects instead of failing
+			// sessions the moment the speech-service restarts.
+			grpc.WaitForReady(true),
+		),
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                cfg.GRPCKeepaliveTime,
+			Timeout:             cfg.GRPCKeepaliveTimeout,
+			PermitWithoutStream: true,
+		}),
+		// Per-RPC retry policy for transient speech-service failures; see
+		// config.DefaultGRPCServiceConfig for the defaults.
+		grpc.WithDefaultServiceConfig(cfg.GRPCServiceConfigJSON),
+	}, nil
+}
+
+// Startup dial retry budget. The channel itself reconnects indefinitely once
+// created; the retries cover the blocking startup dial timing out or failing
+// outright, like a DNS resolution failure while the cluster is still coming
+// up.
+const (
+	dialAttempts   = 5
+	dialRetryDelay = 2 * time.Second
+)
+
+// dialSpeechService creates the speech-service channel. Each attempt blocks
+// until the connection is ready or GRPCDialTimeout passes, so an unreachable
+// speech service surfaces here at startup instead of on the first session;
+// transient failures are retried instead of killing the process.
+func dialSpeechService(cfg *config.Config, addr string, logger *logrus.Logger) (*grpc.ClientConn, error) {
+	dialOpts, err := buildDialOptions(cfg)
+	if err != nil {
+		return nil, err
+	}
+	dialOpts = append(dialOpts,
+		grpc.WithBlock(),
+		// Surface non-temporary errors (bad address, TLS failure) immediately
+		// instead of letting the blocking dial burn its whole timeout.
+		grpc.FailOnNonTempDialError(true),
+	)
+
+	var lastErr error
+	for attempt := 1; attempt <= dialAttempts; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.GRPCDialTimeout)
+		conn, err := grpc.DialContext(ctx, addr, dialOpts...)
+		cancel()
+		if err == nil {
+			return conn, nil
+		}
+		lastErr = err
+		logger.Warnf("Dial attempt %d/%d for speech service %s failed: %v",
+			attempt, dialAttempts, addr, err)
+		time.Sleep(dialRetryDelay)
+	}
+	return nil, lastErr
+}
+
+// watchConnState logs connectivity transitions so reconnects and outages are
+// visible in the gateway's own logs.
+func watchConnState(conn *grpc.ClientConn, addr string, logger *logrus.Logger) {
+	state := conn.GetState()
+	for {
+		if !conn.WaitForStateChange(context.Background(), state) {
+			return
+		}
+		next := conn.GetState()
+		logger.Infof("Speech service connection %s: %s -> %s", addr, state, next)
+		state = next
+	}
+}
+
+func main() {
+	cfg := config.Load()
+
+	logger := logrus.New()
+	logger.SetFormatter(&logrus.JSONFormatter{})
+	if level, err := logrus.ParseLevel(cfg.LogLevel); err == nil {
+		logger.SetLevel(level)
+	}
+
+	// One channel per backend; sessions are pinned across them by session ID.
+	backends := handler.NewBackendPool(logger)
+	var speechClient speechv1.SpeechServiceClient
+	conns := make(map[string]*grpc.ClientConn)
+	for _, addr := range cfg.SpeechServiceBackends() {
+		conn, err := dialSpeechService(cfg, addr, logger)
+		if err != nil {
+			log.Fatalf("Failed to connect to speech service at %s: %v", addr, err)
+		}
+		defer conn.Close()
+		go watchConnState(conn, addr, logger)
+		conns[addr] = conn
+		client := speechv1.NewSpeechServiceClient(conn)
+		backends.Add(addr, client)
+		// The Connect handler's RPCs are stateless, so the first backend
+		// serves them; per-RPC retries cover its blips.
+		if speechClient == nil {
+			speechClient = client
+		}
+	}
+
+	validator, err := validation.NewMessageValidator()
+	if err != nil {
+		log.Fatalf("Failed to load message schemas: %v", err)
+	}
+
+	auditor, err := audit.NewAuditLogger(cfg.AuditLogDir, cfg.AuditMaxSizeMB, logger)
+	if err != nil {
+		logger.Warnf("Audit trail disabled: %v", err)
+	}
+	defer auditor.Close()
+
+	var quotaStore quota.Store = quota.NewMemoryStore()
+	if cfg.RedisAddr != "" {
+		quotaStore = quota.NewRedisStore(cfg.RedisAddr)
+	}
+	quotaService := quota.NewService(quota.Limits{
+		AudioSecondsPerDay: cfg.QuotaAudioSecondsPerDay,
+		LLMTokensPerDay:    cfg.QuotaLLMTokensPerDay,
+	}, quotaStore, logger)
+
+	sessions := manager.NewSessionManager(logger)
+	sessions.StartCleanupRoutine(5 * time.Minute)
+	wsHandler := handler.NewEnhancedWebSocketHandler(speechClient, sessions, validator, auditor, quotaService, cfg.WSCompressMinBytes, logger)
+	wsHandler.SetBackendPool(backends)
+	handler.ConfigureUpgrader(cfg.WSReadBufferBytes, cfg.WSWriteBufferBytes, cfg.WSReadLimitBytes, cfg.WSEnableCompression)
+
+	ipLimiter := handler.NewIPRateLimiter(cfg.IPRateLimitRPS, cfg.IPRateLimitBurst, auditor, logger)
+	ipLimiter.StartCleanupRoutine(time.Minute)
+
+	router := gin.Default()
+	router.Use(ipLimiter.Middleware())
+	router.GET("/health", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "ok", "sessions": sessions.Count()})
+	})
+	router.GET("/ready", func(c *gin.Context) {
+		// Ready means every backend channel is ready: a degraded pool still
+		// serves pinned sessions, but new deployments shouldn't pass the
+		// readiness gate until the whole pool is usable.
+		states := gin.H{}
+		ready := true
+		for addr, conn := range conns {
+			state := conn.GetState()
+			if state == connectivity.Idle {
+				conn.Connect()
+			}
+			if state != connectivity.Ready {
+				ready = false
+			}
+			states[addr] = state.String()
+		}
+		if !ready {
+			c.JSON(http.StatusServiceUnavailable, gin.H{
+				"status":         "not_ready",
+				"speech_service": states,
+			})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"status": "ready", "speech_service": states})
+	})
+	router.GET("/ws", wsHandler.HandleWebSocket)
+	// REST+SSE fallback for networks that block WebSocket upgrades.
+	sseHandler := handler.NewSSETransportHandler(wsHandler)
+	sseHandler.RegisterRoutes(router.Group("/api"))
+	// Connect unary proxy for connect-web clients; the streaming
+	// conversation stays on /ws.
+	connectGroup := router.Group("/speech.v1.SpeechService", handler.ConnectCORS())
+	if cfg.ConnectAuthToken != "" {
+		connectGroup.Use(handler.BearerTokenAuth(cfg.ConnectAuthToken))
+	}
+	handler.NewConnectHandler(speechClient, logger).RegisterRoutes(connectGroup)
+	router.GET("/admin/quota/:user_id", func(c *gin.Context) {
+		usage, err := quotaService.Usage(c.Param("user_id"))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, usage)
+	})
+	router.POST("/admin/quota/:user_id/reset", func(c *gin.Context) {
+		if err := quotaService.Reset(c.Param("user_id")); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"status": "reset"})
+	})
+	if cfg.AdminToken != "" {
+		diag := router.Group("/admin/debug", handler.AdminTokenAuth(cfg.AdminToken))
+		handler.RegisterDiagRoutes(diag, sessions)
+	}
+	// The frontend is embedded in the binary; unmatched non-API paths fall
+	// through to it so client-side routes deep-link correctly.
+	site, err := web.NewStaticSite(embeddedStatic(), cfg.StaticDir,
+		"/api/", "/admin/", "/speech.v1.", "/ws")
+	if err != nil {
+		log.Fatalf("Failed to load static frontend: %v", err)
+	}
+	router.NoRoute(gin.WrapH(site))
+
+	httpServer := &http.Server{Addr: ":" + cfg.Port, Handler: router}
+	go func() {
+		logger.Infof("Gateway listening on :%s (speech service: %s)", cfg.Port, cfg.SpeechServiceAddr)
+		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			log.Fatalf("Gateway server failed: %v", err)
+		}
+	}()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+	logger.Info("Shutting down gateway")
+	// Tell connected clients this is a shutdown, not a failure, before the
+	// listener goes away.
+	sessions.Shutdown()
+	httpServer.Close()
+}
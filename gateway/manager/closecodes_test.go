@@ -0,0 +1,135 @@
+package manager
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/gateway/model"
+)
+
+// newWSPair upgrades a loopback WebSocket and returns both ends, so tests can
+// register the server side with the manager and read close frames on the
+// client.
+func newWSPair(t *testing.T) (server *websocket.Conn, client *websocket.Conn) {
+	t.Helper()
+
+	var upgrader websocket.Upgrader
+	serverConns := make(chan *websocket.Conn, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade: %v", err)
+			return
+		}
+		serverConns <- conn
+	}))
+	t.Cleanup(srv.Close)
+
+	client, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+
+	select {
+	case server = <-serverConns:
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for server-side connection")
+	}
+	t.Cleanup(func() { server.Close() })
+	return server, client
+}
+
+// expectClose reads (and discards) frames until the connection is closed and
+// returns the close error, so tests can assert the code and reason a client
+// actually received.
+func expectClose(t *testing.T, conn *websocket.Conn) *websocket.CloseError {
+	t.Helper()
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	for {
+		_, _, err := conn.ReadMessage()
+		if err == nil {
+			continue
+		}
+		var closeErr *websocket.CloseError
+		if !errors.As(err, &closeErr) {
+			t.Fatalf("read error = %v, want a close error", err)
+		}
+		return closeErr
+	}
+}
+
+func TestSupersededConnectionCloseCode(t *testing.T) {
+	m := quietManager()
+	firstSrv, firstClient := newWSPair(t)
+	m.Add("takeover", "user-1", firstSrv)
+
+	secondSrv, _ := newWSPair(t)
+	m.Add("takeover", "user-1", secondSrv)
+
+	closeErr := expectClose(t, firstClient)
+	if closeErr.Code != model.CloseSuperseded {
+		t.Errorf("close code = %d, want %d", closeErr.Code, model.CloseSuperseded)
+	}
+	if closeErr.Text == "" {
+		t.Error("superseded close carried no reason")
+	}
+}
+
+func TestRemoveCloseCode(t *testing.T) {
+	m := quietManager()
+	srv, client := newWSPair(t)
+	m.Add("ending", "user-1", srv)
+
+	m.Remove("ending")
+
+	if got := expectClose(t, client).Code; got != model.CloseNormalEnd {
+		t.Errorf("close code = %d, want %d", got, model.CloseNormalEnd)
+	}
+}
+
+func TestInactivityRemovalCloseCode(t *testing.T) {
+	m := quietManager()
+	srv, client := newWSPair(t)
+	session := m.Add("idle-close", "user-1", srv)
+
+	// Jump straight to the end of an already-warned grace window, so one
+	// sweep removes the session.
+	base := time.Now()
+	session.lastActivity.Store(base.Add(-time.Hour).UnixNano())
+	session.inactivityWarnedAt = base.Add(-m.inactivityWarning)
+	m.now = func() time.Time { return base }
+	m.cleanupInactive()
+
+	closeErr := expectClose(t, client)
+	if closeErr.Code != model.CloseIdleTimeout {
+		t.Errorf("close code = %d, want %d", closeErr.Code, model.CloseIdleTimeout)
+	}
+}
+
+func TestShutdownCloseCode(t *testing.T) {
+	m := quietManager()
+	srv, client := newWSPair(t)
+	session := m.Add("shutdown", "user-1", srv)
+
+	m.Shutdown()
+
+	if m.Count() != 0 {
+		t.Error("sessions survived the shutdown")
+	}
+	select {
+	case <-session.Closed():
+	default:
+		t.Error("shut-down session was not marked closed")
+	}
+	closeErr := expectClose(t, client)
+	if closeErr.Code != model.CloseServerShutdown {
+		t.Errorf("close code = %d, want %d", closeErr.Code, model.CloseServerShutdown)
+	}
+}
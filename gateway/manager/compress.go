@@ -0,0 +1,63 @@
+package manager
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/gorilla/websocket"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/gateway/model"
+)
+
+// Large ISE results serialize to 100+ KB of JSON; these track how much the
+// gzip envelope saves for sessions that negotiated it.
+var (
+	wsCompressRawBytes = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "gateway_ws_compress_raw_bytes_total",
+		Help: "Uncompressed size of text payloads sent through the gzip envelope.",
+	})
+	wsCompressSentBytes = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "gateway_ws_compress_sent_bytes_total",
+		Help: "On-the-wire size of the gzip envelopes actually sent.",
+	})
+)
+
+// compressMessage gzips an already-marshaled message into a CompressedEnvelope.
+// It reports false when compression would not help: base64 inflates the gzip
+// output by a third, so a payload that barely shrinks is cheaper sent as-is.
+func compressMessage(raw []byte) (*model.CompressedEnvelope, bool) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(raw); err != nil {
+		return nil, false
+	}
+	if err := zw.Close(); err != nil {
+		return nil, false
+	}
+	encoded := base64.StdEncoding.EncodeToString(buf.Bytes())
+	if len(encoded) >= len(raw) {
+		return nil, false
+	}
+	wsCompressRawBytes.Add(float64(len(raw)))
+	wsCompressSentBytes.Add(float64(len(encoded)))
+	return &model.CompressedEnvelope{Compressed: true, Data: encoded}, true
+}
+
+// writeMaybeCompressed sends a message through the gzip envelope when it
+// clears the session's size threshold. Caller holds writeMu.
+func (s *Session) writeMaybeCompressed(msg *model.WebSocketMessage) error {
+	raw, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	if len(raw) >= s.CompressMinBytes {
+		if envelope, ok := compressMessage(raw); ok {
+			return s.Conn.WriteJSON(envelope)
+		}
+	}
+	return s.Conn.WriteMessage(websocket.TextMessage, raw)
+}
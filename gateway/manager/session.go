@@ -0,0 +1,335 @@
+package manager
+
+import (
+	"encoding/base64"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/gateway/model"
+)
+
+// Session is the gateway-side state for one connected WebSocket client.
+type Session struct {
+	ID        string
+	UserID    string
+	Conn      *websocket.Conn
+	CreatedAt time.Time
+
+	// lastActivity is the UnixNano of the last inbound client message.
+	// Atomic so the read loop can refresh it without racing the sweep.
+	lastActivity atomic.Int64
+
+	// inactivityWarnedAt is when the session_expiring warning went out; zero
+	// when no warning is pending. Guarded by the manager lock.
+	inactivityWarnedAt time.Time
+
+	// AudioFormat is the container format the client declared for its binary
+	// frames; forwarded on every AudioChunk.
+	AudioFormat string
+
+	// Options are the processing options fixed at connect time via query
+	// parameters.
+	Options model.SessionOptions
+
+	// CompressMinBytes is the smallest text payload WriteJSON gzips into a
+	// CompressedEnvelope. Zero — the default — disables the envelope; the
+	// handler sets it only for sessions that negotiated compress=gzip.
+	CompressMinBytes int
+
+	// ProtocolVersion is the protocol major version negotiated at connect
+	// time.
+	ProtocolVersion int
+
+	// Backend is the speech-service backend address this session's stream is
+	// pinned to; set by the handler when the stream opens.
+	Backend string
+
+	// ClientMeta holds client metadata collected from the connect request's
+	// headers (client version, preferred language), forwarded to the
+	// speech-service as gRPC metadata when the stream opens. Nil when the
+	// client sent none.
+	ClientMeta map[string]string
+
+	// BytesIn and BytesOut count payload bytes relayed for the session.
+	// Atomics because the response pump writes from its own goroutine.
+	BytesIn  atomic.Int64
+	BytesOut atomic.Int64
+
+	// Events buffers outbound messages for sessions on the REST+SSE fallback
+	// transport; nil for WebSocket sessions, which write straight to Conn.
+	Events chan *model.WebSocketMessage
+
+	closed    chan struct{}
+	closeOnce sync.Once
+
+	writeMu sync.Mutex
+}
+
+// sseEventBuffer bounds how many undelivered events a fallback session may
+// accumulate while no client is attached to its event stream.
+const sseEventBuffer = 64
+
+// Touch records client activity on the session now. Safe from any goroutine.
+func (s *Session) Touch() { s.lastActivity.Store(time.Now().UnixNano()) }
+
+// LastActivity returns when the session last saw client activity.
+func (s *Session) LastActivity() time.Time {
+	return time.Unix(0, s.lastActivity.Load())
+}
+
+// Closed is closed when the session is removed from the manager, so event
+// stream readers know to stop waiting.
+func (s *Session) Closed() <-chan struct{} { return s.closed }
+
+func (s *Session) markClosed() { s.closeOnce.Do(func() { close(s.closed) }) }
+
+// CloseWithCode sends a close frame carrying the application close code and
+// reason, then closes the connection, so the client's onclose handler can tell
+// why the session ended. Fallback sessions have no connection to close.
+func (s *Session) CloseWithCode(code int, reason string) {
+	if s.Conn == nil {
+		return
+	}
+	// Best effort: on an already-dead connection the control write fails and
+	// the Close below is all that matters.
+	s.Conn.WriteControl(websocket.CloseMessage,
+		websocket.FormatCloseMessage(code, reason), time.Now().Add(time.Second))
+	s.Conn.Close()
+}
+
+// WriteJSON delivers a message to the client: a text frame for WebSocket
+// sessions, an enqueued event for fallback sessions.
+func (s *Session) WriteJSON(msg *model.WebSocketMessage) error {
+	if msg.Timestamp == 0 {
+		msg.Timestamp = time.Now().UnixMilli()
+	}
+	if s.Conn == nil {
+		select {
+		case s.Events <- msg:
+			return nil
+		case <-s.closed:
+			return fmt.Errorf("session %s closed", s.ID)
+		default:
+			return fmt.Errorf("session %s event buffer full, dropping %s", s.ID, msg.Type)
+		}
+	}
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	if s.CompressMinBytes > 0 {
+		return s.writeMaybeCompressed(msg)
+	}
+	return s.Conn.WriteJSON(msg)
+}
+
+// WriteBinary delivers audio to the client. SSE cannot carry binary frames,
+// so fallback sessions receive it base64-encoded in a tts_audio event.
+func (s *Session) WriteBinary(data []byte) error {
+	if s.Conn == nil {
+		s.BytesOut.Add(int64(len(data)))
+		return s.WriteJSON(&model.WebSocketMessage{
+			Type: model.MessageTypeTTSAudio,
+			Data: map[string]interface{}{
+				"audio_base64": base64.StdEncoding.EncodeToString(data),
+				"bytes":        len(data),
+			},
+		})
+	}
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	s.BytesOut.Add(int64(len(data)))
+	return s.Conn.WriteMessage(websocket.BinaryMessage, data)
+}
+
+// WriteBinaryWithHeader delivers a JSON header immediately followed by the
+// binary frame it describes, holding the write lock across both so no other
+// message can land between them. Fallback sessions get the header event
+// followed by the base64 tts_audio event, which the buffered channel keeps in
+// order.
+func (s *Session) WriteBinaryWithHeader(header *model.WebSocketMessage, data []byte) error {
+	if s.Conn == nil {
+		if err := s.WriteJSON(header); err != nil {
+			return err
+		}
+		return s.WriteBinary(data)
+	}
+	if header.Timestamp == 0 {
+		header.Timestamp = time.Now().UnixMilli()
+	}
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	if err := s.Conn.WriteJSON(header); err != nil {
+		return err
+	}
+	s.BytesOut.Add(int64(len(data)))
+	return s.Conn.WriteMessage(websocket.BinaryMessage, data)
+}
+
+// SessionManager tracks live gateway sessions and sweeps inactive ones.
+type SessionManager struct {
+	mu       sync.RWMutex
+	sessions map[string]*Session
+	logger   *logrus.Logger
+
+	maxInactivity time.Duration
+	// inactivityWarning is the grace window before the inactivity cutoff: a
+	// session idle past maxInactivity-inactivityWarning is warned with a
+	// session_expiring status and only removed once the window passes with
+	// no further activity.
+	inactivityWarning time.Duration
+
+	// now overrides the sweep's clock in tests; nil means time.Now.
+	now func() time.Time
+}
+
+// NewSessionManager creates the manager.
+func NewSessionManager(logger *logrus.Logger) *SessionManager {
+	return &SessionManager{
+		sessions:          make(map[string]*Session),
+		logger:            logger,
+		maxInactivity:     30 * time.Minute,
+		inactivityWarning: 5 * time.Minute,
+	}
+}
+
+// clock returns the sweep's current time.
+func (m *SessionManager) clock() time.Time {
+	if m.now != nil {
+		return m.now()
+	}
+	return time.Now()
+}
+
+// Add registers a session for the given connection.
+func (m *SessionManager) Add(sessionID, userID string, conn *websocket.Conn) *Session {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if existing, ok := m.sessions[sessionID]; ok {
+		existing.CloseWithCode(model.CloseSuperseded, "session superseded by a new connection")
+		existing.markClosed()
+	}
+	session := newSession(sessionID, userID)
+	session.Conn = conn
+	m.sessions[sessionID] = session
+	return session
+}
+
+// AddSSE registers a session for the REST+SSE fallback transport, which has
+// no WebSocket connection; outbound messages buffer on Events until the
+// client attaches to the event stream.
+func (m *SessionManager) AddSSE(sessionID, userID string) *Session {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if existing, ok := m.sessions[sessionID]; ok {
+		existing.CloseWithCode(model.CloseSuperseded, "session superseded by a new connection")
+		existing.markClosed()
+	}
+	session := newSession(sessionID, userID)
+	session.Events = make(chan *model.WebSocketMessage, sseEventBuffer)
+	m.sessions[sessionID] = session
+	return session
+}
+
+func newSession(sessionID, userID string) *Session {
+	session := &Session{
+		ID:          sessionID,
+		UserID:      userID,
+		CreatedAt:   time.Now(),
+		AudioFormat: "webm",
+		Options:     model.DefaultSessionOptions(),
+		closed:      make(chan struct{}),
+	}
+	session.Touch()
+	return session
+}
+
+// Remove drops a session and closes its connection with a normal-end close
+// code. On a client-initiated disconnect the connection is already dead and
+// the close frame goes nowhere, which is fine.
+func (m *SessionManager) Remove(sessionID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if session, ok := m.sessions[sessionID]; ok {
+		session.CloseWithCode(model.CloseNormalEnd, "session ended")
+		session.markClosed()
+		delete(m.sessions, sessionID)
+	}
+}
+
+// Get returns the session, if registered.
+func (m *SessionManager) Get(sessionID string) (*Session, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	session, ok := m.sessions[sessionID]
+	return session, ok
+}
+
+// Count returns the number of live sessions.
+func (m *SessionManager) Count() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.sessions)
+}
+
+// StartCleanupRoutine sweeps inactive sessions until the process exits.
+func (m *SessionManager) StartCleanupRoutine(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			m.cleanupInactive()
+		}
+	}()
+}
+
+// cleanupInactive is a two-phase sweep: a session idle past the warning
+// threshold first gets a session_expiring status with its remaining grace
+// time, and is only removed once the grace window passes with no further
+// activity. Any inbound client message resets it to fully active.
+func (m *SessionManager) cleanupInactive() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.clock()
+	for id, session := range m.sessions {
+		idle := now.Sub(session.LastActivity())
+		switch {
+		case idle <= m.maxInactivity-m.inactivityWarning:
+			// Active again (or still): a pending warning is forgotten.
+			session.inactivityWarnedAt = time.Time{}
+		case session.inactivityWarnedAt.IsZero():
+			session.inactivityWarnedAt = now
+			if err := session.WriteJSON(&model.WebSocketMessage{
+				Type: model.MessageTypeStatus,
+				Data: map[string]interface{}{
+					"status":            "session_expiring",
+					"remaining_seconds": int((m.maxInactivity - idle).Seconds()),
+				},
+				Timestamp: now.UnixMilli(),
+			}); err != nil {
+				m.logger.WithField("session_id", id).Warnf("Failed to send expiry warning: %v", err)
+			}
+		case now.Sub(session.inactivityWarnedAt) >= m.inactivityWarning:
+			m.logger.WithField("session_id", id).Info("Removing inactive gateway session")
+			session.CloseWithCode(model.CloseIdleTimeout, "session idle timeout")
+			session.markClosed()
+			delete(m.sessions, id)
+		}
+	}
+}
+
+// Shutdown closes every live session with a server-shutdown close code, so
+// clients can tell a rolling restart from a failure and reconnect. Called once
+// when the process is going down.
+func (m *SessionManager) Shutdown() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for id, session := range m.sessions {
+		session.CloseWithCode(model.CloseServerShutdown, "server shutting down")
+		session.markClosed()
+		delete(m.sessions, id)
+	}
+}
@@ -0,0 +1,117 @@
+package manager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/gateway/model"
+)
+
+func quietManager() *SessionManager {
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+	return NewSessionManager(logger)
+}
+
+// readEvent drains the next buffered event from a fallback session; the sweep
+// delivers its warning synchronously, so the event is already queued.
+func readEvent(t *testing.T, session *Session) *model.WebSocketMessage {
+	t.Helper()
+	select {
+	case msg := <-session.Events:
+		return msg
+	default:
+		t.Fatal("no event queued on the session")
+		return nil
+	}
+}
+
+// The inactivity flows run against a fake sweep clock (m.now) with the
+// session's activity pinned, so no test waits out a real grace window. SSE
+// sessions are used so warnings land on the Events channel.
+
+func TestCleanupWarnsThenExpires(t *testing.T) {
+	m := quietManager()
+	session := m.AddSSE("idle-session", "user-1")
+
+	base := time.Now()
+	session.lastActivity.Store(base.UnixNano())
+	clock := base
+	m.now = func() time.Time { return clock }
+
+	// 26 of 30 idle minutes: inside the warning window. The warning goes out
+	// once, and the session stays registered.
+	clock = base.Add(26 * time.Minute)
+	m.cleanupInactive()
+	m.cleanupInactive()
+
+	warning := readEvent(t, session)
+	if warning.Type != model.MessageTypeStatus {
+		t.Fatalf("warning type = %q, want %q", warning.Type, model.MessageTypeStatus)
+	}
+	if status := warning.Data["status"]; status != "session_expiring" {
+		t.Fatalf("status = %v, want session_expiring", status)
+	}
+	if remaining, ok := warning.Data["remaining_seconds"].(int); !ok || remaining <= 0 {
+		t.Errorf("remaining_seconds = %v, want a positive number", warning.Data["remaining_seconds"])
+	}
+	select {
+	case extra := <-session.Events:
+		t.Fatalf("second sweep queued another event: %v", extra.Type)
+	default:
+	}
+	if m.Count() != 1 {
+		t.Fatal("session removed during the grace window")
+	}
+
+	// Grace window over with no further activity: the session is removed.
+	clock = base.Add(32 * time.Minute)
+	m.cleanupInactive()
+
+	if m.Count() != 0 {
+		t.Error("session survived past the grace window")
+	}
+	select {
+	case <-session.Closed():
+	default:
+		t.Error("removed session was not marked closed")
+	}
+}
+
+func TestCleanupWarnThenRescue(t *testing.T) {
+	m := quietManager()
+	session := m.AddSSE("rescued-session", "user-1")
+
+	base := time.Now()
+	session.lastActivity.Store(base.UnixNano())
+	clock := base
+	m.now = func() time.Time { return clock }
+
+	clock = base.Add(26 * time.Minute)
+	m.cleanupInactive()
+	if warning := readEvent(t, session); warning.Data["status"] != "session_expiring" {
+		t.Fatalf("status = %v, want session_expiring", warning.Data["status"])
+	}
+
+	// The client sends something inside the grace window: the pending warning
+	// is forgotten and the idle clock starts over.
+	session.lastActivity.Store(base.Add(27 * time.Minute).UnixNano())
+	clock = base.Add(32 * time.Minute)
+	m.cleanupInactive()
+	if m.Count() != 1 {
+		t.Fatal("session removed despite activity inside the grace window")
+	}
+
+	// Idle long enough again: a fresh warning goes out rather than an
+	// immediate removal.
+	clock = base.Add(54 * time.Minute)
+	m.cleanupInactive()
+	if warning := readEvent(t, session); warning.Data["status"] != "session_expiring" {
+		t.Fatalf("status = %v, want session_expiring", warning.Data["status"])
+	}
+	if m.Count() != 1 {
+		t.Error("session removed instead of being re-warned")
+	}
+}
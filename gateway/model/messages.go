@@ -0,0 +1,117 @@
+package model
+
+// ProtocolVersion is the semantic version of the WebSocket message protocol
+// spoken between clients and the gateway. Bump the minor version for
+// backwards-compatible additions and the major version for breaking changes.
+const ProtocolVersion = "1.0.0"
+
+// ProtocolMajorVersion is the major version negotiated at connect time and
+// the only value accepted in a message's "v" field.
+const ProtocolMajorVersion = 1
+
+// ProtocolSubprotocolPrefix is how clients request a protocol version via the
+// Sec-WebSocket-Protocol header, e.g. "aitutor.v1".
+const ProtocolSubprotocolPrefix = "aitutor.v"
+
+// Application WebSocket close codes. Codes 4000-4999 are reserved for
+// application use; every server-initiated close sends one of these in a
+// proper close frame so clients can tell why the connection ended.
+const (
+	// CloseNormalEnd: the session ended normally (end_session, session
+	// removal, or the session's duration limit).
+	CloseNormalEnd = 4000
+	// CloseSuperseded: a new connection took over this session ID.
+	CloseSuperseded = 4001
+	// CloseIdleTimeout: the inactivity sweep removed the session after the
+	// warned grace window passed with no activity.
+	CloseIdleTimeout = 4002
+	// CloseAuthExpired: the session's credentials lapsed mid-session.
+	CloseAuthExpired = 4003
+	// CloseQuotaExceeded: a spent daily budget ended the session. The default
+	// quota policy drops frames instead of closing, so this is only sent by
+	// deployments that terminate on exhaustion.
+	CloseQuotaExceeded = 4004
+	// CloseServerShutdown: the server is shutting down.
+	CloseServerShutdown = 4005
+	// CloseUnsupportedProtocolVersion: the client asked for a protocol
+	// version this gateway cannot speak. Moved from 4001 when the lifecycle
+	// codes above claimed the low numbers.
+	CloseUnsupportedProtocolVersion = 4006
+)
+
+// WebSocketMessage is the JSON envelope for every text frame exchanged with a
+// client. Binary frames carry raw audio and are not enveloped.
+type WebSocketMessage struct {
+	Type string `json:"type"`
+	// V is the protocol major version of the message; 0 means v1, so clients
+	// predating the field keep working.
+	V         int                    `json:"v,omitempty"`
+	Data      map[string]interface{} `json:"data,omitempty"`
+	Timestamp int64                  `json:"timestamp,omitempty"`
+}
+
+// CompressedEnvelope wraps a large text payload for clients that negotiated
+// the application-level gzip envelope at connect time (compress=gzip). Data is
+// the gzip-compressed JSON of the original message, base64-encoded.
+type CompressedEnvelope struct {
+	Compressed bool   `json:"compressed"`
+	Data       string `json:"data"`
+}
+
+// Message types sent by clients.
+const (
+	MessageTypeControl = "control"
+	MessageTypeText    = "text"
+)
+
+// Message types sent by the gateway.
+const (
+	MessageTypeStatus      = "status"
+	MessageTypeError       = "error"
+	MessageTypeASRResult   = "asr_result"
+	MessageTypeLLMResponse = "llm_response"
+	MessageTypeTTSReady    = "tts_ready"
+	MessageTypeISEResult   = "ise_result"
+
+	// MessageTypeTTSChunkHeader announces the binary frame that immediately
+	// follows it, carrying the turn id, chunk index, byte length, format and
+	// final-chunk flag so clients can attribute audio to the right reply.
+	MessageTypeTTSChunkHeader = "tts_chunk_header"
+
+	// MessageTypeTTSAudio carries base64-encoded synthesis audio on the
+	// REST+SSE fallback transport, which has no binary frames.
+	MessageTypeTTSAudio = "tts_audio"
+
+	// MessageTypePronunciationComparison carries pitch/rhythm/stress
+	// correlations between the learner's recording and a synthesized
+	// rendition of the reference text.
+	MessageTypePronunciationComparison = "pronunciation_comparison"
+
+	// MessageTypeShadowingResult carries the word-level diff between a
+	// shadowing target and what the learner actually said.
+	MessageTypeShadowingResult = "shadowing_result"
+
+	// MessageTypeClarificationRequest is the tutor asking the learner to
+	// repeat themselves because the transcript was too unreliable to answer.
+	MessageTypeClarificationRequest = "clarification_request"
+
+	// MessageTypeCorrection carries an on-demand correction of a learner
+	// utterance, produced by the request_correction control action.
+	MessageTypeCorrection = "correction"
+)
+
+// Control actions clients may send in a control message's data.action field.
+const (
+	ActionStartRecording   = "start_recording"
+	ActionStopRecording    = "stop_recording"
+	ActionSetReferenceText = "set_reference_text"
+	ActionSetAudioFormat   = "set_audio_format"
+	ActionEndSession       = "end_session"
+	ActionStartShadowing   = "start_shadowing"
+	// ActionRetry re-runs one stage ("ise", "tts" or "llm") of a recent turn
+	// against the audio the speech-service retained for it.
+	ActionRetry = "retry"
+	// ActionRequestCorrection asks the tutor to correct the "text" parameter,
+	// or the last thing the learner said when absent.
+	ActionRequestCorrection = "request_correction"
+)
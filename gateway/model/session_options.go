@@ -0,0 +1,73 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// SessionOptions are the per-session processing options a client selects at
+// connect time via query parameters on the WebSocket URL. They are fixed for
+// the lifetime of the session and forwarded to the speech-service as a
+// configure_session control message before any audio flows.
+type SessionOptions struct {
+	// Language is a BCP 47 language tag such as "en-US".
+	Language string
+	// Voice is the TTS voice identifier; empty keeps the server default.
+	Voice string
+	// ISEEnabled controls whether pronunciation evaluation runs for the
+	// session.
+	ISEEnabled bool
+	// Difficulty is one of "beginner", "intermediate" or "advanced"; empty
+	// keeps the server default.
+	Difficulty string
+	// TutorStarts controls whether the tutor opens the session with a spoken
+	// conversation starter.
+	TutorStarts bool
+	// GzipEnvelope asks the gateway to gzip large text payloads into a
+	// CompressedEnvelope, for clients whose WebSocket stack lacks
+	// permessage-deflate support. A transport option, not a processing one:
+	// it is never forwarded to the speech-service.
+	GzipEnvelope bool
+	// ResumeSessionID, when non-empty, asks the speech-service to seed this
+	// session's conversation with the named previous session's history.
+	ResumeSessionID string
+}
+
+// DefaultSessionOptions are the options used when the client supplies none.
+func DefaultSessionOptions() SessionOptions {
+	return SessionOptions{
+		Language:    "en-US",
+		ISEEnabled:  true,
+		TutorStarts: true,
+	}
+}
+
+var (
+	languageTagPattern = regexp.MustCompile(`^[a-z]{2,3}(-[A-Z]{2})?$`)
+	voiceNamePattern   = regexp.MustCompile(`^[a-z0-9_]+$`)
+)
+
+// ValidateLanguageTag checks a lang query value.
+func ValidateLanguageTag(lang string) error {
+	if !languageTagPattern.MatchString(lang) {
+		return fmt.Errorf("invalid lang %q: want a tag like \"en-US\"", lang)
+	}
+	return nil
+}
+
+// ValidateVoiceName checks a voice query value.
+func ValidateVoiceName(voice string) error {
+	if !voiceNamePattern.MatchString(voice) {
+		return fmt.Errorf("invalid voice %q: want lowercase letters, digits and underscores", voice)
+	}
+	return nil
+}
+
+// ValidateDifficulty checks a difficulty query value.
+func ValidateDifficulty(difficulty string) error {
+	switch difficulty {
+	case "beginner", "intermediate", "advanced":
+		return nil
+	}
+	return fmt.Errorf("invalid difficulty %q: want beginner, intermediate or advanced", difficulty)
+}
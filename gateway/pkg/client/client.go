@@ -0,0 +1,531 @@
+// Package client is the Go SDK for the gateway's WebSocket protocol. It wraps
+// the JSON/binary message conventions in typed methods and callbacks so load
+// testers, proxies and e2e tests don't each re-implement the wire format.
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/gateway/model"
+)
+
+// AudioMeta describes the encoding of audio passed to SendAudio.
+type AudioMeta struct {
+	Format     string
+	SampleRate int
+}
+
+// ASRResult is a transcription delivered by the gateway.
+type ASRResult struct {
+	TurnID     int
+	Text       string
+	Confidence float64
+	IsFinal    bool
+}
+
+// LLMResponse is a tutor reply.
+type LLMResponse struct {
+	TurnID    int
+	Text      string
+	IsWelcome bool
+}
+
+// TTSAudio is one synthesized audio payload, paired from the tts_chunk_header
+// and the binary frame that follows it. Gateways predating the header deliver
+// the pair in the old order (binary, then tts_ready), which is still handled.
+type TTSAudio struct {
+	TurnID     int
+	ChunkIndex int
+	IsFinal    bool
+	Data       []byte
+	Format     string
+}
+
+// ISEResult is a pronunciation evaluation summary.
+type ISEResult struct {
+	TurnID         int
+	OverallScore   float64
+	AccuracyScore  float64
+	FluencyScore   float64
+	IntegrityScore float64
+	Words          []ISEWord
+}
+
+// ISEWord is a per-word pronunciation score.
+type ISEWord struct {
+	Word  string
+	Score float64
+}
+
+// Status is a gateway status update.
+type Status struct {
+	Status  string
+	Message string
+}
+
+// Handlers receives protocol events. Nil callbacks are skipped. Callbacks run
+// on the client's read goroutine, so they must not block.
+type Handlers struct {
+	OnASRResult   func(ASRResult)
+	OnLLMResponse func(LLMResponse)
+	OnTTSAudio    func(TTSAudio)
+	OnISEResult   func(ISEResult)
+	OnStatus      func(Status)
+	OnError       func(message string)
+
+	// OnClose reports the close code and reason when the gateway ends the
+	// connection with a close frame (see the model.Close* codes), so callers
+	// can tell an idle timeout from a shutdown from a session takeover. Not
+	// invoked for connections dropped without a close handshake or closed
+	// locally via Close.
+	OnClose func(code int, reason string)
+}
+
+// Options configures a Client.
+type Options struct {
+	// SessionID pins the connection to a session; empty lets the gateway
+	// assign one.
+	SessionID string
+	UserID    string
+
+	Handlers Handlers
+
+	// Reconnect re-dials with the same session ID when the connection drops.
+	Reconnect         bool
+	MaxReconnects     int           // 0 means DefaultMaxReconnects
+	ReconnectInterval time.Duration // 0 means DefaultReconnectInterval
+
+	// PingInterval controls keepalive pings; 0 means DefaultPingInterval.
+	PingInterval time.Duration
+
+	// Compress asks the gateway to gzip large text payloads into its
+	// application-level envelope (compress=gzip). The client transparently
+	// decompresses them, so handlers see ordinary messages. Independent of
+	// permessage-deflate, which the dialer always offers.
+	Compress bool
+}
+
+// Defaults for Options zero values.
+const (
+	DefaultMaxReconnects     = 3
+	DefaultReconnectInterval = time.Second
+	DefaultPingInterval      = 20 * time.Second
+
+	defaultPongWait = 60 * time.Second
+)
+
+// Client is a connection to the gateway's /ws endpoint.
+type Client struct {
+	url  string
+	opts Options
+
+	mu        sync.Mutex
+	conn      *websocket.Conn
+	sessionID string
+	closed    bool
+
+	// turnID increments on each utterance or typed message the client sends,
+	// so results can be correlated with the turn that produced them.
+	turnID int
+
+	// pendingHeader holds the last tts_chunk_header until the binary frame it
+	// announces arrives; pendingAudio holds a binary frame that arrived
+	// without a header until its tts_ready (the pre-header gateway ordering).
+	pendingHeader *ttsHeader
+	pendingAudio  []byte
+
+	lastMeta AudioMeta
+	done     chan struct{}
+}
+
+// Dial connects to the gateway WebSocket endpoint (e.g.
+// "ws://localhost:8080/ws") and starts the read loop.
+func Dial(url string, opts Options) (*Client, error) {
+	c := &Client{
+		url:       url,
+		opts:      opts,
+		sessionID: opts.SessionID,
+		done:      make(chan struct{}),
+	}
+	if err := c.connect(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// SessionID returns the session ID in use, once assigned by the gateway.
+func (c *Client) SessionID() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.sessionID
+}
+
+// StartRecording begins a new utterance. The turn ID advances so subsequent
+// results correlate with this utterance.
+func (c *Client) StartRecording() error {
+	c.mu.Lock()
+	c.turnID++
+	c.mu.Unlock()
+	return c.sendControl(model.ActionStartRecording, nil)
+}
+
+// SendAudio sends one binary audio frame. A change of metadata is announced
+// to the gateway before the frame.
+func (c *Client) SendAudio(data []byte, meta AudioMeta) error {
+	c.mu.Lock()
+	metaChanged := meta != (AudioMeta{}) && meta != c.lastMeta
+	if metaChanged {
+		c.lastMeta = meta
+	}
+	c.mu.Unlock()
+
+	if metaChanged {
+		if err := c.sendControl(model.ActionSetAudioFormat, map[string]interface{}{
+			"format":      meta.Format,
+			"sample_rate": meta.SampleRate,
+		}); err != nil {
+			return err
+		}
+	}
+	return c.writeBinary(data)
+}
+
+// StopRecording ends the current utterance and triggers processing.
+func (c *Client) StopRecording() error {
+	return c.sendControl(model.ActionStopRecording, nil)
+}
+
+// SendText submits typed text instead of speech.
+func (c *Client) SendText(text string) error {
+	c.mu.Lock()
+	c.turnID++
+	c.mu.Unlock()
+	return c.writeJSON(&model.WebSocketMessage{
+		Type: model.MessageTypeText,
+		Data: map[string]interface{}{"text": text},
+	})
+}
+
+// SetReferenceText sets the sentence pronunciation will be evaluated against.
+func (c *Client) SetReferenceText(text string) error {
+	return c.sendControl(model.ActionSetReferenceText, map[string]interface{}{"text": text})
+}
+
+// EndSession asks the gateway to terminate the session.
+func (c *Client) EndSession() error {
+	return c.sendControl(model.ActionEndSession, nil)
+}
+
+// Close shuts the connection down without reconnecting.
+func (c *Client) Close() error {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return nil
+	}
+	c.closed = true
+	conn := c.conn
+	c.mu.Unlock()
+
+	close(c.done)
+	if conn != nil {
+		return conn.Close()
+	}
+	return nil
+}
+
+// dialer matches websocket.DefaultDialer but offers permessage-deflate, which
+// the gateway accepts.
+var dialer = websocket.Dialer{
+	Proxy:             http.ProxyFromEnvironment,
+	HandshakeTimeout:  45 * time.Second,
+	EnableCompression: true,
+}
+
+// connect dials and starts the read and ping loops for one connection.
+func (c *Client) connect() error {
+	url := c.url
+	c.mu.Lock()
+	var params []string
+	if c.sessionID != "" {
+		params = append(params, "session_id="+c.sessionID)
+	}
+	if c.opts.UserID != "" {
+		params = append(params, "user_id="+c.opts.UserID)
+	}
+	if c.opts.Compress {
+		params = append(params, "compress=gzip")
+	}
+	c.mu.Unlock()
+	if len(params) > 0 {
+		url += "?" + strings.Join(params, "&")
+	}
+
+	conn, _, err := dialer.Dial(url, http.Header{})
+	if err != nil {
+		return fmt.Errorf("dial gateway: %w", err)
+	}
+
+	conn.SetReadDeadline(time.Now().Add(defaultPongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(defaultPongWait))
+	})
+
+	c.mu.Lock()
+	c.conn = conn
+	c.mu.Unlock()
+
+	go c.readLoop(conn)
+	go c.pingLoop(conn)
+	return nil
+}
+
+// readLoop dispatches inbound frames until the connection drops, then
+// reconnects if configured.
+func (c *Client) readLoop(conn *websocket.Conn) {
+	var readErr error
+	for {
+		msgType, data, err := conn.ReadMessage()
+		if err != nil {
+			readErr = err
+			break
+		}
+		switch msgType {
+		case websocket.TextMessage:
+			c.handleTextFrame(data)
+		case websocket.BinaryMessage:
+			c.handleBinaryFrame(data)
+		}
+	}
+
+	c.mu.Lock()
+	closed := c.closed
+	c.mu.Unlock()
+
+	// A close frame from the gateway carries why the session ended; surface
+	// it unless the close was ours.
+	var closeErr *websocket.CloseError
+	if !closed && errors.As(readErr, &closeErr) && c.opts.Handlers.OnClose != nil {
+		c.opts.Handlers.OnClose(closeErr.Code, closeErr.Text)
+	}
+
+	if closed || !c.opts.Reconnect {
+		return
+	}
+	c.reconnect()
+}
+
+// reconnect re-dials with backoff, preserving the session ID.
+func (c *Client) reconnect() {
+	max := c.opts.MaxReconnects
+	if max == 0 {
+		max = DefaultMaxReconnects
+	}
+	interval := c.opts.ReconnectInterval
+	if interval == 0 {
+		interval = DefaultReconnectInterval
+	}
+
+	for attempt := 1; attempt <= max; attempt++ {
+		select {
+		case <-c.done:
+			return
+		case <-time.After(interval):
+		}
+		if err := c.connect(); err == nil {
+			return
+		}
+		interval *= 2
+	}
+	if c.opts.Handlers.OnError != nil {
+		c.opts.Handlers.OnError("reconnect failed")
+	}
+}
+
+// pingLoop sends keepalive pings until the connection or client closes.
+func (c *Client) pingLoop(conn *websocket.Conn) {
+	interval := c.opts.PingInterval
+	if interval == 0 {
+		interval = DefaultPingInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-ticker.C:
+			c.mu.Lock()
+			current := c.conn
+			c.mu.Unlock()
+			if current != conn {
+				return
+			}
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(5*time.Second)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// ttsHeader is the decoded tts_chunk_header metadata for the next binary
+// frame.
+type ttsHeader struct {
+	turnID     int
+	chunkIndex int
+	isFinal    bool
+	format     string
+}
+
+// handleBinaryFrame pairs audio with the header announcing it. Without a
+// header (a pre-header gateway) the frame is held until its tts_ready.
+func (c *Client) handleBinaryFrame(data []byte) {
+	c.mu.Lock()
+	header := c.pendingHeader
+	c.pendingHeader = nil
+	if header == nil {
+		c.pendingAudio = data
+		c.mu.Unlock()
+		return
+	}
+	c.mu.Unlock()
+
+	if c.opts.Handlers.OnTTSAudio != nil {
+		c.opts.Handlers.OnTTSAudio(TTSAudio{
+			TurnID:     header.turnID,
+			ChunkIndex: header.chunkIndex,
+			IsFinal:    header.isFinal,
+			Data:       data,
+			Format:     header.format,
+		})
+	}
+}
+
+// handleTextFrame decodes a JSON envelope and invokes the typed callback.
+// Gzip envelopes are unwrapped first, so callbacks never see them.
+func (c *Client) handleTextFrame(data []byte) {
+	if decoded, ok, err := decodeCompressed(data); err != nil {
+		if c.opts.Handlers.OnError != nil {
+			c.opts.Handlers.OnError("malformed compressed message from gateway: " + err.Error())
+		}
+		return
+	} else if ok {
+		data = decoded
+	}
+	msg, err := parseMessage(data)
+	if err != nil {
+		if c.opts.Handlers.OnError != nil {
+			c.opts.Handlers.OnError("malformed message from gateway: " + err.Error())
+		}
+		return
+	}
+
+	c.mu.Lock()
+	turnID := c.turnID
+	c.mu.Unlock()
+
+	h := c.opts.Handlers
+	switch msg.Type {
+	case model.MessageTypeStatus:
+		status, _ := msg.Data["status"].(string)
+		if status == "connected" {
+			if id, ok := msg.Data["session_id"].(string); ok {
+				c.mu.Lock()
+				c.sessionID = id
+				c.mu.Unlock()
+			}
+		}
+		if h.OnStatus != nil {
+			message, _ := msg.Data["message"].(string)
+			h.OnStatus(Status{Status: status, Message: message})
+		}
+	case model.MessageTypeASRResult:
+		if h.OnASRResult != nil {
+			text, _ := msg.Data["text"].(string)
+			confidence, _ := msg.Data["confidence"].(float64)
+			isFinal, _ := msg.Data["is_final"].(bool)
+			h.OnASRResult(ASRResult{TurnID: turnID, Text: text, Confidence: confidence, IsFinal: isFinal})
+		}
+	case model.MessageTypeLLMResponse:
+		if h.OnLLMResponse != nil {
+			text, _ := msg.Data["text"].(string)
+			isWelcome, _ := msg.Data["is_welcome"].(bool)
+			h.OnLLMResponse(LLMResponse{TurnID: turnID, Text: text, IsWelcome: isWelcome})
+		}
+	case model.MessageTypeTTSChunkHeader:
+		chunkIndex, _ := msg.Data["chunk_index"].(float64)
+		isFinal, _ := msg.Data["is_final"].(bool)
+		format, _ := msg.Data["format"].(string)
+		headerTurn, hasTurn := msg.Data["turn_id"].(float64)
+		if !hasTurn {
+			headerTurn = float64(turnID)
+		}
+		c.mu.Lock()
+		c.pendingHeader = &ttsHeader{
+			turnID:     int(headerTurn),
+			chunkIndex: int(chunkIndex),
+			isFinal:    isFinal,
+			format:     format,
+		}
+		c.mu.Unlock()
+	case model.MessageTypeTTSReady:
+		c.mu.Lock()
+		audio := c.pendingAudio
+		c.pendingAudio = nil
+		c.mu.Unlock()
+		// Audio announced by a header was already delivered when its binary
+		// frame arrived; tts_ready only pairs header-less frames.
+		if audio != nil && h.OnTTSAudio != nil {
+			format, _ := msg.Data["format"].(string)
+			h.OnTTSAudio(TTSAudio{TurnID: turnID, IsFinal: true, Data: audio, Format: format})
+		}
+	case model.MessageTypeISEResult:
+		if h.OnISEResult != nil {
+			h.OnISEResult(parseISEResult(turnID, msg.Data))
+		}
+	case model.MessageTypeError:
+		if h.OnError != nil {
+			message, _ := msg.Data["message"].(string)
+			h.OnError(message)
+		}
+	}
+}
+
+func (c *Client) sendControl(action string, extra map[string]interface{}) error {
+	data := map[string]interface{}{"action": action}
+	for k, v := range extra {
+		data[k] = v
+	}
+	return c.writeJSON(&model.WebSocketMessage{
+		Type: model.MessageTypeControl,
+		Data: data,
+	})
+}
+
+func (c *Client) writeJSON(msg *model.WebSocketMessage) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.conn == nil || c.closed {
+		return fmt.Errorf("client is closed")
+	}
+	if msg.Timestamp == 0 {
+		msg.Timestamp = time.Now().UnixMilli()
+	}
+	return c.conn.WriteJSON(msg)
+}
+
+func (c *Client) writeBinary(data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.conn == nil || c.closed {
+		return fmt.Errorf("client is closed")
+	}
+	return c.conn.WriteMessage(websocket.BinaryMessage, data)
+}
@@ -0,0 +1,313 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/gateway/model"
+)
+
+var testUpgrader = websocket.Upgrader{
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// newMockGateway runs a scripted gateway: it sends the connected status and
+// then answers a stop_recording control with a full result sequence.
+func newMockGateway(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := testUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade failed: %v", err)
+			return
+		}
+		defer conn.Close()
+
+		sessionID := r.URL.Query().Get("session_id")
+		if sessionID == "" {
+			sessionID = "assigned-session"
+		}
+		conn.WriteJSON(&model.WebSocketMessage{
+			Type: model.MessageTypeStatus,
+			Data: map[string]interface{}{"status": "connected", "session_id": sessionID},
+		})
+
+		for {
+			msgType, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if msgType != websocket.TextMessage || !strings.Contains(string(data), model.ActionStopRecording) {
+				continue
+			}
+			conn.WriteJSON(&model.WebSocketMessage{
+				Type: model.MessageTypeASRResult,
+				Data: map[string]interface{}{"text": "hello there", "confidence": 0.93, "is_final": true},
+			})
+			conn.WriteJSON(&model.WebSocketMessage{
+				Type: model.MessageTypeLLMResponse,
+				Data: map[string]interface{}{"text": "Hi! How are you?"},
+			})
+			conn.WriteMessage(websocket.BinaryMessage, []byte("mp3-bytes"))
+			conn.WriteJSON(&model.WebSocketMessage{
+				Type: model.MessageTypeTTSReady,
+				Data: map[string]interface{}{"format": "mp3", "bytes": 9},
+			})
+		}
+	}))
+}
+
+func wsURL(server *httptest.Server) string {
+	return "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+func TestClientFullTurn(t *testing.T) {
+	server := newMockGateway(t)
+	defer server.Close()
+
+	asr := make(chan ASRResult, 1)
+	llm := make(chan LLMResponse, 1)
+	tts := make(chan TTSAudio, 1)
+
+	c, err := Dial(wsURL(server), Options{
+		SessionID: "test-session",
+		Handlers: Handlers{
+			OnASRResult:   func(r ASRResult) { asr <- r },
+			OnLLMResponse: func(r LLMResponse) { llm <- r },
+			OnTTSAudio:    func(r TTSAudio) { tts <- r },
+		},
+	})
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	defer c.Close()
+
+	if err := c.StartRecording(); err != nil {
+		t.Fatalf("StartRecording failed: %v", err)
+	}
+	if err := c.SendAudio([]byte("pcm"), AudioMeta{Format: "webm", SampleRate: 16000}); err != nil {
+		t.Fatalf("SendAudio failed: %v", err)
+	}
+	if err := c.StopRecording(); err != nil {
+		t.Fatalf("StopRecording failed: %v", err)
+	}
+
+	select {
+	case r := <-asr:
+		if r.Text != "hello there" || !r.IsFinal || r.TurnID != 1 {
+			t.Errorf("unexpected ASR result: %+v", r)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for ASR result")
+	}
+
+	select {
+	case r := <-llm:
+		if r.Text != "Hi! How are you?" {
+			t.Errorf("unexpected LLM response: %+v", r)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for LLM response")
+	}
+
+	select {
+	case r := <-tts:
+		if string(r.Data) != "mp3-bytes" || r.Format != "mp3" {
+			t.Errorf("binary frame not paired with tts_ready: %+v", r)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for TTS audio")
+	}
+}
+
+func TestClientPairsHeaderWithBinary(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := testUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.WriteJSON(&model.WebSocketMessage{
+			Type: model.MessageTypeStatus,
+			Data: map[string]interface{}{"status": "connected", "session_id": "header-session"},
+		})
+		conn.WriteJSON(&model.WebSocketMessage{
+			Type: model.MessageTypeTTSChunkHeader,
+			Data: map[string]interface{}{
+				"turn_id": 7, "chunk_index": 2, "bytes": 9, "format": "mp3", "is_final": true,
+			},
+		})
+		conn.WriteMessage(websocket.BinaryMessage, []byte("mp3-bytes"))
+		// The compatibility tts_ready must not deliver the audio twice.
+		conn.WriteJSON(&model.WebSocketMessage{
+			Type: model.MessageTypeTTSReady,
+			Data: map[string]interface{}{"format": "mp3", "bytes": 9},
+		})
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	tts := make(chan TTSAudio, 2)
+	c, err := Dial(wsURL(server), Options{
+		Handlers: Handlers{OnTTSAudio: func(r TTSAudio) { tts <- r }},
+	})
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	defer c.Close()
+
+	select {
+	case r := <-tts:
+		if r.TurnID != 7 || r.ChunkIndex != 2 || !r.IsFinal {
+			t.Errorf("header metadata not applied: %+v", r)
+		}
+		if string(r.Data) != "mp3-bytes" || r.Format != "mp3" {
+			t.Errorf("audio not paired with its header: %+v", r)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for TTS audio")
+	}
+
+	select {
+	case r := <-tts:
+		t.Fatalf("audio delivered twice, second delivery: %+v", r)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestClientSessionIDAssigned(t *testing.T) {
+	server := newMockGateway(t)
+	defer server.Close()
+
+	connected := make(chan Status, 1)
+	c, err := Dial(wsURL(server), Options{
+		Handlers: Handlers{OnStatus: func(s Status) { connected <- s }},
+	})
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	defer c.Close()
+
+	select {
+	case <-connected:
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for connected status")
+	}
+	if got := c.SessionID(); got != "assigned-session" {
+		t.Errorf("SessionID = %q, want %q", got, "assigned-session")
+	}
+}
+
+func TestClientReconnect(t *testing.T) {
+	var dials int32
+	sessionIDs := make(chan string, 4)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&dials, 1)
+		conn, err := testUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		sessionIDs <- r.URL.Query().Get("session_id")
+		conn.WriteJSON(&model.WebSocketMessage{
+			Type: model.MessageTypeStatus,
+			Data: map[string]interface{}{"status": "connected", "session_id": "sticky"},
+		})
+		if n == 1 {
+			// Drop the first connection to force a reconnect.
+			conn.Close()
+			return
+		}
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				conn.Close()
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	c, err := Dial(wsURL(server), Options{
+		SessionID:         "sticky",
+		Reconnect:         true,
+		ReconnectInterval: 10 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	defer c.Close()
+
+	deadline := time.After(2 * time.Second)
+	for atomic.LoadInt32(&dials) < 2 {
+		select {
+		case <-deadline:
+			t.Fatalf("expected a reconnect, got %d dials", atomic.LoadInt32(&dials))
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+
+	for i := 0; i < 2; i++ {
+		if id := <-sessionIDs; id != "sticky" {
+			t.Errorf("dial %d used session_id %q, want %q", i+1, id, "sticky")
+		}
+	}
+}
+
+func TestClientOnCloseExposesCode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := testUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade failed: %v", err)
+			return
+		}
+		defer conn.Close()
+		conn.WriteControl(websocket.CloseMessage,
+			websocket.FormatCloseMessage(model.CloseIdleTimeout, "session idle timeout"),
+			time.Now().Add(time.Second))
+		// Read until the client's close acknowledgment so the handshake
+		// completes before the server side goes away.
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	type closeEvent struct {
+		code   int
+		reason string
+	}
+	closes := make(chan closeEvent, 1)
+	c, err := Dial(wsURL(server), Options{
+		Handlers: Handlers{
+			OnClose: func(code int, reason string) { closes <- closeEvent{code, reason} },
+		},
+	})
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	defer c.Close()
+
+	select {
+	case got := <-closes:
+		if got.code != model.CloseIdleTimeout {
+			t.Errorf("close code = %d, want %d", got.code, model.CloseIdleTimeout)
+		}
+		if got.reason != "session idle timeout" {
+			t.Errorf("close reason = %q, want %q", got.reason, "session idle timeout")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("OnClose was not invoked")
+	}
+}
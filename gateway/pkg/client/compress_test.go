@@ -0,0 +1,153 @@
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/gateway/manager"
+	"github.com/CurvatureX/ai-tutor-monorepo/gateway/model"
+)
+
+// bigISEResult builds an ise_result with enough per-word scores to clear any
+// reasonable compression threshold.
+func bigISEResult(wordCount int) *model.WebSocketMessage {
+	words := make([]interface{}, 0, wordCount)
+	for i := 0; i < wordCount; i++ {
+		words = append(words, map[string]interface{}{"word": "pronunciation", "score": 87.5})
+	}
+	return &model.WebSocketMessage{
+		Type: model.MessageTypeISEResult,
+		Data: map[string]interface{}{
+			"overall_score": 87.0,
+			"words":         words,
+		},
+	}
+}
+
+// newCompressGateway runs a gateway that writes through a real manager.Session
+// configured with the given envelope threshold: the connected status first,
+// then a large ise_result.
+func newCompressGateway(t *testing.T, minBytes int, sawCompressParam *atomic.Bool) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sawCompressParam.Store(r.URL.Query().Get("compress") == "gzip")
+		conn, err := testUpgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade failed: %v", err)
+			return
+		}
+		defer conn.Close()
+
+		session := &manager.Session{ID: "compress-session", Conn: conn, CompressMinBytes: minBytes}
+		session.WriteJSON(&model.WebSocketMessage{
+			Type: model.MessageTypeStatus,
+			Data: map[string]interface{}{"status": "connected", "session_id": session.ID},
+		})
+		session.WriteJSON(bigISEResult(300))
+
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+}
+
+func TestCompressedEnvelopeRoundTrip(t *testing.T) {
+	var sawCompressParam atomic.Bool
+	server := newCompressGateway(t, 256, &sawCompressParam)
+	defer server.Close()
+
+	results := make(chan ISEResult, 1)
+	c, err := Dial(wsURL(server), Options{
+		Compress: true,
+		Handlers: Handlers{
+			OnISEResult: func(r ISEResult) { results <- r },
+		},
+	})
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+	defer c.Close()
+
+	select {
+	case result := <-results:
+		if !sawCompressParam.Load() {
+			t.Error("client did not send compress=gzip at connect")
+		}
+		if result.OverallScore != 87.0 {
+			t.Errorf("overall score = %v, want 87", result.OverallScore)
+		}
+		if len(result.Words) != 300 {
+			t.Errorf("word count = %d, want 300", len(result.Words))
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no ise_result within 2s")
+	}
+}
+
+// TestEnvelopeOnlyWrapsLargePayloads reads raw frames to pin the wire format:
+// messages under the threshold go out as plain JSON, larger ones as a
+// {"compressed":true,"data":...} envelope that gunzips back to the original.
+func TestEnvelopeOnlyWrapsLargePayloads(t *testing.T) {
+	var sawCompressParam atomic.Bool
+	server := newCompressGateway(t, 256, &sawCompressParam)
+	defer server.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL(server), nil)
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+
+	_, small, err := conn.ReadMessage()
+	if err != nil {
+		t.Fatalf("read status frame: %v", err)
+	}
+	if bytes.Contains(small, []byte(`"compressed":true`)) {
+		t.Errorf("status frame below the threshold was wrapped: %s", small)
+	}
+
+	_, large, err := conn.ReadMessage()
+	if err != nil {
+		t.Fatalf("read ise frame: %v", err)
+	}
+	var envelope model.CompressedEnvelope
+	if err := json.Unmarshal(large, &envelope); err != nil || !envelope.Compressed {
+		t.Fatal("large frame is not a compressed envelope")
+	}
+	if len(large) >= 300*20 {
+		t.Errorf("envelope is %d bytes, no smaller than the raw payload", len(large))
+	}
+
+	compressed, err := base64.StdEncoding.DecodeString(envelope.Data)
+	if err != nil {
+		t.Fatalf("envelope data is not base64: %v", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		t.Fatalf("envelope data is not gzip: %v", err)
+	}
+	raw, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatalf("decompress envelope: %v", err)
+	}
+	var msg model.WebSocketMessage
+	if err := json.Unmarshal(raw, &msg); err != nil {
+		t.Fatalf("decompressed payload is not a message: %v", err)
+	}
+	if msg.Type != model.MessageTypeISEResult {
+		t.Errorf("decompressed type = %q, want %q", msg.Type, model.MessageTypeISEResult)
+	}
+}
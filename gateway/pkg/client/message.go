@@ -0,0 +1,69 @@
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/gateway/model"
+)
+
+// decodeCompressed unwraps the gateway's gzip envelope. The boolean reports
+// whether the frame was an envelope at all; ordinary messages pass through
+// untouched with ok=false.
+func decodeCompressed(data []byte) ([]byte, bool, error) {
+	var envelope model.CompressedEnvelope
+	if err := json.Unmarshal(data, &envelope); err != nil || !envelope.Compressed {
+		return nil, false, nil
+	}
+	compressed, err := base64.StdEncoding.DecodeString(envelope.Data)
+	if err != nil {
+		return nil, true, fmt.Errorf("decode envelope: %w", err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, true, fmt.Errorf("decode envelope: %w", err)
+	}
+	defer zr.Close()
+	raw, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, true, fmt.Errorf("decode envelope: %w", err)
+	}
+	return raw, true, nil
+}
+
+// parseMessage decodes a gateway JSON envelope.
+func parseMessage(data []byte) (*model.WebSocketMessage, error) {
+	var msg model.WebSocketMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		return nil, err
+	}
+	if msg.Type == "" {
+		return nil, fmt.Errorf("message has no type")
+	}
+	return &msg, nil
+}
+
+// parseISEResult extracts the typed evaluation from an ise_result payload.
+func parseISEResult(turnID int, data map[string]interface{}) ISEResult {
+	result := ISEResult{TurnID: turnID}
+	result.OverallScore, _ = data["overall_score"].(float64)
+	result.AccuracyScore, _ = data["accuracy_score"].(float64)
+	result.FluencyScore, _ = data["fluency_score"].(float64)
+	result.IntegrityScore, _ = data["integrity_score"].(float64)
+
+	words, _ := data["words"].([]interface{})
+	for _, raw := range words {
+		entry, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		word, _ := entry["word"].(string)
+		score, _ := entry["score"].(float64)
+		result.Words = append(result.Words, ISEWord{Word: word, Score: score})
+	}
+	return result
+}
@@ -0,0 +1,58 @@
+package quota
+
+import "sync"
+
+// MemoryStore keeps counters in process memory. Usage resets when the gateway
+// restarts; deployments that need durable accounting configure the Redis
+// store instead.
+type MemoryStore struct {
+	mu       sync.Mutex
+	counters map[string]*memoryCounters
+}
+
+type memoryCounters struct {
+	audioSeconds float64
+	tokens       int64
+}
+
+// NewMemoryStore creates the in-memory store.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{counters: make(map[string]*memoryCounters)}
+}
+
+func (s *MemoryStore) key(userID, day string) string {
+	return userID + ":" + day
+}
+
+// Add increments the user's counters for the day.
+func (s *MemoryStore) Add(userID, day string, audioSeconds float64, tokens int64) (float64, int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.counters[s.key(userID, day)]
+	if !ok {
+		c = &memoryCounters{}
+		s.counters[s.key(userID, day)] = c
+	}
+	c.audioSeconds += audioSeconds
+	c.tokens += tokens
+	return c.audioSeconds, c.tokens, nil
+}
+
+// Get returns the user's counters for the day.
+func (s *MemoryStore) Get(userID, day string) (float64, int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.counters[s.key(userID, day)]
+	if !ok {
+		return 0, 0, nil
+	}
+	return c.audioSeconds, c.tokens, nil
+}
+
+// Reset clears the user's counters for the day.
+func (s *MemoryStore) Reset(userID, day string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.counters, s.key(userID, day))
+	return nil
+}
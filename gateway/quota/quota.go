@@ -0,0 +1,133 @@
+// Package quota enforces per-user daily usage caps at the gateway: seconds of
+// audio forwarded and LLM tokens consumed (as reported back by the
+// speech-service). Counters live in a pluggable store so a Redis deployment
+// survives gateway restarts.
+package quota
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Limits are the per-user daily budgets; a zero value disables that cap.
+type Limits struct {
+	AudioSecondsPerDay float64
+	LLMTokensPerDay    int64
+}
+
+// Usage is the read-only view served by the admin endpoint.
+type Usage struct {
+	AudioSeconds float64   `json:"audio_seconds"`
+	LLMTokens    int64     `json:"llm_tokens"`
+	ResetAt      time.Time `json:"reset_at"`
+}
+
+// ErrQuotaExceeded reports which budget ran out and when it resets.
+type ErrQuotaExceeded struct {
+	Resource string // "audio" or "llm_tokens"
+	ResetAt  time.Time
+}
+
+func (e *ErrQuotaExceeded) Error() string {
+	return fmt.Sprintf("daily %s quota exceeded, resets at %s", e.Resource, e.ResetAt.Format(time.RFC3339))
+}
+
+// Store is the persistence backend for per-user daily counters. day is a
+// "2006-01-02" date string so backends can keep one bucket per day.
+type Store interface {
+	// Add increments the user's counters for the day and returns the updated
+	// totals.
+	Add(userID, day string, audioSeconds float64, tokens int64) (float64, int64, error)
+	// Get returns the user's counters for the day.
+	Get(userID, day string) (float64, int64, error)
+	// Reset clears the user's counters for the day.
+	Reset(userID, day string) error
+}
+
+// Service ties limits to a store. A nil *Service disables quota enforcement,
+// so callers don't guard every call.
+type Service struct {
+	limits Limits
+	store  Store
+	logger *logrus.Logger
+
+	// now is injectable so tests can cross the daily boundary.
+	now func() time.Time
+}
+
+// NewService creates the quota service.
+func NewService(limits Limits, store Store, logger *logrus.Logger) *Service {
+	return &Service{
+		limits: limits,
+		store:  store,
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+// Check returns ErrQuotaExceeded when either of the user's daily budgets is
+// spent. Anonymous sessions (empty user ID) are not metered, and store errors
+// fail open: a quota backend outage must not take practice sessions down.
+func (s *Service) Check(userID string) error {
+	if s == nil || userID == "" {
+		return nil
+	}
+	now := s.now()
+	audio, tokens, err := s.store.Get(userID, day(now))
+	if err != nil {
+		s.logger.WithError(err).Warn("Quota lookup failed; allowing request")
+		return nil
+	}
+	if s.limits.AudioSecondsPerDay > 0 && audio >= s.limits.AudioSecondsPerDay {
+		return &ErrQuotaExceeded{Resource: "audio", ResetAt: nextMidnight(now)}
+	}
+	if s.limits.LLMTokensPerDay > 0 && tokens >= s.limits.LLMTokensPerDay {
+		return &ErrQuotaExceeded{Resource: "llm_tokens", ResetAt: nextMidnight(now)}
+	}
+	return nil
+}
+
+// RecordAudio adds seconds of audio forwarded for the user.
+func (s *Service) RecordAudio(userID string, seconds float64) {
+	s.record(userID, seconds, 0)
+}
+
+// RecordTokens adds LLM tokens the speech-service reported for the user.
+func (s *Service) RecordTokens(userID string, tokens int64) {
+	s.record(userID, 0, tokens)
+}
+
+func (s *Service) record(userID string, seconds float64, tokens int64) {
+	if s == nil || userID == "" || (seconds == 0 && tokens == 0) {
+		return
+	}
+	if _, _, err := s.store.Add(userID, day(s.now()), seconds, tokens); err != nil {
+		s.logger.WithError(err).Warn("Failed to record quota usage")
+	}
+}
+
+// Usage returns the user's consumption for the current day.
+func (s *Service) Usage(userID string) (Usage, error) {
+	now := s.now()
+	audio, tokens, err := s.store.Get(userID, day(now))
+	if err != nil {
+		return Usage{}, err
+	}
+	return Usage{AudioSeconds: audio, LLMTokens: tokens, ResetAt: nextMidnight(now)}, nil
+}
+
+// Reset clears the user's counters for the current day.
+func (s *Service) Reset(userID string) error {
+	return s.store.Reset(userID, day(s.now()))
+}
+
+func day(now time.Time) string {
+	return now.Format("2006-01-02")
+}
+
+func nextMidnight(now time.Time) time.Time {
+	year, month, d := now.Date()
+	return time.Date(year, month, d, 0, 0, 0, 0, now.Location()).AddDate(0, 0, 1)
+}
@@ -0,0 +1,115 @@
+package quota
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+func newTestService(t *testing.T, limits Limits) *Service {
+	t.Helper()
+	logger := logrus.New()
+	logger.SetLevel(logrus.ErrorLevel)
+	return NewService(limits, NewMemoryStore(), logger)
+}
+
+func TestQuotaBlocksWhenAudioBudgetSpent(t *testing.T) {
+	svc := newTestService(t, Limits{AudioSecondsPerDay: 10})
+
+	if err := svc.Check("u-1"); err != nil {
+		t.Fatalf("Check before usage: %v", err)
+	}
+	svc.RecordAudio("u-1", 10)
+
+	err := svc.Check("u-1")
+	var qerr *ErrQuotaExceeded
+	if !errors.As(err, &qerr) {
+		t.Fatalf("Check after spending = %v, want ErrQuotaExceeded", err)
+	}
+	if qerr.Resource != "audio" {
+		t.Errorf("resource = %q, want audio", qerr.Resource)
+	}
+	if !qerr.ResetAt.After(time.Now()) {
+		t.Errorf("reset time %v is not in the future", qerr.ResetAt)
+	}
+
+	// Other users keep their own budget.
+	if err := svc.Check("u-2"); err != nil {
+		t.Errorf("Check for other user: %v", err)
+	}
+}
+
+func TestQuotaBlocksWhenTokenBudgetSpent(t *testing.T) {
+	svc := newTestService(t, Limits{LLMTokensPerDay: 100})
+	svc.RecordTokens("u-1", 100)
+
+	err := svc.Check("u-1")
+	var qerr *ErrQuotaExceeded
+	if !errors.As(err, &qerr) || qerr.Resource != "llm_tokens" {
+		t.Fatalf("Check = %v, want llm_tokens ErrQuotaExceeded", err)
+	}
+}
+
+func TestQuotaResetsAtDailyBoundary(t *testing.T) {
+	svc := newTestService(t, Limits{AudioSecondsPerDay: 10})
+	now := time.Date(2026, 8, 29, 23, 50, 0, 0, time.UTC)
+	svc.now = func() time.Time { return now }
+
+	svc.RecordAudio("u-1", 10)
+	if err := svc.Check("u-1"); err == nil {
+		t.Fatal("no error with the budget spent")
+	}
+
+	// Cross midnight: the next day's bucket is empty.
+	now = now.Add(20 * time.Minute)
+	if err := svc.Check("u-1"); err != nil {
+		t.Fatalf("Check after the daily rollover: %v", err)
+	}
+	usage, err := svc.Usage("u-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if usage.AudioSeconds != 0 {
+		t.Errorf("audio usage after rollover = %v, want 0", usage.AudioSeconds)
+	}
+	if want := time.Date(2026, 8, 31, 0, 0, 0, 0, time.UTC); !usage.ResetAt.Equal(want) {
+		t.Errorf("reset at = %v, want %v", usage.ResetAt, want)
+	}
+}
+
+func TestQuotaAdminReset(t *testing.T) {
+	svc := newTestService(t, Limits{AudioSecondsPerDay: 10})
+	svc.RecordAudio("u-1", 10)
+	if err := svc.Check("u-1"); err == nil {
+		t.Fatal("no error with the budget spent")
+	}
+
+	if err := svc.Reset("u-1"); err != nil {
+		t.Fatalf("Reset: %v", err)
+	}
+	if err := svc.Check("u-1"); err != nil {
+		t.Errorf("Check after reset: %v", err)
+	}
+}
+
+func TestQuotaAnonymousAndDisabled(t *testing.T) {
+	svc := newTestService(t, Limits{})
+	svc.RecordAudio("u-1", 1e9)
+	if err := svc.Check("u-1"); err != nil {
+		t.Errorf("Check with no limits configured: %v", err)
+	}
+
+	limited := newTestService(t, Limits{AudioSecondsPerDay: 1})
+	limited.RecordAudio("", 100)
+	if err := limited.Check(""); err != nil {
+		t.Errorf("Check for anonymous user: %v", err)
+	}
+
+	var nilSvc *Service
+	if err := nilSvc.Check("u-1"); err != nil {
+		t.Errorf("Check on nil service: %v", err)
+	}
+	nilSvc.RecordAudio("u-1", 1)
+}
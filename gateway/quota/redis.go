@@ -0,0 +1,65 @@
+package quota
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisKeyTTL keeps yesterday's bucket around long enough for timezone skew
+// and inspection, then lets Redis expire it.
+const redisKeyTTL = 48 * time.Hour
+
+// RedisStore persists counters in Redis so quota accounting survives gateway
+// restarts and is shared between replicas.
+type RedisStore struct {
+	client *redis.Client
+}
+
+// NewRedisStore connects to the Redis server at addr.
+func NewRedisStore(addr string) *RedisStore {
+	return &RedisStore{client: redis.NewClient(&redis.Options{Addr: addr})}
+}
+
+func (s *RedisStore) key(userID, day string) string {
+	return "quota:" + userID + ":" + day
+}
+
+// Add increments the user's counters for the day in one transaction.
+func (s *RedisStore) Add(userID, day string, audioSeconds float64, tokens int64) (float64, int64, error) {
+	ctx := context.Background()
+	key := s.key(userID, day)
+	pipe := s.client.TxPipeline()
+	audio := pipe.HIncrByFloat(ctx, key, "audio_seconds", audioSeconds)
+	total := pipe.HIncrBy(ctx, key, "llm_tokens", tokens)
+	pipe.Expire(ctx, key, redisKeyTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, 0, err
+	}
+	return audio.Val(), total.Val(), nil
+}
+
+// Get returns the user's counters for the day.
+func (s *RedisStore) Get(userID, day string) (float64, int64, error) {
+	ctx := context.Background()
+	values, err := s.client.HMGet(ctx, s.key(userID, day), "audio_seconds", "llm_tokens").Result()
+	if err != nil {
+		return 0, 0, err
+	}
+	var audio float64
+	var tokens int64
+	if raw, ok := values[0].(string); ok {
+		audio, _ = strconv.ParseFloat(raw, 64)
+	}
+	if raw, ok := values[1].(string); ok {
+		tokens, _ = strconv.ParseInt(raw, 10, 64)
+	}
+	return audio, tokens, nil
+}
+
+// Reset clears the user's counters for the day.
+func (s *RedisStore) Reset(userID, day string) error {
+	return s.client.Del(context.Background(), s.key(userID, day)).Err()
+}
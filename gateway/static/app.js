@@ -0,0 +1,61 @@
+// Minimal demo client for the gateway's versioned WebSocket protocol.
+// Production clients live in clients/; this page exists to smoke-test a
+// running gateway from a browser.
+(function () {
+  const statusEl = document.getElementById("status");
+  const connectBtn = document.getElementById("connect");
+  const recordBtn = document.getElementById("record");
+  const transcript = document.getElementById("transcript");
+
+  let ws = null;
+  let recording = false;
+
+  function addLine(text, cls) {
+    const li = document.createElement("li");
+    li.textContent = text;
+    if (cls) li.className = cls;
+    transcript.appendChild(li);
+  }
+
+  connectBtn.addEventListener("click", function () {
+    if (ws) {
+      ws.close();
+      return;
+    }
+    const proto = location.protocol === "https:" ? "wss" : "ws";
+    ws = new WebSocket(proto + "://" + location.host + "/ws?v=1");
+    ws.addEventListener("open", function () {
+      statusEl.textContent = "Connected";
+      connectBtn.textContent = "Disconnect";
+      recordBtn.disabled = false;
+    });
+    ws.addEventListener("close", function (ev) {
+      statusEl.textContent = "Disconnected (" + ev.code + ")";
+      connectBtn.textContent = "Connect";
+      recordBtn.disabled = true;
+      ws = null;
+    });
+    ws.addEventListener("message", function (ev) {
+      if (typeof ev.data !== "string") return; // raw TTS audio frame
+      const msg = JSON.parse(ev.data);
+      if (msg.type === "asr_result") {
+        addLine("You: " + msg.data.text);
+      } else if (msg.type === "llm_response") {
+        addLine("Tutor: " + msg.data.text, "tutor");
+      } else if (msg.type === "error") {
+        addLine("Error: " + (msg.data.message || ev.data));
+      }
+    });
+  });
+
+  recordBtn.addEventListener("click", function () {
+    if (!ws) return;
+    recording = !recording;
+    ws.send(JSON.stringify({
+      type: "control",
+      data: { action: recording ? "start_recording" : "stop_recording" },
+      timestamp: Date.now(),
+    }));
+    recordBtn.textContent = recording ? "Stop Recording" : "Start Recording";
+  });
+})();
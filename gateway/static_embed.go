@@ -0,0 +1,21 @@
+package main
+
+import (
+	"embed"
+	"io/fs"
+)
+
+// The demo frontend is compiled into the binary so the gateway serves it
+// regardless of working directory; STATIC_DIR overrides it for development.
+//
+//go:embed static
+var staticFiles embed.FS
+
+// embeddedStatic returns the frontend rooted at index.html.
+func embeddedStatic() fs.FS {
+	sub, err := fs.Sub(staticFiles, "static")
+	if err != nil {
+		panic(err) // unreachable: the embedded tree always has static/
+	}
+	return sub
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/shared/web"
+)
+
+// The embedded frontend must serve without any files on disk; this is what
+// lets the container image ship just the binary.
+func TestEmbeddedFrontendServesWithoutDiskFiles(t *testing.T) {
+	site, err := web.NewStaticSite(embeddedStatic(), "")
+	if err != nil {
+		t.Fatalf("NewStaticSite: %v", err)
+	}
+
+	for _, path := range []string{"/", "/static/app.js", "/static/app.css"} {
+		rec := httptest.NewRecorder()
+		site.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		if rec.Code != http.StatusOK {
+			t.Errorf("GET %s = %d, want 200", path, rec.Code)
+		}
+	}
+
+	rec := httptest.NewRecorder()
+	site.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if !strings.Contains(rec.Body.String(), "Voice Practice") {
+		t.Errorf("index body = %q, want the embedded page", rec.Body.String())
+	}
+}
@@ -0,0 +1,130 @@
+// Package validation enforces JSON Schema on inbound WebSocket messages so
+// malformed frames are rejected with actionable errors before they reach
+// handler logic.
+package validation
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/qri-io/jsonschema"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/gateway/model"
+)
+
+//go:embed schemas/*.json
+var schemaFS embed.FS
+
+var validationErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "validation_errors_total",
+	Help: "Inbound WebSocket messages rejected by schema validation.",
+}, []string{"type"})
+
+// ValidationErrorDetail names the offending field so clients can fix the
+// message.
+type ValidationErrorDetail struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationError carries all schema violations found in one message.
+type ValidationError struct {
+	MessageType string
+	Details     []ValidationErrorDetail
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, 0, len(e.Details))
+	for _, d := range e.Details {
+		parts = append(parts, fmt.Sprintf("%s: %s", d.Field, d.Message))
+	}
+	return fmt.Sprintf("invalid %s message: %s", e.MessageType, strings.Join(parts, "; "))
+}
+
+// MessageValidator validates raw text frames against per-type schemas.
+type MessageValidator struct {
+	schemas map[string]*jsonschema.Schema
+}
+
+// NewMessageValidator loads the embedded schemas.
+func NewMessageValidator() (*MessageValidator, error) {
+	v := &MessageValidator{schemas: make(map[string]*jsonschema.Schema)}
+	for _, msgType := range []string{model.MessageTypeControl, model.MessageTypeText} {
+		raw, err := schemaFS.ReadFile("schemas/" + msgType + ".json")
+		if err != nil {
+			return nil, fmt.Errorf("read schema for %q: %w", msgType, err)
+		}
+		schema := &jsonschema.Schema{}
+		if err := json.Unmarshal(raw, schema); err != nil {
+			return nil, fmt.Errorf("parse schema for %q: %w", msgType, err)
+		}
+		v.schemas[msgType] = schema
+	}
+	return v, nil
+}
+
+// Validate parses and validates one text frame, returning the decoded
+// envelope or a *ValidationError describing every violation.
+func (v *MessageValidator) Validate(raw []byte) (*model.WebSocketMessage, error) {
+	var msg model.WebSocketMessage
+	if err := json.Unmarshal(raw, &msg); err != nil {
+		validationErrors.WithLabelValues("unknown").Inc()
+		return nil, &ValidationError{
+			MessageType: "unknown",
+			Details:     []ValidationErrorDetail{{Field: "(root)", Message: "not a JSON object: " + err.Error()}},
+		}
+	}
+	if msg.Type == "" {
+		validationErrors.WithLabelValues("unknown").Inc()
+		return nil, &ValidationError{
+			MessageType: "unknown",
+			Details:     []ValidationErrorDetail{{Field: "type", Message: "is required"}},
+		}
+	}
+	if msg.V != 0 && msg.V != model.ProtocolMajorVersion {
+		validationErrors.WithLabelValues(msg.Type).Inc()
+		return nil, &ValidationError{
+			MessageType: msg.Type,
+			Details: []ValidationErrorDetail{{
+				Field:   "v",
+				Message: fmt.Sprintf("unsupported protocol version %d: this gateway speaks v%d", msg.V, model.ProtocolMajorVersion),
+			}},
+		}
+	}
+
+	schema, ok := v.schemas[msg.Type]
+	if !ok {
+		// Unknown types pass through; the handler decides how to reject them.
+		return &msg, nil
+	}
+
+	keyErrors, err := schema.ValidateBytes(context.Background(), raw)
+	if err != nil {
+		validationErrors.WithLabelValues(msg.Type).Inc()
+		return nil, &ValidationError{
+			MessageType: msg.Type,
+			Details:     []ValidationErrorDetail{{Field: "(root)", Message: err.Error()}},
+		}
+	}
+	if len(keyErrors) > 0 {
+		validationErrors.WithLabelValues(msg.Type).Inc()
+		verr := &ValidationError{MessageType: msg.Type}
+		for _, ke := range keyErrors {
+			field := ke.PropertyPath
+			if field == "" || field == "/" {
+				field = "(root)"
+			}
+			verr.Details = append(verr.Details, ValidationErrorDetail{
+				Field:   field,
+				Message: ke.Message,
+			})
+		}
+		return nil, verr
+	}
+	return &msg, nil
+}
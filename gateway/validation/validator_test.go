@@ -0,0 +1,116 @@
+package validation
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestValidateAcceptsWellFormedMessages(t *testing.T) {
+	v, err := NewMessageValidator()
+	if err != nil {
+		t.Fatalf("NewMessageValidator failed: %v", err)
+	}
+
+	for _, raw := range []string{
+		`{"type":"control","data":{"action":"start_recording"}}`,
+		`{"type":"control","data":{"action":"set_reference_text","text":"hello"}}`,
+		`{"type":"text","data":{"text":"How do I say this?"}}`,
+	} {
+		msg, err := v.Validate([]byte(raw))
+		if err != nil {
+			t.Errorf("Validate(%s) returned error: %v", raw, err)
+			continue
+		}
+		if msg.Type == "" {
+			t.Errorf("Validate(%s) returned empty type", raw)
+		}
+	}
+}
+
+func TestValidateProtocolVersionField(t *testing.T) {
+	v, err := NewMessageValidator()
+	if err != nil {
+		t.Fatalf("NewMessageValidator failed: %v", err)
+	}
+
+	// v:1 and an absent v are both the current protocol.
+	for _, raw := range []string{
+		`{"type":"text","v":1,"data":{"text":"hi"}}`,
+		`{"type":"text","data":{"text":"hi"}}`,
+	} {
+		if _, err := v.Validate([]byte(raw)); err != nil {
+			t.Errorf("Validate(%s) returned error: %v", raw, err)
+		}
+	}
+
+	_, err = v.Validate([]byte(`{"type":"text","v":2,"data":{"text":"hi"}}`))
+	if err == nil {
+		t.Fatal("Validate accepted v:2")
+	}
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("error is %T, want *ValidationError", err)
+	}
+	if len(verr.Details) != 1 || verr.Details[0].Field != "v" {
+		t.Errorf("details = %+v, want one naming field v", verr.Details)
+	}
+}
+
+func TestValidateRejectsUnknownControlAction(t *testing.T) {
+	v, err := NewMessageValidator()
+	if err != nil {
+		t.Fatalf("NewMessageValidator failed: %v", err)
+	}
+
+	_, err = v.Validate([]byte(`{"type":"control","data":{"action":"reboot"}}`))
+	if err == nil {
+		t.Fatal("Validate accepted an unknown control action")
+	}
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("error is %T, want *ValidationError", err)
+	}
+	found := false
+	for _, d := range verr.Details {
+		if strings.Contains(d.Field, "action") {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("details = %+v, want one naming the action field", verr.Details)
+	}
+}
+
+func TestValidateRejectsMalformedMessages(t *testing.T) {
+	v, err := NewMessageValidator()
+	if err != nil {
+		t.Fatalf("NewMessageValidator failed: %v", err)
+	}
+
+	cases := []struct {
+		name string
+		raw  string
+	}{
+		{"data not an object", `{"type":"control","data":12345}`},
+		{"missing action", `{"type":"control","data":{}}`},
+		{"missing text", `{"type":"text","data":{}}`},
+		{"no type", `{"data":{"action":"start_recording"}}`},
+		{"not json", `not json at all`},
+	}
+	for _, tc := range cases {
+		_, err := v.Validate([]byte(tc.raw))
+		if err == nil {
+			t.Errorf("%s: Validate accepted %s", tc.name, tc.raw)
+			continue
+		}
+		var verr *ValidationError
+		if !errors.As(err, &verr) {
+			t.Errorf("%s: error is %T, want *ValidationError", tc.name, err)
+			continue
+		}
+		if len(verr.Details) == 0 {
+			t.Errorf("%s: ValidationError has no details", tc.name)
+		}
+	}
+}
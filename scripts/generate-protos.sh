@@ -0,0 +1,18 @@
+#!/usr/bin/env bash
+# Regenerates the Go bindings for the shared protobuf definitions under
+# shared/proto/. Both the gateway and the speech service import these
+# packages; never hand-edit the generated files or regenerate per-service
+# copies.
+#
+# Requires protoc plus the Go plugins on PATH:
+#   go install google.golang.org/protobuf/cmd/protoc-gen-go@latest
+#   go install google.golang.org/grpc/cmd/protoc-gen-go-grpc@latest
+set -euo pipefail
+
+cd "$(dirname "$0")/.."
+
+protoc \
+	--proto_path=shared/proto \
+	--go_out=shared/proto --go_opt=paths=source_relative \
+	--go-grpc_out=shared/proto --go-grpc_opt=paths=source_relative \
+	shared/proto/speech/speech.proto
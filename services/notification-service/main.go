@@ -0,0 +1,5 @@
+// The notification service is not implemented yet; this placeholder keeps
+// the module building until it is.
+package main
+
+func main() {}
@@ -0,0 +1,116 @@
+package audio
+
+import (
+	"math"
+	"sync"
+)
+
+// AGC timing constants, in samples at the pipeline's 16 kHz rate.
+const (
+	agcWindowSamples  = 1600 // 100ms RMS measurement window
+	agcAttackSamples  = 160  // 10ms: gain drops quickly when the signal gets loud
+	agcReleaseSamples = 1600 // 100ms: gain recovers slowly on quiet passages
+)
+
+// AGC is an automatic gain control filter that normalizes recording volume
+// so quiet microphones don't drag down ASR confidence. It measures RMS over
+// 100ms windows, steers the gain toward TargetRMS (as a fraction of full
+// scale), and smooths gain changes with a fast attack and slow release so
+// level jumps don't pump audibly. Upward gain is capped at MaxGainDB;
+// samples are clamped to the int16 range so loud input never wraps.
+type AGC struct {
+	// TargetRMS is the desired RMS level as a fraction of full scale (1.0
+	// is a rail-to-rail square wave).
+	TargetRMS float64
+	// MaxGainDB caps how much boost is applied to very quiet audio, keeping
+	// the filter from amplifying silence into noise.
+	MaxGainDB float64
+
+	mu             sync.Mutex
+	maxGainApplied float64 // largest smoothed gain from the last Apply, linear
+}
+
+// Name implements Filter.
+func (a *AGC) Name() string { return "agc" }
+
+// Apply implements Filter, normalizing toward the configured TargetRMS.
+func (a *AGC) Apply(pcm []byte) []byte {
+	out, maxGain := a.Process(pcm, a.TargetRMS)
+	a.mu.Lock()
+	a.maxGainApplied = maxGain
+	a.mu.Unlock()
+	return out
+}
+
+// MaxGainAppliedDB reports the largest gain applied during the most recent
+// Apply call, in decibels.
+func (a *AGC) MaxGainAppliedDB() float64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.maxGainApplied <= 0 {
+		return 0
+	}
+	return 20 * math.Log10(a.maxGainApplied)
+}
+
+// Process normalizes the PCM buffer toward targetRMS and returns the result
+// together with the largest linear gain that was applied.
+func (a *AGC) Process(pcm []byte, targetRMS float64) ([]byte, float64) {
+	if len(pcm) < 2 || targetRMS <= 0 {
+		return pcm, 1
+	}
+
+	samples := pcmToFloat(pcm)
+	maxGain := math.Pow(10, a.MaxGainDB/20)
+	if a.MaxGainDB <= 0 {
+		maxGain = 1
+	}
+
+	attackAlpha := 1 - math.Exp(-1/float64(agcAttackSamples))
+	releaseAlpha := 1 - math.Exp(-1/float64(agcReleaseSamples))
+
+	gain := 1.0
+	peakGain := 1.0
+	out := make([]float64, len(samples))
+	for start := 0; start < len(samples); start += agcWindowSamples {
+		end := start + agcWindowSamples
+		if end > len(samples) {
+			end = len(samples)
+		}
+
+		// The gain this window is steering toward; silence holds the
+		// current gain instead of slamming it to the cap.
+		target := gain
+		if rms := rmsLevel(samples[start:end]); rms > 1e-4 {
+			target = targetRMS / rms
+			if target > maxGain {
+				target = maxGain
+			}
+		}
+
+		for i := start; i < end; i++ {
+			alpha := releaseAlpha
+			if target < gain {
+				alpha = attackAlpha
+			}
+			gain += (target - gain) * alpha
+			if gain > peakGain {
+				peakGain = gain
+			}
+			out[i] = samples[i] * gain
+		}
+	}
+	return floatToPCM(out, len(pcm)), peakGain
+}
+
+// rmsLevel is the RMS of the samples as a fraction of full scale.
+func rmsLevel(samples []float64) float64 {
+	if len(samples) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, s := range samples {
+		sum += s * s
+	}
+	return math.Sqrt(sum/float64(len(samples))) / 32768
+}
@@ -0,0 +1,80 @@
+package audio
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+// sinePCM builds n samples of a 440 Hz tone at the given peak amplitude.
+func sinePCM(n int, amplitude float64) []byte {
+	pcm := make([]byte, 2*n)
+	for i := 0; i < n; i++ {
+		s := amplitude * math.Sin(2*math.Pi*440*float64(i)/denoiseSampleRate)
+		binary.LittleEndian.PutUint16(pcm[2*i:], uint16(int16(s)))
+	}
+	return pcm
+}
+
+// pcmRMS is the RMS level of the buffer as a fraction of full scale.
+func pcmRMS(pcm []byte) float64 {
+	return rmsLevel(pcmToFloat(pcm))
+}
+
+func TestAGCBoostsQuietSignalToTarget(t *testing.T) {
+	agc := &AGC{TargetRMS: 0.1, MaxGainDB: 30}
+
+	// -30 dBFS peak: roughly what a distant laptop microphone produces.
+	quiet := sinePCM(2*denoiseSampleRate, 32768*math.Pow(10, -30.0/20))
+	out, maxGain := agc.Process(quiet, agc.TargetRMS)
+
+	// Skip the first half so the release-smoothed gain has settled.
+	settled := out[len(out)/2:]
+	if got := pcmRMS(settled); got < 0.07 || got > 0.13 {
+		t.Errorf("settled RMS = %.3f, want near target 0.1", got)
+	}
+	if maxGain <= 1 {
+		t.Errorf("max gain = %.2f, want a boost for quiet input", maxGain)
+	}
+	if gainDB := 20 * math.Log10(maxGain); gainDB > 30.01 {
+		t.Errorf("max gain = %.1f dB, want capped at 30", gainDB)
+	}
+}
+
+func TestAGCDoesNotClipLoudSignal(t *testing.T) {
+	agc := &AGC{TargetRMS: 0.1, MaxGainDB: 30}
+
+	loud := sinePCM(denoiseSampleRate, 32767)
+	out, _ := agc.Process(loud, agc.TargetRMS)
+
+	inRMS, outRMS := pcmRMS(loud), pcmRMS(out)
+	if outRMS > inRMS {
+		t.Errorf("output RMS %.3f exceeds input RMS %.3f: AGC boosted a 0 dBFS signal", outRMS, inRMS)
+	}
+	// A wrapped sample shows up as a huge sample-to-sample jump the sine
+	// can't produce.
+	samples := pcmToFloat(out)
+	for i := 1; i < len(samples); i++ {
+		if math.Abs(samples[i]-samples[i-1]) > 32768 {
+			t.Fatalf("sample discontinuity at %d: %v -> %v (clipped wraparound)", i, samples[i-1], samples[i])
+		}
+	}
+}
+
+func TestAGCGainCappedForNearSilence(t *testing.T) {
+	agc := &AGC{TargetRMS: 0.1, MaxGainDB: 12}
+
+	faint := sinePCM(denoiseSampleRate, 40)
+	_, maxGain := agc.Process(faint, agc.TargetRMS)
+	if gainDB := 20 * math.Log10(maxGain); gainDB > 12.01 {
+		t.Errorf("gain = %.1f dB, want capped at 12", gainDB)
+	}
+}
+
+func TestAGCReportsGainThroughFilter(t *testing.T) {
+	agc := &AGC{TargetRMS: 0.1, MaxGainDB: 30}
+	agc.Apply(sinePCM(denoiseSampleRate, 1000))
+	if agc.MaxGainAppliedDB() <= 0 {
+		t.Errorf("MaxGainAppliedDB() = %.2f, want positive after boosting", agc.MaxGainAppliedDB())
+	}
+}
@@ -0,0 +1,95 @@
+// Package audio contains codec-level helpers shared by the speech pipeline:
+// format conversion, WAV framing and PCM analysis.
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"os/exec"
+	"time"
+)
+
+// DefaultSampleRate is the canonical sample rate the pipeline operates at.
+const DefaultSampleRate = 16000
+
+// BytesPerSample is the width of one PCM sample (16-bit little endian).
+const BytesPerSample = 2
+
+// Converter converts between the browser upload format (WebM/Opus) and the
+// 16 kHz mono 16-bit PCM the providers expect.
+type Converter struct {
+	// FFmpegPath is the ffmpeg binary used for container decoding.
+	FFmpegPath string
+	SampleRate int
+}
+
+// NewConverter returns a converter with default settings.
+func NewConverter() *Converter {
+	return &Converter{
+		FFmpegPath: "ffmpeg",
+		SampleRate: DefaultSampleRate,
+	}
+}
+
+// ConvertWebMToPCM decodes a WebM/Opus blob to 16 kHz mono 16-bit PCM.
+func (c *Converter) ConvertWebMToPCM(webmData []byte) ([]byte, error) {
+	return c.decodeToPCM(webmData)
+}
+
+// ConvertMP3ToPCM decodes an MP3 blob (the TTS output format) to 16 kHz mono
+// 16-bit PCM.
+func (c *Converter) ConvertMP3ToPCM(mp3Data []byte) ([]byte, error) {
+	return c.decodeToPCM(mp3Data)
+}
+
+// decodeToPCM runs ffmpeg with container auto-detection, so any format the
+// binary understands decodes to canonical PCM.
+func (c *Converter) decodeToPCM(data []byte) ([]byte, error) {
+	cmd := exec.Command(c.FFmpegPath,
+		"-i", "pipe:0",
+		"-f", "s16le",
+		"-acodec", "pcm_s16le",
+		"-ar", fmt.Sprintf("%d", c.SampleRate),
+		"-ac", "1",
+		"pipe:1",
+	)
+	cmd.Stdin = bytes.NewReader(data)
+	var out, errBuf bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &errBuf
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("ffmpeg decode failed: %w (%s)", err, errBuf.String())
+	}
+	return out.Bytes(), nil
+}
+
+// ConvertPCMToWAV wraps raw 16-bit mono PCM in a RIFF/WAVE header.
+func (c *Converter) ConvertPCMToWAV(pcmData []byte) []byte {
+	sampleRate := uint32(c.SampleRate)
+	byteRate := sampleRate * BytesPerSample
+	dataLen := uint32(len(pcmData))
+
+	buf := bytes.NewBuffer(make([]byte, 0, 44+len(pcmData)))
+	buf.WriteString("RIFF")
+	binary.Write(buf, binary.LittleEndian, 36+dataLen)
+	buf.WriteString("WAVE")
+	buf.WriteString("fmt ")
+	binary.Write(buf, binary.LittleEndian, uint32(16))
+	binary.Write(buf, binary.LittleEndian, uint16(1)) // PCM
+	binary.Write(buf, binary.LittleEndian, uint16(1)) // mono
+	binary.Write(buf, binary.LittleEndian, sampleRate)
+	binary.Write(buf, binary.LittleEndian, byteRate)
+	binary.Write(buf, binary.LittleEndian, uint16(BytesPerSample)) // block align
+	binary.Write(buf, binary.LittleEndian, uint16(16))             // bits per sample
+	buf.WriteString("data")
+	binary.Write(buf, binary.LittleEndian, dataLen)
+	buf.Write(pcmData)
+	return buf.Bytes()
+}
+
+// GetAudioDuration returns the play time of raw 16-bit mono PCM.
+func (c *Converter) GetAudioDuration(pcmData []byte) time.Duration {
+	samples := len(pcmData) / BytesPerSample
+	return time.Duration(samples) * time.Second / time.Duration(c.SampleRate)
+}
@@ -0,0 +1,209 @@
+package audio
+
+import (
+	"encoding/binary"
+	"math"
+	"math/cmplx"
+)
+
+// STFT parameters for the denoiser: 32 ms frames with 50% overlap at the
+// pipeline's fixed 16 kHz rate. A Hann analysis window at this hop sums to a
+// constant, so overlap-add reconstructs the signal without extra weighting.
+const (
+	denoiseFrameSize  = 512
+	denoiseHopSize    = denoiseFrameSize / 2
+	denoiseSampleRate = 16000
+)
+
+// defaultOverSubtraction removes slightly more than the estimated noise in
+// each bin, trading a little speech energy for fewer residual artifacts.
+const defaultOverSubtraction = 1.5
+
+// spectralFloor keeps a fraction of each bin's original magnitude instead of
+// letting over-subtraction zero it, which would produce musical noise.
+const spectralFloor = 0.05
+
+// NoiseProfile holds the per-frequency-bin noise magnitude estimated from a
+// stretch of background-only audio.
+type NoiseProfile struct {
+	// Magnitudes has denoiseFrameSize entries, one per FFT bin.
+	Magnitudes []float64
+}
+
+// SpectralDenoiser suppresses steady background noise (fan hum, room tone)
+// by spectral subtraction: the noise spectrum is estimated from the leading
+// part of the recording, where the user has not started speaking yet, and
+// subtracted from every frame's magnitude spectrum.
+type SpectralDenoiser struct {
+	// NoiseSampleMs is how much leading audio is treated as background-only
+	// when Apply estimates the noise profile.
+	NoiseSampleMs int
+	// OverSubtraction scales the subtracted noise magnitude; 0 uses the
+	// default. Larger values denoise harder at the cost of artifacts.
+	OverSubtraction float64
+}
+
+// Name implements Filter.
+func (d *SpectralDenoiser) Name() string { return "spectral_denoise" }
+
+// Apply implements Filter: it estimates the noise profile from the leading
+// NoiseSampleMs of the buffer and subtracts it from the whole utterance.
+func (d *SpectralDenoiser) Apply(pcm []byte) []byte {
+	profile := d.EstimateNoise(pcm, d.NoiseSampleMs)
+	return d.Denoise(pcm, profile, d.OverSubtraction)
+}
+
+// EstimateNoise computes the per-bin noise magnitude from the first
+// noiseSampleMs of the buffer by averaging frame magnitude spectra. Audio
+// shorter than one frame yields a zero profile, which makes Denoise a no-op.
+func (d *SpectralDenoiser) EstimateNoise(pcm []byte, noiseSampleMs int) NoiseProfile {
+	profile := NoiseProfile{Magnitudes: make([]float64, denoiseFrameSize)}
+	samples := pcmToFloat(pcm)
+
+	region := noiseSampleMs * denoiseSampleRate / 1000
+	if region > len(samples) {
+		region = len(samples)
+	}
+	if region < denoiseFrameSize {
+		return profile
+	}
+
+	frames := 0
+	for start := 0; start+denoiseFrameSize <= region; start += denoiseHopSize {
+		spectrum := fft(windowed(samples[start : start+denoiseFrameSize]))
+		for bin, v := range spectrum {
+			profile.Magnitudes[bin] += cmplx.Abs(v)
+		}
+		frames++
+	}
+	for bin := range profile.Magnitudes {
+		profile.Magnitudes[bin] /= float64(frames)
+	}
+	return profile
+}
+
+// Denoise applies spectral subtraction to the whole buffer: each frame's
+// magnitude spectrum is reduced by overSubtractionFactor times the profile,
+// floored at a fraction of the original magnitude, and the frame is rebuilt
+// with the original phase. 0 for overSubtractionFactor uses the default.
+func (d *SpectralDenoiser) Denoise(pcm []byte, profile NoiseProfile, overSubtractionFactor float64) []byte {
+	if overSubtractionFactor <= 0 {
+		overSubtractionFactor = defaultOverSubtraction
+	}
+	samples := pcmToFloat(pcm)
+	if len(samples) < denoiseFrameSize || len(profile.Magnitudes) != denoiseFrameSize {
+		return pcm
+	}
+
+	out := make([]float64, len(samples))
+	for start := 0; start+denoiseFrameSize <= len(samples); start += denoiseHopSize {
+		spectrum := fft(windowed(samples[start : start+denoiseFrameSize]))
+		for bin, v := range spectrum {
+			mag := cmplx.Abs(v)
+			cleaned := mag - overSubtractionFactor*profile.Magnitudes[bin]
+			if floor := spectralFloor * mag; cleaned < floor {
+				cleaned = floor
+			}
+			if mag > 0 {
+				spectrum[bin] = v * complex(cleaned/mag, 0)
+			}
+		}
+		frame := ifft(spectrum)
+		for i, v := range frame {
+			out[start+i] += real(v)
+		}
+	}
+
+	// The tail shorter than one frame is passed through untouched.
+	for i := len(samples) - len(samples)%denoiseHopSize; i < len(samples); i++ {
+		if out[i] == 0 {
+			out[i] = samples[i]
+		}
+	}
+	return floatToPCM(out, len(pcm))
+}
+
+// windowed copies the frame with a Hann window applied.
+func windowed(frame []float64) []complex128 {
+	out := make([]complex128, len(frame))
+	n := float64(len(frame) - 1)
+	for i, v := range frame {
+		w := 0.5 * (1 - math.Cos(2*math.Pi*float64(i)/n))
+		out[i] = complex(v*w, 0)
+	}
+	return out
+}
+
+// fft is an iterative radix-2 Cooley-Tukey transform; the input length must
+// be a power of two.
+func fft(data []complex128) []complex128 {
+	n := len(data)
+	out := make([]complex128, n)
+	copy(out, data)
+
+	// Bit-reversal permutation.
+	for i, j := 1, 0; i < n; i++ {
+		bit := n >> 1
+		for ; j&bit != 0; bit >>= 1 {
+			j ^= bit
+		}
+		j |= bit
+		if i < j {
+			out[i], out[j] = out[j], out[i]
+		}
+	}
+
+	for length := 2; length <= n; length <<= 1 {
+		step := cmplx.Exp(complex(0, -2*math.Pi/float64(length)))
+		for start := 0; start < n; start += length {
+			w := complex(1, 0)
+			for k := 0; k < length/2; k++ {
+				even := out[start+k]
+				odd := out[start+k+length/2] * w
+				out[start+k] = even + odd
+				out[start+k+length/2] = even - odd
+				w *= step
+			}
+		}
+	}
+	return out
+}
+
+// ifft inverts fft via the conjugate trick.
+func ifft(data []complex128) []complex128 {
+	n := len(data)
+	conj := make([]complex128, n)
+	for i, v := range data {
+		conj[i] = cmplx.Conj(v)
+	}
+	out := fft(conj)
+	for i, v := range out {
+		out[i] = cmplx.Conj(v) / complex(float64(n), 0)
+	}
+	return out
+}
+
+func pcmToFloat(pcm []byte) []float64 {
+	samples := make([]float64, len(pcm)/2)
+	for i := range samples {
+		samples[i] = float64(int16(binary.LittleEndian.Uint16(pcm[2*i:])))
+	}
+	return samples
+}
+
+func floatToPCM(samples []float64, byteLen int) []byte {
+	out := make([]byte, byteLen)
+	for i, v := range samples {
+		if 2*i+1 >= byteLen {
+			break
+		}
+		switch {
+		case v > math.MaxInt16:
+			v = math.MaxInt16
+		case v < math.MinInt16:
+			v = math.MinInt16
+		}
+		binary.LittleEndian.PutUint16(out[2*i:], uint16(int16(v)))
+	}
+	return out
+}
@@ -0,0 +1,17 @@
+package audio_test
+
+import (
+	"fmt"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/audio"
+)
+
+func ExampleConverter_ConvertPCMToWAV() {
+	converter := audio.NewConverter()
+
+	pcm := make([]byte, 32000) // one second of 16 kHz mono PCM16
+	wav := converter.ConvertPCMToWAV(pcm)
+
+	fmt.Printf("%s container, %d bytes\n", wav[:4], len(wav))
+	// Output: RIFF container, 32044 bytes
+}
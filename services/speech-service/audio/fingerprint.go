@@ -0,0 +1,15 @@
+package audio
+
+import "hash/fnv"
+
+// Fingerprinter hashes an utterance's converted PCM into a compact key so
+// byte-identical audio — a retried turn, a re-submitted recording — can be
+// recognized without holding or comparing the buffers themselves.
+type Fingerprinter struct{}
+
+// Compute returns the 64-bit FNV-1a hash of the PCM bytes.
+func (Fingerprinter) Compute(pcm []byte) uint64 {
+	h := fnv.New64a()
+	h.Write(pcm)
+	return h.Sum64()
+}
@@ -0,0 +1,106 @@
+package audio
+
+import (
+	"errors"
+	"fmt"
+)
+
+// MP3 frame header parsing. The streaming TTS provider emits bare MPEG audio
+// frames; a mid-stream disconnect can cut a frame in half, which many browser
+// decoders refuse to play past. These helpers let the pipeline hold back
+// partial frames until the bytes that complete them arrive.
+
+var errNoSyncWord = errors.New("no MP3 sync word")
+
+// mp3BitrateKbps indexes bitrate by [mpeg1][bitrateIndex] for Layer III.
+var mp3BitrateKbps = [2][16]int{
+	// MPEG-2 / MPEG-2.5
+	{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160, 0},
+	// MPEG-1
+	{0, 32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 0},
+}
+
+// mp3SampleRates indexes sample rate by [version][sampleRateIndex].
+var mp3SampleRates = [4][4]int{
+	{11025, 12000, 8000, 0},  // MPEG-2.5
+	{0, 0, 0, 0},             // reserved
+	{22050, 24000, 16000, 0}, // MPEG-2
+	{44100, 48000, 32000, 0}, // MPEG-1
+}
+
+// mp3FrameLength parses one Layer III frame header and returns the total
+// frame size in bytes, including the header.
+func mp3FrameLength(header []byte) (int, error) {
+	if len(header) < 4 {
+		return 0, fmt.Errorf("mp3 header too short: %d bytes", len(header))
+	}
+	if header[0] != 0xFF || header[1]&0xE0 != 0xE0 {
+		return 0, errNoSyncWord
+	}
+	version := (header[1] >> 3) & 0x3
+	layer := (header[1] >> 1) & 0x3
+	if version == 1 || layer != 0x1 {
+		return 0, fmt.Errorf("unsupported mpeg version/layer bits %d/%d", version, layer)
+	}
+	bitrateIndex := header[2] >> 4
+	sampleIndex := (header[2] >> 2) & 0x3
+	padding := int((header[2] >> 1) & 0x1)
+
+	mpeg1 := 0
+	if version == 3 {
+		mpeg1 = 1
+	}
+	bitrate := mp3BitrateKbps[mpeg1][bitrateIndex] * 1000
+	sampleRate := mp3SampleRates[version][sampleIndex]
+	if bitrate == 0 || sampleRate == 0 {
+		return 0, fmt.Errorf("invalid bitrate/sample rate indexes %d/%d", bitrateIndex, sampleIndex)
+	}
+
+	// Layer III frames hold 1152 samples under MPEG-1 and 576 under
+	// MPEG-2/2.5; dividing by 8 bits gives the 144/72 length coefficients.
+	coefficient := 144
+	if mpeg1 == 0 {
+		coefficient = 72
+	}
+	return coefficient*bitrate/sampleRate + padding, nil
+}
+
+// MP3Validator checks that a buffer is a sequence of complete, contiguous
+// MP3 frames.
+type MP3Validator struct{}
+
+// Validate walks the buffer frame by frame. It fails on a missing sync word,
+// an undecodable header, or a truncated final frame.
+func (MP3Validator) Validate(data []byte) error {
+	if len(data) == 0 {
+		return fmt.Errorf("empty mp3 buffer")
+	}
+	offset := 0
+	for offset < len(data) {
+		frameLen, err := mp3FrameLength(data[offset:])
+		if err != nil {
+			return fmt.Errorf("frame at offset %d: %w", offset, err)
+		}
+		if offset+frameLen > len(data) {
+			return fmt.Errorf("frame at offset %d truncated: need %d bytes, have %d",
+				offset, frameLen, len(data)-offset)
+		}
+		offset += frameLen
+	}
+	return nil
+}
+
+// ExtractCompleteFrames splits a buffer into the leading run of complete MP3
+// frames and whatever partial frame (or unparseable tail) follows. Callers
+// stream the complete part and carry the remainder into the next chunk.
+func ExtractCompleteFrames(data []byte) (complete []byte, remainder []byte) {
+	offset := 0
+	for offset < len(data) {
+		frameLen, err := mp3FrameLength(data[offset:])
+		if err != nil || offset+frameLen > len(data) {
+			break
+		}
+		offset += frameLen
+	}
+	return data[:offset], data[offset:]
+}
@@ -0,0 +1,83 @@
+package audio
+
+import (
+	"bytes"
+	"testing"
+)
+
+// testFrame builds one synthetic MPEG-1 Layer III frame: 128 kbps at
+// 44.1 kHz, which works out to 417 bytes.
+func testFrame(fill byte) []byte {
+	frame := bytes.Repeat([]byte{fill}, 417)
+	frame[0] = 0xFF
+	frame[1] = 0xFB
+	frame[2] = 0x90
+	frame[3] = 0x00
+	return frame
+}
+
+func TestExtractCompleteFramesAtBoundary(t *testing.T) {
+	stream := append(testFrame(0xAA), testFrame(0xBB)...)
+
+	// Split mid-way through the second frame, as a provider chunk boundary
+	// would.
+	first, second := stream[:600], stream[600:]
+
+	complete, remainder := ExtractCompleteFrames(first)
+	if len(complete) != 417 {
+		t.Fatalf("complete = %d bytes, want one 417-byte frame", len(complete))
+	}
+	if len(remainder) != 600-417 {
+		t.Fatalf("remainder = %d bytes, want %d", len(remainder), 600-417)
+	}
+
+	// The next chunk completes the held-back frame.
+	complete2, remainder2 := ExtractCompleteFrames(append(remainder, second...))
+	if len(complete2) != 417 {
+		t.Fatalf("second extraction = %d bytes, want 417", len(complete2))
+	}
+	if len(remainder2) != 0 {
+		t.Fatalf("second remainder = %d bytes, want 0", len(remainder2))
+	}
+	if !bytes.Equal(append(complete, complete2...), stream) {
+		t.Error("reassembled frames differ from the original stream")
+	}
+}
+
+func TestExtractCompleteFramesExactBoundary(t *testing.T) {
+	stream := append(testFrame(0xAA), testFrame(0xBB)...)
+	complete, remainder := ExtractCompleteFrames(stream)
+	if !bytes.Equal(complete, stream) {
+		t.Errorf("complete = %d bytes, want whole %d-byte stream", len(complete), len(stream))
+	}
+	if len(remainder) != 0 {
+		t.Errorf("remainder = %d bytes, want 0", len(remainder))
+	}
+}
+
+func TestMP3ValidatorValid(t *testing.T) {
+	var v MP3Validator
+	if err := v.Validate(append(testFrame(0xAA), testFrame(0xBB)...)); err != nil {
+		t.Errorf("Validate(two frames) = %v, want nil", err)
+	}
+}
+
+func TestMP3ValidatorRejects(t *testing.T) {
+	var v MP3Validator
+	cases := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", nil},
+		{"no sync word", []byte{0x00, 0x01, 0x02, 0x03}},
+		{"truncated frame", testFrame(0xAA)[:100]},
+		{"garbage after frame", append(testFrame(0xAA), 0x00, 0x01)},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := v.Validate(tc.data); err == nil {
+				t.Error("Validate accepted invalid data")
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package audio
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+)
+
+// DefaultOpusBitrateKbps is the Opus target bitrate for synthesized speech.
+// 24 kbps is transparent for a single voice and roughly a third the size of
+// the providers' MP3 output.
+const DefaultOpusBitrateKbps = 24
+
+// opusChunkBytes caps the encoded bytes delivered per chunk. Reads return as
+// soon as the encoder flushes a page, so chunks are usually smaller; the cap
+// just bounds per-message allocation.
+const opusChunkBytes = 4 * 1024
+
+// OpusTranscoder re-encodes synthesized audio to Opus in an Ogg container,
+// for clients that negotiated the smaller format. Encoded pages are emitted
+// as the encoder produces them, so delivery overlaps with transcoding
+// instead of waiting for the whole clip.
+type OpusTranscoder struct {
+	converter *Converter
+	// BitrateKbps is the Opus target bitrate; 0 means
+	// DefaultOpusBitrateKbps.
+	BitrateKbps int
+}
+
+// NewOpusTranscoder creates a transcoder sharing the converter's ffmpeg
+// setting.
+func NewOpusTranscoder(c *Converter) *OpusTranscoder {
+	return &OpusTranscoder{converter: c}
+}
+
+// Transcode re-encodes an audio clip (any container ffmpeg understands) to
+// Opus/Ogg, delivering the encoded bytes in chunks as they become available.
+// The chunk channel is closed at end of stream; at most one error is sent on
+// the error channel, and chunks delivered before the error are still a valid
+// stream prefix.
+func (t *OpusTranscoder) Transcode(ctx context.Context, in io.Reader) (<-chan []byte, <-chan error) {
+	chunks := make(chan []byte)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(chunks)
+		if err := t.transcode(ctx, in, chunks); err != nil {
+			errs <- err
+		}
+	}()
+	return chunks, errs
+}
+
+func (t *OpusTranscoder) transcode(ctx context.Context, in io.Reader, chunks chan<- []byte) error {
+	bitrate := t.BitrateKbps
+	if bitrate == 0 {
+		bitrate = DefaultOpusBitrateKbps
+	}
+	cmd := exec.CommandContext(ctx, t.converter.FFmpegPath,
+		"-i", "pipe:0",
+		"-c:a", "libopus",
+		"-b:a", fmt.Sprintf("%dk", bitrate),
+		"-ac", "1",
+		"-f", "ogg",
+		"pipe:1",
+	)
+	cmd.Stdin = in
+	var errBuf bytes.Buffer
+	cmd.Stderr = &errBuf
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("start ffmpeg: %w", err)
+	}
+
+	emitErr := emitAvailable(ctx, stdout, chunks)
+	if err := cmd.Wait(); err != nil && emitErr == nil {
+		return fmt.Errorf("ffmpeg opus encode failed: %w (%s)", err, errBuf.String())
+	}
+	return emitErr
+}
+
+// emitAvailable forwards whatever the reader has, as soon as it has it, in
+// pieces of at most opusChunkBytes. Unlike emitChunks it never waits to fill
+// a chunk: encoded output has no fixed frame size and first-byte latency
+// matters more than even sizing.
+func emitAvailable(ctx context.Context, r io.Reader, chunks chan<- []byte) error {
+	buf := make([]byte, opusChunkBytes)
+	for {
+		n, err := r.Read(buf)
+		if n > 0 {
+			chunk := make([]byte, n)
+			copy(chunk, buf[:n])
+			select {
+			case chunks <- chunk:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		switch err {
+		case nil:
+		case io.EOF:
+			return nil
+		default:
+			return fmt.Errorf("read encoded stream: %w", err)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package audio
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// fakeFFmpeg installs a stand-in ffmpeg that copies stdin to stdout, so the
+// chunked plumbing is testable without a real encoder.
+func fakeFFmpeg(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "ffmpeg")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\ncat\n"), 0o755); err != nil {
+		t.Fatalf("write fake ffmpeg: %v", err)
+	}
+	return path
+}
+
+func TestTranscodeEmitsChunksAsProduced(t *testing.T) {
+	c := NewConverter()
+	c.FFmpegPath = fakeFFmpeg(t)
+	tr := NewOpusTranscoder(c)
+
+	clip := bytes.Repeat([]byte("synthesized-audio"), 2000)
+	chunks, errs := tr.Transcode(context.Background(), bytes.NewReader(clip))
+	var got []byte
+	count := 0
+	for chunk := range chunks {
+		if len(chunk) > opusChunkBytes {
+			t.Errorf("chunk of %d bytes exceeds the %d byte cap", len(chunk), opusChunkBytes)
+		}
+		got = append(got, chunk...)
+		count++
+	}
+	select {
+	case err := <-errs:
+		t.Fatalf("Transcode: %v", err)
+	default:
+	}
+	if !bytes.Equal(got, clip) {
+		t.Errorf("reassembled output is %d bytes, want the %d input bytes back", len(got), len(clip))
+	}
+	if count < 2 {
+		t.Errorf("clip arrived in %d chunk(s), want it split across several", count)
+	}
+}
+
+func TestTranscodeReportsMissingEncoder(t *testing.T) {
+	c := NewConverter()
+	c.FFmpegPath = filepath.Join(t.TempDir(), "missing-ffmpeg")
+	tr := NewOpusTranscoder(c)
+
+	chunks, errs := tr.Transcode(context.Background(), bytes.NewReader([]byte("clip")))
+	for range chunks {
+		t.Error("got a chunk from a missing encoder")
+	}
+	if err := <-errs; err == nil {
+		t.Fatal("Transcode reported no error without an encoder")
+	}
+}
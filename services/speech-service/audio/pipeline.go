@@ -0,0 +1,59 @@
+package audio
+
+import (
+	"encoding/binary"
+)
+
+// Filter transforms a PCM buffer in the processing pipeline. Filters must
+// treat the input as 16 kHz mono 16-bit little-endian PCM.
+type Filter interface {
+	Name() string
+	Apply(pcm []byte) []byte
+}
+
+// Pipeline applies a sequence of filters to PCM audio before it is handed to
+// the recognition providers.
+type Pipeline struct {
+	filters []Filter
+}
+
+// NewPipeline builds a pipeline from the given filters, applied in order.
+func NewPipeline(filters ...Filter) *Pipeline {
+	return &Pipeline{filters: filters}
+}
+
+// Append adds a filter to the end of the pipeline.
+func (p *Pipeline) Append(f Filter) {
+	p.filters = append(p.filters, f)
+}
+
+// Process runs the PCM buffer through every filter in order.
+func (p *Pipeline) Process(pcm []byte) []byte {
+	for _, f := range p.filters {
+		pcm = f.Apply(pcm)
+	}
+	return pcm
+}
+
+// NoiseGate zeroes samples below a fixed amplitude threshold, removing low
+// level background hiss between words.
+type NoiseGate struct {
+	Threshold int16
+}
+
+// Name implements Filter.
+func (g *NoiseGate) Name() string { return "noise_gate" }
+
+// Apply implements Filter.
+func (g *NoiseGate) Apply(pcm []byte) []byte {
+	out := make([]byte, len(pcm))
+	copy(out, pcm)
+	for i := 0; i+1 < len(out); i += 2 {
+		sample := int16(binary.LittleEndian.Uint16(out[i : i+2]))
+		if sample > -g.Threshold && sample < g.Threshold {
+			out[i] = 0
+			out[i+1] = 0
+		}
+	}
+	return out
+}
@@ -0,0 +1,224 @@
+package audio
+
+import "math"
+
+// ComparisonResult scores how closely the learner's prosody tracks a model
+// rendition of the same text. Each value is a Pearson correlation in [-1, 1]:
+// 1 means the contours move together, 0 means they are unrelated.
+type ComparisonResult struct {
+	// PitchCorrelation compares the fundamental frequency contours.
+	PitchCorrelation float64
+	// RhythmCorrelation compares the inter-word pause patterns.
+	RhythmCorrelation float64
+	// StressCorrelation compares relative loudness, approximating which
+	// syllables carried stress.
+	StressCorrelation float64
+}
+
+const (
+	// prosodyFrameMs is the analysis frame length; 25 ms is short enough to
+	// resolve syllables and long enough to hold two pitch periods at 80 Hz.
+	prosodyFrameMs = 25
+	// prosodyTrackLen is the common length feature tracks are resampled to
+	// before correlating, since the two recordings rarely have equal duration.
+	prosodyTrackLen = 64
+	// yinThreshold is the cumulative mean normalized difference below which a
+	// lag is accepted as the pitch period (the YIN paper suggests 0.1-0.15).
+	yinThreshold = 0.15
+	// minPitchHz and maxPitchHz bound the pitch search to the spoken voice
+	// range.
+	minPitchHz = 60
+	maxPitchHz = 400
+	// prosodySilenceRMS is the int16-scale RMS below which a frame counts as
+	// a pause.
+	prosodySilenceRMS = 300
+)
+
+// PronunciationComparator extracts pitch, rhythm and stress contours from two
+// recordings of the same text and reports how well they correlate. It is a
+// demonstration aid, not a score: the result shows where prosody differs, the
+// ISE result says whether the pronunciation was right.
+type PronunciationComparator struct {
+	sampleRate int
+}
+
+// NewPronunciationComparator returns a comparator for the pipeline's
+// canonical 16 kHz mono PCM.
+func NewPronunciationComparator() *PronunciationComparator {
+	return &PronunciationComparator{sampleRate: DefaultSampleRate}
+}
+
+// Compare correlates the prosodic contours of a reference rendition and the
+// learner's recording, both raw 16-bit mono PCM. Recordings shorter than one
+// analysis frame yield the zero result.
+func (p *PronunciationComparator) Compare(reference, learner []byte) ComparisonResult {
+	ref := pcmToFloat(reference)
+	lrn := pcmToFloat(learner)
+	frame := p.sampleRate * prosodyFrameMs / 1000
+	if len(ref) < frame || len(lrn) < frame {
+		return ComparisonResult{}
+	}
+	return ComparisonResult{
+		PitchCorrelation:  trackCorrelation(pitchTrack(ref, frame, p.sampleRate), pitchTrack(lrn, frame, p.sampleRate)),
+		RhythmCorrelation: trackCorrelation(pauseTrack(ref, frame), pauseTrack(lrn, frame)),
+		StressCorrelation: trackCorrelation(stressTrack(ref, frame), stressTrack(lrn, frame)),
+	}
+}
+
+// pitchTrack estimates the fundamental frequency per frame; silent or
+// unvoiced frames contribute 0 so pauses do not fake agreement.
+func pitchTrack(samples []float64, frame, sampleRate int) []float64 {
+	track := make([]float64, 0, len(samples)/frame)
+	for start := 0; start+2*frame <= len(samples); start += frame {
+		// Pitch needs a window longer than one period at minPitchHz, so each
+		// estimate looks one frame ahead.
+		window := samples[start : start+2*frame]
+		if frameRMS(samples[start:start+frame]) < prosodySilenceRMS {
+			track = append(track, 0)
+			continue
+		}
+		track = append(track, yinPitch(window, sampleRate))
+	}
+	return track
+}
+
+// yinPitch implements the core of the YIN estimator: the cumulative mean
+// normalized difference over candidate lags, taking the first lag under the
+// threshold or the global minimum as the period.
+func yinPitch(window []float64, sampleRate int) float64 {
+	minLag := sampleRate / maxPitchHz
+	maxLag := sampleRate / minPitchHz
+	if maxLag >= len(window) {
+		maxLag = len(window) - 1
+	}
+	if minLag < 2 || minLag >= maxLag {
+		return 0
+	}
+
+	diff := make([]float64, maxLag+1)
+	for lag := minLag; lag <= maxLag; lag++ {
+		var d float64
+		for i := 0; i+lag < len(window); i++ {
+			delta := window[i] - window[i+lag]
+			d += delta * delta
+		}
+		diff[lag] = d
+	}
+
+	var cumulative float64
+	bestLag, bestValue := 0, math.Inf(1)
+	for lag := minLag; lag <= maxLag; lag++ {
+		cumulative += diff[lag]
+		if cumulative == 0 {
+			continue
+		}
+		normalized := diff[lag] * float64(lag-minLag+1) / cumulative
+		if normalized < yinThreshold {
+			return float64(sampleRate) / float64(lag)
+		}
+		if normalized < bestValue {
+			bestLag, bestValue = lag, normalized
+		}
+	}
+	if bestLag == 0 {
+		return 0
+	}
+	return float64(sampleRate) / float64(bestLag)
+}
+
+// pauseTrack marks each frame as pause (1) or speech (0), capturing the
+// inter-word pause pattern the rhythm comparison runs on.
+func pauseTrack(samples []float64, frame int) []float64 {
+	track := make([]float64, 0, len(samples)/frame)
+	for start := 0; start+frame <= len(samples); start += frame {
+		if frameRMS(samples[start:start+frame]) < prosodySilenceRMS {
+			track = append(track, 1)
+		} else {
+			track = append(track, 0)
+		}
+	}
+	return track
+}
+
+// stressTrack is the per-frame RMS relative to the utterance's mean loudness,
+// so a quiet speaker and a loud one produce comparable contours.
+func stressTrack(samples []float64, frame int) []float64 {
+	track := make([]float64, 0, len(samples)/frame)
+	var mean float64
+	for start := 0; start+frame <= len(samples); start += frame {
+		rms := frameRMS(samples[start : start+frame])
+		track = append(track, rms)
+		mean += rms
+	}
+	if len(track) == 0 || mean == 0 {
+		return track
+	}
+	mean /= float64(len(track))
+	for i := range track {
+		track[i] /= mean
+	}
+	return track
+}
+
+func frameRMS(samples []float64) float64 {
+	var sum float64
+	for _, s := range samples {
+		sum += s * s
+	}
+	return math.Sqrt(sum / float64(len(samples)))
+}
+
+// trackCorrelation resamples both tracks to a common length and returns their
+// Pearson correlation; a track with no variance (flat pitch, no pauses)
+// correlates as 0.
+func trackCorrelation(a, b []float64) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	x := resampleTrack(a, prosodyTrackLen)
+	y := resampleTrack(b, prosodyTrackLen)
+
+	var meanX, meanY float64
+	for i := range x {
+		meanX += x[i]
+		meanY += y[i]
+	}
+	meanX /= float64(len(x))
+	meanY /= float64(len(y))
+
+	var cov, varX, varY float64
+	for i := range x {
+		dx, dy := x[i]-meanX, y[i]-meanY
+		cov += dx * dy
+		varX += dx * dx
+		varY += dy * dy
+	}
+	if varX == 0 || varY == 0 {
+		return 0
+	}
+	return cov / math.Sqrt(varX*varY)
+}
+
+// resampleTrack stretches or compresses a track to n points by linear
+// interpolation.
+func resampleTrack(track []float64, n int) []float64 {
+	out := make([]float64, n)
+	if len(track) == 1 {
+		for i := range out {
+			out[i] = track[0]
+		}
+		return out
+	}
+	scale := float64(len(track)-1) / float64(n-1)
+	for i := range out {
+		pos := float64(i) * scale
+		j := int(pos)
+		if j >= len(track)-1 {
+			out[i] = track[len(track)-1]
+			continue
+		}
+		frac := pos - float64(j)
+		out[i] = track[j]*(1-frac) + track[j+1]*frac
+	}
+	return out
+}
@@ -0,0 +1,113 @@
+package audio
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+// prosodySegment is one stretch of synthetic speech: a tone at hz (0 for a
+// pause) with the given amplitude and duration.
+type prosodySegment struct {
+	hz  float64
+	amp float64
+	ms  int
+}
+
+// prosodyPCM renders segments as 16 kHz mono PCM.
+func prosodyPCM(segments ...prosodySegment) []byte {
+	var pcm []byte
+	for _, seg := range segments {
+		n := DefaultSampleRate * seg.ms / 1000
+		buf := make([]byte, n*BytesPerSample)
+		if seg.hz > 0 {
+			for i := 0; i < n; i++ {
+				v := seg.amp * math.Sin(2*math.Pi*seg.hz*float64(i)/DefaultSampleRate)
+				binary.LittleEndian.PutUint16(buf[2*i:], uint16(int16(v)))
+			}
+		}
+		pcm = append(pcm, buf...)
+	}
+	return pcm
+}
+
+// sweepPCM renders a tone gliding from fromHz to toHz.
+func sweepPCM(fromHz, toHz, amp float64, ms int) []byte {
+	n := DefaultSampleRate * ms / 1000
+	buf := make([]byte, n*BytesPerSample)
+	var phase float64
+	for i := 0; i < n; i++ {
+		hz := fromHz + (toHz-fromHz)*float64(i)/float64(n)
+		phase += 2 * math.Pi * hz / DefaultSampleRate
+		binary.LittleEndian.PutUint16(buf[2*i:], uint16(int16(amp*math.Sin(phase))))
+	}
+	return buf
+}
+
+func TestCompareIdenticalRecordings(t *testing.T) {
+	pcm := prosodyPCM(
+		prosodySegment{hz: 150, amp: 12000, ms: 300},
+		prosodySegment{ms: 150},
+		prosodySegment{hz: 220, amp: 5000, ms: 300},
+	)
+	result := NewPronunciationComparator().Compare(pcm, pcm)
+	if result.PitchCorrelation < 0.95 {
+		t.Errorf("PitchCorrelation = %.3f, want ~1 for identical audio", result.PitchCorrelation)
+	}
+	if result.RhythmCorrelation < 0.95 {
+		t.Errorf("RhythmCorrelation = %.3f, want ~1 for identical audio", result.RhythmCorrelation)
+	}
+	if result.StressCorrelation < 0.95 {
+		t.Errorf("StressCorrelation = %.3f, want ~1 for identical audio", result.StressCorrelation)
+	}
+}
+
+func TestCompareOppositePitchContours(t *testing.T) {
+	rising := sweepPCM(100, 300, 10000, 800)
+	falling := sweepPCM(300, 100, 10000, 800)
+	result := NewPronunciationComparator().Compare(rising, falling)
+	if result.PitchCorrelation > -0.5 {
+		t.Errorf("PitchCorrelation = %.3f, want strongly negative for opposite sweeps", result.PitchCorrelation)
+	}
+}
+
+func TestCompareDifferentPausePatterns(t *testing.T) {
+	pauseMiddle := prosodyPCM(
+		prosodySegment{hz: 180, amp: 10000, ms: 400},
+		prosodySegment{ms: 200},
+		prosodySegment{hz: 180, amp: 10000, ms: 400},
+	)
+	pauseFront := prosodyPCM(
+		prosodySegment{ms: 200},
+		prosodySegment{hz: 180, amp: 10000, ms: 800},
+	)
+	result := NewPronunciationComparator().Compare(pauseMiddle, pauseFront)
+	if result.RhythmCorrelation > 0.3 {
+		t.Errorf("RhythmCorrelation = %.3f, want low for different pause placement", result.RhythmCorrelation)
+	}
+}
+
+func TestCompareSwappedStressPlacement(t *testing.T) {
+	frontStress := prosodyPCM(
+		prosodySegment{hz: 180, amp: 12000, ms: 400},
+		prosodySegment{hz: 180, amp: 3000, ms: 400},
+	)
+	backStress := prosodyPCM(
+		prosodySegment{hz: 180, amp: 3000, ms: 400},
+		prosodySegment{hz: 180, amp: 12000, ms: 400},
+	)
+	result := NewPronunciationComparator().Compare(frontStress, backStress)
+	if result.StressCorrelation > -0.5 {
+		t.Errorf("StressCorrelation = %.3f, want strongly negative for swapped stress", result.StressCorrelation)
+	}
+}
+
+func TestCompareTooShortReturnsZero(t *testing.T) {
+	long := prosodyPCM(prosodySegment{hz: 180, amp: 10000, ms: 200})
+	if result := NewPronunciationComparator().Compare(nil, long); result != (ComparisonResult{}) {
+		t.Errorf("Compare(nil, audio) = %+v, want zero result", result)
+	}
+	if result := NewPronunciationComparator().Compare(long, []byte{0, 0}); result != (ComparisonResult{}) {
+		t.Errorf("Compare(audio, tiny) = %+v, want zero result", result)
+	}
+}
@@ -0,0 +1,45 @@
+package audio
+
+import "math"
+
+// clipSampleLevel is the absolute int16 amplitude at which a sample counts
+// as clipped. Slightly under the rail so codecs that shave the extremes off
+// a squared-off wave still register.
+const clipSampleLevel = 32700
+
+// QualityReport summarizes one utterance's converted PCM, used to reject
+// silent recordings and flag clipped ones before any provider is called.
+type QualityReport struct {
+	// RMS and Peak are amplitude levels as fractions of full scale (1.0 is
+	// a rail-to-rail square wave).
+	RMS  float64
+	Peak float64
+	// ClippingRatio is the fraction of samples at or near the int16 rails.
+	ClippingRatio float64
+}
+
+// AnalyzeQuality measures the buffer in a single pass. An empty buffer
+// reports all zeros, which reads as silence.
+func AnalyzeQuality(pcm []byte) QualityReport {
+	samples := pcmToFloat(pcm)
+	if len(samples) == 0 {
+		return QualityReport{}
+	}
+	var sumSquares, peak float64
+	clipped := 0
+	for _, v := range samples {
+		abs := math.Abs(v)
+		sumSquares += v * v
+		if abs > peak {
+			peak = abs
+		}
+		if abs >= clipSampleLevel {
+			clipped++
+		}
+	}
+	return QualityReport{
+		RMS:           math.Sqrt(sumSquares/float64(len(samples))) / 32768,
+		Peak:          peak / 32768,
+		ClippingRatio: float64(clipped) / float64(len(samples)),
+	}
+}
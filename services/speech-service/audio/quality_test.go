@@ -0,0 +1,62 @@
+package audio
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+// qualityPCM builds a buffer of int16 samples at a fixed amplitude.
+func qualityPCM(n int, amplitude int16) []byte {
+	pcm := make([]byte, n*2)
+	for i := 0; i < n; i++ {
+		binary.LittleEndian.PutUint16(pcm[2*i:], uint16(amplitude))
+	}
+	return pcm
+}
+
+func TestAnalyzeQualitySilence(t *testing.T) {
+	report := AnalyzeQuality(qualityPCM(16000, 0))
+	if report.RMS != 0 || report.Peak != 0 || report.ClippingRatio != 0 {
+		t.Errorf("silent buffer report = %+v, want all zeros", report)
+	}
+}
+
+func TestAnalyzeQualityEmptyBuffer(t *testing.T) {
+	if report := AnalyzeQuality(nil); report != (QualityReport{}) {
+		t.Errorf("empty buffer report = %+v, want zero value", report)
+	}
+}
+
+func TestAnalyzeQualitySpeechLevels(t *testing.T) {
+	report := AnalyzeQuality(qualityPCM(16000, 8192))
+	want := 8192.0 / 32768
+	if math.Abs(report.RMS-want) > 1e-9 {
+		t.Errorf("RMS = %v, want %v", report.RMS, want)
+	}
+	if math.Abs(report.Peak-want) > 1e-9 {
+		t.Errorf("Peak = %v, want %v", report.Peak, want)
+	}
+	if report.ClippingRatio != 0 {
+		t.Errorf("ClippingRatio = %v, want 0", report.ClippingRatio)
+	}
+}
+
+func TestAnalyzeQualityClippedBuffer(t *testing.T) {
+	report := AnalyzeQuality(qualityPCM(16000, math.MaxInt16))
+	if report.ClippingRatio != 1 {
+		t.Errorf("ClippingRatio = %v, want 1 for a railed buffer", report.ClippingRatio)
+	}
+	if report.Peak < 0.99 {
+		t.Errorf("Peak = %v, want full scale", report.Peak)
+	}
+}
+
+func TestAnalyzeQualityPartialClipping(t *testing.T) {
+	// Half the samples at the rail, half at speech level.
+	pcm := append(qualityPCM(8000, math.MaxInt16), qualityPCM(8000, 4000)...)
+	report := AnalyzeQuality(pcm)
+	if math.Abs(report.ClippingRatio-0.5) > 1e-9 {
+		t.Errorf("ClippingRatio = %v, want 0.5", report.ClippingRatio)
+	}
+}
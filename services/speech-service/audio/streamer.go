@@ -0,0 +1,127 @@
+package audio
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"time"
+)
+
+// AudioFormat identifies the container of an uploaded audio stream.
+type AudioFormat string
+
+// Formats the streamer can decode.
+const (
+	FormatWebM AudioFormat = "webm"
+	FormatOgg  AudioFormat = "ogg"
+	FormatPCM  AudioFormat = "pcm"
+	FormatWAV  AudioFormat = "wav"
+)
+
+// Streamed chunk sizing: 40 ms of 16 kHz mono 16-bit PCM per chunk.
+const (
+	ChunkDuration = 40 * time.Millisecond
+	ChunkBytes    = DefaultSampleRate * BytesPerSample * 40 / 1000
+)
+
+// wavHeaderBytes is the RIFF/WAVE header the streamer skips over.
+const wavHeaderBytes = 44
+
+// AudioStreamer converts an incoming audio stream to PCM chunks as bytes
+// arrive, so recognition can start before the whole utterance is buffered.
+type AudioStreamer struct {
+	converter *Converter
+}
+
+// NewAudioStreamer creates a streamer sharing the converter's ffmpeg and
+// sample rate settings.
+func NewAudioStreamer(c *Converter) *AudioStreamer {
+	return &AudioStreamer{converter: c}
+}
+
+// Stream decodes audioReader to 16 kHz mono 16-bit PCM and delivers it in
+// 40 ms chunks as they become available. The chunk channel is closed at end
+// of stream; at most one error is sent on the error channel, and chunks
+// delivered before the error are still valid audio.
+func (s *AudioStreamer) Stream(ctx context.Context, audioReader io.Reader, format AudioFormat) (<-chan []byte, <-chan error) {
+	chunks := make(chan []byte)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(chunks)
+		if err := s.stream(ctx, audioReader, format, chunks); err != nil {
+			errs <- err
+		}
+	}()
+	return chunks, errs
+}
+
+func (s *AudioStreamer) stream(ctx context.Context, audioReader io.Reader, format AudioFormat, chunks chan<- []byte) error {
+	switch format {
+	case FormatPCM:
+		return emitChunks(ctx, audioReader, chunks)
+	case FormatWAV:
+		if _, err := io.CopyN(io.Discard, audioReader, wavHeaderBytes); err != nil {
+			return fmt.Errorf("skip wav header: %w", err)
+		}
+		return emitChunks(ctx, audioReader, chunks)
+	case FormatWebM, FormatOgg:
+		return s.streamDecoded(ctx, audioReader, chunks)
+	default:
+		return fmt.Errorf("unsupported audio format: %s", format)
+	}
+}
+
+// streamDecoded runs ffmpeg with the container stream on stdin and emits PCM
+// chunks from stdout as the decoder produces them.
+func (s *AudioStreamer) streamDecoded(ctx context.Context, audioReader io.Reader, chunks chan<- []byte) error {
+	cmd := exec.CommandContext(ctx, s.converter.FFmpegPath,
+		"-i", "pipe:0",
+		"-f", "s16le",
+		"-acodec", "pcm_s16le",
+		"-ar", fmt.Sprintf("%d", s.converter.SampleRate),
+		"-ac", "1",
+		"pipe:1",
+	)
+	cmd.Stdin = audioReader
+	var errBuf bytes.Buffer
+	cmd.Stderr = &errBuf
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("start ffmpeg: %w", err)
+	}
+
+	emitErr := emitChunks(ctx, stdout, chunks)
+	if err := cmd.Wait(); err != nil && emitErr == nil {
+		return fmt.Errorf("ffmpeg decode failed: %w (%s)", err, errBuf.String())
+	}
+	return emitErr
+}
+
+// emitChunks copies pcm to the channel in ChunkBytes pieces, ending cleanly
+// at EOF. A short final read is delivered as a partial chunk.
+func emitChunks(ctx context.Context, pcm io.Reader, chunks chan<- []byte) error {
+	for {
+		buf := make([]byte, ChunkBytes)
+		n, err := io.ReadFull(pcm, buf)
+		if n > 0 {
+			select {
+			case chunks <- buf[:n]:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		switch err {
+		case nil:
+		case io.EOF, io.ErrUnexpectedEOF:
+			return nil
+		default:
+			return fmt.Errorf("read audio stream: %w", err)
+		}
+	}
+}
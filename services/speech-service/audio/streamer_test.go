@@ -0,0 +1,89 @@
+package audio
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+// collect drains the stream, returning the chunks and the error, if any.
+func collect(t *testing.T, chunks <-chan []byte, errs <-chan error) ([][]byte, error) {
+	t.Helper()
+	var got [][]byte
+	for chunk := range chunks {
+		got = append(got, chunk)
+	}
+	select {
+	case err := <-errs:
+		return got, err
+	default:
+		return got, nil
+	}
+}
+
+func TestStreamPCMChunking(t *testing.T) {
+	s := NewAudioStreamer(NewConverter())
+	pcm := make([]byte, 2*ChunkBytes+440)
+
+	chunks, errs := s.Stream(context.Background(), bytes.NewReader(pcm), FormatPCM)
+	got, err := collect(t, chunks, errs)
+	if err != nil {
+		t.Fatalf("Stream: %v", err)
+	}
+	want := []int{ChunkBytes, ChunkBytes, 440}
+	if len(got) != len(want) {
+		t.Fatalf("got %d chunks, want %d", len(got), len(want))
+	}
+	for i, size := range want {
+		if len(got[i]) != size {
+			t.Errorf("chunk %d = %d bytes, want %d", i, len(got[i]), size)
+		}
+	}
+}
+
+func TestStreamWAVSkipsHeader(t *testing.T) {
+	c := NewConverter()
+	s := NewAudioStreamer(c)
+	pcm := []byte(strings.Repeat("ab", 100))
+	wav := c.ConvertPCMToWAV(pcm)
+
+	chunks, errs := s.Stream(context.Background(), bytes.NewReader(wav), FormatWAV)
+	got, err := collect(t, chunks, errs)
+	if err != nil {
+		t.Fatalf("Stream: %v", err)
+	}
+	if len(got) != 1 || !bytes.Equal(got[0], pcm) {
+		t.Errorf("streamed WAV delivered %d chunks, want the raw samples back", len(got))
+	}
+}
+
+func TestStreamUnsupportedFormat(t *testing.T) {
+	s := NewAudioStreamer(NewConverter())
+	chunks, errs := s.Stream(context.Background(), bytes.NewReader(nil), AudioFormat("flac"))
+	got, err := collect(t, chunks, errs)
+	if err == nil || !strings.Contains(err.Error(), "unsupported audio format") {
+		t.Fatalf("Stream error = %v, want unsupported format", err)
+	}
+	if len(got) != 0 {
+		t.Errorf("got %d chunks for an unsupported format, want none", len(got))
+	}
+}
+
+func TestStreamStopsOnCancel(t *testing.T) {
+	s := NewAudioStreamer(NewConverter())
+	ctx, cancel := context.WithCancel(context.Background())
+	pcm := make([]byte, 10*ChunkBytes)
+
+	chunks, errs := s.Stream(ctx, bytes.NewReader(pcm), FormatPCM)
+	<-chunks
+	cancel()
+	// With no receiver left, the blocked emitter can only take the
+	// cancellation path.
+	if err := <-errs; err != context.Canceled {
+		t.Errorf("Stream error after cancel = %v, want context.Canceled", err)
+	}
+	if _, open := <-chunks; open {
+		t.Error("chunk channel still open after cancellation")
+	}
+}
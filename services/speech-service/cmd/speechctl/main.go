@@ -0,0 +1,259 @@
+// speechctl runs audio files and text through the speech pipeline from the
+// command line, reusing the service implementations directly. It is the
+// quickest way to debug a provider issue without clicking through a browser,
+// and doubles as living documentation of the service APIs.
+//
+// Usage:
+//
+//	speechctl transcribe <file.wav|file.webm>
+//	speechctl synthesize "<text>" [-o out.mp3]
+//	speechctl evaluate <file> --text "reference sentence" [--lang en_us]
+//	speechctl converse <file> [-o reply.mp3]
+//
+// Pass --mock before the subcommand to use offline mock providers.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/config"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/service"
+)
+
+// transcriber, synthesizer, evaluator and responder mirror the service
+// methods the CLI uses, so --mock can swap in offline implementations.
+type transcriber interface {
+	ProcessAudio(req *model.ASRRequest) (*model.ASRResponse, error)
+}
+
+type synthesizer interface {
+	SynthesizeSpeech(req *model.TTSRequest) (*model.TTSResponse, error)
+}
+
+type evaluator interface {
+	EvaluateSpeech(ctx context.Context, req *model.ISERequest) (*model.ISEResponse, error)
+}
+
+type responder interface {
+	GenerateResponse(userText string) (*model.LLMResponse, error)
+}
+
+// providers bundles the pipeline stages behind the CLI-facing interfaces.
+type providers struct {
+	asr   transcriber
+	tts   synthesizer
+	ise   evaluator
+	llm   responder
+	audio *service.AudioService
+}
+
+func main() {
+	mock := flag.Bool("mock", false, "use offline mock providers")
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) < 1 {
+		usage()
+		os.Exit(2)
+	}
+
+	logger := logrus.New()
+	logger.SetOutput(os.Stderr)
+	logger.SetLevel(logrus.WarnLevel)
+
+	p := buildProviders(*mock, logger)
+
+	var err error
+	switch args[0] {
+	case "transcribe":
+		err = runTranscribe(p, args[1:])
+	case "synthesize":
+		err = runSynthesize(p, args[1:])
+	case "evaluate":
+		err = runEvaluate(p, args[1:])
+	case "converse":
+		err = runConverse(p, args[1:])
+	default:
+		usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "speechctl: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, `usage: speechctl [--mock] <command> [args]
+
+commands:
+  transcribe <file>                      run ASR on an audio file
+  synthesize "<text>" [-o out.mp3]       run TTS on a sentence
+  evaluate <file> --text "<reference>"   score pronunciation against a reference
+  converse <file> [-o reply.mp3]         full ASR -> LLM -> TTS round trip`)
+}
+
+func buildProviders(mock bool, logger *logrus.Logger) *providers {
+	if mock {
+		return &providers{
+			asr:   mockASR{},
+			tts:   mockTTS{},
+			ise:   mockISE{},
+			llm:   mockLLM{},
+			audio: service.NewAudioService(config.AudioConfig{}, logger),
+		}
+	}
+	cfg := config.Load()
+	return &providers{
+		asr:   service.NewASRService(cfg.ASR, logger),
+		tts:   service.NewTTSService(cfg.TTS, logger),
+		ise:   service.NewISEService(cfg.ISE, logger),
+		llm:   service.NewLLMService(cfg.LLM, nil, logger),
+		audio: service.NewAudioService(cfg.Audio, logger),
+	}
+}
+
+func runTranscribe(p *providers, args []string) error {
+	if len(args) < 1 {
+		return fmt.Errorf("transcribe: audio file required")
+	}
+	wav, err := loadAsWAV(p, args[0])
+	if err != nil {
+		return err
+	}
+	resp, err := p.asr.ProcessAudio(&model.ASRRequest{
+		AudioData:  wav,
+		Format:     "wav",
+		SampleRate: 16000,
+		Language:   "en-US",
+	})
+	if err != nil {
+		return fmt.Errorf("transcribe: %w", err)
+	}
+	return printJSON(resp)
+}
+
+func runSynthesize(p *providers, args []string) error {
+	fs := flag.NewFlagSet("synthesize", flag.ExitOnError)
+	out := fs.String("o", "out.mp3", "output audio file")
+	if len(args) < 1 {
+		return fmt.Errorf("synthesize: text required")
+	}
+	fs.Parse(args[1:])
+
+	resp, err := p.tts.SynthesizeSpeech(&model.TTSRequest{Text: args[0], Language: "en-US"})
+	if err != nil {
+		return fmt.Errorf("synthesize: %w", err)
+	}
+	if err := os.WriteFile(*out, resp.AudioData, 0o644); err != nil {
+		return fmt.Errorf("write %s: %w", *out, err)
+	}
+	return printJSON(map[string]interface{}{
+		"output":      *out,
+		"format":      resp.Format,
+		"bytes":       len(resp.AudioData),
+		"duration_ms": resp.DurationMs,
+	})
+}
+
+func runEvaluate(p *providers, args []string) error {
+	fs := flag.NewFlagSet("evaluate", flag.ExitOnError)
+	text := fs.String("text", "", "reference sentence (required)")
+	lang := fs.String("lang", "en_us", "evaluation language")
+	if len(args) < 1 {
+		return fmt.Errorf("evaluate: audio file required")
+	}
+	fs.Parse(args[1:])
+	if *text == "" {
+		return fmt.Errorf("evaluate: --text is required")
+	}
+
+	wav, err := loadAsWAV(p, args[0])
+	if err != nil {
+		return err
+	}
+	resp, err := p.ise.EvaluateSpeech(context.Background(), &model.ISERequest{
+		AudioData: wav,
+		Text:      *text,
+		Language:  *lang,
+	})
+	if err != nil {
+		return fmt.Errorf("evaluate: %w", err)
+	}
+	return printJSON(resp)
+}
+
+func runConverse(p *providers, args []string) error {
+	fs := flag.NewFlagSet("converse", flag.ExitOnError)
+	out := fs.String("o", "reply.mp3", "output audio file for the tutor reply")
+	if len(args) < 1 {
+		return fmt.Errorf("converse: audio file required")
+	}
+	fs.Parse(args[1:])
+
+	wav, err := loadAsWAV(p, args[0])
+	if err != nil {
+		return err
+	}
+	asrResp, err := p.asr.ProcessAudio(&model.ASRRequest{
+		AudioData:  wav,
+		Format:     "wav",
+		SampleRate: 16000,
+		Language:   "en-US",
+	})
+	if err != nil {
+		return fmt.Errorf("converse asr: %w", err)
+	}
+	llmResp, err := p.llm.GenerateResponse(asrResp.Text)
+	if err != nil {
+		return fmt.Errorf("converse llm: %w", err)
+	}
+	ttsResp, err := p.tts.SynthesizeSpeech(&model.TTSRequest{Text: llmResp.Text, Language: "en-US"})
+	if err != nil {
+		return fmt.Errorf("converse tts: %w", err)
+	}
+	if err := os.WriteFile(*out, ttsResp.AudioData, 0o644); err != nil {
+		return fmt.Errorf("write %s: %w", *out, err)
+	}
+	return printJSON(map[string]interface{}{
+		"transcript":  asrResp.Text,
+		"confidence":  asrResp.Confidence,
+		"reply":       llmResp.Text,
+		"tokens_used": llmResp.TokensUsed,
+		"output":      *out,
+		"audio_bytes": len(ttsResp.AudioData),
+	})
+}
+
+// loadAsWAV reads an audio file and normalizes it to 16 kHz mono WAV, using
+// the file extension to pick the input format.
+func loadAsWAV(p *providers, path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	format := strings.TrimPrefix(filepath.Ext(path), ".")
+	if format == "" {
+		format = "wav"
+	}
+	wav, err := p.audio.OptimizeAudioForASR(data, format)
+	if err != nil {
+		return nil, fmt.Errorf("convert %s: %w", path, err)
+	}
+	return wav, nil
+}
+
+func printJSON(v interface{}) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+)
+
+// Offline mock providers for --mock smoke tests: deterministic canned results
+// with no network or credentials.
+
+type mockASR struct{}
+
+func (mockASR) ProcessAudio(req *model.ASRRequest) (*model.ASRResponse, error) {
+	return &model.ASRResponse{
+		Text:       "this is a mock transcription",
+		Confidence: 0.95,
+		IsFinal:    true,
+		Language:   req.Language,
+	}, nil
+}
+
+type mockTTS struct{}
+
+func (mockTTS) SynthesizeSpeech(req *model.TTSRequest) (*model.TTSResponse, error) {
+	return &model.TTSResponse{
+		AudioData:  []byte(fmt.Sprintf("mock-audio:%s", req.Text)),
+		Format:     "mp3",
+		DurationMs: int64(len(req.Text)) * 60,
+	}, nil
+}
+
+type mockISE struct{}
+
+func (mockISE) EvaluateSpeech(ctx context.Context, req *model.ISERequest) (*model.ISEResponse, error) {
+	return &model.ISEResponse{
+		OverallScore:   78.5,
+		AccuracyScore:  80.0,
+		FluencyScore:   75.0,
+		IntegrityScore: 82.0,
+		ReferenceText:  req.Text,
+	}, nil
+}
+
+type mockLLM struct{}
+
+func (mockLLM) GenerateResponse(userText string) (*model.LLMResponse, error) {
+	return &model.LLMResponse{
+		Text:       fmt.Sprintf("That's interesting! You said: %q. Tell me more.", userText),
+		TokensUsed: 42,
+	}, nil
+}
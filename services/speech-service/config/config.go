@@ -0,0 +1,652 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Config holds all runtime configuration for the speech service. Values are
+// read from environment variables with sensible development defaults.
+type Config struct {
+	HTTPPort string
+	GRPCPort string
+	// GRPCHealthPort serves the standard grpc.health.v1 service on its own
+	// listener so probes work even when the main server is saturated.
+	GRPCHealthPort string
+	LogLevel       string
+	// AdminToken gates the pprof and runtime diagnostics endpoints under
+	// /admin/debug; empty (the default) disables them.
+	AdminToken string
+	// StaticDir overrides the embedded practice page with an on-disk
+	// directory for development; empty serves the compiled-in assets.
+	StaticDir string
+
+	ASR       ASRConfig
+	Audio     AudioConfig
+	TTS       TTSConfig
+	LLM       LLMConfig
+	ISE       ISEConfig
+	Events    EventsConfig
+	Session   SessionConfig
+	Backend   BackendConfig
+	GRPC      GRPCServerConfig
+	RateLimit RateLimitConfig
+	Recording RecordingConfig
+	SLO       SLOConfig
+	Warmup    WarmupConfig
+	Pipeline  PipelineConfig
+	WebSocket WebSocketConfig
+	Memory    MemoryConfig
+	Scheduler SchedulerConfig
+}
+
+// SchedulerConfig splits provider-bound work into an interactive lane (the
+// conversational stages of short utterances) and a batch lane (evaluations of
+// long recordings and multi-utterance splits), each with its own concurrency
+// budget per provider, so one learner's read-aloud evaluation cannot starve
+// everyone else's quick back-and-forth turns. BatchAudioThreshold is the
+// utterance duration at which a turn's provider work moves to the batch lane;
+// WaitTimeout is how long a queued job waits for a slot before giving up. A
+// lane budget of 0 leaves that lane unbounded.
+type SchedulerConfig struct {
+	ASR                 LaneBudget
+	LLM                 LaneBudget
+	TTS                 LaneBudget
+	ISE                 LaneBudget
+	BatchAudioThreshold time.Duration
+	WaitTimeout         time.Duration
+}
+
+// LaneBudget is one provider's concurrency budget for the two scheduler
+// lanes.
+type LaneBudget struct {
+	Interactive int
+	Batch       int
+}
+
+// MemoryConfig bounds the bytes sessions may hold in process memory — audio
+// buffers and conversation history. SessionBudgetBytes caps one session and
+// rejects further buffering past it; ProcessCeilingBytes is the point at
+// which the largest idle sessions are evicted. 0 disables the respective
+// limit.
+type MemoryConfig struct {
+	SessionBudgetBytes  int64
+	ProcessCeilingBytes int64
+}
+
+// WebSocketConfig tunes the direct WebSocket endpoint's gorilla upgrader.
+// Clients upload utterances as 100-500 KB binary frames, so gorilla's 1 KB
+// default buffers cost needless syscalls and allocation churn; 32 KB matches
+// our audio frame sizes. ReadLimitBytes caps a single incoming frame — an
+// oversized frame closes the connection instead of buffering without bound;
+// 0 leaves frames uncapped.
+type WebSocketConfig struct {
+	ReadBufferBytes   int
+	WriteBufferBytes  int
+	ReadLimitBytes    int64
+	EnableCompression bool
+}
+
+// PipelineConfig switches individual pipeline stages on or off for the
+// deployment. Disabled stages are skipped by the handlers, left out of
+// warm-up and readiness checking, and reported as "disabled" by the health
+// endpoint — a deployment without ISE credentials simply turns the stage off.
+// Connect-time session options can further disable stages but never enable
+// ones the deployment turned off.
+type PipelineConfig struct {
+	ASR         bool
+	LLM         bool
+	TTS         bool
+	ISE         bool
+	Corrections bool
+}
+
+// WarmupConfig gates the optional provider warm-up phase at startup: a
+// handshake against each websocket provider, a one-token LLM completion and a
+// one-word TTS clip, all bounded by Timeout. With Required set, any warm-up
+// failure aborts startup instead of only being logged.
+type WarmupConfig struct {
+	Enabled  bool
+	Required bool
+	Timeout  time.Duration
+}
+
+// BackendConfig selects where the direct WebSocket pipeline's ASR, LLM and
+// TTS stages run.
+type BackendConfig struct {
+	// Mode is "direct" (in-process provider clients, the default) or "grpc"
+	// (thin clients proxying to another speech-service instance over the
+	// shared proto, so this deployment needs no provider credentials).
+	Mode string
+	// GRPCAddr is the upstream speech-service address for grpc mode.
+	GRPCAddr string
+}
+
+// SLOConfig sets per-stage latency budgets for the pipeline. A turn with a
+// stage over budget is logged as a single structured warning carrying all of
+// the turn's timings. 0 disables that stage's check.
+type SLOConfig struct {
+	ASRWarn          time.Duration
+	LLMWarn          time.Duration
+	TTSFirstByteWarn time.Duration
+	TTSWarn          time.Duration
+	ISEWarn          time.Duration
+}
+
+// RateLimitConfig sets per-session request budgets for the pipeline stages.
+// Rates are requests per second; 0 disables the stage's limit.
+type RateLimitConfig struct {
+	AudioPerSecond float64
+	LLMPerSecond   float64
+	TTSPerSecond   float64
+	// Burst is how many requests a session may make back-to-back before the
+	// per-second rates apply.
+	Burst int
+}
+
+// GRPCServerConfig sizes the gRPC server's message limits. A 60-second
+// utterance at 16 kHz 16-bit PCM is close to 2 MB before the WAV header, so
+// the 4 MB gRPC default leaves little headroom.
+type GRPCServerConfig struct {
+	MaxRecvMsgSizeMB int
+	MaxSendMsgSizeMB int
+	// MaxAudioSizeMB bounds a single buffered utterance; anything larger is
+	// rejected before audio conversion is attempted.
+	MaxAudioSizeMB int
+	// KeepaliveMinTime is the minimum ping interval the server tolerates from
+	// clients; KeepaliveTime/KeepaliveTimeout drive the server's own pings.
+	KeepaliveMinTime time.Duration
+	KeepaliveTime    time.Duration
+	KeepaliveTimeout time.Duration
+	// MaxConcurrentStreams caps simultaneous streams per connection.
+	MaxConcurrentStreams int
+	// TLSCertFile/TLSKeyFile enable TLS when both are set.
+	TLSCertFile string
+	TLSKeyFile  string
+	// EnableReflection registers server reflection; development only.
+	EnableReflection bool
+}
+
+// RecordingConfig controls server-side debug recording of session audio.
+// Recording is off by default; it stores user speech, so retention is bounded.
+type RecordingConfig struct {
+	Enabled bool
+	// Dir is the base directory recordings are written under.
+	Dir string
+	// RetentionDays is how long recordings are kept before the cleanup
+	// routine purges them.
+	RetentionDays int
+	// MaxBytesPerSession caps how much audio one opted-in session may store;
+	// further turns are dropped once it is reached. 0 leaves sessions
+	// uncapped.
+	MaxBytesPerSession int64
+}
+
+// SessionConfig controls session snapshot persistence and pause behavior.
+type SessionConfig struct {
+	// SnapshotDir is where per-session snapshot files are written.
+	SnapshotDir string
+	// SnapshotTTL is how long a snapshot stays restorable after the last
+	// update.
+	SnapshotTTL time.Duration
+	// PausedAudioPolicy is "buffer" (hold audio arriving while paused and
+	// process it on resume) or "reject".
+	PausedAudioPolicy string
+	// TeacherToken gates read-only teacher/observer connections to live
+	// sessions; empty (the default) disables them.
+	TeacherToken string
+	// RetryAudioBudgetMB bounds the converted audio retained per session for
+	// the retry control action; 0 disables turn retention entirely.
+	RetryAudioBudgetMB int
+	// MaxSessionDuration caps how long a session may stay open regardless of
+	// activity; 0 disables the limit.
+	MaxSessionDuration time.Duration
+	// TokenSecret signs reconnection tokens that let a dropped client resume
+	// its session; empty (the default) disables token reconnects. TokenTTL
+	// is how long a dropped client has to present the token.
+	TokenSecret string
+	TokenTTL    time.Duration
+	// DeterministicEnabled lets clients opt a session into deterministic mode
+	// (temperature-0 completions, request ids derived from session and turn).
+	// Meant for dev and test environments only; off by default.
+	DeterministicEnabled bool
+}
+
+// AudioConfig controls the optional audio preprocessing filters.
+type AudioConfig struct {
+	// DenoiseEnabled turns on spectral-subtraction denoising ahead of the
+	// noise gate.
+	DenoiseEnabled bool
+	// DenoiseNoiseSampleMs is how much leading audio is treated as
+	// background-only when estimating the noise profile.
+	DenoiseNoiseSampleMs int
+	// AGCEnabled turns on automatic gain control after the noise gate, so
+	// quiet microphones don't drag down ASR confidence.
+	AGCEnabled bool
+	// AGCTargetRMS is the level the AGC normalizes toward, as a fraction of
+	// full scale.
+	AGCTargetRMS float64
+	// AGCMaxGainDB caps the boost applied to very quiet audio.
+	AGCMaxGainDB float64
+	// StreamingMode feeds ASR 40 ms PCM chunks as the utterance decodes
+	// instead of buffering and converting the whole blob first. Off by
+	// default until clients submit audio in chunks; note the filter pipeline
+	// does not run on the streamed path.
+	StreamingMode bool
+	// PreRollMs is how many milliseconds of pre-recording preview audio the
+	// server retains and prepends to the utterance on start_recording, so a
+	// learner who speaks before clicking Record keeps their first syllable.
+	// 0 disables pre-roll.
+	PreRollMs int
+	// SilenceRMS is the full-scale RMS fraction below which an utterance
+	// counts as silence (muted microphone) and skips every provider stage.
+	// 0 disables the check.
+	SilenceRMS float64
+	// ClippingWarnRatio is the fraction of clipped samples above which the
+	// ASR result carries an audio quality warning so the UI can suggest
+	// lowering the mic gain. 0 disables the check.
+	ClippingWarnRatio float64
+	// WorkerPoolSize bounds how many utterances the gRPC handler processes
+	// concurrently; utterances arriving past the pool's queue are rejected
+	// with a service-overloaded error. 0 processes every utterance in its own
+	// goroutines without bound.
+	WorkerPoolSize int
+}
+
+// ASRConfig configures the ByteDance streaming ASR client.
+type ASRConfig struct {
+	AppID   string
+	Token   string
+	Cluster string
+	BaseURL string
+	// MinConfidence is the transcript confidence below which the tutor asks
+	// the learner to repeat instead of answering; 0 disables the check.
+	MinConfidence float64
+	// MinTranscriptWords is the minimum word count for a transcript to be
+	// answered; shorter ones trigger the same clarification request.
+	MinTranscriptWords int
+}
+
+// TTSConfig configures the synthesis stage. The ByteDance fields apply to
+// the default provider; Azure holds the alternative's credentials.
+type TTSConfig struct {
+	// Provider selects the synthesis backend: "bytedance" (the default) or
+	// "azure".
+	Provider  string
+	AppID     string
+	Token     string
+	Cluster   string
+	BaseURL   string
+	VoiceType string
+	Speed     float64
+	// LanguageAutoSelect routes each reply to a voice matching its detected
+	// language instead of always using VoiceType.
+	LanguageAutoSelect bool
+	Azure              AzureTTSConfig
+}
+
+// AzureTTSConfig configures the Azure Cognitive Services TTS REST client,
+// used when TTSConfig.Provider is "azure".
+type AzureTTSConfig struct {
+	// BaseURL overrides the regional endpoint, mainly for tests.
+	BaseURL         string
+	Region          string
+	SubscriptionKey string
+	Voice           string
+	Language        string
+}
+
+// LLMConfig configures the chat completion client.
+type LLMConfig struct {
+	APIKey      string
+	BaseURL     string
+	Model       string
+	MaxTokens   int
+	Temperature float64
+	// DailyTokenLimit caps total tokens per day; 0 disables enforcement.
+	DailyTokenLimit int64
+	// UsageDir is where daily consumption files are written.
+	UsageDir string
+	// CEFRParamsPath locates the per-CEFR-level generation params JSON file.
+	CEFRParamsPath string
+	// MinResponseWords regenerates conversational replies shorter than this
+	// many words once; 0 disables the check.
+	MinResponseWords int
+	// RequireQuestion flags replies without a question mark, since a turn
+	// that asks nothing tends to stall the conversation.
+	RequireQuestion bool
+	// AnimateSpeedWPM paces the reply to direct WebSocket clients as
+	// partial_llm_response chunks at this many words per minute, so a
+	// typewriter animation moves at human typing speed; 0 disables pacing.
+	AnimateSpeedWPM int
+	// BurstThresholdChars skips the pacing for replies shorter than this
+	// many characters, which would otherwise feel artificially slow.
+	BurstThresholdChars int
+}
+
+// ISEConfig configures the iFlytek speech evaluation client.
+type ISEConfig struct {
+	AppID     string
+	APIKey    string
+	APISecret string
+	BaseURL   string
+	// CalibrationPath locates the score calibration bands JSON file.
+	CalibrationPath string
+	// MaxMessageBytes / JSONOverheadBytes describe the endpoint's hard cap on
+	// a single JSON message and the envelope size around the base64 audio;
+	// together they determine the audio chunk size. 0 keeps the defaults.
+	MaxMessageBytes   int
+	JSONOverheadBytes int
+	// Mode is "referenced" (score against the session's reference text, the
+	// default) or "free_speak" (score against the ASR transcript).
+	Mode string
+	// ProsodyComparison synthesizes the reference text after each evaluation
+	// and sends pitch/rhythm/stress correlations against the learner's
+	// recording. Off by default: it costs one extra TTS call per utterance.
+	ProsodyComparison bool
+	// CredentialSource selects how signing credentials are obtained:
+	// "static" (APIKey/APISecret above, the default) or "env" (re-read
+	// ISE_API_KEY and ISE_API_SECRET on every connection, so secret managers
+	// can rotate them without a restart). A non-empty CredentialRefreshURL
+	// overrides both.
+	CredentialSource string
+	// CredentialRefreshURL, when set, is polled every
+	// CredentialRefreshInterval for new signing credentials as a JSON
+	// {"api_key": ..., "api_secret": ...} document.
+	CredentialRefreshURL      string
+	CredentialRefreshInterval time.Duration
+	// CacheEnabled serves repeated evaluations of byte-identical audio
+	// against the same reference text and language from an in-memory LRU
+	// cache instead of re-billing the provider; CacheSize caps how many
+	// results it holds.
+	CacheEnabled bool
+	CacheSize    int
+	// SchedulerMaxConcurrent, when positive, runs evaluations through a
+	// priority scheduler with that many workers, scoring interactive turns
+	// ahead of queued batch work. 0 (the default) calls the provider
+	// directly.
+	SchedulerMaxConcurrent int
+	// ProtocolLogEnabled writes every raw frame exchanged with the provider
+	// to ProtocolLogPath as JSON Lines, for debugging the underdocumented
+	// protocol. Off by default: the log contains full payloads.
+	ProtocolLogEnabled bool
+	ProtocolLogPath    string
+	// Silence tunes the pre-upload silence filter per evaluation language
+	// ("en_us", "zh_cn"), since typical speech energy differs by language.
+	// Loaded from ISE_SILENCE_CONFIG_JSON; languages without an entry keep
+	// the built-in thresholds.
+	Silence map[string]SilenceConfig
+}
+
+// SilenceConfig sets the amplitude thresholds the ISE client uses to drop
+// silent chunks before uploading, and the fraction of loud samples below
+// which a chunk counts as silent. The aggressive threshold applies when the
+// audio would otherwise exceed the provider's frame budget. Zero fields fall
+// back to the built-in values.
+type SilenceConfig struct {
+	StandardThreshold     int16
+	AggressiveThreshold   int16
+	LoudSampleRatioCutoff float64
+}
+
+// EventsConfig selects the event publisher backend used for analytics events.
+type EventsConfig struct {
+	// Publisher is one of "none", "nats", "kafka".
+	Publisher string
+	// NATSURL is the server URL for the NATS publisher.
+	NATSURL string
+	// KafkaBrokers is a comma-separated broker list for the Kafka publisher.
+	KafkaBrokers string
+	// Topic is the subject/topic events are published to.
+	Topic string
+	// BufferSize bounds the async publish queue; events beyond it are dropped.
+	BufferSize int
+}
+
+// Load reads configuration from the environment.
+func Load() *Config {
+	return &Config{
+		HTTPPort:       getEnv("HTTP_PORT", "8081"),
+		AdminToken:     getEnv("ADMIN_TOKEN", ""),
+		StaticDir:      getEnv("STATIC_DIR", ""),
+		GRPCPort:       getEnv("GRPC_PORT", "50051"),
+		GRPCHealthPort: getEnv("GRPC_HEALTH_PORT", "50052"),
+		LogLevel:       getEnv("LOG_LEVEL", "info"),
+		ASR: ASRConfig{
+			AppID:   getEnv("ASR_APP_ID", ""),
+			Token:   getEnv("ASR_TOKEN", ""),
+			Cluster: getEnv("ASR_CLUSTER", "volcengine_streaming_common"),
+			BaseURL: getEnv("ASR_BASE_URL", "wss://openspeech.bytedance.com/api/v2/asr"),
+
+			MinConfidence:      getEnvFloat("ASR_MIN_CONFIDENCE", 0.4),
+			MinTranscriptWords: getEnvInt("ASR_MIN_TRANSCRIPT_WORDS", 1),
+		},
+		Audio: AudioConfig{
+			DenoiseEnabled:       getEnvBool("AUDIO_DENOISE_ENABLED", false),
+			DenoiseNoiseSampleMs: getEnvInt("AUDIO_DENOISE_NOISE_SAMPLE_MS", 300),
+			AGCEnabled:           getEnvBool("AUDIO_AGC_ENABLED", true),
+			AGCTargetRMS:         getEnvFloat("AUDIO_AGC_TARGET_RMS", 0.1),
+			AGCMaxGainDB:         getEnvFloat("AUDIO_AGC_MAX_GAIN_DB", 30),
+			StreamingMode:        getEnvBool("AUDIO_STREAMING_MODE", false),
+			PreRollMs:            getEnvInt("AUDIO_PREROLL_MS", 200),
+			SilenceRMS:           getEnvFloat("AUDIO_SILENCE_RMS", 0.005),
+			ClippingWarnRatio:    getEnvFloat("AUDIO_CLIPPING_WARN_RATIO", 0.03),
+			WorkerPoolSize:       getEnvInt("AUDIO_WORKER_POOL_SIZE", 10),
+		},
+		TTS: TTSConfig{
+			Provider:           getEnv("TTS_PROVIDER", "bytedance"),
+			AppID:              getEnv("TTS_APP_ID", ""),
+			Token:              getEnv("TTS_TOKEN", ""),
+			Cluster:            getEnv("TTS_CLUSTER", "volcano_tts"),
+			BaseURL:            getEnv("TTS_BASE_URL", "wss://openspeech.bytedance.com/api/v1/tts/ws_binary"),
+			VoiceType:          getEnv("TTS_VOICE_TYPE", "en_us_002"),
+			Speed:              getEnvFloat("TTS_SPEED", 1.0),
+			LanguageAutoSelect: getEnvBool("TTS_LANGUAGE_AUTO_SELECT", true),
+			Azure: AzureTTSConfig{
+				BaseURL:         getEnv("AZURE_TTS_BASE_URL", ""),
+				Region:          getEnv("AZURE_TTS_REGION", "eastus"),
+				SubscriptionKey: getEnv("AZURE_TTS_KEY", ""),
+				Voice:           getEnv("AZURE_TTS_VOICE", "en-US-JennyNeural"),
+				Language:        getEnv("AZURE_TTS_LANGUAGE", "en-US"),
+			},
+		},
+		LLM: LLMConfig{
+			APIKey:          getEnv("LLM_API_KEY", ""),
+			BaseURL:         getEnv("LLM_BASE_URL", "https://ark.cn-beijing.volces.com/api/v3"),
+			Model:           getEnv("LLM_MODEL", "doubao-pro-32k"),
+			MaxTokens:       getEnvInt("LLM_MAX_TOKENS", 150),
+			Temperature:     getEnvFloat("LLM_TEMPERATURE", 0.7),
+			DailyTokenLimit: int64(getEnvInt("LLM_DAILY_TOKEN_LIMIT", 100000)),
+			UsageDir:        getEnv("LLM_USAGE_DIR", "data/llm_usage"),
+			CEFRParamsPath:  getEnv("LLM_CEFR_PARAMS_PATH", "llm_cefr_params.json"),
+
+			MinResponseWords: getEnvInt("LLM_MIN_RESPONSE_WORDS", 8),
+			RequireQuestion:  getEnvBool("LLM_REQUIRE_QUESTION_IN_RESPONSE", false),
+
+			AnimateSpeedWPM:     getEnvInt("ANIMATE_SPEED_WPM", 250),
+			BurstThresholdChars: getEnvInt("BURST_THRESHOLD_CHARS", 200),
+		},
+		ISE: ISEConfig{
+			AppID:             getEnv("ISE_APP_ID", ""),
+			APIKey:            getEnv("ISE_API_KEY", ""),
+			APISecret:         getEnv("ISE_API_SECRET", ""),
+			BaseURL:           getEnv("ISE_BASE_URL", "wss://ise-api.xfyun.cn/v2/open-ise"),
+			CalibrationPath:   getEnv("ISE_CALIBRATION_PATH", "ise_calibration.json"),
+			MaxMessageBytes:   getEnvInt("ISE_MAX_MESSAGE_BYTES", 26000),
+			JSONOverheadBytes: getEnvInt("ISE_JSON_OVERHEAD_BYTES", 800),
+			Mode:              getEnv("ISE_MODE", "referenced"),
+			ProsodyComparison: getEnvBool("ISE_PROSODY_COMPARISON", false),
+			CacheEnabled:      getEnvBool("ISE_CACHE_ENABLED", true),
+			CacheSize:         getEnvInt("ISE_CACHE_SIZE", 1000),
+
+			SchedulerMaxConcurrent: getEnvInt("ISE_SCHEDULER_MAX_CONCURRENT", 0),
+
+			CredentialSource:          getEnv("ISE_CREDENTIAL_SOURCE", "static"),
+			CredentialRefreshURL:      getEnv("CREDENTIAL_REFRESH_URL", ""),
+			CredentialRefreshInterval: getEnvDuration("CREDENTIAL_REFRESH_INTERVAL", 15*time.Minute),
+
+			ProtocolLogEnabled: getEnvBool("ISE_PROTOCOL_LOG_ENABLED", false),
+			ProtocolLogPath:    getEnv("ISE_PROTOCOL_LOG_PATH", "ise_protocol.log"),
+
+			Silence: parseSilenceConfig(getEnv("ISE_SILENCE_CONFIG_JSON", "")),
+		},
+		Events: EventsConfig{
+			Publisher:    getEnv("EVENT_PUBLISHER", "none"),
+			NATSURL:      getEnv("EVENT_NATS_URL", "nats://localhost:4222"),
+			KafkaBrokers: getEnv("EVENT_KAFKA_BROKERS", "localhost:9092"),
+			Topic:        getEnv("EVENT_TOPIC", "tutor.speech.events"),
+			BufferSize:   getEnvInt("EVENT_BUFFER_SIZE", 1024),
+		},
+		GRPC: GRPCServerConfig{
+			MaxRecvMsgSizeMB:     getEnvInt("GRPC_MAX_MSG_SIZE_MB", 16),
+			MaxSendMsgSizeMB:     getEnvInt("GRPC_MAX_MSG_SIZE_MB", 16),
+			MaxAudioSizeMB:       getEnvInt("MAX_AUDIO_SIZE_MB", 8),
+			KeepaliveMinTime:     getEnvDuration("GRPC_KEEPALIVE_MIN_TIME", 30*time.Second),
+			KeepaliveTime:        getEnvDuration("GRPC_KEEPALIVE_TIME", 2*time.Minute),
+			KeepaliveTimeout:     getEnvDuration("GRPC_KEEPALIVE_TIMEOUT", 20*time.Second),
+			MaxConcurrentStreams: getEnvInt("GRPC_MAX_CONCURRENT_STREAMS", 256),
+			TLSCertFile:          getEnv("GRPC_TLS_CERT", ""),
+			TLSKeyFile:           getEnv("GRPC_TLS_KEY", ""),
+			EnableReflection:     getEnvBool("GRPC_REFLECTION", false),
+		},
+		SLO: SLOConfig{
+			ASRWarn:          getEnvDuration("SLO_ASR_WARN", 1500*time.Millisecond),
+			LLMWarn:          getEnvDuration("SLO_LLM_WARN", 2500*time.Millisecond),
+			TTSFirstByteWarn: getEnvDuration("SLO_TTS_FIRST_BYTE_WARN", time.Second),
+			TTSWarn:          getEnvDuration("SLO_TTS_WARN", 4*time.Second),
+			ISEWarn:          getEnvDuration("SLO_ISE_WARN", 3*time.Second),
+		},
+		RateLimit: RateLimitConfig{
+			AudioPerSecond: getEnvFloat("AUDIO_RATE_LIMIT", 2),
+			LLMPerSecond:   getEnvFloat("LLM_RATE_LIMIT", 2),
+			TTSPerSecond:   getEnvFloat("TTS_RATE_LIMIT", 1),
+			Burst:          getEnvInt("RATE_LIMIT_BURST", 3),
+		},
+		Recording: RecordingConfig{
+			Enabled:            getEnvBool("SESSION_RECORDING_ENABLED", false),
+			Dir:                getEnv("SESSION_RECORDING_DIR", "data/recordings"),
+			RetentionDays:      getEnvInt("RECORDING_RETENTION_DAYS", 7),
+			MaxBytesPerSession: int64(getEnvInt("RECORDING_MAX_BYTES_PER_SESSION", 50<<20)),
+		},
+		Session: SessionConfig{
+			SnapshotDir:          getEnv("SESSION_SNAPSHOT_DIR", "data/sessions"),
+			SnapshotTTL:          getEnvDuration("SESSION_SNAPSHOT_TTL", 24*time.Hour),
+			PausedAudioPolicy:    getEnv("PAUSED_AUDIO_POLICY", "buffer"),
+			TeacherToken:         getEnv("TEACHER_TOKEN", ""),
+			RetryAudioBudgetMB:   getEnvInt("SESSION_RETRY_AUDIO_BUDGET_MB", 8),
+			MaxSessionDuration:   time.Duration(getEnvInt("MAX_SESSION_DURATION_MINUTES", 60)) * time.Minute,
+			TokenSecret:          getEnv("SESSION_TOKEN_SECRET", ""),
+			TokenTTL:             getEnvDuration("SESSION_TOKEN_TTL", 2*time.Minute),
+			DeterministicEnabled: getEnvBool("DETERMINISTIC_SESSIONS_ENABLED", false),
+		},
+		Backend: BackendConfig{
+			Mode:     getEnv("BACKEND_MODE", "direct"),
+			GRPCAddr: getEnv("SPEECH_BACKEND_ADDR", "localhost:50051"),
+		},
+		Warmup: WarmupConfig{
+			Enabled:  getEnvBool("WARMUP_ENABLED", false),
+			Required: getEnvBool("WARMUP_REQUIRED", false),
+			Timeout:  getEnvDuration("WARMUP_TIMEOUT", 15*time.Second),
+		},
+		Pipeline: PipelineConfig{
+			ASR:         getEnvBool("PIPELINE_ASR_ENABLED", true),
+			LLM:         getEnvBool("PIPELINE_LLM_ENABLED", true),
+			TTS:         getEnvBool("PIPELINE_TTS_ENABLED", true),
+			ISE:         getEnvBool("PIPELINE_ISE_ENABLED", true),
+			Corrections: getEnvBool("PIPELINE_CORRECTIONS_ENABLED", true),
+		},
+		WebSocket: WebSocketConfig{
+			ReadBufferBytes:   getEnvInt("WS_READ_BUFFER_BYTES", 32*1024),
+			WriteBufferBytes:  getEnvInt("WS_WRITE_BUFFER_BYTES", 32*1024),
+			ReadLimitBytes:    int64(getEnvInt("WS_READ_LIMIT_BYTES", 1<<20)),
+			EnableCompression: getEnvBool("WS_ENABLE_COMPRESSION", false),
+		},
+		Memory: MemoryConfig{
+			SessionBudgetBytes:  int64(getEnvInt("MEMORY_SESSION_BUDGET_BYTES", 32<<20)),
+			ProcessCeilingBytes: int64(getEnvInt("MEMORY_PROCESS_CEILING_BYTES", 512<<20)),
+		},
+		Scheduler: SchedulerConfig{
+			ASR:                 LaneBudget{Interactive: getEnvInt("LANE_ASR_INTERACTIVE", 8), Batch: getEnvInt("LANE_ASR_BATCH", 2)},
+			LLM:                 LaneBudget{Interactive: getEnvInt("LANE_LLM_INTERACTIVE", 8), Batch: getEnvInt("LANE_LLM_BATCH", 2)},
+			TTS:                 LaneBudget{Interactive: getEnvInt("LANE_TTS_INTERACTIVE", 8), Batch: getEnvInt("LANE_TTS_BATCH", 2)},
+			ISE:                 LaneBudget{Interactive: getEnvInt("LANE_ISE_INTERACTIVE", 4), Batch: getEnvInt("LANE_ISE_BATCH", 2)},
+			BatchAudioThreshold: getEnvDuration("LANE_BATCH_AUDIO_THRESHOLD", 10*time.Second),
+			WaitTimeout:         getEnvDuration("LANE_WAIT_TIMEOUT", 30*time.Second),
+		},
+	}
+}
+
+// parseSilenceConfig parses the ISE_SILENCE_CONFIG_JSON value, a JSON object
+// keyed by evaluation language:
+//
+//	{"en_us": {"standard": 500, "aggressive": 800, "ratio": 0.1}}
+//
+// Like the other getEnv helpers, a malformed value falls back to the default
+// (no overrides) rather than failing startup.
+func parseSilenceConfig(raw string) map[string]SilenceConfig {
+	if raw == "" {
+		return nil
+	}
+	var parsed map[string]struct {
+		Standard   int16   `json:"standard"`
+		Aggressive int16   `json:"aggressive"`
+		Ratio      float64 `json:"ratio"`
+	}
+	if err := json.Unmarshal([]byte(raw), &parsed); err != nil {
+		return nil
+	}
+	overrides := make(map[string]SilenceConfig, len(parsed))
+	for language, c := range parsed {
+		overrides[language] = SilenceConfig{
+			StandardThreshold:     c.Standard,
+			AggressiveThreshold:   c.Aggressive,
+			LoudSampleRatioCutoff: c.Ratio,
+		}
+	}
+	return overrides
+}
+
+func getEnv(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+func getEnvInt(key string, fallback int) int {
+	if v := os.Getenv(key); v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			return n
+		}
+	}
+	return fallback
+}
+
+func getEnvBool(key string, fallback bool) bool {
+	if v := os.Getenv(key); v != "" {
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b
+		}
+	}
+	return fallback
+}
+
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	if v := os.Getenv(key); v != "" {
+		if d, err := time.ParseDuration(v); err == nil {
+			return d
+		}
+	}
+	return fallback
+}
+
+func getEnvFloat(key string, fallback float64) float64 {
+	if v := os.Getenv(key); v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return f
+		}
+	}
+	return fallback
+}
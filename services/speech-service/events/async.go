@@ -0,0 +1,72 @@
+package events
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sirupsen/logrus"
+)
+
+var droppedEvents = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "speech_events_dropped_total",
+	Help: "Events dropped because the publish buffer was full.",
+})
+
+// AsyncPublisher decouples event producers from the broker: Publish enqueues
+// into a bounded buffer and returns immediately; a background goroutine
+// drains the buffer. When the buffer is full the event is dropped and
+// counted, so a broker outage never stalls a conversation.
+type AsyncPublisher struct {
+	inner  EventPublisher
+	buffer chan Event
+	logger *logrus.Logger
+
+	closeOnce sync.Once
+	done      chan struct{}
+}
+
+// NewAsyncPublisher wraps inner with a bounded async delivery queue.
+func NewAsyncPublisher(inner EventPublisher, bufferSize int, logger *logrus.Logger) *AsyncPublisher {
+	if bufferSize <= 0 {
+		bufferSize = 1024
+	}
+	p := &AsyncPublisher{
+		inner:  inner,
+		buffer: make(chan Event, bufferSize),
+		logger: logger,
+		done:   make(chan struct{}),
+	}
+	go p.drain()
+	return p
+}
+
+// Publish enqueues the event, dropping it if the buffer is full.
+func (p *AsyncPublisher) Publish(event Event) error {
+	select {
+	case p.buffer <- event:
+	default:
+		droppedEvents.Inc()
+		p.logger.Warnf("Event buffer full, dropping %s for session %s", event.Type, event.SessionID)
+	}
+	return nil
+}
+
+// Close stops the drain goroutine after flushing buffered events and closes
+// the wrapped publisher.
+func (p *AsyncPublisher) Close() error {
+	p.closeOnce.Do(func() {
+		close(p.buffer)
+		<-p.done
+	})
+	return p.inner.Close()
+}
+
+func (p *AsyncPublisher) drain() {
+	defer close(p.done)
+	for event := range p.buffer {
+		if err := p.inner.Publish(event); err != nil {
+			p.logger.Warnf("Failed to publish %s event for session %s: %v", event.Type, event.SessionID, err)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+// Package events publishes session and turn lifecycle events for analytics
+// consumers, decoupled from the realtime conversation path.
+package events
+
+import (
+	"time"
+)
+
+// SchemaVersion is bumped whenever the event payload shape changes in a way
+// consumers must handle.
+const SchemaVersion = 1
+
+// Event types emitted by the speech pipeline.
+const (
+	TypeSessionStarted = "session_started"
+	TypeTurnCompleted  = "turn_completed"
+	TypeSessionEnded   = "session_ended"
+)
+
+// Event is the versioned envelope for all published events.
+type Event struct {
+	SchemaVersion int         `json:"schema_version"`
+	Type          string      `json:"type"`
+	SessionID     string      `json:"session_id"`
+	UserID        string      `json:"user_id"`
+	TurnID        int         `json:"turn_id,omitempty"`
+	Timestamp     time.Time   `json:"timestamp"`
+	Data          interface{} `json:"data,omitempty"`
+}
+
+// SessionStarted is the payload for session_started events.
+type SessionStarted struct {
+	Language string `json:"language,omitempty"`
+}
+
+// TurnTimings holds per-stage elapsed milliseconds for one turn.
+type TurnTimings struct {
+	ASRMs int64 `json:"asr_ms,omitempty"`
+	LLMMs int64 `json:"llm_ms,omitempty"`
+	// TTSFirstByteMs is the delay until the first synthesized audio byte
+	// arrived; TTSMs covers the whole synthesis.
+	TTSFirstByteMs int64 `json:"tts_first_byte_ms,omitempty"`
+	TTSMs          int64 `json:"tts_ms,omitempty"`
+	ISEMs          int64 `json:"ise_ms,omitempty"`
+}
+
+// TurnCompleted is the payload for turn_completed events.
+type TurnCompleted struct {
+	Transcript    string   `json:"transcript"`
+	ResponseText  string   `json:"response_text"`
+	ASRConfidence float64  `json:"asr_confidence,omitempty"`
+	ISEScore      *float64 `json:"ise_score,omitempty"`
+	TokensUsed    int      `json:"tokens_used,omitempty"`
+	// ClarificationRequested marks a turn where the transcript was too
+	// unreliable to answer and the tutor asked the learner to repeat.
+	ClarificationRequested bool `json:"clarification_requested,omitempty"`
+	// AudioDurationMs is the measured play time of the learner's utterance;
+	// 0 for typed turns.
+	AudioDurationMs int64 `json:"audio_duration_ms,omitempty"`
+	// AudioRMS, AudioPeak and AudioClippingRatio come from the pre-flight
+	// quality analysis of the utterance's converted PCM; amplitudes are
+	// fractions of full scale. All zero for typed turns.
+	AudioRMS           float64     `json:"audio_rms,omitempty"`
+	AudioPeak          float64     `json:"audio_peak,omitempty"`
+	AudioClippingRatio float64     `json:"audio_clipping_ratio,omitempty"`
+	Timings            TurnTimings `json:"timings"`
+}
+
+// SessionEnded is the payload for session_ended events.
+type SessionEnded struct {
+	DurationMs int64 `json:"duration_ms"`
+	// PausedMs is how much of the duration the session spent paused, so
+	// consumers don't count paused time as user inactivity.
+	PausedMs  int64 `json:"paused_ms,omitempty"`
+	TurnCount int   `json:"turn_count"`
+}
+
+// New builds an event envelope with the current schema version and timestamp.
+func New(eventType, sessionID, userID string, turnID int, data interface{}) Event {
+	return Event{
+		SchemaVersion: SchemaVersion,
+		Type:          eventType,
+		SessionID:     sessionID,
+		UserID:        userID,
+		TurnID:        turnID,
+		Timestamp:     time.Now().UTC(),
+		Data:          data,
+	}
+}
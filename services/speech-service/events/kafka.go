@@ -0,0 +1,46 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// KafkaPublisher publishes events to a Kafka topic as JSON, keyed by session
+// ID so one session's events stay ordered within a partition.
+type KafkaPublisher struct {
+	writer *kafka.Writer
+}
+
+// NewKafkaPublisher creates a writer for the given brokers and topic.
+func NewKafkaPublisher(brokers, topic string) (*KafkaPublisher, error) {
+	writer := &kafka.Writer{
+		Addr:         kafka.TCP(strings.Split(brokers, ",")...),
+		Topic:        topic,
+		Balancer:     &kafka.Hash{},
+		BatchTimeout: 100 * time.Millisecond,
+	}
+	return &KafkaPublisher{writer: writer}, nil
+}
+
+// Publish implements EventPublisher.
+func (p *KafkaPublisher) Publish(event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	return p.writer.WriteMessages(ctx, kafka.Message{
+		Key:   []byte(event.SessionID),
+		Value: payload,
+	})
+}
+
+// Close implements EventPublisher.
+func (p *KafkaPublisher) Close() error {
+	return p.writer.Close()
+}
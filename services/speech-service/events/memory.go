@@ -0,0 +1,29 @@
+package events
+
+import "sync"
+
+// MemoryPublisher records events in memory. It is intended for tests.
+type MemoryPublisher struct {
+	mu     sync.Mutex
+	events []Event
+}
+
+// Publish implements EventPublisher.
+func (p *MemoryPublisher) Publish(event Event) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, event)
+	return nil
+}
+
+// Close implements EventPublisher.
+func (p *MemoryPublisher) Close() error { return nil }
+
+// Events returns a copy of the recorded events.
+func (p *MemoryPublisher) Events() []Event {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	out := make([]Event, len(p.events))
+	copy(out, p.events)
+	return out
+}
@@ -0,0 +1,38 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/nats-io/nats.go"
+)
+
+// NATSPublisher publishes events to a NATS subject as JSON.
+type NATSPublisher struct {
+	conn    *nats.Conn
+	subject string
+}
+
+// NewNATSPublisher connects to the NATS server.
+func NewNATSPublisher(url, subject string) (*NATSPublisher, error) {
+	conn, err := nats.Connect(url, nats.Name("speech-service-events"))
+	if err != nil {
+		return nil, fmt.Errorf("connect to nats: %w", err)
+	}
+	return &NATSPublisher{conn: conn, subject: subject}, nil
+}
+
+// Publish implements EventPublisher.
+func (p *NATSPublisher) Publish(event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	return p.conn.Publish(p.subject, payload)
+}
+
+// Close implements EventPublisher.
+func (p *NATSPublisher) Close() error {
+	p.conn.Close()
+	return nil
+}
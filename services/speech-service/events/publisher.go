@@ -0,0 +1,47 @@
+package events
+
+import (
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/config"
+)
+
+// EventPublisher delivers events to an external stream. Implementations must
+// be safe for concurrent use.
+type EventPublisher interface {
+	Publish(event Event) error
+	Close() error
+}
+
+// NewPublisher builds the publisher selected by configuration, wrapped in the
+// bounded async delivery layer so a slow broker never blocks the caller.
+func NewPublisher(cfg config.EventsConfig, logger *logrus.Logger) (EventPublisher, error) {
+	var inner EventPublisher
+	var err error
+
+	switch cfg.Publisher {
+	case "", "none":
+		return &NoopPublisher{}, nil
+	case "nats":
+		inner, err = NewNATSPublisher(cfg.NATSURL, cfg.Topic)
+	case "kafka":
+		inner, err = NewKafkaPublisher(cfg.KafkaBrokers, cfg.Topic)
+	default:
+		return nil, fmt.Errorf("unknown event publisher %q", cfg.Publisher)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return NewAsyncPublisher(inner, cfg.BufferSize, logger), nil
+}
+
+// NoopPublisher discards all events. Used when no broker is configured.
+type NoopPublisher struct{}
+
+// Publish implements EventPublisher.
+func (*NoopPublisher) Publish(Event) error { return nil }
+
+// Close implements EventPublisher.
+func (*NoopPublisher) Close() error { return nil }
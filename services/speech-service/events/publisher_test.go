@@ -0,0 +1,115 @@
+package events
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestTurnCompletedPayload(t *testing.T) {
+	score := 87.5
+	event := New(TypeTurnCompleted, "sess-1", "user-1", 3, TurnCompleted{
+		Transcript:    "I goed to the park",
+		ResponseText:  "Nice! You went to the park — what did you do there?",
+		ASRConfidence: 0.92,
+		ISEScore:      &score,
+		TokensUsed:    42,
+		Timings:       TurnTimings{ASRMs: 350, LLMMs: 900, TTSMs: 400},
+	})
+
+	raw, err := json.Marshal(event)
+	if err != nil {
+		t.Fatalf("marshal event: %v", err)
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		t.Fatalf("unmarshal event: %v", err)
+	}
+
+	if decoded["schema_version"].(float64) != SchemaVersion {
+		t.Errorf("schema_version = %v, want %d", decoded["schema_version"], SchemaVersion)
+	}
+	if decoded["type"] != TypeTurnCompleted {
+		t.Errorf("type = %v, want %s", decoded["type"], TypeTurnCompleted)
+	}
+	if decoded["session_id"] != "sess-1" || decoded["user_id"] != "user-1" {
+		t.Errorf("identity fields wrong: %v", decoded)
+	}
+	if decoded["turn_id"].(float64) != 3 {
+		t.Errorf("turn_id = %v, want 3", decoded["turn_id"])
+	}
+	data := decoded["data"].(map[string]interface{})
+	if data["transcript"] != "I goed to the park" {
+		t.Errorf("transcript = %v", data["transcript"])
+	}
+	if data["ise_score"].(float64) != 87.5 {
+		t.Errorf("ise_score = %v, want 87.5", data["ise_score"])
+	}
+	timings := data["timings"].(map[string]interface{})
+	if timings["llm_ms"].(float64) != 900 {
+		t.Errorf("llm_ms = %v, want 900", timings["llm_ms"])
+	}
+}
+
+func TestAsyncPublisherDelivers(t *testing.T) {
+	mem := &MemoryPublisher{}
+	pub := NewAsyncPublisher(mem, 16, logrus.New())
+
+	pub.Publish(New(TypeSessionStarted, "sess-1", "user-1", 0, SessionStarted{Language: "en-US"}))
+	pub.Publish(New(TypeSessionEnded, "sess-1", "user-1", 0, SessionEnded{DurationMs: 60000, TurnCount: 5}))
+	if err := pub.Close(); err != nil {
+		t.Fatalf("close publisher: %v", err)
+	}
+
+	got := mem.Events()
+	if len(got) != 2 {
+		t.Fatalf("got %d events, want 2", len(got))
+	}
+	if got[0].Type != TypeSessionStarted || got[1].Type != TypeSessionEnded {
+		t.Errorf("event order wrong: %s, %s", got[0].Type, got[1].Type)
+	}
+	ended := got[1].Data.(SessionEnded)
+	if ended.TurnCount != 5 {
+		t.Errorf("turn_count = %d, want 5", ended.TurnCount)
+	}
+}
+
+func TestAsyncPublisherDropsOnOverflow(t *testing.T) {
+	// A publisher that blocks forever simulates a broker outage.
+	blocked := make(chan struct{})
+	slow := &blockingPublisher{unblock: blocked}
+	pub := NewAsyncPublisher(slow, 1, logrus.New())
+
+	// First event is picked up by the drain goroutine and blocks; give it a
+	// moment so the buffer is actually empty again.
+	pub.Publish(New(TypeSessionStarted, "s", "u", 0, nil))
+	time.Sleep(20 * time.Millisecond)
+
+	// Second event fills the buffer, third must be dropped without blocking.
+	done := make(chan struct{})
+	go func() {
+		pub.Publish(New(TypeTurnCompleted, "s", "u", 1, nil))
+		pub.Publish(New(TypeTurnCompleted, "s", "u", 2, nil))
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Publish blocked on full buffer")
+	}
+	close(blocked)
+	pub.Close()
+}
+
+type blockingPublisher struct {
+	unblock chan struct{}
+}
+
+func (p *blockingPublisher) Publish(Event) error {
+	<-p.unblock
+	return nil
+}
+
+func (p *blockingPublisher) Close() error { return nil }
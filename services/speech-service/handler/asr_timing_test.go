@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+)
+
+// TestASRResultDurationFromPCM measures a known-length PCM fixture and
+// verifies the reported end time matches its play time — not the byte count,
+// which the old estimate read as roughly double the real duration.
+func TestASRResultDurationFromPCM(t *testing.T) {
+	h := newTestSpeechHandler(t, "", "")
+	stream := newFakeStream()
+	session := &VoiceSession{ID: "timing-session", stream: stream}
+
+	// 1.5 s of 16 kHz 16-bit mono PCM.
+	pcm := bytes.Repeat([]byte{0x00, 0x01}, 16000*3/2)
+	durationMs := h.audioService.Converter().GetAudioDuration(pcm).Milliseconds()
+
+	h.sendASRResult(session, &model.ASRResponse{Text: "hello"}, durationMs, &turnStats{})
+
+	asr := stream.responses()[0].GetAsrResult()
+	if asr == nil {
+		t.Fatal("no asr_result sent")
+	}
+	if asr.StartTimeMs != 0 {
+		t.Errorf("StartTimeMs = %d, want 0 without utterance timings", asr.StartTimeMs)
+	}
+	if asr.EndTimeMs < 1495 || asr.EndTimeMs > 1505 {
+		t.Errorf("EndTimeMs = %d, want about 1500 for a 1.5 s fixture", asr.EndTimeMs)
+	}
+}
+
+// TestASRResultUsesUtteranceTimings prefers the provider's utterance timings
+// over the whole-clip duration when they are present.
+func TestASRResultUsesUtteranceTimings(t *testing.T) {
+	h := newTestSpeechHandler(t, "", "")
+	stream := newFakeStream()
+	session := &VoiceSession{ID: "timing-session", stream: stream}
+
+	h.sendASRResult(session, &model.ASRResponse{
+		Text: "hello there",
+		Utterances: []model.Utterance{
+			{Text: "hello", StartTimeMs: 120, EndTimeMs: 700},
+			{Text: "there", StartTimeMs: 750, EndTimeMs: 1380},
+		},
+	}, 1500, &turnStats{})
+
+	asr := stream.responses()[0].GetAsrResult()
+	if asr == nil {
+		t.Fatal("no asr_result sent")
+	}
+	if asr.StartTimeMs != 120 || asr.EndTimeMs != 1380 {
+		t.Errorf("timings = [%d, %d], want the recognized speech span [120, 1380]", asr.StartTimeMs, asr.EndTimeMs)
+	}
+}
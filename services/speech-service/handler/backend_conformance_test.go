@@ -0,0 +1,164 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/config"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/manager"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/service"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/testutil"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/validation"
+	speechv1 "github.com/CurvatureX/ai-tutor-monorepo/shared/proto/speech"
+	"github.com/CurvatureX/ai-tutor-monorepo/shared/speechtest"
+)
+
+// Canned stage results shared by both backend modes, so any sequence
+// difference comes from the transport and not the fixtures.
+const (
+	conformanceReply = "Great question! The past tense of go is went."
+	conformanceAudio = "synthesized-reply-audio"
+)
+
+// backendStubServer answers the unary RPCs gRPC backend mode proxies to,
+// with the same canned values the direct-mode mock providers return.
+type backendStubServer struct {
+	speechv1.UnimplementedSpeechServiceServer
+}
+
+func (backendStubServer) GenerateReply(_ context.Context, _ *speechv1.GenerateReplyRequest) (*speechv1.GenerateReplyResponse, error) {
+	return &speechv1.GenerateReplyResponse{Text: conformanceReply, TokensUsed: 12}, nil
+}
+
+func (backendStubServer) Synthesize(_ context.Context, _ *speechv1.SynthesizeRequest) (*speechv1.SynthesizeResponse, error) {
+	return &speechv1.SynthesizeResponse{AudioData: []byte(conformanceAudio), Format: "mp3", DurationMs: 420}, nil
+}
+
+// newConformanceWSHandler assembles a WebSocket handler around the given LLM
+// and TTS providers; ASR and ISE are wired but unused by a text-only script.
+func newConformanceWSHandler(t *testing.T, llm LLMProvider, tts TTSProvider) *WebSocketHandler {
+	t.Helper()
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	validator, err := validation.NewMessageValidator()
+	if err != nil {
+		t.Fatalf("NewMessageValidator: %v", err)
+	}
+	return NewWebSocketHandler(
+		manager.NewWebSocketManager(logger, nil),
+		validator,
+		NewRateLimiter(config.RateLimitConfig{}),
+		service.NewASRService(config.ASRConfig{}, logger),
+		tts,
+		llm,
+		service.NewISEService(config.ISEConfig{}, logger),
+		service.NewAudioService(config.AudioConfig{}, logger),
+		newTestVoiceSelector(t, logger),
+		"", logger)
+}
+
+// newTestVoiceSelector builds a disabled selector so tests keep the default
+// voice regardless of reply language.
+func newTestVoiceSelector(t *testing.T, logger *logrus.Logger) *service.VoiceSelector {
+	t.Helper()
+	selector, err := service.NewVoiceSelector(config.TTSConfig{}, logger)
+	if err != nil {
+		t.Fatalf("NewVoiceSelector: %v", err)
+	}
+	return selector
+}
+
+// runConformanceScript drives one scripted text turn against the handler and
+// returns the normalized sequence of messages the client observed.
+func runConformanceScript(t *testing.T, h *WebSocketHandler) []string {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(h.HandleWebSocket))
+	defer srv.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial(
+		"ws"+strings.TrimPrefix(srv.URL, "http")+"?session_id=conformance&user_id=learner-1", nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	if err := conn.WriteJSON(&model.WebSocketMessage{
+		Type: model.MessageTypeText,
+		Data: map[string]interface{}{"text": "How do I use the past tense?"},
+	}); err != nil {
+		t.Fatalf("send text turn: %v", err)
+	}
+
+	var seq []string
+	for {
+		msgType, data, err := conn.ReadMessage()
+		if err != nil {
+			t.Fatalf("read (sequence so far %v): %v", seq, err)
+		}
+		if msgType == websocket.BinaryMessage {
+			seq = append(seq, "binary:"+string(data))
+			continue
+		}
+		var msg model.WebSocketMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			t.Fatalf("parse %q: %v", data, err)
+		}
+		switch msg.Type {
+		case model.MessageTypeStatus:
+			seq = append(seq, "status:"+fmt.Sprint(msg.Data["status"]))
+		case model.MessageTypeLLMResponse:
+			seq = append(seq, "llm_response:"+fmt.Sprint(msg.Data["text"]))
+		case model.MessageTypeTTSReady:
+			seq = append(seq, fmt.Sprintf("tts_ready:%v:%v", msg.Data["format"], msg.Data["bytes"]))
+			return seq
+		case model.MessageTypeError:
+			t.Fatalf("pipeline error: %v", msg.Data)
+		default:
+			seq = append(seq, msg.Type)
+		}
+	}
+}
+
+// TestBackendModesProduceIdenticalMessageSequences is the conformance check
+// for BACKEND_MODE=grpc: a scripted client must observe exactly the same
+// WebSocket messages whether the stages run in-process or behind the proxy.
+func TestBackendModesProduceIdenticalMessageSequences(t *testing.T) {
+	llmMock := testutil.NewMockLLMServer(testutil.MockLLMConfig{ReplyText: conformanceReply, TokensUsed: 12})
+	defer llmMock.Close()
+	ttsMock := testutil.NewMockTTSServer(testutil.MockTTSConfig{AudioChunks: [][]byte{[]byte(conformanceAudio)}})
+	defer ttsMock.Close()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	direct := newConformanceWSHandler(t,
+		service.NewLLMService(config.LLMConfig{BaseURL: llmMock.URL(), Model: "test-model", MaxTokens: 50}, nil, logger),
+		service.NewTTSService(config.TTSConfig{BaseURL: ttsMock.URL(), VoiceType: "test_voice", Speed: 1.0}, logger))
+
+	backend := speechtest.NewBufconnClient(t, backendStubServer{})
+	proxied := newConformanceWSHandler(t,
+		service.NewGRPCLLMBackend(backend),
+		service.NewGRPCTTSBackend(backend))
+
+	directSeq := runConformanceScript(t, direct)
+	proxiedSeq := runConformanceScript(t, proxied)
+
+	if len(directSeq) == 0 || directSeq[len(directSeq)-1] != fmt.Sprintf("tts_ready:mp3:%d", len(conformanceAudio)) {
+		t.Errorf("direct sequence %v does not end with the expected tts_ready", directSeq)
+	}
+	if fmt.Sprint(directSeq) != fmt.Sprint(proxiedSeq) {
+		t.Errorf("backend modes diverged:\n direct: %v\n grpc:   %v", directSeq, proxiedSeq)
+	}
+}
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/logging"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+	speechv1 "github.com/CurvatureX/ai-tutor-monorepo/shared/proto/speech"
+)
+
+// clarificationPhrases are the tutor's ways of asking the learner to repeat.
+// Sessions rotate through them so repeated requests don't sound robotic.
+var clarificationPhrases = []string{
+	"Sorry, could you say that again?",
+	"I didn't quite catch that. One more time?",
+	"Could you repeat that a little more slowly?",
+	"Hmm, I missed that. Can you say it again?",
+}
+
+// fillerWords are one-word transcripts that carry no content; answering them
+// produces nonsense replies, so they trigger a clarification instead.
+var fillerWords = map[string]bool{
+	"um": true, "uh": true, "er": true, "ah": true,
+	"hmm": true, "mhm": true, "mm": true, "huh": true,
+}
+
+// clarificationReason returns why the transcript should not be answered:
+// "low_confidence", "short_transcript" or "filler_word", or "" when it is
+// good enough to feed the LLM. Session overrides from configure_session take
+// precedence over the configured thresholds; confidence is only checked when
+// the provider reported one.
+func (h *SpeechHandler) clarificationReason(session *VoiceSession, resp *model.ASRResponse) string {
+	minConfidence := h.asrService.MinConfidence()
+	minWords := h.asrService.MinTranscriptWords()
+	session.clarifyMu.Lock()
+	if session.asrMinConfidence != nil {
+		minConfidence = *session.asrMinConfidence
+	}
+	if session.asrMinWords != nil {
+		minWords = *session.asrMinWords
+	}
+	session.clarifyMu.Unlock()
+
+	words := strings.Fields(resp.Text)
+	switch {
+	case minConfidence > 0 && resp.Confidence > 0 && resp.Confidence < minConfidence:
+		return "low_confidence"
+	case len(words) < minWords:
+		return "short_transcript"
+	case len(words) == 1 && fillerWords[normalizeWord(words[0])]:
+		return "filler_word"
+	}
+	return ""
+}
+
+// requestClarification asks the learner to repeat instead of answering: the
+// LLM stage is skipped, the turn stays out of the conversation history, and
+// the next phrase from the rotation is spoken in the reply's place.
+func (h *SpeechHandler) requestClarification(session *VoiceSession, turnID int, reason string, stats *turnStats) {
+	session.clarifyMu.Lock()
+	phrase := clarificationPhrases[session.clarifyCount%len(clarificationPhrases)]
+	session.clarifyCount++
+	session.clarifyMu.Unlock()
+
+	stats.mu.Lock()
+	stats.clarification = true
+	stats.responseText = phrase
+	stats.mu.Unlock()
+
+	logging.SessionLogger(session.ID, h.logger).WithField("reason", reason).Info("Asking the learner to repeat")
+	session.send(&speechv1.VoiceResponse{
+		ResponseType: &speechv1.VoiceResponse_LlmResult{
+			LlmResult: &speechv1.LLMResult{
+				Text: phrase,
+				Type: speechv1.LLMResultType_LLM_RESULT_TYPE_CLARIFICATION,
+			},
+		},
+	})
+	h.processTextWithTTS(session, turnID, phrase, stats)
+}
+
+// applyClarificationOverrides reads the per-session threshold overrides from
+// configure_session parameters.
+func (session *VoiceSession) applyClarificationOverrides(params map[string]string) {
+	session.clarifyMu.Lock()
+	defer session.clarifyMu.Unlock()
+	if v := params["asr_min_confidence"]; v != "" {
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			session.asrMinConfidence = &f
+		}
+	}
+	if v := params["asr_min_words"]; v != "" {
+		if n, err := strconv.Atoi(v); err == nil {
+			session.asrMinWords = &n
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package handler
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/config"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/events"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/service"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/store"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/testutil"
+	speechv1 "github.com/CurvatureX/ai-tutor-monorepo/shared/proto/speech"
+)
+
+// newClarifyHandler builds a SpeechHandler with the clarification thresholds
+// enabled: transcripts below minConfidence get a repeat request instead of a
+// reply.
+func newClarifyHandler(t *testing.T, asrURL, llmURL, ttsURL string, minConfidence float64) *SpeechHandler {
+	t.Helper()
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	publisher, err := events.NewPublisher(config.EventsConfig{Publisher: "none"}, logger)
+	if err != nil {
+		t.Fatalf("NewPublisher: %v", err)
+	}
+	return NewSpeechHandler(
+		service.NewASRService(config.ASRConfig{
+			BaseURL:            asrURL,
+			Cluster:            "test",
+			MinConfidence:      minConfidence,
+			MinTranscriptWords: 1,
+		}, logger),
+		service.NewTTSService(config.TTSConfig{BaseURL: ttsURL, VoiceType: "test_voice", Speed: 1.0}, logger),
+		service.NewLLMService(config.LLMConfig{BaseURL: llmURL, Model: "test-model", MaxTokens: 50}, nil, logger),
+		service.NewISEService(config.ISEConfig{}, logger),
+		service.NewAudioService(config.AudioConfig{}, logger),
+		publisher, store.NewSessionRecorder(false, nil, logger), 0, 0, pausePolicyBuffer,
+		ISEModeReferenced, config.SLOConfig{}, logger)
+}
+
+func waitForClarification(t *testing.T, stream *fakeConversationStream, want string) {
+	t.Helper()
+	waitForResponse(t, stream, "clarification "+want, func(resp *speechv1.VoiceResponse) bool {
+		r, ok := resp.GetResponseType().(*speechv1.VoiceResponse_LlmResult)
+		return ok && r.LlmResult.Type == speechv1.LLMResultType_LLM_RESULT_TYPE_CLARIFICATION &&
+			r.LlmResult.Text == want
+	})
+}
+
+func TestLowConfidenceTranscriptGetsRepeatRequest(t *testing.T) {
+	asr := testutil.NewMockASRServer(testutil.MockASRConfig{Transcript: "i goed to the park", Confidence: 0.2})
+	defer asr.Close()
+	llm := testutil.NewMockLLMServer(testutil.MockLLMConfig{ReplyText: "unused"})
+	defer llm.Close()
+	tts := testutil.NewMockTTSServer(testutil.MockTTSConfig{AudioChunks: [][]byte{[]byte("clarify-audio")}})
+	defer tts.Close()
+
+	h := newClarifyHandler(t, asr.URL(), llm.URL(), tts.URL(), 0.5)
+	sessionID := "low-confidence-session"
+	stream := newFakeStream(
+		configureReq(sessionID, map[string]string{"tutor_starts": "false"}),
+		freeSpeakAudioRequest(sessionID),
+		freeSpeakAudioRequest(sessionID),
+	)
+	if err := h.ProcessVoiceConversation(stream); err != nil {
+		t.Fatalf("ProcessVoiceConversation: %v", err)
+	}
+
+	// The phrase rotates between requests so it doesn't get robotic.
+	waitForClarification(t, stream, clarificationPhrases[0])
+	waitForClarification(t, stream, clarificationPhrases[1])
+	waitForResponse(t, stream, "clarification audio", func(resp *speechv1.VoiceResponse) bool {
+		r, ok := resp.GetResponseType().(*speechv1.VoiceResponse_TtsResult)
+		return ok && string(r.TtsResult.AudioData) == "clarify-audio"
+	})
+
+	// The LLM stage was skipped, so the unreliable turns never reached the
+	// conversation history.
+	if got := len(llm.Requests()); got != 0 {
+		t.Errorf("llm requests = %d, want 0 for clarification turns", got)
+	}
+}
+
+func TestFillerWordGetsRepeatRequest(t *testing.T) {
+	asr := testutil.NewMockASRServer(testutil.MockASRConfig{Transcript: "Um.", Confidence: 0.95})
+	defer asr.Close()
+	llm := testutil.NewMockLLMServer(testutil.MockLLMConfig{ReplyText: "unused"})
+	defer llm.Close()
+	tts := testutil.NewMockTTSServer(testutil.MockTTSConfig{AudioChunks: [][]byte{[]byte("audio")}})
+	defer tts.Close()
+
+	h := newClarifyHandler(t, asr.URL(), llm.URL(), tts.URL(), 0.5)
+	sessionID := "filler-session"
+	stream := newFakeStream(
+		configureReq(sessionID, map[string]string{"tutor_starts": "false"}),
+		freeSpeakAudioRequest(sessionID),
+	)
+	if err := h.ProcessVoiceConversation(stream); err != nil {
+		t.Fatalf("ProcessVoiceConversation: %v", err)
+	}
+
+	waitForClarification(t, stream, clarificationPhrases[0])
+	if got := len(llm.Requests()); got != 0 {
+		t.Errorf("llm requests = %d, want 0 for a filler-word turn", got)
+	}
+}
+
+func TestSessionOverrideLowersConfidenceThreshold(t *testing.T) {
+	asr := testutil.NewMockASRServer(testutil.MockASRConfig{Transcript: "i went to the park", Confidence: 0.2})
+	defer asr.Close()
+	llm := testutil.NewMockLLMServer(testutil.MockLLMConfig{ReplyText: "That sounds fun! What did you do there?"})
+	defer llm.Close()
+	tts := testutil.NewMockTTSServer(testutil.MockTTSConfig{AudioChunks: [][]byte{[]byte("audio")}})
+	defer tts.Close()
+
+	h := newClarifyHandler(t, asr.URL(), llm.URL(), tts.URL(), 0.5)
+	sessionID := "override-session"
+	stream := newFakeStream(
+		configureReq(sessionID, map[string]string{"tutor_starts": "false", "asr_min_confidence": "0.1"}),
+		freeSpeakAudioRequest(sessionID),
+	)
+	if err := h.ProcessVoiceConversation(stream); err != nil {
+		t.Fatalf("ProcessVoiceConversation: %v", err)
+	}
+
+	waitForResponse(t, stream, "normal reply", func(resp *speechv1.VoiceResponse) bool {
+		r, ok := resp.GetResponseType().(*speechv1.VoiceResponse_LlmResult)
+		return ok && r.LlmResult.Type == speechv1.LLMResultType_LLM_RESULT_TYPE_RESPONSE
+	})
+	time.Sleep(50 * time.Millisecond)
+	for _, resp := range stream.responses() {
+		if r, ok := resp.GetResponseType().(*speechv1.VoiceResponse_LlmResult); ok &&
+			r.LlmResult.Type == speechv1.LLMResultType_LLM_RESULT_TYPE_CLARIFICATION {
+			t.Error("clarification sent despite the lowered session threshold")
+		}
+	}
+}
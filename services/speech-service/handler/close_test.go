@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/config"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/manager"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/service"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/store"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/validation"
+)
+
+// newCloseTestHandler builds a WebSocket handler with snapshot persistence
+// and a log hook, so tests can observe both sides of the close policy.
+func newCloseTestHandler(t *testing.T) (*WebSocketHandler, *manager.WebSocketManager, *store.MemorySessionStore, *test.Hook) {
+	t.Helper()
+	logger, hook := test.NewNullLogger()
+	snapshots := store.NewMemorySessionStore(time.Hour)
+	wsManager := manager.NewWebSocketManager(logger, snapshots)
+
+	validator, err := validation.NewMessageValidator()
+	if err != nil {
+		t.Fatalf("NewMessageValidator: %v", err)
+	}
+	h := NewWebSocketHandler(
+		wsManager,
+		validator,
+		NewRateLimiter(config.RateLimitConfig{}),
+		service.NewASRService(config.ASRConfig{}, logger),
+		service.NewTTSService(config.TTSConfig{}, logger),
+		service.NewLLMService(config.LLMConfig{}, nil, logger),
+		service.NewISEService(config.ISEConfig{}, logger),
+		service.NewAudioService(config.AudioConfig{}, logger),
+		newTestVoiceSelector(t, logger),
+		"", logger)
+	return h, wsManager, snapshots, hook
+}
+
+// closeSession connects, makes one state change so a snapshot exists, then
+// sends the given close frame via WriteControl and waits for the server's
+// acknowledgment and cleanup.
+func closeSession(t *testing.T, h *WebSocketHandler, wsManager *manager.WebSocketManager, sessionID string, code int, reason string) {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(h.HandleWebSocket))
+	defer srv.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial(
+		"ws"+strings.TrimPrefix(srv.URL, "http")+"?session_id="+sessionID, nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	if err := conn.WriteJSON(&model.WebSocketMessage{
+		Type: model.MessageTypeControl,
+		Data: map[string]interface{}{"action": "set_reference_text", "text": "hello"},
+	}); err != nil {
+		t.Fatalf("send control: %v", err)
+	}
+	// Wait for the reference_text_set status so the snapshot has been saved
+	// before the close frame races it.
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			t.Fatalf("read: %v", err)
+		}
+		var msg model.WebSocketMessage
+		if json.Unmarshal(data, &msg) == nil && msg.Data["status"] == "reference_text_set" {
+			break
+		}
+	}
+
+	if err := conn.WriteControl(websocket.CloseMessage,
+		websocket.FormatCloseMessage(code, reason), time.Now().Add(time.Second)); err != nil {
+		t.Fatalf("write close frame: %v", err)
+	}
+	// The server acknowledges with the same code before dropping the session.
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			if !websocket.IsCloseError(err, code) {
+				t.Errorf("close acknowledgment = %v, want close %d", err, code)
+			}
+			break
+		}
+	}
+	deadline := time.Now().Add(5 * time.Second)
+	for wsManager.GetActiveSessions() > 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("session was not cleaned up after close")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// TestNormalCloseDiscardsSnapshot: a deliberate 1000 means there is nothing
+// to resume, so the persisted snapshot goes with the session.
+func TestNormalCloseDiscardsSnapshot(t *testing.T) {
+	h, wsManager, snapshots, _ := newCloseTestHandler(t)
+	closeSession(t, h, wsManager, "normal-close", websocket.CloseNormalClosure, "done practicing")
+	if _, ok := snapshots.Load("normal-close"); ok {
+		t.Error("snapshot survived a normal closure")
+	}
+}
+
+// TestGoingAwayKeepsSnapshot: a 1001 (navigation, refresh) keeps the snapshot
+// so the session resumes on reconnect.
+func TestGoingAwayKeepsSnapshot(t *testing.T) {
+	h, wsManager, snapshots, _ := newCloseTestHandler(t)
+	closeSession(t, h, wsManager, "going-away", websocket.CloseGoingAway, "")
+	if _, ok := snapshots.Load("going-away"); !ok {
+		t.Error("snapshot missing after a going-away closure")
+	}
+}
+
+// TestPolicyViolationCloseIsLogged: a 1008 leaves an operator-visible warning
+// carrying the client's reason.
+func TestPolicyViolationCloseIsLogged(t *testing.T) {
+	h, wsManager, _, hook := newCloseTestHandler(t)
+	closeSession(t, h, wsManager, "policy-close", websocket.ClosePolicyViolation, "unexpected frame")
+	for _, entry := range hook.AllEntries() {
+		if entry.Level == logrus.WarnLevel && entry.Data["close_reason"] == "unexpected frame" {
+			return
+		}
+	}
+	t.Error("no warning logged for the policy-violation close")
+}
+
+// TestServerErrorCloseIsRecorded: a 1011 is logged as an error (and counted)
+// because the client saw a failure the server may not have.
+func TestServerErrorCloseIsRecorded(t *testing.T) {
+	h, wsManager, _, hook := newCloseTestHandler(t)
+	closeSession(t, h, wsManager, "server-error-close", websocket.CloseInternalServerErr, "audio never arrived")
+	for _, entry := range hook.AllEntries() {
+		if entry.Level == logrus.ErrorLevel && entry.Data["close_reason"] == "audio never arrived" {
+			return
+		}
+	}
+	t.Error("no error logged for the client-reported server error")
+}
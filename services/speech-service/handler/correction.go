@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/logging"
+	speechv1 "github.com/CurvatureX/ai-tutor-monorepo/shared/proto/speech"
+)
+
+// noteFinalTranscript records the last final ASR transcript, the default
+// target of the request_correction control action.
+func (s *VoiceSession) noteFinalTranscript(text string) {
+	if text == "" {
+		return
+	}
+	s.correctionMu.Lock()
+	s.lastTranscript = text
+	s.correctionMu.Unlock()
+}
+
+// beginCorrection claims the session's single correction slot; it fails while
+// an earlier correction is still being generated.
+func (s *VoiceSession) beginCorrection() bool {
+	s.correctionMu.Lock()
+	defer s.correctionMu.Unlock()
+	if s.correctionPending {
+		return false
+	}
+	s.correctionPending = true
+	return true
+}
+
+// endCorrection releases the correction slot.
+func (s *VoiceSession) endCorrection() {
+	s.correctionMu.Lock()
+	s.correctionPending = false
+	s.correctionMu.Unlock()
+}
+
+// handleRequestCorrection corrects a learner utterance on demand: the "text"
+// parameter, or the last final ASR transcript when absent. The correction
+// arrives as an LLMResult of type CORRECTION and stays out of the
+// conversation history, so asking for one doesn't derail the conversation.
+func (h *SpeechHandler) handleRequestCorrection(session *VoiceSession, params map[string]string) {
+	text := params["text"]
+	if text == "" {
+		session.correctionMu.Lock()
+		text = session.lastTranscript
+		session.correctionMu.Unlock()
+	}
+	if text == "" {
+		h.sendError(session, speechv1.ErrorCode_ERROR_CODE_INVALID_REQUEST,
+			"nothing to correct: no text parameter and no transcript yet")
+		return
+	}
+	if !h.llmEnabled() || session.LLMDisabled {
+		h.sendError(session, speechv1.ErrorCode_ERROR_CODE_INVALID_REQUEST, "llm stage is disabled")
+		return
+	}
+	if !session.beginCorrection() {
+		h.sendError(session, speechv1.ErrorCode_ERROR_CODE_INVALID_REQUEST,
+			"a correction is already being generated for this session")
+		return
+	}
+	h.safeGo(session, "llm", func() { h.generateCorrection(session, text) })
+}
+
+// generateCorrection runs the LLM correction and delivers it.
+func (h *SpeechHandler) generateCorrection(session *VoiceSession, text string) {
+	defer session.endCorrection()
+	resp, err := h.llmFor(session).GenerateCorrection(text)
+	if err != nil {
+		logging.SessionLogger(session.ID, h.logger).WithError(err).WithField(logging.LogFieldService, "llm").Error("Correction failed")
+		h.sendError(session, speechv1.ErrorCode_ERROR_CODE_LLM_FAILED, "correction generation failed")
+		return
+	}
+	session.send(&speechv1.VoiceResponse{
+		ResponseType: &speechv1.VoiceResponse_LlmResult{
+			LlmResult: &speechv1.LLMResult{
+				Text:       resp.Text,
+				Type:       speechv1.LLMResultType_LLM_RESULT_TYPE_CORRECTION,
+				TokensUsed: int32(resp.TokensUsed),
+			},
+		},
+	})
+}
@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/testutil"
+	speechv1 "github.com/CurvatureX/ai-tutor-monorepo/shared/proto/speech"
+)
+
+// correctionReq builds a request_correction control request; text may be
+// empty to fall back to the session's last transcript.
+func correctionReq(sessionID, text string) *speechv1.VoiceRequest {
+	params := map[string]string{}
+	if text != "" {
+		params["text"] = text
+	}
+	return &speechv1.VoiceRequest{
+		SessionId: sessionID,
+		RequestType: &speechv1.VoiceRequest_Control{
+			Control: &speechv1.ControlMessage{
+				Action:     speechv1.ControlAction_CONTROL_ACTION_REQUEST_CORRECTION,
+				Parameters: params,
+			},
+		},
+	}
+}
+
+func waitForCorrection(t *testing.T, stream *fakeConversationStream, want string) {
+	t.Helper()
+	waitForResponse(t, stream, "correction "+want, func(resp *speechv1.VoiceResponse) bool {
+		r, ok := resp.GetResponseType().(*speechv1.VoiceResponse_LlmResult)
+		return ok && r.LlmResult.Type == speechv1.LLMResultType_LLM_RESULT_TYPE_CORRECTION &&
+			r.LlmResult.Text == want
+	})
+}
+
+func TestRequestCorrectionWithExplicitText(t *testing.T) {
+	llm := testutil.NewMockLLMServer(testutil.MockLLMConfig{
+		ReplyText: `Say "I went home" - "went" is the past tense of "go".`, TokensUsed: 9})
+	defer llm.Close()
+	tts := testutil.NewMockTTSServer(testutil.MockTTSConfig{AudioChunks: [][]byte{[]byte("audio")}})
+	defer tts.Close()
+
+	h := newTestSpeechHandler(t, llm.URL(), tts.URL())
+	stream := newFakeStream(
+		configureReq("correction-session", map[string]string{"tutor_starts": "false"}),
+		correctionReq("correction-session", "i goed home"),
+	)
+	if err := h.ProcessVoiceConversation(stream); err != nil {
+		t.Fatalf("ProcessVoiceConversation: %v", err)
+	}
+
+	waitForCorrection(t, stream, `Say "I went home" - "went" is the past tense of "go".`)
+	reqs := llm.Requests()
+	if len(reqs) != 1 || !bytes.Contains(reqs[0], []byte("i goed home")) {
+		t.Errorf("llm requests = %d, want one carrying the learner text", len(reqs))
+	}
+}
+
+func TestRequestCorrectionDefaultsToLastTranscript(t *testing.T) {
+	asr := testutil.NewMockASRServer(testutil.MockASRConfig{Transcript: "she don't like apples", Confidence: 0.95})
+	defer asr.Close()
+	llm := testutil.NewMockLLMServer(testutil.MockLLMConfig{ReplyText: `Say "she doesn't like apples".`})
+	defer llm.Close()
+	tts := testutil.NewMockTTSServer(testutil.MockTTSConfig{AudioChunks: [][]byte{[]byte("audio")}})
+	defer tts.Close()
+
+	h := newClarifyHandler(t, asr.URL(), llm.URL(), tts.URL(), 0)
+	sessionID := "last-transcript-session"
+	stream := &fakeConversationStream{reqCh: make(chan *speechv1.VoiceRequest, 8)}
+	done := make(chan struct{})
+	go func() {
+		h.ProcessVoiceConversation(stream)
+		close(done)
+	}()
+
+	stream.reqCh <- configureReq(sessionID, map[string]string{"tutor_starts": "false"})
+	stream.reqCh <- freeSpeakAudioRequest(sessionID)
+	// Wait for the spoken turn's transcript before asking for the
+	// correction, as a client reacting to the asr_result would.
+	waitForResponse(t, stream, "asr result", func(resp *speechv1.VoiceResponse) bool {
+		_, ok := resp.GetResponseType().(*speechv1.VoiceResponse_AsrResult)
+		return ok
+	})
+	stream.reqCh <- correctionReq(sessionID, "")
+	waitForCorrection(t, stream, `Say "she doesn't like apples".`)
+	close(stream.reqCh)
+	<-done
+
+	var found bool
+	for _, body := range llm.Requests() {
+		if bytes.Contains(body, []byte("she don't like apples")) && bytes.Contains(body, []byte("Correct")) {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("no correction prompt carried the last transcript")
+	}
+}
+
+func TestRequestCorrectionWithoutTranscriptRejected(t *testing.T) {
+	llm := testutil.NewMockLLMServer(testutil.MockLLMConfig{ReplyText: "unused"})
+	defer llm.Close()
+	tts := testutil.NewMockTTSServer(testutil.MockTTSConfig{AudioChunks: [][]byte{[]byte("audio")}})
+	defer tts.Close()
+
+	h := newTestSpeechHandler(t, llm.URL(), tts.URL())
+	stream := newFakeStream(
+		configureReq("no-transcript-session", map[string]string{"tutor_starts": "false"}),
+		correctionReq("no-transcript-session", ""),
+	)
+	if err := h.ProcessVoiceConversation(stream); err != nil {
+		t.Fatalf("ProcessVoiceConversation: %v", err)
+	}
+
+	waitForResponse(t, stream, "invalid request error", func(resp *speechv1.VoiceResponse) bool {
+		r, ok := resp.GetResponseType().(*speechv1.VoiceResponse_Error)
+		return ok && r.Error.Code == speechv1.ErrorCode_ERROR_CODE_INVALID_REQUEST
+	})
+	if got := len(llm.Requests()); got != 0 {
+		t.Errorf("llm requests = %d, want 0 without anything to correct", got)
+	}
+}
+
+func TestRequestCorrectionLimitedToOneOutstanding(t *testing.T) {
+	// The delay keeps the first correction in flight while the second
+	// arrives, so the second is rejected deterministically.
+	llm := testutil.NewMockLLMServer(testutil.MockLLMConfig{
+		ReplyText: "That sentence is already correct.", Delay: 200 * time.Millisecond})
+	defer llm.Close()
+	tts := testutil.NewMockTTSServer(testutil.MockTTSConfig{AudioChunks: [][]byte{[]byte("audio")}})
+	defer tts.Close()
+
+	h := newTestSpeechHandler(t, llm.URL(), tts.URL())
+	stream := newFakeStream(
+		configureReq("outstanding-session", map[string]string{"tutor_starts": "false"}),
+		correctionReq("outstanding-session", "first sentence"),
+		correctionReq("outstanding-session", "second sentence"),
+	)
+	if err := h.ProcessVoiceConversation(stream); err != nil {
+		t.Fatalf("ProcessVoiceConversation: %v", err)
+	}
+
+	waitForResponse(t, stream, "outstanding-correction rejection", func(resp *speechv1.VoiceResponse) bool {
+		r, ok := resp.GetResponseType().(*speechv1.VoiceResponse_Error)
+		return ok && r.Error.Code == speechv1.ErrorCode_ERROR_CODE_INVALID_REQUEST
+	})
+	waitForCorrection(t, stream, "That sentence is already correct.")
+	if got := len(llm.Requests()); got != 1 {
+		t.Errorf("llm requests = %d, want only the first correction to reach the model", got)
+	}
+}
@@ -0,0 +1,69 @@
+package handler
+
+// Deterministic session mode, for reproducible dev and test runs: a session
+// configured with deterministic=true gets temperature-0 completions, provider
+// request ids derived from the session id and turn counter, and a per-session
+// conversation starter instead of the shared cache. The option only takes
+// effect when the deployment enables it via DETERMINISTIC_SESSIONS_ENABLED,
+// so production sessions cannot opt out of sampling.
+
+import (
+	"fmt"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/manager"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/service"
+)
+
+// SetDeterministicSessions lets sessions opt into deterministic mode via the
+// deterministic session option; false (the default) makes the option a no-op.
+func (h *SpeechHandler) SetDeterministicSessions(enabled bool) {
+	h.deterministicAllowed = enabled
+}
+
+// llmFor is the LLM service a session's completions should go through: the
+// temperature-0 view for deterministic sessions, the shared service otherwise.
+func (h *SpeechHandler) llmFor(session *VoiceSession) *service.LLMService {
+	if session.Deterministic {
+		return h.llmService.Deterministic()
+	}
+	return h.llmService
+}
+
+// deterministicRequestID derives a provider request id from the session and
+// turn, so two runs of the same scripted session issue identical requests.
+// Empty for regular sessions, which lets the providers mint random ids.
+func deterministicRequestID(session *VoiceSession, turnID int) string {
+	if !session.Deterministic {
+		return ""
+	}
+	return fmt.Sprintf("%s-turn-%d", session.ID, turnID)
+}
+
+// SetDeterministicSessions lets direct WebSocket sessions opt into
+// deterministic mode via ?deterministic=true; false (the default) makes the
+// flag a no-op.
+func (h *WebSocketHandler) SetDeterministicSessions(enabled bool) {
+	h.deterministicEnabled = enabled
+}
+
+// llmProviderFor is the LLM provider a direct WebSocket session's completions
+// should go through. The temperature-0 view only exists for the in-process
+// LLMService; a gRPC backend provider is returned unchanged, since the remote
+// instance owns its sampling parameters.
+func (h *WebSocketHandler) llmProviderFor(session *manager.WebSocketSession) LLMProvider {
+	if session.Deterministic {
+		if d, ok := h.llmService.(interface{ Deterministic() *service.LLMService }); ok {
+			return d.Deterministic()
+		}
+	}
+	return h.llmService
+}
+
+// wsDeterministicRequestID is deterministicRequestID for the direct WebSocket
+// pipeline, which tracks the turn on the session rather than threading an id.
+func wsDeterministicRequestID(session *manager.WebSocketSession) string {
+	if !session.Deterministic {
+		return ""
+	}
+	return fmt.Sprintf("%s-turn-%d", session.ID, session.TurnCount)
+}
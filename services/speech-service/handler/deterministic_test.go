@@ -0,0 +1,182 @@
+package handler
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/config"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/service"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/testutil"
+)
+
+// updateGolden rewrites the stored golden transcripts with the observed
+// output: go test ./handler -run Golden -update.
+var updateGolden = flag.Bool("update", false, "rewrite golden files with the observed output")
+
+// Canned deterministic-session fixtures. The audio is what every tts_ready
+// byte count in the golden file derives from.
+const (
+	deterministicReply1 = "Went is the past tense of go. Can you use it in a sentence?"
+	deterministicReply2 = "Nice try! What did you do after you went home?"
+	deterministicAudio  = "deterministic-reply-audio"
+)
+
+// newDeterministicWSHandler is newConformanceWSHandler around real LLM and
+// TTS services pointed at the given mocks, with deterministic sessions
+// enabled.
+func newDeterministicWSHandler(t *testing.T, llmMock *testutil.MockLLMServer, ttsMock *testutil.MockTTSServer) *WebSocketHandler {
+	t.Helper()
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	h := newConformanceWSHandler(t,
+		service.NewLLMService(config.LLMConfig{BaseURL: llmMock.URL(), Model: "test-model", MaxTokens: 50}, nil, logger),
+		service.NewTTSService(config.TTSConfig{BaseURL: ttsMock.URL(), VoiceType: "test_voice", Speed: 1.0}, logger))
+	h.SetDeterministicSessions(true)
+	return h
+}
+
+// runDeterministicScript drives a whole scripted session — every turn in
+// order, reading each turn's messages through its tts_ready — and returns the
+// normalized sequence the client observed.
+func runDeterministicScript(t *testing.T, h *WebSocketHandler, query string, turns []string) []string {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(h.HandleWebSocket))
+	defer srv.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial(
+		"ws"+strings.TrimPrefix(srv.URL, "http")+query, nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	var seq []string
+	for _, turn := range turns {
+		if err := conn.WriteJSON(&model.WebSocketMessage{
+			Type: model.MessageTypeText,
+			Data: map[string]interface{}{"text": turn},
+		}); err != nil {
+			t.Fatalf("send text turn %q: %v", turn, err)
+		}
+		for done := false; !done; {
+			msgType, data, err := conn.ReadMessage()
+			if err != nil {
+				t.Fatalf("read (sequence so far %v): %v", seq, err)
+			}
+			if msgType == websocket.BinaryMessage {
+				seq = append(seq, "binary:"+string(data))
+				continue
+			}
+			var msg model.WebSocketMessage
+			if err := json.Unmarshal(data, &msg); err != nil {
+				t.Fatalf("parse %q: %v", data, err)
+			}
+			switch msg.Type {
+			case model.MessageTypeStatus:
+				seq = append(seq, "status:"+fmt.Sprint(msg.Data["status"]))
+			case model.MessageTypeLLMResponse:
+				seq = append(seq, "llm_response:"+fmt.Sprint(msg.Data["text"]))
+			case model.MessageTypeTTSReady:
+				seq = append(seq, fmt.Sprintf("tts_ready:%v:%v", msg.Data["format"], msg.Data["bytes"]))
+				done = true
+			case model.MessageTypeError:
+				t.Fatalf("pipeline error: %v", msg.Data)
+			default:
+				seq = append(seq, msg.Type)
+			}
+		}
+	}
+	return seq
+}
+
+// requestTemperatures decodes the temperature each recorded completion
+// request carried.
+func requestTemperatures(t *testing.T, llmMock *testutil.MockLLMServer) []float64 {
+	t.Helper()
+	var temps []float64
+	for _, raw := range llmMock.Requests() {
+		var body struct {
+			Temperature float64 `json:"temperature"`
+		}
+		if err := json.Unmarshal(raw, &body); err != nil {
+			t.Fatalf("parse llm request %q: %v", raw, err)
+		}
+		temps = append(temps, body.Temperature)
+	}
+	return temps
+}
+
+// TestDeterministicSessionGoldenTranscript replays a scripted two-turn
+// session with ?deterministic=true and compares the entire observed message
+// sequence against the stored golden transcript. Run with -update to rewrite
+// the golden file after a deliberate protocol change.
+func TestDeterministicSessionGoldenTranscript(t *testing.T) {
+	llmMock := testutil.NewMockLLMServer(testutil.MockLLMConfig{
+		ReplySequence: []string{deterministicReply1, deterministicReply2},
+		TokensUsed:    12,
+	})
+	defer llmMock.Close()
+	ttsMock := testutil.NewMockTTSServer(testutil.MockTTSConfig{AudioChunks: [][]byte{[]byte(deterministicAudio)}})
+	defer ttsMock.Close()
+	h := newDeterministicWSHandler(t, llmMock, ttsMock)
+
+	seq := runDeterministicScript(t, h,
+		"?session_id=deterministic-golden&user_id=learner-1&deterministic=true",
+		[]string{"How do I use the past tense?", "I went home."})
+	got := strings.Join(seq, "\n") + "\n"
+
+	goldenPath := filepath.Join("testdata", "deterministic_session.golden")
+	if *updateGolden {
+		if err := os.WriteFile(goldenPath, []byte(got), 0o644); err != nil {
+			t.Fatalf("write golden: %v", err)
+		}
+	}
+	want, err := os.ReadFile(goldenPath)
+	if err != nil {
+		t.Fatalf("read golden: %v", err)
+	}
+	if got != string(want) {
+		t.Errorf("session transcript diverged from golden (run with -update after a deliberate change):\n got:\n%s\n want:\n%s", got, want)
+	}
+
+	for i, temp := range requestTemperatures(t, llmMock) {
+		if temp != 0 {
+			t.Errorf("completion %d ran at temperature %v, want 0 for a deterministic session", i, temp)
+		}
+	}
+}
+
+// TestDeterministicFlagIgnoredWhenDisabled pins the gate: without the
+// deployment opt-in, ?deterministic=true changes nothing and completions keep
+// their CEFR-tuned temperature.
+func TestDeterministicFlagIgnoredWhenDisabled(t *testing.T) {
+	llmMock := testutil.NewMockLLMServer(testutil.MockLLMConfig{ReplyText: deterministicReply1, TokensUsed: 12})
+	defer llmMock.Close()
+	ttsMock := testutil.NewMockTTSServer(testutil.MockTTSConfig{AudioChunks: [][]byte{[]byte(deterministicAudio)}})
+	defer ttsMock.Close()
+	h := newDeterministicWSHandler(t, llmMock, ttsMock)
+	h.SetDeterministicSessions(false)
+
+	runDeterministicScript(t, h,
+		"?session_id=deterministic-gated&user_id=learner-1&deterministic=true",
+		[]string{"How do I use the past tense?"})
+
+	temps := requestTemperatures(t, llmMock)
+	if len(temps) != 1 || temps[0] == 0 {
+		t.Errorf("temperatures = %v, want one CEFR-tuned (non-zero) completion", temps)
+	}
+}
@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// trackGoroutine adjusts the per-session pipeline goroutine count reported by
+// the runtime diagnostics endpoint; entries are dropped when they reach zero.
+func (h *SpeechHandler) trackGoroutine(sessionID string, delta int) {
+	h.goroutineMu.Lock()
+	defer h.goroutineMu.Unlock()
+	h.pipelineGoroutines[sessionID] += delta
+	if h.pipelineGoroutines[sessionID] <= 0 {
+		delete(h.pipelineGoroutines, sessionID)
+	}
+}
+
+// PipelineGoroutines returns a copy of the live pipeline goroutine counts per
+// session, so a leak can be attributed to the session that spawned it.
+func (h *SpeechHandler) PipelineGoroutines() map[string]int {
+	h.goroutineMu.Lock()
+	defer h.goroutineMu.Unlock()
+	out := make(map[string]int, len(h.pipelineGoroutines))
+	for id, n := range h.pipelineGoroutines {
+		out[id] = n
+	}
+	return out
+}
+
+// ActiveSessions reports the number of live gRPC voice sessions.
+func (h *SpeechHandler) ActiveSessions() int {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.sessions)
+}
+
+// AdminTokenAuth rejects requests that do not carry the configured admin
+// token in the X-Admin-Token header. The comparison is constant-time so the
+// token cannot be recovered byte by byte.
+func AdminTokenAuth(token string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		got := c.GetHeader("X-Admin-Token")
+		if subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "admin token required"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// RegisterDiagRoutes mounts net/http/pprof and a runtime summary under the
+// given route group, which the caller is expected to gate with AdminTokenAuth.
+// extra supplies the service-specific fields merged into the runtime summary.
+func RegisterDiagRoutes(rg *gin.RouterGroup, extra func() gin.H) {
+	rg.GET("/pprof/*profile", func(c *gin.Context) {
+		profile := strings.TrimPrefix(c.Param("profile"), "/")
+		switch profile {
+		case "cmdline":
+			pprof.Cmdline(c.Writer, c.Request)
+		case "profile":
+			pprof.Profile(c.Writer, c.Request)
+		case "symbol":
+			pprof.Symbol(c.Writer, c.Request)
+		case "trace":
+			pprof.Trace(c.Writer, c.Request)
+		default:
+			// Index serves the named profiles (goroutine, heap, ...) from the
+			// request path, so rewrite it to the prefix pprof expects.
+			c.Request.URL.Path = "/debug/pprof/" + profile
+			pprof.Index(c.Writer, c.Request)
+		}
+	})
+	rg.GET("/runtime", func(c *gin.Context) {
+		var mem runtime.MemStats
+		runtime.ReadMemStats(&mem)
+		info := gin.H{
+			"goroutines":       runtime.NumGoroutine(),
+			"heap_alloc_bytes": mem.HeapAlloc,
+			"heap_sys_bytes":   mem.HeapSys,
+			"heap_objects":     mem.HeapObjects,
+			"num_gc":           mem.NumGC,
+		}
+		for k, v := range extra() {
+			info[k] = v
+		}
+		c.JSON(http.StatusOK, info)
+	})
+}
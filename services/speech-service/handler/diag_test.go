@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestDiagRuntimeGatedByAdminToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := newTestSpeechHandler(t, "http://unused.invalid", "ws://unused.invalid")
+
+	router := gin.New()
+	diag := router.Group("/admin/debug", AdminTokenAuth("s3cret"))
+	RegisterDiagRoutes(diag, func() gin.H {
+		return gin.H{
+			"active_sessions":     h.ActiveSessions(),
+			"pipeline_goroutines": h.PipelineGoroutines(),
+		}
+	})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/debug/runtime", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Fatalf("GET /admin/debug/runtime without token = %d, want 401", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/admin/debug/runtime", nil)
+	req.Header.Set("X-Admin-Token", "s3cret")
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /admin/debug/runtime with token = %d, want 200", rec.Code)
+	}
+	var info map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &info); err != nil {
+		t.Fatalf("parse runtime response: %v", err)
+	}
+	if _, ok := info["pipeline_goroutines"]; !ok {
+		t.Error("runtime response missing pipeline_goroutines")
+	}
+}
+
+func TestPipelineGoroutineAttribution(t *testing.T) {
+	h := newTestSpeechHandler(t, "http://unused.invalid", "ws://unused.invalid")
+	session := &VoiceSession{ID: "diag-session"}
+
+	release := make(chan struct{})
+	h.safeGo(session, "test", func() { <-release })
+
+	counts := h.PipelineGoroutines()
+	if counts["diag-session"] != 1 {
+		t.Errorf("live goroutines for session = %d, want 1", counts["diag-session"])
+	}
+
+	close(release)
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if len(h.PipelineGoroutines()) == 0 {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Error("goroutine count not released after the stage finished")
+}
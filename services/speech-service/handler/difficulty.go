@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/logging"
+)
+
+// Adaptation directions emitted by the DifficultyAdapter.
+const (
+	DirectionSimplify = "simplify"
+	DirectionIncrease = "increase"
+)
+
+// adaptStreakLength is how many consecutive turns must point the same way
+// before the session's level actually moves; a single hard turn should not
+// whipsaw the difficulty.
+const adaptStreakLength = 3
+
+// Thresholds for adapting upward: the learner is comfortably understood and
+// pronouncing well.
+const (
+	increaseConfidenceThreshold = 0.9
+	increaseISEThreshold        = 80.0
+)
+
+// DifficultyEvent is one turn's comprehension verdict. An empty Direction
+// means the turn was unremarkable.
+type DifficultyEvent struct {
+	Direction string
+}
+
+// DifficultyAdapter turns per-turn comprehension signals into adaptation
+// events: consistently short answers or low pronunciation accuracy mean the
+// learner is struggling, while high ASR confidence with strong ISE scores
+// means there is headroom.
+type DifficultyAdapter struct {
+	// MinWordsForSimplification flags replies shorter than this many words.
+	MinWordsForSimplification int
+	// MinISEForSimplification flags accuracy scores below this value.
+	MinISEForSimplification float64
+}
+
+// NewDifficultyAdapter creates the adapter with the default thresholds.
+func NewDifficultyAdapter() *DifficultyAdapter {
+	return &DifficultyAdapter{
+		MinWordsForSimplification: 5,
+		MinISEForSimplification:   50,
+	}
+}
+
+// Observe classifies one completed turn. iseScore is nil when no evaluation
+// ran for the turn.
+func (a *DifficultyAdapter) Observe(transcript string, asrConfidence float64, iseScore *float64) DifficultyEvent {
+	words := len(strings.Fields(transcript))
+	if words > 0 && words < a.MinWordsForSimplification {
+		return DifficultyEvent{Direction: DirectionSimplify}
+	}
+	if iseScore != nil && *iseScore < a.MinISEForSimplification {
+		return DifficultyEvent{Direction: DirectionSimplify}
+	}
+	if asrConfidence >= increaseConfidenceThreshold && iseScore != nil && *iseScore > increaseISEThreshold {
+		return DifficultyEvent{Direction: DirectionIncrease}
+	}
+	return DifficultyEvent{}
+}
+
+// adaptDifficulty feeds one completed turn into the difficulty adapter. Once
+// adaptStreakLength consecutive turns point the same way the session moves
+// one CEFR level in that direction and the client is told via a
+// difficulty_adapted status message.
+func (h *SpeechHandler) adaptDifficulty(session *VoiceSession, transcript string, asrConfidence float64, iseScore *float64) {
+	event := h.difficulty.Observe(transcript, asrConfidence, iseScore)
+
+	session.adaptMu.Lock()
+	if event.Direction == "" {
+		session.adaptDir = ""
+		session.adaptStreak = 0
+		session.adaptMu.Unlock()
+		return
+	}
+	if event.Direction == session.adaptDir {
+		session.adaptStreak++
+	} else {
+		session.adaptDir = event.Direction
+		session.adaptStreak = 1
+	}
+	if session.adaptStreak < adaptStreakLength {
+		session.adaptMu.Unlock()
+		return
+	}
+	session.adaptStreak = 0
+
+	current := session.AdaptedCEFRLevel
+	if current == "" {
+		current = session.CEFREstimate
+	}
+	step := 1
+	if event.Direction == DirectionSimplify {
+		step = -1
+	}
+	next := h.llmService.AdaptToLevel(current, step)
+	if next == strings.ToUpper(strings.TrimSpace(current)) {
+		// Already at the end of the scale.
+		session.adaptMu.Unlock()
+		return
+	}
+	session.AdaptedCEFRLevel = next
+	session.adaptMu.Unlock()
+
+	logging.SessionLogger(session.ID, h.logger).WithFields(logrus.Fields{
+		"direction": event.Direction,
+		"level":     next,
+	}).Info("Difficulty adapted")
+	h.sendStatus(session, "difficulty_adapted", next)
+}
@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/testutil"
+	speechv1 "github.com/CurvatureX/ai-tutor-monorepo/shared/proto/speech"
+)
+
+func floatPtr(v float64) *float64 { return &v }
+
+func TestDifficultyAdapterObserve(t *testing.T) {
+	adapter := NewDifficultyAdapter()
+
+	cases := []struct {
+		name          string
+		transcript    string
+		asrConfidence float64
+		iseScore      *float64
+		want          string
+	}{
+		{"short reply", "Yes.", 0.8, nil, DirectionSimplify},
+		{"four words", "I do not know", 0.8, nil, DirectionSimplify},
+		{"low ise score", "I went to the market yesterday morning", 0.8, floatPtr(42), DirectionSimplify},
+		{"strong turn", "I went to the market yesterday morning", 0.95, floatPtr(88), DirectionIncrease},
+		{"high confidence without ise", "I went to the market yesterday morning", 0.95, nil, ""},
+		{"ordinary turn", "I went to the market yesterday", 0.7, floatPtr(65), ""},
+		{"empty transcript is not short", "", 0.8, nil, ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			event := adapter.Observe(tc.transcript, tc.asrConfidence, tc.iseScore)
+			if event.Direction != tc.want {
+				t.Errorf("Observe(%q, %v) direction = %q, want %q",
+					tc.transcript, tc.asrConfidence, event.Direction, tc.want)
+			}
+		})
+	}
+}
+
+// waitForStatusCount polls the stream until at least n status messages with
+// the given value have been sent.
+func waitForStatusCount(t *testing.T, stream *fakeConversationStream, status string, n int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		count := 0
+		for _, resp := range stream.responses() {
+			if r, ok := resp.GetResponseType().(*speechv1.VoiceResponse_Status); ok && r.Status.Status == status {
+				count++
+			}
+		}
+		if count >= n {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d %q statuses", n, status)
+}
+
+func TestConsecutiveShortTurnsSimplifyDifficulty(t *testing.T) {
+	llm := testutil.NewMockLLMServer(testutil.MockLLMConfig{ReplyText: "Let's try something easier."})
+	defer llm.Close()
+	tts := testutil.NewMockTTSServer(testutil.MockTTSConfig{AudioChunks: [][]byte{[]byte("audio")}})
+	defer tts.Close()
+
+	h := newTestSpeechHandler(t, llm.URL(), tts.URL())
+	stream := &fakeConversationStream{reqCh: make(chan *speechv1.VoiceRequest, 8)}
+	done := make(chan struct{})
+	go func() {
+		h.ProcessVoiceConversation(stream)
+		close(done)
+	}()
+
+	const sessionID = "adapt-session"
+	// Three consecutive very short replies mark the learner as struggling.
+	// Turns run on pipeline goroutines, so wait for each to complete before
+	// sending the next to keep them consecutive.
+	for i := 1; i <= 3; i++ {
+		stream.reqCh <- &speechv1.VoiceRequest{
+			SessionId:   sessionID,
+			RequestType: &speechv1.VoiceRequest_TextInput{TextInput: &speechv1.TextInput{Text: "Yes."}},
+		}
+		waitForStatusCount(t, stream, "complete", i)
+	}
+
+	var adaptedTo string
+	waitForResponse(t, stream, `status "difficulty_adapted"`, func(resp *speechv1.VoiceResponse) bool {
+		r, ok := resp.GetResponseType().(*speechv1.VoiceResponse_Status)
+		if ok && r.Status.Status == "difficulty_adapted" {
+			adaptedTo = r.Status.Message
+			return true
+		}
+		return false
+	})
+	// The session starts from the B1 fallback, so one step down is A2.
+	if adaptedTo != "A2" {
+		t.Errorf("adapted level = %q, want A2", adaptedTo)
+	}
+
+	h.mu.RLock()
+	session := h.sessions[sessionID]
+	h.mu.RUnlock()
+	if session == nil {
+		t.Fatal("session not found")
+	}
+	if level := session.effectiveCEFRLevel(); level != "A2" {
+		t.Errorf("effective level = %q, want A2", level)
+	}
+
+	close(stream.reqCh)
+	<-done
+}
+
+func TestSingleHardTurnDoesNotAdapt(t *testing.T) {
+	llm := testutil.NewMockLLMServer(testutil.MockLLMConfig{ReplyText: "Good effort, keep going."})
+	defer llm.Close()
+	tts := testutil.NewMockTTSServer(testutil.MockTTSConfig{AudioChunks: [][]byte{[]byte("audio")}})
+	defer tts.Close()
+
+	h := newTestSpeechHandler(t, llm.URL(), tts.URL())
+	stream := &fakeConversationStream{reqCh: make(chan *speechv1.VoiceRequest, 8)}
+	done := make(chan struct{})
+	go func() {
+		h.ProcessVoiceConversation(stream)
+		close(done)
+	}()
+
+	const sessionID = "no-adapt-session"
+	// Two short turns with an ordinary one between them: the streak resets,
+	// so the level must not move.
+	texts := []string{"Yes.", "I think it was a good day overall", "No."}
+	for i, text := range texts {
+		stream.reqCh <- &speechv1.VoiceRequest{
+			SessionId:   sessionID,
+			RequestType: &speechv1.VoiceRequest_TextInput{TextInput: &speechv1.TextInput{Text: text}},
+		}
+		waitForStatusCount(t, stream, "complete", i+1)
+	}
+
+	for _, resp := range stream.responses() {
+		if r, ok := resp.GetResponseType().(*speechv1.VoiceResponse_Status); ok && r.Status.Status == "difficulty_adapted" {
+			t.Fatalf("difficulty adapted to %q without a full streak", r.Status.Message)
+		}
+	}
+
+	close(stream.reqCh)
+	<-done
+}
@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"encoding/binary"
+	"io"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/config"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/events"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/service"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/store"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/testutil"
+	speechv1 "github.com/CurvatureX/ai-tutor-monorepo/shared/proto/speech"
+)
+
+// newFreeSpeakHandler builds a SpeechHandler in free-speak ISE mode with all
+// four pipeline stages pointed at mock servers.
+func newFreeSpeakHandler(t *testing.T, asrURL, iseURL, llmURL, ttsURL string) *SpeechHandler {
+	t.Helper()
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	publisher, err := events.NewPublisher(config.EventsConfig{Publisher: "none"}, logger)
+	if err != nil {
+		t.Fatalf("NewPublisher: %v", err)
+	}
+	return NewSpeechHandler(
+		service.NewASRService(config.ASRConfig{BaseURL: asrURL, Cluster: "test"}, logger),
+		service.NewTTSService(config.TTSConfig{BaseURL: ttsURL, VoiceType: "test_voice", Speed: 1.0}, logger),
+		service.NewLLMService(config.LLMConfig{BaseURL: llmURL, Model: "test-model", MaxTokens: 50}, nil, logger),
+		service.NewISEService(config.ISEConfig{
+			AppID:     "test-app",
+			APIKey:    "test-key",
+			APISecret: "test-secret",
+			BaseURL:   iseURL,
+		}, logger),
+		service.NewAudioService(config.AudioConfig{}, logger),
+		publisher, store.NewSessionRecorder(false, nil, logger), 0, 0, pausePolicyBuffer,
+		ISEModeFreeSpeak, config.SLOConfig{}, logger)
+}
+
+// loudPCM builds an utterance every silence filter keeps.
+func loudPCM(n int) []byte {
+	audio := make([]byte, n)
+	for i := 0; i+1 < n; i += 2 {
+		binary.LittleEndian.PutUint16(audio[i:], 4000)
+	}
+	return audio
+}
+
+func freeSpeakAudioRequest(sessionID string) *speechv1.VoiceRequest {
+	return &speechv1.VoiceRequest{
+		SessionId: sessionID,
+		UserId:    "learner-1",
+		RequestType: &speechv1.VoiceRequest_AudioData{
+			AudioData: &speechv1.AudioChunk{Data: loudPCM(16000), Format: "pcm", IsFinal: true},
+		},
+	}
+}
+
+func TestFreeSpeakModeScoresAgainstTranscript(t *testing.T) {
+	asr := testutil.NewMockASRServer(testutil.MockASRConfig{Transcript: "hello there", Confidence: 0.93})
+	defer asr.Close()
+	ise := testutil.NewMockISEServer(testutil.MockISEConfig{})
+	defer ise.Close()
+	llm := testutil.NewMockLLMServer(testutil.MockLLMConfig{ReplyText: "Nice to meet you!", TokensUsed: 8})
+	defer llm.Close()
+	tts := testutil.NewMockTTSServer(testutil.MockTTSConfig{AudioChunks: [][]byte{[]byte("audio")}})
+	defer tts.Close()
+
+	h := newFreeSpeakHandler(t, asr.URL(), ise.URL(), llm.URL(), tts.URL())
+	stream := newFakeStream(freeSpeakAudioRequest("free-speak-session"))
+	if err := h.ProcessVoiceConversation(stream); err != nil {
+		t.Fatalf("ProcessVoiceConversation: %v", err)
+	}
+
+	var result *speechv1.ISEResult
+	waitForResponse(t, stream, "ise result", func(resp *speechv1.VoiceResponse) bool {
+		if r, ok := resp.GetResponseType().(*speechv1.VoiceResponse_IseResult); ok {
+			result = r.IseResult
+			return true
+		}
+		return false
+	})
+	if result.Mode != "free_speak" {
+		t.Errorf("Mode = %q, want free_speak", result.Mode)
+	}
+	if got := ise.ReferenceText(); got != "hello there" {
+		t.Errorf("evaluated reference text = %q, want the ASR transcript", got)
+	}
+}
+
+func TestFreeSpeakModeSkipsISEWhenASRFails(t *testing.T) {
+	asr := testutil.NewMockASRServer(testutil.MockASRConfig{ErrorFrame: true})
+	defer asr.Close()
+	ise := testutil.NewMockISEServer(testutil.MockISEConfig{})
+	defer ise.Close()
+	llm := testutil.NewMockLLMServer(testutil.MockLLMConfig{ReplyText: "unused"})
+	defer llm.Close()
+	tts := testutil.NewMockTTSServer(testutil.MockTTSConfig{AudioChunks: [][]byte{[]byte("audio")}})
+	defer tts.Close()
+
+	h := newFreeSpeakHandler(t, asr.URL(), ise.URL(), llm.URL(), tts.URL())
+	stream := newFakeStream(freeSpeakAudioRequest("free-speak-asr-error"))
+	if err := h.ProcessVoiceConversation(stream); err != nil {
+		t.Fatalf("ProcessVoiceConversation: %v", err)
+	}
+
+	waitForResponse(t, stream, "asr error", func(resp *speechv1.VoiceResponse) bool {
+		r, ok := resp.GetResponseType().(*speechv1.VoiceResponse_Error)
+		return ok && r.Error.Code == speechv1.ErrorCode_ERROR_CODE_ASR_FAILED
+	})
+	if frames := ise.Frames(); len(frames) != 0 {
+		t.Errorf("ISE server saw %d frames after an ASR failure, want none", len(frames))
+	}
+}
@@ -0,0 +1,144 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/logging"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/service"
+)
+
+// historyTTL is how long an ended session's conversation history stays
+// exportable and resumable.
+const historyTTL = 24 * time.Hour
+
+// maxResumedTurns bounds how many prior user turns a resumed or imported
+// history carries into a session, so a long practice streak cannot overflow
+// the LLM context.
+const maxResumedTurns = 20
+
+// storedHistory is one ended session's conversation, kept for export and
+// resumption.
+type storedHistory struct {
+	history  *model.ConversationHistory
+	storedAt time.Time
+}
+
+// retireSessionHistory keeps the ended session's conversation around for
+// export and resumption, pruning entries past their TTL so the map cannot
+// grow without bound.
+func (h *SpeechHandler) retireSessionHistory(session *VoiceSession) {
+	messages := session.ConversationHistory.Snapshot()
+	if len(messages) == 0 {
+		return
+	}
+	snapshot := &model.ConversationHistory{Messages: messages}
+	h.historyMu.Lock()
+	defer h.historyMu.Unlock()
+	for id, stored := range h.histories {
+		if time.Since(stored.storedAt) > historyTTL {
+			delete(h.histories, id)
+		}
+	}
+	h.histories[session.ID] = storedHistory{history: snapshot, storedAt: time.Now()}
+}
+
+// sessionHistory looks a conversation history up by session ID: a live
+// session's history first, then the retired ones.
+func (h *SpeechHandler) sessionHistory(id string) (*model.ConversationHistory, bool) {
+	h.mu.RLock()
+	session, ok := h.sessions[id]
+	h.mu.RUnlock()
+	if ok {
+		return session.ConversationHistory, true
+	}
+	h.historyMu.Lock()
+	defer h.historyMu.Unlock()
+	stored, ok := h.histories[id]
+	if !ok || time.Since(stored.storedAt) > historyTTL {
+		return nil, false
+	}
+	return stored.history, true
+}
+
+// historyFormat maps the format query value onto an export format; JSON is
+// the default.
+func historyFormat(c *gin.Context) service.ExportFormat {
+	if c.Query("format") == "text" {
+		return service.ExportFormatText
+	}
+	return service.ExportFormatJSON
+}
+
+// ServeSessionHistory handles GET /sessions/:id/history, exporting the
+// session's conversation as JSON, or as plain text with ?format=text.
+func (h *SpeechHandler) ServeSessionHistory(c *gin.Context) {
+	history, ok := h.sessionHistory(c.Param("id"))
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "history not found"})
+		return
+	}
+	format := historyFormat(c)
+	data, err := h.historyExporter.Export(history, format)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	if format == service.ExportFormatText {
+		c.Data(http.StatusOK, "text/plain; charset=utf-8", data)
+		return
+	}
+	c.Data(http.StatusOK, "application/json", data)
+}
+
+// ImportSessionHistory handles POST /sessions/:id/history, seeding a live
+// session's conversation with an exported history (JSON, or plain text with
+// ?format=text). The import replaces the session's history, trimmed to the
+// most recent turns.
+func (h *SpeechHandler) ImportSessionHistory(c *gin.Context) {
+	h.mu.RLock()
+	session, ok := h.sessions[c.Param("id")]
+	h.mu.RUnlock()
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "session not found"})
+		return
+	}
+	data, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	history, err := h.historyImporter.Import(data, historyFormat(c))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	trimmed := service.TrimToRecentTurns(history, maxResumedTurns)
+	session.ConversationHistory.Replace(trimmed.Messages)
+	c.JSON(http.StatusOK, gin.H{"messages": len(trimmed.Messages)})
+}
+
+// resumeHistory seeds a fresh session with the tail of a previous session's
+// conversation, so the tutor remembers what was practiced before. A missing
+// or expired history is reported but never fails the session: starting fresh
+// is the sensible fallback.
+func (h *SpeechHandler) resumeHistory(session *VoiceSession, previousID string) {
+	history, ok := h.sessionHistory(previousID)
+	if !ok {
+		logging.SessionLogger(session.ID, h.logger).WithField("previous_session", previousID).
+			Warn("No history to resume; starting fresh")
+		h.sendStatus(session, "resume_unavailable", "no history for session "+previousID)
+		return
+	}
+	trimmed := service.TrimToRecentTurns(history, maxResumedTurns)
+	session.ConversationHistory.Replace(append([]model.ChatMessage(nil), trimmed.Messages...))
+	logging.SessionLogger(session.ID, h.logger).WithFields(logrus.Fields{
+		"previous_session": previousID,
+		"messages":         len(trimmed.Messages),
+	}).Info("Resumed conversation history from previous session")
+	h.sendStatus(session, "history_resumed", previousID)
+}
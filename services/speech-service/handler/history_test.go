@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/testutil"
+	speechv1 "github.com/CurvatureX/ai-tutor-monorepo/shared/proto/speech"
+)
+
+// historyRouter exposes the history endpoints the way main.go registers them.
+func historyRouter(h *SpeechHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/sessions/:id/history", h.ServeSessionHistory)
+	router.POST("/sessions/:id/history", h.ImportSessionHistory)
+	return router
+}
+
+// runHistoryTurn exchanges one text turn on a fresh session and ends it, so
+// its history is retired for export and resumption. The stream stays open
+// until the turn completes, so the retired history holds the full exchange.
+func runHistoryTurn(t *testing.T, h *SpeechHandler, sessionID, text string) {
+	t.Helper()
+	stream := &fakeConversationStream{reqCh: make(chan *speechv1.VoiceRequest, 2)}
+	done := make(chan struct{})
+	go func() {
+		h.ProcessVoiceConversation(stream)
+		close(done)
+	}()
+	stream.reqCh <- &speechv1.VoiceRequest{
+		SessionId: sessionID,
+		UserId:    "learner-1",
+		RequestType: &speechv1.VoiceRequest_Control{
+			Control: &speechv1.ControlMessage{
+				Action:     speechv1.ControlAction_CONTROL_ACTION_CONFIGURE_SESSION,
+				Parameters: map[string]string{"tutor_starts": "false"},
+			},
+		},
+	}
+	stream.reqCh <- &speechv1.VoiceRequest{
+		SessionId:   sessionID,
+		RequestType: &speechv1.VoiceRequest_TextInput{TextInput: &speechv1.TextInput{Text: text}},
+	}
+	waitForResponse(t, stream, `status "complete"`, func(resp *speechv1.VoiceResponse) bool {
+		r, ok := resp.GetResponseType().(*speechv1.VoiceResponse_Status)
+		return ok && r.Status.Status == "complete"
+	})
+	close(stream.reqCh)
+	<-done
+}
+
+func TestSessionHistoryExportAndResume(t *testing.T) {
+	llm := testutil.NewMockLLMServer(testutil.MockLLMConfig{ReplyText: "Which exhibit did you like best?"})
+	defer llm.Close()
+	tts := testutil.NewMockTTSServer(testutil.MockTTSConfig{AudioChunks: [][]byte{[]byte("audio")}})
+	defer tts.Close()
+
+	h := newTestSpeechHandler(t, llm.URL(), tts.URL())
+	runHistoryTurn(t, h, "day-one-session", "I visited the museum")
+
+	// The stream closed, so the session ended and its history was retired.
+	router := historyRouter(h)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/sessions/day-one-session/history", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET history = %d, want 200", rec.Code)
+	}
+	var exported struct {
+		Messages []struct {
+			Role    string `json:"role"`
+			Content string `json:"content"`
+		} `json:"messages"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &exported); err != nil {
+		t.Fatalf("parse exported history: %v", err)
+	}
+	var sawUser, sawAssistant bool
+	for _, msg := range exported.Messages {
+		sawUser = sawUser || (msg.Role == "user" && msg.Content == "I visited the museum")
+		sawAssistant = sawAssistant || msg.Role == "assistant"
+	}
+	if !sawUser || !sawAssistant {
+		t.Errorf("exported history missing the exchanged turn: %+v", exported.Messages)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/sessions/day-one-session/history?format=text", nil))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "[user] I visited the museum") {
+		t.Errorf("GET text history = %d, body:\n%s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/sessions/unknown/history", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("GET unknown history = %d, want 404", rec.Code)
+	}
+
+	// A new session resuming day one carries its conversation forward.
+	stream := newFakeStream(&speechv1.VoiceRequest{
+		SessionId: "day-two-session",
+		UserId:    "learner-1",
+		RequestType: &speechv1.VoiceRequest_Control{
+			Control: &speechv1.ControlMessage{
+				Action:     speechv1.ControlAction_CONTROL_ACTION_CONFIGURE_SESSION,
+				Parameters: map[string]string{"tutor_starts": "false", "resume": "day-one-session"},
+			},
+		},
+	})
+	if err := h.ProcessVoiceConversation(stream); err != nil {
+		t.Fatalf("ProcessVoiceConversation: %v", err)
+	}
+	waitForResponse(t, stream, `status "history_resumed"`, func(resp *speechv1.VoiceResponse) bool {
+		r, ok := resp.GetResponseType().(*speechv1.VoiceResponse_Status)
+		return ok && r.Status.Status == "history_resumed" && r.Status.Message == "day-one-session"
+	})
+}
+
+func TestResumeUnknownSessionStartsFresh(t *testing.T) {
+	llm := testutil.NewMockLLMServer(testutil.MockLLMConfig{ReplyText: "unused"})
+	defer llm.Close()
+	tts := testutil.NewMockTTSServer(testutil.MockTTSConfig{AudioChunks: [][]byte{[]byte("audio")}})
+	defer tts.Close()
+
+	h := newTestSpeechHandler(t, llm.URL(), tts.URL())
+	stream := newFakeStream(&speechv1.VoiceRequest{
+		SessionId: "fresh-session",
+		RequestType: &speechv1.VoiceRequest_Control{
+			Control: &speechv1.ControlMessage{
+				Action:     speechv1.ControlAction_CONTROL_ACTION_CONFIGURE_SESSION,
+				Parameters: map[string]string{"tutor_starts": "false", "resume": "never-existed"},
+			},
+		},
+	})
+	if err := h.ProcessVoiceConversation(stream); err != nil {
+		t.Fatalf("ProcessVoiceConversation: %v", err)
+	}
+	waitForResponse(t, stream, `status "resume_unavailable"`, func(resp *speechv1.VoiceResponse) bool {
+		r, ok := resp.GetResponseType().(*speechv1.VoiceResponse_Status)
+		return ok && r.Status.Status == "resume_unavailable"
+	})
+	waitForResponse(t, stream, `status "session_configured"`, func(resp *speechv1.VoiceResponse) bool {
+		r, ok := resp.GetResponseType().(*speechv1.VoiceResponse_Status)
+		return ok && r.Status.Status == "session_configured"
+	})
+}
+
+func TestImportSessionHistoryIntoLiveSession(t *testing.T) {
+	llm := testutil.NewMockLLMServer(testutil.MockLLMConfig{ReplyText: "unused"})
+	defer llm.Close()
+	tts := testutil.NewMockTTSServer(testutil.MockTTSConfig{AudioChunks: [][]byte{[]byte("audio")}})
+	defer tts.Close()
+
+	h := newTestSpeechHandler(t, llm.URL(), tts.URL())
+	stream := &fakeConversationStream{reqCh: make(chan *speechv1.VoiceRequest, 2)}
+	done := make(chan struct{})
+	go func() {
+		h.ProcessVoiceConversation(stream)
+		close(done)
+	}()
+	stream.reqCh <- &speechv1.VoiceRequest{
+		SessionId: "import-session",
+		RequestType: &speechv1.VoiceRequest_Control{
+			Control: &speechv1.ControlMessage{
+				Action:     speechv1.ControlAction_CONTROL_ACTION_CONFIGURE_SESSION,
+				Parameters: map[string]string{"tutor_starts": "false"},
+			},
+		},
+	}
+	waitForResponse(t, stream, `status "session_configured"`, func(resp *speechv1.VoiceResponse) bool {
+		r, ok := resp.GetResponseType().(*speechv1.VoiceResponse_Status)
+		return ok && r.Status.Status == "session_configured"
+	})
+
+	router := historyRouter(h)
+	body := `{"messages":[{"role":"user","content":"imported turn"},{"role":"assistant","content":"imported reply"}]}`
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/sessions/import-session/history", strings.NewReader(body)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("POST history = %d, body %s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/sessions/import-session/history?format=text", nil))
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "[user] imported turn") {
+		t.Errorf("GET after import = %d, body:\n%s", rec.Code, rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/sessions/unknown/history", strings.NewReader(body)))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("POST to unknown session = %d, want 404", rec.Code)
+	}
+
+	close(stream.reqCh)
+	<-done
+}
@@ -0,0 +1,33 @@
+package handler
+
+// ISEMode selects where the reference text for pronunciation evaluation
+// comes from.
+type ISEMode int
+
+const (
+	// ISEModeReferenced scores against the session's reference text, the
+	// read-aloud behavior the service has always had.
+	ISEModeReferenced ISEMode = iota
+	// ISEModeFreeSpeak scores free speech against the ASR transcript of the
+	// utterance itself, so the evaluation measures how clearly the learner
+	// said whatever they chose to say. ISE waits for ASR instead of running
+	// alongside it.
+	ISEModeFreeSpeak
+)
+
+// String returns the mode name used in config and result messages.
+func (m ISEMode) String() string {
+	if m == ISEModeFreeSpeak {
+		return "free_speak"
+	}
+	return "referenced"
+}
+
+// ParseISEMode maps the ISE_MODE setting to a mode; anything but
+// "free_speak" keeps the referenced default.
+func ParseISEMode(s string) ISEMode {
+	if s == "free_speak" {
+		return ISEModeFreeSpeak
+	}
+	return ISEModeReferenced
+}
@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/config"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/logging"
+)
+
+var (
+	laneQueueWait = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "lane_queue_wait_seconds",
+		Help:    "Time provider-bound jobs spent waiting for a lane slot.",
+		Buckets: []float64{0.001, 0.01, 0.05, 0.1, 0.25, 0.5, 1, 2, 5, 10, 30},
+	}, []string{"provider", "lane"})
+	laneWaitingJobs = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "lane_waiting_jobs",
+		Help: "Provider-bound jobs currently queued for a lane slot.",
+	}, []string{"provider", "lane"})
+)
+
+// Lane labels which scheduling queue a provider-bound job belongs to.
+type Lane string
+
+const (
+	// LaneInteractive is the lane for the conversational stages of short
+	// utterances, where the learner is waiting on the reply.
+	LaneInteractive Lane = "interactive"
+	// LaneBatch is the lane for work that tolerates queueing: evaluations of
+	// long recordings and the per-sentence stages of multi-utterance splits.
+	LaneBatch Lane = "batch"
+)
+
+// LaneScheduler budgets one provider's concurrency across the two lanes.
+// Because each lane has its own slots, a run of long batch jobs queues among
+// themselves and never delays interactive work — the worker pool already
+// bounds total utterances in flight; this bounds what each provider sees.
+type LaneScheduler struct {
+	provider    string
+	slots       map[Lane]chan struct{}
+	waitTimeout time.Duration
+}
+
+// NewLaneScheduler builds the scheduler for one provider. A lane with budget
+// 0 is unbounded and never queues; waitTimeout bounds how long an acquisition
+// queues before giving up, with 0 waiting as long as the context allows.
+func NewLaneScheduler(provider string, budget config.LaneBudget, waitTimeout time.Duration) *LaneScheduler {
+	s := &LaneScheduler{
+		provider:    provider,
+		slots:       make(map[Lane]chan struct{}),
+		waitTimeout: waitTimeout,
+	}
+	if budget.Interactive > 0 {
+		s.slots[LaneInteractive] = make(chan struct{}, budget.Interactive)
+	}
+	if budget.Batch > 0 {
+		s.slots[LaneBatch] = make(chan struct{}, budget.Batch)
+	}
+	return s
+}
+
+// Acquire blocks until the lane has a free slot and returns the release the
+// caller must invoke as soon as its provider call finishes. The wait is
+// cancellable: it ends early with the context's error when the context is
+// done or the scheduler's wait timeout passes.
+func (s *LaneScheduler) Acquire(ctx context.Context, lane Lane) (release func(), err error) {
+	sem := s.slots[lane]
+	if sem == nil {
+		return func() {}, nil
+	}
+	if s.waitTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.waitTimeout)
+		defer cancel()
+	}
+	start := time.Now()
+	laneWaitingJobs.WithLabelValues(s.provider, string(lane)).Inc()
+	defer laneWaitingJobs.WithLabelValues(s.provider, string(lane)).Dec()
+	select {
+	case sem <- struct{}{}:
+		laneQueueWait.WithLabelValues(s.provider, string(lane)).Observe(time.Since(start).Seconds())
+		return func() { <-sem }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// LaneSchedulers is the per-provider scheduler set plus the duration at which
+// a turn's provider work counts as batch.
+type LaneSchedulers struct {
+	byProvider map[string]*LaneScheduler
+	batchAudio time.Duration
+}
+
+// NewLaneSchedulers builds the scheduler set from configuration.
+func NewLaneSchedulers(cfg config.SchedulerConfig) *LaneSchedulers {
+	return &LaneSchedulers{
+		byProvider: map[string]*LaneScheduler{
+			"asr": NewLaneScheduler("asr", cfg.ASR, cfg.WaitTimeout),
+			"llm": NewLaneScheduler("llm", cfg.LLM, cfg.WaitTimeout),
+			"tts": NewLaneScheduler("tts", cfg.TTS, cfg.WaitTimeout),
+			"ise": NewLaneScheduler("ise", cfg.ISE, cfg.WaitTimeout),
+		},
+		batchAudio: cfg.BatchAudioThreshold,
+	}
+}
+
+// laneFor classifies a turn's provider work by utterance length: at or past
+// the batch threshold it joins the batch lane, where long evaluations queue
+// among themselves. Without schedulers or a threshold everything stays
+// interactive.
+func (h *SpeechHandler) laneFor(durationMs int64) Lane {
+	if h.lanes != nil && h.lanes.batchAudio > 0 && durationMs >= h.lanes.batchAudio.Milliseconds() {
+		return LaneBatch
+	}
+	return LaneInteractive
+}
+
+// acquireLane reserves a slot on the provider's lane ahead of a provider
+// call. The caller invokes the returned release as soon as the call returns —
+// never deferred across the rest of the turn, which would hold the slot
+// through unrelated stages. A non-nil error means the wait was abandoned
+// (stream ended, or the job's deadline passed) and the stage must not run;
+// the abandonment is logged here, what to tell the client is the caller's
+// decision since a skipped evaluation and a dropped reply read differently.
+func (h *SpeechHandler) acquireLane(session *VoiceSession, provider string, lane Lane) (func(), error) {
+	if h.lanes == nil {
+		return func() {}, nil
+	}
+	s := h.lanes.byProvider[provider]
+	if s == nil {
+		return func() {}, nil
+	}
+	release, err := s.Acquire(session.stream.Context(), lane)
+	if err != nil {
+		logging.SessionLogger(session.ID, h.logger).WithError(err).WithFields(logrus.Fields{
+			logging.LogFieldService: provider,
+			"lane":                  lane,
+		}).Warn("Abandoned wait for a scheduler lane slot")
+		return nil, err
+	}
+	return release, nil
+}
@@ -0,0 +1,130 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/config"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/testutil"
+	speechv1 "github.com/CurvatureX/ai-tutor-monorepo/shared/proto/speech"
+)
+
+func TestLaneSchedulerKeepsInteractiveSlotsFree(t *testing.T) {
+	s := NewLaneScheduler("ise", config.LaneBudget{Interactive: 1, Batch: 1}, 50*time.Millisecond)
+
+	// A long job holds the only batch slot; the next batch job queues until
+	// its deadline passes.
+	releaseBatch, err := s.Acquire(context.Background(), LaneBatch)
+	if err != nil {
+		t.Fatalf("Acquire(batch) on an idle lane: %v", err)
+	}
+	if _, err := s.Acquire(context.Background(), LaneBatch); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("second batch Acquire err = %v, want deadline exceeded", err)
+	}
+
+	// The saturated batch lane never touches the interactive budget.
+	start := time.Now()
+	release, err := s.Acquire(context.Background(), LaneInteractive)
+	if err != nil {
+		t.Fatalf("Acquire(interactive) with batch saturated: %v", err)
+	}
+	if wait := time.Since(start); wait >= 50*time.Millisecond {
+		t.Errorf("interactive acquisition waited %v behind batch work", wait)
+	}
+	release()
+	releaseBatch()
+
+	// The released batch slot is usable again.
+	if releaseBatch, err = s.Acquire(context.Background(), LaneBatch); err != nil {
+		t.Fatalf("Acquire(batch) after release: %v", err)
+	}
+	releaseBatch()
+}
+
+func TestLaneAcquireCancellable(t *testing.T) {
+	s := NewLaneScheduler("asr", config.LaneBudget{Interactive: 1}, time.Minute)
+	release, err := s.Acquire(context.Background(), LaneInteractive)
+	if err != nil {
+		t.Fatalf("Acquire: %v", err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := s.Acquire(ctx, LaneInteractive); !errors.Is(err, context.Canceled) {
+		t.Errorf("Acquire on a cancelled context err = %v, want canceled", err)
+	}
+
+	// A lane without a budget never queues, cancelled context or not.
+	if _, err := s.Acquire(ctx, LaneBatch); err != nil {
+		t.Errorf("Acquire on an unbudgeted lane: %v", err)
+	}
+}
+
+// TestInteractiveTurnUnaffectedByQueuedBatchISE drives a long referenced
+// recording through the full pipeline while another long evaluation holds the
+// ISE batch lane: the conversational stages complete normally, and the turn's
+// evaluation arrives only once the lane frees up.
+func TestInteractiveTurnUnaffectedByQueuedBatchISE(t *testing.T) {
+	asr := testutil.NewMockASRServer(testutil.MockASRConfig{Transcript: "hello there", Confidence: 0.93})
+	defer asr.Close()
+	ise := testutil.NewMockISEServer(testutil.MockISEConfig{})
+	defer ise.Close()
+	llm := testutil.NewMockLLMServer(testutil.MockLLMConfig{ReplyText: "Nicely read!", TokensUsed: 4})
+	defer llm.Close()
+	tts := testutil.NewMockTTSServer(testutil.MockTTSConfig{AudioChunks: [][]byte{[]byte("audio")}})
+	defer tts.Close()
+
+	h := newSplitHandler(t, asr.URL(), ise.URL(), llm.URL(), tts.URL())
+	lanes := NewLaneSchedulers(config.SchedulerConfig{
+		ISE:                 config.LaneBudget{Interactive: 2, Batch: 1},
+		BatchAudioThreshold: time.Second,
+		WaitTimeout:         10 * time.Second,
+	})
+	h.SetLaneSchedulers(lanes)
+
+	// Another session's long evaluation occupies the only batch slot.
+	releaseBatch, err := lanes.byProvider["ise"].Acquire(context.Background(), LaneBatch)
+	if err != nil {
+		t.Fatalf("Acquire(batch): %v", err)
+	}
+
+	// Two seconds of audio: past the threshold, so this turn's evaluation
+	// joins the batch queue while ASR, LLM and TTS run unscheduled.
+	stream := newFakeStream(
+		&speechv1.VoiceRequest{
+			SessionId: "lane-session",
+			RequestType: &speechv1.VoiceRequest_Control{
+				Control: &speechv1.ControlMessage{
+					Action:     speechv1.ControlAction_CONTROL_ACTION_SET_REFERENCE_TEXT,
+					Parameters: map[string]string{"text": "hello there"},
+				},
+			},
+		},
+		&speechv1.VoiceRequest{
+			SessionId: "lane-session",
+			RequestType: &speechv1.VoiceRequest_AudioData{
+				AudioData: &speechv1.AudioChunk{Data: loudPCM(64000), Format: "pcm", IsFinal: true},
+			},
+		})
+	if err := h.ProcessVoiceConversation(stream); err != nil {
+		t.Fatalf("ProcessVoiceConversation: %v", err)
+	}
+
+	waitForResponse(t, stream, "complete status with the batch lane held", func(resp *speechv1.VoiceResponse) bool {
+		s := resp.GetStatus()
+		return s != nil && s.Status == "complete"
+	})
+	for _, resp := range stream.responses() {
+		if resp.GetIseResult() != nil {
+			t.Fatal("evaluation ran while the batch lane was fully occupied")
+		}
+	}
+
+	releaseBatch()
+	waitForResponse(t, stream, "ise result after the lane freed", func(resp *speechv1.VoiceResponse) bool {
+		return resp.GetIseResult() != nil
+	})
+}
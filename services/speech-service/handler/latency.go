@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/events"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/logging"
+	speechv1 "github.com/CurvatureX/ai-tutor-monorepo/shared/proto/speech"
+)
+
+var stageDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "pipeline_stage_duration_seconds",
+	Help:    "Per-turn pipeline stage latency, by stage.",
+	Buckets: []float64{0.1, 0.25, 0.5, 1, 2, 4, 8, 16},
+}, []string{"stage"})
+
+// timingsProto converts the turn's timings for the StatusResult completion
+// message. ISE runs alongside the conversational stages, so its timing is
+// only present when the evaluation finished before the turn completed.
+func timingsProto(t events.TurnTimings) *speechv1.StageTimings {
+	return &speechv1.StageTimings{
+		AsrMs:          t.ASRMs,
+		LlmMs:          t.LLMMs,
+		TtsFirstByteMs: t.TTSFirstByteMs,
+		TtsMs:          t.TTSMs,
+		IseMs:          t.ISEMs,
+		TotalMs:        t.ASRMs + t.LLMMs + t.TTSMs,
+	}
+}
+
+// observeStageTimings records the turn's stage latencies in the histograms
+// and emits one structured warning when any stage blew its latency budget.
+func (h *SpeechHandler) observeStageTimings(session *VoiceSession, turnID int, t events.TurnTimings) {
+	stages := []struct {
+		name   string
+		ms     int64
+		budget time.Duration
+	}{
+		{"asr", t.ASRMs, h.slo.ASRWarn},
+		{"llm", t.LLMMs, h.slo.LLMWarn},
+		{"tts_first_byte", t.TTSFirstByteMs, h.slo.TTSFirstByteWarn},
+		{"tts", t.TTSMs, h.slo.TTSWarn},
+		{"ise", t.ISEMs, h.slo.ISEWarn},
+	}
+
+	var over []string
+	for _, stage := range stages {
+		if stage.ms > 0 {
+			stageDuration.WithLabelValues(stage.name).Observe(float64(stage.ms) / 1000)
+		}
+		if stage.budget > 0 && stage.ms > stage.budget.Milliseconds() {
+			over = append(over, stage.name)
+		}
+	}
+	if len(over) == 0 {
+		return
+	}
+	logging.SessionLogger(session.ID, h.logger).WithFields(logrus.Fields{
+		"turn_id":           turnID,
+		"over_budget":       over,
+		"asr_ms":            t.ASRMs,
+		"llm_ms":            t.LLMMs,
+		"tts_first_byte_ms": t.TTSFirstByteMs,
+		"tts_ms":            t.TTSMs,
+		"ise_ms":            t.ISEMs,
+	}).Warn("Turn exceeded latency budget")
+}
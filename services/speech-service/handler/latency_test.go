@@ -0,0 +1,118 @@
+package handler
+
+import (
+	"io"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/config"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/events"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/service"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/store"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/testutil"
+	speechv1 "github.com/CurvatureX/ai-tutor-monorepo/shared/proto/speech"
+)
+
+// newSLOTestHandler is newTestSpeechHandler with latency budgets and a log
+// hook for asserting on the over-budget warning.
+func newSLOTestHandler(t *testing.T, llmURL, ttsURL string, slo config.SLOConfig) (*SpeechHandler, *test.Hook) {
+	t.Helper()
+	logger, hook := test.NewNullLogger()
+	logger.SetOutput(io.Discard)
+
+	publisher, err := events.NewPublisher(config.EventsConfig{Publisher: "none"}, logger)
+	if err != nil {
+		t.Fatalf("NewPublisher: %v", err)
+	}
+	h := NewSpeechHandler(
+		service.NewASRService(config.ASRConfig{}, logger),
+		service.NewTTSService(config.TTSConfig{BaseURL: ttsURL, VoiceType: "test_voice", Speed: 1.0}, logger),
+		service.NewLLMService(config.LLMConfig{BaseURL: llmURL, Model: "test-model", MaxTokens: 50}, nil, logger),
+		service.NewISEService(config.ISEConfig{}, logger),
+		service.NewAudioService(config.AudioConfig{}, logger),
+		publisher, store.NewSessionRecorder(false, nil, logger), 0, 0, pausePolicyBuffer,
+		ISEModeReferenced, slo, logger)
+	return h, hook
+}
+
+func TestSlowStageLogsLatencyWarningAndAttachesTimings(t *testing.T) {
+	llm := testutil.NewMockLLMServer(testutil.MockLLMConfig{ReplyText: "Sorry for the wait.", Delay: 50 * time.Millisecond})
+	defer llm.Close()
+	tts := testutil.NewMockTTSServer(testutil.MockTTSConfig{AudioChunks: [][]byte{[]byte("audio")}})
+	defer tts.Close()
+
+	h, hook := newSLOTestHandler(t, llm.URL(), tts.URL(), config.SLOConfig{LLMWarn: 10 * time.Millisecond})
+	stream := newFakeStream(&speechv1.VoiceRequest{
+		SessionId:   "slow-turn-session",
+		RequestType: &speechv1.VoiceRequest_TextInput{TextInput: &speechv1.TextInput{Text: "Tell me a story"}},
+	})
+	if err := h.ProcessVoiceConversation(stream); err != nil {
+		t.Fatalf("ProcessVoiceConversation: %v", err)
+	}
+
+	var timings *speechv1.StageTimings
+	waitForResponse(t, stream, `status "complete" with timings`, func(resp *speechv1.VoiceResponse) bool {
+		r, ok := resp.GetResponseType().(*speechv1.VoiceResponse_Status)
+		if ok && r.Status.Status == "complete" {
+			timings = r.Status.Timings
+			return true
+		}
+		return false
+	})
+	if timings == nil {
+		t.Fatal("complete status carries no timings")
+	}
+	if timings.LlmMs < 50 {
+		t.Errorf("llm_ms = %d, want at least the mock's 50ms delay", timings.LlmMs)
+	}
+	if timings.TotalMs < timings.LlmMs {
+		t.Errorf("total_ms = %d is less than llm_ms %d", timings.TotalMs, timings.LlmMs)
+	}
+
+	var warned bool
+	for _, entry := range hook.AllEntries() {
+		if entry.Level != logrus.WarnLevel || entry.Message != "Turn exceeded latency budget" {
+			continue
+		}
+		warned = true
+		over, _ := entry.Data["over_budget"].([]string)
+		if len(over) != 1 || over[0] != "llm" {
+			t.Errorf("over_budget = %v, want [llm]", over)
+		}
+		if entry.Data["llm_ms"].(int64) < 50 {
+			t.Errorf("warn llm_ms = %v, want at least 50", entry.Data["llm_ms"])
+		}
+	}
+	if !warned {
+		t.Error("no latency budget warning was logged")
+	}
+}
+
+func TestFastTurnLogsNoLatencyWarning(t *testing.T) {
+	llm := testutil.NewMockLLMServer(testutil.MockLLMConfig{ReplyText: "Quick reply."})
+	defer llm.Close()
+	tts := testutil.NewMockTTSServer(testutil.MockTTSConfig{AudioChunks: [][]byte{[]byte("audio")}})
+	defer tts.Close()
+
+	h, hook := newSLOTestHandler(t, llm.URL(), tts.URL(), config.SLOConfig{LLMWarn: 5 * time.Second, TTSWarn: 5 * time.Second})
+	stream := newFakeStream(&speechv1.VoiceRequest{
+		SessionId:   "fast-turn-session",
+		RequestType: &speechv1.VoiceRequest_TextInput{TextInput: &speechv1.TextInput{Text: "Hello there"}},
+	})
+	if err := h.ProcessVoiceConversation(stream); err != nil {
+		t.Fatalf("ProcessVoiceConversation: %v", err)
+	}
+	waitForResponse(t, stream, `status "complete"`, func(resp *speechv1.VoiceResponse) bool {
+		r, ok := resp.GetResponseType().(*speechv1.VoiceResponse_Status)
+		return ok && r.Status.Status == "complete"
+	})
+
+	for _, entry := range hook.AllEntries() {
+		if entry.Message == "Turn exceeded latency budget" {
+			t.Fatalf("unexpected latency warning: %v", entry.Data)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/memory"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+)
+
+// TestAudioBufferingRejectedOverMemoryBudget drives a session past its memory
+// budget with binary frames and verifies the resource_exhausted rejection,
+// then that clearing the buffer (start_recording) makes room again.
+func TestAudioBufferingRejectedOverMemoryBudget(t *testing.T) {
+	h, wsManager, _, _ := newCloseTestHandler(t)
+	accountant := memory.NewAccountant(64, 0)
+	h.SetMemoryAccountant(accountant)
+	wsManager.SetMemoryAccountant(accountant)
+
+	srv := httptest.NewServer(http.HandlerFunc(h.HandleWebSocket))
+	defer srv.Close()
+	conn, _, err := websocket.DefaultDialer.Dial(
+		"ws"+strings.TrimPrefix(srv.URL, "http")+"?session_id=over-budget", nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	frame := bytes.Repeat([]byte{0xAB}, 48)
+	if err := conn.WriteMessage(websocket.BinaryMessage, frame); err != nil {
+		t.Fatalf("send first frame: %v", err)
+	}
+	// The second frame would exceed the 64-byte budget.
+	if err := conn.WriteMessage(websocket.BinaryMessage, frame); err != nil {
+		t.Fatalf("send second frame: %v", err)
+	}
+
+	msg := readUntilType(t, conn, model.MessageTypeError)
+	text, _ := msg.Data["message"].(string)
+	if !strings.Contains(text, "resource_exhausted") {
+		t.Fatalf("error message = %q, want the resource_exhausted marker", text)
+	}
+	if got := accountant.SessionUsage("over-budget"); got != 48 {
+		t.Errorf("usage after rejection = %d, want only the accepted frame", got)
+	}
+
+	// start_recording clears the buffer, releasing its charge; buffering
+	// then works again.
+	if err := conn.WriteJSON(&model.WebSocketMessage{
+		Type: model.MessageTypeControl,
+		Data: map[string]interface{}{"action": "start_recording"},
+	}); err != nil {
+		t.Fatalf("send control: %v", err)
+	}
+	for {
+		status := readUntilType(t, conn, model.MessageTypeStatus)
+		if status.Data["status"] == "recording_started" {
+			break
+		}
+	}
+	if err := conn.WriteMessage(websocket.BinaryMessage, frame); err != nil {
+		t.Fatalf("send frame after release: %v", err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for accountant.SessionUsage("over-budget") != 48 {
+		if time.Now().After(deadline) {
+			t.Fatalf("usage after recovery = %d, want 48", accountant.SessionUsage("over-budget"))
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
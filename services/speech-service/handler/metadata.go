@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Custom gRPC metadata keys the gateway forwards from the client's connect
+// request, so sessions carry who and what connected without widening the
+// proto. The speech-service stores them on the VoiceSession for
+// language-aware routing, version segmentation and per-user analytics.
+const (
+	MetadataKeyUserID            = "x-user-id"
+	MetadataKeyClientVersion     = "x-client-version"
+	MetadataKeyPreferredLanguage = "x-preferred-language"
+	MetadataKeySessionType       = "x-session-type"
+)
+
+// sessionMetadataKeys lists every key the extractor copies out of the
+// incoming metadata; unknown keys are ignored.
+var sessionMetadataKeys = []string{
+	MetadataKeyUserID,
+	MetadataKeyClientVersion,
+	MetadataKeyPreferredLanguage,
+	MetadataKeySessionType,
+}
+
+// sessionMetadataCtxKey carries the extracted metadata map on the stream
+// context.
+type sessionMetadataCtxKey struct{}
+
+// GRPCMetadataExtractor is a stream interceptor that copies the known client
+// metadata keys out of the incoming gRPC metadata into a plain map on the
+// stream context, so handlers read session metadata without touching raw
+// metadata themselves. Streams carrying none of the keys pass through
+// unwrapped.
+func GRPCMetadataExtractor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		extracted := extractSessionMetadata(ss.Context())
+		if extracted == nil {
+			return handler(srv, ss)
+		}
+		return handler(srv, &metadataServerStream{
+			ServerStream: ss,
+			ctx:          context.WithValue(ss.Context(), sessionMetadataCtxKey{}, extracted),
+		})
+	}
+}
+
+// metadataServerStream overrides only the stream context, leaving every other
+// method to the wrapped stream.
+type metadataServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *metadataServerStream) Context() context.Context { return s.ctx }
+
+// SessionMetadataFromContext returns the client metadata for a stream: the
+// map the interceptor extracted when it ran, otherwise a fresh extraction
+// from the raw incoming metadata, so handlers also work on servers without
+// the interceptor. Nil when the gateway sent none of the known keys.
+func SessionMetadataFromContext(ctx context.Context) map[string]string {
+	if extracted, ok := ctx.Value(sessionMetadataCtxKey{}).(map[string]string); ok {
+		return extracted
+	}
+	return extractSessionMetadata(ctx)
+}
+
+// extractSessionMetadata copies the known keys out of the context's incoming
+// gRPC metadata; nil when none are present.
+func extractSessionMetadata(ctx context.Context) map[string]string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil
+	}
+	var out map[string]string
+	for _, key := range sessionMetadataKeys {
+		if values := md.Get(key); len(values) > 0 && values[0] != "" {
+			if out == nil {
+				out = make(map[string]string, len(sessionMetadataKeys))
+			}
+			out[key] = values[0]
+		}
+	}
+	return out
+}
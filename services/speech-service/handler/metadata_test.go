@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestGRPCMetadataExtractorCachesKnownKeys(t *testing.T) {
+	incoming := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		MetadataKeyUserID, "user-7",
+		MetadataKeyClientVersion, "1.4.0",
+		"x-unrelated", "dropped",
+	))
+
+	var handlerCtx context.Context
+	interceptor := GRPCMetadataExtractor()
+	err := interceptor(nil, &fakeConversationStream{ctx: incoming}, &grpc.StreamServerInfo{},
+		func(srv interface{}, ss grpc.ServerStream) error {
+			handlerCtx = ss.Context()
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("interceptor: %v", err)
+	}
+
+	md := SessionMetadataFromContext(handlerCtx)
+	if md[MetadataKeyUserID] != "user-7" || md[MetadataKeyClientVersion] != "1.4.0" {
+		t.Errorf("metadata = %v, want user-7/1.4.0", md)
+	}
+	if _, ok := md["x-unrelated"]; ok {
+		t.Error("unknown metadata key was not dropped")
+	}
+}
+
+func TestGRPCMetadataExtractorPassesThroughWithoutMetadata(t *testing.T) {
+	stream := &fakeConversationStream{}
+	var got grpc.ServerStream
+	err := GRPCMetadataExtractor()(nil, stream, &grpc.StreamServerInfo{},
+		func(srv interface{}, ss grpc.ServerStream) error {
+			got = ss
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("interceptor: %v", err)
+	}
+	if got != grpc.ServerStream(stream) {
+		t.Error("metadata-free stream was wrapped")
+	}
+	if md := SessionMetadataFromContext(got.Context()); md != nil {
+		t.Errorf("metadata = %v, want nil", md)
+	}
+}
+
+// TestSessionStoresForwardedMetadata pins down the handoff: the session
+// created for a stream keeps the metadata the gateway sent, and a reconnect
+// carrying fresh metadata replaces it while a bare reconnect keeps the old.
+func TestSessionStoresForwardedMetadata(t *testing.T) {
+	h := newTestSpeechHandler(t, "", "")
+
+	stream := &fakeConversationStream{ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		MetadataKeyPreferredLanguage, "zh-CN",
+		MetadataKeySessionType, "websocket",
+	))}
+	session, created := h.getOrCreateSession("meta-session", "user-7", stream)
+	if !created {
+		t.Fatal("session was not created")
+	}
+	if session.Metadata[MetadataKeyPreferredLanguage] != "zh-CN" {
+		t.Errorf("preferred language = %q, want zh-CN", session.Metadata[MetadataKeyPreferredLanguage])
+	}
+
+	// A reconnect without metadata keeps what the session already has.
+	if got, _ := h.getOrCreateSession("meta-session", "user-7", &fakeConversationStream{}); got.Metadata[MetadataKeyPreferredLanguage] != "zh-CN" {
+		t.Error("bare reconnect dropped the session metadata")
+	}
+
+	// A reconnect with fresh metadata replaces it.
+	fresh := &fakeConversationStream{ctx: metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+		MetadataKeyClientVersion, "2.0.0",
+	))}
+	if got, _ := h.getOrCreateSession("meta-session", "user-7", fresh); got.Metadata[MetadataKeyClientVersion] != "2.0.0" {
+		t.Errorf("metadata after reconnect = %v, want client version 2.0.0", got.Metadata)
+	}
+}
@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/config"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/memory"
+)
+
+// Stage switches. Deployments without credentials for a provider turn its
+// stage off in PipelineConfig; both handlers then skip the stage instead of
+// failing it. Handlers built without SetPipeline keep every stage enabled, so
+// direct construction in tests is unaffected.
+
+// disabledStages lists the stages a PipelineConfig turns off, for the
+// one-time startup log.
+func disabledStages(p config.PipelineConfig) []string {
+	var off []string
+	if !p.ASR {
+		off = append(off, "asr")
+	}
+	if !p.LLM {
+		off = append(off, "llm")
+	}
+	if !p.TTS {
+		off = append(off, "tts")
+	}
+	if !p.ISE {
+		off = append(off, "ise")
+	}
+	if !p.Corrections {
+		off = append(off, "corrections")
+	}
+	return off
+}
+
+// SetPipeline applies the deployment's stage switches and logs the disabled
+// stages once, so per-turn skips stay quiet.
+func (h *SpeechHandler) SetPipeline(p config.PipelineConfig) {
+	h.pipeline = &p
+	if off := disabledStages(p); len(off) > 0 {
+		h.logger.Infof("Pipeline stages disabled by configuration: %v", off)
+	}
+}
+
+// SetMemoryAccountant installs the buffer ledger; nil (the default) disables
+// memory accounting.
+func (h *SpeechHandler) SetMemoryAccountant(a *memory.Accountant) {
+	h.memory = a
+}
+
+// SetWorkerPool bounds concurrent utterance processing: final audio chunks
+// are enqueued on the pool instead of fanning out into per-utterance
+// goroutines, and arrive back as SERVICE_OVERLOADED errors when the queue is
+// full.
+func (h *SpeechHandler) SetWorkerPool(p *WorkerPool) {
+	h.pool = p
+}
+
+// SetLaneSchedulers installs the per-provider two-lane schedulers, so long
+// evaluations queue on the batch lane instead of starving short
+// conversational turns. The streaming ASR path is deliberately not scheduled:
+// it feeds audio as it arrives and cannot wait for a slot.
+func (h *SpeechHandler) SetLaneSchedulers(s *LaneSchedulers) {
+	h.lanes = s
+}
+
+// releaseSessionMemory returns bytes to the session's memory budget.
+func (h *SpeechHandler) releaseSessionMemory(session *VoiceSession, n int64) {
+	if h.memory != nil && n > 0 {
+		h.memory.Release(session.ID, n)
+	}
+}
+
+func (h *SpeechHandler) asrEnabled() bool { return h.pipeline == nil || h.pipeline.ASR }
+func (h *SpeechHandler) llmEnabled() bool { return h.pipeline == nil || h.pipeline.LLM }
+func (h *SpeechHandler) ttsEnabled() bool { return h.pipeline == nil || h.pipeline.TTS }
+func (h *SpeechHandler) iseEnabled() bool { return h.pipeline == nil || h.pipeline.ISE }
+
+// SetPipeline applies the deployment's stage switches and logs the disabled
+// stages once, so per-turn skips stay quiet.
+func (h *WebSocketHandler) SetPipeline(p config.PipelineConfig) {
+	h.pipeline = &p
+	if off := disabledStages(p); len(off) > 0 {
+		h.logger.Infof("Pipeline stages disabled by configuration: %v", off)
+	}
+}
+
+func (h *WebSocketHandler) asrEnabled() bool { return h.pipeline == nil || h.pipeline.ASR }
+func (h *WebSocketHandler) llmEnabled() bool { return h.pipeline == nil || h.pipeline.LLM }
+func (h *WebSocketHandler) ttsEnabled() bool { return h.pipeline == nil || h.pipeline.TTS }
+func (h *WebSocketHandler) iseEnabled() bool { return h.pipeline == nil || h.pipeline.ISE }
@@ -0,0 +1,209 @@
+package handler
+
+import (
+	"io"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/config"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/events"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/service"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/store"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/testutil"
+	speechv1 "github.com/CurvatureX/ai-tutor-monorepo/shared/proto/speech"
+)
+
+// newPipelineHandler builds a SpeechHandler with the given stage switches.
+// Disabled stages get unreachable provider URLs: the switches, not working
+// mocks, must be what keeps them out of the turn.
+func newPipelineHandler(t *testing.T, p config.PipelineConfig, asrURL, llmURL, ttsURL string) *SpeechHandler {
+	t.Helper()
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	publisher, err := events.NewPublisher(config.EventsConfig{Publisher: "none"}, logger)
+	if err != nil {
+		t.Fatalf("NewPublisher: %v", err)
+	}
+	h := NewSpeechHandler(
+		service.NewASRService(config.ASRConfig{BaseURL: asrURL, Cluster: "test"}, logger),
+		service.NewTTSService(config.TTSConfig{BaseURL: ttsURL, VoiceType: "test_voice", Speed: 1.0}, logger),
+		service.NewLLMService(config.LLMConfig{BaseURL: llmURL, Model: "test-model", MaxTokens: 50}, nil, logger),
+		service.NewISEService(config.ISEConfig{BaseURL: "ws://unused.invalid"}, logger),
+		service.NewAudioService(config.AudioConfig{}, logger),
+		publisher, store.NewSessionRecorder(false, nil, logger), 0, 0, pausePolicyBuffer,
+		ISEModeReferenced, config.SLOConfig{}, logger)
+	h.SetPipeline(p)
+	return h
+}
+
+// completeStatus waits for a "complete" status and returns its message.
+func completeStatus(t *testing.T, stream *fakeConversationStream) string {
+	t.Helper()
+	var message string
+	waitForResponse(t, stream, "complete status", func(resp *speechv1.VoiceResponse) bool {
+		if s := resp.GetStatus(); s != nil && s.Status == "complete" {
+			message = s.Message
+			return true
+		}
+		return false
+	})
+	return message
+}
+
+// assertStagesAbsent fails if any of the named stage results appear in the
+// stream's responses.
+func assertStagesAbsent(t *testing.T, stream *fakeConversationStream, asr, llm, tts, ise bool) {
+	t.Helper()
+	for _, resp := range stream.responses() {
+		switch r := resp.GetResponseType().(type) {
+		case *speechv1.VoiceResponse_AsrResult:
+			if asr {
+				t.Error("got an asr_result from a disabled ASR stage")
+			}
+		case *speechv1.VoiceResponse_LlmResult:
+			if llm {
+				t.Error("got an llm_result from a disabled LLM stage")
+			}
+		case *speechv1.VoiceResponse_TtsResult:
+			if tts {
+				t.Error("got a tts_result from a disabled TTS stage")
+			}
+		case *speechv1.VoiceResponse_IseResult:
+			if ise {
+				t.Error("got an ise_result from a disabled ISE stage")
+			}
+		case *speechv1.VoiceResponse_Error:
+			t.Errorf("unexpected pipeline error: %v", r.Error.Message)
+		}
+	}
+}
+
+// TestASROnlyPipeline disables everything past recognition: a spoken turn
+// yields the transcript and completes without a reply or audio.
+func TestASROnlyPipeline(t *testing.T) {
+	asr := testutil.NewMockASRServer(testutil.MockASRConfig{Transcript: "just transcribe me", Confidence: 0.9})
+	defer asr.Close()
+
+	h := newPipelineHandler(t, config.PipelineConfig{ASR: true},
+		asr.URL(), "http://unused.invalid", "ws://unused.invalid")
+	stream := newFakeStream(freeSpeakAudioRequest("asr-only-session"))
+	if err := h.ProcessVoiceConversation(stream); err != nil {
+		t.Fatalf("ProcessVoiceConversation: %v", err)
+	}
+
+	waitForResponse(t, stream, "asr result", func(resp *speechv1.VoiceResponse) bool {
+		r := resp.GetAsrResult()
+		return r != nil && r.Text == "just transcribe me"
+	})
+	if msg := completeStatus(t, stream); msg != "llm stage disabled" {
+		t.Errorf("complete message = %q, want %q", msg, "llm stage disabled")
+	}
+	assertStagesAbsent(t, stream, false, true, true, true)
+}
+
+// TestNoISEPipeline turns off evaluation for a deployment without ISE
+// credentials: a referenced spoken turn still runs the conversational stages
+// and never touches the (unreachable) ISE endpoint.
+func TestNoISEPipeline(t *testing.T) {
+	asr := testutil.NewMockASRServer(testutil.MockASRConfig{Transcript: "hello there", Confidence: 0.93})
+	defer asr.Close()
+	llm := testutil.NewMockLLMServer(testutil.MockLLMConfig{ReplyText: "Well said!", TokensUsed: 5})
+	defer llm.Close()
+	tts := testutil.NewMockTTSServer(testutil.MockTTSConfig{AudioChunks: [][]byte{[]byte("audio")}})
+	defer tts.Close()
+
+	h := newPipelineHandler(t, config.PipelineConfig{ASR: true, LLM: true, TTS: true},
+		asr.URL(), llm.URL(), tts.URL())
+	stream := newFakeStream(
+		&speechv1.VoiceRequest{
+			SessionId: "no-ise-session",
+			RequestType: &speechv1.VoiceRequest_Control{
+				Control: &speechv1.ControlMessage{
+					Action:     speechv1.ControlAction_CONTROL_ACTION_SET_REFERENCE_TEXT,
+					Parameters: map[string]string{"text": "hello there"},
+				},
+			},
+		},
+		freeSpeakAudioRequest("no-ise-session"))
+	if err := h.ProcessVoiceConversation(stream); err != nil {
+		t.Fatalf("ProcessVoiceConversation: %v", err)
+	}
+
+	waitForResponse(t, stream, "tts result", func(resp *speechv1.VoiceResponse) bool {
+		return resp.GetTtsResult() != nil
+	})
+	assertStagesAbsent(t, stream, false, false, false, true)
+}
+
+// TestTextOnlyPipeline disables the audio stages: spoken turns are skipped
+// with a status, while typed turns still get a reply and synthesis.
+func TestTextOnlyPipeline(t *testing.T) {
+	llm := testutil.NewMockLLMServer(testutil.MockLLMConfig{ReplyText: "Typed replies work.", TokensUsed: 5})
+	defer llm.Close()
+	tts := testutil.NewMockTTSServer(testutil.MockTTSConfig{AudioChunks: [][]byte{[]byte("audio")}})
+	defer tts.Close()
+
+	h := newPipelineHandler(t, config.PipelineConfig{LLM: true, TTS: true},
+		"ws://unused.invalid", llm.URL(), tts.URL())
+	stream := newFakeStream(
+		freeSpeakAudioRequest("text-only-session"),
+		&speechv1.VoiceRequest{
+			SessionId: "text-only-session",
+			RequestType: &speechv1.VoiceRequest_TextInput{
+				TextInput: &speechv1.TextInput{Text: "Can you hear me?"},
+			},
+		})
+	if err := h.ProcessVoiceConversation(stream); err != nil {
+		t.Fatalf("ProcessVoiceConversation: %v", err)
+	}
+
+	waitForResponse(t, stream, "asr-disabled status", func(resp *speechv1.VoiceResponse) bool {
+		s := resp.GetStatus()
+		return s != nil && s.Status == "complete" && s.Message == "asr stage disabled"
+	})
+	waitForResponse(t, stream, "tts result for the typed turn", func(resp *speechv1.VoiceResponse) bool {
+		return resp.GetTtsResult() != nil
+	})
+	assertStagesAbsent(t, stream, true, false, false, true)
+}
+
+// TestSessionOptionDisablesTTS covers the connect-time override: the
+// deployment leaves TTS on, the session turns it off, and the typed turn
+// completes with a text-only reply.
+func TestSessionOptionDisablesTTS(t *testing.T) {
+	llm := testutil.NewMockLLMServer(testutil.MockLLMConfig{ReplyText: "No audio for you.", TokensUsed: 5})
+	defer llm.Close()
+
+	h := newPipelineHandler(t, config.PipelineConfig{ASR: true, LLM: true, TTS: true, ISE: true, Corrections: true},
+		"ws://unused.invalid", llm.URL(), "ws://unused.invalid")
+	stream := newFakeStream(
+		&speechv1.VoiceRequest{
+			SessionId: "tts-off-session",
+			RequestType: &speechv1.VoiceRequest_Control{
+				Control: &speechv1.ControlMessage{
+					Action:     speechv1.ControlAction_CONTROL_ACTION_CONFIGURE_SESSION,
+					Parameters: map[string]string{"tts": "off", "tutor_starts": "false"},
+				},
+			},
+		},
+		&speechv1.VoiceRequest{
+			SessionId: "tts-off-session",
+			RequestType: &speechv1.VoiceRequest_TextInput{
+				TextInput: &speechv1.TextInput{Text: "Just text, please."},
+			},
+		})
+	if err := h.ProcessVoiceConversation(stream); err != nil {
+		t.Fatalf("ProcessVoiceConversation: %v", err)
+	}
+
+	waitForResponse(t, stream, "llm result", func(resp *speechv1.VoiceResponse) bool {
+		r := resp.GetLlmResult()
+		return r != nil && r.Text == "No audio for you."
+	})
+	if msg := completeStatus(t, stream); msg != "" {
+		t.Errorf("complete message = %q, want empty", msg)
+	}
+	assertStagesAbsent(t, stream, true, false, true, true)
+}
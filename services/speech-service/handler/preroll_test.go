@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/manager"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+)
+
+// TestPreRollSeedsTheUtteranceBuffer streams preview frames before
+// start_recording and verifies the retained tail seeds the utterance buffer,
+// bounded by the configured pre-roll window.
+func TestPreRollSeedsTheUtteranceBuffer(t *testing.T) {
+	h, wsManager, _, _ := newCloseTestHandler(t)
+	// 10 ms of pre-roll = 160 bytes of preview audio retained.
+	h.SetAudioPreRoll(10)
+
+	srv := httptest.NewServer(http.HandlerFunc(h.HandleWebSocket))
+	defer srv.Close()
+	conn, _, err := websocket.DefaultDialer.Dial(
+		"ws"+strings.TrimPrefix(srv.URL, "http")+"?session_id=preroll", nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	// Three 80-byte preview frames: 240 bytes total, so the oldest frame
+	// falls out of the 160-byte window.
+	for _, fill := range []byte{'a', 'b', 'c'} {
+		if err := conn.WriteMessage(websocket.BinaryMessage, bytes.Repeat([]byte{fill}, 80)); err != nil {
+			t.Fatalf("send preview frame: %v", err)
+		}
+	}
+
+	if err := conn.WriteJSON(&model.WebSocketMessage{
+		Type: model.MessageTypeControl,
+		Data: map[string]interface{}{"action": "start_recording"},
+	}); err != nil {
+		t.Fatalf("send control: %v", err)
+	}
+	for {
+		status := readUntilType(t, conn, model.MessageTypeStatus)
+		if status.Data["status"] == "recording_started" {
+			break
+		}
+	}
+
+	// Read the buffer under the manager's lock: the handler goroutine keeps
+	// appending through UpdateSession while the test polls.
+	audioBuffer := func() []byte {
+		var buf []byte
+		if err := wsManager.UpdateSession("preroll", func(s *manager.WebSocketSession) {
+			buf = append([]byte(nil), s.AudioBuffer...)
+		}); err != nil {
+			t.Fatalf("UpdateSession: %v", err)
+		}
+		return buf
+	}
+
+	want := append(bytes.Repeat([]byte{'b'}, 80), bytes.Repeat([]byte{'c'}, 80)...)
+	if got := audioBuffer(); !bytes.Equal(got, want) {
+		t.Fatalf("AudioBuffer = %d bytes starting %q, want the newest 160 preview bytes", len(got), firstBytes(got))
+	}
+	var preRollLen int
+	if err := wsManager.UpdateSession("preroll", func(s *manager.WebSocketSession) {
+		preRollLen = s.PreRoll.Len()
+	}); err != nil {
+		t.Fatalf("UpdateSession: %v", err)
+	}
+	if preRollLen != 0 {
+		t.Errorf("PreRoll.Len() = %d after start_recording, want the ring drained", preRollLen)
+	}
+
+	// A recording frame appends after the seeded pre-roll.
+	if err := conn.WriteMessage(websocket.BinaryMessage, bytes.Repeat([]byte{'d'}, 40)); err != nil {
+		t.Fatalf("send recording frame: %v", err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	buf := audioBuffer()
+	for len(buf) != 200 {
+		if time.Now().After(deadline) {
+			t.Fatalf("AudioBuffer = %d bytes, want pre-roll plus the recorded frame", len(buf))
+		}
+		time.Sleep(5 * time.Millisecond)
+		buf = audioBuffer()
+	}
+	if !bytes.HasPrefix(buf, want) {
+		t.Error("recorded frame did not append after the pre-roll seed")
+	}
+}
+
+func firstBytes(b []byte) []byte {
+	if len(b) > 8 {
+		return b[:8]
+	}
+	return b
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/audio"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/logging"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+	speechv1 "github.com/CurvatureX/ai-tutor-monorepo/shared/proto/speech"
+)
+
+// nominalWordsPerSecond is a typical TTS speaking rate at speed 1.0, used to
+// match the model rendition's pace to the learner's.
+const nominalWordsPerSecond = 2.5
+
+// compareProsody synthesizes a model rendition of the evaluated reference
+// text at the learner's speaking rate and sends pitch/rhythm/stress
+// correlations against the learner's recording. The comparison is a visual
+// demonstration on top of the ISE scores, so failures only log.
+func (h *SpeechHandler) compareProsody(session *VoiceSession, ise *model.ISEResponse, pcmData []byte) {
+	result := compareAgainstRendition(h.ttsService, h.audioService.Converter(), h.logger,
+		session.ID, ise, session.Voice, session.Language, pcmData)
+	if result == nil {
+		return
+	}
+	session.send(&speechv1.VoiceResponse{
+		ResponseType: &speechv1.VoiceResponse_PronunciationComparison{
+			PronunciationComparison: &speechv1.PronunciationComparison{
+				PitchCorrelation:  result.PitchCorrelation,
+				RhythmCorrelation: result.RhythmCorrelation,
+				StressCorrelation: result.StressCorrelation,
+				ReferenceText:     ise.ReferenceText,
+			},
+		},
+	})
+}
+
+// compareAgainstRendition synthesizes the reference text at the learner's
+// pace, decodes it and correlates its prosody with the recording. The result
+// is nil when synthesis or decoding failed; those failures are logged here.
+func compareAgainstRendition(tts TTSProvider, converter *audio.Converter, logger *logrus.Logger,
+	sessionID string, ise *model.ISEResponse, voice, language string, learnerPCM []byte) *audio.ComparisonResult {
+	ttsResp, err := tts.SynthesizeSpeech(&model.TTSRequest{
+		Text:     ise.ReferenceText,
+		Voice:    voice,
+		Language: language,
+		Speed:    learnerSpeechRate(converter, ise, learnerPCM),
+	})
+	if err != nil {
+		logging.SessionLogger(sessionID, logger).WithError(err).WithField(logging.LogFieldService, "tts").
+			Warn("Prosody comparison synthesis failed")
+		return nil
+	}
+	referencePCM, err := converter.ConvertMP3ToPCM(ttsResp.AudioData)
+	if err != nil {
+		logging.SessionLogger(sessionID, logger).WithError(err).WithField(logging.LogFieldService, "audio").
+			Warn("Prosody comparison decode failed")
+		return nil
+	}
+
+	result := audio.NewPronunciationComparator().Compare(referencePCM, learnerPCM)
+	return &result
+}
+
+// learnerSpeechRate converts the learner's pace into a TTS speed multiplier
+// so the model rendition and the recording line up in time. The ISE word list
+// over the recording's duration approximates words per second; without words
+// or audio the rendition keeps the default pace.
+func learnerSpeechRate(converter *audio.Converter, ise *model.ISEResponse, learnerPCM []byte) float64 {
+	duration := converter.GetAudioDuration(learnerPCM).Seconds()
+	if duration <= 0 || len(ise.Words) == 0 {
+		return 1.0
+	}
+	speed := float64(len(ise.Words)) / duration / nominalWordsPerSecond
+	switch {
+	case speed < 0.5:
+		return 0.5
+	case speed > 1.5:
+		return 1.5
+	}
+	return speed
+}
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	speechv1 "github.com/CurvatureX/ai-tutor-monorepo/shared/proto/speech"
+)
+
+// protoFieldNumbers reads the wire field numbers out of a generated message's
+// protobuf struct tags, keyed by Go field name. Oneof wrapper fields carry no
+// number themselves and are skipped.
+func protoFieldNumbers(t *testing.T, msg interface{}) map[string]int {
+	t.Helper()
+	numbers := make(map[string]int)
+	typ := reflect.TypeOf(msg).Elem()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tag, ok := field.Tag.Lookup("protobuf")
+		if !ok {
+			continue
+		}
+		parts := strings.Split(tag, ",")
+		if len(parts) < 2 {
+			t.Fatalf("%s.%s: malformed protobuf tag %q", typ.Name(), field.Name, tag)
+		}
+		n, err := strconv.Atoi(parts[1])
+		if err != nil {
+			t.Fatalf("%s.%s: non-numeric field number in tag %q", typ.Name(), field.Name, tag)
+		}
+		numbers[field.Name] = n
+	}
+	return numbers
+}
+
+// TestSharedProtoFieldNumbers pins the wire numbers of the messages this
+// service writes onto the conversation stream. The shared proto package is
+// the single source of truth for both sides; if a regeneration ever renumbers
+// a field, this fails here instead of surfacing as the gateway silently
+// misreading scores.
+func TestSharedProtoFieldNumbers(t *testing.T) {
+	cases := []struct {
+		name string
+		msg  interface{}
+		want map[string]int
+	}{
+		{"ASRResult", &speechv1.ASRResult{}, map[string]int{
+			"Text": 1, "Confidence": 2, "IsFinal": 3, "Language": 4,
+			"StartTimeMs": 5, "EndTimeMs": 6, "AudioQualityWarning": 7,
+			"SegmentIndex": 8, "SegmentCount": 9,
+		}},
+		{"LLMResult", &speechv1.LLMResult{}, map[string]int{
+			"Text": 1, "Type": 2, "TokensUsed": 3,
+			"SegmentIndex": 4, "SegmentCount": 5,
+		}},
+		{"TTSResult", &speechv1.TTSResult{}, map[string]int{
+			"AudioData": 1, "Format": 2, "DurationMs": 3,
+			"TurnId": 4, "ChunkIndex": 5, "IsFinal": 6, "WordBoundaries": 7,
+		}},
+		{"ISEResult", &speechv1.ISEResult{}, map[string]int{
+			"OverallScore": 1, "AccuracyScore": 2, "FluencyScore": 3,
+			"IntegrityScore": 4, "ReferenceText": 5, "Words": 6,
+			"NormalizedScore": 7, "Mode": 8,
+			"SegmentIndex": 9, "SegmentCount": 10,
+		}},
+		{"WordScore", &speechv1.WordScore{}, map[string]int{
+			"Word": 1, "Score": 2, "Phones": 3, "ErrorType": 4,
+		}},
+		{"PhoneScore", &speechv1.PhoneScore{}, map[string]int{
+			"Phone": 1, "Score": 2, "ErrorType": 3,
+		}},
+		{"ErrorResult", &speechv1.ErrorResult{}, map[string]int{
+			"Code": 1, "Message": 2,
+		}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := protoFieldNumbers(t, tc.msg)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("field numbers = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/store"
+)
+
+// newQATestRecorder builds an enabled recorder over a throwaway directory.
+func newQATestRecorder(t *testing.T) (*store.SessionRecorder, store.DebugAudioStore) {
+	t.Helper()
+	audioStore, err := store.NewFileDebugAudioStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("create store: %v", err)
+	}
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	return store.NewSessionRecorder(true, audioStore, logger), audioStore
+}
+
+func dialQASession(t *testing.T, h *WebSocketHandler, query string) *websocket.Conn {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(h.HandleWebSocket))
+	t.Cleanup(srv.Close)
+	conn, _, err := websocket.DefaultDialer.Dial(
+		"ws"+strings.TrimPrefix(srv.URL, "http")+query, nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	// The connected status confirms the server finished session setup, so
+	// the caller can inspect manager state without racing the handshake.
+	for {
+		status := readUntilType(t, conn, model.MessageTypeStatus)
+		if status.Data["status"] == "connected" {
+			return conn
+		}
+	}
+}
+
+// TestQARecordingOffByDefault verifies that without the connect opt-in
+// nothing reaches the store, even with recording globally enabled.
+func TestQARecordingOffByDefault(t *testing.T) {
+	h, wsManager, _, _ := newCloseTestHandler(t)
+	recorder, audioStore := newQATestRecorder(t)
+	h.SetQARecorder(recorder, 0)
+
+	dialQASession(t, h, "?session_id=qa-default")
+	session, ok := wsManager.GetSession("qa-default")
+	if !ok {
+		t.Fatal("session not found")
+	}
+	if session.QARecord {
+		t.Error("QARecord set without the record_session opt-in")
+	}
+
+	h.recordTurnAudio(session, 1, []byte("raw"), []byte("wav"))
+	files, err := audioStore.List("qa-default")
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(files) != 0 {
+		t.Errorf("store holds %v for a session that never opted in", files)
+	}
+}
+
+// TestQARecordingOptInRequiresEnabledRecorder verifies the global switch
+// stays the master gate: the opt-in is ignored when recording is disabled.
+func TestQARecordingOptInRequiresEnabledRecorder(t *testing.T) {
+	h, wsManager, _, _ := newCloseTestHandler(t)
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	h.SetQARecorder(store.NewSessionRecorder(false, nil, logger), 0)
+
+	dialQASession(t, h, "?session_id=qa-disabled&record_session=true")
+	session, ok := wsManager.GetSession("qa-disabled")
+	if !ok {
+		t.Fatal("session not found")
+	}
+	if session.QARecord {
+		t.Error("QARecord set while recording is globally disabled")
+	}
+}
+
+// TestQARecordingHonorsByteCap verifies turns past the per-session cap are
+// dropped while earlier recordings stay intact.
+func TestQARecordingHonorsByteCap(t *testing.T) {
+	h, wsManager, _, _ := newCloseTestHandler(t)
+	recorder, audioStore := newQATestRecorder(t)
+	h.SetQARecorder(recorder, 100)
+
+	dialQASession(t, h, "?session_id=qa-cap&record_session=true")
+	session, ok := wsManager.GetSession("qa-cap")
+	if !ok {
+		t.Fatal("session not found")
+	}
+	if !session.QARecord {
+		t.Fatal("QARecord not set by the record_session opt-in")
+	}
+
+	// 60 bytes fit; the next 60 would cross the 100-byte cap.
+	h.recordTurnAudio(session, 1, bytes.Repeat([]byte{'r'}, 30), bytes.Repeat([]byte{'w'}, 30))
+	h.recordTurnAudio(session, 2, bytes.Repeat([]byte{'r'}, 30), bytes.Repeat([]byte{'w'}, 30))
+
+	files, err := audioStore.List("qa-cap")
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("store holds %v, want only the first turn's raw+wav pair", files)
+	}
+	for _, name := range files {
+		if !strings.Contains(name, "_1.") {
+			t.Errorf("unexpected recording %q from a capped turn", name)
+		}
+	}
+	if got := session.QARecordedBytes; got != 60 {
+		t.Errorf("QARecordedBytes = %d, want 60", got)
+	}
+}
@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/config"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/events"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/service"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/store"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/testutil"
+	speechv1 "github.com/CurvatureX/ai-tutor-monorepo/shared/proto/speech"
+)
+
+// newQualityHandler builds a SpeechHandler with the pre-flight quality
+// thresholds enabled, pointed at mock ASR, LLM and TTS servers.
+func newQualityHandler(t *testing.T, asrURL, llmURL, ttsURL string) *SpeechHandler {
+	t.Helper()
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	publisher, err := events.NewPublisher(config.EventsConfig{Publisher: "none"}, logger)
+	if err != nil {
+		t.Fatalf("NewPublisher: %v", err)
+	}
+	return NewSpeechHandler(
+		service.NewASRService(config.ASRConfig{BaseURL: asrURL, Cluster: "test"}, logger),
+		service.NewTTSService(config.TTSConfig{BaseURL: ttsURL, VoiceType: "test_voice", Speed: 1.0}, logger),
+		service.NewLLMService(config.LLMConfig{BaseURL: llmURL, Model: "test-model", MaxTokens: 50}, nil, logger),
+		service.NewISEService(config.ISEConfig{}, logger),
+		service.NewAudioService(config.AudioConfig{SilenceRMS: 0.005, ClippingWarnRatio: 0.03}, logger),
+		publisher, store.NewSessionRecorder(false, nil, logger), 0, 0, pausePolicyBuffer,
+		ISEModeReferenced, config.SLOConfig{}, logger)
+}
+
+// clippedPCM builds an utterance railed at full scale, the shape a mic with
+// too much gain produces.
+func clippedPCM(n int) []byte {
+	audio := make([]byte, n)
+	for i := 0; i+1 < n; i += 2 {
+		binary.LittleEndian.PutUint16(audio[i:], uint16(int16(math.MaxInt16)))
+	}
+	return audio
+}
+
+func qualityAudioRequest(sessionID string, audio []byte) *speechv1.VoiceRequest {
+	return &speechv1.VoiceRequest{
+		SessionId: sessionID,
+		UserId:    "learner-1",
+		RequestType: &speechv1.VoiceRequest_AudioData{
+			AudioData: &speechv1.AudioChunk{Data: audio, Format: "pcm", IsFinal: true},
+		},
+	}
+}
+
+func TestSilentUtteranceSkipsProviders(t *testing.T) {
+	asr := testutil.NewMockASRServer(testutil.MockASRConfig{Transcript: "should never transcribe"})
+	defer asr.Close()
+	llm := testutil.NewMockLLMServer(testutil.MockLLMConfig{ReplyText: "unused"})
+	defer llm.Close()
+	tts := testutil.NewMockTTSServer(testutil.MockTTSConfig{AudioChunks: [][]byte{[]byte("unused")}})
+	defer tts.Close()
+
+	h := newQualityHandler(t, asr.URL(), llm.URL(), tts.URL())
+	stream := newFakeStream(qualityAudioRequest("silent-session", make([]byte, 16000)))
+	if err := h.ProcessVoiceConversation(stream); err != nil {
+		t.Fatalf("ProcessVoiceConversation: %v", err)
+	}
+
+	waitForResponse(t, stream, "no_speech_detected status", func(resp *speechv1.VoiceResponse) bool {
+		s := resp.GetStatus()
+		return s != nil && s.Status == "no_speech_detected"
+	})
+	for _, resp := range stream.responses() {
+		if resp.GetAsrResult() != nil {
+			t.Fatalf("asr_result sent for a silent utterance: %+v", resp.GetAsrResult())
+		}
+	}
+	if got := asr.AudioFrames(); got != 0 {
+		t.Errorf("ASR provider received %d frames, want 0 for a silent utterance", got)
+	}
+}
+
+func TestClippedUtteranceCarriesQualityWarning(t *testing.T) {
+	asr := testutil.NewMockASRServer(testutil.MockASRConfig{Transcript: "hello there", Confidence: 0.91})
+	defer asr.Close()
+	llm := testutil.NewMockLLMServer(testutil.MockLLMConfig{ReplyText: "Nice to meet you!"})
+	defer llm.Close()
+	tts := testutil.NewMockTTSServer(testutil.MockTTSConfig{AudioChunks: [][]byte{[]byte("audio")}})
+	defer tts.Close()
+
+	h := newQualityHandler(t, asr.URL(), llm.URL(), tts.URL())
+	stream := newFakeStream(qualityAudioRequest("clipped-session", clippedPCM(16000)))
+	if err := h.ProcessVoiceConversation(stream); err != nil {
+		t.Fatalf("ProcessVoiceConversation: %v", err)
+	}
+
+	var result *speechv1.ASRResult
+	waitForResponse(t, stream, "asr result", func(resp *speechv1.VoiceResponse) bool {
+		if r := resp.GetAsrResult(); r != nil {
+			result = r
+			return true
+		}
+		return false
+	})
+	if result.Text != "hello there" {
+		t.Errorf("transcript = %q, want the mock transcript", result.Text)
+	}
+	if result.AudioQualityWarning == "" {
+		t.Error("AudioQualityWarning empty for a railed utterance")
+	}
+}
+
+func TestCleanUtteranceHasNoQualityWarning(t *testing.T) {
+	asr := testutil.NewMockASRServer(testutil.MockASRConfig{Transcript: "hello there", Confidence: 0.91})
+	defer asr.Close()
+	llm := testutil.NewMockLLMServer(testutil.MockLLMConfig{ReplyText: "Nice to meet you!"})
+	defer llm.Close()
+	tts := testutil.NewMockTTSServer(testutil.MockTTSConfig{AudioChunks: [][]byte{[]byte("audio")}})
+	defer tts.Close()
+
+	h := newQualityHandler(t, asr.URL(), llm.URL(), tts.URL())
+	stream := newFakeStream(qualityAudioRequest("clean-session", loudPCM(16000)))
+	if err := h.ProcessVoiceConversation(stream); err != nil {
+		t.Fatalf("ProcessVoiceConversation: %v", err)
+	}
+
+	var result *speechv1.ASRResult
+	waitForResponse(t, stream, "asr result", func(resp *speechv1.VoiceResponse) bool {
+		if r := resp.GetAsrResult(); r != nil {
+			result = r
+			return true
+		}
+		return false
+	})
+	if result.AudioQualityWarning != "" {
+		t.Errorf("AudioQualityWarning = %q for clean speech-level audio, want none", result.AudioQualityWarning)
+	}
+}
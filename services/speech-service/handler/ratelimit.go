@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"golang.org/x/time/rate"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/config"
+)
+
+// ErrRateLimited is returned when a session exceeds its per-stage request
+// budget.
+var ErrRateLimited = errors.New("rate limit exceeded, slow down")
+
+var rateLimitedRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "rate_limited_requests_total",
+	Help: "Requests rejected by per-session rate limits, by pipeline stage.",
+}, []string{"type"})
+
+// Pipeline stages with independent per-session budgets.
+const (
+	stageAudio = "audio"
+	stageLLM   = "llm"
+	stageTTS   = "tts"
+)
+
+// RateLimiter enforces per-session request budgets for the audio, LLM and
+// TTS stages, so a single session cannot burn through the shared upstream
+// provider quotas.
+type RateLimiter struct {
+	cfg config.RateLimitConfig
+
+	mu       sync.Mutex
+	sessions map[string]*sessionLimiters
+}
+
+// sessionLimiters holds one token bucket per pipeline stage.
+type sessionLimiters struct {
+	audio *rate.Limiter
+	llm   *rate.Limiter
+	tts   *rate.Limiter
+}
+
+// NewRateLimiter creates the per-session limiter registry.
+func NewRateLimiter(cfg config.RateLimitConfig) *RateLimiter {
+	return &RateLimiter{
+		cfg:      cfg,
+		sessions: make(map[string]*sessionLimiters),
+	}
+}
+
+// Allow consumes one token from the session's budget for the given stage,
+// returning ErrRateLimited when the bucket is empty. A zero configured rate
+// disables the check for that stage.
+func (r *RateLimiter) Allow(sessionID, stage string) error {
+	r.mu.Lock()
+	limiters, ok := r.sessions[sessionID]
+	if !ok {
+		limiters = &sessionLimiters{
+			audio: rate.NewLimiter(rate.Limit(r.cfg.AudioPerSecond), r.cfg.Burst),
+			llm:   rate.NewLimiter(rate.Limit(r.cfg.LLMPerSecond), r.cfg.Burst),
+			tts:   rate.NewLimiter(rate.Limit(r.cfg.TTSPerSecond), r.cfg.Burst),
+		}
+		r.sessions[sessionID] = limiters
+	}
+	r.mu.Unlock()
+
+	var limiter *rate.Limiter
+	switch stage {
+	case stageAudio:
+		limiter = limiters.audio
+	case stageLLM:
+		limiter = limiters.llm
+	case stageTTS:
+		limiter = limiters.tts
+	default:
+		return nil
+	}
+	if limiter.Limit() == 0 {
+		return nil
+	}
+	if !limiter.Allow() {
+		rateLimitedRequests.WithLabelValues(stage).Inc()
+		return ErrRateLimited
+	}
+	return nil
+}
+
+// Forget drops the limiters for a closed session.
+func (r *RateLimiter) Forget(sessionID string) {
+	r.mu.Lock()
+	delete(r.sessions, sessionID)
+	r.mu.Unlock()
+}
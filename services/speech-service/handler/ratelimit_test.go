@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/config"
+)
+
+func testRateLimitConfig() config.RateLimitConfig {
+	return config.RateLimitConfig{
+		AudioPerSecond: 1,
+		LLMPerSecond:   2,
+		TTSPerSecond:   1,
+		Burst:          2,
+	}
+}
+
+func TestRateLimiterBurstThenDeny(t *testing.T) {
+	limiter := NewRateLimiter(testRateLimitConfig())
+
+	for i := 0; i < 2; i++ {
+		if err := limiter.Allow("s1", stageAudio); err != nil {
+			t.Fatalf("request %d within burst denied: %v", i, err)
+		}
+	}
+	if err := limiter.Allow("s1", stageAudio); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("request beyond burst = %v, want ErrRateLimited", err)
+	}
+}
+
+func TestRateLimiterSessionsIndependent(t *testing.T) {
+	limiter := NewRateLimiter(testRateLimitConfig())
+
+	for i := 0; i < 2; i++ {
+		limiter.Allow("noisy", stageTTS)
+	}
+	if err := limiter.Allow("noisy", stageTTS); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("noisy session = %v, want ErrRateLimited", err)
+	}
+	if err := limiter.Allow("quiet", stageTTS); err != nil {
+		t.Fatalf("quiet session denied by noisy session's usage: %v", err)
+	}
+}
+
+func TestRateLimiterStagesIndependent(t *testing.T) {
+	limiter := NewRateLimiter(testRateLimitConfig())
+
+	for i := 0; i < 2; i++ {
+		limiter.Allow("s1", stageAudio)
+	}
+	if err := limiter.Allow("s1", stageAudio); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("audio stage = %v, want ErrRateLimited", err)
+	}
+	if err := limiter.Allow("s1", stageLLM); err != nil {
+		t.Fatalf("llm stage denied by audio stage usage: %v", err)
+	}
+}
+
+func TestRateLimiterForgetResetsBudget(t *testing.T) {
+	limiter := NewRateLimiter(testRateLimitConfig())
+
+	for i := 0; i < 2; i++ {
+		limiter.Allow("s1", stageAudio)
+	}
+	limiter.Forget("s1")
+	if err := limiter.Allow("s1", stageAudio); err != nil {
+		t.Fatalf("request after Forget denied: %v", err)
+	}
+}
+
+func TestRateLimiterZeroRateDisablesStage(t *testing.T) {
+	limiter := NewRateLimiter(config.RateLimitConfig{Burst: 1})
+
+	for i := 0; i < 10; i++ {
+		if err := limiter.Allow("s1", stageLLM); err != nil {
+			t.Fatalf("request %d denied with limits disabled: %v", i, err)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/manager"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+)
+
+// TestReconnectTokenResumesSession drops a session without a close handshake
+// and resumes it with the issued token, verifying the restored turn count
+// reaches the client.
+func TestReconnectTokenResumesSession(t *testing.T) {
+	h, wsManager, _, _ := newCloseTestHandler(t)
+	h.SetReconnectTokens(manager.NewReconnectTokens("test-secret", 2*time.Minute))
+
+	srv := httptest.NewServer(http.HandlerFunc(h.HandleWebSocket))
+	defer srv.Close()
+	wsURL := "ws" + strings.TrimPrefix(srv.URL, "http")
+
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL+"?session_id=resume-me", nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	tokenMsg := readUntilType(t, conn, model.MessageTypeSessionToken)
+	token, _ := tokenMsg.Data["token"].(string)
+	if token == "" {
+		t.Fatal("no reconnect token issued at connect time")
+	}
+	if _, ok := tokenMsg.Data["expires_at"]; !ok {
+		t.Error("session_token missing expires_at")
+	}
+
+	wsManager.UpdateSession("resume-me", func(s *manager.WebSocketSession) {
+		s.TurnCount = 4
+	})
+
+	// Drop the connection without a close frame, as a connectivity loss
+	// would, and wait for the server to persist the snapshot.
+	conn.Close()
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if _, ok := wsManager.GetSession("resume-me"); !ok {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("session not removed after the connection dropped")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	conn2, _, err := websocket.DefaultDialer.Dial(
+		wsURL+"?reconnect_token="+url.QueryEscape(token), nil)
+	if err != nil {
+		t.Fatalf("reconnect dial: %v", err)
+	}
+	defer conn2.Close()
+	conn2.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	resumed := readUntilType(t, conn2, model.MessageTypeSessionResumed)
+	if got, _ := resumed.Data["turn_count"].(float64); got != 4 {
+		t.Errorf("turn_count = %v, want the restored 4", resumed.Data["turn_count"])
+	}
+	if drift, _ := resumed.Data["state_drift"].(bool); drift {
+		t.Error("state_drift reported for an unchanged history")
+	}
+	// The resumed connection gets a fresh token; the old one is consumed.
+	readUntilType(t, conn2, model.MessageTypeSessionToken)
+	if _, _, err := websocket.DefaultDialer.Dial(
+		wsURL+"?reconnect_token="+url.QueryEscape(token), nil); err == nil {
+		t.Error("consumed token accepted a second time")
+	}
+}
+
+// TestReconnectRejectsInvalidToken verifies a forged token never upgrades.
+func TestReconnectRejectsInvalidToken(t *testing.T) {
+	h, _, _, _ := newCloseTestHandler(t)
+	h.SetReconnectTokens(manager.NewReconnectTokens("test-secret", 2*time.Minute))
+
+	srv := httptest.NewServer(http.HandlerFunc(h.HandleWebSocket))
+	defer srv.Close()
+
+	_, resp, err := websocket.DefaultDialer.Dial(
+		"ws"+strings.TrimPrefix(srv.URL, "http")+"?reconnect_token=bogus.bogus", nil)
+	if err == nil {
+		t.Fatal("dial with a forged token succeeded")
+	}
+	if resp == nil || resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("status = %v, want 401", resp)
+	}
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"context"
+	"runtime/debug"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/logging"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/manager"
+	speechv1 "github.com/CurvatureX/ai-tutor-monorepo/shared/proto/speech"
+)
+
+var pipelinePanics = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "pipeline_panics_total",
+	Help: "Panics recovered in pipeline goroutines and gRPC handlers, by stage.",
+}, []string{"stage"})
+
+// RecoveryUnaryInterceptor recovers from panics in unary handlers so one bad
+// request cannot take down every active session.
+func RecoveryUnaryInterceptor(logger *logrus.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				logPanic(logger, info.FullMethod, r)
+				err = status.Errorf(codes.Internal, "internal error")
+			}
+		}()
+		return handler(ctx, req)
+	}
+}
+
+// RecoveryStreamInterceptor is the streaming counterpart of
+// RecoveryUnaryInterceptor.
+func RecoveryStreamInterceptor(logger *logrus.Logger) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				logPanic(logger, info.FullMethod, r)
+				err = status.Errorf(codes.Internal, "internal error")
+			}
+		}()
+		return handler(srv, ss)
+	}
+}
+
+// logPanic records a recovered panic with its stack and bumps the metric.
+func logPanic(logger *logrus.Logger, stage string, recovered interface{}) {
+	pipelinePanics.WithLabelValues(stage).Inc()
+	logger.WithFields(logrus.Fields{
+		"stage": stage,
+		"panic": recovered,
+		"stack": string(debug.Stack()),
+	}).Error("Recovered from panic")
+}
+
+// safeGo runs one pipeline stage in its own goroutine, containing any panic
+// to the session that triggered it: the panic is logged, counted, and turned
+// into an internal-error result for that session only.
+func (h *SpeechHandler) safeGo(session *VoiceSession, stage string, fn func()) {
+	h.trackGoroutine(session.ID, 1)
+	go func() {
+		defer h.trackGoroutine(session.ID, -1)
+		h.safeRun(session, stage, fn)
+	}()
+}
+
+// safeRun is safeGo without the goroutine: worker-pool jobs run on a pooled
+// worker's stack but need the same per-session panic containment.
+func (h *SpeechHandler) safeRun(session *VoiceSession, stage string, fn func()) {
+	defer func() {
+		if r := recover(); r != nil {
+			pipelinePanics.WithLabelValues(stage).Inc()
+			logging.SessionLogger(session.ID, h.logger).WithFields(logrus.Fields{
+				"stage": stage,
+				"panic": r,
+				"stack": string(debug.Stack()),
+			}).Error("Pipeline stage panicked")
+			h.sendError(session, speechv1.ErrorCode_ERROR_CODE_INTERNAL, "internal error")
+		}
+	}()
+	fn()
+}
+
+// safeGo is the WebSocket counterpart: the panic surfaces as an error message
+// on the affected session's connection.
+func (h *WebSocketHandler) safeGo(session *manager.WebSocketSession, stage string, fn func()) {
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				pipelinePanics.WithLabelValues(stage).Inc()
+				logging.SessionLogger(session.ID, h.logger).WithFields(logrus.Fields{
+					"stage": stage,
+					"panic": r,
+					"stack": string(debug.Stack()),
+				}).Error("Pipeline stage panicked")
+				h.sendError(session, "internal error")
+			}
+		}()
+		fn()
+	}()
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/service"
+)
+
+// reportTTL is how long a generated report stays downloadable after the
+// session ended.
+const reportTTL = 24 * time.Hour
+
+// buildSessionReport snapshots the finished session and generates its report.
+func (h *SpeechHandler) buildSessionReport(session *VoiceSession) *model.SessionReport {
+	var transcript []model.TranscriptEntry
+	for _, msg := range session.ConversationHistory.Snapshot() {
+		switch msg.Role {
+		case "user":
+			transcript = append(transcript, model.TranscriptEntry{Speaker: "user", Text: msg.Content})
+		case "assistant":
+			transcript = append(transcript, model.TranscriptEntry{Speaker: "tutor", Text: msg.Content})
+		}
+	}
+	session.iseMu.Lock()
+	iseResults := append([]*model.ISEResponse(nil), session.iseResults...)
+	session.iseMu.Unlock()
+
+	return h.reportGen.Generate(service.SessionReportData{
+		SessionID:    session.ID,
+		UserID:       session.UserID,
+		Duration:     time.Since(session.CreatedAt) - session.pausedDuration(),
+		TotalTurns:   session.TurnCount,
+		CEFREstimate: session.effectiveCEFRLevel(),
+		Transcript:   transcript,
+		ISEResults:   iseResults,
+		Vocabulary:   session.vocabularySnapshot(),
+	})
+}
+
+// storeSessionReport keeps the report downloadable, pruning reports past
+// their TTL so the map cannot grow without bound.
+func (h *SpeechHandler) storeSessionReport(report *model.SessionReport) {
+	h.reportsMu.Lock()
+	defer h.reportsMu.Unlock()
+	for id, r := range h.reports {
+		if time.Since(r.GeneratedAt) > reportTTL {
+			delete(h.reports, id)
+		}
+	}
+	h.reports[report.SessionID] = report
+}
+
+// SessionReport looks up a stored report by session ID.
+func (h *SpeechHandler) SessionReport(id string) (*model.SessionReport, bool) {
+	h.reportsMu.Lock()
+	defer h.reportsMu.Unlock()
+	report, ok := h.reports[id]
+	return report, ok
+}
+
+// ServeSessionReport handles GET /sessions/:id/report, returning the report
+// as JSON, or as plain text with ?format=text.
+func (h *SpeechHandler) ServeSessionReport(c *gin.Context) {
+	report, ok := h.SessionReport(c.Param("id"))
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "report not found"})
+		return
+	}
+	if c.Query("format") == "text" {
+		c.String(http.StatusOK, h.reportGen.FormatText(report))
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/testutil"
+	speechv1 "github.com/CurvatureX/ai-tutor-monorepo/shared/proto/speech"
+)
+
+func TestEndSessionGeneratesDownloadableReport(t *testing.T) {
+	llm := testutil.NewMockLLMServer(testutil.MockLLMConfig{ReplyText: "The museum sounds fascinating!"})
+	defer llm.Close()
+	tts := testutil.NewMockTTSServer(testutil.MockTTSConfig{AudioChunks: [][]byte{[]byte("audio")}})
+	defer tts.Close()
+
+	h := newTestSpeechHandler(t, llm.URL(), tts.URL())
+	stream := &fakeConversationStream{reqCh: make(chan *speechv1.VoiceRequest, 4)}
+	done := make(chan struct{})
+	go func() {
+		h.ProcessVoiceConversation(stream)
+		close(done)
+	}()
+
+	const sessionID = "report-session"
+	// Disable the conversation starter so the transcript holds exactly the
+	// turn exchanged below.
+	stream.reqCh <- &speechv1.VoiceRequest{
+		SessionId: sessionID,
+		UserId:    "learner-1",
+		RequestType: &speechv1.VoiceRequest_Control{
+			Control: &speechv1.ControlMessage{
+				Action:     speechv1.ControlAction_CONTROL_ACTION_CONFIGURE_SESSION,
+				Parameters: map[string]string{"tutor_starts": "false"},
+			},
+		},
+	}
+	stream.reqCh <- &speechv1.VoiceRequest{
+		SessionId:   sessionID,
+		UserId:      "learner-1",
+		RequestType: &speechv1.VoiceRequest_TextInput{TextInput: &speechv1.TextInput{Text: "I visited the museum"}},
+	}
+	waitForResponse(t, stream, `status "complete"`, func(resp *speechv1.VoiceResponse) bool {
+		r, ok := resp.GetResponseType().(*speechv1.VoiceResponse_Status)
+		return ok && r.Status.Status == "complete"
+	})
+
+	stream.reqCh <- controlReq(sessionID, speechv1.ControlAction_CONTROL_ACTION_END_SESSION)
+	var reportURL string
+	waitForResponse(t, stream, `status "session_report_ready"`, func(resp *speechv1.VoiceResponse) bool {
+		r, ok := resp.GetResponseType().(*speechv1.VoiceResponse_Status)
+		if ok && r.Status.Status == "session_report_ready" {
+			reportURL = r.Status.Message
+			return true
+		}
+		return false
+	})
+	if reportURL != "/sessions/report-session/report" {
+		t.Errorf("report URL = %q, want /sessions/report-session/report", reportURL)
+	}
+	close(stream.reqCh)
+	<-done
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/sessions/:id/report", h.ServeSessionReport)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, reportURL, nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET %s = %d, want 200", reportURL, rec.Code)
+	}
+	var report struct {
+		SessionID  string `json:"session_id"`
+		UserID     string `json:"user_id"`
+		TotalTurns int    `json:"total_turns"`
+		Transcript []struct {
+			Speaker string `json:"speaker"`
+			Text    string `json:"text"`
+		} `json:"transcript"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+		t.Fatalf("parse report JSON: %v", err)
+	}
+	if report.SessionID != sessionID || report.UserID != "learner-1" || report.TotalTurns != 1 {
+		t.Errorf("report = %+v, want the session's data", report)
+	}
+	if len(report.Transcript) != 2 || report.Transcript[0].Speaker != "user" || report.Transcript[1].Speaker != "tutor" {
+		t.Errorf("transcript = %+v, want user and tutor entries", report.Transcript)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, reportURL+"?format=text", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET text report = %d, want 200", rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), "Session report report-session") {
+		t.Errorf("text report body:\n%s", rec.Body.String())
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/sessions/unknown/report", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("GET unknown report = %d, want 404", rec.Code)
+	}
+}
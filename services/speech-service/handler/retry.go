@@ -0,0 +1,221 @@
+package handler
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/logging"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+	speechv1 "github.com/CurvatureX/ai-tutor-monorepo/shared/proto/speech"
+)
+
+// Stages the retry control action can re-run.
+const (
+	retryStageISE = "ise"
+	retryStageLLM = "llm"
+	retryStageTTS = "tts"
+)
+
+// turnArtifacts is what a finished turn leaves behind so a transiently failed
+// stage can be re-run without re-recording the utterance: the converted
+// audio, the texts each stage consumes, and how often each stage has been
+// retried already.
+type turnArtifacts struct {
+	turnID int
+	// pcmData is the canonical PCM conversion of the utterance, the form the
+	// ISE retry uploads directly.
+	pcmData []byte
+	// iseReference is the text pronunciation was scored against; empty in
+	// free-speak mode, where the transcript takes its place.
+	iseReference string
+	transcript   string
+	responseText string
+	retries      map[string]int
+}
+
+// retainTurn keeps a turn's audio for later retries, evicting the oldest
+// retained turns once the session exceeds the configured byte budget. A
+// budget of 0 disables retention.
+func (s *VoiceSession) retainTurn(turnID int, pcmData []byte, iseReference string, budgetBytes int) {
+	if budgetBytes <= 0 {
+		return
+	}
+	s.retryMu.Lock()
+	defer s.retryMu.Unlock()
+	s.retainedTurns = append(s.retainedTurns, &turnArtifacts{
+		turnID:       turnID,
+		pcmData:      pcmData,
+		iseReference: iseReference,
+		retries:      make(map[string]int),
+	})
+	s.retainedBytes += len(pcmData)
+	for s.retainedBytes > budgetBytes && len(s.retainedTurns) > 0 {
+		s.retainedBytes -= len(s.retainedTurns[0].pcmData)
+		s.retainedTurns = s.retainedTurns[1:]
+	}
+}
+
+// retainedTurn looks up a retained turn; nil means it was never retained or
+// has been evicted.
+func (s *VoiceSession) retainedTurn(turnID int) *turnArtifacts {
+	s.retryMu.Lock()
+	defer s.retryMu.Unlock()
+	for _, art := range s.retainedTurns {
+		if art.turnID == turnID {
+			return art
+		}
+	}
+	return nil
+}
+
+// noteTranscript records the ASR transcript on the retained turn, if it is
+// still retained.
+func (s *VoiceSession) noteTranscript(turnID int, text string) {
+	s.retryMu.Lock()
+	defer s.retryMu.Unlock()
+	for _, art := range s.retainedTurns {
+		if art.turnID == turnID {
+			art.transcript = text
+			return
+		}
+	}
+}
+
+// noteResponseText records the tutor reply on the retained turn, if it is
+// still retained.
+func (s *VoiceSession) noteResponseText(turnID int, text string) {
+	s.retryMu.Lock()
+	defer s.retryMu.Unlock()
+	for _, art := range s.retainedTurns {
+		if art.turnID == turnID {
+			art.responseText = text
+			return
+		}
+	}
+}
+
+// bumpRetry increments and returns the retry counter for one stage.
+func (s *VoiceSession) bumpRetry(art *turnArtifacts, stage string) int {
+	s.retryMu.Lock()
+	defer s.retryMu.Unlock()
+	art.retries[stage]++
+	return art.retries[stage]
+}
+
+// handleRetryTurn re-runs one stage of a retained turn against its stored
+// artifacts, so a transient ISE/LLM/TTS failure doesn't force the learner to
+// repeat the whole utterance.
+func (h *SpeechHandler) handleRetryTurn(session *VoiceSession, params map[string]string) {
+	turnID, err := strconv.Atoi(params["turn_id"])
+	if err != nil {
+		h.sendError(session, speechv1.ErrorCode_ERROR_CODE_INVALID_REQUEST, "retry requires a numeric turn_id parameter")
+		return
+	}
+	stage := params["stage"]
+
+	art := session.retainedTurn(turnID)
+	if art == nil {
+		h.sendError(session, speechv1.ErrorCode_ERROR_CODE_INVALID_REQUEST,
+			fmt.Sprintf("turn %d is not retained for retry; only recent turns within the audio budget can be retried", turnID))
+		return
+	}
+
+	switch stage {
+	case retryStageISE:
+		reference := art.iseReference
+		if reference == "" {
+			// Free-speak turns are scored against their own transcript.
+			reference = art.transcript
+		}
+		if reference == "" {
+			h.sendError(session, speechv1.ErrorCode_ERROR_CODE_INVALID_REQUEST,
+				fmt.Sprintf("turn %d has no reference text or transcript to evaluate against", turnID))
+			return
+		}
+		attempt := session.bumpRetry(art, stage)
+		h.safeGo(session, "ise", func() { h.retryISE(session, art, reference, attempt) })
+	case retryStageLLM:
+		if art.transcript == "" {
+			h.sendError(session, speechv1.ErrorCode_ERROR_CODE_INVALID_REQUEST,
+				fmt.Sprintf("turn %d has no transcript to generate a reply from", turnID))
+			return
+		}
+		attempt := session.bumpRetry(art, stage)
+		h.safeGo(session, "llm", func() { h.retryLLM(session, art, attempt) })
+	case retryStageTTS:
+		if art.responseText == "" {
+			h.sendError(session, speechv1.ErrorCode_ERROR_CODE_INVALID_REQUEST,
+				fmt.Sprintf("turn %d has no reply to synthesize", turnID))
+			return
+		}
+		attempt := session.bumpRetry(art, stage)
+		h.safeGo(session, "tts", func() { h.retryTTS(session, art, attempt) })
+	default:
+		h.sendError(session, speechv1.ErrorCode_ERROR_CODE_INVALID_REQUEST,
+			`retry stage must be "ise", "tts" or "llm"`)
+	}
+}
+
+// retryISE re-evaluates the retained audio and delivers a fresh ISE result.
+func (h *SpeechHandler) retryISE(session *VoiceSession, art *turnArtifacts, reference string, attempt int) {
+	resp, err := h.iseService.EvaluateSpeechNormalized(session.stream.Context(), &model.ISERequest{
+		AudioData: art.pcmData,
+		Text:      reference,
+		Language:  iseLanguage(session.Language),
+	})
+	if err != nil {
+		logging.SessionLogger(session.ID, h.logger).WithError(err).WithField(logging.LogFieldService, "ise").Error("ISE retry failed")
+		h.sendError(session, speechv1.ErrorCode_ERROR_CODE_ISE_FAILED, "pronunciation evaluation failed")
+		return
+	}
+	h.sendISEResult(session, resp)
+	h.sendRetryComplete(session, art.turnID, retryStageISE, attempt)
+}
+
+// retryLLM regenerates the tutor reply from the retained transcript. The
+// fresh reply replaces the retained one, so a follow-up TTS retry speaks it.
+func (h *SpeechHandler) retryLLM(session *VoiceSession, art *turnArtifacts, attempt int) {
+	resp, err := h.llmFor(session).GeneratePracticeResponse(art.transcript, session.effectiveCEFRLevel(),
+		session.PracticeMode, session.PracticeTopic, session.ConversationHistory)
+	if err != nil {
+		logging.SessionLogger(session.ID, h.logger).WithError(err).WithField(logging.LogFieldService, "llm").Error("LLM retry failed")
+		h.sendError(session, speechv1.ErrorCode_ERROR_CODE_LLM_FAILED, "response generation failed")
+		return
+	}
+	session.noteResponseText(art.turnID, resp.Text)
+	session.send(&speechv1.VoiceResponse{
+		ResponseType: &speechv1.VoiceResponse_LlmResult{
+			LlmResult: &speechv1.LLMResult{
+				Text:       resp.Text,
+				Type:       speechv1.LLMResultType_LLM_RESULT_TYPE_RESPONSE,
+				TokensUsed: int32(resp.TokensUsed),
+			},
+		},
+	})
+	h.sendRetryComplete(session, art.turnID, retryStageLLM, attempt)
+}
+
+// retryTTS re-synthesizes the retained reply, tagged with the original turn.
+// It bypasses the synthesis cache: the client is retrying because the audio
+// it got was bad, and the cache would hand those bytes straight back.
+func (h *SpeechHandler) retryTTS(session *VoiceSession, art *turnArtifacts, attempt int) {
+	resp, err := h.ttsService.ResynthesizeSpeech(&model.TTSRequest{
+		Text:     art.responseText,
+		Voice:    session.Voice,
+		Language: session.Language,
+	})
+	if err != nil {
+		logging.SessionLogger(session.ID, h.logger).WithError(err).WithField(logging.LogFieldService, "tts").Error("TTS retry failed")
+		h.sendError(session, speechv1.ErrorCode_ERROR_CODE_TTS_FAILED, "speech synthesis failed")
+		return
+	}
+	h.recorder.RecordTTS(session.ID, art.turnID, resp.AudioData)
+	h.deliverSynthesized(session, art.turnID, resp)
+	h.sendRetryComplete(session, art.turnID, retryStageTTS, attempt)
+}
+
+// sendRetryComplete reports a finished retry with the original turn ID and
+// the per-stage retry counter.
+func (h *SpeechHandler) sendRetryComplete(session *VoiceSession, turnID int, stage string, attempt int) {
+	h.sendStatus(session, "retry_complete", fmt.Sprintf("turn %d %s retry %d", turnID, stage, attempt))
+}
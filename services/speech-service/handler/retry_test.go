@@ -0,0 +1,181 @@
+package handler
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/config"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/events"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/service"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/store"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/testutil"
+	speechv1 "github.com/CurvatureX/ai-tutor-monorepo/shared/proto/speech"
+)
+
+// newRetryHandler builds a SpeechHandler that retains turn audio for stage
+// retries within the given byte budget.
+func newRetryHandler(t *testing.T, asrURL, llmURL, ttsURL string, budgetBytes int) *SpeechHandler {
+	t.Helper()
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	publisher, err := events.NewPublisher(config.EventsConfig{Publisher: "none"}, logger)
+	if err != nil {
+		t.Fatalf("NewPublisher: %v", err)
+	}
+	return NewSpeechHandler(
+		service.NewASRService(config.ASRConfig{BaseURL: asrURL, Cluster: "test"}, logger),
+		service.NewTTSService(config.TTSConfig{BaseURL: ttsURL, VoiceType: "test_voice", Speed: 1.0}, logger),
+		service.NewLLMService(config.LLMConfig{BaseURL: llmURL, Model: "test-model", MaxTokens: 50}, nil, logger),
+		service.NewISEService(config.ISEConfig{}, logger),
+		service.NewAudioService(config.AudioConfig{}, logger),
+		publisher, store.NewSessionRecorder(false, nil, logger), 0, budgetBytes, pausePolicyBuffer,
+		ISEModeReferenced, config.SLOConfig{}, logger)
+}
+
+// conversationalRequests counts the LLM calls carrying the tutor system
+// prompt, leaving out the asynchronous per-turn vocabulary extraction that
+// shares the mock.
+func conversationalRequests(llm *testutil.MockLLMServer) int {
+	n := 0
+	for _, raw := range llm.Requests() {
+		if strings.Contains(string(raw), "English tutor") {
+			n++
+		}
+	}
+	return n
+}
+
+func retryReq(sessionID string, turnID int, stage string) *speechv1.VoiceRequest {
+	return &speechv1.VoiceRequest{
+		SessionId: sessionID,
+		RequestType: &speechv1.VoiceRequest_Control{
+			Control: &speechv1.ControlMessage{
+				Action:     speechv1.ControlAction_CONTROL_ACTION_RETRY_TURN,
+				Parameters: map[string]string{"turn_id": strconv.Itoa(turnID), "stage": stage},
+			},
+		},
+	}
+}
+
+// runRetryConversation drives ProcessVoiceConversation on a manually fed
+// stream so the retry control can be sent after the turn's pipeline has
+// actually finished, which is when the artifacts are complete.
+func runRetryConversation(t *testing.T, h *SpeechHandler, sessionID string) (*fakeConversationStream, chan<- *speechv1.VoiceRequest, func()) {
+	t.Helper()
+	reqCh := make(chan *speechv1.VoiceRequest)
+	stream := &fakeConversationStream{reqCh: reqCh}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if err := h.ProcessVoiceConversation(stream); err != nil {
+			t.Errorf("ProcessVoiceConversation: %v", err)
+		}
+	}()
+	return stream, reqCh, func() {
+		close(reqCh)
+		<-done
+	}
+}
+
+func TestRetryReRunsSingleStage(t *testing.T) {
+	asr := testutil.NewMockASRServer(testutil.MockASRConfig{Transcript: "i like music", Confidence: 0.9})
+	defer asr.Close()
+	llm := testutil.NewMockLLMServer(testutil.MockLLMConfig{ReplyText: "What kind of music do you like?"})
+	defer llm.Close()
+	tts := testutil.NewMockTTSServer(testutil.MockTTSConfig{AudioChunks: [][]byte{[]byte("reply-audio")}})
+	defer tts.Close()
+
+	h := newRetryHandler(t, asr.URL(), llm.URL(), tts.URL(), 1024*1024)
+	sessionID := "retry-session"
+	stream, reqCh, finish := runRetryConversation(t, h, sessionID)
+	defer finish()
+
+	reqCh <- configureReq(sessionID, map[string]string{"tutor_starts": "false"})
+	reqCh <- freeSpeakAudioRequest(sessionID)
+	waitForResponse(t, stream, "turn audio", func(resp *speechv1.VoiceResponse) bool {
+		_, ok := resp.GetResponseType().(*speechv1.VoiceResponse_TtsResult)
+		return ok
+	})
+
+	reqCh <- retryReq(sessionID, 1, "tts")
+	waitForResponse(t, stream, "tts retry status", func(resp *speechv1.VoiceResponse) bool {
+		r, ok := resp.GetResponseType().(*speechv1.VoiceResponse_Status)
+		return ok && r.Status.Status == "retry_complete" && r.Status.Message == "turn 1 tts retry 1"
+	})
+	if got := tts.Dials(); got != 2 {
+		t.Errorf("tts dials = %d, want 2 after one retry", got)
+	}
+	// Retrying TTS must not re-run the LLM stage.
+	if got := conversationalRequests(llm); got != 1 {
+		t.Errorf("llm requests = %d, want 1", got)
+	}
+
+	reqCh <- retryReq(sessionID, 1, "llm")
+	waitForResponse(t, stream, "llm retry status", func(resp *speechv1.VoiceResponse) bool {
+		r, ok := resp.GetResponseType().(*speechv1.VoiceResponse_Status)
+		return ok && r.Status.Status == "retry_complete" && r.Status.Message == "turn 1 llm retry 1"
+	})
+	if got := conversationalRequests(llm); got != 2 {
+		t.Errorf("llm requests = %d, want 2 after llm retry", got)
+	}
+}
+
+func TestRetryEvictedTurnReturnsError(t *testing.T) {
+	asr := testutil.NewMockASRServer(testutil.MockASRConfig{Transcript: "hello there", Confidence: 0.9})
+	defer asr.Close()
+	llm := testutil.NewMockLLMServer(testutil.MockLLMConfig{ReplyText: "Hello! How are you?"})
+	defer llm.Close()
+	tts := testutil.NewMockTTSServer(testutil.MockTTSConfig{AudioChunks: [][]byte{[]byte("audio")}})
+	defer tts.Close()
+
+	// A one-byte budget evicts every turn the moment it is retained.
+	h := newRetryHandler(t, asr.URL(), llm.URL(), tts.URL(), 1)
+	sessionID := "evicted-session"
+	stream, reqCh, finish := runRetryConversation(t, h, sessionID)
+	defer finish()
+
+	reqCh <- configureReq(sessionID, map[string]string{"tutor_starts": "false"})
+	reqCh <- freeSpeakAudioRequest(sessionID)
+	waitForResponse(t, stream, "turn audio", func(resp *speechv1.VoiceResponse) bool {
+		_, ok := resp.GetResponseType().(*speechv1.VoiceResponse_TtsResult)
+		return ok
+	})
+
+	reqCh <- retryReq(sessionID, 1, "tts")
+	waitForResponse(t, stream, "eviction error", func(resp *speechv1.VoiceResponse) bool {
+		r, ok := resp.GetResponseType().(*speechv1.VoiceResponse_Error)
+		return ok && strings.Contains(r.Error.Message, "turn 1 is not retained")
+	})
+}
+
+func TestRetryUnsupportedStageReturnsError(t *testing.T) {
+	asr := testutil.NewMockASRServer(testutil.MockASRConfig{Transcript: "hello there", Confidence: 0.9})
+	defer asr.Close()
+	llm := testutil.NewMockLLMServer(testutil.MockLLMConfig{ReplyText: "Hello! How are you?"})
+	defer llm.Close()
+	tts := testutil.NewMockTTSServer(testutil.MockTTSConfig{AudioChunks: [][]byte{[]byte("audio")}})
+	defer tts.Close()
+
+	h := newRetryHandler(t, asr.URL(), llm.URL(), tts.URL(), 1024*1024)
+	sessionID := "bad-stage-session"
+	stream, reqCh, finish := runRetryConversation(t, h, sessionID)
+	defer finish()
+
+	reqCh <- configureReq(sessionID, map[string]string{"tutor_starts": "false"})
+	reqCh <- freeSpeakAudioRequest(sessionID)
+	waitForResponse(t, stream, "turn audio", func(resp *speechv1.VoiceResponse) bool {
+		_, ok := resp.GetResponseType().(*speechv1.VoiceResponse_TtsResult)
+		return ok
+	})
+
+	reqCh <- retryReq(sessionID, 1, "asr")
+	waitForResponse(t, stream, "stage error", func(resp *speechv1.VoiceResponse) bool {
+		r, ok := resp.GetResponseType().(*speechv1.VoiceResponse_Error)
+		return ok && strings.Contains(r.Error.Message, "retry stage")
+	})
+}
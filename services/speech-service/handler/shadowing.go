@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/logging"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+	speechv1 "github.com/CurvatureX/ai-tutor-monorepo/shared/proto/speech"
+)
+
+// Word alignment operations reported in a ShadowingResult.
+const (
+	alignMatch       = "match"
+	alignSubstituted = "substituted"
+	alignMissing     = "missing"
+	alignExtra       = "extra"
+)
+
+// startShadowing begins a shadowing exercise: the tutor speaks the target
+// text and the next utterance is scored and diffed against it instead of
+// feeding the conversation.
+func (h *SpeechHandler) startShadowing(session *VoiceSession, params map[string]string) {
+	target := params["target_text"]
+	if target == "" {
+		h.sendError(session, speechv1.ErrorCode_ERROR_CODE_INVALID_REQUEST, "start_shadowing requires target_text")
+		return
+	}
+	if session.ShadowingTarget == "" {
+		session.shadowingPrevReference = session.ReferenceText
+	}
+	session.ShadowingTarget = target
+	session.ReferenceText = target
+	h.sendStatus(session, "shadowing_started", target)
+
+	h.safeGo(session, "tts", func() {
+		resp, err := h.ttsService.SynthesizeSpeech(&model.TTSRequest{
+			Text:     target,
+			Voice:    session.Voice,
+			Language: session.Language,
+		})
+		if err != nil {
+			logging.SessionLogger(session.ID, h.logger).WithError(err).WithField(logging.LogFieldService, "tts").Error("Shadowing target synthesis failed")
+			h.sendError(session, speechv1.ErrorCode_ERROR_CODE_TTS_FAILED, "speech synthesis failed")
+			return
+		}
+		h.deliverSynthesized(session, 0, resp)
+	})
+}
+
+// sendShadowingResult diffs the transcript against the shadowing target and
+// completes the turn. The ISE evaluation of the same utterance arrives
+// separately.
+func (h *SpeechHandler) sendShadowingResult(session *VoiceSession, target, transcript string) {
+	result := &speechv1.ShadowingResult{
+		TargetText: target,
+		Transcript: transcript,
+	}
+	for _, entry := range alignWords(strings.Fields(target), strings.Fields(transcript)) {
+		result.Words = append(result.Words, &speechv1.WordAlignment{
+			TargetWord: entry.Target,
+			HeardWord:  entry.Heard,
+			Op:         entry.Op,
+		})
+	}
+	session.send(&speechv1.VoiceResponse{
+		ResponseType: &speechv1.VoiceResponse_ShadowingResult{ShadowingResult: result},
+	})
+	h.sendStatus(session, "complete", "shadowing")
+}
+
+// wordAlignment is one aligned position between target and heard words.
+type wordAlignment struct {
+	Target string
+	Heard  string
+	Op     string
+}
+
+// alignWords computes a word-level edit alignment between the target sentence
+// and what was heard, marking each position as match, substituted, missing
+// (target word not heard) or extra (heard word not in the target). Words
+// compare case-insensitively with surrounding punctuation ignored; the output
+// keeps the original spellings.
+func alignWords(target, heard []string) []wordAlignment {
+	n, m := len(target), len(heard)
+	// dist[i][j] is the edit distance between target[:i] and heard[:j].
+	dist := make([][]int, n+1)
+	for i := range dist {
+		dist[i] = make([]int, m+1)
+		dist[i][0] = i
+	}
+	for j := 0; j <= m; j++ {
+		dist[0][j] = j
+	}
+	for i := 1; i <= n; i++ {
+		for j := 1; j <= m; j++ {
+			sub := dist[i-1][j-1]
+			if !wordsEqual(target[i-1], heard[j-1]) {
+				sub++
+			}
+			dist[i][j] = min3(sub, dist[i-1][j]+1, dist[i][j-1]+1)
+		}
+	}
+
+	// Backtrack from the corner, preferring diagonal moves so substitutions
+	// line words up instead of reporting a missing/extra pair.
+	var reversed []wordAlignment
+	i, j := n, m
+	for i > 0 || j > 0 {
+		switch {
+		case i > 0 && j > 0 && wordsEqual(target[i-1], heard[j-1]) && dist[i][j] == dist[i-1][j-1]:
+			reversed = append(reversed, wordAlignment{Target: target[i-1], Heard: heard[j-1], Op: alignMatch})
+			i, j = i-1, j-1
+		case i > 0 && j > 0 && dist[i][j] == dist[i-1][j-1]+1:
+			reversed = append(reversed, wordAlignment{Target: target[i-1], Heard: heard[j-1], Op: alignSubstituted})
+			i, j = i-1, j-1
+		case i > 0 && dist[i][j] == dist[i-1][j]+1:
+			reversed = append(reversed, wordAlignment{Target: target[i-1], Op: alignMissing})
+			i--
+		default:
+			reversed = append(reversed, wordAlignment{Heard: heard[j-1], Op: alignExtra})
+			j--
+		}
+	}
+
+	aligned := make([]wordAlignment, 0, len(reversed))
+	for k := len(reversed) - 1; k >= 0; k-- {
+		aligned = append(aligned, reversed[k])
+	}
+	return aligned
+}
+
+// wordsEqual compares words case-insensitively, ignoring surrounding
+// punctuation so "museum." still matches "museum".
+func wordsEqual(a, b string) bool {
+	return normalizeWord(a) == normalizeWord(b)
+}
+
+func normalizeWord(word string) string {
+	trimmed := strings.TrimFunc(word, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	return strings.ToLower(trimmed)
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
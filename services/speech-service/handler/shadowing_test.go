@@ -0,0 +1,189 @@
+package handler
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/testutil"
+	speechv1 "github.com/CurvatureX/ai-tutor-monorepo/shared/proto/speech"
+)
+
+func TestAlignWords(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		heard  string
+		want   []wordAlignment
+	}{
+		{
+			name:   "exact match",
+			target: "I visited the museum",
+			heard:  "I visited the museum",
+			want: []wordAlignment{
+				{Target: "I", Heard: "I", Op: "match"},
+				{Target: "visited", Heard: "visited", Op: "match"},
+				{Target: "the", Heard: "the", Op: "match"},
+				{Target: "museum", Heard: "museum", Op: "match"},
+			},
+		},
+		{
+			name:   "substituted word",
+			target: "she sells shells",
+			heard:  "she smells shells",
+			want: []wordAlignment{
+				{Target: "she", Heard: "she", Op: "match"},
+				{Target: "sells", Heard: "smells", Op: "substituted"},
+				{Target: "shells", Heard: "shells", Op: "match"},
+			},
+		},
+		{
+			name:   "missing word",
+			target: "I visited the big museum",
+			heard:  "I visited the museum",
+			want: []wordAlignment{
+				{Target: "I", Heard: "I", Op: "match"},
+				{Target: "visited", Heard: "visited", Op: "match"},
+				{Target: "the", Heard: "the", Op: "match"},
+				{Target: "big", Op: "missing"},
+				{Target: "museum", Heard: "museum", Op: "match"},
+			},
+		},
+		{
+			name:   "extra word",
+			target: "see you tomorrow",
+			heard:  "see you again tomorrow",
+			want: []wordAlignment{
+				{Target: "see", Heard: "see", Op: "match"},
+				{Target: "you", Heard: "you", Op: "match"},
+				{Heard: "again", Op: "extra"},
+				{Target: "tomorrow", Heard: "tomorrow", Op: "match"},
+			},
+		},
+		{
+			name:   "case and punctuation ignored",
+			target: "Hello, how are you?",
+			heard:  "hello how are you",
+			want: []wordAlignment{
+				{Target: "Hello,", Heard: "hello", Op: "match"},
+				{Target: "how", Heard: "how", Op: "match"},
+				{Target: "are", Heard: "are", Op: "match"},
+				{Target: "you?", Heard: "you", Op: "match"},
+			},
+		},
+		{
+			name:   "empty transcript marks everything missing",
+			target: "good morning",
+			heard:  "",
+			want: []wordAlignment{
+				{Target: "good", Op: "missing"},
+				{Target: "morning", Op: "missing"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := alignWords(strings.Fields(tt.target), strings.Fields(tt.heard))
+			if len(got) != len(tt.want) {
+				t.Fatalf("alignWords returned %d entries, want %d: %+v", len(got), len(tt.want), got)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("entry %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestShadowingTurnDiffsTranscriptAndSkipsLLM(t *testing.T) {
+	asr := testutil.NewMockASRServer(testutil.MockASRConfig{Transcript: "i visited the museum", Confidence: 0.91})
+	defer asr.Close()
+	ise := testutil.NewMockISEServer(testutil.MockISEConfig{})
+	defer ise.Close()
+	llm := testutil.NewMockLLMServer(testutil.MockLLMConfig{ReplyText: "unused"})
+	defer llm.Close()
+	tts := testutil.NewMockTTSServer(testutil.MockTTSConfig{AudioChunks: [][]byte{[]byte("target-audio")}})
+	defer tts.Close()
+
+	h := newFreeSpeakHandler(t, asr.URL(), ise.URL(), llm.URL(), tts.URL())
+	sessionID := "shadowing-session"
+	start := &speechv1.VoiceRequest{
+		SessionId: sessionID,
+		RequestType: &speechv1.VoiceRequest_Control{
+			Control: &speechv1.ControlMessage{
+				Action:     speechv1.ControlAction_CONTROL_ACTION_START_SHADOWING,
+				Parameters: map[string]string{"target_text": "I visited the big museum"},
+			},
+		},
+	}
+	stream := newFakeStream(
+		configureReq(sessionID, map[string]string{"tutor_starts": "false"}),
+		start,
+		freeSpeakAudioRequest(sessionID),
+	)
+	if err := h.ProcessVoiceConversation(stream); err != nil {
+		t.Fatalf("ProcessVoiceConversation: %v", err)
+	}
+
+	waitForResponse(t, stream, "shadowing target audio", func(resp *speechv1.VoiceResponse) bool {
+		r, ok := resp.GetResponseType().(*speechv1.VoiceResponse_TtsResult)
+		return ok && string(r.TtsResult.AudioData) == "target-audio"
+	})
+
+	var result *speechv1.ShadowingResult
+	waitForResponse(t, stream, "shadowing result", func(resp *speechv1.VoiceResponse) bool {
+		if r, ok := resp.GetResponseType().(*speechv1.VoiceResponse_ShadowingResult); ok {
+			result = r.ShadowingResult
+			return true
+		}
+		return false
+	})
+	if result.TargetText != "I visited the big museum" {
+		t.Errorf("TargetText = %q", result.TargetText)
+	}
+	if result.Transcript != "i visited the museum" {
+		t.Errorf("Transcript = %q", result.Transcript)
+	}
+	wantOps := []string{"match", "match", "match", "missing", "match"}
+	if len(result.Words) != len(wantOps) {
+		t.Fatalf("got %d aligned words, want %d: %+v", len(result.Words), len(wantOps), result.Words)
+	}
+	for i, word := range result.Words {
+		if word.Op != wantOps[i] {
+			t.Errorf("word %d op = %q, want %q (%+v)", i, word.Op, wantOps[i], word)
+		}
+	}
+	if result.Words[3].TargetWord != "big" {
+		t.Errorf("missing word = %q, want big", result.Words[3].TargetWord)
+	}
+
+	// The shadowing turn replaces the conversational reply: even in free-speak
+	// mode the target text, not the transcript, is the ISE reference and the
+	// LLM never runs.
+	if got := ise.ReferenceText(); got != "I visited the big museum" {
+		t.Errorf("ISE reference text = %q, want the shadowing target", got)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if n := len(llm.Requests()); n != 0 {
+		t.Errorf("LLM saw %d requests during a shadowing turn, want 0", n)
+	}
+}
+
+func TestStartShadowingWithoutTargetIsRejected(t *testing.T) {
+	llm := testutil.NewMockLLMServer(testutil.MockLLMConfig{ReplyText: "unused"})
+	defer llm.Close()
+	tts := testutil.NewMockTTSServer(testutil.MockTTSConfig{AudioChunks: [][]byte{[]byte("audio")}})
+	defer tts.Close()
+
+	h := newTestSpeechHandler(t, llm.URL(), tts.URL())
+	stream := newFakeStream(controlReq("shadowing-no-target", speechv1.ControlAction_CONTROL_ACTION_START_SHADOWING))
+	if err := h.ProcessVoiceConversation(stream); err != nil {
+		t.Fatalf("ProcessVoiceConversation: %v", err)
+	}
+
+	waitForResponse(t, stream, "invalid request error", func(resp *speechv1.VoiceResponse) bool {
+		r, ok := resp.GetResponseType().(*speechv1.VoiceResponse_Error)
+		return ok && r.Error.Code == speechv1.ErrorCode_ERROR_CODE_INVALID_REQUEST
+	})
+}
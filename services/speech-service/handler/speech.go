@@ -0,0 +1,1267 @@
+// Package handler implements the speech-service's gRPC conversation stream:
+// it owns per-session state, sequences the ASR -> LLM -> TTS turn pipeline
+// with ISE scoring in parallel, and streams results back to the gateway.
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/audio"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/config"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/events"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/logging"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/memory"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/service"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/store"
+	speechv1 "github.com/CurvatureX/ai-tutor-monorepo/shared/proto/speech"
+)
+
+// VoiceSession is the per-stream conversation state for one gRPC client.
+type VoiceSession struct {
+	ID           string
+	UserID       string
+	Language     string
+	Voice        string
+	CEFREstimate string
+	IsRecording  bool
+	CreatedAt    time.Time
+	LastActivity time.Time
+
+	// Metadata is the client metadata the gateway forwarded as gRPC metadata
+	// (see the MetadataKey* constants): client version, preferred language,
+	// transport. Nil for streams that carried none.
+	Metadata map[string]string
+
+	// AudioBuffer accumulates chunks for the utterance being recorded.
+	AudioBuffer []byte
+	AudioFormat string
+
+	ConversationHistory *model.ConversationHistory
+	ReferenceText       string
+	TurnCount           int
+
+	// Scenario seeds the conversation starter; empty means a generic one.
+	Scenario string
+	// ShadowingTarget, when non-empty, marks the next utterance as a
+	// shadowing repetition: it is scored against this text and diffed
+	// word-by-word instead of feeding the conversation.
+	ShadowingTarget string
+	// shadowingPrevReference restores ReferenceText once the shadowing turn
+	// is consumed.
+	shadowingPrevReference string
+	// TutorStartsDisabled skips the spoken conversation starter, set by the
+	// tutor_starts=false session option.
+	TutorStartsDisabled bool
+
+	// ISEDisabled turns pronunciation evaluation off for the whole session,
+	// set by the connect-time configure_session control. LLMDisabled and
+	// TTSDisabled do the same for their stages; session options can only
+	// disable stages on top of the deployment's pipeline switches, never
+	// enable ones the deployment turned off.
+	ISEDisabled bool
+	LLMDisabled bool
+	TTSDisabled bool
+
+	// TTSFormat is the wire format the session negotiated for synthesized
+	// replies via the tts_format session option: "ogg" means replies are
+	// re-encoded to Opus/Ogg (roughly a third the size of the providers'
+	// MP3) and streamed chunk-wise. Empty means the provider's native
+	// format, delivered as a single chunk.
+	TTSFormat string
+
+	// Deterministic pins the session's outputs for reproducible test runs:
+	// completions run at temperature 0, provider request ids derive from the
+	// session and turn, and the conversation starter bypasses the shared
+	// cache. Set by the deterministic session option, which only takes effect
+	// when the deployment enables it; see deterministic.go.
+	Deterministic bool
+
+	// SplitUtterances breaks a recording that parsed into several sentences
+	// at the recognizer's utterance boundaries and runs evaluation and the
+	// reply per sentence, set by the split_utterances session option; see
+	// split.go.
+	SplitUtterances bool
+
+	// PracticeMode and PracticeTopic select the tutor's turn structure, set
+	// by the practice_mode/practice_topic session options. The zero value
+	// behaves as free conversation.
+	PracticeMode  service.PracticeMode
+	PracticeTopic string
+
+	// AdaptedCEFRLevel overrides CEFREstimate once the difficulty adapter
+	// has moved the session away from its starting level.
+	AdaptedCEFRLevel string
+
+	adaptMu     sync.Mutex
+	adaptDir    string
+	adaptStreak int
+
+	// Pause state. While paused the pipeline keeps running, but synthesized
+	// replies are held in heldTTS and flushed on resume, and incoming audio
+	// is buffered (or rejected) instead of processed.
+	Paused      bool
+	PausedAt    time.Time
+	PausedTotal time.Duration
+
+	pauseMu      sync.Mutex
+	heldTTS      []*speechv1.VoiceResponse
+	pendingFinal bool
+
+	// iseResults accumulates the session's pronunciation evaluations for the
+	// end-of-session report.
+	iseMu      sync.Mutex
+	iseResults []*model.ISEResponse
+
+	// vocabulary accumulates the deduplicated review words extracted after
+	// each turn, for the session summary and the vocabulary endpoint.
+	vocabMu    sync.Mutex
+	vocabulary []model.VocabularyEntry
+	vocabSeen  map[string]bool
+
+	// Clarification state: how many repeat requests the session has made (to
+	// rotate the phrasing) and the per-session threshold overrides.
+	clarifyMu        sync.Mutex
+	clarifyCount     int
+	asrMinConfidence *float64
+	asrMinWords      *int
+
+	// Correction state: the last final ASR transcript (what the
+	// request_correction control corrects when the client sends no text) and
+	// whether a correction is already in flight; see correction.go.
+	correctionMu      sync.Mutex
+	lastTranscript    string
+	correctionPending bool
+
+	// retainedTurns holds the artifacts of recent turns for the retry
+	// control action, oldest first, with retainedBytes tracking the audio
+	// they hold against the configured budget; see retry.go.
+	retryMu       sync.Mutex
+	retainedTurns []*turnArtifacts
+	retainedBytes int
+
+	stream speechv1.SpeechService_ProcessVoiceConversationServer
+	sendMu sync.Mutex
+}
+
+// effectiveCEFRLevel is the level replies should target: the adapted level
+// once the difficulty adapter has moved it, otherwise the configured estimate.
+func (s *VoiceSession) effectiveCEFRLevel() string {
+	s.adaptMu.Lock()
+	defer s.adaptMu.Unlock()
+	if s.AdaptedCEFRLevel != "" {
+		return s.AdaptedCEFRLevel
+	}
+	return s.CEFREstimate
+}
+
+// isPaused reports the pause state under the pause lock.
+func (s *VoiceSession) isPaused() bool {
+	s.pauseMu.Lock()
+	defer s.pauseMu.Unlock()
+	return s.Paused
+}
+
+// beginPause transitions into the paused state. It fails when the session is
+// already paused so clients get an explicit error for invalid transitions.
+func (s *VoiceSession) beginPause() bool {
+	s.pauseMu.Lock()
+	defer s.pauseMu.Unlock()
+	if s.Paused {
+		return false
+	}
+	s.Paused = true
+	s.PausedAt = time.Now()
+	s.IsRecording = false
+	return true
+}
+
+// endPause leaves the paused state, returning the replies held while paused,
+// whether a complete utterance finished buffering in the meantime, and
+// whether the session was paused at all.
+func (s *VoiceSession) endPause() (held []*speechv1.VoiceResponse, pendingFinal, ok bool) {
+	s.pauseMu.Lock()
+	defer s.pauseMu.Unlock()
+	if !s.Paused {
+		return nil, false, false
+	}
+	s.Paused = false
+	s.PausedTotal += time.Since(s.PausedAt)
+	held = s.heldTTS
+	s.heldTTS = nil
+	pendingFinal = s.pendingFinal
+	s.pendingFinal = false
+	return held, pendingFinal, true
+}
+
+// pausedDuration is the total time spent paused, including an in-progress
+// pause, so idle accounting can exclude it.
+func (s *VoiceSession) pausedDuration() time.Duration {
+	s.pauseMu.Lock()
+	defer s.pauseMu.Unlock()
+	total := s.PausedTotal
+	if s.Paused {
+		total += time.Since(s.PausedAt)
+	}
+	return total
+}
+
+// send serializes writes on the gRPC stream: pipeline stages complete from
+// multiple goroutines.
+func (s *VoiceSession) send(resp *speechv1.VoiceResponse) error {
+	s.sendMu.Lock()
+	defer s.sendMu.Unlock()
+	resp.SessionId = s.ID
+	return s.stream.Send(resp)
+}
+
+// turnStats accumulates per-turn results across the concurrent pipeline
+// stages so the turn_completed event carries a complete picture.
+type turnStats struct {
+	mu            sync.Mutex
+	transcript    string
+	responseText  string
+	asrConfidence float64
+	iseScore      *float64
+	tokensUsed    int
+	// audioDurationMs is the measured play time of the utterance's PCM.
+	audioDurationMs int64
+	// quality is the pre-flight analysis of the converted PCM;
+	// qualityWarning is set when the clipping ratio crossed the configured
+	// threshold and rides along on the ASR result.
+	quality        audio.QualityReport
+	qualityWarning string
+	// clarification marks a turn where the transcript was too unreliable to
+	// answer and the tutor asked the learner to repeat instead.
+	clarification bool
+	timings       events.TurnTimings
+}
+
+// SpeechHandler implements the SpeechService gRPC API and orchestrates the
+// ASR → LLM → TTS pipeline (with ISE running alongside when a reference text
+// is set).
+type SpeechHandler struct {
+	speechv1.UnimplementedSpeechServiceServer
+
+	asrService   *service.ASRService
+	ttsService   *service.TTSService
+	llmService   *service.LLMService
+	iseService   *service.ISEService
+	audioService *service.AudioService
+	publisher    events.EventPublisher
+	recorder     *store.SessionRecorder
+	difficulty   *DifficultyAdapter
+	logger       *logrus.Logger
+
+	// maxAudioBytes bounds one buffered utterance; 0 disables the check.
+	maxAudioBytes int
+	// retryBudgetBytes bounds the converted audio retained per session for
+	// stage retries; 0 disables retention.
+	retryBudgetBytes int
+	// pausedAudioPolicy is what happens to audio arriving while paused:
+	// pausePolicyBuffer or pausePolicyReject.
+	pausedAudioPolicy string
+	// iseMode selects referenced or free-speak pronunciation evaluation.
+	iseMode ISEMode
+	// slo holds the per-stage latency budgets for the over-budget warning.
+	slo config.SLOConfig
+	// pipeline holds the deployment's stage switches; nil (the default)
+	// leaves every stage enabled. Set once at startup via SetPipeline.
+	pipeline *config.PipelineConfig
+	// memory is the per-session buffer ledger; nil (the default) disables
+	// accounting. Set once at startup via SetMemoryAccountant.
+	memory *memory.Accountant
+	// pool bounds concurrent utterance processing; nil (the default) fans
+	// every utterance out into its own goroutines. Set once at startup via
+	// SetWorkerPool.
+	pool *WorkerPool
+	// lanes budgets per-provider concurrency across the interactive and batch
+	// scheduling lanes; nil (the default) leaves provider calls unscheduled.
+	// Set once at startup via SetLaneSchedulers.
+	lanes *LaneSchedulers
+	// deterministicAllowed lets sessions opt into deterministic mode; false
+	// (the default) makes the option a no-op. Set once at startup via
+	// SetDeterministicSessions; see deterministic.go.
+	deterministicAllowed bool
+
+	mu       sync.RWMutex
+	sessions map[string]*VoiceSession
+
+	// pipelineGoroutines counts live safeGo goroutines per session, for the
+	// runtime diagnostics endpoint.
+	goroutineMu        sync.Mutex
+	pipelineGoroutines map[string]int
+
+	// reports holds end-of-session reports for download; see report.go.
+	reportGen *service.ReportGenerator
+	reportsMu sync.Mutex
+	reports   map[string]*model.SessionReport
+
+	// histories keeps ended sessions' conversations exportable and resumable
+	// for a day; see history.go.
+	historyExporter *service.ConversationHistoryExporter
+	historyImporter *service.ConversationHistoryImporter
+	historyMu       sync.Mutex
+	histories       map[string]storedHistory
+
+	// starters caches one conversation starter per scenario for a few
+	// minutes so every connection doesn't cost an LLM call; see starter.go.
+	starterMu sync.Mutex
+	starters  map[string]cachedStarter
+}
+
+// NewSpeechHandler wires the pipeline services together.
+func NewSpeechHandler(
+	asr *service.ASRService,
+	tts *service.TTSService,
+	llm *service.LLMService,
+	ise *service.ISEService,
+	audio *service.AudioService,
+	publisher events.EventPublisher,
+	recorder *store.SessionRecorder,
+	maxAudioBytes int,
+	retryBudgetBytes int,
+	pausedAudioPolicy string,
+	iseMode ISEMode,
+	slo config.SLOConfig,
+	logger *logrus.Logger,
+) *SpeechHandler {
+	return &SpeechHandler{
+		asrService:         asr,
+		ttsService:         tts,
+		llmService:         llm,
+		iseService:         ise,
+		audioService:       audio,
+		publisher:          publisher,
+		recorder:           recorder,
+		difficulty:         NewDifficultyAdapter(),
+		maxAudioBytes:      maxAudioBytes,
+		retryBudgetBytes:   retryBudgetBytes,
+		pausedAudioPolicy:  pausedAudioPolicy,
+		iseMode:            iseMode,
+		slo:                slo,
+		logger:             logger,
+		sessions:           make(map[string]*VoiceSession),
+		pipelineGoroutines: make(map[string]int),
+		reportGen:          service.NewReportGenerator(),
+		reports:            make(map[string]*model.SessionReport),
+		historyExporter:    service.NewConversationHistoryExporter(),
+		historyImporter:    service.NewConversationHistoryImporter(),
+		histories:          make(map[string]storedHistory),
+		starters:           make(map[string]cachedStarter),
+	}
+}
+
+// Paused-audio policies.
+const (
+	pausePolicyBuffer = "buffer"
+	pausePolicyReject = "reject"
+)
+
+// ProcessVoiceConversation is the bidirectional conversation stream.
+func (h *SpeechHandler) ProcessVoiceConversation(stream speechv1.SpeechService_ProcessVoiceConversationServer) error {
+	var session *VoiceSession
+	defer func() {
+		if session != nil {
+			h.endSession(session, "stream_closed")
+		}
+	}()
+
+	for {
+		req, err := stream.Recv()
+		if err != nil {
+			return nil
+		}
+		created := false
+		if session == nil {
+			session, created = h.getOrCreateSession(req.GetSessionId(), req.GetUserId(), stream)
+		}
+		session.LastActivity = time.Now()
+
+		switch r := req.GetRequestType().(type) {
+		case *speechv1.VoiceRequest_AudioData:
+			h.handleAudioData(session, r.AudioData)
+		case *speechv1.VoiceRequest_Control:
+			h.handleControlMessage(session, r.Control)
+		case *speechv1.VoiceRequest_TextInput:
+			h.handleTextInput(session, r.TextInput)
+		default:
+			h.sendError(session, speechv1.ErrorCode_ERROR_CODE_INVALID_REQUEST, "empty request")
+		}
+
+		// The starter fires after the first message is dispatched so a
+		// connect-time configure_session (scenario, tutor_starts) is already
+		// applied when it generates.
+		if created {
+			h.maybeSendStarter(session)
+		}
+	}
+}
+
+// getOrCreateSession looks up or registers the session for this stream and
+// announces a new session to the client and the event stream. The second
+// return value reports whether the session was created by this call.
+func (h *SpeechHandler) getOrCreateSession(sessionID, userID string, stream speechv1.SpeechService_ProcessVoiceConversationServer) (*VoiceSession, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if existing, ok := h.sessions[sessionID]; ok {
+		existing.stream = stream
+		// A reconnect may come through a different gateway connection with
+		// fresh client metadata; keep the old map when the new stream has none.
+		if md := SessionMetadataFromContext(stream.Context()); md != nil {
+			existing.Metadata = md
+		}
+		return existing, false
+	}
+	session := &VoiceSession{
+		ID:                  sessionID,
+		UserID:              userID,
+		Language:            "en-US",
+		CreatedAt:           time.Now(),
+		LastActivity:        time.Now(),
+		Metadata:            SessionMetadataFromContext(stream.Context()),
+		ConversationHistory: &model.ConversationHistory{},
+		stream:              stream,
+	}
+	h.sessions[sessionID] = session
+	logging.SessionLogger(sessionID, h.logger).WithField(logging.LogFieldUserID, userID).Info("Voice session started")
+
+	session.send(&speechv1.VoiceResponse{
+		ResponseType: &speechv1.VoiceResponse_Status{
+			Status: &speechv1.StatusResult{Status: "session_started"},
+		},
+	})
+	h.publisher.Publish(events.New(events.TypeSessionStarted, session.ID, session.UserID, 0,
+		events.SessionStarted{Language: session.Language}))
+	return session, true
+}
+
+// endSession removes the session and publishes the session_ended event.
+func (h *SpeechHandler) endSession(session *VoiceSession, reason string) {
+	h.mu.Lock()
+	_, ok := h.sessions[session.ID]
+	delete(h.sessions, session.ID)
+	h.mu.Unlock()
+	if !ok {
+		return
+	}
+	if h.memory != nil {
+		h.memory.ReleaseSession(session.ID)
+	}
+	h.retireSessionHistory(session)
+	logging.SessionLogger(session.ID, h.logger).WithFields(logrus.Fields{
+		"reason":     reason,
+		"turn_count": session.TurnCount,
+	}).Info("Voice session ended")
+	h.publisher.Publish(events.New(events.TypeSessionEnded, session.ID, session.UserID, 0,
+		events.SessionEnded{
+			DurationMs: time.Since(session.CreatedAt).Milliseconds(),
+			PausedMs:   session.pausedDuration().Milliseconds(),
+			TurnCount:  session.TurnCount,
+		}))
+}
+
+// handleAudioData buffers an audio chunk and kicks off processing on the
+// final chunk of the utterance.
+func (h *SpeechHandler) handleAudioData(session *VoiceSession, chunk *speechv1.AudioChunk) {
+	if session.isPaused() && h.pausedAudioPolicy == pausePolicyReject {
+		h.sendError(session, speechv1.ErrorCode_ERROR_CODE_INVALID_REQUEST, "session is paused")
+		return
+	}
+	if h.maxAudioBytes > 0 && len(session.AudioBuffer)+len(chunk.GetData()) > h.maxAudioBytes {
+		h.releaseSessionMemory(session, int64(len(session.AudioBuffer)))
+		session.AudioBuffer = nil
+		h.sendError(session, speechv1.ErrorCode_ERROR_CODE_INVALID_REQUEST,
+			fmt.Sprintf("audio exceeds the %d MB per-utterance limit", h.maxAudioBytes/(1024*1024)))
+		return
+	}
+	if h.memory != nil {
+		if err := h.memory.Charge(session.ID, int64(len(chunk.GetData()))); err != nil {
+			h.sendError(session, speechv1.ErrorCode_ERROR_CODE_INVALID_REQUEST, err.Error())
+			return
+		}
+	}
+	session.AudioBuffer = append(session.AudioBuffer, chunk.GetData()...)
+	if chunk.GetFormat() != "" {
+		session.AudioFormat = chunk.GetFormat()
+	}
+	if !chunk.GetIsFinal() {
+		return
+	}
+	if session.isPaused() {
+		// Buffered while paused; the utterance is processed on resume.
+		session.pauseMu.Lock()
+		session.pendingFinal = true
+		session.pauseMu.Unlock()
+		return
+	}
+	h.processCompleteAudio(session)
+}
+
+// handleControlMessage dispatches client control actions.
+func (h *SpeechHandler) handleControlMessage(session *VoiceSession, ctrl *speechv1.ControlMessage) {
+	switch ctrl.GetAction() {
+	case speechv1.ControlAction_CONTROL_ACTION_START_RECORDING:
+		session.IsRecording = true
+		h.releaseSessionMemory(session, int64(len(session.AudioBuffer)))
+		session.AudioBuffer = nil
+		h.sendStatus(session, "recording_started", "")
+	case speechv1.ControlAction_CONTROL_ACTION_STOP_RECORDING:
+		session.IsRecording = false
+		if len(session.AudioBuffer) > 0 {
+			h.processCompleteAudio(session)
+		}
+	case speechv1.ControlAction_CONTROL_ACTION_PAUSE_SESSION:
+		if !session.beginPause() {
+			h.sendError(session, speechv1.ErrorCode_ERROR_CODE_INVALID_REQUEST, "session is already paused")
+			return
+		}
+		h.sendStatus(session, "session_paused", "")
+	case speechv1.ControlAction_CONTROL_ACTION_RESUME_SESSION:
+		held, pendingFinal, ok := session.endPause()
+		if !ok {
+			h.sendError(session, speechv1.ErrorCode_ERROR_CODE_INVALID_REQUEST, "session is not paused")
+			return
+		}
+		h.sendStatus(session, "session_resumed", "")
+		for _, resp := range held {
+			session.send(resp)
+		}
+		if pendingFinal && len(session.AudioBuffer) > 0 {
+			h.processCompleteAudio(session)
+		}
+	case speechv1.ControlAction_CONTROL_ACTION_END_SESSION:
+		h.storeSessionReport(h.buildSessionReport(session))
+		h.sendStatus(session, "session_report_ready", "/sessions/"+session.ID+"/report")
+		h.sendStatus(session, "session_ended", "")
+		h.endSession(session, "client_request")
+	case speechv1.ControlAction_CONTROL_ACTION_SET_REFERENCE_TEXT:
+		session.ReferenceText = ctrl.GetParameters()["text"]
+		h.sendStatus(session, "reference_text_set", session.ReferenceText)
+	case speechv1.ControlAction_CONTROL_ACTION_CONFIGURE_SESSION:
+		h.configureSession(session, ctrl.GetParameters())
+	case speechv1.ControlAction_CONTROL_ACTION_START_SHADOWING:
+		h.startShadowing(session, ctrl.GetParameters())
+	case speechv1.ControlAction_CONTROL_ACTION_RETRY_TURN:
+		h.handleRetryTurn(session, ctrl.GetParameters())
+	case speechv1.ControlAction_CONTROL_ACTION_REQUEST_CORRECTION:
+		h.handleRequestCorrection(session, ctrl.GetParameters())
+	default:
+		h.sendError(session, speechv1.ErrorCode_ERROR_CODE_INVALID_REQUEST, "unsupported control action")
+	}
+}
+
+// configureSession applies connect-time options forwarded by the gateway.
+// It runs before the first utterance, so the whole session sees the selected
+// language, voice, difficulty and ISE setting.
+func (h *SpeechHandler) configureSession(session *VoiceSession, params map[string]string) {
+	if lang := params["language"]; lang != "" {
+		session.Language = lang
+	}
+	if voice := params["voice"]; voice != "" {
+		session.Voice = voice
+	}
+	if params["ise"] == "off" {
+		session.ISEDisabled = true
+	}
+	if params["llm"] == "off" {
+		session.LLMDisabled = true
+	}
+	if params["tts"] == "off" {
+		session.TTSDisabled = true
+	}
+	switch params["tts_format"] {
+	case "opus", "ogg":
+		session.TTSFormat = ttsFormatOgg
+	}
+	if params["deterministic"] == "true" && h.deterministicAllowed {
+		session.Deterministic = true
+	}
+	if scenario := params["scenario"]; scenario != "" {
+		session.Scenario = scenario
+	}
+	if mode, ok := service.ParsePracticeMode(params["practice_mode"]); ok {
+		session.PracticeMode = mode
+	}
+	if topic := params["practice_topic"]; topic != "" {
+		session.PracticeTopic = topic
+	}
+	if params["tutor_starts"] == "false" {
+		session.TutorStartsDisabled = true
+	}
+	if params["split_utterances"] == "true" {
+		session.SplitUtterances = true
+	}
+	if cefr := difficultyToCEFR(params["difficulty"]); cefr != "" {
+		session.CEFREstimate = cefr
+	}
+	if prev := params["resume"]; prev != "" {
+		h.resumeHistory(session, prev)
+	}
+	session.applyClarificationOverrides(params)
+	h.sendStatus(session, "session_configured", "")
+}
+
+// difficultyToCEFR maps the client-facing difficulty names onto the CEFR
+// levels the LLM params are keyed by. Unknown values map to empty, leaving
+// the session's estimate untouched.
+func difficultyToCEFR(difficulty string) string {
+	switch difficulty {
+	case "beginner":
+		return "A2"
+	case "intermediate":
+		return "B1"
+	case "advanced":
+		return "C1"
+	}
+	return ""
+}
+
+// handleTextInput runs a typed user turn through the conversational pipeline.
+// There is no audio, so ASR (and ISE) are skipped and the turn goes straight
+// to the LLM and TTS stages, emitting the same result sequence as a spoken
+// turn.
+func (h *SpeechHandler) handleTextInput(session *VoiceSession, input *speechv1.TextInput) {
+	text := input.GetText()
+	if text == "" {
+		h.sendError(session, speechv1.ErrorCode_ERROR_CODE_INVALID_REQUEST, "empty text input")
+		return
+	}
+
+	if !h.llmEnabled() || session.LLMDisabled {
+		h.sendError(session, speechv1.ErrorCode_ERROR_CODE_INVALID_REQUEST, "llm stage is disabled")
+		return
+	}
+
+	// The turn lands in the conversation history, so it counts against the
+	// session's memory budget.
+	if h.memory != nil {
+		if err := h.memory.Charge(session.ID, int64(len(text))); err != nil {
+			h.sendError(session, speechv1.ErrorCode_ERROR_CODE_INVALID_REQUEST, err.Error())
+			return
+		}
+	}
+
+	session.TurnCount++
+	turnID := session.TurnCount
+	stats := &turnStats{transcript: text}
+
+	h.sendStatus(session, "processing", "")
+	h.safeGo(session, "llm", func() { h.processTextWithLLM(session, turnID, text, stats) })
+}
+
+// processCompleteAudio takes ownership of the buffered utterance on the
+// dispatch loop and hands it to processUtterance, through the worker pool
+// when one is configured. Session state that must not race with later
+// messages on the stream — shadowing consumption and turn numbering — is
+// settled here before the handoff.
+func (h *SpeechHandler) processCompleteAudio(session *VoiceSession) {
+	audioData := session.AudioBuffer
+	h.releaseSessionMemory(session, int64(len(audioData)))
+	session.AudioBuffer = nil
+	format := session.AudioFormat
+	if format == "" {
+		format = "webm"
+	}
+
+	// A spoken turn starts at ASR; with the stage disabled (a text-only
+	// deployment) there is nothing to do with audio.
+	if !h.asrEnabled() {
+		h.sendStatus(session, "complete", "asr stage disabled")
+		return
+	}
+
+	// A pending shadowing exercise claims this utterance: its target becomes
+	// the ISE reference and the transcript is diffed against it instead of
+	// feeding the LLM. The state is consumed here on the dispatch loop, so a
+	// follow-up utterance is back to normal conversation immediately.
+	shadowTarget := session.ShadowingTarget
+	prevReference := session.shadowingPrevReference
+	if shadowTarget != "" {
+		session.ShadowingTarget = ""
+		session.ReferenceText = prevReference
+		session.shadowingPrevReference = ""
+	}
+
+	session.TurnCount++
+	turnID := session.TurnCount
+
+	if h.pool == nil {
+		h.sendStatus(session, "processing", "")
+		h.processUtterance(session, turnID, audioData, format, shadowTarget)
+		return
+	}
+	enqueued := h.pool.TryEnqueue(ProcessingJob{
+		sessionID: session.ID,
+		audioData: audioData,
+		jobType:   JobTypeUtterance,
+		run: func() {
+			h.safeRun(session, "audio", func() {
+				h.processUtterance(session, turnID, audioData, format, shadowTarget)
+			})
+		},
+	})
+	if !enqueued {
+		// Roll the turn state back so the rejected utterance leaves no
+		// trace, then tell the client to back off; blocking until a worker
+		// frees up would stall every message on the stream.
+		session.TurnCount--
+		if shadowTarget != "" {
+			session.ShadowingTarget = shadowTarget
+			session.ReferenceText = shadowTarget
+			session.shadowingPrevReference = prevReference
+		}
+		h.sendError(session, speechv1.ErrorCode_ERROR_CODE_SERVICE_OVERLOADED,
+			"audio workers are saturated; try again shortly")
+		return
+	}
+	h.sendStatus(session, "processing", "")
+}
+
+// processUtterance converts the utterance and fans out to the recognition
+// stages. It runs inline on the dispatch loop, or on a pooled worker when a
+// worker pool is configured.
+func (h *SpeechHandler) processUtterance(session *VoiceSession, turnID int, audioData []byte, format, shadowTarget string) {
+	stats := &turnStats{}
+
+	h.recorder.RecordRawAudio(session.ID, turnID, audioData, format)
+	// Convert once to canonical PCM: ASR gets it WAV-framed, while ISE and
+	// the retained retry copy use the raw samples the provider's audio/L16
+	// payload is declared to be.
+	pcmData, _, err := h.audioService.OptimizeAudio(audioData, format)
+	if err != nil {
+		logging.SessionLogger(session.ID, h.logger).WithError(err).Error("Audio conversion failed")
+		h.sendError(session, speechv1.ErrorCode_ERROR_CODE_INVALID_REQUEST, "audio conversion failed")
+		return
+	}
+	wavData := h.audioService.Converter().ConvertPCMToWAV(pcmData)
+	h.recorder.RecordAudio(session.ID, turnID, wavData)
+
+	// Pre-flight quality check on the converted PCM: pure silence means a
+	// muted microphone, so skip every provider stage rather than burn ASR
+	// and ISE calls discovering it; heavy clipping still transcribes but
+	// the ASR result carries a warning the UI can surface.
+	quality, silent, clipped := h.audioService.AnalyzeQuality(pcmData)
+	stats.mu.Lock()
+	stats.quality = quality
+	if clipped {
+		stats.qualityWarning = "audio is clipping; try lowering the microphone gain"
+	}
+	stats.mu.Unlock()
+	if silent {
+		logging.SessionLogger(session.ID, h.logger).WithFields(logrus.Fields{
+			"rms":  quality.RMS,
+			"peak": quality.Peak,
+		}).Info("Rejecting silent utterance before provider calls")
+		h.sendStatus(session, "no_speech_detected", "recording contained no audible speech")
+		return
+	}
+
+	// Retain the converted audio so a transiently failed stage can be
+	// re-run via the retry control action; free-speak turns leave the
+	// reference empty and retry ISE against their own transcript.
+	retainRef := shadowTarget
+	if retainRef == "" && h.iseMode != ISEModeFreeSpeak && session.ReferenceText != "" {
+		retainRef = h.extractReferenceText(session)
+	}
+	session.retainTurn(turnID, pcmData, retainRef, h.retryBudgetBytes)
+
+	// A split_utterances session needs the recognizer's utterance boundaries
+	// before it can evaluate anything, so the parallel ISE launch below is
+	// deferred to the ASR path; streaming recognition feeds audio
+	// incrementally and keeps the unsplit flow.
+	splitTurn := session.SplitUtterances && shadowTarget == "" && !h.audioService.StreamingEnabled()
+
+	// In free-speak mode ISE scores against the ASR transcript, so the ISE
+	// goroutine waits on a channel ASR fills; in referenced mode the two
+	// stages stay fully concurrent.
+	var transcript chan string
+	switch {
+	case splitTurn:
+		// Evaluation runs per sentence (or once, deferred) after ASR returns.
+	case !h.iseEnabled():
+		// Evaluation is off for the deployment; the conversational stages
+		// below still run.
+	case shadowTarget != "" && !session.ISEDisabled:
+		h.safeGo(session, "ise", func() { h.processAudioWithISE(session, turnID, pcmData, shadowTarget, stats, nil) })
+	case h.iseMode == ISEModeFreeSpeak && !session.ISEDisabled:
+		transcript = make(chan string, 1)
+		ch := transcript
+		h.safeGo(session, "ise", func() { h.processAudioWithISE(session, turnID, pcmData, "", stats, ch) })
+	case session.ReferenceText != "" && !session.ISEDisabled:
+		reference := h.extractReferenceText(session)
+		h.safeGo(session, "ise", func() { h.processAudioWithISE(session, turnID, pcmData, reference, stats, nil) })
+	}
+	// On a pooled worker the ASR → LLM → TTS chain runs on the worker's own
+	// stack, so the pool genuinely bounds concurrent provider calls; ISE
+	// still runs alongside in its goroutine.
+	runASR := func(fn func()) { h.safeGo(session, "asr", fn) }
+	if h.pool != nil {
+		runASR = func(fn func()) { fn() }
+	}
+	// Streaming mode feeds ASR 40 ms PCM chunks as the blob decodes instead
+	// of the buffered WAV, so recognition starts before conversion finishes;
+	// ISE and the recorder still use the buffered conversion above.
+	if h.audioService.StreamingEnabled() {
+		runASR(func() {
+			h.processAudioStreamWithASR(session, turnID, audioData, format, stats, transcript, shadowTarget)
+		})
+		return
+	}
+	durationMs := h.audioService.Converter().GetAudioDuration(pcmData).Milliseconds()
+	runASR(func() {
+		h.processAudioWithASR(session, turnID, wavData, pcmData, durationMs, stats, transcript, shadowTarget)
+	})
+}
+
+// processAudioWithASR transcribes the utterance and continues the pipeline
+// with the LLM and TTS stages. pcmData is the canonical PCM conversion of the
+// utterance, kept alongside the WAV framing so a split turn can slice segments
+// for evaluation. transcript, when non-nil, receives the final transcript (and
+// is closed) so a free-speak ISE goroutine can use it as the reference text;
+// it is closed without a value when ASR fails. A non-empty shadowTarget
+// diverts the turn into the shadowing diff instead of the LLM.
+func (h *SpeechHandler) processAudioWithASR(session *VoiceSession, turnID int, wavData, pcmData []byte, durationMs int64, stats *turnStats, transcript chan<- string, shadowTarget string) {
+	release, err := h.acquireLane(session, "asr", h.laneFor(durationMs))
+	if err != nil {
+		if transcript != nil {
+			close(transcript)
+		}
+		if !errors.Is(err, context.Canceled) {
+			h.sendError(session, speechv1.ErrorCode_ERROR_CODE_SERVICE_OVERLOADED,
+				"speech recognition capacity is saturated; try again shortly")
+		}
+		return
+	}
+	start := time.Now()
+	resp, err := h.asrService.ProcessAudio(&model.ASRRequest{
+		AudioData:  wavData,
+		Format:     "wav",
+		SampleRate: 16000,
+		Language:   session.Language,
+		RequestID:  deterministicRequestID(session, turnID),
+	})
+	release()
+	if err != nil {
+		if transcript != nil {
+			close(transcript)
+		}
+		logging.SessionLogger(session.ID, h.logger).WithError(err).WithField(logging.LogFieldService, "asr").Error("ASR failed")
+		h.sendError(session, speechv1.ErrorCode_ERROR_CODE_ASR_FAILED, "speech recognition failed")
+		return
+	}
+	if transcript != nil {
+		transcript <- resp.Text
+		close(transcript)
+	}
+	stats.mu.Lock()
+	stats.transcript = resp.Text
+	stats.asrConfidence = resp.Confidence
+	stats.audioDurationMs = durationMs
+	stats.timings.ASRMs = time.Since(start).Milliseconds()
+	stats.mu.Unlock()
+	session.noteTranscript(turnID, resp.Text)
+	session.noteFinalTranscript(resp.Text)
+
+	// A split_utterances session breaks a multi-sentence recording at the
+	// recognizer's utterance boundaries and handles each sentence separately;
+	// a single-sentence recording falls through to the normal flow, picking
+	// up the evaluation deferred while the boundaries were unknown.
+	if session.SplitUtterances && shadowTarget == "" {
+		if len(resp.Utterances) > 1 {
+			h.processSplitTurn(session, turnID, resp, pcmData, stats)
+			return
+		}
+		h.evaluateUnsplitTurn(session, turnID, pcmData, resp.Text, stats)
+	}
+
+	h.sendASRResult(session, resp, durationMs, stats)
+	if shadowTarget != "" {
+		h.sendShadowingResult(session, shadowTarget, resp.Text)
+		return
+	}
+	if resp.Text == "" {
+		h.sendStatus(session, "complete", "no speech detected")
+		return
+	}
+	if reason := h.clarificationReason(session, resp); reason != "" {
+		h.requestClarification(session, turnID, reason, stats)
+		return
+	}
+	if !h.llmEnabled() || session.LLMDisabled {
+		// ASR-only session: the transcript is the product of the turn.
+		h.sendStatus(session, "complete", "llm stage disabled")
+		return
+	}
+	// The transcript joins the conversation history; force-charge it since
+	// the audio that produced it was already accepted.
+	if h.memory != nil {
+		h.memory.ForceCharge(session.ID, int64(len(resp.Text)))
+	}
+	h.processTextWithLLM(session, turnID, resp.Text, stats)
+}
+
+// sendASRResult delivers the transcript to the client. durationMs is the
+// measured play time of the utterance's PCM; the provider's utterance
+// timings, when present, narrow the start/end to the recognized speech.
+func (h *SpeechHandler) sendASRResult(session *VoiceSession, resp *model.ASRResponse, durationMs int64, stats *turnStats) {
+	startMs, endMs := int64(0), durationMs
+	if n := len(resp.Utterances); n > 0 {
+		startMs = resp.Utterances[0].StartTimeMs
+		if last := resp.Utterances[n-1].EndTimeMs; last > 0 {
+			endMs = last
+		}
+	}
+	stats.mu.Lock()
+	warning := stats.qualityWarning
+	stats.mu.Unlock()
+	session.send(&speechv1.VoiceResponse{
+		ResponseType: &speechv1.VoiceResponse_AsrResult{
+			AsrResult: &speechv1.ASRResult{
+				Text:                resp.Text,
+				Confidence:          resp.Confidence,
+				IsFinal:             true,
+				Language:            resp.Language,
+				StartTimeMs:         startMs,
+				EndTimeMs:           endMs,
+				AudioQualityWarning: warning,
+			},
+		},
+	})
+}
+
+// processTextWithLLM generates the tutor reply and hands it to TTS.
+func (h *SpeechHandler) processTextWithLLM(session *VoiceSession, turnID int, text string, stats *turnStats) {
+	release, err := h.acquireLane(session, "llm", LaneInteractive)
+	if err != nil {
+		if !errors.Is(err, context.Canceled) {
+			h.sendError(session, speechv1.ErrorCode_ERROR_CODE_SERVICE_OVERLOADED,
+				"response generation capacity is saturated; try again shortly")
+		}
+		return
+	}
+	start := time.Now()
+	resp, err := h.llmFor(session).GeneratePracticeResponse(text, session.effectiveCEFRLevel(),
+		session.PracticeMode, session.PracticeTopic, session.ConversationHistory)
+	release()
+	if err != nil {
+		logging.SessionLogger(session.ID, h.logger).WithError(err).WithField(logging.LogFieldService, "llm").Error("LLM failed")
+		h.sendError(session, speechv1.ErrorCode_ERROR_CODE_LLM_FAILED, "response generation failed")
+		return
+	}
+	// The reply is now part of the conversation history.
+	if h.memory != nil {
+		h.memory.ForceCharge(session.ID, int64(len(resp.Text)))
+	}
+	stats.mu.Lock()
+	stats.responseText = resp.Text
+	stats.tokensUsed = resp.TokensUsed
+	stats.timings.LLMMs = time.Since(start).Milliseconds()
+	stats.mu.Unlock()
+
+	session.send(&speechv1.VoiceResponse{
+		ResponseType: &speechv1.VoiceResponse_LlmResult{
+			LlmResult: &speechv1.LLMResult{
+				Text:       resp.Text,
+				Type:       speechv1.LLMResultType_LLM_RESULT_TYPE_RESPONSE,
+				TokensUsed: int32(resp.TokensUsed),
+			},
+		},
+	})
+	session.noteResponseText(turnID, resp.Text)
+	replyText := resp.Text
+	h.safeGo(session, "vocab", func() { h.collectTurnVocabulary(session, text, replyText) })
+	h.processTextWithTTS(session, turnID, resp.Text, stats)
+}
+
+// processTextWithTTS synthesizes the reply, completes the turn and publishes
+// the turn_completed event. With the TTS stage disabled the reply stays
+// text-only and the turn still completes normally.
+func (h *SpeechHandler) processTextWithTTS(session *VoiceSession, turnID int, text string, stats *turnStats) {
+	if h.ttsEnabled() && !session.TTSDisabled {
+		release, err := h.acquireLane(session, "tts", LaneInteractive)
+		if err != nil {
+			if !errors.Is(err, context.Canceled) {
+				h.sendError(session, speechv1.ErrorCode_ERROR_CODE_SERVICE_OVERLOADED,
+					"speech synthesis capacity is saturated; try again shortly")
+			}
+			return
+		}
+		start := time.Now()
+		resp, err := h.ttsService.SynthesizeSpeech(&model.TTSRequest{
+			Text:      text,
+			Voice:     session.Voice,
+			Language:  session.Language,
+			RequestID: deterministicRequestID(session, turnID),
+		})
+		release()
+		if err != nil {
+			logging.SessionLogger(session.ID, h.logger).WithError(err).WithField(logging.LogFieldService, "tts").Error("TTS failed")
+			h.sendError(session, speechv1.ErrorCode_ERROR_CODE_TTS_FAILED, "speech synthesis failed")
+			return
+		}
+		stats.mu.Lock()
+		stats.timings.TTSMs = time.Since(start).Milliseconds()
+		stats.timings.TTSFirstByteMs = resp.FirstByteMs
+		stats.mu.Unlock()
+		h.recorder.RecordTTS(session.ID, turnID, resp.AudioData)
+
+		h.deliverSynthesized(session, turnID, resp)
+	}
+	stats.mu.Lock()
+	payload := events.TurnCompleted{
+		Transcript:             stats.transcript,
+		ResponseText:           stats.responseText,
+		ASRConfidence:          stats.asrConfidence,
+		ISEScore:               stats.iseScore,
+		TokensUsed:             stats.tokensUsed,
+		ClarificationRequested: stats.clarification,
+		AudioDurationMs:        stats.audioDurationMs,
+		AudioRMS:               stats.quality.RMS,
+		AudioPeak:              stats.quality.Peak,
+		AudioClippingRatio:     stats.quality.ClippingRatio,
+		Timings:                stats.timings,
+	}
+	stats.mu.Unlock()
+
+	session.send(&speechv1.VoiceResponse{
+		ResponseType: &speechv1.VoiceResponse_Status{
+			Status: &speechv1.StatusResult{Status: "complete", Timings: timingsProto(payload.Timings)},
+		},
+	})
+	h.publisher.Publish(events.New(events.TypeTurnCompleted, session.ID, session.UserID, turnID, payload))
+
+	h.observeStageTimings(session, turnID, payload.Timings)
+	// A clarification turn says nothing about the learner's level, so it is
+	// not a difficulty signal.
+	if !payload.ClarificationRequested {
+		h.adaptDifficulty(session, payload.Transcript, payload.ASRConfidence, payload.ISEScore)
+	}
+}
+
+// deliverTTS sends a synthesized reply to the client, holding it back while
+// the session is paused; held replies are flushed in order on resume.
+func (h *SpeechHandler) deliverTTS(session *VoiceSession, resp *speechv1.VoiceResponse) {
+	session.pauseMu.Lock()
+	if session.Paused {
+		session.heldTTS = append(session.heldTTS, resp)
+		session.pauseMu.Unlock()
+		return
+	}
+	session.pauseMu.Unlock()
+	session.send(resp)
+}
+
+// processAudioWithISE evaluates pronunciation against referenceText in
+// parallel with the conversational pipeline. pcmData is the canonical PCM
+// conversion of the utterance; the provider payload is raw samples, never
+// WAV-framed. The caller resolves the reference synchronously so shadowing
+// turns keep their target even after the session's state moves on. In
+// free-speak mode transcript is non-nil and the ASR transcript of the
+// utterance becomes the reference, so the stage waits for ASR before
+// evaluating.
+func (h *SpeechHandler) processAudioWithISE(session *VoiceSession, turnID int, pcmData []byte, referenceText string, stats *turnStats, transcript <-chan string) {
+	if transcript != nil {
+		text, ok := <-transcript
+		if !ok || text == "" {
+			// ASR failed or heard nothing; there is no utterance to score and
+			// the ASR path already reported to the client.
+			logging.SessionLogger(session.ID, h.logger).WithField(logging.LogFieldService, "ise").
+				Info("Skipping free-speak evaluation without a transcript")
+			return
+		}
+		referenceText = text
+	}
+
+	// Long recordings take the batch lane so they queue behind each other
+	// instead of occupying slots a short conversational turn needs.
+	durationMs := h.audioService.Converter().GetAudioDuration(pcmData).Milliseconds()
+	lane := h.laneFor(durationMs)
+	release, err := h.acquireLane(session, "ise", lane)
+	if err != nil {
+		if !errors.Is(err, context.Canceled) {
+			h.sendError(session, speechv1.ErrorCode_ERROR_CODE_ISE_FAILED,
+				"pronunciation evaluation timed out waiting for capacity")
+		}
+		return
+	}
+	start := time.Now()
+	// Batch-lane work also takes batch priority with the ISE scheduler, so
+	// a long recording never jumps ahead of a waiting conversational turn.
+	evaluate := h.iseService.EvaluateSpeechNormalized
+	if lane == LaneBatch {
+		evaluate = h.iseService.EvaluateSpeechNormalizedBatch
+	}
+	// The stream context ends when the client disconnects, which aborts an
+	// in-flight evaluation instead of letting it run to completion unheard.
+	resp, err := evaluate(session.stream.Context(), &model.ISERequest{
+		AudioData: pcmData,
+		Text:      referenceText,
+		Language:  iseLanguage(session.Language),
+	})
+	release()
+	if err != nil {
+		logging.SessionLogger(session.ID, h.logger).WithError(err).WithField(logging.LogFieldService, "ise").Error("ISE failed")
+		h.sendError(session, speechv1.ErrorCode_ERROR_CODE_ISE_FAILED, "pronunciation evaluation failed")
+		return
+	}
+	stats.mu.Lock()
+	score := resp.OverallScore
+	stats.iseScore = &score
+	stats.timings.ISEMs = time.Since(start).Milliseconds()
+	stats.mu.Unlock()
+
+	session.iseMu.Lock()
+	session.iseResults = append(session.iseResults, resp)
+	session.iseMu.Unlock()
+
+	h.sendISEResult(session, resp)
+
+	if h.iseService.ProsodyComparisonEnabled() {
+		h.compareProsody(session, resp, pcmData)
+	}
+}
+
+// sendISEResult converts the evaluation to proto and delivers it.
+func (h *SpeechHandler) sendISEResult(session *VoiceSession, resp *model.ISEResponse) {
+	session.send(&speechv1.VoiceResponse{
+		ResponseType: &speechv1.VoiceResponse_IseResult{IseResult: h.iseResultProto(resp)},
+	})
+}
+
+// iseResultProto converts an evaluation to its wire message, including the
+// word and phone breakdowns.
+func (h *SpeechHandler) iseResultProto(resp *model.ISEResponse) *speechv1.ISEResult {
+	result := &speechv1.ISEResult{
+		OverallScore:    resp.OverallScore,
+		NormalizedScore: resp.NormalizedScore,
+		AccuracyScore:   resp.AccuracyScore,
+		FluencyScore:    resp.FluencyScore,
+		IntegrityScore:  resp.IntegrityScore,
+		ReferenceText:   resp.ReferenceText,
+		Mode:            h.iseMode.String(),
+	}
+	for _, w := range resp.Words {
+		word := &speechv1.WordScore{Word: w.Word, Score: w.Score, ErrorType: w.ErrorCode}
+		for _, p := range w.Phones {
+			word.Phones = append(word.Phones, &speechv1.PhoneScore{Phone: p.Phone, Score: p.Score, ErrorType: p.ErrorCode})
+		}
+		result.Words = append(result.Words, word)
+	}
+	return result
+}
+
+// extractReferenceText returns the text pronunciation is scored against.
+func (h *SpeechHandler) extractReferenceText(session *VoiceSession) string {
+	if session.ReferenceText != "" {
+		return session.ReferenceText
+	}
+	return "Hello, how are you today?"
+}
+
+// iseLanguage maps a BCP-47 session language to the provider language code.
+func iseLanguage(language string) string {
+	if len(language) >= 2 && language[:2] == "zh" {
+		return "zh_cn"
+	}
+	return "en_us"
+}
+
+func (h *SpeechHandler) sendStatus(session *VoiceSession, status, message string) {
+	session.send(&speechv1.VoiceResponse{
+		ResponseType: &speechv1.VoiceResponse_Status{
+			Status: &speechv1.StatusResult{Status: status, Message: message},
+		},
+	})
+}
+
+func (h *SpeechHandler) sendError(session *VoiceSession, code speechv1.ErrorCode, message string) {
+	session.send(&speechv1.VoiceResponse{
+		ResponseType: &speechv1.VoiceResponse_Error{
+			Error: &speechv1.ErrorResult{Code: code, Message: message},
+		},
+	})
+}
+
+// Transcribe implements the unary transcription RPC.
+func (h *SpeechHandler) Transcribe(ctx context.Context, req *speechv1.TranscribeRequest) (*speechv1.TranscribeResponse, error) {
+	wavData, err := h.audioService.OptimizeAudioForASR(req.AudioData, req.Format)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := h.asrService.ProcessAudio(&model.ASRRequest{
+		AudioData:  wavData,
+		Format:     "wav",
+		SampleRate: 16000,
+		Language:   req.Language,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &speechv1.TranscribeResponse{
+		Text:       resp.Text,
+		Confidence: resp.Confidence,
+		Language:   resp.Language,
+	}, nil
+}
+
+// Synthesize implements the unary synthesis RPC.
+func (h *SpeechHandler) Synthesize(ctx context.Context, req *speechv1.SynthesizeRequest) (*speechv1.SynthesizeResponse, error) {
+	resp, err := h.ttsService.SynthesizeSpeech(&model.TTSRequest{
+		Text:     req.Text,
+		Voice:    req.Voice,
+		Language: req.Language,
+		Speed:    req.Speed,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &speechv1.SynthesizeResponse{
+		AudioData:  resp.AudioData,
+		Format:     resp.Format,
+		DurationMs: resp.DurationMs,
+	}, nil
+}
+
+// EvaluatePronunciation implements the unary evaluation RPC.
+func (h *SpeechHandler) EvaluatePronunciation(ctx context.Context, req *speechv1.EvaluateRequest) (*speechv1.EvaluateResponse, error) {
+	resp, err := h.iseService.EvaluateSpeech(ctx, &model.ISERequest{
+		AudioData: req.AudioData,
+		Text:      req.ReferenceText,
+		Language:  req.Language,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result := &speechv1.ISEResult{
+		OverallScore:   resp.OverallScore,
+		AccuracyScore:  resp.AccuracyScore,
+		FluencyScore:   resp.FluencyScore,
+		IntegrityScore: resp.IntegrityScore,
+		ReferenceText:  resp.ReferenceText,
+	}
+	return &speechv1.EvaluateResponse{Result: result}, nil
+}
+
+// GenerateReply implements the stateless unary LLM RPC: the caller owns the
+// conversation history and passes it in full on every turn.
+func (h *SpeechHandler) GenerateReply(ctx context.Context, req *speechv1.GenerateReplyRequest) (*speechv1.GenerateReplyResponse, error) {
+	history := &model.ConversationHistory{}
+	for _, turn := range req.History {
+		history.Append(turn.Role, turn.Content)
+	}
+	resp, err := h.llmService.GenerateResponseWithHistory(req.Text, req.CefrLevel, history)
+	if err != nil {
+		return nil, err
+	}
+	return &speechv1.GenerateReplyResponse{
+		Text:       resp.Text,
+		TokensUsed: int32(resp.TokensUsed),
+	}, nil
+}
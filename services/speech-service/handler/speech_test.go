@@ -0,0 +1,321 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/config"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/events"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/service"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/store"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/testutil"
+	speechv1 "github.com/CurvatureX/ai-tutor-monorepo/shared/proto/speech"
+)
+
+// fakeConversationStream feeds client requests from a channel and records
+// every response the handler sends. Only the methods the handler touches are
+// implemented; the embedded grpc.ServerStream stays nil.
+type fakeConversationStream struct {
+	grpc.ServerStream
+
+	reqCh chan *speechv1.VoiceRequest
+
+	// ctx overrides the stream context; nil means context.Background().
+	ctx context.Context
+
+	mu   sync.Mutex
+	sent []*speechv1.VoiceResponse
+}
+
+// newFakeStream pre-loads the given requests and closes the channel, so the
+// handler sees them in order followed by end-of-stream.
+func newFakeStream(reqs ...*speechv1.VoiceRequest) *fakeConversationStream {
+	f := &fakeConversationStream{reqCh: make(chan *speechv1.VoiceRequest, len(reqs))}
+	for _, req := range reqs {
+		f.reqCh <- req
+	}
+	close(f.reqCh)
+	return f
+}
+
+func (f *fakeConversationStream) Recv() (*speechv1.VoiceRequest, error) {
+	req, ok := <-f.reqCh
+	if !ok {
+		return nil, io.EOF
+	}
+	return req, nil
+}
+
+func (f *fakeConversationStream) Send(resp *speechv1.VoiceResponse) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sent = append(f.sent, resp)
+	return nil
+}
+
+func (f *fakeConversationStream) Context() context.Context {
+	if f.ctx != nil {
+		return f.ctx
+	}
+	return context.Background()
+}
+
+func (f *fakeConversationStream) responses() []*speechv1.VoiceResponse {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*speechv1.VoiceResponse(nil), f.sent...)
+}
+
+// newTestSpeechHandler builds a SpeechHandler against mock LLM and TTS
+// endpoints; the remaining services are wired but unused by text-only turns.
+func newTestSpeechHandler(t *testing.T, llmURL, ttsURL string) *SpeechHandler {
+	t.Helper()
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	publisher, err := events.NewPublisher(config.EventsConfig{Publisher: "none"}, logger)
+	if err != nil {
+		t.Fatalf("NewPublisher: %v", err)
+	}
+	return NewSpeechHandler(
+		service.NewASRService(config.ASRConfig{}, logger),
+		service.NewTTSService(config.TTSConfig{BaseURL: ttsURL, VoiceType: "test_voice", Speed: 1.0}, logger),
+		service.NewLLMService(config.LLMConfig{BaseURL: llmURL, Model: "test-model", MaxTokens: 50}, nil, logger),
+		service.NewISEService(config.ISEConfig{}, logger),
+		service.NewAudioService(config.AudioConfig{}, logger),
+		publisher, store.NewSessionRecorder(false, nil, logger), 0, 0, pausePolicyBuffer,
+		ISEModeReferenced, config.SLOConfig{}, logger)
+}
+
+func TestTextInputProducesReplyAndAudio(t *testing.T) {
+	llm := testutil.NewMockLLMServer(testutil.MockLLMConfig{ReplyText: "Great question! The past tense of go is went.", TokensUsed: 12})
+	defer llm.Close()
+	tts := testutil.NewMockTTSServer(testutil.MockTTSConfig{AudioChunks: [][]byte{[]byte("synthesized-reply-audio")}})
+	defer tts.Close()
+
+	h := newTestSpeechHandler(t, llm.URL(), tts.URL())
+	stream := newFakeStream(&speechv1.VoiceRequest{
+		SessionId: "text-turn-session",
+		UserId:    "learner-1",
+		RequestType: &speechv1.VoiceRequest_TextInput{
+			TextInput: &speechv1.TextInput{Text: "How do I use the past tense?"},
+		},
+	})
+	if err := h.ProcessVoiceConversation(stream); err != nil {
+		t.Fatalf("ProcessVoiceConversation: %v", err)
+	}
+
+	// The LLM and TTS stages complete on a pipeline goroutine after the
+	// stream's receive loop returns, so poll for the results.
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		var llmText string
+		var audio []byte
+		for _, resp := range stream.responses() {
+			switch r := resp.GetResponseType().(type) {
+			case *speechv1.VoiceResponse_LlmResult:
+				llmText = r.LlmResult.Text
+			case *speechv1.VoiceResponse_TtsResult:
+				audio = r.TtsResult.AudioData
+			case *speechv1.VoiceResponse_Error:
+				t.Fatalf("pipeline error: %s", r.Error.Message)
+			}
+		}
+		if llmText != "" && len(audio) > 0 {
+			if llmText != "Great question! The past tense of go is went." {
+				t.Errorf("llm text = %q, want the mock reply", llmText)
+			}
+			if !bytes.Equal(audio, []byte("synthesized-reply-audio")) {
+				t.Errorf("tts audio = %q, want the mock chunk", audio)
+			}
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("timed out waiting for LLM and TTS results from a text turn")
+}
+
+// controlReq builds a control request for the session.
+func controlReq(sessionID string, action speechv1.ControlAction) *speechv1.VoiceRequest {
+	return &speechv1.VoiceRequest{
+		SessionId: sessionID,
+		RequestType: &speechv1.VoiceRequest_Control{
+			Control: &speechv1.ControlMessage{Action: action},
+		},
+	}
+}
+
+// waitForResponse polls the stream until a response matches or the deadline
+// passes.
+func waitForResponse(t *testing.T, stream *fakeConversationStream, desc string, match func(*speechv1.VoiceResponse) bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		for _, resp := range stream.responses() {
+			if match(resp) {
+				return
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %s", desc)
+}
+
+func TestPauseHoldsTTSUntilResume(t *testing.T) {
+	llm := testutil.NewMockLLMServer(testutil.MockLLMConfig{ReplyText: "Reply while paused."})
+	defer llm.Close()
+	tts := testutil.NewMockTTSServer(testutil.MockTTSConfig{AudioChunks: [][]byte{[]byte("held-audio")}})
+	defer tts.Close()
+
+	h := newTestSpeechHandler(t, llm.URL(), tts.URL())
+	stream := &fakeConversationStream{reqCh: make(chan *speechv1.VoiceRequest, 8)}
+	done := make(chan struct{})
+	go func() {
+		h.ProcessVoiceConversation(stream)
+		close(done)
+	}()
+
+	const sessionID = "pause-session"
+	stream.reqCh <- controlReq(sessionID, speechv1.ControlAction_CONTROL_ACTION_PAUSE_SESSION)
+	stream.reqCh <- &speechv1.VoiceRequest{
+		SessionId:   sessionID,
+		RequestType: &speechv1.VoiceRequest_TextInput{TextInput: &speechv1.TextInput{Text: "Still there?"}},
+	}
+
+	// The turn finishes while paused: "complete" arrives but the TTS result
+	// must be held back.
+	waitForResponse(t, stream, `status "complete"`, func(resp *speechv1.VoiceResponse) bool {
+		r, ok := resp.GetResponseType().(*speechv1.VoiceResponse_Status)
+		return ok && r.Status.Status == "complete"
+	})
+	for _, resp := range stream.responses() {
+		if _, ok := resp.GetResponseType().(*speechv1.VoiceResponse_TtsResult); ok {
+			t.Fatal("TTS result delivered while the session was paused")
+		}
+	}
+
+	stream.reqCh <- controlReq(sessionID, speechv1.ControlAction_CONTROL_ACTION_RESUME_SESSION)
+	waitForResponse(t, stream, "held TTS result after resume", func(resp *speechv1.VoiceResponse) bool {
+		r, ok := resp.GetResponseType().(*speechv1.VoiceResponse_TtsResult)
+		return ok && bytes.Equal(r.TtsResult.AudioData, []byte("held-audio"))
+	})
+
+	close(stream.reqCh)
+	<-done
+}
+
+func TestResumeWithoutPauseRejected(t *testing.T) {
+	h := newTestSpeechHandler(t, "http://unused.invalid", "ws://unused.invalid")
+	stream := newFakeStream(controlReq("no-pause-session", speechv1.ControlAction_CONTROL_ACTION_RESUME_SESSION))
+	if err := h.ProcessVoiceConversation(stream); err != nil {
+		t.Fatalf("ProcessVoiceConversation: %v", err)
+	}
+	for _, resp := range stream.responses() {
+		if r, ok := resp.GetResponseType().(*speechv1.VoiceResponse_Error); ok {
+			if r.Error.Code != speechv1.ErrorCode_ERROR_CODE_INVALID_REQUEST {
+				t.Errorf("error code = %v, want INVALID_REQUEST", r.Error.Code)
+			}
+			return
+		}
+	}
+	t.Fatal("no error response for resume without pause")
+}
+
+func TestPausedAudioRejectedUnderRejectPolicy(t *testing.T) {
+	h := newTestSpeechHandler(t, "http://unused.invalid", "ws://unused.invalid")
+	h.pausedAudioPolicy = pausePolicyReject
+	stream := newFakeStream(
+		controlReq("reject-session", speechv1.ControlAction_CONTROL_ACTION_PAUSE_SESSION),
+		&speechv1.VoiceRequest{
+			SessionId: "reject-session",
+			RequestType: &speechv1.VoiceRequest_AudioData{
+				AudioData: &speechv1.AudioChunk{Data: []byte{1, 2, 3}, IsFinal: true},
+			},
+		},
+	)
+	if err := h.ProcessVoiceConversation(stream); err != nil {
+		t.Fatalf("ProcessVoiceConversation: %v", err)
+	}
+	for _, resp := range stream.responses() {
+		if r, ok := resp.GetResponseType().(*speechv1.VoiceResponse_Error); ok {
+			if r.Error.Message != "session is paused" {
+				t.Errorf("error message = %q, want %q", r.Error.Message, "session is paused")
+			}
+			return
+		}
+	}
+	t.Fatal("no error response for audio sent while paused")
+}
+
+func TestConfigureSessionAppliesConnectOptions(t *testing.T) {
+	h := newTestSpeechHandler(t, "http://unused.invalid", "ws://unused.invalid")
+	stream := &fakeConversationStream{reqCh: make(chan *speechv1.VoiceRequest, 1)}
+	done := make(chan struct{})
+	go func() {
+		h.ProcessVoiceConversation(stream)
+		close(done)
+	}()
+
+	const sessionID = "configure-session"
+	stream.reqCh <- &speechv1.VoiceRequest{
+		SessionId: sessionID,
+		RequestType: &speechv1.VoiceRequest_Control{
+			Control: &speechv1.ControlMessage{
+				Action: speechv1.ControlAction_CONTROL_ACTION_CONFIGURE_SESSION,
+				Parameters: map[string]string{
+					"language":   "en-GB",
+					"voice":      "en_uk_003",
+					"ise":        "off",
+					"difficulty": "advanced",
+				},
+			},
+		},
+	}
+	waitForResponse(t, stream, `status "session_configured"`, func(resp *speechv1.VoiceResponse) bool {
+		r, ok := resp.GetResponseType().(*speechv1.VoiceResponse_Status)
+		return ok && r.Status.Status == "session_configured"
+	})
+
+	h.mu.RLock()
+	session := h.sessions[sessionID]
+	h.mu.RUnlock()
+	if session.Language != "en-GB" || session.Voice != "en_uk_003" {
+		t.Errorf("language/voice = %q/%q, want the configured values", session.Language, session.Voice)
+	}
+	if !session.ISEDisabled {
+		t.Error("ISE not disabled by ise=off")
+	}
+	if session.CEFREstimate != "C1" {
+		t.Errorf("CEFR estimate = %q, want C1 for advanced", session.CEFREstimate)
+	}
+
+	close(stream.reqCh)
+	<-done
+}
+
+func TestTextInputEmptyRejected(t *testing.T) {
+	h := newTestSpeechHandler(t, "http://unused.invalid", "ws://unused.invalid")
+	stream := newFakeStream(&speechv1.VoiceRequest{
+		SessionId:   "empty-text-session",
+		RequestType: &speechv1.VoiceRequest_TextInput{TextInput: &speechv1.TextInput{}},
+	})
+	if err := h.ProcessVoiceConversation(stream); err != nil {
+		t.Fatalf("ProcessVoiceConversation: %v", err)
+	}
+	for _, resp := range stream.responses() {
+		if r, ok := resp.GetResponseType().(*speechv1.VoiceResponse_Error); ok {
+			if r.Error.Code != speechv1.ErrorCode_ERROR_CODE_INVALID_REQUEST {
+				t.Errorf("error code = %v, want INVALID_REQUEST", r.Error.Code)
+			}
+			return
+		}
+	}
+	t.Fatal("no error response for empty text input")
+}
@@ -0,0 +1,271 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/audio"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/logging"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+	speechv1 "github.com/CurvatureX/ai-tutor-monorepo/shared/proto/speech"
+)
+
+// processSplitTurn handles a split_utterances recording that parsed into
+// several sentences — a learner reading a paragraph. Each sentence gets its
+// own ASR, ISE and LLM results at the recognizer's utterance boundaries,
+// numbered by a segment index; scoring sentences individually also keeps the
+// provider off the coarse read_chapter aggregate a whole paragraph triggers.
+// The turn closes with a combined evaluation summary and one synthesized
+// reply joined from the per-sentence replies, so playback isn't a stack of
+// overlapping clips. Runs on the ASR goroutine (or pooled worker) like the
+// rest of the post-recognition pipeline.
+func (h *SpeechHandler) processSplitTurn(session *VoiceSession, turnID int, resp *model.ASRResponse, pcmData []byte, stats *turnStats) {
+	count := int32(len(resp.Utterances))
+	logger := logging.SessionLogger(session.ID, h.logger)
+	logger.WithField("segments", count).Info("Splitting multi-sentence turn at utterance boundaries")
+
+	stats.mu.Lock()
+	warning := stats.qualityWarning
+	stats.mu.Unlock()
+
+	iseOn := h.iseEnabled() && !session.ISEDisabled
+	llmOn := h.llmEnabled() && !session.LLMDisabled
+	if llmOn && h.memory != nil {
+		h.memory.ForceCharge(session.ID, int64(len(resp.Text)))
+	}
+
+	var replies []string
+	var tokensUsed int
+	var iseMs, llmMs int64
+	var aggregate iseAggregate
+	for i, utt := range resp.Utterances {
+		index := int32(i + 1)
+		result := &speechv1.ASRResult{
+			Text:         utt.Text,
+			Confidence:   resp.Confidence,
+			IsFinal:      true,
+			Language:     resp.Language,
+			StartTimeMs:  utt.StartTimeMs,
+			EndTimeMs:    utt.EndTimeMs,
+			SegmentIndex: index,
+			SegmentCount: count,
+		}
+		if i == 0 {
+			// The quality warning describes the whole recording; it rides on
+			// the first segment only.
+			result.AudioQualityWarning = warning
+		}
+		session.send(&speechv1.VoiceResponse{
+			ResponseType: &speechv1.VoiceResponse_AsrResult{AsrResult: result},
+		})
+
+		if iseOn {
+			iseMs += h.evaluateSegment(session, &aggregate, pcmData, utt, index, count)
+		}
+		if llmOn {
+			// Per-sentence replies are batch work: a learner reading a
+			// paragraph is not waiting turn-by-turn the way a conversation is.
+			release, err := h.acquireLane(session, "llm", LaneBatch)
+			if err != nil {
+				if !errors.Is(err, context.Canceled) {
+					h.sendError(session, speechv1.ErrorCode_ERROR_CODE_SERVICE_OVERLOADED,
+						"response generation capacity is saturated; try again shortly")
+				}
+				return
+			}
+			start := time.Now()
+			reply, err := h.llmFor(session).GeneratePracticeResponse(utt.Text, session.effectiveCEFRLevel(),
+				session.PracticeMode, session.PracticeTopic, session.ConversationHistory)
+			release()
+			llmMs += time.Since(start).Milliseconds()
+			if err != nil {
+				logger.WithError(err).WithField(logging.LogFieldService, "llm").Error("LLM failed")
+				h.sendError(session, speechv1.ErrorCode_ERROR_CODE_LLM_FAILED, "response generation failed")
+				return
+			}
+			if h.memory != nil {
+				h.memory.ForceCharge(session.ID, int64(len(reply.Text)))
+			}
+			session.send(&speechv1.VoiceResponse{
+				ResponseType: &speechv1.VoiceResponse_LlmResult{
+					LlmResult: &speechv1.LLMResult{
+						Text:         reply.Text,
+						Type:         speechv1.LLMResultType_LLM_RESULT_TYPE_RESPONSE,
+						TokensUsed:   int32(reply.TokensUsed),
+						SegmentIndex: index,
+						SegmentCount: count,
+					},
+				},
+			})
+			replies = append(replies, reply.Text)
+			tokensUsed += reply.TokensUsed
+		}
+	}
+
+	// The combined summary: one evaluation over the whole turn, averaged
+	// across the scored sentences weighted by their duration.
+	if summary := aggregate.summary(resp.Text, count); summary != nil {
+		summary.Mode = h.iseMode.String()
+		session.send(&speechv1.VoiceResponse{
+			ResponseType: &speechv1.VoiceResponse_IseResult{IseResult: summary},
+		})
+		stats.mu.Lock()
+		score := summary.OverallScore
+		stats.iseScore = &score
+		stats.mu.Unlock()
+	}
+
+	stats.mu.Lock()
+	stats.timings.ISEMs = iseMs
+	stats.timings.LLMMs = llmMs
+	stats.responseText = strings.Join(replies, " ")
+	stats.tokensUsed = tokensUsed
+	stats.mu.Unlock()
+
+	if !llmOn {
+		h.sendStatus(session, "complete", "llm stage disabled")
+		return
+	}
+	joined := strings.Join(replies, " ")
+	session.noteResponseText(turnID, joined)
+	h.safeGo(session, "vocab", func() { h.collectTurnVocabulary(session, resp.Text, joined) })
+	h.processTextWithTTS(session, turnID, joined, stats)
+}
+
+// evaluateSegment scores one sentence of a split turn against its slice of
+// the utterance's PCM and delivers the result. One weak or failed sentence
+// must not sink the rest of the paragraph, so errors are logged and the
+// segment is simply left out of the aggregate. Returns the elapsed
+// milliseconds for the turn's ISE timing total.
+func (h *SpeechHandler) evaluateSegment(session *VoiceSession, aggregate *iseAggregate, pcmData []byte, utt model.Utterance, index, count int32) int64 {
+	logger := logging.SessionLogger(session.ID, h.logger).WithFields(logrus.Fields{
+		logging.LogFieldService: "ise",
+		"segment":               index,
+	})
+	segment := segmentPCM(pcmData, utt.StartTimeMs, utt.EndTimeMs)
+	if len(segment) == 0 {
+		logger.Warn("Utterance carries no usable timings; skipping its evaluation")
+		return 0
+	}
+	// Split-turn evaluations are batch work by definition; an abandoned wait
+	// drops the segment like any other failed evaluation.
+	release, err := h.acquireLane(session, "ise", LaneBatch)
+	if err != nil {
+		return 0
+	}
+	start := time.Now()
+	resp, err := h.iseService.EvaluateSpeechNormalizedBatch(session.stream.Context(), &model.ISERequest{
+		AudioData: segment,
+		Text:      utt.Text,
+		Language:  iseLanguage(session.Language),
+		Category:  "read_sentence",
+	})
+	release()
+	elapsed := time.Since(start).Milliseconds()
+	if err != nil {
+		logger.WithError(err).Error("ISE failed")
+		return elapsed
+	}
+
+	session.iseMu.Lock()
+	session.iseResults = append(session.iseResults, resp)
+	session.iseMu.Unlock()
+
+	result := h.iseResultProto(resp)
+	result.SegmentIndex = index
+	result.SegmentCount = count
+	session.send(&speechv1.VoiceResponse{
+		ResponseType: &speechv1.VoiceResponse_IseResult{IseResult: result},
+	})
+
+	aggregate.add(resp, utt.EndTimeMs-utt.StartTimeMs)
+	return elapsed
+}
+
+// iseAggregate accumulates duration-weighted score sums across the scored
+// sentences of a split turn.
+type iseAggregate struct {
+	overall    float64
+	normalized float64
+	accuracy   float64
+	fluency    float64
+	integrity  float64
+	weight     float64
+}
+
+func (a *iseAggregate) add(resp *model.ISEResponse, durationMs int64) {
+	w := float64(durationMs)
+	a.overall += resp.OverallScore * w
+	a.normalized += resp.NormalizedScore * w
+	a.accuracy += resp.AccuracyScore * w
+	a.fluency += resp.FluencyScore * w
+	a.integrity += resp.IntegrityScore * w
+	a.weight += w
+}
+
+// summary builds the turn's combined evaluation, marked as the aggregate by
+// segment index 0 with the count set. Nil when no sentence was scored.
+func (a *iseAggregate) summary(referenceText string, count int32) *speechv1.ISEResult {
+	if a.weight == 0 {
+		return nil
+	}
+	return &speechv1.ISEResult{
+		OverallScore:    a.overall / a.weight,
+		NormalizedScore: a.normalized / a.weight,
+		AccuracyScore:   a.accuracy / a.weight,
+		FluencyScore:    a.fluency / a.weight,
+		IntegrityScore:  a.integrity / a.weight,
+		ReferenceText:   referenceText,
+		SegmentCount:    count,
+	}
+}
+
+// evaluateUnsplitTurn launches the pronunciation evaluation a split-capable
+// turn deferred while waiting for utterance boundaries, once the recording
+// turned out to hold a single sentence. The reference resolution mirrors the
+// parallel launch in processUtterance, except the free-speak case uses the
+// transcript directly instead of waiting on a channel.
+func (h *SpeechHandler) evaluateUnsplitTurn(session *VoiceSession, turnID int, pcmData []byte, transcript string, stats *turnStats) {
+	if !h.iseEnabled() || session.ISEDisabled {
+		return
+	}
+	var reference string
+	switch {
+	case h.iseMode == ISEModeFreeSpeak:
+		reference = transcript
+	case session.ReferenceText != "":
+		reference = h.extractReferenceText(session)
+	}
+	if reference == "" {
+		return
+	}
+	h.safeGo(session, "ise", func() { h.processAudioWithISE(session, turnID, pcmData, reference, stats, nil) })
+}
+
+// segmentPCM slices the utterance's PCM to the [startMs, endMs) window of one
+// recognized segment, aligned to whole 16-bit samples and clamped to the
+// buffer. A segment without usable timings yields nil.
+func segmentPCM(pcm []byte, startMs, endMs int64) []byte {
+	if endMs <= startMs {
+		return nil
+	}
+	bytesPerMs := int64(audio.DefaultSampleRate * audio.BytesPerSample / 1000)
+	start := startMs * bytesPerMs
+	if start >= int64(len(pcm)) {
+		return nil
+	}
+	end := endMs * bytesPerMs
+	if end > int64(len(pcm)) {
+		end = int64(len(pcm))
+	}
+	start -= start % int64(audio.BytesPerSample)
+	end -= end % int64(audio.BytesPerSample)
+	if end <= start {
+		return nil
+	}
+	return pcm[start:end]
+}
@@ -0,0 +1,225 @@
+package handler
+
+import (
+	"io"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/config"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/events"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/service"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/store"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/testutil"
+	speechv1 "github.com/CurvatureX/ai-tutor-monorepo/shared/proto/speech"
+)
+
+// newSplitHandler builds a SpeechHandler pointed at mock ASR, ISE, LLM and
+// TTS servers, for turns split at utterance boundaries.
+func newSplitHandler(t *testing.T, asrURL, iseURL, llmURL, ttsURL string) *SpeechHandler {
+	t.Helper()
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	publisher, err := events.NewPublisher(config.EventsConfig{Publisher: "none"}, logger)
+	if err != nil {
+		t.Fatalf("NewPublisher: %v", err)
+	}
+	return NewSpeechHandler(
+		service.NewASRService(config.ASRConfig{BaseURL: asrURL, Cluster: "test"}, logger),
+		service.NewTTSService(config.TTSConfig{BaseURL: ttsURL, VoiceType: "test_voice", Speed: 1.0}, logger),
+		service.NewLLMService(config.LLMConfig{BaseURL: llmURL, Model: "test-model", MaxTokens: 50}, nil, logger),
+		service.NewISEService(config.ISEConfig{AppID: "test-app", APIKey: "test-key", APISecret: "test-secret", BaseURL: iseURL}, logger),
+		service.NewAudioService(config.AudioConfig{}, logger),
+		publisher, store.NewSessionRecorder(false, nil, logger), 0, 0, pausePolicyBuffer,
+		ISEModeReferenced, config.SLOConfig{}, logger)
+}
+
+// splitSessionRequests enables split_utterances and submits one recording.
+func splitSessionRequests(sessionID string, audio []byte) []*speechv1.VoiceRequest {
+	return []*speechv1.VoiceRequest{
+		{
+			SessionId: sessionID,
+			UserId:    "learner-1",
+			RequestType: &speechv1.VoiceRequest_Control{
+				Control: &speechv1.ControlMessage{
+					Action:     speechv1.ControlAction_CONTROL_ACTION_CONFIGURE_SESSION,
+					Parameters: map[string]string{"split_utterances": "true", "tutor_starts": "false"},
+				},
+			},
+		},
+		{
+			SessionId: sessionID,
+			RequestType: &speechv1.VoiceRequest_AudioData{
+				AudioData: &speechv1.AudioChunk{Data: audio, Format: "pcm", IsFinal: true},
+			},
+		},
+	}
+}
+
+func TestSplitUtterancesEmitsPerSentenceResults(t *testing.T) {
+	asr := testutil.NewMockASRServer(testutil.MockASRConfig{
+		Transcript: "It rained. We stayed inside.",
+		Confidence: 0.93,
+		Utterances: []testutil.ASRUtterance{
+			{Text: "It rained.", StartMs: 0, EndMs: 1000},
+			{Text: "We stayed inside.", StartMs: 1000, EndMs: 2000},
+		},
+	})
+	defer asr.Close()
+	ise := testutil.NewMockISEServer(testutil.MockISEConfig{})
+	defer ise.Close()
+	llm := testutil.NewMockLLMServer(testutil.MockLLMConfig{ReplyText: "Nice sentence!", TokensUsed: 4})
+	defer llm.Close()
+	tts := testutil.NewMockTTSServer(testutil.MockTTSConfig{AudioChunks: [][]byte{[]byte("audio")}})
+	defer tts.Close()
+
+	h := newSplitHandler(t, asr.URL(), ise.URL(), llm.URL(), tts.URL())
+	// Two seconds of PCM, matching the scripted utterance timings.
+	stream := newFakeStream(splitSessionRequests("split-session", loudPCM(64000))...)
+	if err := h.ProcessVoiceConversation(stream); err != nil {
+		t.Fatalf("ProcessVoiceConversation: %v", err)
+	}
+
+	waitForResponse(t, stream, "complete status", func(resp *speechv1.VoiceResponse) bool {
+		s := resp.GetStatus()
+		return s != nil && s.Status == "complete"
+	})
+
+	var asrResults []*speechv1.ASRResult
+	var iseResults []*speechv1.ISEResult
+	var llmResults []*speechv1.LLMResult
+	ttsResults := 0
+	for _, resp := range stream.responses() {
+		switch r := resp.GetResponseType().(type) {
+		case *speechv1.VoiceResponse_AsrResult:
+			asrResults = append(asrResults, r.AsrResult)
+		case *speechv1.VoiceResponse_IseResult:
+			iseResults = append(iseResults, r.IseResult)
+		case *speechv1.VoiceResponse_LlmResult:
+			llmResults = append(llmResults, r.LlmResult)
+		case *speechv1.VoiceResponse_TtsResult:
+			ttsResults++
+		case *speechv1.VoiceResponse_Error:
+			t.Fatalf("pipeline error: %s", r.Error.Message)
+		}
+	}
+
+	if len(asrResults) != 2 {
+		t.Fatalf("got %d asr_results, want one per sentence: %+v", len(asrResults), asrResults)
+	}
+	wantSentences := []struct {
+		text           string
+		startMs, endMs int64
+	}{
+		{"It rained.", 0, 1000},
+		{"We stayed inside.", 1000, 2000},
+	}
+	for i, want := range wantSentences {
+		got := asrResults[i]
+		if got.Text != want.text || got.StartTimeMs != want.startMs || got.EndTimeMs != want.endMs {
+			t.Errorf("segment %d = %q [%d,%d], want %q [%d,%d]",
+				i+1, got.Text, got.StartTimeMs, got.EndTimeMs, want.text, want.startMs, want.endMs)
+		}
+		if got.SegmentIndex != int32(i+1) || got.SegmentCount != 2 {
+			t.Errorf("segment %d numbering = %d/%d, want %d/2", i+1, got.SegmentIndex, got.SegmentCount, i+1)
+		}
+	}
+
+	if len(iseResults) != 3 {
+		t.Fatalf("got %d ise_results, want two segments plus the summary: %+v", len(iseResults), iseResults)
+	}
+	for i := 0; i < 2; i++ {
+		if iseResults[i].SegmentIndex != int32(i+1) || iseResults[i].SegmentCount != 2 {
+			t.Errorf("ise segment numbering = %d/%d, want %d/2",
+				iseResults[i].SegmentIndex, iseResults[i].SegmentCount, i+1)
+		}
+		if iseResults[i].OverallScore != 85.5 {
+			t.Errorf("ise segment %d score = %v, want the mock 85.5", i+1, iseResults[i].OverallScore)
+		}
+	}
+	summary := iseResults[2]
+	if summary.SegmentIndex != 0 || summary.SegmentCount != 2 {
+		t.Errorf("summary numbering = %d/%d, want 0/2", summary.SegmentIndex, summary.SegmentCount)
+	}
+	if summary.OverallScore != 85.5 {
+		t.Errorf("summary score = %v, want the duration-weighted 85.5", summary.OverallScore)
+	}
+	if summary.ReferenceText != "It rained. We stayed inside." {
+		t.Errorf("summary reference = %q, want the full transcript", summary.ReferenceText)
+	}
+
+	if len(llmResults) != 2 {
+		t.Fatalf("got %d llm_results, want one per sentence: %+v", len(llmResults), llmResults)
+	}
+	for i, result := range llmResults {
+		if result.SegmentIndex != int32(i+1) || result.SegmentCount != 2 {
+			t.Errorf("llm segment numbering = %d/%d, want %d/2", result.SegmentIndex, result.SegmentCount, i+1)
+		}
+	}
+	// The per-sentence replies are joined into a single synthesized response.
+	if ttsResults != 1 {
+		t.Errorf("got %d tts_results, want one combined reply", ttsResults)
+	}
+}
+
+func TestSplitFlagSingleSentenceKeepsUnsplitFlow(t *testing.T) {
+	asr := testutil.NewMockASRServer(testutil.MockASRConfig{
+		Transcript: "It rained.",
+		Confidence: 0.93,
+		Utterances: []testutil.ASRUtterance{{Text: "It rained.", StartMs: 0, EndMs: 1000}},
+	})
+	defer asr.Close()
+	ise := testutil.NewMockISEServer(testutil.MockISEConfig{})
+	defer ise.Close()
+	llm := testutil.NewMockLLMServer(testutil.MockLLMConfig{ReplyText: "Did you get wet?"})
+	defer llm.Close()
+	tts := testutil.NewMockTTSServer(testutil.MockTTSConfig{AudioChunks: [][]byte{[]byte("audio")}})
+	defer tts.Close()
+
+	h := newSplitHandler(t, asr.URL(), ise.URL(), llm.URL(), tts.URL())
+	stream := newFakeStream(splitSessionRequests("single-sentence-session", loudPCM(32000))...)
+	if err := h.ProcessVoiceConversation(stream); err != nil {
+		t.Fatalf("ProcessVoiceConversation: %v", err)
+	}
+
+	waitForResponse(t, stream, "complete status", func(resp *speechv1.VoiceResponse) bool {
+		s := resp.GetStatus()
+		return s != nil && s.Status == "complete"
+	})
+	for _, resp := range stream.responses() {
+		if r := resp.GetAsrResult(); r != nil && (r.SegmentIndex != 0 || r.SegmentCount != 0) {
+			t.Errorf("single-sentence asr_result numbered %d/%d, want unsplit zeros", r.SegmentIndex, r.SegmentCount)
+		}
+		if r := resp.GetLlmResult(); r != nil && r.SegmentCount != 0 {
+			t.Errorf("single-sentence llm_result numbered %d/%d, want unsplit zeros", r.SegmentIndex, r.SegmentCount)
+		}
+	}
+}
+
+func TestSegmentPCM(t *testing.T) {
+	pcm := loudPCM(64000) // two seconds at 16 kHz mono 16-bit
+	cases := []struct {
+		name             string
+		startMs, endMs   int64
+		wantLen, wantOff int
+	}{
+		{"first second", 0, 1000, 32000, 0},
+		{"second second", 1000, 2000, 32000, 32000},
+		{"end clamped to buffer", 1000, 5000, 32000, 32000},
+		{"no timings", 0, 0, 0, 0},
+		{"inverted window", 1000, 500, 0, 0},
+		{"start past buffer", 3000, 4000, 0, 0},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := segmentPCM(pcm, tc.startMs, tc.endMs)
+			if len(got) != tc.wantLen {
+				t.Fatalf("segment length = %d, want %d", len(got), tc.wantLen)
+			}
+			if tc.wantLen > 0 && &got[0] != &pcm[tc.wantOff] {
+				t.Errorf("segment does not start at byte %d of the buffer", tc.wantOff)
+			}
+		})
+	}
+}
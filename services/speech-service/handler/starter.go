@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/logging"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+	speechv1 "github.com/CurvatureX/ai-tutor-monorepo/shared/proto/speech"
+)
+
+// starterTTL is how long a generated conversation starter is reused for new
+// sessions on the same scenario.
+const starterTTL = 5 * time.Minute
+
+// cachedStarter is one generated starter with its expiry.
+type cachedStarter struct {
+	text    string
+	expires time.Time
+}
+
+// maybeSendStarter kicks off the spoken conversation starter for a freshly
+// created session, unless the tutor_starts=false option disabled it.
+func (h *SpeechHandler) maybeSendStarter(session *VoiceSession) {
+	if session.TutorStartsDisabled || !h.llmEnabled() {
+		return
+	}
+	h.safeGo(session, "starter", func() { h.sendConversationStarter(session) })
+}
+
+// sendConversationStarter generates (or reuses) an opening line, delivers it
+// as a starter-typed LLMResult and synthesizes it so the tutor speaks first.
+// Failures are logged but not surfaced: a session without a starter is still
+// fully usable.
+func (h *SpeechHandler) sendConversationStarter(session *VoiceSession) {
+	text, err := h.starterText(session, session.Scenario)
+	if err != nil {
+		logging.SessionLogger(session.ID, h.logger).WithError(err).WithField(logging.LogFieldService, "llm").Warn("Conversation starter failed")
+		return
+	}
+	session.ConversationHistory.Append("assistant", text)
+
+	session.send(&speechv1.VoiceResponse{
+		ResponseType: &speechv1.VoiceResponse_LlmResult{
+			LlmResult: &speechv1.LLMResult{
+				Text: text,
+				Type: speechv1.LLMResultType_LLM_RESULT_TYPE_STARTER,
+			},
+		},
+	})
+
+	resp, err := h.ttsService.SynthesizeSpeech(&model.TTSRequest{
+		Text:     text,
+		Voice:    session.Voice,
+		Language: session.Language,
+	})
+	if err != nil {
+		logging.SessionLogger(session.ID, h.logger).WithError(err).WithField(logging.LogFieldService, "tts").Warn("Starter synthesis failed")
+		return
+	}
+	h.deliverSynthesized(session, 0, resp)
+}
+
+// starterText returns the cached starter for the scenario, generating a new
+// one when none is fresh. The LLM call happens inside the lock so concurrent
+// connections on the same scenario share one generation rather than racing.
+// Deterministic sessions bypass the cache both ways: they always generate at
+// temperature 0 and never publish the result for regular sessions to reuse.
+func (h *SpeechHandler) starterText(session *VoiceSession, scenario string) (string, error) {
+	if session.Deterministic {
+		resp, err := h.llmFor(session).GenerateConversationStarter(scenario)
+		if err != nil {
+			return "", err
+		}
+		return resp.Text, nil
+	}
+	h.starterMu.Lock()
+	defer h.starterMu.Unlock()
+	if cached, ok := h.starters[scenario]; ok && time.Now().Before(cached.expires) {
+		return cached.text, nil
+	}
+	resp, err := h.llmService.GenerateConversationStarter(scenario)
+	if err != nil {
+		return "", err
+	}
+	h.starters[scenario] = cachedStarter{text: resp.Text, expires: time.Now().Add(starterTTL)}
+	return resp.Text, nil
+}
@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/testutil"
+	speechv1 "github.com/CurvatureX/ai-tutor-monorepo/shared/proto/speech"
+)
+
+// configureReq builds a configure_session control carrying the given
+// parameters.
+func configureReq(sessionID string, params map[string]string) *speechv1.VoiceRequest {
+	return &speechv1.VoiceRequest{
+		SessionId: sessionID,
+		RequestType: &speechv1.VoiceRequest_Control{
+			Control: &speechv1.ControlMessage{
+				Action:     speechv1.ControlAction_CONTROL_ACTION_CONFIGURE_SESSION,
+				Parameters: params,
+			},
+		},
+	}
+}
+
+func TestNewSessionGetsSpokenStarter(t *testing.T) {
+	llm := testutil.NewMockLLMServer(testutil.MockLLMConfig{ReplyText: "Shall we talk about your last trip?"})
+	defer llm.Close()
+	tts := testutil.NewMockTTSServer(testutil.MockTTSConfig{AudioChunks: [][]byte{[]byte("starter-audio")}})
+	defer tts.Close()
+
+	h := newTestSpeechHandler(t, llm.URL(), tts.URL())
+	stream := newFakeStream(configureReq("starter-session", map[string]string{"scenario": "travel"}))
+	if err := h.ProcessVoiceConversation(stream); err != nil {
+		t.Fatalf("ProcessVoiceConversation: %v", err)
+	}
+
+	waitForResponse(t, stream, "starter-typed LLM result", func(resp *speechv1.VoiceResponse) bool {
+		r, ok := resp.GetResponseType().(*speechv1.VoiceResponse_LlmResult)
+		return ok && r.LlmResult.Type == speechv1.LLMResultType_LLM_RESULT_TYPE_STARTER &&
+			r.LlmResult.Text == "Shall we talk about your last trip?"
+	})
+	waitForResponse(t, stream, "synthesized starter audio", func(resp *speechv1.VoiceResponse) bool {
+		r, ok := resp.GetResponseType().(*speechv1.VoiceResponse_TtsResult)
+		return ok && bytes.Equal(r.TtsResult.AudioData, []byte("starter-audio")) && r.TtsResult.IsFinal
+	})
+
+	// The LLM result must precede its audio.
+	llmIdx, ttsIdx := -1, -1
+	for i, resp := range stream.responses() {
+		switch resp.GetResponseType().(type) {
+		case *speechv1.VoiceResponse_LlmResult:
+			if llmIdx == -1 {
+				llmIdx = i
+			}
+		case *speechv1.VoiceResponse_TtsResult:
+			if ttsIdx == -1 {
+				ttsIdx = i
+			}
+		}
+	}
+	if llmIdx == -1 || ttsIdx == -1 || llmIdx > ttsIdx {
+		t.Errorf("starter order: llm at %d, tts at %d, want llm first", llmIdx, ttsIdx)
+	}
+
+	reqs := llm.Requests()
+	if len(reqs) != 1 {
+		t.Fatalf("llm requests = %d, want 1", len(reqs))
+	}
+	if !strings.Contains(string(reqs[0]), "travel") {
+		t.Errorf("starter prompt does not mention the scenario: %s", reqs[0])
+	}
+}
+
+func TestStarterReusedAcrossSessionsOnSameScenario(t *testing.T) {
+	llm := testutil.NewMockLLMServer(testutil.MockLLMConfig{ReplyText: "How was the food?"})
+	defer llm.Close()
+	tts := testutil.NewMockTTSServer(testutil.MockTTSConfig{AudioChunks: [][]byte{[]byte("audio")}})
+	defer tts.Close()
+
+	h := newTestSpeechHandler(t, llm.URL(), tts.URL())
+	for _, sessionID := range []string{"cache-session-1", "cache-session-2"} {
+		stream := newFakeStream(configureReq(sessionID, map[string]string{"scenario": "restaurant"}))
+		if err := h.ProcessVoiceConversation(stream); err != nil {
+			t.Fatalf("ProcessVoiceConversation(%s): %v", sessionID, err)
+		}
+		waitForResponse(t, stream, "starter-typed LLM result", func(resp *speechv1.VoiceResponse) bool {
+			r, ok := resp.GetResponseType().(*speechv1.VoiceResponse_LlmResult)
+			return ok && r.LlmResult.Type == speechv1.LLMResultType_LLM_RESULT_TYPE_STARTER
+		})
+	}
+
+	if got := len(llm.Requests()); got != 1 {
+		t.Errorf("llm requests = %d, want 1 generation shared by both sessions", got)
+	}
+}
+
+func TestTutorStartsFalseSkipsStarter(t *testing.T) {
+	llm := testutil.NewMockLLMServer(testutil.MockLLMConfig{ReplyText: "Should never be generated."})
+	defer llm.Close()
+	tts := testutil.NewMockTTSServer(testutil.MockTTSConfig{AudioChunks: [][]byte{[]byte("audio")}})
+	defer tts.Close()
+
+	h := newTestSpeechHandler(t, llm.URL(), tts.URL())
+	stream := newFakeStream(configureReq("no-starter-session", map[string]string{"tutor_starts": "false"}))
+	if err := h.ProcessVoiceConversation(stream); err != nil {
+		t.Fatalf("ProcessVoiceConversation: %v", err)
+	}
+	waitForResponse(t, stream, `status "session_configured"`, func(resp *speechv1.VoiceResponse) bool {
+		r, ok := resp.GetResponseType().(*speechv1.VoiceResponse_Status)
+		return ok && r.Status.Status == "session_configured"
+	})
+
+	// Give a wrongly spawned starter goroutine time to surface before
+	// asserting nothing arrived.
+	time.Sleep(100 * time.Millisecond)
+	for _, resp := range stream.responses() {
+		if _, ok := resp.GetResponseType().(*speechv1.VoiceResponse_LlmResult); ok {
+			t.Fatal("starter sent despite tutor_starts=false")
+		}
+	}
+	if got := len(llm.Requests()); got != 0 {
+		t.Errorf("llm requests = %d, want none", got)
+	}
+}
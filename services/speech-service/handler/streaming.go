@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"time"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/audio"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/logging"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+	speechv1 "github.com/CurvatureX/ai-tutor-monorepo/shared/proto/speech"
+)
+
+// streamedASRTimeout bounds one streamed recognition, decode included.
+const streamedASRTimeout = 30 * time.Second
+
+// processAudioStreamWithASR is the streaming counterpart of
+// processAudioWithASR: the utterance is decoded to 40 ms PCM chunks that are
+// forwarded to the provider as they are produced, so recognition starts
+// before the conversion finishes. The transcript channel and shadowTarget
+// contracts match processAudioWithASR.
+func (h *SpeechHandler) processAudioStreamWithASR(session *VoiceSession, turnID int, audioData []byte, format string, stats *turnStats, transcript chan<- string, shadowTarget string) {
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(context.Background(), streamedASRTimeout)
+	defer cancel()
+
+	chunks, streamErrs := h.audioService.Streamer().Stream(ctx, bytes.NewReader(audioData), audio.AudioFormat(format))
+
+	// Count the PCM actually delivered so the result's end time reflects the
+	// decoded audio, not the container size.
+	counted := make(chan []byte)
+	var pcmBytes int64
+	go func() {
+		defer close(counted)
+		for chunk := range chunks {
+			pcmBytes += int64(len(chunk))
+			counted <- chunk
+		}
+	}()
+
+	resp, err := h.asrService.ProcessAudioStream(&model.ASRRequest{
+		Format:     "raw",
+		SampleRate: 16000,
+		Language:   session.Language,
+	}, counted)
+	// A decode failure truncates the chunk stream, so it is the root cause
+	// even when recognition of the partial audio nominally succeeded.
+	select {
+	case streamErr := <-streamErrs:
+		err = streamErr
+	default:
+	}
+	if err != nil {
+		if transcript != nil {
+			close(transcript)
+		}
+		logging.SessionLogger(session.ID, h.logger).WithError(err).WithField(logging.LogFieldService, "asr").Error("Streamed ASR failed")
+		h.sendError(session, speechv1.ErrorCode_ERROR_CODE_ASR_FAILED, "speech recognition failed")
+		return
+	}
+	if transcript != nil {
+		transcript <- resp.Text
+		close(transcript)
+	}
+	// 16 kHz 16-bit mono PCM plays at 32 bytes per millisecond.
+	durationMs := pcmBytes / 32
+	stats.mu.Lock()
+	stats.transcript = resp.Text
+	stats.asrConfidence = resp.Confidence
+	stats.audioDurationMs = durationMs
+	stats.timings.ASRMs = time.Since(start).Milliseconds()
+	stats.mu.Unlock()
+	session.noteTranscript(turnID, resp.Text)
+	session.noteFinalTranscript(resp.Text)
+
+	h.sendASRResult(session, resp, durationMs, stats)
+	if shadowTarget != "" {
+		h.sendShadowingResult(session, shadowTarget, resp.Text)
+		return
+	}
+	if resp.Text == "" {
+		h.sendStatus(session, "complete", "no speech detected")
+		return
+	}
+	if reason := h.clarificationReason(session, resp); reason != "" {
+		h.requestClarification(session, turnID, reason, stats)
+		return
+	}
+	if !h.llmEnabled() || session.LLMDisabled {
+		h.sendStatus(session, "complete", "llm stage disabled")
+		return
+	}
+	h.processTextWithLLM(session, turnID, resp.Text, stats)
+}
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"io"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/config"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/events"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/service"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/store"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/testutil"
+	speechv1 "github.com/CurvatureX/ai-tutor-monorepo/shared/proto/speech"
+)
+
+// newStreamingHandler builds a SpeechHandler with AUDIO_STREAMING_MODE on.
+func newStreamingHandler(t *testing.T, asrURL, llmURL, ttsURL string) *SpeechHandler {
+	t.Helper()
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	publisher, err := events.NewPublisher(config.EventsConfig{Publisher: "none"}, logger)
+	if err != nil {
+		t.Fatalf("NewPublisher: %v", err)
+	}
+	return NewSpeechHandler(
+		service.NewASRService(config.ASRConfig{BaseURL: asrURL, Cluster: "test"}, logger),
+		service.NewTTSService(config.TTSConfig{BaseURL: ttsURL, VoiceType: "test_voice", Speed: 1.0}, logger),
+		service.NewLLMService(config.LLMConfig{BaseURL: llmURL, Model: "test-model", MaxTokens: 50}, nil, logger),
+		service.NewISEService(config.ISEConfig{}, logger),
+		service.NewAudioService(config.AudioConfig{StreamingMode: true}, logger),
+		publisher, store.NewSessionRecorder(false, nil, logger), 0, 0, pausePolicyBuffer,
+		ISEModeReferenced, config.SLOConfig{}, logger)
+}
+
+func TestStreamingModeTranscribesChunkedPCM(t *testing.T) {
+	asr := testutil.NewMockASRServer(testutil.MockASRConfig{Transcript: "streamed hello", Confidence: 0.91})
+	defer asr.Close()
+	llm := testutil.NewMockLLMServer(testutil.MockLLMConfig{ReplyText: "Hello back!", TokensUsed: 5})
+	defer llm.Close()
+	tts := testutil.NewMockTTSServer(testutil.MockTTSConfig{AudioChunks: [][]byte{[]byte("audio")}})
+	defer tts.Close()
+
+	h := newStreamingHandler(t, asr.URL(), llm.URL(), tts.URL())
+	stream := newFakeStream(freeSpeakAudioRequest("streaming-session"))
+	if err := h.ProcessVoiceConversation(stream); err != nil {
+		t.Fatalf("ProcessVoiceConversation: %v", err)
+	}
+
+	var result *speechv1.ASRResult
+	waitForResponse(t, stream, "asr result", func(resp *speechv1.VoiceResponse) bool {
+		if r, ok := resp.GetResponseType().(*speechv1.VoiceResponse_AsrResult); ok {
+			result = r.AsrResult
+			return true
+		}
+		return false
+	})
+	if result.Text != "streamed hello" {
+		t.Errorf("Text = %q, want the mock transcript", result.Text)
+	}
+	// 16000 bytes of PCM in 1280-byte chunks is 13 audio frames.
+	if got := asr.AudioFrames(); got != 13 {
+		t.Errorf("ASR server received %d audio frames, want 13", got)
+	}
+	waitForResponse(t, stream, `status "complete"`, func(resp *speechv1.VoiceResponse) bool {
+		r, ok := resp.GetResponseType().(*speechv1.VoiceResponse_Status)
+		return ok && r.Status.Status == "complete"
+	})
+}
+
+func TestStreamingModeHandlesWAVInput(t *testing.T) {
+	asr := testutil.NewMockASRServer(testutil.MockASRConfig{Transcript: "unused"})
+	defer asr.Close()
+	llm := testutil.NewMockLLMServer(testutil.MockLLMConfig{ReplyText: "unused"})
+	defer llm.Close()
+	tts := testutil.NewMockTTSServer(testutil.MockTTSConfig{AudioChunks: [][]byte{[]byte("audio")}})
+	defer tts.Close()
+
+	h := newStreamingHandler(t, asr.URL(), llm.URL(), tts.URL())
+	stream := newFakeStream(&speechv1.VoiceRequest{
+		SessionId: "streaming-bad-format",
+		UserId:    "learner-1",
+		RequestType: &speechv1.VoiceRequest_AudioData{
+			AudioData: &speechv1.AudioChunk{Data: loudPCM(4000), Format: "wav", IsFinal: true},
+		},
+	})
+	if err := h.ProcessVoiceConversation(stream); err != nil {
+		t.Fatalf("ProcessVoiceConversation: %v", err)
+	}
+
+	// WAV works on the streamed path too: the header is skipped instead of
+	// decoded, so this must transcribe rather than error.
+	waitForResponse(t, stream, "asr result", func(resp *speechv1.VoiceResponse) bool {
+		_, ok := resp.GetResponseType().(*speechv1.VoiceResponse_AsrResult)
+		return ok
+	})
+}
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"strings"
+	"time"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/config"
+)
+
+// LLMStreamThrottle paces the tutor's reply out to the client word by word so
+// a typewriter animation moves at human typing speed instead of the whole
+// reply appearing at once.
+type LLMStreamThrottle struct {
+	// WordsPerSecond is the release rate; 0 or less disables pacing.
+	WordsPerSecond float64
+	// BurstThresholdChars skips pacing for replies shorter than this many
+	// characters, which would otherwise feel artificially slow.
+	BurstThresholdChars int
+}
+
+// NewLLMStreamThrottle builds the throttle from the LLM config. It returns
+// nil when ANIMATE_SPEED_WPM is 0, which callers treat as pacing disabled.
+func NewLLMStreamThrottle(cfg config.LLMConfig) *LLMStreamThrottle {
+	if cfg.AnimateSpeedWPM <= 0 {
+		return nil
+	}
+	return &LLMStreamThrottle{
+		WordsPerSecond:      float64(cfg.AnimateSpeedWPM) / 60,
+		BurstThresholdChars: cfg.BurstThresholdChars,
+	}
+}
+
+// Applies reports whether a reply is long enough to pace. Replies under the
+// burst threshold go out in the single llm_response message as before.
+func (t *LLMStreamThrottle) Applies(text string) bool {
+	return t != nil && t.WordsPerSecond > 0 &&
+		len(text) >= t.BurstThresholdChars && len(strings.Fields(text)) > 1
+}
+
+// Stream releases the reply's words on a timer, calling emit with the
+// cumulative text after each release so a client that drops a frame still
+// renders correctly. The first chunk goes out without waiting, and the
+// complete reply is withheld: the caller sends it immediately after Stream
+// returns, so the final chunk is never delayed by a tick.
+func (t *LLMStreamThrottle) Stream(text string, emit func(partial string)) {
+	words := strings.Fields(text)
+	interval := time.Duration(float64(time.Second) / t.WordsPerSecond)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for i := 1; i < len(words); i++ {
+		if i > 1 {
+			<-ticker.C
+		}
+		emit(strings.Join(words[:i], " "))
+	}
+}
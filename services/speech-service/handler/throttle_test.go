@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/config"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/manager"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/service"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/testutil"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/validation"
+)
+
+func TestThrottleAppliesOnlyAboveBurstThreshold(t *testing.T) {
+	throttle := &LLMStreamThrottle{WordsPerSecond: 4, BurstThresholdChars: 50}
+	if throttle.Applies("Short reply.") {
+		t.Error("reply under the burst threshold should bypass pacing")
+	}
+	long := strings.Repeat("word ", 20)
+	if !throttle.Applies(long) {
+		t.Error("long reply should be paced")
+	}
+
+	var disabled *LLMStreamThrottle
+	if disabled.Applies(long) {
+		t.Error("nil throttle should never apply")
+	}
+}
+
+func TestNewLLMStreamThrottleDisabledAtZeroWPM(t *testing.T) {
+	if NewLLMStreamThrottle(config.LLMConfig{AnimateSpeedWPM: 0}) != nil {
+		t.Error("ANIMATE_SPEED_WPM=0 should disable the throttle")
+	}
+	throttle := NewLLMStreamThrottle(config.LLMConfig{AnimateSpeedWPM: 240, BurstThresholdChars: 200})
+	if throttle == nil || throttle.WordsPerSecond != 4 {
+		t.Fatalf("throttle = %+v, want 4 words per second from 240 WPM", throttle)
+	}
+}
+
+func TestThrottleStreamsCumulativePrefixes(t *testing.T) {
+	throttle := &LLMStreamThrottle{WordsPerSecond: 1000}
+	var partials []string
+	throttle.Stream("one two three four", func(partial string) {
+		partials = append(partials, partial)
+	})
+	want := []string{"one", "one two", "one two three"}
+	if len(partials) != len(want) {
+		t.Fatalf("partials = %q, want %q", partials, want)
+	}
+	for i := range want {
+		if partials[i] != want[i] {
+			t.Errorf("partial[%d] = %q, want %q", i, partials[i], want[i])
+		}
+	}
+}
+
+// newThrottleWSHandler builds a direct WebSocket handler with mock LLM and
+// TTS backends and an aggressive pacer so the test finishes quickly.
+func newThrottleWSHandler(t *testing.T, llmURL, ttsURL string) *WebSocketHandler {
+	t.Helper()
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	validator, err := validation.NewMessageValidator()
+	if err != nil {
+		t.Fatalf("NewMessageValidator: %v", err)
+	}
+	h := NewWebSocketHandler(
+		manager.NewWebSocketManager(logger, nil),
+		validator,
+		NewRateLimiter(config.RateLimitConfig{}),
+		service.NewASRService(config.ASRConfig{}, logger),
+		service.NewTTSService(config.TTSConfig{BaseURL: ttsURL, VoiceType: "test_voice", Speed: 1.0}, logger),
+		service.NewLLMService(config.LLMConfig{BaseURL: llmURL, Model: "test-model", MaxTokens: 50}, nil, logger),
+		service.NewISEService(config.ISEConfig{}, logger),
+		service.NewAudioService(config.AudioConfig{}, logger),
+		newTestVoiceSelector(t, logger),
+		"", logger)
+	h.SetLLMThrottle(&LLMStreamThrottle{WordsPerSecond: 200, BurstThresholdChars: 10})
+	return h
+}
+
+func TestLongReplyIsPacedAsPartials(t *testing.T) {
+	reply := "That is a great question and here is a suitably long answer."
+	llm := testutil.NewMockLLMServer(testutil.MockLLMConfig{ReplyText: reply})
+	defer llm.Close()
+	tts := testutil.NewMockTTSServer(testutil.MockTTSConfig{AudioChunks: [][]byte{[]byte("audio")}})
+	defer tts.Close()
+	h := newThrottleWSHandler(t, llm.URL(), tts.URL())
+
+	srv := httptest.NewServer(http.HandlerFunc(h.HandleWebSocket))
+	defer srv.Close()
+	conn, _, err := websocket.DefaultDialer.Dial(
+		"ws"+strings.TrimPrefix(srv.URL, "http")+"?session_id=throttle-session", nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+	readUntilType(t, conn, model.MessageTypeStatus)
+
+	if err := conn.WriteJSON(&model.WebSocketMessage{
+		Type: model.MessageTypeText,
+		Data: map[string]interface{}{"text": "Tell me something long."},
+	}); err != nil {
+		t.Fatalf("send text: %v", err)
+	}
+
+	var partials []string
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for {
+		frameType, data, err := conn.ReadMessage()
+		if err != nil {
+			t.Fatalf("waiting for llm_response: %v", err)
+		}
+		// Skip the binary audio frames interleaved with the JSON stream.
+		if frameType != websocket.TextMessage {
+			continue
+		}
+		var msg model.WebSocketMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			t.Fatalf("waiting for llm_response: %v", err)
+		}
+		if msg.Type == model.MessageTypePartialLLMResponse {
+			text, _ := msg.Data["text"].(string)
+			partials = append(partials, text)
+			continue
+		}
+		if msg.Type != model.MessageTypeLLMResponse {
+			continue
+		}
+		if got := msg.Data["text"]; got != reply {
+			t.Errorf("final text = %v, want the full reply", got)
+		}
+		break
+	}
+	if len(partials) == 0 {
+		t.Fatal("no partial_llm_response before the final reply")
+	}
+	last := partials[len(partials)-1]
+	if !strings.HasPrefix(reply, last) || last == reply {
+		t.Errorf("last partial %q should be a strict prefix of the reply", last)
+	}
+}
@@ -0,0 +1,104 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/logging"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+	speechv1 "github.com/CurvatureX/ai-tutor-monorepo/shared/proto/speech"
+)
+
+// ttsFormatOgg is the wire format of transcoded replies: Opus audio in an
+// Ogg container. Both the "opus" and "ogg" values of the tts_format session
+// option select it.
+const ttsFormatOgg = "ogg"
+
+var ttsAudioBytes = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "tts_audio_bytes_total",
+	Help: "Synthesized audio bytes sent to clients, by wire format.",
+}, []string{"format"})
+
+// deliverSynthesized ships one synthesized clip to the client. When the
+// session negotiated a compressed format (the tts_format session option) and
+// the provider emitted something else, the clip is re-encoded to Opus/Ogg
+// and streamed chunk by chunk as the encoder produces output, so playback
+// can start before the transcode finishes. Otherwise — no negotiated format,
+// or the provider already emits the requested one — the clip goes out
+// unchanged as a single final chunk. turnID is 0 for clips outside the turn
+// sequence (conversation starters, shadowing targets).
+func (h *SpeechHandler) deliverSynthesized(session *VoiceSession, turnID int, resp *model.TTSResponse) {
+	if target := session.TTSFormat; target == "" || target == resp.Format {
+		h.deliverNativeTTS(session, turnID, resp)
+		return
+	}
+
+	chunks, errs := h.audioService.OpusTranscoder().Transcode(context.Background(), bytes.NewReader(resp.AudioData))
+
+	// One chunk of lookahead: each chunk ships when its successor arrives,
+	// so the last one can carry IsFinal and the clip duration.
+	var pending *speechv1.TTSResult
+	index := int32(0)
+	for chunk := range chunks {
+		if pending != nil {
+			ttsAudioBytes.WithLabelValues(ttsFormatOgg).Add(float64(len(pending.AudioData)))
+			h.deliverTTS(session, ttsResultResponse(pending))
+		}
+		pending = &speechv1.TTSResult{
+			AudioData:  chunk,
+			Format:     ttsFormatOgg,
+			TurnId:     int32(turnID),
+			ChunkIndex: index,
+		}
+		index++
+	}
+	var encodeErr error
+	select {
+	case encodeErr = <-errs:
+	default:
+	}
+
+	if pending == nil {
+		// The encoder produced nothing (ffmpeg unavailable, or the clip was
+		// unreadable); fall back to the provider's format rather than drop
+		// the reply.
+		logging.SessionLogger(session.ID, h.logger).WithError(encodeErr).WithField(logging.LogFieldService, "tts").
+			Warn("Opus transcode produced no output; sending native audio")
+		h.deliverNativeTTS(session, turnID, resp)
+		return
+	}
+	if encodeErr != nil {
+		// Chunks already sent are a valid stream prefix; close out the clip
+		// so the client stops waiting for more.
+		logging.SessionLogger(session.ID, h.logger).WithError(encodeErr).WithField(logging.LogFieldService, "tts").
+			Warn("Opus transcode failed mid-clip")
+	}
+	pending.DurationMs = resp.DurationMs
+	pending.IsFinal = true
+	ttsAudioBytes.WithLabelValues(ttsFormatOgg).Add(float64(len(pending.AudioData)))
+	h.deliverTTS(session, ttsResultResponse(pending))
+}
+
+// deliverNativeTTS sends the provider's clip unchanged as a single final
+// chunk.
+func (h *SpeechHandler) deliverNativeTTS(session *VoiceSession, turnID int, resp *model.TTSResponse) {
+	ttsAudioBytes.WithLabelValues(resp.Format).Add(float64(len(resp.AudioData)))
+	h.deliverTTS(session, ttsResultResponse(&speechv1.TTSResult{
+		AudioData:  resp.AudioData,
+		Format:     resp.Format,
+		DurationMs: resp.DurationMs,
+		TurnId:     int32(turnID),
+		ChunkIndex: 0,
+		IsFinal:    true,
+	}))
+}
+
+// ttsResultResponse wraps one TTS chunk in the stream envelope.
+func ttsResultResponse(result *speechv1.TTSResult) *speechv1.VoiceResponse {
+	return &speechv1.VoiceResponse{
+		ResponseType: &speechv1.VoiceResponse_TtsResult{TtsResult: result},
+	}
+}
@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+	speechv1 "github.com/CurvatureX/ai-tutor-monorepo/shared/proto/speech"
+)
+
+// fakeFFmpeg installs a stand-in ffmpeg that copies stdin to stdout, so the
+// delivery path is testable without a real encoder.
+func fakeFFmpeg(t *testing.T) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "ffmpeg")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\ncat\n"), 0o755); err != nil {
+		t.Fatalf("write fake ffmpeg: %v", err)
+	}
+	return path
+}
+
+// sentTTSResults extracts the TTS chunks the stream received, in order.
+func sentTTSResults(stream *fakeConversationStream) []*speechv1.TTSResult {
+	var out []*speechv1.TTSResult
+	for _, resp := range stream.responses() {
+		if r, ok := resp.GetResponseType().(*speechv1.VoiceResponse_TtsResult); ok {
+			out = append(out, r.TtsResult)
+		}
+	}
+	return out
+}
+
+func TestConfigureSessionTTSFormat(t *testing.T) {
+	h := newTestSpeechHandler(t, "", "")
+	session, _ := h.getOrCreateSession("format-session", "learner-1", newFakeStream())
+
+	h.configureSession(session, map[string]string{"tts_format": "opus"})
+	if session.TTSFormat != ttsFormatOgg {
+		t.Errorf("TTSFormat after tts_format=opus is %q, want %q", session.TTSFormat, ttsFormatOgg)
+	}
+
+	// Unknown values leave the negotiated format untouched.
+	session.TTSFormat = ""
+	h.configureSession(session, map[string]string{"tts_format": "flac"})
+	if session.TTSFormat != "" {
+		t.Errorf("TTSFormat after an unknown value is %q, want it unset", session.TTSFormat)
+	}
+}
+
+func TestDeliverSynthesizedNativePassThrough(t *testing.T) {
+	h := newTestSpeechHandler(t, "", "")
+	stream := newFakeStream()
+	session, _ := h.getOrCreateSession("native-session", "learner-1", stream)
+
+	h.deliverSynthesized(session, 3, &model.TTSResponse{
+		AudioData:  []byte("mp3-clip"),
+		Format:     "mp3",
+		DurationMs: 750,
+	})
+
+	results := sentTTSResults(stream)
+	if len(results) != 1 {
+		t.Fatalf("got %d chunks, want the clip in one", len(results))
+	}
+	r := results[0]
+	if r.Format != "mp3" || !r.IsFinal || r.TurnId != 3 || r.DurationMs != 750 {
+		t.Errorf("chunk = format %q final %t turn %d duration %d, want the native clip unchanged", r.Format, r.IsFinal, r.TurnId, r.DurationMs)
+	}
+	if !bytes.Equal(r.AudioData, []byte("mp3-clip")) {
+		t.Errorf("audio = %q, want the provider's bytes", r.AudioData)
+	}
+}
+
+// TestDeliverSynthesizedSkipsNativeTarget pins the skip: a provider already
+// emitting the negotiated format is not re-encoded.
+func TestDeliverSynthesizedSkipsNativeTarget(t *testing.T) {
+	h := newTestSpeechHandler(t, "", "")
+	// No encoder available, so any transcode attempt would fall back.
+	h.audioService.Converter().FFmpegPath = filepath.Join(t.TempDir(), "missing-ffmpeg")
+	stream := newFakeStream()
+	session, _ := h.getOrCreateSession("skip-session", "learner-1", stream)
+	session.TTSFormat = ttsFormatOgg
+
+	h.deliverSynthesized(session, 1, &model.TTSResponse{AudioData: []byte("ogg-clip"), Format: "ogg"})
+
+	results := sentTTSResults(stream)
+	if len(results) != 1 || results[0].Format != "ogg" || !bytes.Equal(results[0].AudioData, []byte("ogg-clip")) {
+		t.Fatalf("results = %v, want the native ogg clip passed through", results)
+	}
+}
+
+func TestDeliverSynthesizedTranscodesChunkwise(t *testing.T) {
+	h := newTestSpeechHandler(t, "", "")
+	h.audioService.Converter().FFmpegPath = fakeFFmpeg(t)
+	stream := newFakeStream()
+	session, _ := h.getOrCreateSession("opus-session", "learner-1", stream)
+	session.TTSFormat = ttsFormatOgg
+
+	clip := bytes.Repeat([]byte("synthesized-audio"), 2000)
+	h.deliverSynthesized(session, 5, &model.TTSResponse{AudioData: clip, Format: "mp3", DurationMs: 2200})
+
+	results := sentTTSResults(stream)
+	if len(results) < 2 {
+		t.Fatalf("clip arrived in %d chunk(s), want it streamed across several", len(results))
+	}
+	var got []byte
+	for i, r := range results {
+		if r.Format != ttsFormatOgg {
+			t.Errorf("chunk %d format = %q, want %q", i, r.Format, ttsFormatOgg)
+		}
+		if r.ChunkIndex != int32(i) {
+			t.Errorf("chunk %d carries index %d", i, r.ChunkIndex)
+		}
+		if r.TurnId != 5 {
+			t.Errorf("chunk %d turn = %d, want 5", i, r.TurnId)
+		}
+		if final := i == len(results)-1; r.IsFinal != final {
+			t.Errorf("chunk %d IsFinal = %t, want %t", i, r.IsFinal, final)
+		}
+		got = append(got, r.AudioData...)
+	}
+	if last := results[len(results)-1]; last.DurationMs != 2200 {
+		t.Errorf("final chunk duration = %d, want the clip's 2200", last.DurationMs)
+	}
+	if !bytes.Equal(got, clip) {
+		t.Errorf("reassembled audio is %d bytes, want the %d encoder output bytes", len(got), len(clip))
+	}
+}
+
+// TestDeliverSynthesizedFallsBackWithoutEncoder pins the degradation path: a
+// missing ffmpeg must not cost the learner the reply.
+func TestDeliverSynthesizedFallsBackWithoutEncoder(t *testing.T) {
+	h := newTestSpeechHandler(t, "", "")
+	h.audioService.Converter().FFmpegPath = filepath.Join(t.TempDir(), "missing-ffmpeg")
+	stream := newFakeStream()
+	session, _ := h.getOrCreateSession("fallback-session", "learner-1", stream)
+	session.TTSFormat = ttsFormatOgg
+
+	h.deliverSynthesized(session, 2, &model.TTSResponse{AudioData: []byte("mp3-clip"), Format: "mp3", DurationMs: 600})
+
+	results := sentTTSResults(stream)
+	if len(results) != 1 {
+		t.Fatalf("got %d chunks, want the native fallback in one", len(results))
+	}
+	r := results[0]
+	if r.Format != "mp3" || !r.IsFinal || !bytes.Equal(r.AudioData, []byte("mp3-clip")) {
+		t.Errorf("fallback chunk = format %q final %t audio %q, want the provider's clip", r.Format, r.IsFinal, r.AudioData)
+	}
+}
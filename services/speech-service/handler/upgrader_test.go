@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/config"
+)
+
+// TestReadLimitClosesOversizedFrames arms a small read limit and checks that
+// a frame over it closes the connection with 1009 instead of being buffered.
+func TestReadLimitClosesOversizedFrames(t *testing.T) {
+	ConfigureUpgrader(config.WebSocketConfig{
+		ReadBufferBytes:  4 * 1024,
+		WriteBufferBytes: 4 * 1024,
+		ReadLimitBytes:   8 * 1024,
+	})
+	defer ConfigureUpgrader(config.WebSocketConfig{
+		ReadBufferBytes:  32 * 1024,
+		WriteBufferBytes: 32 * 1024,
+		ReadLimitBytes:   1 << 20,
+	})
+
+	h := newWordTimingWSHandler(t, "http://unused.invalid", "ws://unused.invalid")
+	srv := httptest.NewServer(http.HandlerFunc(h.HandleWebSocket))
+	defer srv.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial(
+		"ws"+strings.TrimPrefix(srv.URL, "http")+"?session_id=read-limit", nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+
+	// Skip the connected greeting, then send a frame over the limit.
+	if _, _, err := conn.ReadMessage(); err != nil {
+		t.Fatalf("read greeting: %v", err)
+	}
+	if err := conn.WriteMessage(websocket.BinaryMessage, make([]byte, 64*1024)); err != nil {
+		t.Fatalf("write oversized frame: %v", err)
+	}
+
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			if !websocket.IsCloseError(err, websocket.CloseMessageTooBig) {
+				t.Errorf("close error = %v, want close %d", err, websocket.CloseMessageTooBig)
+			}
+			return
+		}
+	}
+}
+
+// benchmarkFrameThroughput measures round-tripping utterance-sized binary
+// frames over a loopback connection with the given connection buffer sizes,
+// isolating the gorilla buffer tuning from the rest of the handler.
+func benchmarkFrameThroughput(b *testing.B, bufferSize int) {
+	up := websocket.Upgrader{
+		ReadBufferSize:  bufferSize,
+		WriteBufferSize: bufferSize,
+		CheckOrigin:     func(r *http.Request) bool { return true },
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := up.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		for {
+			msgType, data, err := conn.ReadMessage()
+			if err != nil {
+				return
+			}
+			if err := conn.WriteMessage(msgType, data); err != nil {
+				return
+			}
+		}
+	}))
+	defer srv.Close()
+
+	dialer := websocket.Dialer{ReadBufferSize: bufferSize, WriteBufferSize: bufferSize}
+	conn, _, err := dialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	if err != nil {
+		b.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+
+	// A typical complete utterance upload.
+	frame := bytes.Repeat([]byte("audio-frame-data"), 16*1024) // 256 KB
+	b.SetBytes(int64(len(frame)) * 2)                          // sent and echoed back
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := conn.WriteMessage(websocket.BinaryMessage, frame); err != nil {
+			b.Fatalf("write: %v", err)
+		}
+		if _, _, err := conn.ReadMessage(); err != nil {
+			b.Fatalf("read: %v", err)
+		}
+	}
+}
+
+func BenchmarkFrameThroughputDefaultBuffers(b *testing.B) { benchmarkFrameThroughput(b, 1024) }
+func BenchmarkFrameThroughputTunedBuffers(b *testing.B)   { benchmarkFrameThroughput(b, 32*1024) }
@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+)
+
+// collectTurnVocabulary runs the per-turn vocabulary extraction and merges the
+// result into the session's list. It runs off the turn's critical path and
+// never fails the turn: the extractor falls back to a heuristic internally.
+func (h *SpeechHandler) collectTurnVocabulary(session *VoiceSession, userText, assistantText string) {
+	session.addVocabulary(h.llmService.ExtractVocabulary(userText, assistantText))
+}
+
+// addVocabulary merges entries into the session's word list, keeping the
+// first example seen for each word.
+func (s *VoiceSession) addVocabulary(entries []model.VocabularyEntry) {
+	s.vocabMu.Lock()
+	defer s.vocabMu.Unlock()
+	if s.vocabSeen == nil {
+		s.vocabSeen = make(map[string]bool)
+	}
+	for _, entry := range entries {
+		if s.vocabSeen[entry.Word] {
+			continue
+		}
+		s.vocabSeen[entry.Word] = true
+		s.vocabulary = append(s.vocabulary, entry)
+	}
+}
+
+// vocabularySnapshot copies the accumulated word list under the lock.
+func (s *VoiceSession) vocabularySnapshot() []model.VocabularyEntry {
+	s.vocabMu.Lock()
+	defer s.vocabMu.Unlock()
+	return append([]model.VocabularyEntry(nil), s.vocabulary...)
+}
+
+// ServeSessionVocabulary handles GET /sessions/:id/vocabulary, returning the
+// word list of a live session, or of a finished one while its report is still
+// retained.
+func (h *SpeechHandler) ServeSessionVocabulary(c *gin.Context) {
+	id := c.Param("id")
+
+	h.mu.RLock()
+	session, ok := h.sessions[id]
+	h.mu.RUnlock()
+	if ok {
+		c.JSON(http.StatusOK, gin.H{"session_id": id, "vocabulary": session.vocabularySnapshot()})
+		return
+	}
+	if report, ok := h.SessionReport(id); ok {
+		c.JSON(http.StatusOK, gin.H{"session_id": id, "vocabulary": report.Vocabulary})
+		return
+	}
+	c.JSON(http.StatusNotFound, gin.H{"error": "session not found"})
+}
@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/testutil"
+)
+
+func TestAddVocabularyDeduplicates(t *testing.T) {
+	session := &VoiceSession{}
+	session.addVocabulary([]model.VocabularyEntry{
+		{Word: "peculiar", Example: "What a peculiar bird."},
+		{Word: "scenery", Example: "The scenery was stunning."},
+	})
+	session.addVocabulary([]model.VocabularyEntry{
+		{Word: "peculiar", Example: "Another peculiar thing."},
+		{Word: "stunning", Example: "The scenery was stunning."},
+	})
+
+	got := session.vocabularySnapshot()
+	if len(got) != 3 {
+		t.Fatalf("got %d entries, want 3: %+v", len(got), got)
+	}
+	if got[0].Word != "peculiar" || got[1].Word != "scenery" || got[2].Word != "stunning" {
+		t.Errorf("words = %+v, want peculiar, scenery, stunning in first-seen order", got)
+	}
+	// The first example seen for a word wins.
+	if got[0].Example != "What a peculiar bird." {
+		t.Errorf("peculiar example = %q, want the first one", got[0].Example)
+	}
+}
+
+func TestServeSessionVocabulary(t *testing.T) {
+	llm := testutil.NewMockLLMServer(testutil.MockLLMConfig{ReplyText: "unused"})
+	defer llm.Close()
+	tts := testutil.NewMockTTSServer(testutil.MockTTSConfig{AudioChunks: [][]byte{[]byte("audio")}})
+	defer tts.Close()
+
+	h := newTestSpeechHandler(t, llm.URL(), tts.URL())
+	session := &VoiceSession{ID: "vocab-session"}
+	session.addVocabulary([]model.VocabularyEntry{{Word: "peculiar", Example: "What a peculiar bird."}})
+	h.mu.Lock()
+	h.sessions[session.ID] = session
+	h.mu.Unlock()
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/sessions/:id/vocabulary", h.ServeSessionVocabulary)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/sessions/vocab-session/vocabulary", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	var body struct {
+		SessionID  string                  `json:"session_id"`
+		Vocabulary []model.VocabularyEntry `json:"vocabulary"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("parse response: %v", err)
+	}
+	if len(body.Vocabulary) != 1 || body.Vocabulary[0].Word != "peculiar" {
+		t.Errorf("vocabulary = %+v, want the session's one entry", body.Vocabulary)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/sessions/unknown/vocabulary", nil))
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status for unknown session = %d, want 404", rec.Code)
+	}
+}
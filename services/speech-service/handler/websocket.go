@@ -0,0 +1,906 @@
+package handler
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/config"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/logging"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/manager"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/memory"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/ring"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/service"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/store"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/validation"
+	shared "github.com/CurvatureX/ai-tutor-monorepo/shared/models"
+)
+
+// upgrader accepts direct WebSocket connections. The buffer sizes and the
+// per-connection read limit default to the config package's values and are
+// overridden from the deployment config via ConfigureUpgrader.
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  32 * 1024,
+	WriteBufferSize: 32 * 1024,
+	CheckOrigin: func(r *http.Request) bool {
+		return true
+	},
+}
+
+// wsReadLimit caps a single incoming frame on upgraded connections; 0 leaves
+// frames uncapped.
+var wsReadLimit int64 = 1 << 20
+
+// clientReportedErrors counts sessions the client closed with 1011, i.e.
+// failures the client perceived that the server side may not have logged.
+var clientReportedErrors = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "ws_client_reported_errors_total",
+	Help: "WebSocket sessions closed by the client with code 1011 (internal error).",
+})
+
+// ConfigureUpgrader applies the deployment's WebSocket tuning to the shared
+// upgrader and the per-connection read limit. Call once at startup, before
+// any connection is accepted.
+func ConfigureUpgrader(cfg config.WebSocketConfig) {
+	upgrader.ReadBufferSize = cfg.ReadBufferBytes
+	upgrader.WriteBufferSize = cfg.WriteBufferBytes
+	upgrader.EnableCompression = cfg.EnableCompression
+	wsReadLimit = cfg.ReadLimitBytes
+}
+
+// applyReadLimit arms the frame-size cap on a freshly upgraded connection.
+func applyReadLimit(conn *websocket.Conn) {
+	if wsReadLimit > 0 {
+		conn.SetReadLimit(wsReadLimit)
+	}
+}
+
+// ASRProvider transcribes a finished utterance. In direct mode this is the
+// in-process ASRService; in gRPC backend mode it is a thin client forwarding
+// to another speech-service instance.
+type ASRProvider interface {
+	ProcessAudio(req *model.ASRRequest) (*model.ASRResponse, error)
+}
+
+// LLMProvider generates the tutor's reply for a user turn under the
+// session's practice mode.
+type LLMProvider interface {
+	GeneratePracticeResponse(userText, cefrEstimate string, mode service.PracticeMode, topic string, history *model.ConversationHistory) (*model.LLMResponse, error)
+}
+
+// TTSProvider synthesizes the reply audio, with or without per-word timings.
+type TTSProvider interface {
+	SynthesizeSpeech(req *model.TTSRequest) (*model.TTSResponse, error)
+	SynthesizeSpeechWithWordTimings(req *model.TTSRequest) (*model.TTSResponse, error)
+}
+
+// WebSocketHandler serves browser clients that connect to the speech service
+// directly (without going through the gateway's gRPC bridge).
+type WebSocketHandler struct {
+	wsManager     *manager.WebSocketManager
+	validator     *validation.MessageValidator
+	limiter       *RateLimiter
+	asrService    ASRProvider
+	ttsService    TTSProvider
+	llmService    LLMProvider
+	iseService    *service.ISEService
+	audioService  *service.AudioService
+	voiceSelector *service.VoiceSelector
+	teacherToken  string
+	logger        *logrus.Logger
+
+	// llmThrottle paces long replies out as partial_llm_response chunks for
+	// typewriter animation; nil sends every reply in one message.
+	llmThrottle *LLMStreamThrottle
+
+	// pipeline holds the deployment's stage switches; nil (the default)
+	// leaves every stage enabled. Set once at startup via SetPipeline.
+	pipeline *config.PipelineConfig
+
+	// memory is the per-session buffer ledger; nil (the default) disables
+	// accounting. Set once at startup via SetMemoryAccountant.
+	memory *memory.Accountant
+
+	// preRollMs is how much preview audio is retained ahead of a recording;
+	// 0 (the default) disables the pre-roll flow. Set once at startup via
+	// SetAudioPreRoll.
+	preRollMs int
+
+	// recorder tees utterance audio into the QA store for sessions that
+	// opted in at connect time; nil (the default) disables the opt-in.
+	// recordMaxBytes caps what one session may store. Set once at startup
+	// via SetQARecorder.
+	recorder       *store.SessionRecorder
+	recordMaxBytes int64
+
+	// reconnect issues and validates signed reconnection tokens; nil (the
+	// default) disables token reconnects. Set once at startup via
+	// SetReconnectTokens.
+	reconnect *manager.ReconnectTokens
+
+	// deterministicEnabled lets sessions opt into deterministic mode via
+	// ?deterministic=true; false (the default) makes the flag a no-op. Set
+	// once at startup via SetDeterministicSessions; see deterministic.go.
+	deterministicEnabled bool
+}
+
+// NewWebSocketHandler creates the direct WebSocket handler. teacherToken
+// authorizes read-only teacher/observer connections; empty disables them.
+func NewWebSocketHandler(
+	wsManager *manager.WebSocketManager,
+	validator *validation.MessageValidator,
+	limiter *RateLimiter,
+	asr ASRProvider,
+	tts TTSProvider,
+	llm LLMProvider,
+	ise *service.ISEService,
+	audio *service.AudioService,
+	voices *service.VoiceSelector,
+	teacherToken string,
+	logger *logrus.Logger,
+) *WebSocketHandler {
+	return &WebSocketHandler{
+		wsManager:     wsManager,
+		validator:     validator,
+		limiter:       limiter,
+		asrService:    asr,
+		ttsService:    tts,
+		llmService:    llm,
+		iseService:    ise,
+		audioService:  audio,
+		voiceSelector: voices,
+		teacherToken:  teacherToken,
+		logger:        logger,
+	}
+}
+
+// SetLLMThrottle installs the reply pacer; nil (the default) disables it.
+func (h *WebSocketHandler) SetLLMThrottle(t *LLMStreamThrottle) {
+	h.llmThrottle = t
+}
+
+// SetMemoryAccountant installs the buffer ledger; nil (the default) disables
+// memory accounting.
+func (h *WebSocketHandler) SetMemoryAccountant(a *memory.Accountant) {
+	h.memory = a
+}
+
+// preRollBytesPerMs sizes the pre-roll ring from its duration: the preview
+// stream is nominally 8 kHz 16-bit mono, 16 bytes per millisecond. Compressed
+// preview frames fit with room to spare.
+const preRollBytesPerMs = 16
+
+// SetAudioPreRoll enables the pre-recording preview flow: binary frames
+// arriving outside a recording feed a per-session ring holding the last ms
+// milliseconds, prepended to the utterance on start_recording. 0 (the
+// default) keeps the old buffer-everything behavior.
+func (h *WebSocketHandler) SetAudioPreRoll(ms int) {
+	h.preRollMs = ms
+}
+
+// SetQARecorder enables the per-session recording opt-in: sessions that
+// connect with ?record_session=true get their utterance audio teed into the
+// QA store, up to maxBytesPerSession per session (0 = uncapped). The global
+// recording switch stays the master gate — a disabled recorder ignores the
+// opt-in entirely.
+func (h *WebSocketHandler) SetQARecorder(rec *store.SessionRecorder, maxBytesPerSession int64) {
+	h.recorder = rec
+	h.recordMaxBytes = maxBytesPerSession
+}
+
+// SetReconnectTokens enables token-based reconnects: every session gets a
+// signed session_token at connect time, and presenting it as
+// ?reconnect_token=<token> after a dropped connection resumes the session
+// from its snapshot.
+func (h *WebSocketHandler) SetReconnectTokens(tokens *manager.ReconnectTokens) {
+	h.reconnect = tokens
+}
+
+// qaRecordingEnabled reports whether opted-in sessions can actually store
+// audio.
+func (h *WebSocketHandler) qaRecordingEnabled() bool {
+	return h.recorder != nil && h.recorder.Enabled()
+}
+
+// recordTurnAudio tees one utterance — as uploaded and as converted WAV —
+// into the QA store for an opted-in session, stopping at the per-session
+// byte cap. Sessions without the opt-in store nothing.
+func (h *WebSocketHandler) recordTurnAudio(session *manager.WebSocketSession, turnID int, raw, wav []byte) {
+	if !h.qaRecordingEnabled() || !session.QARecord {
+		return
+	}
+	size := int64(len(raw) + len(wav))
+	withinCap := false
+	h.wsManager.UpdateSession(session.ID, func(s *manager.WebSocketSession) {
+		if h.recordMaxBytes > 0 && s.QARecordedBytes+size > h.recordMaxBytes {
+			return
+		}
+		s.QARecordedBytes += size
+		withinCap = true
+	})
+	if !withinCap {
+		logging.SessionLogger(session.ID, h.logger).Debug("QA recording cap reached; utterance not stored")
+		return
+	}
+	h.recorder.RecordRawAudio(session.ID, turnID, raw, "webm")
+	h.recorder.RecordAudio(session.ID, turnID, wav)
+}
+
+// HandleWebSocket upgrades the connection and runs the session read loop.
+// With ?role=teacher or ?role=observer the connection attaches read-only to
+// an existing session instead of starting its own.
+func (h *WebSocketHandler) HandleWebSocket(w http.ResponseWriter, r *http.Request) {
+	sessionID := r.URL.Query().Get("session_id")
+	if role := r.URL.Query().Get("role"); role == "teacher" || role == "observer" {
+		h.handleSecondaryConnection(w, r, sessionID, role)
+		return
+	}
+
+	// The ise connect flag mirrors the gateway's: on/off, rejected before
+	// the upgrade when malformed.
+	iseFlag := r.URL.Query().Get("ise")
+	if iseFlag != "" && iseFlag != "on" && iseFlag != "off" {
+		http.Error(w, fmt.Sprintf("invalid ise %q: want on or off", iseFlag), http.StatusBadRequest)
+		return
+	}
+
+	// A reconnect token authenticates resuming a dropped session. It
+	// overrides any session_id in the query, and an invalid one is rejected
+	// before the upgrade, like an invalid teacher token.
+	var resumed *manager.ReconnectionToken
+	if tok := r.URL.Query().Get("reconnect_token"); tok != "" && h.reconnect != nil {
+		rt, err := h.reconnect.Validate(tok)
+		if err != nil {
+			h.logger.Warnf("WebSocket reconnect rejected: %v", err)
+			http.Error(w, "invalid reconnect token", http.StatusUnauthorized)
+			return
+		}
+		resumed = &rt
+		sessionID = rt.SessionID
+	}
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		h.logger.Errorf("WebSocket upgrade failed: %v", err)
+		return
+	}
+	applyReadLimit(conn)
+
+	if sessionID == "" {
+		sessionID = uuid.NewString()
+	}
+	userID := r.URL.Query().Get("user_id")
+
+	session := h.wsManager.AddConnection(sessionID, userID, conn)
+	defer h.wsManager.RemoveConnection(sessionID)
+	defer h.limiter.Forget(sessionID)
+
+	if iseFlag != "" {
+		h.wsManager.UpdateSession(sessionID, func(s *manager.WebSocketSession) {
+			s.ISEDisabled = iseFlag == "off"
+		})
+	}
+
+	if r.URL.Query().Get("record_session") == "true" {
+		if h.qaRecordingEnabled() {
+			h.wsManager.UpdateSession(sessionID, func(s *manager.WebSocketSession) {
+				s.QARecord = true
+			})
+			// The consent decision belongs in the log: recordings hold user
+			// speech, so who opted in must be auditable.
+			logging.SessionLogger(sessionID, h.logger).WithField("user_id", userID).
+				Info("QA audio recording enabled by client opt-in")
+		} else {
+			logging.SessionLogger(sessionID, h.logger).
+				Info("record_session requested but recording is disabled")
+		}
+	}
+
+	if r.URL.Query().Get("deterministic") == "true" {
+		if h.deterministicEnabled {
+			h.wsManager.UpdateSession(sessionID, func(s *manager.WebSocketSession) {
+				s.Deterministic = true
+			})
+		} else {
+			logging.SessionLogger(sessionID, h.logger).
+				Info("deterministic requested but deterministic sessions are disabled")
+		}
+	}
+
+	// A proper close handshake carries intent the error path cannot see:
+	// record the code and reason, apply the per-code policy, and acknowledge
+	// so the client's handshake completes. The read loop then unwinds into
+	// the deferred cleanup as usual.
+	conn.SetCloseHandler(func(code int, text string) error {
+		h.handleClientClose(session, code, text)
+		err := conn.WriteControl(websocket.CloseMessage,
+			websocket.FormatCloseMessage(code, ""), time.Now().Add(time.Second))
+		if err == websocket.ErrCloseSent {
+			// The server already initiated its own close (read limit,
+			// shutdown); the handshake is complete without the echo.
+			return nil
+		}
+		return err
+	})
+
+	h.wsManager.SendMessage(sessionID, &model.WebSocketMessage{
+		Type: model.MessageTypeStatus,
+		Data: map[string]interface{}{"status": "connected", "session_id": sessionID},
+	})
+	if resumed != nil {
+		h.wsManager.SendMessage(sessionID, &model.WebSocketMessage{
+			Type: model.MessageTypeSessionResumed,
+			Data: map[string]interface{}{
+				"turn_count":  session.TurnCount,
+				"state_drift": manager.HistoryChecksum(session.ConversationHistory) != resumed.StateChecksum,
+			},
+		})
+		logging.SessionLogger(sessionID, h.logger).Info("Session resumed via reconnect token")
+	}
+	if h.reconnect != nil {
+		issued := h.reconnect.Issue(sessionID, session.ConversationHistory)
+		h.wsManager.SendMessage(sessionID, &model.WebSocketMessage{
+			Type: model.MessageTypeSessionToken,
+			Data: map[string]interface{}{
+				"token":      issued.Token,
+				"expires_at": issued.ExpiresAt.UnixMilli(),
+			},
+		})
+	}
+
+	for {
+		msgType, data, err := conn.ReadMessage()
+		if err != nil {
+			logging.SessionLogger(sessionID, h.logger).WithError(err).Info("WebSocket closed")
+			break
+		}
+		switch msgType {
+		case websocket.TextMessage:
+			h.handleTextMessage(session, data)
+		case websocket.BinaryMessage:
+			h.handleBinaryMessage(session, data)
+		}
+	}
+}
+
+// handleClientClose records a client close frame and applies the per-code
+// policy. A normal closure (1000) marks the session as deliberately ended, so
+// RemoveConnection discards the snapshot; going away (1001, navigations and
+// refreshes) keeps the default persist-for-reconnect path; policy violations
+// (1008) and client-reported server errors (1011) are surfaced for operators.
+func (h *WebSocketHandler) handleClientClose(session *manager.WebSocketSession, code int, reason string) {
+	h.wsManager.NoteClose(session.ID, code, reason)
+	logger := logging.SessionLogger(session.ID, h.logger)
+	switch code {
+	case websocket.CloseNormalClosure:
+		logger.Info("Client ended the session")
+	case websocket.CloseGoingAway:
+		logger.Info("Client going away; session state kept for reconnect")
+	case websocket.ClosePolicyViolation:
+		logger.WithFields(logrus.Fields{
+			"close_code":   code,
+			"close_reason": reason,
+		}).Warn("Client closed with policy violation")
+	case websocket.CloseInternalServerErr:
+		clientReportedErrors.Inc()
+		logger.WithField("close_reason", reason).Error("Client closed reporting a server error")
+	default:
+		logger.WithFields(logrus.Fields{
+			"close_code":   code,
+			"close_reason": reason,
+		}).Info("Client closed the connection")
+	}
+}
+
+// handleSecondaryConnection attaches a read-only teacher or observer to an
+// existing learner session. The connection receives copies of every frame the
+// learner does but cannot send audio or text.
+func (h *WebSocketHandler) handleSecondaryConnection(w http.ResponseWriter, r *http.Request, sessionID, roleName string) {
+	if h.teacherToken == "" ||
+		subtle.ConstantTimeCompare([]byte(r.URL.Query().Get("teacher_token")), []byte(h.teacherToken)) != 1 {
+		http.Error(w, "invalid teacher token", http.StatusUnauthorized)
+		return
+	}
+	if _, ok := h.wsManager.GetSession(sessionID); !ok {
+		http.Error(w, "session not found", http.StatusNotFound)
+		return
+	}
+
+	role := manager.RoleTeacher
+	if roleName == "observer" {
+		role = manager.RoleObserver
+	}
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		h.logger.Errorf("WebSocket upgrade failed for %s: %v", roleName, err)
+		return
+	}
+	applyReadLimit(conn)
+
+	// Greet before registering for fan-out so this write cannot race a
+	// mirrored frame on the same connection.
+	conn.WriteJSON(&model.WebSocketMessage{
+		Type: model.MessageTypeStatus,
+		Data: map[string]interface{}{
+			"status":     "connected",
+			"session_id": sessionID,
+			"role":       role.String(),
+		},
+		Timestamp: time.Now().UnixMilli(),
+	})
+
+	secondaryID := uuid.NewString()
+	if _, err := h.wsManager.AddSecondaryConnection(sessionID, secondaryID, conn, role); err != nil {
+		// The session ended between the lookup and the attach.
+		conn.WriteControl(websocket.CloseMessage,
+			websocket.FormatCloseMessage(model.CloseNormalEnd, "session ended"),
+			time.Now().Add(time.Second))
+		conn.Close()
+		return
+	}
+	defer h.wsManager.RemoveSecondaryConnection(sessionID, secondaryID)
+
+	for {
+		msgType, _, err := conn.ReadMessage()
+		if err != nil {
+			logging.SessionLogger(sessionID, h.logger).WithError(err).Infof("%s connection closed", roleName)
+			break
+		}
+		if msgType == websocket.BinaryMessage && !role.CanSendAudio() {
+			// Watchers cannot drive the pipeline; drop the frame. Replying on
+			// conn here would race the fan-out writes, so just log it.
+			logging.SessionLogger(sessionID, h.logger).Warnf("Dropping audio frame from read-only %s connection", roleName)
+		}
+		// Text frames from watchers are likewise ignored.
+	}
+}
+
+// handleTextMessage validates a JSON envelope and dispatches on its type.
+func (h *WebSocketHandler) handleTextMessage(session *manager.WebSocketSession, data []byte) {
+	msg, err := h.validator.Validate(data)
+	if err != nil {
+		h.sendValidationError(session, err)
+		return
+	}
+	if msg.Timestamp == 0 {
+		msg.Timestamp = time.Now().UnixMilli()
+	}
+
+	switch msg.Type {
+	case model.MessageTypeControl:
+		action, _ := msg.Data["action"].(string)
+		h.handleControlAction(session, action, msg.Data)
+	case model.MessageTypeText:
+		text, _ := msg.Data["text"].(string)
+		if text != "" {
+			// The turn lands in the conversation history, so it counts
+			// against the session's memory budget.
+			if err := h.chargeMemory(session, int64(len(text))); err != nil {
+				h.sendError(session, err.Error())
+				return
+			}
+			h.safeGo(session, "llm", func() { h.processTextWithLLM(session, text) })
+		}
+	default:
+		h.sendError(session, "Unknown message type: "+msg.Type)
+	}
+}
+
+// handleControlAction applies a client control command.
+func (h *WebSocketHandler) handleControlAction(session *manager.WebSocketSession, action string, data map[string]interface{}) {
+	switch action {
+	case "start_recording":
+		h.wsManager.UpdateSession(session.ID, func(s *manager.WebSocketSession) {
+			s.IsRecording = true
+			h.releaseMemory(session, int64(len(s.AudioBuffer)))
+			s.AudioBuffer = nil
+			if s.PreRoll != nil && s.PreRoll.Len() > 0 {
+				// Seed the utterance with the preview tail so speech from
+				// just before the control frame is kept. The pre-roll frames
+				// were already accepted, so this is a forced charge.
+				s.AudioBuffer = s.PreRoll.Bytes()
+				if h.memory != nil {
+					h.memory.ForceCharge(s.ID, int64(len(s.AudioBuffer)))
+				}
+				s.PreRoll.Reset()
+			}
+		})
+		h.sendStatus(session, "recording_started")
+	case "stop_recording":
+		h.wsManager.UpdateSession(session.ID, func(s *manager.WebSocketSession) {
+			s.IsRecording = false
+		})
+		if len(session.AudioBuffer) > 0 {
+			h.processCompleteAudio(session)
+		}
+	case "set_reference_text":
+		text, _ := data["text"].(string)
+		h.wsManager.UpdateSession(session.ID, func(s *manager.WebSocketSession) {
+			s.ReferenceText = text
+		})
+		h.sendStatus(session, "reference_text_set")
+	case "set_word_timings":
+		enabled, _ := data["enabled"].(bool)
+		h.wsManager.UpdateSession(session.ID, func(s *manager.WebSocketSession) {
+			s.WordTimings = enabled
+		})
+		h.sendStatus(session, "word_timings_set")
+	case "set_practice_mode":
+		name, _ := data["mode"].(string)
+		mode, ok := service.ParsePracticeMode(name)
+		if !ok {
+			h.sendError(session, "Unknown practice mode: "+name)
+			return
+		}
+		topic, _ := data["topic"].(string)
+		h.wsManager.UpdateSession(session.ID, func(s *manager.WebSocketSession) {
+			s.PracticeMode = string(mode)
+			s.PracticeTopic = topic
+		})
+		h.sendStatus(session, "practice_mode_set")
+	default:
+		h.sendError(session, "Unknown control action: "+action)
+	}
+}
+
+// handleBinaryMessage buffers audio while recording. With pre-roll enabled,
+// frames outside a recording are the preview stream and land in the
+// session's bounded pre-roll ring instead of the utterance buffer.
+func (h *WebSocketHandler) handleBinaryMessage(session *manager.WebSocketSession, data []byte) {
+	if h.preRollMs > 0 && !session.IsRecording {
+		h.wsManager.UpdateSession(session.ID, func(s *manager.WebSocketSession) {
+			if s.PreRoll == nil {
+				s.PreRoll = ring.NewBuffer(h.preRollMs * preRollBytesPerMs)
+			}
+			s.PreRoll.Push(data)
+		})
+		return
+	}
+	if err := h.chargeMemory(session, int64(len(data))); err != nil {
+		h.sendError(session, err.Error())
+		return
+	}
+	// Without pre-roll, still buffer when not marked recording, in case the
+	// start control and the first audio frame raced each other.
+	err := h.wsManager.UpdateSession(session.ID, func(s *manager.WebSocketSession) {
+		s.AudioBuffer = append(s.AudioBuffer, data...)
+	})
+	if err != nil {
+		// The session vanished between charge and buffer; undo the charge.
+		h.releaseMemory(session, int64(len(data)))
+	}
+}
+
+// chargeMemory charges bytes against the session's memory budget; a nil
+// accountant means accounting is disabled and every charge succeeds. The
+// returned error is the accountant's *memory.BudgetExceededError.
+func (h *WebSocketHandler) chargeMemory(session *manager.WebSocketSession, n int64) error {
+	if h.memory == nil {
+		return nil
+	}
+	return h.memory.Charge(session.ID, n)
+}
+
+// releaseMemory returns bytes to the session's memory budget.
+func (h *WebSocketHandler) releaseMemory(session *manager.WebSocketSession, n int64) {
+	if h.memory != nil {
+		h.memory.Release(session.ID, n)
+	}
+}
+
+// processCompleteAudio converts the utterance and fans out to ASR and ISE.
+func (h *WebSocketHandler) processCompleteAudio(session *manager.WebSocketSession) {
+	if err := h.limiter.Allow(session.ID, stageAudio); err != nil {
+		h.sendError(session, err.Error())
+		return
+	}
+	if !h.asrEnabled() {
+		h.sendError(session, "asr stage is disabled")
+		return
+	}
+	audioData := session.AudioBuffer
+	turnID := 0
+	h.wsManager.UpdateSession(session.ID, func(s *manager.WebSocketSession) {
+		h.releaseMemory(session, int64(len(s.AudioBuffer)))
+		s.AudioBuffer = nil
+		s.TurnCount++
+		turnID = s.TurnCount
+	})
+
+	h.sendStatus(session, "processing")
+	// Convert once to canonical PCM; ASR gets it WAV-framed while ISE uploads
+	// the raw samples its audio/L16 payload is declared to be.
+	pcmData, audioStats, err := h.audioService.OptimizeAudio(audioData, "webm")
+	if err != nil {
+		logging.SessionLogger(session.ID, h.logger).WithError(err).Error("Audio conversion failed")
+		h.sendError(session, "audio conversion failed")
+		return
+	}
+	wavData := h.audioService.Converter().ConvertPCMToWAV(pcmData)
+	h.recordTurnAudio(session, turnID, audioData, wavData)
+
+	// Tell the client what preprocessing did to the utterance before the
+	// recognition stages start, so the UI can flag quiet or clipped input.
+	h.wsManager.SendMessage(session.ID, &model.WebSocketMessage{
+		Type: model.MessageTypeStatus,
+		Data: map[string]interface{}{
+			"status": "audio_processed",
+			"stats":  audioStats,
+		},
+	})
+
+	// The same pre-flight check the gRPC pipeline runs: a silent utterance
+	// skips the recognition stages outright, a clipped one annotates the
+	// ASR result so the UI can suggest lowering the mic gain.
+	_, silent, clipped := h.audioService.AnalyzeQuality(pcmData)
+	if silent {
+		h.sendStatus(session, "no_speech_detected")
+		return
+	}
+	qualityWarning := ""
+	if clipped {
+		qualityWarning = "audio is clipping; try lowering the microphone gain"
+	}
+
+	h.safeGo(session, "asr", func() { h.processAudioWithASR(session, wavData, qualityWarning) })
+	if session.ReferenceText != "" && h.iseEnabled() && !session.ISEDisabled {
+		h.safeGo(session, "ise", func() { h.processAudioWithISE(session, pcmData) })
+	}
+}
+
+// processAudioWithASR transcribes and continues with the LLM stage.
+// qualityWarning, when non-empty, is the pre-flight clipping warning and is
+// attached to the ASR result.
+func (h *WebSocketHandler) processAudioWithASR(session *manager.WebSocketSession, audioData []byte, qualityWarning string) {
+	resp, err := h.asrService.ProcessAudio(&model.ASRRequest{
+		AudioData:  audioData,
+		Format:     "wav",
+		SampleRate: 16000,
+		Language:   session.Language,
+		RequestID:  wsDeterministicRequestID(session),
+	})
+	if err != nil {
+		logging.SessionLogger(session.ID, h.logger).WithError(err).WithField(logging.LogFieldService, "asr").Error("ASR failed")
+		h.sendError(session, "speech recognition failed")
+		return
+	}
+
+	data := map[string]interface{}{
+		"text":       resp.Text,
+		"confidence": resp.Confidence,
+		"is_final":   true,
+	}
+	if qualityWarning != "" {
+		data["audio_quality_warning"] = qualityWarning
+	}
+	h.wsManager.SendMessage(session.ID, &model.WebSocketMessage{
+		Type: model.MessageTypeASRResult,
+		Data: data,
+	})
+	if resp.Text != "" {
+		// The transcript joins the conversation history; force-charge it
+		// since the audio that produced it was already accepted.
+		if h.memory != nil {
+			h.memory.ForceCharge(session.ID, int64(len(resp.Text)))
+		}
+		h.processTextWithLLM(session, resp.Text)
+	}
+}
+
+// processTextWithLLM generates a reply and synthesizes it.
+func (h *WebSocketHandler) processTextWithLLM(session *manager.WebSocketSession, text string) {
+	if !h.llmEnabled() {
+		h.sendError(session, "llm stage is disabled")
+		return
+	}
+	if err := h.limiter.Allow(session.ID, stageLLM); err != nil {
+		h.sendError(session, err.Error())
+		return
+	}
+	resp, err := h.llmProviderFor(session).GeneratePracticeResponse(text, session.CEFREstimate,
+		service.PracticeMode(session.PracticeMode), session.PracticeTopic, session.ConversationHistory)
+	if err != nil {
+		logging.SessionLogger(session.ID, h.logger).WithError(err).WithField(logging.LogFieldService, "llm").Error("LLM failed")
+		h.sendError(session, "response generation failed")
+		return
+	}
+	// The reply is now part of the conversation history.
+	if h.memory != nil {
+		h.memory.ForceCharge(session.ID, int64(len(resp.Text)))
+	}
+
+	if h.llmThrottle.Applies(resp.Text) {
+		// Pacing runs in the background so it never delays synthesis; the
+		// complete reply follows the last partial without a tick.
+		h.safeGo(session, "llm_throttle", func() {
+			h.llmThrottle.Stream(resp.Text, func(partial string) {
+				h.wsManager.SendMessage(session.ID, &model.WebSocketMessage{
+					Type: model.MessageTypePartialLLMResponse,
+					Data: map[string]interface{}{"text": partial, "is_final": false},
+				})
+			})
+			h.wsManager.SendMessage(session.ID, &model.WebSocketMessage{
+				Type: model.MessageTypeLLMResponse,
+				Data: map[string]interface{}{"text": resp.Text},
+			})
+		})
+	} else {
+		h.wsManager.SendMessage(session.ID, &model.WebSocketMessage{
+			Type: model.MessageTypeLLMResponse,
+			Data: map[string]interface{}{"text": resp.Text},
+		})
+	}
+	h.processTextWithTTS(session, resp.Text)
+}
+
+// processTextWithTTS synthesizes the reply and streams it to the client. The
+// voice selector may override the default voice when the reply's detected
+// language calls for a different one (a Chinese reply gets a Chinese voice).
+func (h *WebSocketHandler) processTextWithTTS(session *manager.WebSocketSession, text string) {
+	if !h.ttsEnabled() {
+		// Text-only deployment: the reply was already delivered as
+		// llm_response, there is just no audio to follow it.
+		return
+	}
+	if err := h.limiter.Allow(session.ID, stageTTS); err != nil {
+		h.sendError(session, err.Error())
+		return
+	}
+	req := &model.TTSRequest{
+		Text:      text,
+		Language:  session.Language,
+		RequestID: wsDeterministicRequestID(session),
+	}
+	if voice, lang, ok := h.voiceSelector.Select(text); ok {
+		req.Voice = voice.VoiceID
+		req.Language = lang
+	}
+	var resp *model.TTSResponse
+	var err error
+	if session.WordTimings {
+		resp, err = h.ttsService.SynthesizeSpeechWithWordTimings(req)
+	} else {
+		resp, err = h.ttsService.SynthesizeSpeech(req)
+	}
+	if err != nil {
+		logging.SessionLogger(session.ID, h.logger).WithError(err).WithField(logging.LogFieldService, "tts").Error("TTS failed")
+		h.sendError(session, "speech synthesis failed")
+		return
+	}
+
+	h.wsManager.SendBinaryMessage(session.ID, resp.AudioData)
+	h.wsManager.SendMessage(session.ID, &model.WebSocketMessage{
+		Type: model.MessageTypeTTSReady,
+		Data: map[string]interface{}{
+			"format": resp.Format,
+			"bytes":  len(resp.AudioData),
+		},
+	})
+	if len(resp.WordBoundaries) > 0 {
+		h.emitWordBoundaries(session, resp.WordBoundaries)
+	}
+}
+
+// emitWordBoundaries sends one word_boundary event per word, paced to the
+// word's start offset in the clip. The clock starts when tts_ready goes out,
+// approximating the client starting playback on arrival.
+func (h *WebSocketHandler) emitWordBoundaries(session *manager.WebSocketSession, boundaries []model.WordBoundary) {
+	h.safeGo(session, "word_timing", func() {
+		start := time.Now()
+		for _, b := range boundaries {
+			if wait := time.Duration(b.StartMs)*time.Millisecond - time.Since(start); wait > 0 {
+				time.Sleep(wait)
+			}
+			h.wsManager.SendMessage(session.ID, &model.WebSocketMessage{
+				Type: model.MessageTypeWordBoundary,
+				Data: map[string]interface{}{
+					"word":     b.Word,
+					"start_ms": b.StartMs,
+					"end_ms":   b.EndMs,
+				},
+			})
+		}
+	})
+}
+
+// processAudioWithISE evaluates pronunciation against the reference text.
+// pcmData is the canonical PCM conversion of the utterance, uploaded as raw
+// samples. Direct WebSocket sessions have no per-connection context, so the
+// evaluation is not cancellable here; the gRPC pipeline passes its stream
+// context instead.
+func (h *WebSocketHandler) processAudioWithISE(session *manager.WebSocketSession, pcmData []byte) {
+	resp, err := h.iseService.EvaluateSpeech(context.Background(), &model.ISERequest{
+		AudioData: pcmData,
+		Text:      session.ReferenceText,
+		Language:  iseLanguage(session.Language),
+	})
+	if err != nil {
+		logging.SessionLogger(session.ID, h.logger).WithError(err).WithField(logging.LogFieldService, "ise").Error("ISE failed")
+		h.sendError(session, "pronunciation evaluation failed")
+		return
+	}
+
+	// The breakdown goes out in the shared payload shape, so clients see the
+	// same ise_result here as through the gateway.
+	words := make([]shared.ISEWord, 0, len(resp.Words))
+	for _, w := range resp.Words {
+		word := shared.ISEWord{Word: w.Word, Score: w.Score, ErrorType: w.ErrorCode}
+		for _, p := range w.Phones {
+			word.Phones = append(word.Phones, shared.ISEPhone{Phone: p.Phone, Score: p.Score, ErrorType: p.ErrorCode})
+		}
+		words = append(words, word)
+	}
+	h.wsManager.SendMessage(session.ID, &model.WebSocketMessage{
+		Type: model.MessageTypeISEResult,
+		Data: map[string]interface{}{
+			"overall_score":   resp.OverallScore,
+			"accuracy_score":  resp.AccuracyScore,
+			"fluency_score":   resp.FluencyScore,
+			"integrity_score": resp.IntegrityScore,
+			"reference_text":  resp.ReferenceText,
+			"words":           words,
+		},
+	})
+
+	if h.iseService.ProsodyComparisonEnabled() {
+		h.compareProsody(session, resp, pcmData)
+	}
+}
+
+// compareProsody mirrors the gRPC pipeline's prosody comparison for direct
+// WebSocket sessions, delivering the correlations as a JSON message.
+func (h *WebSocketHandler) compareProsody(session *manager.WebSocketSession, ise *model.ISEResponse, pcmData []byte) {
+	result := compareAgainstRendition(h.ttsService, h.audioService.Converter(), h.logger,
+		session.ID, ise, "", session.Language, pcmData)
+	if result == nil {
+		return
+	}
+	h.wsManager.SendMessage(session.ID, &model.WebSocketMessage{
+		Type: model.MessageTypePronunciationComparison,
+		Data: map[string]interface{}{
+			"pitch_correlation":  result.PitchCorrelation,
+			"rhythm_correlation": result.RhythmCorrelation,
+			"stress_correlation": result.StressCorrelation,
+			"reference_text":     ise.ReferenceText,
+		},
+	})
+}
+
+func (h *WebSocketHandler) sendStatus(session *manager.WebSocketSession, status string) {
+	h.wsManager.SendMessage(session.ID, &model.WebSocketMessage{
+		Type: model.MessageTypeStatus,
+		Data: map[string]interface{}{"status": status},
+	})
+}
+
+// sendValidationError includes per-field details so clients can repair the
+// malformed message.
+func (h *WebSocketHandler) sendValidationError(session *manager.WebSocketSession, err error) {
+	data := map[string]interface{}{"message": err.Error()}
+	var verr *validation.ValidationError
+	if errors.As(err, &verr) {
+		data["details"] = verr.Details
+	}
+	h.wsManager.SendMessage(session.ID, &model.WebSocketMessage{
+		Type: model.MessageTypeError,
+		Data: data,
+	})
+}
+
+func (h *WebSocketHandler) sendError(session *manager.WebSocketSession, message string) {
+	h.wsManager.SendMessage(session.ID, &model.WebSocketMessage{
+		Type: model.MessageTypeError,
+		Data: map[string]interface{}{"message": message},
+	})
+}
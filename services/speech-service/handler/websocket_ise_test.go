@@ -0,0 +1,247 @@
+package handler
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/config"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/manager"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/service"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/testutil"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/validation"
+	shared "github.com/CurvatureX/ai-tutor-monorepo/shared/models"
+)
+
+// newISEWSHandler builds a WebSocket handler whose evaluator talks to the
+// scripted ISE server; the conversational stages are wired but unused.
+func newISEWSHandler(t *testing.T, iseURL string) (*WebSocketHandler, *manager.WebSocketManager) {
+	t.Helper()
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	validator, err := validation.NewMessageValidator()
+	if err != nil {
+		t.Fatalf("NewMessageValidator: %v", err)
+	}
+	wsManager := manager.NewWebSocketManager(logger, nil)
+	h := NewWebSocketHandler(
+		wsManager,
+		validator,
+		NewRateLimiter(config.RateLimitConfig{}),
+		service.NewASRService(config.ASRConfig{}, logger),
+		service.NewTTSService(config.TTSConfig{}, logger),
+		service.NewLLMService(config.LLMConfig{}, nil, logger),
+		service.NewISEService(config.ISEConfig{
+			AppID:     "demo-app",
+			APIKey:    "demo-key",
+			APISecret: "demo-secret",
+			BaseURL:   iseURL,
+		}, logger),
+		service.NewAudioService(config.AudioConfig{}, logger),
+		newTestVoiceSelector(t, logger),
+		"", logger)
+	return h, wsManager
+}
+
+// audiblePCMFixture is raw PCM loud enough that the ISE silence filter keeps
+// it, the form processCompleteAudio hands the evaluation stage.
+func audiblePCMFixture() []byte {
+	audio := make([]byte, 16000)
+	for i := 0; i+1 < len(audio); i += 2 {
+		binary.LittleEndian.PutUint16(audio[i:], 4000)
+	}
+	return audio
+}
+
+// readUntilType reads frames until one of the given message type arrives.
+func readUntilType(t *testing.T, conn *websocket.Conn, msgType string) *model.WebSocketMessage {
+	t.Helper()
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for {
+		frameType, data, err := conn.ReadMessage()
+		if err != nil {
+			t.Fatalf("waiting for %s: %v", msgType, err)
+		}
+		// Binary audio frames ride alongside the JSON stream; skip them.
+		if frameType != websocket.TextMessage {
+			continue
+		}
+		var msg model.WebSocketMessage
+		if err := json.Unmarshal(data, &msg); err != nil {
+			t.Fatalf("waiting for %s: %v", msgType, err)
+		}
+		if msg.Type == msgType {
+			return &msg
+		}
+	}
+}
+
+func TestSetReferenceTextThenISEResult(t *testing.T) {
+	ise := testutil.NewMockISEServer(testutil.MockISEConfig{})
+	defer ise.Close()
+	h, wsManager := newISEWSHandler(t, ise.URL())
+
+	srv := httptest.NewServer(http.HandlerFunc(h.HandleWebSocket))
+	defer srv.Close()
+	conn, _, err := websocket.DefaultDialer.Dial(
+		"ws"+strings.TrimPrefix(srv.URL, "http")+"?session_id=ise-session", nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+	readUntilType(t, conn, model.MessageTypeStatus)
+
+	if err := conn.WriteJSON(&model.WebSocketMessage{
+		Type: model.MessageTypeControl,
+		Data: map[string]interface{}{"action": "set_reference_text", "text": "hello there."},
+	}); err != nil {
+		t.Fatalf("send control: %v", err)
+	}
+	if msg := readUntilType(t, conn, model.MessageTypeStatus); msg.Data["status"] != "reference_text_set" {
+		t.Fatalf("status = %v, want reference_text_set", msg.Data["status"])
+	}
+
+	session, ok := wsManager.GetSession("ise-session")
+	if !ok {
+		t.Fatal("session not registered")
+	}
+	if session.ReferenceText != "hello there." {
+		t.Fatalf("ReferenceText = %q, want the control payload", session.ReferenceText)
+	}
+
+	// Drive the evaluation stage directly with already-converted audio, the
+	// way processCompleteAudio fans it out after an utterance ends.
+	go h.processAudioWithISE(session, audiblePCMFixture())
+
+	msg := readUntilType(t, conn, model.MessageTypeISEResult)
+	if got := msg.Data["overall_score"]; got != 85.5 {
+		t.Errorf("overall_score = %v, want 85.5 from the mock", got)
+	}
+	if got := msg.Data["reference_text"]; got != "hello there." {
+		t.Errorf("reference_text = %v, want the configured text", got)
+	}
+}
+
+func TestISEFailureReportsErrorWithoutKillingSession(t *testing.T) {
+	ise := testutil.NewMockISEServer(testutil.MockISEConfig{ErrorCode: 10165})
+	defer ise.Close()
+	h, wsManager := newISEWSHandler(t, ise.URL())
+
+	srv := httptest.NewServer(http.HandlerFunc(h.HandleWebSocket))
+	defer srv.Close()
+	conn, _, err := websocket.DefaultDialer.Dial(
+		"ws"+strings.TrimPrefix(srv.URL, "http")+"?session_id=ise-err", nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+	readUntilType(t, conn, model.MessageTypeStatus)
+
+	session, ok := wsManager.GetSession("ise-err")
+	if !ok {
+		t.Fatal("session not registered")
+	}
+	session.ReferenceText = "hello there."
+	go h.processAudioWithISE(session, audiblePCMFixture())
+
+	msg := readUntilType(t, conn, model.MessageTypeError)
+	if got, _ := msg.Data["message"].(string); !strings.Contains(got, "pronunciation evaluation failed") {
+		t.Errorf("error message = %q, want the ISE failure text", got)
+	}
+	if _, ok := wsManager.GetSession("ise-err"); !ok {
+		t.Error("session removed after an ISE failure; evaluation must not end the session")
+	}
+}
+
+// The direct endpoint must emit the same ise_result payload the gateway does;
+// decoding through shared/models keeps the two from drifting apart.
+func TestISEResultWordBreakdownUsesSharedShape(t *testing.T) {
+	ise := testutil.NewMockISEServer(testutil.MockISEConfig{})
+	defer ise.Close()
+	h, wsManager := newISEWSHandler(t, ise.URL())
+
+	srv := httptest.NewServer(http.HandlerFunc(h.HandleWebSocket))
+	defer srv.Close()
+	conn, _, err := websocket.DefaultDialer.Dial(
+		"ws"+strings.TrimPrefix(srv.URL, "http")+"?session_id=ise-shape", nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+	readUntilType(t, conn, model.MessageTypeStatus)
+
+	session, ok := wsManager.GetSession("ise-shape")
+	if !ok {
+		t.Fatal("session not registered")
+	}
+	session.ReferenceText = "hello there."
+	go h.processAudioWithISE(session, audiblePCMFixture())
+
+	msg := readUntilType(t, conn, model.MessageTypeISEResult)
+	raw, err := json.Marshal(msg.Data)
+	if err != nil {
+		t.Fatalf("re-marshal payload: %v", err)
+	}
+	var data shared.ISEResultData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		t.Fatalf("payload does not fit the shared shape: %v", err)
+	}
+
+	if data.OverallScore != 85.5 || data.ReferenceText != "hello there." {
+		t.Errorf("scores = %+v, want the mock evaluation", data)
+	}
+	if len(data.Words) != 2 {
+		t.Fatalf("words = %+v, want both words from the mock", data.Words)
+	}
+	if w := data.Words[0]; w.Word != "hello" || w.Score != 86.0 || w.ErrorType != "correct" {
+		t.Errorf("words[0] = %+v, want the decoded mock word", w)
+	}
+}
+
+func TestISEConnectFlagDisablesEvaluation(t *testing.T) {
+	h, wsManager := newISEWSHandler(t, "")
+
+	srv := httptest.NewServer(http.HandlerFunc(h.HandleWebSocket))
+	defer srv.Close()
+	conn, _, err := websocket.DefaultDialer.Dial(
+		"ws"+strings.TrimPrefix(srv.URL, "http")+"?session_id=ise-flag&ise=off", nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+	readUntilType(t, conn, model.MessageTypeStatus)
+
+	session, ok := wsManager.GetSession("ise-flag")
+	if !ok {
+		t.Fatal("session not registered")
+	}
+	if !session.ISEDisabled {
+		t.Error("ISEDisabled = false after connecting with ise=off")
+	}
+}
+
+func TestInvalidISEFlagRejectsUpgrade(t *testing.T) {
+	h, _ := newISEWSHandler(t, "")
+
+	srv := httptest.NewServer(http.HandlerFunc(h.HandleWebSocket))
+	defer srv.Close()
+	conn, resp, err := websocket.DefaultDialer.Dial(
+		"ws"+strings.TrimPrefix(srv.URL, "http")+"?session_id=ise-bad&ise=maybe", nil)
+	if err == nil {
+		conn.Close()
+		t.Fatal("dial with ise=maybe succeeded, want a rejected upgrade")
+	}
+	if resp == nil || resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("status = %v, want 400", resp)
+	}
+}
@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/config"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/manager"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/service"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/testutil"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/validation"
+)
+
+// newWordTimingWSHandler builds a direct WebSocket handler with mock LLM and
+// TTS backends for word-timing tests.
+func newWordTimingWSHandler(t *testing.T, llmURL, ttsURL string) *WebSocketHandler {
+	t.Helper()
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	validator, err := validation.NewMessageValidator()
+	if err != nil {
+		t.Fatalf("NewMessageValidator: %v", err)
+	}
+	return NewWebSocketHandler(
+		manager.NewWebSocketManager(logger, nil),
+		validator,
+		NewRateLimiter(config.RateLimitConfig{}),
+		service.NewASRService(config.ASRConfig{}, logger),
+		service.NewTTSService(config.TTSConfig{BaseURL: ttsURL, VoiceType: "test_voice", Speed: 1.0}, logger),
+		service.NewLLMService(config.LLMConfig{BaseURL: llmURL, Model: "test-model", MaxTokens: 50}, nil, logger),
+		service.NewISEService(config.ISEConfig{}, logger),
+		service.NewAudioService(config.AudioConfig{}, logger),
+		newTestVoiceSelector(t, logger),
+		"", logger)
+}
+
+func TestWordBoundariesFollowSynthesis(t *testing.T) {
+	llm := testutil.NewMockLLMServer(testutil.MockLLMConfig{ReplyText: "hello there"})
+	defer llm.Close()
+	tts := testutil.NewMockTTSServer(testutil.MockTTSConfig{
+		AudioChunks:  [][]byte{[]byte("speech-audio")},
+		FrontendJSON: `{"words":[{"word":"hello","start_time":0,"end_time":80},{"word":"there","start_time":80,"end_time":200}]}`,
+	})
+	defer tts.Close()
+	h := newWordTimingWSHandler(t, llm.URL(), tts.URL())
+
+	srv := httptest.NewServer(http.HandlerFunc(h.HandleWebSocket))
+	defer srv.Close()
+	conn, _, err := websocket.DefaultDialer.Dial(
+		"ws"+strings.TrimPrefix(srv.URL, "http")+"?session_id=word-timing", nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+	readUntilType(t, conn, model.MessageTypeStatus)
+
+	if err := conn.WriteJSON(&model.WebSocketMessage{
+		Type: model.MessageTypeControl,
+		Data: map[string]interface{}{"action": "set_word_timings", "enabled": true},
+	}); err != nil {
+		t.Fatalf("send control: %v", err)
+	}
+	if msg := readUntilType(t, conn, model.MessageTypeStatus); msg.Data["status"] != "word_timings_set" {
+		t.Fatalf("status = %v, want word_timings_set", msg.Data["status"])
+	}
+
+	if err := conn.WriteJSON(&model.WebSocketMessage{
+		Type: model.MessageTypeText,
+		Data: map[string]interface{}{"text": "Say hello."},
+	}); err != nil {
+		t.Fatalf("send text: %v", err)
+	}
+
+	// The TTS audio arrives as a binary frame between the JSON messages, so
+	// frames are read raw and non-text ones skipped.
+	var words []string
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	for len(words) < 2 {
+		frameType, raw, err := conn.ReadMessage()
+		if err != nil {
+			t.Fatalf("waiting for word_boundary: %v", err)
+		}
+		if frameType != websocket.TextMessage {
+			continue
+		}
+		var msg model.WebSocketMessage
+		if err := json.Unmarshal(raw, &msg); err != nil {
+			t.Fatalf("unmarshal frame: %v", err)
+		}
+		if msg.Type != model.MessageTypeWordBoundary {
+			continue
+		}
+		word, _ := msg.Data["word"].(string)
+		words = append(words, word)
+		if len(words) == 2 {
+			if got := msg.Data["end_ms"]; got != float64(200) {
+				t.Errorf("end_ms = %v, want 200", got)
+			}
+		}
+	}
+	if words[0] != "hello" || words[1] != "there" {
+		t.Errorf("word sequence = %v, want [hello there]", words)
+	}
+}
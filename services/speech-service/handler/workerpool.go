@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	workerPoolQueueDepth = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "worker_pool_queue_depth",
+		Help: "Audio processing jobs waiting for a free worker.",
+	})
+	workerPoolIdleWorkers = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "worker_pool_idle_workers",
+		Help: "Audio workers currently waiting for a job.",
+	})
+)
+
+// JobType labels what a queued job does with its audio.
+type JobType string
+
+// JobTypeUtterance is a complete utterance run through the conversation
+// pipeline.
+const JobTypeUtterance JobType = "utterance"
+
+// ProcessingJob is one unit of audio work queued on the pool. run carries the
+// bound pipeline invocation; the remaining fields describe the job for
+// logging and scheduling.
+type ProcessingJob struct {
+	sessionID string
+	audioData []byte
+	jobType   JobType
+	run       func()
+}
+
+// WorkerPool bounds how many utterances the gRPC handler processes at once.
+// Without it every final audio chunk fans out into its own goroutines, which
+// under load means thousands of concurrent conversions and provider calls;
+// with it at most size utterances are in flight and at most size more wait in
+// the queue.
+type WorkerPool struct {
+	size int
+	jobs chan ProcessingJob
+}
+
+// NewWorkerPool starts size workers. The queue holds another size jobs, so
+// brief bursts are absorbed before TryEnqueue starts rejecting.
+func NewWorkerPool(size int) *WorkerPool {
+	p := &WorkerPool{size: size, jobs: make(chan ProcessingJob, size)}
+	workerPoolIdleWorkers.Set(float64(size))
+	for i := 0; i < size; i++ {
+		go p.worker()
+	}
+	return p
+}
+
+// TryEnqueue hands a job to the pool without blocking; false means every
+// worker is busy and the queue is full, and the caller should reject the work
+// immediately rather than stall its stream.
+func (p *WorkerPool) TryEnqueue(job ProcessingJob) bool {
+	select {
+	case p.jobs <- job:
+		workerPoolQueueDepth.Set(float64(len(p.jobs)))
+		return true
+	default:
+		return false
+	}
+}
+
+func (p *WorkerPool) worker() {
+	for job := range p.jobs {
+		workerPoolQueueDepth.Set(float64(len(p.jobs)))
+		workerPoolIdleWorkers.Dec()
+		job.run()
+		workerPoolIdleWorkers.Inc()
+	}
+}
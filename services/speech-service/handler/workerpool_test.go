@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"testing"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/testutil"
+	speechv1 "github.com/CurvatureX/ai-tutor-monorepo/shared/proto/speech"
+)
+
+func TestWorkerPoolRejectsWhenSaturated(t *testing.T) {
+	p := NewWorkerPool(1)
+	block := make(chan struct{})
+	started := make(chan struct{})
+	if !p.TryEnqueue(ProcessingJob{jobType: JobTypeUtterance, run: func() { close(started); <-block }}) {
+		t.Fatal("idle pool rejected a job")
+	}
+	<-started
+
+	// The worker is occupied; one job fits in the queue, the next is
+	// rejected without blocking.
+	if !p.TryEnqueue(ProcessingJob{run: func() {}}) {
+		t.Fatal("queue slot rejected while the worker is busy")
+	}
+	if p.TryEnqueue(ProcessingJob{run: func() {}}) {
+		t.Error("job accepted past the queue capacity")
+	}
+	close(block)
+}
+
+func TestPooledUtteranceStillCompletes(t *testing.T) {
+	asr := testutil.NewMockASRServer(testutil.MockASRConfig{Transcript: "hello from the pool", Confidence: 0.9})
+	defer asr.Close()
+	llm := testutil.NewMockLLMServer(testutil.MockLLMConfig{ReplyText: "Hello! How can I help you practice?"})
+	defer llm.Close()
+	tts := testutil.NewMockTTSServer(testutil.MockTTSConfig{AudioChunks: [][]byte{[]byte("audio")}})
+	defer tts.Close()
+
+	h := newClarifyHandler(t, asr.URL(), llm.URL(), tts.URL(), 0)
+	h.SetWorkerPool(NewWorkerPool(2))
+
+	sessionID := "pooled-session"
+	stream := newFakeStream(
+		configureReq(sessionID, map[string]string{"tutor_starts": "false"}),
+		freeSpeakAudioRequest(sessionID),
+	)
+	if err := h.ProcessVoiceConversation(stream); err != nil {
+		t.Fatalf("ProcessVoiceConversation: %v", err)
+	}
+
+	waitForResponse(t, stream, "asr result through the pool", func(resp *speechv1.VoiceResponse) bool {
+		r, ok := resp.GetResponseType().(*speechv1.VoiceResponse_AsrResult)
+		return ok && r.AsrResult.Text == "hello from the pool"
+	})
+	waitForResponse(t, stream, "llm reply through the pool", func(resp *speechv1.VoiceResponse) bool {
+		_, ok := resp.GetResponseType().(*speechv1.VoiceResponse_LlmResult)
+		return ok
+	})
+}
+
+func TestUtteranceRejectedWhenPoolSaturated(t *testing.T) {
+	asr := testutil.NewMockASRServer(testutil.MockASRConfig{Transcript: "never transcribed", Confidence: 0.9})
+	defer asr.Close()
+	llm := testutil.NewMockLLMServer(testutil.MockLLMConfig{ReplyText: "unused"})
+	defer llm.Close()
+	tts := testutil.NewMockTTSServer(testutil.MockTTSConfig{AudioChunks: [][]byte{[]byte("audio")}})
+	defer tts.Close()
+
+	h := newClarifyHandler(t, asr.URL(), llm.URL(), tts.URL(), 0)
+	pool := NewWorkerPool(1)
+	// Occupy the single worker and fill the queue so the utterance has
+	// nowhere to go.
+	block := make(chan struct{})
+	started := make(chan struct{})
+	pool.TryEnqueue(ProcessingJob{jobType: JobTypeUtterance, run: func() { close(started); <-block }})
+	<-started
+	pool.TryEnqueue(ProcessingJob{jobType: JobTypeUtterance, run: func() {}})
+	h.SetWorkerPool(pool)
+	defer close(block)
+
+	sessionID := "saturated-session"
+	stream := newFakeStream(
+		configureReq(sessionID, map[string]string{"tutor_starts": "false"}),
+		freeSpeakAudioRequest(sessionID),
+	)
+	if err := h.ProcessVoiceConversation(stream); err != nil {
+		t.Fatalf("ProcessVoiceConversation: %v", err)
+	}
+
+	waitForResponse(t, stream, "service overloaded error", func(resp *speechv1.VoiceResponse) bool {
+		r, ok := resp.GetResponseType().(*speechv1.VoiceResponse_Error)
+		return ok && r.Error.Code == speechv1.ErrorCode_ERROR_CODE_SERVICE_OVERLOADED
+	})
+	if got := asr.AudioFrames(); got != 0 {
+		t.Errorf("asr received %d audio frames, want 0 for a rejected utterance", got)
+	}
+}
@@ -0,0 +1,107 @@
+// Package health exposes the standard gRPC health service
+// (grpc.health.v1.Health) for Kubernetes liveness probes, with one status per
+// provider backend refreshed by periodic reachability checks.
+package health
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Checker reports whether a backend currently accepts connections. The
+// provider services implement it with a bounded dial.
+type Checker interface {
+	IsAvailable(ctx context.Context) bool
+}
+
+// checkTimeout bounds one backend probe so a hung provider cannot stall the
+// whole refresh round.
+const checkTimeout = 5 * time.Second
+
+// Service owns the gRPC health state: per-subsystem statuses (e.g. "asr",
+// "tts", "llm") plus the overall empty-name status probes usually ask for,
+// which is serving only while every subsystem is.
+type Service struct {
+	server   *health.Server
+	checks   map[string]Checker
+	interval time.Duration
+	logger   *logrus.Logger
+	stop     chan struct{}
+}
+
+// NewService creates the health service. Every subsystem reports NOT_SERVING
+// until the first round of checks completes, so probes never pass before the
+// backends have been seen up.
+func NewService(checks map[string]Checker, interval time.Duration, logger *logrus.Logger) *Service {
+	s := &Service{
+		server:   health.NewServer(),
+		checks:   checks,
+		interval: interval,
+		logger:   logger,
+		stop:     make(chan struct{}),
+	}
+	for name := range checks {
+		s.server.SetServingStatus(name, healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+	s.server.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	return s
+}
+
+// Register attaches the health service to a gRPC server.
+func (s *Service) Register(srv *grpc.Server) {
+	healthpb.RegisterHealthServer(srv, s.server)
+}
+
+// Start runs the periodic checks until Stop is called. The first round runs
+// immediately so probes don't wait a full interval after boot.
+func (s *Service) Start() {
+	go func() {
+		s.refresh()
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-s.stop:
+				return
+			case <-ticker.C:
+				s.refresh()
+			}
+		}
+	}()
+}
+
+// Stop ends the check loop and marks every status NOT_SERVING, so probes
+// fail fast while the process shuts down.
+func (s *Service) Stop() {
+	close(s.stop)
+	s.server.Shutdown()
+}
+
+// refresh probes every backend and updates the statuses atomically per
+// subsystem; the overall status flips only when all subsystems serve.
+func (s *Service) refresh() {
+	allUp := true
+	for name, check := range s.checks {
+		ctx, cancel := context.WithTimeout(context.Background(), checkTimeout)
+		up := check.IsAvailable(ctx)
+		cancel()
+
+		status := healthpb.HealthCheckResponse_SERVING
+		if !up {
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+			allUp = false
+			s.logger.Warnf("Health check failed for %s backend", name)
+		}
+		s.server.SetServingStatus(name, status)
+	}
+	overall := healthpb.HealthCheckResponse_SERVING
+	if !allUp {
+		overall = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	s.server.SetServingStatus("", overall)
+}
@@ -0,0 +1,115 @@
+package health
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// stubChecker reports a fixed availability.
+type stubChecker bool
+
+func (c stubChecker) IsAvailable(ctx context.Context) bool { return bool(c) }
+
+// newHealthClient serves the health service over an in-memory listener and
+// returns a client for it.
+func newHealthClient(t *testing.T, svc *Service) healthpb.HealthClient {
+	t.Helper()
+	lis := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer()
+	svc.Register(srv)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.Dial("bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("dial bufconn: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return healthpb.NewHealthClient(conn)
+}
+
+func checkStatus(t *testing.T, client healthpb.HealthClient, service string) healthpb.HealthCheckResponse_ServingStatus {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+	if err != nil {
+		t.Fatalf("Check(%q): %v", service, err)
+	}
+	return resp.Status
+}
+
+func quietLogger() *logrus.Logger {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	return logger
+}
+
+func TestServiceReportsNotServingBeforeFirstCheck(t *testing.T) {
+	svc := NewService(map[string]Checker{"asr": stubChecker(true)}, time.Hour, quietLogger())
+	client := newHealthClient(t, svc)
+
+	if got := checkStatus(t, client, "asr"); got != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("asr status before first check = %v, want NOT_SERVING", got)
+	}
+	if got := checkStatus(t, client, ""); got != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("overall status before first check = %v, want NOT_SERVING", got)
+	}
+}
+
+func TestRefreshTracksBackendAvailability(t *testing.T) {
+	svc := NewService(map[string]Checker{
+		"asr": stubChecker(true),
+		"tts": stubChecker(true),
+		"llm": stubChecker(false),
+	}, time.Hour, quietLogger())
+	client := newHealthClient(t, svc)
+	svc.refresh()
+
+	if got := checkStatus(t, client, "asr"); got != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("asr status = %v, want SERVING", got)
+	}
+	if got := checkStatus(t, client, "llm"); got != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("llm status = %v, want NOT_SERVING", got)
+	}
+	if got := checkStatus(t, client, ""); got != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("overall status with one backend down = %v, want NOT_SERVING", got)
+	}
+}
+
+func TestOverallServesOnlyWhenAllBackendsDo(t *testing.T) {
+	svc := NewService(map[string]Checker{
+		"asr": stubChecker(true),
+		"tts": stubChecker(true),
+	}, time.Hour, quietLogger())
+	client := newHealthClient(t, svc)
+	svc.refresh()
+
+	if got := checkStatus(t, client, ""); got != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("overall status with all backends up = %v, want SERVING", got)
+	}
+}
+
+func TestStopMarksEverythingNotServing(t *testing.T) {
+	svc := NewService(map[string]Checker{"asr": stubChecker(true)}, time.Hour, quietLogger())
+	client := newHealthClient(t, svc)
+	svc.Start()
+	svc.Stop()
+
+	if got := checkStatus(t, client, "asr"); got != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("asr status after Stop = %v, want NOT_SERVING", got)
+	}
+}
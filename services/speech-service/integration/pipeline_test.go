@@ -0,0 +1,157 @@
+//go:build integration
+
+// Package integration exercises the speech service end to end: the real
+// SpeechHandler behind a real gRPC stream, with every provider replaced by an
+// in-process mock speaking its wire protocol. Run with go test -tags=integration.
+package integration
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/audio"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/config"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/events"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/handler"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/service"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/store"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/testutil"
+	speechv1 "github.com/CurvatureX/ai-tutor-monorepo/shared/proto/speech"
+	"github.com/CurvatureX/ai-tutor-monorepo/shared/speechtest"
+)
+
+const (
+	pipelineTranscript = "how do i order coffee"
+	pipelineReply      = "You could say: one coffee, please!"
+	pipelineAudio      = "synthesized-reply-audio"
+)
+
+// oneSecondWAV builds a 1-second 16 kHz mono WAV loud enough that no
+// preprocessing filter mutes it.
+func oneSecondWAV() []byte {
+	pcm := make([]byte, 2*16000)
+	for i := 0; i+1 < len(pcm); i += 2 {
+		binary.LittleEndian.PutUint16(pcm[i:], uint16(int16(4000)))
+	}
+	return audio.NewConverter().ConvertPCMToWAV(pcm)
+}
+
+// TestFullPipeline drives one spoken turn through a real gRPC stream and
+// asserts the client observes the stage results in pipeline order:
+// processing status, ASR transcript, LLM reply, TTS audio.
+func TestFullPipeline(t *testing.T) {
+	asr := testutil.NewMockASRServer(testutil.MockASRConfig{Transcript: pipelineTranscript, Confidence: 0.93})
+	defer asr.Close()
+	llm := testutil.NewMockLLMServer(testutil.MockLLMConfig{ReplyText: pipelineReply, TokensUsed: 9})
+	defer llm.Close()
+	tts := testutil.NewMockTTSServer(testutil.MockTTSConfig{AudioChunks: [][]byte{[]byte(pipelineAudio)}})
+	defer tts.Close()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	publisher, err := events.NewPublisher(config.EventsConfig{Publisher: "none"}, logger)
+	if err != nil {
+		t.Fatalf("NewPublisher: %v", err)
+	}
+	h := handler.NewSpeechHandler(
+		service.NewASRService(config.ASRConfig{BaseURL: asr.URL(), Cluster: "test"}, logger),
+		service.NewTTSService(config.TTSConfig{BaseURL: tts.URL(), VoiceType: "test_voice", Speed: 1.0}, logger),
+		service.NewLLMService(config.LLMConfig{BaseURL: llm.URL(), Model: "test-model", MaxTokens: 50}, nil, logger),
+		service.NewISEService(config.ISEConfig{}, logger),
+		service.NewAudioService(config.AudioConfig{}, logger),
+		publisher, store.NewSessionRecorder(false, nil, logger), 0, 0, "buffer",
+		handler.ISEModeReferenced, config.SLOConfig{}, logger)
+
+	client := speechtest.NewBufconnClient(t, h)
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+	stream, err := client.ProcessVoiceConversation(ctx)
+	if err != nil {
+		t.Fatalf("open stream: %v", err)
+	}
+
+	// Disable the conversation starter: the mock LLM answers every request
+	// with the same reply, so a starter would be indistinguishable from the
+	// turn's llm_response in the sequence below.
+	if err := stream.Send(&speechv1.VoiceRequest{
+		SessionId: "integration-pipeline",
+		UserId:    "learner-1",
+		RequestType: &speechv1.VoiceRequest_Control{
+			Control: &speechv1.ControlMessage{
+				Action:     speechv1.ControlAction_CONTROL_ACTION_CONFIGURE_SESSION,
+				Parameters: map[string]string{"tutor_starts": "false"},
+			},
+		},
+	}); err != nil {
+		t.Fatalf("send configure: %v", err)
+	}
+
+	if err := stream.Send(&speechv1.VoiceRequest{
+		SessionId: "integration-pipeline",
+		RequestType: &speechv1.VoiceRequest_AudioData{
+			AudioData: &speechv1.AudioChunk{Data: oneSecondWAV(), Format: "wav", IsFinal: true},
+		},
+	}); err != nil {
+		t.Fatalf("send utterance: %v", err)
+	}
+
+	// Collect responses until the TTS audio arrives, recording the order the
+	// stages reported in.
+	var seq []string
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("recv (sequence so far %v): %v", seq, err)
+		}
+		switch r := resp.GetResponseType().(type) {
+		case *speechv1.VoiceResponse_Status:
+			seq = append(seq, "status:"+r.Status.Status)
+		case *speechv1.VoiceResponse_AsrResult:
+			if r.AsrResult.Text != pipelineTranscript {
+				t.Errorf("transcript = %q, want %q", r.AsrResult.Text, pipelineTranscript)
+			}
+			seq = append(seq, "asr_result")
+		case *speechv1.VoiceResponse_LlmResult:
+			if r.LlmResult.Text != pipelineReply {
+				t.Errorf("reply = %q, want %q", r.LlmResult.Text, pipelineReply)
+			}
+			seq = append(seq, "llm_response")
+		case *speechv1.VoiceResponse_TtsResult:
+			if got := string(r.TtsResult.AudioData); got != pipelineAudio {
+				t.Errorf("tts audio = %q, want %q", got, pipelineAudio)
+			}
+			seq = append(seq, "tts_ready")
+		case *speechv1.VoiceResponse_Error:
+			t.Fatalf("pipeline error: %s", r.Error.Message)
+		}
+		if len(seq) > 0 && seq[len(seq)-1] == "tts_ready" {
+			break
+		}
+	}
+
+	// Statuses beyond the two asserted ones are allowed; filter the observed
+	// sequence down to the events the contract cares about before comparing.
+	want := []string{"status:session_started", "status:processing", "asr_result", "llm_response", "tts_ready"}
+	var filtered []string
+	for _, s := range seq {
+		for _, w := range want {
+			if s == w {
+				filtered = append(filtered, s)
+				break
+			}
+		}
+	}
+	if len(filtered) != len(want) {
+		t.Fatalf("stage sequence = %v (full %v), want %v", filtered, seq, want)
+	}
+	for i := range want {
+		if filtered[i] != want[i] {
+			t.Fatalf("stage sequence = %v, want %v", filtered, want)
+		}
+	}
+}
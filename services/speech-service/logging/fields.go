@@ -0,0 +1,23 @@
+// Package logging defines the standard structured log fields used across the
+// speech service so aggregation queries (Kibana, Loki) can filter on them.
+package logging
+
+import "github.com/sirupsen/logrus"
+
+// Standard field names. Always use these constants instead of string
+// literals so field names stay consistent across services.
+const (
+	LogFieldSessionID  = "session_id"
+	LogFieldService    = "service"
+	LogFieldDurationMs = "duration_ms"
+	LogFieldAudioBytes = "audio_bytes"
+	LogFieldScore      = "score"
+	LogFieldTurnID     = "turn_id"
+	LogFieldUserID     = "user_id"
+)
+
+// SessionLogger returns an entry pre-tagged with the session ID. Handlers
+// should derive all per-session log lines from it.
+func SessionLogger(sessionID string, base *logrus.Logger) *logrus.Entry {
+	return base.WithField(LogFieldSessionID, sessionID)
+}
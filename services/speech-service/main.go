@@ -0,0 +1,375 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/reflection"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/config"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/events"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/handler"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/health"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/manager"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/memory"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/service"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/store"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/validation"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/warmup"
+	speechv1 "github.com/CurvatureX/ai-tutor-monorepo/shared/proto/speech"
+	"github.com/CurvatureX/ai-tutor-monorepo/shared/web"
+)
+
+// stageHealthLabel renders a pipeline switch for the health payload.
+func stageHealthLabel(enabled bool) string {
+	if enabled {
+		return "enabled"
+	}
+	return "disabled"
+}
+
+func main() {
+	logger := logrus.New()
+	logger.SetFormatter(&logrus.JSONFormatter{})
+
+	cfg := config.Load()
+	if level, err := logrus.ParseLevel(cfg.LogLevel); err == nil {
+		logger.SetLevel(level)
+	}
+
+	publisher, err := events.NewPublisher(cfg.Events, logger)
+	if err != nil {
+		logger.Fatalf("Failed to create event publisher: %v", err)
+	}
+	defer publisher.Close()
+
+	asrService := service.NewASRService(cfg.ASR, logger)
+	ttsService := service.NewTTSService(cfg.TTS, logger)
+	usageTracker, err := service.NewUsageTracker(cfg.LLM.DailyTokenLimit, cfg.LLM.UsageDir, logger)
+	if err != nil {
+		logger.Fatalf("Failed to create llm usage tracker: %v", err)
+	}
+	llmService := service.NewLLMService(cfg.LLM, usageTracker, logger)
+	iseService := service.NewISEService(cfg.ISE, logger)
+	audioService := service.NewAudioService(cfg.Audio, logger)
+
+	var debugAudioStore store.DebugAudioStore
+	if cfg.Recording.Enabled {
+		debugAudioStore, err = store.NewFileDebugAudioStore(cfg.Recording.Dir)
+		if err != nil {
+			logger.Fatalf("Failed to create debug audio store: %v", err)
+		}
+	}
+	recorder := store.NewSessionRecorder(cfg.Recording.Enabled, debugAudioStore, logger)
+	recorder.StartCleanupRoutine(time.Hour, time.Duration(cfg.Recording.RetentionDays)*24*time.Hour)
+
+	speechHandler := handler.NewSpeechHandler(
+		asrService, ttsService, llmService, iseService, audioService, publisher,
+		recorder, cfg.GRPC.MaxAudioSizeMB*1024*1024, cfg.Session.RetryAudioBudgetMB*1024*1024,
+		cfg.Session.PausedAudioPolicy, handler.ParseISEMode(cfg.ISE.Mode), cfg.SLO, logger)
+	speechHandler.SetPipeline(cfg.Pipeline)
+	if cfg.Audio.WorkerPoolSize > 0 {
+		speechHandler.SetWorkerPool(handler.NewWorkerPool(cfg.Audio.WorkerPoolSize))
+	}
+	speechHandler.SetLaneSchedulers(handler.NewLaneSchedulers(cfg.Scheduler))
+	speechHandler.SetDeterministicSessions(cfg.Session.DeterministicEnabled)
+
+	sessionStore, err := store.NewFileSessionStore(cfg.Session.SnapshotDir, cfg.Session.SnapshotTTL)
+	if err != nil {
+		logger.Fatalf("Failed to create session store: %v", err)
+	}
+	wsManager := manager.NewWebSocketManager(logger, sessionStore)
+	wsManager.SetMaxSessionDuration(cfg.Session.MaxSessionDuration)
+	wsManager.StartCleanupRoutine(time.Minute)
+
+	// One buffer ledger spans both handlers; under ceiling pressure the
+	// WebSocket manager evicts its largest idle sessions.
+	accountant := memory.NewAccountant(cfg.Memory.SessionBudgetBytes, cfg.Memory.ProcessCeilingBytes)
+	accountant.SetEvictor(wsManager.EvictForMemory)
+	wsManager.SetMemoryAccountant(accountant)
+	speechHandler.SetMemoryAccountant(accountant)
+	msgValidator, err := validation.NewMessageValidator()
+	if err != nil {
+		logger.Fatalf("Failed to load message schemas: %v", err)
+	}
+	rateLimiter := handler.NewRateLimiter(cfg.RateLimit)
+
+	// The WebSocket pipeline's stages default to the in-process provider
+	// services; BACKEND_MODE=grpc swaps them for thin clients that proxy to
+	// another speech-service instance over the shared proto.
+	var (
+		wsASR handler.ASRProvider = asrService
+		wsTTS handler.TTSProvider = ttsService
+		wsLLM handler.LLMProvider = llmService
+	)
+	if cfg.Backend.Mode == "grpc" {
+		backendConn, err := grpc.Dial(cfg.Backend.GRPCAddr,
+			grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			logger.Fatalf("Failed to dial backend speech service at %s: %v", cfg.Backend.GRPCAddr, err)
+		}
+		defer backendConn.Close()
+		backendClient := speechv1.NewSpeechServiceClient(backendConn)
+		wsASR = service.NewGRPCASRBackend(backendClient)
+		wsTTS = service.NewGRPCTTSBackend(backendClient)
+		wsLLM = service.NewGRPCLLMBackend(backendClient)
+		logger.Infof("WebSocket pipeline proxying ASR/LLM/TTS to %s", cfg.Backend.GRPCAddr)
+	}
+
+	voiceSelector, err := service.NewVoiceSelector(cfg.TTS, logger)
+	if err != nil {
+		logger.Fatalf("Failed to load TTS voice catalog: %v", err)
+	}
+	wsHandler := handler.NewWebSocketHandler(
+		wsManager, msgValidator, rateLimiter, wsASR, wsTTS, wsLLM, iseService, audioService,
+		voiceSelector, cfg.Session.TeacherToken, logger)
+	wsHandler.SetLLMThrottle(handler.NewLLMStreamThrottle(cfg.LLM))
+	wsHandler.SetPipeline(cfg.Pipeline)
+	wsHandler.SetMemoryAccountant(accountant)
+	wsHandler.SetAudioPreRoll(cfg.Audio.PreRollMs)
+	wsHandler.SetQARecorder(recorder, cfg.Recording.MaxBytesPerSession)
+	wsHandler.SetDeterministicSessions(cfg.Session.DeterministicEnabled)
+	if cfg.Session.TokenSecret != "" {
+		reconnectTokens := manager.NewReconnectTokens(cfg.Session.TokenSecret, cfg.Session.TokenTTL)
+		reconnectTokens.StartPurgeRoutine(5 * time.Minute)
+		wsHandler.SetReconnectTokens(reconnectTokens)
+	}
+	handler.ConfigureUpgrader(cfg.WebSocket)
+
+	// gRPC server for the gateway.
+	serverOpts := []grpc.ServerOption{
+		grpc.MaxRecvMsgSize(cfg.GRPC.MaxRecvMsgSizeMB * 1024 * 1024),
+		grpc.MaxSendMsgSize(cfg.GRPC.MaxSendMsgSizeMB * 1024 * 1024),
+		grpc.MaxConcurrentStreams(uint32(cfg.GRPC.MaxConcurrentStreams)),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             cfg.GRPC.KeepaliveMinTime,
+			PermitWithoutStream: true,
+		}),
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:    cfg.GRPC.KeepaliveTime,
+			Timeout: cfg.GRPC.KeepaliveTimeout,
+		}),
+		grpc.ChainUnaryInterceptor(handler.RecoveryUnaryInterceptor(logger)),
+		grpc.ChainStreamInterceptor(
+			handler.RecoveryStreamInterceptor(logger),
+			handler.GRPCMetadataExtractor(),
+		),
+	}
+	if cfg.GRPC.TLSCertFile != "" && cfg.GRPC.TLSKeyFile != "" {
+		creds, err := credentials.NewServerTLSFromFile(cfg.GRPC.TLSCertFile, cfg.GRPC.TLSKeyFile)
+		if err != nil {
+			logger.Fatalf("Failed to load gRPC TLS credentials: %v", err)
+		}
+		serverOpts = append(serverOpts, grpc.Creds(creds))
+	}
+	grpcServer := grpc.NewServer(serverOpts...)
+	speechv1.RegisterSpeechServiceServer(grpcServer, speechHandler)
+	if cfg.GRPC.EnableReflection {
+		reflection.Register(grpcServer)
+	}
+	lis, err := net.Listen("tcp", ":"+cfg.GRPCPort)
+	if err != nil {
+		logger.Fatalf("Failed to listen on gRPC port %s: %v", cfg.GRPCPort, err)
+	}
+	go func() {
+		logger.Infof("Speech service gRPC listening on :%s", cfg.GRPCPort)
+		if err := grpcServer.Serve(lis); err != nil {
+			logger.Fatalf("gRPC server failed: %v", err)
+		}
+	}()
+
+	// Separate gRPC server for the standard health service, so Kubernetes
+	// probes keep answering even when the main server is saturated.
+	// Disabled stages are left out of readiness checking entirely: a
+	// deployment without ISE credentials should not fail probes over a stage
+	// it never runs.
+	checkers := map[string]health.Checker{}
+	if cfg.Pipeline.ASR {
+		checkers["asr"] = asrService
+	}
+	if cfg.Pipeline.TTS {
+		checkers["tts"] = ttsService
+	}
+	if cfg.Pipeline.LLM {
+		checkers["llm"] = llmService
+	}
+	healthService := health.NewService(checkers, 30*time.Second, logger)
+	healthGRPCServer := grpc.NewServer()
+	healthService.Register(healthGRPCServer)
+	healthLis, err := net.Listen("tcp", ":"+cfg.GRPCHealthPort)
+	if err != nil {
+		logger.Fatalf("Failed to listen on gRPC health port %s: %v", cfg.GRPCHealthPort, err)
+	}
+	go func() {
+		logger.Infof("Speech service gRPC health listening on :%s", cfg.GRPCHealthPort)
+		if err := healthGRPCServer.Serve(healthLis); err != nil {
+			logger.Fatalf("gRPC health server failed: %v", err)
+		}
+	}()
+	// Optional provider warm-up before the first readiness refresh, so the
+	// first learner turn after a deploy doesn't hit every cold connection at
+	// once. Readiness stays NOT_SERVING until the warm-up has been attempted.
+	var warmupDetails map[string]string
+	if cfg.Warmup.Enabled {
+		runner := warmup.NewRunner(cfg.Warmup.Timeout, cfg.Warmup.Required, logger)
+		if cfg.Pipeline.ASR {
+			runner.Add("asr", func(ctx context.Context) error {
+				if !asrService.IsAvailable(ctx) {
+					return fmt.Errorf("asr endpoint handshake failed")
+				}
+				return nil
+			})
+		}
+		if cfg.Pipeline.ISE {
+			runner.Add("ise", func(ctx context.Context) error {
+				if !iseService.IsAvailable(ctx) {
+					return fmt.Errorf("ise endpoint handshake failed")
+				}
+				return nil
+			})
+		}
+		if cfg.Pipeline.LLM {
+			runner.Add("llm", func(ctx context.Context) error {
+				return llmService.Warmup()
+			})
+		}
+		if cfg.Pipeline.TTS {
+			runner.Add("tts", func(ctx context.Context) error {
+				_, err := ttsService.SynthesizeSpeech(&model.TTSRequest{Text: "Hello"})
+				return err
+			})
+		}
+		results, err := runner.Run()
+		warmupDetails = warmup.Details(results)
+		if err != nil {
+			logger.Fatalf("Provider warm-up failed: %v", err)
+		}
+	}
+	healthService.Start()
+
+	// HTTP server for direct WebSocket clients and health checks.
+	gin.SetMode(gin.ReleaseMode)
+	router := gin.New()
+	router.Use(gin.Recovery())
+	router.GET("/health", func(c *gin.Context) {
+		payload := gin.H{"status": "ok", "active_sessions": wsManager.GetActiveSessions()}
+		if warmupDetails != nil {
+			payload["warmup"] = warmupDetails
+		}
+		payload["pipeline"] = gin.H{
+			"asr":         stageHealthLabel(cfg.Pipeline.ASR),
+			"llm":         stageHealthLabel(cfg.Pipeline.LLM),
+			"tts":         stageHealthLabel(cfg.Pipeline.TTS),
+			"ise":         stageHealthLabel(cfg.Pipeline.ISE),
+			"corrections": stageHealthLabel(cfg.Pipeline.Corrections),
+		}
+		c.JSON(http.StatusOK, payload)
+	})
+	router.GET("/health/ise", func(c *gin.Context) {
+		if !cfg.Pipeline.ISE {
+			c.JSON(http.StatusOK, gin.H{"status": "disabled"})
+			return
+		}
+		if !iseService.IsAvailable(c.Request.Context()) {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"status": "unavailable"})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	})
+	router.GET("/ws", func(c *gin.Context) {
+		wsHandler.HandleWebSocket(c.Writer, c.Request)
+	})
+	router.GET("/llm/usage", func(c *gin.Context) {
+		c.JSON(http.StatusOK, usageTracker.Usage())
+	})
+	router.GET("/ise/queue-stats", func(c *gin.Context) {
+		sched := iseService.Scheduler()
+		if sched == nil {
+			c.JSON(http.StatusOK, gin.H{"enabled": false})
+			return
+		}
+		c.JSON(http.StatusOK, sched.QueueStats())
+	})
+	router.GET("/practice-modes", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"modes": service.PracticeModes()})
+	})
+	router.GET("/sessions/:id/report", speechHandler.ServeSessionReport)
+	router.GET("/sessions/:id/vocabulary", speechHandler.ServeSessionVocabulary)
+	router.GET("/sessions/:id/history", speechHandler.ServeSessionHistory)
+	router.POST("/sessions/:id/history", speechHandler.ImportSessionHistory)
+	if cfg.Recording.Enabled {
+		router.GET("/admin/recordings/:sessionID", func(c *gin.Context) {
+			files, err := debugAudioStore.List(c.Param("sessionID"))
+			if err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+				return
+			}
+			c.JSON(http.StatusOK, gin.H{"session_id": c.Param("sessionID"), "files": files})
+		})
+		router.GET("/admin/recordings/:sessionID/:file", func(c *gin.Context) {
+			path, err := debugAudioStore.FilePath(c.Param("sessionID"), c.Param("file"))
+			if err != nil {
+				c.JSON(http.StatusNotFound, gin.H{"error": "recording not found"})
+				return
+			}
+			c.File(path)
+		})
+	}
+
+	if cfg.AdminToken != "" {
+		diag := router.Group("/admin/debug", handler.AdminTokenAuth(cfg.AdminToken))
+		handler.RegisterDiagRoutes(diag, func() gin.H {
+			return gin.H{
+				"active_sessions":     speechHandler.ActiveSessions() + wsManager.GetActiveSessions(),
+				"pipeline_goroutines": speechHandler.PipelineGoroutines(),
+				"memory":              accountant.Snapshot(),
+			}
+		})
+	}
+
+	// The practice page is embedded in the binary; unmatched paths fall
+	// through to it so the service is usable from a bare browser.
+	site, err := web.NewStaticSite(embeddedStatic(), cfg.StaticDir,
+		"/health", "/ws", "/llm/", "/practice-modes", "/sessions/", "/admin/")
+	if err != nil {
+		logger.Fatalf("Failed to load static frontend: %v", err)
+	}
+	router.NoRoute(gin.WrapH(site))
+
+	httpServer := &http.Server{Addr: ":" + cfg.HTTPPort, Handler: router}
+	go func() {
+		logger.Infof("Speech service HTTP listening on :%s", cfg.HTTPPort)
+		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logger.Fatalf("HTTP server failed: %v", err)
+		}
+	}()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+	logger.Info("Shutting down speech service")
+	healthService.Stop()
+	healthGRPCServer.GracefulStop()
+	grpcServer.GracefulStop()
+	// Tell direct WebSocket clients this is a shutdown, not a failure, before
+	// the listener goes away.
+	wsManager.Shutdown()
+	httpServer.Close()
+	if sched := iseService.Scheduler(); sched != nil {
+		sched.Close()
+	}
+	iseService.FlushProtocolLog()
+}
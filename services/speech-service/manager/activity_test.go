@@ -0,0 +1,81 @@
+package manager
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestGetSessionRacesCleanup hammers the read-locked activity refresh against
+// the cleanup sweep; run with -race this pins the fix for GetSession mutating
+// LastActivity under only the read lock.
+func TestGetSessionRacesCleanup(t *testing.T) {
+	m := quietManager()
+	m.AddConnection("race-session", "user-1", nil)
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+					m.GetSession("race-session")
+				}
+			}
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+				m.CleanupInactiveSessions()
+				m.UpdateSession("race-session", func(s *WebSocketSession) { s.TurnCount++ })
+			}
+		}
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+	close(stop)
+	wg.Wait()
+
+	if _, ok := m.GetSession("race-session"); !ok {
+		t.Fatal("session disappeared; only the inactivity sweep may remove it")
+	}
+}
+
+// TestTouchKeepsSessionAliveThroughCleanup verifies the refresh still works
+// now that it is an atomic store: a touched session survives a sweep that
+// removes an idle one.
+func TestTouchKeepsSessionAliveThroughCleanup(t *testing.T) {
+	m := quietManager()
+	m.maxInactivity = 50 * time.Millisecond
+	m.inactivityWarning = 10 * time.Millisecond
+
+	active := m.AddConnection("active", "user-1", nil)
+	m.AddConnection("idle", "user-2", nil)
+
+	// Two sweeps: the first warns the idle session, the second removes it
+	// once the grace window passes without activity.
+	time.Sleep(60 * time.Millisecond)
+	active.Touch()
+	m.CleanupInactiveSessions()
+	time.Sleep(20 * time.Millisecond)
+	active.Touch()
+	m.CleanupInactiveSessions()
+
+	if _, ok := m.GetSession("active"); !ok {
+		t.Error("recently touched session was swept")
+	}
+	if _, ok := m.GetSession("idle"); ok {
+		t.Error("idle session survived the sweep")
+	}
+}
@@ -0,0 +1,123 @@
+package manager
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+)
+
+// expectClose reads (and discards) frames until the connection is closed and
+// returns the close error, so tests can assert the code and reason a client
+// actually received.
+func expectClose(t *testing.T, conn *websocket.Conn) *websocket.CloseError {
+	t.Helper()
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	for {
+		_, _, err := conn.ReadMessage()
+		if err == nil {
+			continue
+		}
+		var closeErr *websocket.CloseError
+		if !errors.As(err, &closeErr) {
+			t.Fatalf("read error = %v, want a close error", err)
+		}
+		return closeErr
+	}
+}
+
+func TestSupersededConnectionCloseCode(t *testing.T) {
+	m := quietManager()
+	firstSrv, firstClient := newWSPair(t)
+	m.AddConnection("takeover", "user-1", firstSrv)
+
+	secondSrv, _ := newWSPair(t)
+	m.AddConnection("takeover", "user-1", secondSrv)
+
+	closeErr := expectClose(t, firstClient)
+	if closeErr.Code != model.CloseSuperseded {
+		t.Errorf("close code = %d, want %d", closeErr.Code, model.CloseSuperseded)
+	}
+	if closeErr.Text == "" {
+		t.Error("superseded close carried no reason")
+	}
+}
+
+func TestRemoveConnectionCloseCode(t *testing.T) {
+	m := quietManager()
+	learnerSrv, learnerClient := newWSPair(t)
+	watcherSrv, watcherClient := newWSPair(t)
+	m.AddConnection("ending", "user-1", learnerSrv)
+	if _, err := m.AddSecondaryConnection("ending", "watcher-1", watcherSrv, RoleObserver); err != nil {
+		t.Fatalf("AddSecondaryConnection: %v", err)
+	}
+
+	m.RemoveConnection("ending")
+
+	if got := expectClose(t, learnerClient).Code; got != model.CloseNormalEnd {
+		t.Errorf("learner close code = %d, want %d", got, model.CloseNormalEnd)
+	}
+	// Watchers get the same code, so they see why the session ended too.
+	if got := expectClose(t, watcherClient).Code; got != model.CloseNormalEnd {
+		t.Errorf("watcher close code = %d, want %d", got, model.CloseNormalEnd)
+	}
+}
+
+func TestInactivityRemovalCloseCode(t *testing.T) {
+	m := quietManager()
+	srv, client := newWSPair(t)
+	session := m.AddConnection("idle-close", "user-1", srv)
+
+	// Jump straight to the end of an already-warned grace window, so one
+	// sweep removes the session.
+	base := time.Now()
+	session.lastActivity.Store(base.Add(-time.Hour).UnixNano())
+	session.inactivityWarnedAt = base.Add(-m.inactivityWarning)
+	m.now = func() time.Time { return base }
+	m.CleanupInactiveSessions()
+
+	closeErr := expectClose(t, client)
+	if closeErr.Code != model.CloseIdleTimeout {
+		t.Errorf("close code = %d, want %d", closeErr.Code, model.CloseIdleTimeout)
+	}
+}
+
+func TestTimeLimitCloseCode(t *testing.T) {
+	m := quietManager()
+	m.maxSessionDuration = time.Minute
+	srv, client := newWSPair(t)
+	session := m.AddConnection("timed-out", "user-1", srv)
+
+	session.CreatedAt = time.Now().Add(-2 * time.Minute)
+	m.CleanupInactiveSessions()
+
+	// The duration limit is an orderly end of a completed session, not a
+	// timeout the client should retry its way out of.
+	if got := expectClose(t, client).Code; got != model.CloseNormalEnd {
+		t.Errorf("close code = %d, want %d", got, model.CloseNormalEnd)
+	}
+}
+
+func TestShutdownCloseCode(t *testing.T) {
+	m := quietManager()
+	srv, client := newWSPair(t)
+	m.AddConnection("shutdown", "user-1", srv)
+
+	m.Shutdown()
+
+	if m.GetActiveSessions() != 0 {
+		t.Error("sessions survived the shutdown")
+	}
+	// The session_ended message precedes the close frame, then the close
+	// code confirms this was a shutdown.
+	if ended := readMessage(t, client); ended.Data["reason"] != "server_shutdown" {
+		t.Errorf("reason = %v, want server_shutdown", ended.Data["reason"])
+	}
+	closeErr := expectClose(t, client)
+	if closeErr.Code != model.CloseServerShutdown {
+		t.Errorf("close code = %d, want %d", closeErr.Code, model.CloseServerShutdown)
+	}
+}
@@ -0,0 +1,73 @@
+package manager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+)
+
+// readMessage reads one JSON frame from the client side of a pair.
+func readMessage(t *testing.T, client *websocket.Conn) *model.WebSocketMessage {
+	t.Helper()
+	client.SetReadDeadline(time.Now().Add(2 * time.Second))
+	var msg model.WebSocketMessage
+	if err := client.ReadJSON(&msg); err != nil {
+		t.Fatalf("read message: %v", err)
+	}
+	return &msg
+}
+
+func TestSessionDurationLimitWarnsThenCloses(t *testing.T) {
+	m := quietManager()
+	m.maxSessionDuration = 200 * time.Millisecond
+	m.timeLimitWarning = 100 * time.Millisecond
+
+	srv, client := newWSPair(t)
+	session := m.AddConnection("timed-session", "user-1", srv)
+
+	// Inside the warning window but before the limit: the warning goes out
+	// once and the session stays up.
+	time.Sleep(120 * time.Millisecond)
+	m.CleanupInactiveSessions()
+	m.CleanupInactiveSessions()
+
+	warning := readMessage(t, client)
+	if warning.Type != model.MessageTypeSessionTimeLimitWarning {
+		t.Fatalf("message type = %q, want %q", warning.Type, model.MessageTypeSessionTimeLimitWarning)
+	}
+	if _, ok := warning.Data["remaining_seconds"]; !ok {
+		t.Error("warning carries no remaining_seconds")
+	}
+	if _, ok := m.GetSession("timed-session"); !ok {
+		t.Fatal("session closed during the warning window")
+	}
+
+	// Past the limit the session closes even though it was just active.
+	time.Sleep(100 * time.Millisecond)
+	session.Touch()
+	m.CleanupInactiveSessions()
+
+	ended := readMessage(t, client)
+	if ended.Type != model.MessageTypeSessionEnded {
+		t.Fatalf("message type = %q, want %q (got data %v)", ended.Type, model.MessageTypeSessionEnded, ended.Data)
+	}
+	if reason := ended.Data["reason"]; reason != "time_limit_reached" {
+		t.Errorf("reason = %v, want time_limit_reached", reason)
+	}
+	if _, ok := m.GetSession("timed-session"); ok {
+		t.Error("session survived past the duration limit")
+	}
+}
+
+func TestSessionDurationUnlimitedByDefault(t *testing.T) {
+	m := quietManager()
+	m.AddConnection("open-ended", "user-1", nil)
+
+	m.CleanupInactiveSessions()
+	if _, ok := m.GetSession("open-ended"); !ok {
+		t.Error("session swept with no duration limit configured")
+	}
+}
@@ -0,0 +1,52 @@
+package manager_test
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/gorilla/websocket"
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/manager"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+)
+
+func ExampleWebSocketManager_SendMessage() {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	sessions := manager.NewWebSocketManager(logger, nil)
+
+	// The HTTP handler registers each upgraded connection; after that any
+	// goroutine can reach the client by session ID.
+	var upgrader websocket.Upgrader
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		sessions.AddConnection("demo-session", "demo-user", conn)
+		sessions.SendMessage("demo-session", &model.WebSocketMessage{
+			Type: model.MessageTypeStatus,
+			Data: map[string]interface{}{"status": "connected"},
+		})
+	}))
+	defer server.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	if err != nil {
+		fmt.Println("dial:", err)
+		return
+	}
+	defer conn.Close()
+
+	var msg model.WebSocketMessage
+	if err := conn.ReadJSON(&msg); err != nil {
+		fmt.Println("read:", err)
+		return
+	}
+	fmt.Println(msg.Type, msg.Data["status"])
+	// Output: status connected
+}
@@ -0,0 +1,166 @@
+package manager
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+)
+
+// newWSPair upgrades a loopback WebSocket and returns both ends, so tests can
+// register the server side with the manager and read frames on the client.
+func newWSPair(t *testing.T) (server *websocket.Conn, client *websocket.Conn) {
+	t.Helper()
+
+	var upgrader websocket.Upgrader
+	serverConns := make(chan *websocket.Conn, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Errorf("upgrade: %v", err)
+			return
+		}
+		serverConns <- conn
+	}))
+	t.Cleanup(srv.Close)
+
+	client, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+
+	select {
+	case server = <-serverConns:
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for server-side connection")
+	}
+	t.Cleanup(func() { server.Close() })
+	return server, client
+}
+
+func quietManager() *WebSocketManager {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	return NewWebSocketManager(logger, nil)
+}
+
+func TestMessagesFanOutToSecondaryConnections(t *testing.T) {
+	m := quietManager()
+	learnerSrv, learnerClient := newWSPair(t)
+	teacherSrv, teacherClient := newWSPair(t)
+
+	m.AddConnection("sess-1", "user-1", learnerSrv)
+	if _, err := m.AddSecondaryConnection("sess-1", "teacher-1", teacherSrv, RoleTeacher); err != nil {
+		t.Fatalf("attach teacher: %v", err)
+	}
+
+	if err := m.SendMessage("sess-1", &model.WebSocketMessage{
+		Type: model.MessageTypeStatus,
+		Data: map[string]interface{}{"status": "recording_started"},
+	}); err != nil {
+		t.Fatalf("send message: %v", err)
+	}
+	for name, client := range map[string]*websocket.Conn{"learner": learnerClient, "teacher": teacherClient} {
+		client.SetReadDeadline(time.Now().Add(2 * time.Second))
+		var msg model.WebSocketMessage
+		if err := client.ReadJSON(&msg); err != nil {
+			t.Fatalf("%s read: %v", name, err)
+		}
+		if msg.Type != model.MessageTypeStatus || msg.Data["status"] != "recording_started" {
+			t.Errorf("%s got %s/%v, want the status frame", name, msg.Type, msg.Data)
+		}
+	}
+
+	if err := m.SendBinaryMessage("sess-1", []byte("tts-audio")); err != nil {
+		t.Fatalf("send binary: %v", err)
+	}
+	for name, client := range map[string]*websocket.Conn{"learner": learnerClient, "teacher": teacherClient} {
+		client.SetReadDeadline(time.Now().Add(2 * time.Second))
+		msgType, data, err := client.ReadMessage()
+		if err != nil {
+			t.Fatalf("%s read binary: %v", name, err)
+		}
+		if msgType != websocket.BinaryMessage || string(data) != "tts-audio" {
+			t.Errorf("%s got type %d payload %q", name, msgType, data)
+		}
+	}
+
+	// Watchers do not count as active sessions.
+	if got := m.GetActiveSessions(); got != 1 {
+		t.Errorf("GetActiveSessions() = %d, want 1", got)
+	}
+}
+
+func TestAddSecondaryConnectionValidation(t *testing.T) {
+	m := quietManager()
+	srv, _ := newWSPair(t)
+
+	if _, err := m.AddSecondaryConnection("missing", "t-1", srv, RoleTeacher); err == nil {
+		t.Error("expected error attaching to unknown session")
+	}
+
+	m.AddConnection("sess-1", "user-1", nil)
+	if _, err := m.AddSecondaryConnection("sess-1", "t-1", srv, RoleLearner); err == nil {
+		t.Error("expected error attaching a learner as a secondary connection")
+	}
+}
+
+func TestRemoveConnectionClosesSecondaries(t *testing.T) {
+	m := quietManager()
+	learnerSrv, _ := newWSPair(t)
+	teacherSrv, teacherClient := newWSPair(t)
+
+	m.AddConnection("sess-1", "user-1", learnerSrv)
+	if _, err := m.AddSecondaryConnection("sess-1", "teacher-1", teacherSrv, RoleObserver); err != nil {
+		t.Fatalf("attach observer: %v", err)
+	}
+	m.RemoveConnection("sess-1")
+
+	teacherClient.SetReadDeadline(time.Now().Add(2 * time.Second))
+	if _, _, err := teacherClient.ReadMessage(); err == nil {
+		t.Error("observer connection still open after session removal")
+	}
+}
+
+func TestFanOutDropsDeadSecondary(t *testing.T) {
+	m := quietManager()
+	learnerSrv, learnerClient := newWSPair(t)
+	teacherSrv, teacherClient := newWSPair(t)
+
+	session := m.AddConnection("sess-1", "user-1", learnerSrv)
+	if _, err := m.AddSecondaryConnection("sess-1", "teacher-1", teacherSrv, RoleTeacher); err != nil {
+		t.Fatalf("attach teacher: %v", err)
+	}
+
+	// Kill the teacher's connection; the next fan-out should prune it while
+	// the learner still receives the frame.
+	teacherSrv.Close()
+	teacherClient.Close()
+	if err := m.SendMessage("sess-1", &model.WebSocketMessage{
+		Type: model.MessageTypeStatus,
+		Data: map[string]interface{}{"status": "still_here"},
+	}); err != nil {
+		t.Fatalf("send message: %v", err)
+	}
+
+	learnerClient.SetReadDeadline(time.Now().Add(2 * time.Second))
+	var msg model.WebSocketMessage
+	if err := learnerClient.ReadJSON(&msg); err != nil {
+		t.Fatalf("learner read: %v", err)
+	}
+
+	session.secondaryMu.Lock()
+	remaining := len(session.secondaries)
+	session.secondaryMu.Unlock()
+	if remaining != 0 {
+		t.Errorf("dead secondary not pruned: %d watchers remain", remaining)
+	}
+}
@@ -0,0 +1,96 @@
+package manager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+)
+
+// The inactivity flows are driven with a fake sweep clock (m.now) and the
+// session's activity pinned to the clock's base, so no test sleeps through a
+// real grace window.
+
+func TestInactivityWarnThenExpire(t *testing.T) {
+	m := quietManager()
+	srv, client := newWSPair(t)
+	session := m.AddConnection("idle-session", "user-1", srv)
+
+	base := time.Now()
+	session.lastActivity.Store(base.UnixNano())
+	clock := base
+	m.now = func() time.Time { return clock }
+
+	// 26 of 30 idle minutes: inside the warning window. The warning goes
+	// out once, and the session stays up.
+	clock = base.Add(26 * time.Minute)
+	m.CleanupInactiveSessions()
+	m.CleanupInactiveSessions()
+
+	warning := readMessage(t, client)
+	if warning.Type != model.MessageTypeSessionExpiring {
+		t.Fatalf("message type = %q, want %q", warning.Type, model.MessageTypeSessionExpiring)
+	}
+	if remaining, ok := warning.Data["remaining_seconds"].(float64); !ok || remaining <= 0 {
+		t.Errorf("remaining_seconds = %v, want a positive number", warning.Data["remaining_seconds"])
+	}
+	if _, ok := m.GetSession("idle-session"); !ok {
+		t.Fatal("session removed during the grace window")
+	}
+	// GetSession refreshed the activity clock; re-pin it so the session
+	// stays idle for the expiry step.
+	session.lastActivity.Store(base.UnixNano())
+
+	// Grace window over with no activity: the session ends.
+	clock = base.Add(32 * time.Minute)
+	m.CleanupInactiveSessions()
+
+	ended := readMessage(t, client)
+	if ended.Type != model.MessageTypeSessionEnded {
+		t.Fatalf("message type = %q, want %q", ended.Type, model.MessageTypeSessionEnded)
+	}
+	if reason := ended.Data["reason"]; reason != "inactivity_timeout" {
+		t.Errorf("reason = %v, want inactivity_timeout", reason)
+	}
+	if m.GetActiveSessions() != 0 {
+		t.Error("session survived past the grace window")
+	}
+}
+
+func TestInactivityWarnThenRescue(t *testing.T) {
+	m := quietManager()
+	srv, client := newWSPair(t)
+	session := m.AddConnection("rescued-session", "user-1", srv)
+
+	base := time.Now()
+	session.lastActivity.Store(base.UnixNano())
+	clock := base
+	m.now = func() time.Time { return clock }
+
+	clock = base.Add(26 * time.Minute)
+	m.CleanupInactiveSessions()
+	if warning := readMessage(t, client); warning.Type != model.MessageTypeSessionExpiring {
+		t.Fatalf("message type = %q, want %q", warning.Type, model.MessageTypeSessionExpiring)
+	}
+
+	// The learner speaks up inside the grace window: the pending warning is
+	// forgotten and the grace clock starts over.
+	session.lastActivity.Store(base.Add(27 * time.Minute).UnixNano())
+	clock = base.Add(32 * time.Minute)
+	m.CleanupInactiveSessions()
+	if _, ok := m.GetSession("rescued-session"); !ok {
+		t.Fatal("session removed despite activity inside the grace window")
+	}
+	session.lastActivity.Store(base.Add(27 * time.Minute).UnixNano())
+
+	// Idle long enough again: a fresh warning goes out rather than an
+	// immediate removal.
+	clock = base.Add(54 * time.Minute)
+	m.CleanupInactiveSessions()
+	if warning := readMessage(t, client); warning.Type != model.MessageTypeSessionExpiring {
+		t.Fatalf("second message type = %q, want %q", warning.Type, model.MessageTypeSessionExpiring)
+	}
+	if _, ok := m.GetSession("rescued-session"); !ok {
+		t.Error("session removed instead of being re-warned")
+	}
+}
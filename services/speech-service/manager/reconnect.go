@@ -0,0 +1,155 @@
+package manager
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+)
+
+// ReconnectionToken authorizes resuming a session after a dropped connection,
+// without trusting the client-supplied session ID alone. StateChecksum pins
+// the conversation history the token was issued against, so a resume can
+// detect state drift.
+type ReconnectionToken struct {
+	Token         string
+	SessionID     string
+	ExpiresAt     time.Time
+	StateChecksum string
+}
+
+// ReconnectTokens issues and validates HMAC-SHA256 signed reconnection
+// tokens. Tokens are single-use: a successful validation consumes the token,
+// and the resumed connection is issued a fresh one.
+type ReconnectTokens struct {
+	secret []byte
+	ttl    time.Duration
+
+	mu     sync.Mutex
+	issued map[string]ReconnectionToken
+
+	// now overrides the clock in tests; nil means time.Now.
+	now func() time.Time
+}
+
+// NewReconnectTokens creates the token issuer. secret signs every token; ttl
+// is how long a dropped client has to resume.
+func NewReconnectTokens(secret string, ttl time.Duration) *ReconnectTokens {
+	return &ReconnectTokens{
+		secret: []byte(secret),
+		ttl:    ttl,
+		issued: make(map[string]ReconnectionToken),
+	}
+}
+
+func (r *ReconnectTokens) clock() time.Time {
+	if r.now != nil {
+		return r.now()
+	}
+	return time.Now()
+}
+
+// Issue creates a signed token for the session, bound to the current state
+// of its conversation history. A nonce keeps every token unique: without it,
+// reissuing within the same second for unchanged history would reproduce a
+// consumed token byte for byte and revive it.
+func (r *ReconnectTokens) Issue(sessionID string, history *model.ConversationHistory) ReconnectionToken {
+	expires := r.clock().Add(r.ttl)
+	checksum := HistoryChecksum(history)
+	payload := base64.RawURLEncoding.EncodeToString(
+		[]byte(fmt.Sprintf("%s|%d|%s|%s", sessionID, expires.Unix(), checksum, uuid.NewString())))
+	token := ReconnectionToken{
+		Token:         payload + "." + r.sign(payload),
+		SessionID:     sessionID,
+		ExpiresAt:     expires,
+		StateChecksum: checksum,
+	}
+	r.mu.Lock()
+	r.issued[token.Token] = token
+	r.mu.Unlock()
+	return token
+}
+
+// Validate checks the token's signature and expiry and consumes it. The
+// returned token carries the session ID and state checksum to resume with.
+func (r *ReconnectTokens) Validate(token string) (ReconnectionToken, error) {
+	payload, sig, ok := strings.Cut(token, ".")
+	if !ok || !hmac.Equal([]byte(r.sign(payload)), []byte(sig)) {
+		return ReconnectionToken{}, fmt.Errorf("invalid reconnect token signature")
+	}
+	r.mu.Lock()
+	issued, known := r.issued[token]
+	delete(r.issued, token)
+	r.mu.Unlock()
+	if !known {
+		return ReconnectionToken{}, fmt.Errorf("unknown or already used reconnect token")
+	}
+	if r.clock().After(issued.ExpiresAt) {
+		return ReconnectionToken{}, fmt.Errorf("reconnect token expired")
+	}
+	return issued, nil
+}
+
+// sign returns the hex HMAC-SHA256 of the payload.
+func (r *ReconnectTokens) sign(payload string) string {
+	mac := hmac.New(sha256.New, r.secret)
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// purgeExpired drops tokens past their expiry and returns how many.
+func (r *ReconnectTokens) purgeExpired() int {
+	now := r.clock()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	purged := 0
+	for key, tok := range r.issued {
+		if now.After(tok.ExpiresAt) {
+			delete(r.issued, key)
+			purged++
+		}
+	}
+	return purged
+}
+
+// StartPurgeRoutine drops expired tokens on the given interval, so dropped
+// clients that never resume don't accumulate entries.
+func (r *ReconnectTokens) StartPurgeRoutine(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			r.purgeExpired()
+		}
+	}()
+}
+
+// TTL returns the token lifetime, for reporting in the session_token message.
+func (r *ReconnectTokens) TTL() time.Duration { return r.ttl }
+
+// HistoryChecksum hashes the conversation history so a resumed session can
+// report whether its restored state matches what the client last saw.
+func HistoryChecksum(history *model.ConversationHistory) string {
+	if history == nil {
+		return ""
+	}
+	messages := history.Snapshot()
+	if len(messages) == 0 {
+		return ""
+	}
+	raw, err := json.Marshal(messages)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,80 @@
+package manager
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+)
+
+func TestReconnectTokenRoundTrip(t *testing.T) {
+	r := NewReconnectTokens("secret", 2*time.Minute)
+	history := &model.ConversationHistory{}
+	history.Append("user", "hello")
+
+	issued := r.Issue("session-1", history)
+	if issued.SessionID != "session-1" {
+		t.Errorf("SessionID = %q", issued.SessionID)
+	}
+	if issued.StateChecksum != HistoryChecksum(history) {
+		t.Error("StateChecksum does not pin the issued history")
+	}
+
+	got, err := r.Validate(issued.Token)
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if got.SessionID != "session-1" || got.StateChecksum != issued.StateChecksum {
+		t.Errorf("validated token = %+v, want the issued one", got)
+	}
+
+	// Tokens are single-use: the resumed connection gets a fresh one.
+	if _, err := r.Validate(issued.Token); err == nil {
+		t.Error("second validation of a consumed token succeeded")
+	}
+}
+
+func TestReconnectTokenRejectsTampering(t *testing.T) {
+	r := NewReconnectTokens("secret", 2*time.Minute)
+	issued := r.Issue("session-1", nil)
+
+	payload, _, _ := strings.Cut(issued.Token, ".")
+	if _, err := r.Validate(payload + ".deadbeef"); err == nil {
+		t.Error("token with a forged signature validated")
+	}
+
+	other := NewReconnectTokens("other-secret", 2*time.Minute)
+	if _, err := other.Validate(issued.Token); err == nil {
+		t.Error("token signed with a different secret validated")
+	}
+}
+
+func TestReconnectTokenExpiry(t *testing.T) {
+	r := NewReconnectTokens("secret", 2*time.Minute)
+	current := time.Now()
+	r.now = func() time.Time { return current }
+
+	issued := r.Issue("session-1", nil)
+	current = current.Add(3 * time.Minute)
+	if _, err := r.Validate(issued.Token); err == nil {
+		t.Error("expired token validated")
+	}
+}
+
+func TestReconnectTokenPurge(t *testing.T) {
+	r := NewReconnectTokens("secret", 2*time.Minute)
+	current := time.Now()
+	r.now = func() time.Time { return current }
+
+	r.Issue("expired", nil)
+	current = current.Add(3 * time.Minute)
+	kept := r.Issue("fresh", nil)
+
+	if purged := r.purgeExpired(); purged != 1 {
+		t.Errorf("purged %d tokens, want 1", purged)
+	}
+	if _, err := r.Validate(kept.Token); err != nil {
+		t.Errorf("fresh token purged: %v", err)
+	}
+}
@@ -0,0 +1,676 @@
+// Package manager tracks direct WebSocket client sessions for the
+// speech-service's HTTP server: registration, snapshot persistence across
+// reconnects, serialized writes, and inactivity cleanup.
+package manager
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/memory"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/ring"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/store"
+)
+
+// memoryEvictions counts sessions removed because the process memory ceiling
+// was crossed.
+var memoryEvictions = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "session_memory_evictions_total",
+	Help: "Sessions evicted under process-wide memory pressure.",
+})
+
+// memoryEvictionIdleAfter is how long a session must have been quiet before
+// memory pressure may evict it; recently active sessions are spared.
+const memoryEvictionIdleAfter = time.Minute
+
+// SessionRole distinguishes the learner driving a session from read-only
+// participants watching it.
+type SessionRole int
+
+const (
+	RoleLearner SessionRole = iota
+	RoleTeacher
+	RoleObserver
+)
+
+func (r SessionRole) String() string {
+	switch r {
+	case RoleTeacher:
+		return "teacher"
+	case RoleObserver:
+		return "observer"
+	}
+	return "learner"
+}
+
+// CanSendAudio reports whether the role may drive the pipeline; teachers and
+// observers are read-only.
+func (r SessionRole) CanSendAudio() bool { return r == RoleLearner }
+
+// secondaryConn is a read-only connection attached to a learner's session.
+type secondaryConn struct {
+	id      string
+	role    SessionRole
+	conn    *websocket.Conn
+	writeMu sync.Mutex
+}
+
+// WebSocketSession is the per-connection state for a direct WebSocket client.
+type WebSocketSession struct {
+	ID          string
+	UserID      string
+	Conn        *websocket.Conn
+	IsRecording bool
+	CreatedAt   time.Time
+
+	// lastActivity is the UnixNano of the last touch. Atomic so read-locked
+	// accessors can refresh it without racing the cleanup sweep.
+	lastActivity atomic.Int64
+
+	// Role is the connection's participant role. The session owner is always
+	// the learner; teachers and observers attach as secondary connections.
+	Role SessionRole
+
+	// AudioBuffer accumulates binary frames for the current utterance.
+	AudioBuffer []byte
+
+	// PreRoll holds the tail of the pre-recording preview stream, prepended
+	// to the utterance on start_recording so the first syllable of early
+	// speech is kept. Nil when pre-roll is disabled; guarded by the manager
+	// lock like AudioBuffer.
+	PreRoll *ring.Buffer
+
+	ConversationHistory *model.ConversationHistory
+	ReferenceText       string
+	Language            string
+	CEFREstimate        string
+	TurnCount           int
+
+	// WordTimings makes the TTS stage request per-word timings and emit
+	// word_boundary events in step with playback; set via the
+	// set_word_timings control action.
+	WordTimings bool
+
+	// ISEDisabled turns pronunciation evaluation off for the session, set by
+	// the ?ise=off connect flag — the same knob the gateway exposes.
+	ISEDisabled bool
+
+	// Deterministic marks a session that opted into deterministic mode at
+	// connect time (?deterministic=true, honored only when the deployment
+	// enables it): completions run at temperature 0 and provider request ids
+	// derive from the session and turn.
+	Deterministic bool
+
+	// QARecord marks a session that opted in to QA audio recording at
+	// connect time (?record_session=true); nothing is stored for other
+	// sessions. QARecordedBytes counts what has been stored so the
+	// per-session cap can stop further writes. Guarded by the manager lock.
+	QARecord        bool
+	QARecordedBytes int64
+
+	// CloseCode and CloseReason record the client's close frame when the
+	// session ended with a proper close handshake; zero/empty means the
+	// connection dropped without one. A normal closure (1000) makes
+	// RemoveConnection discard the persisted snapshot instead of keeping it
+	// for a reconnect.
+	CloseCode   int
+	CloseReason string
+
+	// PracticeMode and PracticeTopic select the tutor's turn structure
+	// (roleplay, debate, ...), set via the set_practice_mode control action.
+	// Empty means free conversation; the handler validates the name before
+	// storing it, so the value is always a service.PracticeMode.
+	PracticeMode  string
+	PracticeTopic string
+
+	// Lesson progress, persisted via the session store across reconnects.
+	TopicID          string
+	VocabEncountered []string
+	AverageISEScore  float64
+
+	// timeLimitWarned marks that the session duration warning went out, so
+	// the cleanup sweep sends it once. Guarded by the manager lock.
+	timeLimitWarned bool
+
+	// inactivityWarnedAt is when the session_expiring warning went out;
+	// zero when no warning is pending. Activity after the warning clears it
+	// on the next sweep. Guarded by the manager lock.
+	inactivityWarnedAt time.Time
+
+	// secondaries are the read-only connections mirroring this session,
+	// keyed by their connection ID.
+	secondaryMu sync.Mutex
+	secondaries map[string]*secondaryConn
+
+	writeMu sync.Mutex
+}
+
+// Touch records activity on the session now. Safe from any goroutine.
+func (s *WebSocketSession) Touch() { s.lastActivity.Store(time.Now().UnixNano()) }
+
+// LastActivity returns when the session was last touched.
+func (s *WebSocketSession) LastActivity() time.Time {
+	return time.Unix(0, s.lastActivity.Load())
+}
+
+// Snapshot captures the session's durable lesson-progress state.
+func (s *WebSocketSession) Snapshot() *model.SessionSnapshot {
+	return &model.SessionSnapshot{
+		SessionID:        s.ID,
+		UserID:           s.UserID,
+		CEFREstimate:     s.CEFREstimate,
+		VocabEncountered: s.VocabEncountered,
+		TopicID:          s.TopicID,
+		TurnCount:        s.TurnCount,
+		AverageISEScore:  s.AverageISEScore,
+		CreatedAt:        s.CreatedAt,
+	}
+}
+
+// restoreSnapshot applies persisted lesson progress to a fresh session.
+func (s *WebSocketSession) restoreSnapshot(snap *model.SessionSnapshot) {
+	s.UserID = snap.UserID
+	s.CEFREstimate = snap.CEFREstimate
+	s.VocabEncountered = snap.VocabEncountered
+	s.TopicID = snap.TopicID
+	s.TurnCount = snap.TurnCount
+	s.AverageISEScore = snap.AverageISEScore
+	s.CreatedAt = snap.CreatedAt
+}
+
+// WriteJSON sends a JSON text frame, serializing concurrent writers, and
+// mirrors it to every secondary connection.
+func (s *WebSocketSession) WriteJSON(v interface{}) error {
+	s.writeMu.Lock()
+	err := s.Conn.WriteJSON(v)
+	s.writeMu.Unlock()
+	s.fanOut(func(sec *secondaryConn) error { return sec.conn.WriteJSON(v) })
+	return err
+}
+
+// WriteBinary sends a binary frame, serializing concurrent writers, and
+// mirrors it to every secondary connection.
+func (s *WebSocketSession) WriteBinary(data []byte) error {
+	s.writeMu.Lock()
+	err := s.Conn.WriteMessage(websocket.BinaryMessage, data)
+	s.writeMu.Unlock()
+	s.fanOut(func(sec *secondaryConn) error { return sec.conn.WriteMessage(websocket.BinaryMessage, data) })
+	return err
+}
+
+// fanOut copies one outbound frame to the secondary connections; a failed
+// write drops that watcher without affecting the learner.
+func (s *WebSocketSession) fanOut(write func(*secondaryConn) error) {
+	s.secondaryMu.Lock()
+	watchers := make([]*secondaryConn, 0, len(s.secondaries))
+	for _, sec := range s.secondaries {
+		watchers = append(watchers, sec)
+	}
+	s.secondaryMu.Unlock()
+
+	for _, sec := range watchers {
+		sec.writeMu.Lock()
+		err := write(sec)
+		sec.writeMu.Unlock()
+		if err != nil {
+			s.detachSecondary(sec.id)
+		}
+	}
+}
+
+func (s *WebSocketSession) detachSecondary(secondaryID string) {
+	s.secondaryMu.Lock()
+	defer s.secondaryMu.Unlock()
+	if sec, ok := s.secondaries[secondaryID]; ok {
+		// Detachment means the watcher's connection already failed a write,
+		// so there is no point in a close frame.
+		sec.conn.Close()
+		delete(s.secondaries, secondaryID)
+	}
+}
+
+// closeSecondaries closes every watcher connection with the same close code
+// the learner's connection got, so watchers see why the session ended too.
+func (s *WebSocketSession) closeSecondaries(code int, reason string) {
+	s.secondaryMu.Lock()
+	defer s.secondaryMu.Unlock()
+	for id, sec := range s.secondaries {
+		closeConn(sec.conn, code, reason)
+		delete(s.secondaries, id)
+	}
+}
+
+// closeConn sends a close frame carrying the application close code and
+// reason, then closes the connection, so the client's onclose handler can tell
+// why the session ended. The control write is best effort: on an already-dead
+// connection it fails and the Close is all that matters.
+func closeConn(conn *websocket.Conn, code int, reason string) {
+	conn.WriteControl(websocket.CloseMessage,
+		websocket.FormatCloseMessage(code, reason), time.Now().Add(time.Second))
+	conn.Close()
+}
+
+// WebSocketManager tracks all active WebSocket sessions.
+type WebSocketManager struct {
+	mu       sync.RWMutex
+	sessions map[string]*WebSocketSession
+	logger   *logrus.Logger
+	store    store.SessionStore
+
+	maxInactivity time.Duration
+	// inactivityWarning is the grace window before the inactivity cutoff: a
+	// session idle past maxInactivity-inactivityWarning gets a
+	// session_expiring warning, and is only removed once the window passes
+	// with no further activity.
+	inactivityWarning time.Duration
+	// maxSessionDuration caps how long a session may stay open regardless of
+	// activity; 0 (the default) leaves sessions unbounded.
+	maxSessionDuration time.Duration
+	// timeLimitWarning is how long before the duration limit the warning
+	// message goes out.
+	timeLimitWarning time.Duration
+
+	// memory is the per-session buffer ledger; nil disables accounting.
+	memory *memory.Accountant
+
+	// now overrides the sweep's clock in tests; nil means time.Now.
+	now func() time.Time
+}
+
+// clock returns the sweep's current time.
+func (m *WebSocketManager) clock() time.Time {
+	if m.now != nil {
+		return m.now()
+	}
+	return time.Now()
+}
+
+// NewWebSocketManager creates a session manager. The session store may be nil
+// to disable snapshot persistence.
+func NewWebSocketManager(logger *logrus.Logger, sessionStore store.SessionStore) *WebSocketManager {
+	return &WebSocketManager{
+		sessions:          make(map[string]*WebSocketSession),
+		logger:            logger,
+		store:             sessionStore,
+		maxInactivity:     30 * time.Minute,
+		inactivityWarning: 5 * time.Minute,
+		timeLimitWarning:  5 * time.Minute,
+	}
+}
+
+// SetMaxSessionDuration enables the session duration limit. The limit counts
+// from the session's creation (surviving reconnects via the snapshot), so an
+// active session cannot run indefinitely.
+func (m *WebSocketManager) SetMaxSessionDuration(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.maxSessionDuration = d
+}
+
+// SetMemoryAccountant wires the buffer ledger so removed sessions release
+// their charges and memory pressure can evict the largest idle sessions.
+func (m *WebSocketManager) SetMemoryAccountant(a *memory.Accountant) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.memory = a
+}
+
+// EvictForMemory removes idle sessions under process-wide memory pressure,
+// walking the candidates — ordered largest first by the accountant — until
+// usage is back under the ceiling. Sessions active within the last minute,
+// and IDs not registered here (gRPC voice sessions), are skipped.
+func (m *WebSocketManager) EvictForMemory(candidates []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.memory == nil {
+		return
+	}
+	now := m.clock()
+	for _, id := range candidates {
+		if !m.memory.OverCeiling() {
+			return
+		}
+		session, ok := m.sessions[id]
+		if !ok || now.Sub(session.LastActivity()) < memoryEvictionIdleAfter {
+			continue
+		}
+		m.logger.Warnf("Evicting session under memory pressure: %s (%d bytes)", id, m.memory.SessionUsage(id))
+		m.sendLocked(session, &model.WebSocketMessage{
+			Type:      model.MessageTypeSessionEnded,
+			Data:      map[string]interface{}{"reason": "memory_pressure"},
+			Timestamp: now.UnixMilli(),
+		})
+		m.removeSessionLocked(id, session, model.CloseIdleTimeout, "evicted while idle under memory pressure")
+		memoryEvictions.Inc()
+	}
+}
+
+// AddConnection registers a new session for the connection.
+func (m *WebSocketManager) AddConnection(sessionID, userID string, conn *websocket.Conn) *WebSocketSession {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if existing, ok := m.sessions[sessionID]; ok {
+		// A reconnect for an existing session supersedes the old connection.
+		if existing.Conn != nil {
+			closeConn(existing.Conn, model.CloseSuperseded, "session superseded by a new connection")
+		}
+	}
+	session := &WebSocketSession{
+		ID:                  sessionID,
+		UserID:              userID,
+		Conn:                conn,
+		CreatedAt:           time.Now(),
+		ConversationHistory: &model.ConversationHistory{},
+	}
+	session.Touch()
+	if m.store != nil {
+		if snap, ok := m.store.Load(sessionID); ok {
+			session.restoreSnapshot(snap)
+			m.logger.Infof("Session restored from snapshot: %s (topic=%s, turns=%d)",
+				sessionID, snap.TopicID, snap.TurnCount)
+		}
+	}
+	m.sessions[sessionID] = session
+	m.logger.Infof("Session added: %s (user=%s, active=%d)", sessionID, userID, len(m.sessions))
+	return session
+}
+
+// RemoveConnection drops a session and closes its connection.
+func (m *WebSocketManager) RemoveConnection(sessionID string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if session, ok := m.sessions[sessionID]; ok {
+		if session.Conn != nil {
+			closeConn(session.Conn, model.CloseNormalEnd, "session ended")
+		}
+		session.closeSecondaries(model.CloseNormalEnd, "session ended")
+		delete(m.sessions, sessionID)
+		if m.memory != nil {
+			m.memory.ReleaseSession(sessionID)
+		}
+		if session.CloseCode == websocket.CloseNormalClosure {
+			// The client ended the session deliberately; nothing to resume.
+			m.discard(session)
+		} else {
+			m.persist(session)
+		}
+		m.logger.Infof("Session removed: %s (active=%d)", sessionID, len(m.sessions))
+	}
+}
+
+// NoteClose records the client's close frame on the session, ahead of the
+// read loop unwinding into RemoveConnection.
+func (m *WebSocketManager) NoteClose(sessionID string, code int, reason string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if session, ok := m.sessions[sessionID]; ok {
+		session.CloseCode = code
+		session.CloseReason = reason
+	}
+}
+
+// discard drops any stored snapshot for a session that ended cleanly.
+func (m *WebSocketManager) discard(session *WebSocketSession) {
+	if m.store == nil {
+		return
+	}
+	if err := m.store.Delete(session.ID); err != nil {
+		m.logger.Warnf("Failed to delete snapshot for session %s: %v", session.ID, err)
+	}
+}
+
+// persist writes the session snapshot to the store, if one is configured.
+func (m *WebSocketManager) persist(session *WebSocketSession) {
+	if m.store == nil {
+		return
+	}
+	if err := m.store.Save(session.Snapshot()); err != nil {
+		m.logger.Warnf("Failed to persist snapshot for session %s: %v", session.ID, err)
+	}
+}
+
+// GetSession returns the session for the ID, refreshing its activity time.
+// The refresh is an atomic store, so the read lock is enough.
+func (m *WebSocketManager) GetSession(sessionID string) (*WebSocketSession, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	session, ok := m.sessions[sessionID]
+	if ok {
+		session.Touch()
+	}
+	return session, ok
+}
+
+// UpdateSession applies a mutation to the session under the manager lock.
+func (m *WebSocketManager) UpdateSession(sessionID string, fn func(*WebSocketSession)) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	session, ok := m.sessions[sessionID]
+	if !ok {
+		return fmt.Errorf("session %s not found", sessionID)
+	}
+	fn(session)
+	session.Touch()
+	m.persist(session)
+	return nil
+}
+
+// SendMessage sends a JSON message to the session's client.
+func (m *WebSocketManager) SendMessage(sessionID string, msg *model.WebSocketMessage) error {
+	session, ok := m.GetSession(sessionID)
+	if !ok {
+		return fmt.Errorf("session %s not found", sessionID)
+	}
+	if msg.Timestamp == 0 {
+		msg.Timestamp = time.Now().UnixMilli()
+	}
+	return session.WriteJSON(msg)
+}
+
+// SendBinaryMessage sends a binary frame to the session's client.
+func (m *WebSocketManager) SendBinaryMessage(sessionID string, data []byte) error {
+	session, ok := m.GetSession(sessionID)
+	if !ok {
+		return fmt.Errorf("session %s not found", sessionID)
+	}
+	return session.WriteBinary(data)
+}
+
+// GetActiveSessions returns the number of primary learner sessions; attached
+// teacher and observer connections are not counted.
+func (m *WebSocketManager) GetActiveSessions() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.sessions)
+}
+
+// AddSecondaryConnection attaches a read-only connection to an existing
+// learner session. Frames sent to the session fan out to it until it is
+// removed or the session ends.
+func (m *WebSocketManager) AddSecondaryConnection(sessionID, secondaryID string, conn *websocket.Conn, role SessionRole) (*WebSocketSession, error) {
+	if role == RoleLearner {
+		return nil, fmt.Errorf("secondary connections must be teacher or observer, not learner")
+	}
+	m.mu.RLock()
+	session, ok := m.sessions[sessionID]
+	m.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("session %s not found", sessionID)
+	}
+
+	session.secondaryMu.Lock()
+	if session.secondaries == nil {
+		session.secondaries = make(map[string]*secondaryConn)
+	}
+	session.secondaries[secondaryID] = &secondaryConn{id: secondaryID, role: role, conn: conn}
+	watchers := len(session.secondaries)
+	session.secondaryMu.Unlock()
+
+	m.logger.Infof("Secondary connection attached: %s (session=%s, role=%s, watchers=%d)",
+		secondaryID, sessionID, role, watchers)
+	return session, nil
+}
+
+// RemoveSecondaryConnection detaches and closes a secondary connection.
+func (m *WebSocketManager) RemoveSecondaryConnection(sessionID, secondaryID string) {
+	m.mu.RLock()
+	session, ok := m.sessions[sessionID]
+	m.mu.RUnlock()
+	if !ok {
+		return
+	}
+	session.detachSecondary(secondaryID)
+	m.logger.Infof("Secondary connection detached: %s (session=%s)", secondaryID, sessionID)
+}
+
+// CleanupInactiveSessions removes sessions idle beyond the inactivity limit
+// and, when a duration limit is set, sessions that have been open longer than
+// it allows — with a warning message ahead of each cutoff so clients can wrap
+// up. Inactivity is two-phase: an idle session is first warned with
+// session_expiring and only removed once the grace window passes with no
+// further activity; any inbound message resets it to fully active.
+func (m *WebSocketManager) CleanupInactiveSessions() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.clock()
+	for id, session := range m.sessions {
+		idle := now.Sub(session.LastActivity())
+		switch {
+		case idle <= m.maxInactivity-m.inactivityWarning:
+			// Active again (or still): a pending warning is forgotten.
+			session.inactivityWarnedAt = time.Time{}
+		case session.inactivityWarnedAt.IsZero():
+			session.inactivityWarnedAt = now
+			m.sendLocked(session, &model.WebSocketMessage{
+				Type: model.MessageTypeSessionExpiring,
+				Data: map[string]interface{}{
+					"remaining_seconds": int((m.maxInactivity - idle).Seconds()),
+				},
+				Timestamp: now.UnixMilli(),
+			})
+		case now.Sub(session.inactivityWarnedAt) >= m.inactivityWarning:
+			m.logger.Infof("Cleaning up inactive session: %s (idle %v)", id, idle)
+			m.sendLocked(session, &model.WebSocketMessage{
+				Type:      model.MessageTypeSessionEnded,
+				Data:      map[string]interface{}{"reason": "inactivity_timeout"},
+				Timestamp: now.UnixMilli(),
+			})
+			m.removeSessionLocked(id, session, model.CloseIdleTimeout, "inactivity timeout")
+			continue
+		}
+		if m.maxSessionDuration <= 0 {
+			continue
+		}
+		age := now.Sub(session.CreatedAt)
+		switch {
+		case age > m.maxSessionDuration:
+			m.logger.Infof("Closing session at duration limit: %s (open %v)", id, age)
+			m.sendLocked(session, &model.WebSocketMessage{
+				Type:      model.MessageTypeSessionEnded,
+				Data:      map[string]interface{}{"reason": "time_limit_reached"},
+				Timestamp: now.UnixMilli(),
+			})
+			m.removeSessionLocked(id, session, model.CloseNormalEnd, "session time limit reached")
+		case !session.timeLimitWarned && age > m.maxSessionDuration-m.timeLimitWarning:
+			session.timeLimitWarned = true
+			m.sendLocked(session, &model.WebSocketMessage{
+				Type: model.MessageTypeSessionTimeLimitWarning,
+				Data: map[string]interface{}{
+					"remaining_seconds": int((m.maxSessionDuration - age).Seconds()),
+				},
+				Timestamp: now.UnixMilli(),
+			})
+		}
+	}
+}
+
+// removeSessionLocked closes a session with the given close code and deletes
+// it; the caller holds m.mu.
+func (m *WebSocketManager) removeSessionLocked(id string, session *WebSocketSession, code int, reason string) {
+	if session.Conn != nil {
+		closeConn(session.Conn, code, reason)
+	}
+	session.closeSecondaries(code, reason)
+	delete(m.sessions, id)
+	if m.memory != nil {
+		m.memory.ReleaseSession(id)
+	}
+	m.persist(session)
+}
+
+// sendLocked delivers a lifecycle message during a sweep; sessions registered
+// without a connection (tests, half-open reconnects) are skipped.
+func (m *WebSocketManager) sendLocked(session *WebSocketSession, msg *model.WebSocketMessage) {
+	if session.Conn == nil {
+		return
+	}
+	if err := session.WriteJSON(msg); err != nil {
+		m.logger.Warnf("Failed to send %s to session %s: %v", msg.Type, session.ID, err)
+	}
+}
+
+// Shutdown closes every live session with a server-shutdown close code, so
+// clients can tell a rolling restart from a failure and reconnect. Snapshots
+// are persisted as usual, so sessions resume on the next instance. Called once
+// when the process is going down.
+func (m *WebSocketManager) Shutdown() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	now := m.clock()
+	for id, session := range m.sessions {
+		m.sendLocked(session, &model.WebSocketMessage{
+			Type:      model.MessageTypeSessionEnded,
+			Data:      map[string]interface{}{"reason": "server_shutdown"},
+			Timestamp: now.UnixMilli(),
+		})
+		m.removeSessionLocked(id, session, model.CloseServerShutdown, "server shutting down")
+	}
+}
+
+// StartCleanupRoutine runs CleanupInactiveSessions periodically.
+func (m *WebSocketManager) StartCleanupRoutine(interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			m.CleanupInactiveSessions()
+		}
+	}()
+}
+
+// MarshalSessionInfo returns a JSON summary of active sessions for admin and
+// debugging endpoints.
+func (m *WebSocketManager) MarshalSessionInfo() ([]byte, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	type info struct {
+		ID           string    `json:"id"`
+		UserID       string    `json:"user_id"`
+		CreatedAt    time.Time `json:"created_at"`
+		LastActivity time.Time `json:"last_activity"`
+		TurnCount    int       `json:"turn_count"`
+		Recording    bool      `json:"recording,omitempty"`
+	}
+	out := make([]info, 0, len(m.sessions))
+	for _, s := range m.sessions {
+		out = append(out, info{
+			ID:           s.ID,
+			UserID:       s.UserID,
+			CreatedAt:    s.CreatedAt,
+			LastActivity: s.LastActivity(),
+			TurnCount:    s.TurnCount,
+			Recording:    s.QARecord,
+		})
+	}
+	return json.Marshal(out)
+}
@@ -0,0 +1,65 @@
+package manager
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/store"
+)
+
+func TestSnapshotRestoredOnReconnect(t *testing.T) {
+	sessionStore := store.NewMemorySessionStore(24 * time.Hour)
+	m := NewWebSocketManager(logrus.New(), sessionStore)
+
+	m.AddConnection("sess-1", "user-1", nil)
+	err := m.UpdateSession("sess-1", func(s *WebSocketSession) {
+		s.TopicID = "ordering-food"
+		s.CEFREstimate = "B1"
+		s.VocabEncountered = []string{"menu", "appetizer"}
+		s.TurnCount = 7
+		s.AverageISEScore = 82.5
+	})
+	if err != nil {
+		t.Fatalf("update session: %v", err)
+	}
+
+	// Simulate a browser refresh: connection dropped, then a new connection
+	// arrives with the same session ID.
+	m.RemoveConnection("sess-1")
+	restored := m.AddConnection("sess-1", "user-1", nil)
+
+	if restored.TopicID != "ordering-food" {
+		t.Errorf("TopicID = %q, want ordering-food", restored.TopicID)
+	}
+	if restored.CEFREstimate != "B1" {
+		t.Errorf("CEFREstimate = %q, want B1", restored.CEFREstimate)
+	}
+	if restored.TurnCount != 7 {
+		t.Errorf("TurnCount = %d, want 7", restored.TurnCount)
+	}
+	if restored.AverageISEScore != 82.5 {
+		t.Errorf("AverageISEScore = %v, want 82.5", restored.AverageISEScore)
+	}
+	if len(restored.VocabEncountered) != 2 || restored.VocabEncountered[0] != "menu" {
+		t.Errorf("VocabEncountered = %v", restored.VocabEncountered)
+	}
+}
+
+func TestExpiredSnapshotNotRestored(t *testing.T) {
+	sessionStore := store.NewMemorySessionStore(time.Millisecond)
+	m := NewWebSocketManager(logrus.New(), sessionStore)
+
+	m.AddConnection("sess-1", "user-1", nil)
+	m.UpdateSession("sess-1", func(s *WebSocketSession) {
+		s.TurnCount = 3
+	})
+	m.RemoveConnection("sess-1")
+
+	time.Sleep(5 * time.Millisecond)
+	restored := m.AddConnection("sess-1", "user-1", nil)
+	if restored.TurnCount != 0 {
+		t.Errorf("expired snapshot was restored: TurnCount = %d", restored.TurnCount)
+	}
+}
@@ -0,0 +1,206 @@
+// Package memory tracks the bytes each session holds in process memory —
+// audio buffers, conversation history — so a few runaway sessions cannot grow
+// the speech service without bound. Buffering sites charge the accountant
+// before they grow and release when they shrink; charges past the per-session
+// budget are rejected, and crossing the process-wide ceiling triggers
+// eviction of the largest idle sessions.
+package memory
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// BudgetExceededError reports a charge that would push a session past its
+// memory budget. The message carries the resource_exhausted marker clients
+// match on.
+type BudgetExceededError struct {
+	SessionID string
+	Requested int64
+	Used      int64
+	Budget    int64
+}
+
+func (e *BudgetExceededError) Error() string {
+	return fmt.Sprintf("resource_exhausted: session %s memory budget exceeded (%d bytes in use + %d requested > %d budget)",
+		e.SessionID, e.Used, e.Requested, e.Budget)
+}
+
+var (
+	usedBytes = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "session_memory_bytes",
+		Help: "Bytes currently charged to session buffers across the process.",
+	})
+	budgetRejections = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "session_memory_budget_rejections_total",
+		Help: "Charges rejected because a session was over its memory budget.",
+	})
+)
+
+// Accountant is the process-wide ledger of per-session buffer usage. The
+// zero budget/ceiling disables the corresponding check, so an accountant can
+// run in observe-only mode.
+type Accountant struct {
+	mu            sync.Mutex
+	sessionBudget int64
+	ceiling       int64
+	used          map[string]int64
+	total         int64
+
+	// evictor is invoked outside the lock when a charge pushes the total
+	// past the ceiling, with session IDs ordered largest first. evicting
+	// keeps a slow evictor from being re-triggered by concurrent charges.
+	evictor  func(candidates []string)
+	evicting bool
+}
+
+// NewAccountant creates the ledger with the given per-session budget and
+// process-wide ceiling, both in bytes; 0 disables the respective limit.
+func NewAccountant(sessionBudget, ceiling int64) *Accountant {
+	return &Accountant{
+		sessionBudget: sessionBudget,
+		ceiling:       ceiling,
+		used:          make(map[string]int64),
+	}
+}
+
+// SetEvictor registers the callback run when total usage crosses the
+// ceiling. Candidates arrive ordered by usage, largest first; the callback
+// decides which of them are idle enough to evict.
+func (a *Accountant) SetEvictor(fn func(candidates []string)) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.evictor = fn
+}
+
+// Charge records n bytes against the session, failing with a
+// *BudgetExceededError when the session budget does not cover them. A charge
+// that lands over the process ceiling still succeeds — the session itself is
+// within its rights — but triggers the evictor.
+func (a *Accountant) Charge(sessionID string, n int64) error {
+	a.mu.Lock()
+	if a.sessionBudget > 0 && a.used[sessionID]+n > a.sessionBudget {
+		err := &BudgetExceededError{
+			SessionID: sessionID,
+			Requested: n,
+			Used:      a.used[sessionID],
+			Budget:    a.sessionBudget,
+		}
+		a.mu.Unlock()
+		budgetRejections.Inc()
+		return err
+	}
+	a.chargeLocked(sessionID, n)
+
+	var candidates []string
+	if a.ceiling > 0 && a.total > a.ceiling && a.evictor != nil && !a.evicting {
+		a.evicting = true
+		candidates = a.largestLocked()
+	}
+	evictor := a.evictor
+	a.mu.Unlock()
+
+	if candidates != nil {
+		evictor(candidates)
+		a.mu.Lock()
+		a.evicting = false
+		a.mu.Unlock()
+	}
+	return nil
+}
+
+// ForceCharge records bytes the session already holds — a generated reply
+// appended to its history — so usage stays accurate even past the budget.
+func (a *Accountant) ForceCharge(sessionID string, n int64) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.chargeLocked(sessionID, n)
+}
+
+func (a *Accountant) chargeLocked(sessionID string, n int64) {
+	a.used[sessionID] += n
+	a.total += n
+	usedBytes.Set(float64(a.total))
+}
+
+// Release returns n bytes of the session's charge, clamping at zero so a
+// double release cannot corrupt the ledger.
+func (a *Accountant) Release(sessionID string, n int64) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if n > a.used[sessionID] {
+		n = a.used[sessionID]
+	}
+	a.used[sessionID] -= n
+	a.total -= n
+	if a.used[sessionID] == 0 {
+		delete(a.used, sessionID)
+	}
+	usedBytes.Set(float64(a.total))
+}
+
+// ReleaseSession returns everything the session holds; called when the
+// session is removed.
+func (a *Accountant) ReleaseSession(sessionID string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.total -= a.used[sessionID]
+	delete(a.used, sessionID)
+	usedBytes.Set(float64(a.total))
+}
+
+// SessionUsage returns the bytes currently charged to the session.
+func (a *Accountant) SessionUsage(sessionID string) int64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.used[sessionID]
+}
+
+// TotalUsage returns the bytes currently charged across all sessions.
+func (a *Accountant) TotalUsage() int64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.total
+}
+
+// OverCeiling reports whether total usage still exceeds the process ceiling;
+// evictors use it to decide when to stop.
+func (a *Accountant) OverCeiling() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.ceiling > 0 && a.total > a.ceiling
+}
+
+// largestLocked returns session IDs ordered by usage, largest first.
+func (a *Accountant) largestLocked() []string {
+	ids := make([]string, 0, len(a.used))
+	for id := range a.used {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return a.used[ids[i]] > a.used[ids[j]] })
+	return ids
+}
+
+// UsageSnapshot summarizes the ledger for the runtime diagnostics endpoint.
+type UsageSnapshot struct {
+	TotalBytes         int64 `json:"total_bytes"`
+	SessionBudgetBytes int64 `json:"session_budget_bytes"`
+	CeilingBytes       int64 `json:"ceiling_bytes"`
+	Sessions           int   `json:"sessions"`
+}
+
+// Snapshot returns the current usage summary.
+func (a *Accountant) Snapshot() UsageSnapshot {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return UsageSnapshot{
+		TotalBytes:         a.total,
+		SessionBudgetBytes: a.sessionBudget,
+		CeilingBytes:       a.ceiling,
+		Sessions:           len(a.used),
+	}
+}
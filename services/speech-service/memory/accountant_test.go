@@ -0,0 +1,103 @@
+package memory
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestChargeRejectsPastBudgetAndRecoversAfterRelease(t *testing.T) {
+	a := NewAccountant(100, 0)
+
+	if err := a.Charge("s1", 60); err != nil {
+		t.Fatalf("first charge: %v", err)
+	}
+	err := a.Charge("s1", 50)
+	var exceeded *BudgetExceededError
+	if !errors.As(err, &exceeded) {
+		t.Fatalf("over-budget charge error = %v, want *BudgetExceededError", err)
+	}
+	if exceeded.SessionID != "s1" || exceeded.Used != 60 || exceeded.Requested != 50 || exceeded.Budget != 100 {
+		t.Errorf("error fields = %+v, want session s1 with 60 used, 50 requested, 100 budget", exceeded)
+	}
+	if got := a.SessionUsage("s1"); got != 60 {
+		t.Errorf("usage after rejection = %d, want the rejected charge not recorded", got)
+	}
+
+	// Releasing makes room; the same charge then succeeds.
+	a.Release("s1", 60)
+	if err := a.Charge("s1", 50); err != nil {
+		t.Fatalf("charge after release: %v", err)
+	}
+	if got := a.SessionUsage("s1"); got != 50 {
+		t.Errorf("usage after recovery = %d, want 50", got)
+	}
+}
+
+func TestForceChargeIgnoresBudget(t *testing.T) {
+	a := NewAccountant(10, 0)
+	a.ForceCharge("s1", 25)
+	if got := a.SessionUsage("s1"); got != 25 {
+		t.Errorf("usage = %d, want the forced charge recorded past the budget", got)
+	}
+	// The session is now over budget, so checked charges fail until release.
+	if err := a.Charge("s1", 1); err == nil {
+		t.Error("checked charge succeeded for a session over budget")
+	}
+}
+
+func TestReleaseClampsAtZero(t *testing.T) {
+	a := NewAccountant(0, 0)
+	a.ForceCharge("s1", 10)
+	a.Release("s1", 25)
+	if got := a.SessionUsage("s1"); got != 0 {
+		t.Errorf("usage = %d, want clamped to 0", got)
+	}
+	if got := a.TotalUsage(); got != 0 {
+		t.Errorf("total = %d, want 0 after over-release", got)
+	}
+}
+
+func TestCeilingTriggersEvictorLargestFirst(t *testing.T) {
+	a := NewAccountant(0, 100)
+	var candidates []string
+	a.SetEvictor(func(ids []string) {
+		candidates = ids
+		// Evict the largest, as the manager would.
+		a.ReleaseSession(ids[0])
+	})
+
+	if err := a.Charge("small", 30); err != nil {
+		t.Fatalf("charge: %v", err)
+	}
+	if err := a.Charge("large", 60); err != nil {
+		t.Fatalf("charge: %v", err)
+	}
+	if candidates != nil {
+		t.Fatal("evictor ran below the ceiling")
+	}
+
+	// This charge crosses the ceiling; it still succeeds but the evictor
+	// runs with sessions ordered largest first.
+	if err := a.Charge("small", 20); err != nil {
+		t.Fatalf("charge over ceiling: %v", err)
+	}
+	if len(candidates) != 2 || candidates[0] != "large" || candidates[1] != "small" {
+		t.Fatalf("candidates = %v, want [large small]", candidates)
+	}
+	if got := a.TotalUsage(); got != 50 {
+		t.Errorf("total after eviction = %d, want 50", got)
+	}
+	if a.OverCeiling() {
+		t.Error("still over ceiling after evicting the largest session")
+	}
+}
+
+func TestSnapshotSummarizesLedger(t *testing.T) {
+	a := NewAccountant(100, 1000)
+	a.ForceCharge("s1", 40)
+	a.ForceCharge("s2", 10)
+	snap := a.Snapshot()
+	if snap.TotalBytes != 50 || snap.Sessions != 2 || snap.SessionBudgetBytes != 100 || snap.CeilingBytes != 1000 {
+		t.Errorf("snapshot = %+v, want 50 bytes across 2 sessions with the configured limits", snap)
+	}
+}
@@ -0,0 +1,29 @@
+package model
+
+// ASRRequest is a transcription request for a complete utterance.
+type ASRRequest struct {
+	AudioData  []byte
+	Format     string // "wav" or "pcm"
+	SampleRate int
+	Language   string
+	// RequestID overrides the randomly generated provider request id; empty
+	// (the default) lets the client mint one. Deterministic sessions derive
+	// it from the session and turn so runs are reproducible.
+	RequestID string
+}
+
+// Utterance is one recognized segment with provider-reported timings.
+type Utterance struct {
+	Text        string
+	StartTimeMs int64
+	EndTimeMs   int64
+}
+
+// ASRResponse is the transcription result for one utterance.
+type ASRResponse struct {
+	Text       string
+	Confidence float64
+	IsFinal    bool
+	Language   string
+	Utterances []Utterance
+}
@@ -0,0 +1,53 @@
+package model
+
+// ISERequest is a pronunciation evaluation request.
+type ISERequest struct {
+	AudioData []byte
+	// Text is the reference text the learner was supposed to read.
+	Text     string
+	Language string // "en_us" or "zh_cn"
+	// Category is the iFlytek evaluation category ("read_word",
+	// "read_sentence", "read_chapter"). Empty means auto-detect.
+	Category string
+	// ApplyToneSandhi rewrites Chinese pinyin reference text with the
+	// mandatory tone sandhi rules before evaluation, so the expected tones
+	// match what a native speaker actually says.
+	ApplyToneSandhi bool
+}
+
+// PhoneScore is a phoneme-level evaluation result.
+type PhoneScore struct {
+	Phone string  `json:"phone"`
+	Score float64 `json:"score"`
+	// DpMessage is the raw iFlytek error code (0 = correct).
+	DpMessage int `json:"dp_message"`
+	// ErrorCode and ErrorDescription are the decoded DpMessage: the error
+	// category ("omission", "substitution", ...) and its explanation.
+	ErrorCode        string `json:"error_code,omitempty"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+// WordScore is a word-level evaluation result.
+type WordScore struct {
+	Word  string  `json:"word"`
+	Score float64 `json:"score"`
+	// DpMessage is the raw iFlytek error code (0 = correct).
+	DpMessage int `json:"dp_message"`
+	// ErrorCode and ErrorDescription are the decoded DpMessage: the error
+	// category ("omission", "substitution", ...) and its explanation.
+	ErrorCode        string       `json:"error_code,omitempty"`
+	ErrorDescription string       `json:"error_description,omitempty"`
+	Phones           []PhoneScore `json:"phones,omitempty"`
+}
+
+// ISEResponse is the aggregate evaluation result for one utterance.
+type ISEResponse struct {
+	OverallScore   float64 `json:"overall_score"`
+	AccuracyScore  float64 `json:"accuracy_score"`
+	FluencyScore   float64 `json:"fluency_score"`
+	IntegrityScore float64 `json:"integrity_score"`
+	// NormalizedScore is OverallScore adjusted for reference-text difficulty.
+	NormalizedScore float64     `json:"normalized_score,omitempty"`
+	ReferenceText   string      `json:"reference_text"`
+	Words           []WordScore `json:"words,omitempty"`
+}
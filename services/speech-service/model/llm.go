@@ -0,0 +1,83 @@
+package model
+
+import "sync"
+
+// ChatMessage is one turn in a conversation history, OpenAI-style.
+type ChatMessage struct {
+	Role    string `json:"role"` // "system", "user", "assistant"
+	Content string `json:"content"`
+}
+
+// ConversationHistory accumulates the turns of one session. Per-turn pipeline
+// goroutines append while the stream goroutine snapshots it for reports,
+// tokens and persistence, so all access goes through the locked methods;
+// Messages stays exported only for JSON (de)serialization.
+type ConversationHistory struct {
+	mu       sync.Mutex
+	Messages []ChatMessage `json:"messages"`
+}
+
+// Append adds a message to the history.
+func (h *ConversationHistory) Append(role, content string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.Messages = append(h.Messages, ChatMessage{Role: role, Content: content})
+}
+
+// Len returns how many messages the history holds.
+func (h *ConversationHistory) Len() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.Messages)
+}
+
+// Snapshot returns a copy of the messages, safe to read and marshal while
+// other goroutines keep appending.
+func (h *ConversationHistory) Snapshot() []ChatMessage {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]ChatMessage(nil), h.Messages...)
+}
+
+// Replace swaps the message list wholesale, for resume and import.
+func (h *ConversationHistory) Replace(messages []ChatMessage) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.Messages = messages
+}
+
+// DropLast removes the most recent message, rolling back a failed turn.
+func (h *ConversationHistory) DropLast() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.Messages) > 0 {
+		h.Messages = h.Messages[:len(h.Messages)-1]
+	}
+}
+
+// LLMRequest is a chat completion request.
+type LLMRequest struct {
+	Messages    []ChatMessage
+	MaxTokens   int
+	Temperature float64
+}
+
+// LLMResponse is the generated reply.
+type LLMResponse struct {
+	Text       string
+	TokensUsed int
+}
+
+// LLMAPIResponse mirrors the provider's chat completion response body.
+type LLMAPIResponse struct {
+	ID      string `json:"id"`
+	Choices []struct {
+		Message      ChatMessage `json:"message"`
+		FinishReason string      `json:"finish_reason"`
+	} `json:"choices"`
+	Usage struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+		TotalTokens      int `json:"total_tokens"`
+	} `json:"usage"`
+}
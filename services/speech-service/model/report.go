@@ -0,0 +1,57 @@
+package model
+
+import "time"
+
+// TranscriptEntry is one utterance in the session transcript.
+type TranscriptEntry struct {
+	Speaker string `json:"speaker"` // "user" or "tutor"
+	Text    string `json:"text"`
+}
+
+// VocabEntry is a word the tutor exposed the learner to, with how often it
+// came up during the session.
+type VocabEntry struct {
+	Word  string `json:"word"`
+	Count int    `json:"count"`
+}
+
+// VocabularyEntry is one word worth reviewing after the session, with an
+// example sentence showing how it was used.
+type VocabularyEntry struct {
+	Word    string `json:"word"`
+	Example string `json:"example,omitempty"`
+}
+
+// ISESessionSummary aggregates the pronunciation evaluations of one session.
+type ISESessionSummary struct {
+	Evaluations  int     `json:"evaluations"`
+	AverageScore float64 `json:"average_score,omitempty"`
+	BestScore    float64 `json:"best_score,omitempty"`
+	WorstScore   float64 `json:"worst_score,omitempty"`
+	// TrendSlope is the per-evaluation score change from a least-squares fit
+	// over the session: positive means pronunciation improved as it went on.
+	TrendSlope float64 `json:"trend_slope,omitempty"`
+	// ImprovedPhonemes / TroubledPhonemes are the top three phonemes whose
+	// scores rose the most over the session, and the three with the lowest
+	// average scores.
+	ImprovedPhonemes []string `json:"improved_phonemes,omitempty"`
+	TroubledPhonemes []string `json:"troubled_phonemes,omitempty"`
+}
+
+// SessionReport is the structured summary generated when a session ends,
+// served as JSON or formatted text from the report endpoint.
+type SessionReport struct {
+	SessionID               string            `json:"session_id"`
+	UserID                  string            `json:"user_id,omitempty"`
+	GeneratedAt             time.Time         `json:"generated_at"`
+	Duration                time.Duration     `json:"-"`
+	DurationMs              int64             `json:"duration_ms"`
+	TotalTurns              int               `json:"total_turns"`
+	CEFREstimate            string            `json:"cefr_estimate,omitempty"`
+	ISESummary              ISESessionSummary `json:"ise_summary"`
+	VocabLearned            []VocabEntry      `json:"vocab_learned,omitempty"`
+	Vocabulary              []VocabularyEntry `json:"vocabulary,omitempty"`
+	Transcript              []TranscriptEntry `json:"transcript,omitempty"`
+	PronunciationStrengths  []string          `json:"pronunciation_strengths,omitempty"`
+	PronunciationWeaknesses []string          `json:"pronunciation_weaknesses,omitempty"`
+}
@@ -0,0 +1,18 @@
+package model
+
+import "time"
+
+// SessionSnapshot is the durable lesson-progress state for one session. It is
+// persisted on every session update and restored when a client reconnects
+// with the same session ID, so a browser refresh does not reset the lesson.
+type SessionSnapshot struct {
+	SessionID        string    `json:"session_id"`
+	UserID           string    `json:"user_id"`
+	CEFREstimate     string    `json:"cefr_estimate,omitempty"`
+	VocabEncountered []string  `json:"vocab_encountered,omitempty"`
+	TopicID          string    `json:"topic_id,omitempty"`
+	TurnCount        int       `json:"turn_count"`
+	AverageISEScore  float64   `json:"average_ise_score,omitempty"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
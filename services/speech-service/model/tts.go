@@ -0,0 +1,34 @@
+package model
+
+// TTSRequest is a synthesis request for one reply.
+type TTSRequest struct {
+	Text     string
+	Voice    string
+	Language string
+	Speed    float64
+	// RequestID overrides the randomly generated provider request id; empty
+	// (the default) lets the client mint one. Deterministic sessions derive
+	// it from the session and turn so runs are reproducible.
+	RequestID string
+}
+
+// TTSResponse carries the synthesized audio.
+type TTSResponse struct {
+	AudioData  []byte
+	Format     string // "mp3"
+	DurationMs int64
+	// FirstByteMs is how long the provider took to stream the first audio
+	// chunk; 0 for cached responses.
+	FirstByteMs int64
+	// WordBoundaries holds per-word playback timings, populated only when the
+	// synthesis requested the provider's frontend messages.
+	WordBoundaries []WordBoundary
+}
+
+// WordBoundary is one word's position in the synthesized audio, in
+// milliseconds from the start of the clip.
+type WordBoundary struct {
+	Word    string `json:"word"`
+	StartMs int64  `json:"start_ms"`
+	EndMs   int64  `json:"end_ms"`
+}
@@ -0,0 +1,70 @@
+package model
+
+// WebSocket message types exchanged with browser clients.
+const (
+	MessageTypeControl     = "control"
+	MessageTypeText        = "text"
+	MessageTypeStatus      = "status"
+	MessageTypeError       = "error"
+	MessageTypeASRResult   = "asr_result"
+	MessageTypeLLMResponse = "llm_response"
+	// MessageTypePartialLLMResponse carries a paced prefix of the tutor's
+	// reply for word-by-word typewriter animation; the complete reply always
+	// follows as an llm_response.
+	MessageTypePartialLLMResponse = "partial_llm_response"
+	MessageTypeTTSReady           = "tts_ready"
+	// MessageTypeWordBoundary marks one word of the synthesized reply, sent
+	// in step with playback so clients can highlight it karaoke-style.
+	MessageTypeWordBoundary = "word_boundary"
+	MessageTypeISEResult    = "ise_result"
+	// MessageTypePronunciationComparison carries pitch/rhythm/stress
+	// correlations between the learner's recording and a synthesized
+	// rendition of the reference text.
+	MessageTypePronunciationComparison = "pronunciation_comparison"
+	// MessageTypeSessionTimeLimitWarning warns the client shortly before the
+	// session duration limit closes the session, with the remaining seconds
+	// in the data.
+	MessageTypeSessionTimeLimitWarning = "session_time_limit_warning"
+	// MessageTypeSessionExpiring warns an idle client that the inactivity
+	// sweep is about to close the session unless activity resumes, with the
+	// remaining grace seconds in the data.
+	MessageTypeSessionExpiring = "session_expiring"
+	// MessageTypeSessionEnded tells the client the server closed the
+	// session, with a "reason" in the data.
+	MessageTypeSessionEnded = "session_ended"
+	// MessageTypeSessionToken carries a signed reconnection token the client
+	// can present after a dropped connection to resume the session.
+	MessageTypeSessionToken = "session_token"
+	// MessageTypeSessionResumed confirms a token reconnect, with the
+	// restored turn count so the client can sync its UI state.
+	MessageTypeSessionResumed = "session_resumed"
+)
+
+// Application WebSocket close codes, mirroring the gateway's. Codes 4000-4999
+// are reserved for application use; every server-initiated close sends one of
+// these in a proper close frame so clients can tell why the connection ended.
+const (
+	// CloseNormalEnd: the session ended normally (deliberate removal or the
+	// session's duration limit).
+	CloseNormalEnd = 4000
+	// CloseSuperseded: a new connection took over this session ID.
+	CloseSuperseded = 4001
+	// CloseIdleTimeout: the session was removed for inactivity, including
+	// idle sessions evicted under memory pressure.
+	CloseIdleTimeout = 4002
+	// CloseAuthExpired: the session's credentials lapsed mid-session.
+	CloseAuthExpired = 4003
+	// CloseQuotaExceeded: a spent budget ended the session. Quotas are
+	// enforced at the gateway; defined here so the code space stays aligned.
+	CloseQuotaExceeded = 4004
+	// CloseServerShutdown: the service is shutting down.
+	CloseServerShutdown = 4005
+)
+
+// WebSocketMessage is the JSON envelope for all text frames on the client
+// WebSocket. Binary frames carry raw audio and are not enveloped.
+type WebSocketMessage struct {
+	Type      string                 `json:"type"`
+	Data      map[string]interface{} `json:"data,omitempty"`
+	Timestamp int64                  `json:"timestamp,omitempty"`
+}
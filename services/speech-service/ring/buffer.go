@@ -0,0 +1,56 @@
+// Package ring provides a bounded FIFO of byte segments that keeps only the
+// most recently pushed data, for holding the tail of a continuous stream —
+// e.g. pre-roll audio captured ahead of a recording.
+package ring
+
+// Buffer retains the newest segments whose combined size fits its byte
+// capacity, evicting whole segments oldest-first. Segments are copied on
+// push, so callers may reuse their slices. Not safe for concurrent use; the
+// owner serializes access.
+type Buffer struct {
+	capacity int
+	segments [][]byte
+	size     int
+}
+
+// NewBuffer creates a buffer holding at most capacity bytes.
+func NewBuffer(capacity int) *Buffer {
+	return &Buffer{capacity: capacity}
+}
+
+// Push appends a copy of the segment, evicting the oldest segments until the
+// buffer fits its capacity again. A segment larger than the whole capacity is
+// trimmed to its newest capacity bytes.
+func (b *Buffer) Push(segment []byte) {
+	if b.capacity <= 0 || len(segment) == 0 {
+		return
+	}
+	if len(segment) > b.capacity {
+		segment = segment[len(segment)-b.capacity:]
+	}
+	b.segments = append(b.segments, append([]byte(nil), segment...))
+	b.size += len(segment)
+	for b.size > b.capacity {
+		b.size -= len(b.segments[0])
+		b.segments[0] = nil
+		b.segments = b.segments[1:]
+	}
+}
+
+// Bytes returns the buffered data, oldest segment first, as one slice.
+func (b *Buffer) Bytes() []byte {
+	out := make([]byte, 0, b.size)
+	for _, seg := range b.segments {
+		out = append(out, seg...)
+	}
+	return out
+}
+
+// Len returns the number of buffered bytes.
+func (b *Buffer) Len() int { return b.size }
+
+// Reset discards all buffered segments.
+func (b *Buffer) Reset() {
+	b.segments = nil
+	b.size = 0
+}
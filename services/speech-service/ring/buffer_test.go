@@ -0,0 +1,60 @@
+package ring
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPushEvictsOldestSegments(t *testing.T) {
+	b := NewBuffer(6)
+	b.Push([]byte("aa"))
+	b.Push([]byte("bb"))
+	b.Push([]byte("cc"))
+	if got := b.Bytes(); !bytes.Equal(got, []byte("aabbcc")) {
+		t.Fatalf("Bytes() = %q, want everything while under capacity", got)
+	}
+
+	// One more segment pushes the oldest out.
+	b.Push([]byte("dd"))
+	if got := b.Bytes(); !bytes.Equal(got, []byte("bbccdd")) {
+		t.Errorf("Bytes() = %q, want the oldest segment evicted", got)
+	}
+	if b.Len() != 6 {
+		t.Errorf("Len() = %d, want 6", b.Len())
+	}
+}
+
+func TestPushCopiesTheSegment(t *testing.T) {
+	b := NewBuffer(8)
+	seg := []byte("abcd")
+	b.Push(seg)
+	seg[0] = 'X'
+	if got := b.Bytes(); !bytes.Equal(got, []byte("abcd")) {
+		t.Errorf("Bytes() = %q, want the pushed copy unaffected by caller reuse", got)
+	}
+}
+
+func TestOversizedSegmentKeepsNewestTail(t *testing.T) {
+	b := NewBuffer(4)
+	b.Push([]byte("abcdefgh"))
+	if got := b.Bytes(); !bytes.Equal(got, []byte("efgh")) {
+		t.Errorf("Bytes() = %q, want the newest 4 bytes", got)
+	}
+}
+
+func TestResetDiscardsEverything(t *testing.T) {
+	b := NewBuffer(4)
+	b.Push([]byte("ab"))
+	b.Reset()
+	if b.Len() != 0 || len(b.Bytes()) != 0 {
+		t.Errorf("Len() = %d after reset, want empty", b.Len())
+	}
+}
+
+func TestZeroCapacityHoldsNothing(t *testing.T) {
+	b := NewBuffer(0)
+	b.Push([]byte("ab"))
+	if b.Len() != 0 {
+		t.Errorf("Len() = %d, want a disabled buffer to stay empty", b.Len())
+	}
+}
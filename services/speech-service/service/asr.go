@@ -0,0 +1,422 @@
+package service
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/config"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/logging"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+)
+
+// Binary protocol constants for the ByteDance streaming ASR API.
+const (
+	asrProtocolVersion    = 0x1
+	asrHeaderSize         = 0x1
+	asrFullClientRequest  = 0x1
+	asrAudioOnlyRequest   = 0x2
+	asrFullServerResponse = 0x9
+	asrErrorResponse      = 0xF
+	asrJSONSerialization  = 0x1
+	asrGzipCompression    = 0x1
+
+	// asrChunkSize is the audio payload size per frame (100 ms at 16 kHz).
+	asrChunkSize = 3200
+)
+
+// ASRService transcribes utterances using the ByteDance streaming ASR API
+// over a binary WebSocket protocol.
+type ASRService struct {
+	config config.ASRConfig
+	logger *logrus.Logger
+}
+
+// NewASRService creates the ASR client.
+func NewASRService(cfg config.ASRConfig, logger *logrus.Logger) *ASRService {
+	return &ASRService{config: cfg, logger: logger}
+}
+
+// MinConfidence is the configured transcript confidence below which the
+// handler asks the learner to repeat; 0 disables the check.
+func (s *ASRService) MinConfidence() float64 {
+	return s.config.MinConfidence
+}
+
+// MinTranscriptWords is the configured minimum word count for a transcript
+// to be answered.
+func (s *ASRService) MinTranscriptWords() int {
+	return s.config.MinTranscriptWords
+}
+
+// IsAvailable reports whether the ASR endpoint currently accepts
+// connections, bounded to two seconds so health checks stay fast.
+func (s *ASRService) IsAvailable(ctx context.Context) bool {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	header := http.Header{}
+	header.Set("Authorization", fmt.Sprintf("Bearer; %s", s.config.Token))
+	conn, _, err := providerDialer.DialContext(ctx, s.config.BaseURL, header)
+	if err != nil {
+		return false
+	}
+	conn.Close()
+	return true
+}
+
+// ProcessAudio transcribes one complete utterance. The audio must be WAV or
+// raw PCM at 16 kHz mono.
+func (s *ASRService) ProcessAudio(req *model.ASRRequest) (*model.ASRResponse, error) {
+	start := time.Now()
+
+	header := http.Header{}
+	header.Set("Authorization", fmt.Sprintf("Bearer; %s", s.config.Token))
+	conn, _, err := providerDialer.Dial(s.config.BaseURL, header)
+	if err != nil {
+		return nil, fmt.Errorf("dial asr endpoint: %w", err)
+	}
+	defer conn.Close()
+
+	if err := s.sendFullRequest(conn, req); err != nil {
+		return nil, fmt.Errorf("send asr request: %w", err)
+	}
+	if err := s.sendAudioChunks(conn, req.AudioData); err != nil {
+		return nil, fmt.Errorf("send asr audio: %w", err)
+	}
+
+	resp, err := s.receiveFinalResult(conn)
+	if err != nil {
+		return nil, err
+	}
+	s.logger.WithFields(logrus.Fields{
+		logging.LogFieldService:    "asr",
+		logging.LogFieldAudioBytes: len(req.AudioData),
+		logging.LogFieldDurationMs: time.Since(start).Milliseconds(),
+	}).Info("ASR complete")
+	return resp, nil
+}
+
+// ProcessAudioStream transcribes an utterance whose PCM arrives in chunks,
+// forwarding each to the provider as soon as it is produced instead of
+// waiting for the whole utterance. The chunk channel must be closed to mark
+// the end of audio; req.AudioData is ignored.
+func (s *ASRService) ProcessAudioStream(req *model.ASRRequest, chunks <-chan []byte) (*model.ASRResponse, error) {
+	start := time.Now()
+
+	header := http.Header{}
+	header.Set("Authorization", fmt.Sprintf("Bearer; %s", s.config.Token))
+	conn, _, err := providerDialer.Dial(s.config.BaseURL, header)
+	if err != nil {
+		return nil, fmt.Errorf("dial asr endpoint: %w", err)
+	}
+	defer conn.Close()
+
+	if err := s.sendFullRequest(conn, req); err != nil {
+		return nil, fmt.Errorf("send asr request: %w", err)
+	}
+
+	// One chunk is held back so the final one can carry the last-frame flag;
+	// a stream that closes without audio still sends an empty flagged frame.
+	var framer audioFramer
+	var pending []byte
+	var totalBytes int
+	haveAudio := false
+	for chunk := range chunks {
+		if haveAudio {
+			if err := s.sendAudioFrame(conn, &framer, pending, false); err != nil {
+				return nil, fmt.Errorf("send asr audio: %w", err)
+			}
+		}
+		pending = chunk
+		haveAudio = true
+		totalBytes += len(chunk)
+	}
+	if err := s.sendAudioFrame(conn, &framer, pending, true); err != nil {
+		return nil, fmt.Errorf("send asr audio: %w", err)
+	}
+
+	resp, err := s.receiveFinalResult(conn)
+	if err != nil {
+		return nil, err
+	}
+	s.logger.WithFields(logrus.Fields{
+		logging.LogFieldService:    "asr",
+		logging.LogFieldAudioBytes: totalBytes,
+		logging.LogFieldDurationMs: time.Since(start).Milliseconds(),
+		"streaming":                true,
+	}).Info("ASR complete")
+	return resp, nil
+}
+
+// sendFullRequest sends the initial full client request frame describing the
+// audio and requested features.
+func (s *ASRService) sendFullRequest(conn *websocket.Conn, req *model.ASRRequest) error {
+	reqid := req.RequestID
+	if reqid == "" {
+		reqid = uuid.NewString()
+	}
+	payload := map[string]interface{}{
+		"app": map[string]interface{}{
+			"appid":   s.config.AppID,
+			"token":   s.config.Token,
+			"cluster": s.config.Cluster,
+		},
+		"user": map[string]interface{}{
+			"uid": uuid.NewString(),
+		},
+		"request": map[string]interface{}{
+			"reqid":           reqid,
+			"nbest":           1,
+			"result_type":     "full",
+			"sequence":        1,
+			"show_utterances": true,
+			"language":        req.Language,
+		},
+		"audio": map[string]interface{}{
+			"format":   req.Format,
+			"rate":     req.SampleRate,
+			"bits":     16,
+			"channel":  1,
+			"language": req.Language,
+		},
+	}
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	compressed, err := compressData(raw)
+	if err != nil {
+		return err
+	}
+	frame := buildFrame(asrFullClientRequest, 0, compressed)
+	return conn.WriteMessage(websocket.BinaryMessage, frame)
+}
+
+// sendAudioChunks streams the audio in fixed-size frames. The last chunk is
+// marked by a flag bit so the server knows the utterance is complete.
+func (s *ASRService) sendAudioChunks(conn *websocket.Conn, audioData []byte) error {
+	chunks := splitAudioData(audioData, asrChunkSize)
+	var framer audioFramer
+	for i, chunk := range chunks {
+		if err := s.sendAudioFrame(conn, &framer, chunk, i == len(chunks)-1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sendAudioFrame sends one audio-only frame, flagged when it is the last of
+// the utterance. The framer's buffers are reused across the request's frames.
+func (s *ASRService) sendAudioFrame(conn *websocket.Conn, framer *audioFramer, chunk []byte, last bool) error {
+	flags := byte(0)
+	if last {
+		flags = 0x2 // last audio frame
+	}
+	frame, err := framer.frameAudio(asrAudioOnlyRequest, flags, chunk)
+	if err != nil {
+		return err
+	}
+	return conn.WriteMessage(websocket.BinaryMessage, frame)
+}
+
+// receiveFinalResult reads server frames until the final transcription
+// arrives or an error frame is received.
+func (s *ASRService) receiveFinalResult(conn *websocket.Conn) (*model.ASRResponse, error) {
+	deadline := time.Now().Add(15 * time.Second)
+	for {
+		conn.SetReadDeadline(deadline)
+		_, raw, err := conn.ReadMessage()
+		if err != nil {
+			return nil, fmt.Errorf("read asr response: %w", err)
+		}
+		resp, final, err := s.parseResponse(raw)
+		if err != nil {
+			return nil, err
+		}
+		if final {
+			return resp, nil
+		}
+	}
+}
+
+// asrServerResponse mirrors the JSON payload of a full server response frame.
+type asrServerResponse struct {
+	ReqID    string `json:"reqid"`
+	Code     int    `json:"code"`
+	Message  string `json:"message"`
+	Sequence int    `json:"sequence"`
+	Result   []struct {
+		Text       string  `json:"text"`
+		Confidence float64 `json:"confidence"`
+		Language   string  `json:"language"`
+		Utterances []struct {
+			Text      string `json:"text"`
+			StartTime int64  `json:"start_time"`
+			EndTime   int64  `json:"end_time"`
+			Definite  bool   `json:"definite"`
+		} `json:"utterances"`
+	} `json:"result"`
+}
+
+// parseResponse decodes one server frame. The second return value reports
+// whether this frame carried the final result (negative sequence number).
+func (s *ASRService) parseResponse(raw []byte) (*model.ASRResponse, bool, error) {
+	if len(raw) < 4 {
+		return nil, false, fmt.Errorf("asr response frame too short: %d bytes", len(raw))
+	}
+	msgType := raw[1] >> 4
+	compression := raw[2] & 0x0F
+	payload := raw[4:]
+
+	if msgType == asrErrorResponse {
+		if len(payload) >= 8 {
+			code := binary.BigEndian.Uint32(payload[:4])
+			msg := payload[8:]
+			if compression == asrGzipCompression {
+				if d, err := decompressData(msg); err == nil {
+					msg = d
+				}
+			}
+			return nil, false, fmt.Errorf("asr server error %d: %s", code, string(msg))
+		}
+		return nil, false, fmt.Errorf("asr server error frame")
+	}
+
+	// Full server responses carry a 4-byte payload size before the JSON body.
+	if len(payload) > 4 {
+		payload = payload[4:]
+	}
+	if compression == asrGzipCompression {
+		d, err := decompressData(payload)
+		if err != nil {
+			return nil, false, fmt.Errorf("decompress asr response: %w", err)
+		}
+		payload = d
+	}
+
+	var body asrServerResponse
+	if err := json.Unmarshal(payload, &body); err != nil {
+		return nil, false, fmt.Errorf("parse asr response: %w", err)
+	}
+	if body.Code != 1000 && body.Code != 0 {
+		return nil, false, fmt.Errorf("asr error %d: %s", body.Code, body.Message)
+	}
+
+	final := body.Sequence < 0
+	resp := &model.ASRResponse{IsFinal: final}
+	if len(body.Result) > 0 {
+		r := body.Result[0]
+		resp.Text = r.Text
+		resp.Confidence = r.Confidence
+		resp.Language = r.Language
+		for _, u := range r.Utterances {
+			resp.Utterances = append(resp.Utterances, model.Utterance{
+				Text:        u.Text,
+				StartTimeMs: u.StartTime,
+				EndTimeMs:   u.EndTime,
+			})
+		}
+	}
+	return resp, final, nil
+}
+
+// buildFrame assembles a binary protocol frame: 4-byte header, 4-byte payload
+// size, then the payload.
+func buildFrame(msgType, flags byte, payload []byte) []byte {
+	return appendFrame(make([]byte, 0, 8+len(payload)), msgType, flags, payload)
+}
+
+// appendFrame appends one binary protocol frame to dst and returns the
+// extended buffer. Hot paths pass a reused buffer so framing an utterance's
+// worth of chunks costs no per-frame allocations.
+func appendFrame(dst []byte, msgType, flags byte, payload []byte) []byte {
+	dst = append(dst,
+		asrProtocolVersion<<4|asrHeaderSize,
+		msgType<<4|flags,
+		asrJSONSerialization<<4|asrGzipCompression,
+		0x00) // reserved
+	dst = binary.BigEndian.AppendUint32(dst, uint32(len(payload)))
+	return append(dst, payload...)
+}
+
+// audioFramer compresses and frames the audio chunks of one request. Its
+// buffers are reused from frame to frame, so each returned frame is only
+// valid until the next frameAudio call.
+type audioFramer struct {
+	compressed []byte
+	frame      []byte
+}
+
+// frameAudio builds one compressed audio frame in the framer's buffers.
+func (f *audioFramer) frameAudio(msgType, flags byte, chunk []byte) ([]byte, error) {
+	var err error
+	f.compressed, err = compressInto(f.compressed[:0], chunk)
+	if err != nil {
+		return nil, err
+	}
+	f.frame = appendFrame(f.frame[:0], msgType, flags, f.compressed)
+	return f.frame, nil
+}
+
+// splitAudioData slices the buffer into chunks of at most chunkSize bytes.
+// The returned slices alias the input buffer.
+func splitAudioData(data []byte, chunkSize int) [][]byte {
+	var chunks [][]byte
+	for len(data) > chunkSize {
+		chunks = append(chunks, data[:chunkSize])
+		data = data[chunkSize:]
+	}
+	if len(data) > 0 {
+		chunks = append(chunks, data)
+	}
+	return chunks
+}
+
+// gzipWriters pools gzip writers across requests: compression runs once per
+// 100 ms audio frame, and constructing a fresh writer each time dominated the
+// framing cost.
+var gzipWriters = sync.Pool{
+	New: func() interface{} { return gzip.NewWriter(io.Discard) },
+}
+
+// compressData gzips a payload for the wire.
+func compressData(data []byte) ([]byte, error) {
+	return compressInto(nil, data)
+}
+
+// compressInto gzips a payload, appending to dst so callers can reuse a
+// buffer's capacity across calls.
+func compressInto(dst, data []byte) ([]byte, error) {
+	buf := bytes.NewBuffer(dst)
+	w := gzipWriters.Get().(*gzip.Writer)
+	defer gzipWriters.Put(w)
+	w.Reset(buf)
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decompressData reverses compressData.
+func decompressData(data []byte) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
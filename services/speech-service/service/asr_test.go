@@ -0,0 +1,317 @@
+package service
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/config"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/testutil"
+)
+
+// buildServerFrame assembles a server-to-client frame: 4-byte header, then
+// the payload directly. Unlike the client frames buildFrame produces, server
+// frames carry no frame-level payload size word.
+func buildServerFrame(msgType, flags byte, payload []byte) []byte {
+	frame := make([]byte, 0, 4+len(payload))
+	frame = append(frame,
+		asrProtocolVersion<<4|asrHeaderSize,
+		msgType<<4|flags,
+		asrJSONSerialization<<4|asrGzipCompression,
+		0x00) // reserved
+	return append(frame, payload...)
+}
+
+// buildASRBodyFrame wraps an arbitrary JSON body in a full server response
+// frame, for exercising body-level error handling.
+func buildASRBodyFrame(t *testing.T, body map[string]interface{}) []byte {
+	t.Helper()
+	raw, err := json.Marshal(body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	compressed, err := compressData(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload := make([]byte, 4, 4+len(compressed))
+	binary.BigEndian.PutUint32(payload, uint32(len(compressed)))
+	payload = append(payload, compressed...)
+	return buildServerFrame(asrFullServerResponse, 0, payload)
+}
+
+func TestASRParseResponse(t *testing.T) {
+	svc := NewASRService(config.ASRConfig{}, logrus.New())
+
+	errorFrame := func() []byte {
+		compressed, _ := compressData([]byte("invalid audio"))
+		payload := make([]byte, 8, 8+len(compressed))
+		binary.BigEndian.PutUint32(payload[:4], 1013)
+		binary.BigEndian.PutUint32(payload[4:8], uint32(len(compressed)))
+		payload = append(payload, compressed...)
+		return buildServerFrame(asrErrorResponse, 0, payload)
+	}()
+
+	cases := []struct {
+		name      string
+		raw       []byte
+		wantText  string
+		wantFinal bool
+		wantErr   string
+	}{
+		{
+			name:      "final result",
+			raw:       testutil.BuildASRResultFrame("hello world", 0.95, -2),
+			wantText:  "hello world",
+			wantFinal: true,
+		},
+		{
+			name:      "intermediate result",
+			raw:       testutil.BuildASRResultFrame("hello", 0.8, 1),
+			wantText:  "hello",
+			wantFinal: false,
+		},
+		{
+			name: "body error code",
+			raw: buildASRBodyFrame(t, map[string]interface{}{
+				"code":    1002,
+				"message": "invalid token",
+			}),
+			wantErr: "asr error 1002",
+		},
+		{
+			name:    "error frame",
+			raw:     errorFrame,
+			wantErr: "asr server error 1013",
+		},
+		{
+			name:    "frame too short",
+			raw:     []byte{0x11, 0x90},
+			wantErr: "too short",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, final, err := svc.parseResponse(tc.raw)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("parseResponse error = %v, want containing %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseResponse: %v", err)
+			}
+			if resp.Text != tc.wantText {
+				t.Errorf("Text = %q, want %q", resp.Text, tc.wantText)
+			}
+			if final != tc.wantFinal {
+				t.Errorf("final = %v, want %v", final, tc.wantFinal)
+			}
+		})
+	}
+}
+
+func TestASRProcessAudio(t *testing.T) {
+	server := testutil.NewMockASRServer(testutil.MockASRConfig{
+		Transcript: "the cat sat on the mat",
+		Confidence: 0.92,
+	})
+	defer server.Close()
+
+	svc := NewASRService(config.ASRConfig{BaseURL: server.URL()}, logrus.New())
+	resp, err := svc.ProcessAudio(&model.ASRRequest{
+		AudioData:  make([]byte, 8000), // 2 full chunks + 1 partial at asrChunkSize
+		Format:     "pcm",
+		SampleRate: 16000,
+		Language:   "en-US",
+	})
+	if err != nil {
+		t.Fatalf("ProcessAudio: %v", err)
+	}
+	if resp.Text != "the cat sat on the mat" {
+		t.Errorf("Text = %q", resp.Text)
+	}
+	if resp.Confidence != 0.92 {
+		t.Errorf("Confidence = %v", resp.Confidence)
+	}
+	if !resp.IsFinal {
+		t.Error("IsFinal = false, want true")
+	}
+	if got := server.AudioFrames(); got != 3 {
+		t.Errorf("server received %d audio frames, want 3", got)
+	}
+	if !server.SawLastFlag() {
+		t.Error("server never saw the last-chunk flag")
+	}
+}
+
+func TestASRProcessAudioStream(t *testing.T) {
+	server := testutil.NewMockASRServer(testutil.MockASRConfig{
+		Transcript: "streamed utterance",
+		Confidence: 0.9,
+	})
+	defer server.Close()
+
+	chunks := make(chan []byte, 3)
+	for i := 0; i < 3; i++ {
+		chunks <- make([]byte, 1280)
+	}
+	close(chunks)
+
+	svc := NewASRService(config.ASRConfig{BaseURL: server.URL()}, logrus.New())
+	resp, err := svc.ProcessAudioStream(&model.ASRRequest{
+		Format:     "raw",
+		SampleRate: 16000,
+		Language:   "en-US",
+	}, chunks)
+	if err != nil {
+		t.Fatalf("ProcessAudioStream: %v", err)
+	}
+	if resp.Text != "streamed utterance" {
+		t.Errorf("Text = %q", resp.Text)
+	}
+	if got := server.AudioFrames(); got != 3 {
+		t.Errorf("server received %d audio frames, want 3", got)
+	}
+	if !server.SawLastFlag() {
+		t.Error("server never saw the last-chunk flag")
+	}
+}
+
+func TestASRProcessAudioStreamEmpty(t *testing.T) {
+	server := testutil.NewMockASRServer(testutil.MockASRConfig{Transcript: ""})
+	defer server.Close()
+
+	chunks := make(chan []byte)
+	close(chunks)
+
+	svc := NewASRService(config.ASRConfig{BaseURL: server.URL()}, logrus.New())
+	if _, err := svc.ProcessAudioStream(&model.ASRRequest{Format: "raw", SampleRate: 16000}, chunks); err != nil {
+		t.Fatalf("ProcessAudioStream with no audio: %v", err)
+	}
+	if !server.SawLastFlag() {
+		t.Error("empty stream did not end with a flagged frame")
+	}
+}
+
+func TestASRProcessAudioServerError(t *testing.T) {
+	server := testutil.NewMockASRServer(testutil.MockASRConfig{ErrorFrame: true})
+	defer server.Close()
+
+	svc := NewASRService(config.ASRConfig{BaseURL: server.URL()}, logrus.New())
+	_, err := svc.ProcessAudio(&model.ASRRequest{
+		AudioData:  make([]byte, 1000),
+		Format:     "pcm",
+		SampleRate: 16000,
+	})
+	if err == nil || !strings.Contains(err.Error(), "1013") {
+		t.Fatalf("ProcessAudio error = %v, want server error 1013", err)
+	}
+}
+
+func TestASRProcessAudioConnectionDropped(t *testing.T) {
+	server := testutil.NewMockASRServer(testutil.MockASRConfig{CloseEarly: true})
+	defer server.Close()
+
+	svc := NewASRService(config.ASRConfig{BaseURL: server.URL()}, logrus.New())
+	_, err := svc.ProcessAudio(&model.ASRRequest{
+		AudioData:  make([]byte, 1000),
+		Format:     "pcm",
+		SampleRate: 16000,
+	})
+	if err == nil {
+		t.Fatal("ProcessAudio succeeded despite dropped connection")
+	}
+}
+
+// TestAudioFramerMatchesBuildFrame pins down that the buffer-reusing framer
+// emits byte-identical frames to compressing and building each one fresh.
+func TestAudioFramerMatchesBuildFrame(t *testing.T) {
+	var framer audioFramer
+	for i, chunk := range [][]byte{
+		make([]byte, asrChunkSize),
+		[]byte("a short final chunk"),
+		nil,
+	} {
+		got, err := framer.frameAudio(asrAudioOnlyRequest, 0x2, chunk)
+		if err != nil {
+			t.Fatalf("frameAudio chunk %d: %v", i, err)
+		}
+		compressed, err := compressData(chunk)
+		if err != nil {
+			t.Fatalf("compressData chunk %d: %v", i, err)
+		}
+		want := buildFrame(asrAudioOnlyRequest, 0x2, compressed)
+		if !bytes.Equal(got, want) {
+			t.Errorf("chunk %d: framer output differs from fresh frame", i)
+		}
+	}
+}
+
+// BenchmarkFrameUtterance frames a 10-second utterance (100 chunks at
+// asrChunkSize) the way sendAudioChunks does, reusing one framer.
+func BenchmarkFrameUtterance(b *testing.B) {
+	utterance := make([]byte, 10*2*16000)
+	chunks := splitAudioData(utterance, asrChunkSize)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		var framer audioFramer
+		for _, chunk := range chunks {
+			if _, err := framer.frameAudio(asrAudioOnlyRequest, 0, chunk); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+// BenchmarkFrameUtteranceNoReuse is the pre-pooling baseline: a fresh gzip
+// writer, compression buffer, and frame per chunk.
+func BenchmarkFrameUtteranceNoReuse(b *testing.B) {
+	utterance := make([]byte, 10*2*16000)
+	chunks := splitAudioData(utterance, asrChunkSize)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, chunk := range chunks {
+			var buf bytes.Buffer
+			w := gzip.NewWriter(&buf)
+			if _, err := w.Write(chunk); err != nil {
+				b.Fatal(err)
+			}
+			if err := w.Close(); err != nil {
+				b.Fatal(err)
+			}
+			buildFrame(asrAudioOnlyRequest, 0, buf.Bytes())
+		}
+	}
+}
+
+func TestSplitAudioData(t *testing.T) {
+	cases := []struct {
+		size      int
+		chunkSize int
+		want      int
+	}{
+		{0, 3200, 0},
+		{100, 3200, 1},
+		{3200, 3200, 1},
+		{3201, 3200, 2},
+		{8000, 3200, 3},
+	}
+	for _, tc := range cases {
+		chunks := splitAudioData(make([]byte, tc.size), tc.chunkSize)
+		if len(chunks) != tc.want {
+			t.Errorf("splitAudioData(%d bytes, %d) = %d chunks, want %d",
+				tc.size, tc.chunkSize, len(chunks), tc.want)
+		}
+	}
+}
@@ -0,0 +1,234 @@
+package service
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/audio"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/config"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/logging"
+)
+
+var audioDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+	Name:    "audio_duration_distribution_ms",
+	Help:    "Duration of processed utterances in milliseconds.",
+	Buckets: []float64{250, 500, 1000, 2000, 4000, 8000, 16000, 30000, 60000},
+})
+
+// AudioService converts and cleans up uploaded audio so downstream providers
+// receive canonical 16 kHz mono WAV.
+type AudioService struct {
+	converter *audio.Converter
+	pipeline  *audio.Pipeline
+	agc       *audio.AGC
+	streamer  *audio.AudioStreamer
+	opus      *audio.OpusTranscoder
+	streaming bool
+	// silenceRMS and clippingWarnRatio are the pre-flight quality
+	// thresholds; 0 disables the respective verdict.
+	silenceRMS        float64
+	clippingWarnRatio float64
+	logger            *logrus.Logger
+}
+
+// AudioMetrics summarizes the preprocessing applied to the most recent
+// buffer, for logging and diagnostics.
+type AudioMetrics struct {
+	MaxGainAppliedDB float64 `json:"max_gain_applied_db"`
+}
+
+// AudioProcessingStats describes one utterance's journey through the
+// preprocessing pipeline: how large it was in each representation and what
+// the filters did to it. Amplitudes are normalized to 1.0 full scale.
+type AudioProcessingStats struct {
+	OriginalFormatBytes int     `json:"original_format_bytes"`
+	ConvertedPCMBytes   int     `json:"converted_pcm_bytes"`
+	WavBytes            int     `json:"wav_bytes"`
+	DurationMs          int     `json:"duration_ms"`
+	SampleRate          int     `json:"sample_rate"`
+	PeakAmplitude       float64 `json:"peak_amplitude"`
+	MeanAmplitude       float64 `json:"mean_amplitude"`
+	SilenceRemovedMs    int     `json:"silence_removed_ms"`
+	AGCGainDB           float64 `json:"agc_gain_db"`
+}
+
+// NewAudioService creates the audio preprocessing service.
+func NewAudioService(cfg config.AudioConfig, logger *logrus.Logger) *AudioService {
+	// Denoising runs before the gate so the gate sees the cleaned noise
+	// floor instead of the raw one; AGC runs after the gate so it doesn't
+	// boost the hiss the gate is about to cut.
+	var filters []audio.Filter
+	if cfg.DenoiseEnabled {
+		filters = append(filters, &audio.SpectralDenoiser{NoiseSampleMs: cfg.DenoiseNoiseSampleMs})
+	}
+	filters = append(filters, &audio.NoiseGate{Threshold: 50})
+	var agc *audio.AGC
+	if cfg.AGCEnabled {
+		agc = &audio.AGC{TargetRMS: cfg.AGCTargetRMS, MaxGainDB: cfg.AGCMaxGainDB}
+		filters = append(filters, agc)
+	}
+	converter := audio.NewConverter()
+	return &AudioService{
+		converter:         converter,
+		pipeline:          audio.NewPipeline(filters...),
+		agc:               agc,
+		streamer:          audio.NewAudioStreamer(converter),
+		opus:              audio.NewOpusTranscoder(converter),
+		streaming:         cfg.StreamingMode,
+		silenceRMS:        cfg.SilenceRMS,
+		clippingWarnRatio: cfg.ClippingWarnRatio,
+		logger:            logger,
+	}
+}
+
+// AnalyzeQuality runs the pre-flight check on an utterance's converted PCM.
+// silent reports RMS under the configured silence threshold (a muted or
+// disconnected microphone); clipped reports the clipping ratio over its
+// threshold (mic gain too high). A zero threshold disables that verdict.
+func (s *AudioService) AnalyzeQuality(pcm []byte) (report audio.QualityReport, silent, clipped bool) {
+	report = audio.AnalyzeQuality(pcm)
+	silent = s.silenceRMS > 0 && report.RMS < s.silenceRMS
+	clipped = s.clippingWarnRatio > 0 && report.ClippingRatio > s.clippingWarnRatio
+	return report, silent, clipped
+}
+
+// Converter exposes the underlying format converter.
+func (s *AudioService) Converter() *audio.Converter {
+	return s.converter
+}
+
+// Streamer exposes the chunked converter used by streaming mode.
+func (s *AudioService) Streamer() *audio.AudioStreamer {
+	return s.streamer
+}
+
+// OpusTranscoder exposes the Opus re-encoder used for sessions that
+// negotiated compressed TTS delivery.
+func (s *AudioService) OpusTranscoder() *audio.OpusTranscoder {
+	return s.opus
+}
+
+// StreamingEnabled reports whether the ASR stage should receive PCM chunks
+// as the utterance decodes instead of the fully buffered WAV.
+func (s *AudioService) StreamingEnabled() bool {
+	return s.streaming
+}
+
+// OptimizeAudio decodes an uploaded blob once into canonical 16 kHz mono PCM
+// and runs the filter pipeline over it. Both recognition stages consume this
+// PCM: ASR wrapped in the WAV framing its provider expects, ISE as the raw
+// samples its audio/L16 payload is declared to be. The returned stats
+// describe what each stage did to the buffer.
+func (s *AudioService) OptimizeAudio(audioData []byte, format string) ([]byte, *AudioProcessingStats, error) {
+	var pcm []byte
+	var err error
+
+	switch format {
+	case "webm", "ogg":
+		pcm, err = s.converter.ConvertWebMToPCM(audioData)
+		if err != nil {
+			return nil, nil, fmt.Errorf("convert %s to pcm: %w", format, err)
+		}
+	case "pcm":
+		pcm = audioData
+	case "wav":
+		// Strip the 44-byte RIFF header and re-frame below so the filter
+		// pipeline always sees raw samples.
+		if len(audioData) > 44 {
+			pcm = audioData[44:]
+		} else {
+			pcm = audioData
+		}
+	default:
+		return nil, nil, fmt.Errorf("unsupported audio format: %s", format)
+	}
+
+	decoded := pcm
+	pcm = s.pipeline.Process(pcm)
+
+	stats := &AudioProcessingStats{
+		OriginalFormatBytes: len(audioData),
+		ConvertedPCMBytes:   len(decoded),
+		// WAV framing adds the fixed 44-byte RIFF header.
+		WavBytes:         len(pcm) + 44,
+		DurationMs:       int(s.converter.GetAudioDuration(pcm).Milliseconds()),
+		SampleRate:       s.converter.SampleRate,
+		SilenceRemovedMs: s.silencedMs(decoded, pcm),
+		AGCGainDB:        s.Metrics().MaxGainAppliedDB,
+	}
+	stats.PeakAmplitude, stats.MeanAmplitude = measureAmplitude(pcm)
+	audioDuration.Observe(float64(stats.DurationMs))
+
+	fields := logrus.Fields{
+		logging.LogFieldService:    "audio",
+		logging.LogFieldAudioBytes: len(pcm),
+		"input_format":             format,
+		"input_bytes":              len(audioData),
+		logging.LogFieldDurationMs: stats.DurationMs,
+	}
+	if stats.AGCGainDB != 0 {
+		fields["agc_gain_db"] = stats.AGCGainDB
+	}
+	s.logger.WithFields(fields).Info("Audio optimized")
+	return pcm, stats, nil
+}
+
+// silencedMs reports how much of the utterance the noise gate muted: samples
+// that carried signal going into the pipeline and came out zeroed. The gate
+// mutes in place rather than trimming, so the buffer lengths match.
+func (s *AudioService) silencedMs(before, after []byte) int {
+	if len(before) != len(after) {
+		return 0
+	}
+	silenced := 0
+	for i := 0; i+1 < len(after); i += 2 {
+		if binary.LittleEndian.Uint16(after[i:]) == 0 &&
+			binary.LittleEndian.Uint16(before[i:]) != 0 {
+			silenced++
+		}
+	}
+	return silenced * 1000 / s.converter.SampleRate
+}
+
+// measureAmplitude returns the peak and mean absolute sample values of the
+// processed PCM, normalized so 1.0 is full scale.
+func measureAmplitude(pcm []byte) (peak, mean float64) {
+	samples := len(pcm) / 2
+	if samples == 0 {
+		return 0, 0
+	}
+	var sum float64
+	for i := 0; i+1 < len(pcm); i += 2 {
+		v := math.Abs(float64(int16(binary.LittleEndian.Uint16(pcm[i:]))))
+		if v > peak {
+			peak = v
+		}
+		sum += v
+	}
+	return peak / 32768, sum / float64(samples) / 32768
+}
+
+// OptimizeAudioForASR converts an uploaded blob to WAV suitable for the ASR
+// provider: the canonical PCM conversion plus WAV framing.
+func (s *AudioService) OptimizeAudioForASR(audioData []byte, format string) ([]byte, error) {
+	pcm, _, err := s.OptimizeAudio(audioData, format)
+	if err != nil {
+		return nil, err
+	}
+	return s.converter.ConvertPCMToWAV(pcm), nil
+}
+
+// Metrics reports preprocessing stats for the most recent buffer, including
+// the largest AGC gain applied (0 when AGC is disabled).
+func (s *AudioService) Metrics() AudioMetrics {
+	var m AudioMetrics
+	if s.agc != nil {
+		m.MaxGainAppliedDB = s.agc.MaxGainAppliedDB()
+	}
+	return m
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/config"
+)
+
+// TestOptimizeAudioStats pins down the per-utterance stats: one second of
+// audio whose second half sits under the noise gate threshold, so half the
+// buffer is muted and every size, duration, and amplitude field is known.
+func TestOptimizeAudioStats(t *testing.T) {
+	svc := NewAudioService(config.AudioConfig{}, logrus.New())
+
+	const samples = 16000 // one second at 16 kHz
+	pcm := make([]byte, samples*2)
+	for i := 0; i < samples; i++ {
+		amplitude := int16(4000)
+		if i >= samples/2 {
+			amplitude = 20 // under the gate threshold: muted
+		}
+		binary.LittleEndian.PutUint16(pcm[i*2:], uint16(amplitude))
+	}
+	wav := svc.Converter().ConvertPCMToWAV(pcm)
+
+	out, stats, err := svc.OptimizeAudio(wav, "wav")
+	if err != nil {
+		t.Fatalf("OptimizeAudio: %v", err)
+	}
+	if stats == nil {
+		t.Fatal("OptimizeAudio returned nil stats")
+	}
+
+	if stats.OriginalFormatBytes != len(wav) {
+		t.Errorf("OriginalFormatBytes = %d, want %d", stats.OriginalFormatBytes, len(wav))
+	}
+	if stats.ConvertedPCMBytes != len(pcm) {
+		t.Errorf("ConvertedPCMBytes = %d, want %d", stats.ConvertedPCMBytes, len(pcm))
+	}
+	if want := len(out) + 44; stats.WavBytes != want {
+		t.Errorf("WavBytes = %d, want %d", stats.WavBytes, want)
+	}
+	if stats.DurationMs != 1000 {
+		t.Errorf("DurationMs = %d, want 1000", stats.DurationMs)
+	}
+	if stats.SampleRate != 16000 {
+		t.Errorf("SampleRate = %d, want 16000", stats.SampleRate)
+	}
+	if stats.SilenceRemovedMs != 500 {
+		t.Errorf("SilenceRemovedMs = %d, want 500", stats.SilenceRemovedMs)
+	}
+	if want := 4000.0 / 32768; math.Abs(stats.PeakAmplitude-want) > 1e-9 {
+		t.Errorf("PeakAmplitude = %v, want %v", stats.PeakAmplitude, want)
+	}
+	// Half the samples at 4000, half muted to zero.
+	if want := 2000.0 / 32768; math.Abs(stats.MeanAmplitude-want) > 1e-9 {
+		t.Errorf("MeanAmplitude = %v, want %v", stats.MeanAmplitude, want)
+	}
+	if stats.AGCGainDB != 0 {
+		t.Errorf("AGCGainDB = %v, want 0 with AGC disabled", stats.AGCGainDB)
+	}
+}
@@ -0,0 +1,21 @@
+package service
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// providerDialer dials the streaming ASR and TTS providers. Both protocols
+// exchange compressed audio frames well past 100 KB, so the 4 KB gorilla
+// default buffers cost needless syscalls per frame; 32 KB matches the frame
+// sizes. The ISE dialer keeps its own smaller tuning (ise.go) because its
+// frames are short JSON envelopes. Proxy and handshake timeout mirror
+// websocket.DefaultDialer.
+var providerDialer = &websocket.Dialer{
+	Proxy:            http.ProxyFromEnvironment,
+	HandshakeTimeout: 45 * time.Second,
+	ReadBufferSize:   32 * 1024,
+	WriteBufferSize:  32 * 1024,
+}
@@ -0,0 +1,8 @@
+// Package service holds the provider clients that make up the voice tutoring
+// pipeline: ASR transcribes the learner's utterance, LLM generates the
+// tutor's reply, TTS renders it as audio, and ISE scores pronunciation
+// against a reference text. AudioService normalizes uploaded audio before it
+// reaches any provider, and UsageTracker meters LLM token spend. Each client
+// is constructed from its config.*Config section and is safe for concurrent
+// use by the handler's pipeline goroutines.
+package service
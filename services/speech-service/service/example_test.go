@@ -0,0 +1,114 @@
+package service_test
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/config"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/service"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/testutil"
+)
+
+// quietLogger keeps provider log lines out of example output.
+func quietLogger() *logrus.Logger {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	return logger
+}
+
+func ExampleASRService_ProcessAudio() {
+	// A scripted stand-in for the streaming ASR provider; production code
+	// points BaseURL at the real endpoint instead.
+	server := testutil.NewMockASRServer(testutil.MockASRConfig{
+		Transcript: "the cat sat on the mat",
+		Confidence: 0.92,
+	})
+	defer server.Close()
+
+	svc := service.NewASRService(config.ASRConfig{BaseURL: server.URL()}, quietLogger())
+	resp, err := svc.ProcessAudio(&model.ASRRequest{
+		AudioData:  make([]byte, 6400), // 200 ms of 16 kHz mono PCM16
+		Format:     "pcm",
+		SampleRate: 16000,
+		Language:   "en-US",
+	})
+	if err != nil {
+		fmt.Println("error:", err)
+		return
+	}
+	fmt.Println(resp.Text)
+	// Output: the cat sat on the mat
+}
+
+func ExampleTTSService_SynthesizeSpeech() {
+	server := testutil.NewMockTTSServer(testutil.MockTTSConfig{
+		AudioChunks: [][]byte{[]byte("first-half"), []byte("second-half")},
+	})
+	defer server.Close()
+
+	svc := service.NewTTSService(config.TTSConfig{BaseURL: server.URL()}, quietLogger())
+	resp, err := svc.SynthesizeSpeech(&model.TTSRequest{Text: "Welcome back!"})
+	if err != nil {
+		fmt.Println("error:", err)
+		return
+	}
+	fmt.Printf("%s, %d bytes\n", resp.Format, len(resp.AudioData))
+	// Output: mp3, 21 bytes
+}
+
+func ExampleISEService_EvaluateSpeech() {
+	server := testutil.NewMockISEServer(testutil.MockISEConfig{})
+	defer server.Close()
+
+	svc := service.NewISEService(config.ISEConfig{
+		AppID:     "demo-app",
+		APIKey:    "demo-key",
+		APISecret: "demo-secret",
+		BaseURL:   server.URL(),
+	}, quietLogger())
+
+	// Real callers pass the learner's recorded utterance; the samples must be
+	// audible or the silence filter drops them before upload.
+	audio := make([]byte, 16000)
+	for i := 0; i+1 < len(audio); i += 2 {
+		binary.LittleEndian.PutUint16(audio[i:], 4000)
+	}
+
+	resp, err := svc.EvaluateSpeech(context.Background(), &model.ISERequest{
+		AudioData: audio,
+		Text:      "hello there.",
+		Language:  "en_us",
+	})
+	if err != nil {
+		fmt.Println("error:", err)
+		return
+	}
+	fmt.Println(resp.OverallScore)
+	// Output: 85.5
+}
+
+func ExampleLLMService_GenerateResponse() {
+	server := testutil.NewMockLLMServer(testutil.MockLLMConfig{
+		ReplyText: "That sounds wonderful! What did you see at the museum?",
+	})
+	defer server.Close()
+
+	svc := service.NewLLMService(config.LLMConfig{
+		BaseURL:   server.URL(),
+		Model:     "demo-model",
+		MaxTokens: 100,
+	}, nil, quietLogger())
+
+	resp, err := svc.GenerateResponse("I visited the museum yesterday")
+	if err != nil {
+		fmt.Println("error:", err)
+		return
+	}
+	fmt.Println(resp.Text)
+	// Output: That sounds wonderful! What did you see at the museum?
+}
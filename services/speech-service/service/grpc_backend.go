@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+	speechv1 "github.com/CurvatureX/ai-tutor-monorepo/shared/proto/speech"
+)
+
+// backendRPCTimeout bounds a single proxied stage; generous because the
+// upstream itself talks to external providers.
+const backendRPCTimeout = 60 * time.Second
+
+// The gRPC backends are thin clients that forward each pipeline stage to
+// another speech-service instance over the shared proto's unary RPCs,
+// selected with BACKEND_MODE=grpc. They satisfy the same call signatures as
+// the direct provider services, so the WebSocket handler behaves identically
+// in both modes; only where the provider credentials live changes.
+
+// GRPCASRBackend forwards transcription to the upstream Transcribe RPC.
+type GRPCASRBackend struct {
+	client speechv1.SpeechServiceClient
+}
+
+// NewGRPCASRBackend creates the ASR proxy backend.
+func NewGRPCASRBackend(client speechv1.SpeechServiceClient) *GRPCASRBackend {
+	return &GRPCASRBackend{client: client}
+}
+
+// ProcessAudio mirrors ASRService.ProcessAudio over the unary RPC.
+func (b *GRPCASRBackend) ProcessAudio(req *model.ASRRequest) (*model.ASRResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), backendRPCTimeout)
+	defer cancel()
+	resp, err := b.client.Transcribe(ctx, &speechv1.TranscribeRequest{
+		AudioData:  req.AudioData,
+		Format:     req.Format,
+		SampleRate: int32(req.SampleRate),
+		Language:   req.Language,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &model.ASRResponse{
+		Text:       resp.Text,
+		Confidence: resp.Confidence,
+		IsFinal:    true,
+		Language:   resp.Language,
+	}, nil
+}
+
+// GRPCLLMBackend forwards reply generation to the upstream GenerateReply
+// RPC. The RPC is stateless, so this backend keeps the same local history
+// bookkeeping the direct LLMService does: the user turn and reply are
+// appended on success and nothing changes on failure.
+type GRPCLLMBackend struct {
+	client speechv1.SpeechServiceClient
+}
+
+// NewGRPCLLMBackend creates the LLM proxy backend.
+func NewGRPCLLMBackend(client speechv1.SpeechServiceClient) *GRPCLLMBackend {
+	return &GRPCLLMBackend{client: client}
+}
+
+// GenerateResponseWithHistory mirrors LLMService.GenerateResponseWithHistory
+// over the unary RPC.
+func (b *GRPCLLMBackend) GenerateResponseWithHistory(userText, cefrEstimate string, history *model.ConversationHistory) (*model.LLMResponse, error) {
+	req := &speechv1.GenerateReplyRequest{Text: userText, CefrLevel: cefrEstimate}
+	for _, msg := range history.Snapshot() {
+		req.History = append(req.History, &speechv1.ChatTurn{Role: msg.Role, Content: msg.Content})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), backendRPCTimeout)
+	defer cancel()
+	resp, err := b.client.GenerateReply(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	history.Append("user", userText)
+	history.Append("assistant", resp.Text)
+	return &model.LLMResponse{Text: resp.Text, TokensUsed: int(resp.TokensUsed)}, nil
+}
+
+// GeneratePracticeResponse satisfies the practice-mode side of the LLM
+// provider contract. The GenerateReply RPC carries no mode, so proxied
+// sessions always get free conversation regardless of the requested mode.
+func (b *GRPCLLMBackend) GeneratePracticeResponse(userText, cefrEstimate string, _ PracticeMode, _ string, history *model.ConversationHistory) (*model.LLMResponse, error) {
+	return b.GenerateResponseWithHistory(userText, cefrEstimate, history)
+}
+
+// GRPCTTSBackend forwards synthesis to the upstream Synthesize RPC.
+type GRPCTTSBackend struct {
+	client speechv1.SpeechServiceClient
+}
+
+// NewGRPCTTSBackend creates the TTS proxy backend.
+func NewGRPCTTSBackend(client speechv1.SpeechServiceClient) *GRPCTTSBackend {
+	return &GRPCTTSBackend{client: client}
+}
+
+// SynthesizeSpeech mirrors TTSService.SynthesizeSpeech over the unary RPC.
+func (b *GRPCTTSBackend) SynthesizeSpeech(req *model.TTSRequest) (*model.TTSResponse, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), backendRPCTimeout)
+	defer cancel()
+	resp, err := b.client.Synthesize(ctx, &speechv1.SynthesizeRequest{
+		Text:     req.Text,
+		Voice:    req.Voice,
+		Language: req.Language,
+		Speed:    req.Speed,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &model.TTSResponse{
+		AudioData:  resp.AudioData,
+		Format:     resp.Format,
+		DurationMs: resp.DurationMs,
+	}, nil
+}
+
+// SynthesizeSpeechWithWordTimings satisfies the word-timings side of the TTS
+// provider contract. The upstream Synthesize RPC does not carry boundary
+// data, so proxied sessions get the plain synthesis and no word_boundary
+// events.
+func (b *GRPCTTSBackend) SynthesizeSpeechWithWordTimings(req *model.TTSRequest) (*model.TTSResponse, error) {
+	return b.SynthesizeSpeech(req)
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+)
+
+// ExportFormat selects the serialization of an exported conversation history.
+type ExportFormat string
+
+const (
+	// ExportFormatJSON is the canonical machine-readable export; importing it
+	// reproduces the history exactly.
+	ExportFormatJSON ExportFormat = "json"
+	// ExportFormatText is a human-readable transcript, one "[role] content"
+	// block per message separated by blank lines. It round-trips as long as
+	// no message content contains a blank line of its own.
+	ExportFormatText ExportFormat = "text"
+)
+
+// ConversationHistoryExporter serializes a session's conversation history so
+// learners practicing across multiple days can carry it into a later session.
+type ConversationHistoryExporter struct{}
+
+// NewConversationHistoryExporter creates the exporter.
+func NewConversationHistoryExporter() *ConversationHistoryExporter {
+	return &ConversationHistoryExporter{}
+}
+
+// Export serializes the history in the given format.
+func (e *ConversationHistoryExporter) Export(history *model.ConversationHistory, format ExportFormat) ([]byte, error) {
+	switch format {
+	case ExportFormatJSON:
+		// Marshal a snapshot: a live session keeps appending while its
+		// history is exported.
+		return json.MarshalIndent(&model.ConversationHistory{Messages: history.Snapshot()}, "", "  ")
+	case ExportFormatText:
+		var b strings.Builder
+		for i, msg := range history.Snapshot() {
+			if i > 0 {
+				b.WriteString("\n\n")
+			}
+			fmt.Fprintf(&b, "[%s] %s", msg.Role, msg.Content)
+		}
+		return []byte(b.String()), nil
+	}
+	return nil, fmt.Errorf("unsupported export format %q", format)
+}
+
+// ConversationHistoryImporter parses a history produced by the exporter.
+type ConversationHistoryImporter struct{}
+
+// NewConversationHistoryImporter creates the importer.
+func NewConversationHistoryImporter() *ConversationHistoryImporter {
+	return &ConversationHistoryImporter{}
+}
+
+// Import parses a serialized history in the given format.
+func (i *ConversationHistoryImporter) Import(data []byte, format ExportFormat) (*model.ConversationHistory, error) {
+	switch format {
+	case ExportFormatJSON:
+		history := &model.ConversationHistory{}
+		if err := json.Unmarshal(data, history); err != nil {
+			return nil, fmt.Errorf("parse history JSON: %w", err)
+		}
+		return history, nil
+	case ExportFormatText:
+		history := &model.ConversationHistory{}
+		text := strings.TrimSpace(string(data))
+		if text == "" {
+			return history, nil
+		}
+		for _, block := range strings.Split(text, "\n\n") {
+			role, content, ok := parseHistoryBlock(block)
+			if !ok {
+				return nil, fmt.Errorf("malformed history block %q: want \"[role] content\"", firstLine(block))
+			}
+			history.Append(role, content)
+		}
+		return history, nil
+	}
+	return nil, fmt.Errorf("unsupported export format %q", format)
+}
+
+// parseHistoryBlock splits one "[role] content" text block.
+func parseHistoryBlock(block string) (role, content string, ok bool) {
+	if !strings.HasPrefix(block, "[") {
+		return "", "", false
+	}
+	end := strings.Index(block, "] ")
+	if end < 0 {
+		return "", "", false
+	}
+	return block[1:end], block[end+2:], true
+}
+
+// firstLine truncates a block to its first line for error messages.
+func firstLine(block string) string {
+	if i := strings.IndexByte(block, '\n'); i >= 0 {
+		return block[:i]
+	}
+	return block
+}
+
+// TrimToRecentTurns returns the tail of a history covering at most maxTurns
+// user turns (a user message and the replies that follow it), preserving a
+// leading system prompt. Resumed sessions use it to keep long practice
+// streaks from overflowing the LLM context.
+func TrimToRecentTurns(history *model.ConversationHistory, maxTurns int) *model.ConversationHistory {
+	messages := history.Snapshot()
+	var system []model.ChatMessage
+	if len(messages) > 0 && messages[0].Role == "system" {
+		system = messages[:1]
+		messages = messages[1:]
+	}
+
+	turns := 0
+	start := 0
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Role == "user" {
+			turns++
+			if turns == maxTurns {
+				start = i
+				break
+			}
+		}
+	}
+
+	trimmed := &model.ConversationHistory{}
+	trimmed.Messages = append(trimmed.Messages, system...)
+	trimmed.Messages = append(trimmed.Messages, messages[start:]...)
+	return trimmed
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+)
+
+func sampleHistory() *model.ConversationHistory {
+	history := &model.ConversationHistory{}
+	history.Append("system", "You are a friendly language tutor.")
+	history.Append("user", "I visited the museum yesterday.")
+	history.Append("assistant", "That sounds lovely! Which exhibit did you like best?")
+	return history
+}
+
+func TestHistoryExportImportRoundTrip(t *testing.T) {
+	exporter := NewConversationHistoryExporter()
+	importer := NewConversationHistoryImporter()
+
+	for _, format := range []ExportFormat{ExportFormatJSON, ExportFormatText} {
+		t.Run(string(format), func(t *testing.T) {
+			original := sampleHistory()
+			data, err := exporter.Export(original, format)
+			if err != nil {
+				t.Fatalf("Export: %v", err)
+			}
+			imported, err := importer.Import(data, format)
+			if err != nil {
+				t.Fatalf("Import: %v", err)
+			}
+			if !reflect.DeepEqual(imported, original) {
+				t.Errorf("round trip changed the history:\ngot  %+v\nwant %+v", imported, original)
+			}
+		})
+	}
+}
+
+func TestHistoryTextExportIsReadable(t *testing.T) {
+	data, err := NewConversationHistoryExporter().Export(sampleHistory(), ExportFormatText)
+	if err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+	text := string(data)
+	if !strings.Contains(text, "[user] I visited the museum yesterday.") {
+		t.Errorf("text export missing the user turn:\n%s", text)
+	}
+	if !strings.Contains(text, "[assistant] That sounds lovely!") {
+		t.Errorf("text export missing the tutor turn:\n%s", text)
+	}
+}
+
+func TestHistoryUnsupportedFormat(t *testing.T) {
+	if _, err := NewConversationHistoryExporter().Export(sampleHistory(), "xml"); err == nil {
+		t.Error("Export accepted an unsupported format")
+	}
+	if _, err := NewConversationHistoryImporter().Import([]byte("{}"), "xml"); err == nil {
+		t.Error("Import accepted an unsupported format")
+	}
+}
+
+func TestHistoryImportMalformedText(t *testing.T) {
+	if _, err := NewConversationHistoryImporter().Import([]byte("no role marker here"), ExportFormatText); err == nil {
+		t.Error("Import accepted a text block without a role marker")
+	}
+}
+
+func TestTrimToRecentTurns(t *testing.T) {
+	history := &model.ConversationHistory{}
+	history.Append("system", "You are a friendly language tutor.")
+	for i := 1; i <= 25; i++ {
+		history.Append("user", fmt.Sprintf("user turn %d", i))
+		history.Append("assistant", fmt.Sprintf("reply %d", i))
+	}
+
+	trimmed := TrimToRecentTurns(history, 20)
+	if len(trimmed.Messages) != 1+2*20 {
+		t.Fatalf("trimmed to %d messages, want the system prompt plus 20 turns", len(trimmed.Messages))
+	}
+	if trimmed.Messages[0].Role != "system" {
+		t.Errorf("first message role = %q, want the preserved system prompt", trimmed.Messages[0].Role)
+	}
+	if got := trimmed.Messages[1].Content; got != "user turn 6" {
+		t.Errorf("oldest kept turn = %q, want %q", got, "user turn 6")
+	}
+	if got := trimmed.Messages[len(trimmed.Messages)-1].Content; got != "reply 25" {
+		t.Errorf("newest kept message = %q, want %q", got, "reply 25")
+	}
+
+	short := sampleHistory()
+	if got := TrimToRecentTurns(short, 20); !reflect.DeepEqual(got.Messages, short.Messages) {
+		t.Errorf("short history changed by trimming: %+v", got.Messages)
+	}
+}
@@ -0,0 +1,714 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/gorilla/websocket"
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/audio"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/config"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/logging"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+)
+
+// iFlytek audio upload status values for the auw command.
+const (
+	iseAudioFirst  = 1
+	iseAudioMiddle = 2
+	iseAudioLast   = 4
+)
+
+// ISEService evaluates pronunciation using the iFlytek ISE WebSocket API.
+type ISEService struct {
+	config     config.ISEConfig
+	limits     ISEAPILimits
+	normalizer *ISEScoringNormalizer
+	pinyin     *ChinesePinyinPreprocessor
+	rotator    CredentialRotator
+	protocol   *ProtocolLogger
+	// cache, when non-nil, serves repeated evaluations of byte-identical
+	// audio against the same reference without re-billing the provider.
+	cache         ISEResultCache
+	fingerprinter audio.Fingerprinter
+	// scheduler, when non-nil, runs evaluations through a bounded worker
+	// pool that scores interactive turns ahead of batch work; nil calls the
+	// provider directly.
+	scheduler *ISEScheduler
+	logger    *logrus.Logger
+}
+
+// NewISEService creates the ISE client.
+func NewISEService(cfg config.ISEConfig, logger *logrus.Logger) *ISEService {
+	normalizer, err := NewISEScoringNormalizer(cfg.CalibrationPath)
+	if err != nil {
+		logger.Warnf("Falling back to default ISE calibration: %v", err)
+		normalizer = &ISEScoringNormalizer{bands: defaultCalibrationBands}
+	}
+	limits := ISEAPILimits{
+		MaxMessageBytes:   cfg.MaxMessageBytes,
+		JSONOverheadBytes: cfg.JSONOverheadBytes,
+	}
+	if limits.MaxMessageBytes <= 0 {
+		limits.MaxMessageBytes = defaultISEAPILimits.MaxMessageBytes
+	}
+	if limits.JSONOverheadBytes <= 0 {
+		limits.JSONOverheadBytes = defaultISEAPILimits.JSONOverheadBytes
+	}
+	var protocol *ProtocolLogger
+	if cfg.ProtocolLogEnabled {
+		protocol, err = NewProtocolLogger(cfg.ProtocolLogPath)
+		if err != nil {
+			logger.Warnf("ISE protocol log disabled: %v", err)
+		}
+	}
+	var cache ISEResultCache
+	if cfg.CacheEnabled {
+		size := cfg.CacheSize
+		if size <= 0 {
+			size = 1000
+		}
+		cache = NewMemoryISEResultCache(size, iseCacheTTL, logger)
+	}
+	s := &ISEService{
+		config:     cfg,
+		limits:     limits,
+		normalizer: normalizer,
+		pinyin:     NewChinesePinyinPreprocessor(),
+		rotator:    newCredentialRotator(cfg, logger),
+		protocol:   protocol,
+		cache:      cache,
+		logger:     logger,
+	}
+	if cfg.SchedulerMaxConcurrent > 0 {
+		s.scheduler = NewISEScheduler(cfg.SchedulerMaxConcurrent, s.evaluateSpeech, logger)
+	}
+	return s
+}
+
+// Scheduler exposes the priority scheduler for diagnostics and shutdown;
+// nil when ISE_SCHEDULER_MAX_CONCURRENT left scheduling disabled.
+func (s *ISEService) Scheduler() *ISEScheduler {
+	return s.scheduler
+}
+
+// FlushProtocolLog drains the buffered protocol log, if enabled. Called on
+// graceful shutdown so the tail of a debugging session isn't lost.
+func (s *ISEService) FlushProtocolLog() {
+	if err := s.protocol.Flush(); err != nil {
+		s.logger.Warnf("ISE protocol log flush failed: %v", err)
+	}
+}
+
+// ProsodyComparisonEnabled reports whether a prosody comparison against a
+// synthesized rendition should follow each evaluation.
+func (s *ISEService) ProsodyComparisonEnabled() bool {
+	return s.config.ProsodyComparison
+}
+
+// EvaluateSpeechNormalized evaluates pronunciation and additionally fills in
+// NormalizedScore, the raw overall score calibrated for the difficulty of the
+// reference text. OverallScore stays raw so both scales remain available.
+func (s *ISEService) EvaluateSpeechNormalized(ctx context.Context, req *model.ISERequest) (*model.ISEResponse, error) {
+	resp, err := s.EvaluateSpeech(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	resp.NormalizedScore = s.normalizer.Calibrate(resp.OverallScore, AnalyzeTextComplexity(req.Text))
+	return resp, nil
+}
+
+// EvaluateSpeechNormalizedBatch is EvaluateSpeechNormalized at batch
+// priority: with the scheduler enabled, the job queues behind every
+// interactive turn.
+func (s *ISEService) EvaluateSpeechNormalizedBatch(ctx context.Context, req *model.ISERequest) (*model.ISEResponse, error) {
+	resp, err := s.EvaluateSpeechBatch(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	resp.NormalizedScore = s.normalizer.Calibrate(resp.OverallScore, AnalyzeTextComplexity(req.Text))
+	return resp, nil
+}
+
+// EvaluateSpeech runs a full pronunciation evaluation of the audio against
+// the reference text. Audio must be raw 16 kHz mono 16-bit PCM. Cancelling
+// ctx aborts the evaluation mid-flight and closes the provider connection, so
+// a client disconnect doesn't leave the upload running to completion. With
+// the priority scheduler enabled the evaluation queues at interactive
+// priority.
+func (s *ISEService) EvaluateSpeech(ctx context.Context, req *model.ISERequest) (*model.ISEResponse, error) {
+	return s.schedule(ctx, req, ISEPriorityInteractive)
+}
+
+// EvaluateSpeechBatch evaluates like EvaluateSpeech but at batch priority,
+// for long recordings and other background work nobody is waiting on.
+func (s *ISEService) EvaluateSpeechBatch(ctx context.Context, req *model.ISERequest) (*model.ISEResponse, error) {
+	return s.schedule(ctx, req, ISEPriorityBatch)
+}
+
+// schedule routes one evaluation through the priority scheduler, falling
+// back to a direct provider call when none is configured. An abandoned wait
+// leaves the job to run (or fail) under its own context; the worker delivers
+// into the buffered result channel and moves on.
+func (s *ISEService) schedule(ctx context.Context, req *model.ISERequest, priority int) (*model.ISEResponse, error) {
+	if s.scheduler == nil {
+		return s.evaluateSpeech(ctx, req)
+	}
+	results := s.scheduler.Submit(&ISEJob{Priority: priority, Request: req, ctx: ctx})
+	select {
+	case res := <-results:
+		return res.Response, res.Err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// evaluateSpeech is the direct provider round trip behind the Evaluate*
+// methods.
+func (s *ISEService) evaluateSpeech(ctx context.Context, req *model.ISERequest) (*model.ISEResponse, error) {
+	start := time.Now()
+
+	if req.ApplyToneSandhi && req.Language == "zh_cn" {
+		req.Text = s.pinyin.ApplyToneSandhi(req.Text)
+	}
+
+	if err := s.ValidateAudioForISE(req.AudioData, req.Language); err != nil {
+		return nil, err
+	}
+
+	// Byte-identical audio scored against the same reference yields the
+	// same result, so serve repeats from the cache instead of re-billing
+	// the provider. The fingerprint is computed after tone sandhi so the
+	// key matches the text actually evaluated.
+	var fingerprint uint64
+	if s.cache != nil {
+		fingerprint = s.fingerprinter.Compute(req.AudioData)
+		if cached, ok := s.cache.Get(fingerprint, req.Text, req.Language); ok {
+			return cached, nil
+		}
+	}
+
+	conn, err := s.dialWithRetry(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("connect to ise: %w", err)
+	}
+	defer conn.Close()
+
+	if err := s.sendBusinessParameters(conn, req); err != nil {
+		return nil, fmt.Errorf("send ise parameters: %w", err)
+	}
+
+	resp, err := s.sendAudioAndGetResults(ctx, conn, req)
+	if err != nil {
+		return nil, err
+	}
+	resp.ReferenceText = req.Text
+	if s.cache != nil {
+		s.cache.Put(fingerprint, req.Text, req.Language, resp)
+	}
+	s.logger.WithFields(logrus.Fields{
+		logging.LogFieldService:    "ise",
+		logging.LogFieldScore:      resp.OverallScore,
+		logging.LogFieldAudioBytes: len(req.AudioData),
+		logging.LogFieldDurationMs: time.Since(start).Milliseconds(),
+	}).Info("ISE complete")
+	return resp, nil
+}
+
+// createAuthenticatedConnection makes one dial attempt against the ISE
+// endpoint with a freshly signed URL. The handshake response is returned so
+// dialWithRetry can classify rejections; callers wanting retries should use
+// dialWithRetry instead.
+func (s *ISEService) createAuthenticatedConnection(ctx context.Context) (*websocket.Conn, *http.Response, error) {
+	authURL, err := s.generateAuthURL()
+	if err != nil {
+		return nil, nil, err
+	}
+	dialer := websocket.Dialer{
+		HandshakeTimeout: 5 * time.Second,
+		ReadBufferSize:   4096,
+		WriteBufferSize:  4096,
+	}
+	return dialer.DialContext(ctx, authURL, nil)
+}
+
+// generateAuthURL builds the HMAC-SHA256 signed WebSocket URL required by the
+// iFlytek open platform.
+func (s *ISEService) generateAuthURL() (string, error) {
+	u, err := url.Parse(s.config.BaseURL)
+	if err != nil {
+		return "", err
+	}
+	date := time.Now().UTC().Format(time.RFC1123)
+	date = strings.Replace(date, "UTC", "GMT", 1)
+
+	authorization := s.generateAuthorization(u.Host, u.Path, date)
+	q := url.Values{}
+	q.Set("authorization", authorization)
+	q.Set("date", date)
+	q.Set("host", u.Host)
+	u.RawQuery = q.Encode()
+	return u.String(), nil
+}
+
+// generateAuthorization computes the base64 authorization parameter from the
+// request line and date header. Credentials come from the rotator on every
+// call, so each new connection signs with the current secret.
+func (s *ISEService) generateAuthorization(host, path, date string) string {
+	creds := s.rotator.Current()
+	signatureOrigin := fmt.Sprintf("host: %s\ndate: %s\nGET %s HTTP/1.1", host, date, path)
+	mac := hmac.New(sha256.New, []byte(creds.APISecret))
+	mac.Write([]byte(signatureOrigin))
+	signature := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	origin := fmt.Sprintf(
+		`api_key="%s", algorithm="hmac-sha256", headers="host date request-line", signature="%s"`,
+		creds.APIKey, signature)
+	return base64.StdEncoding.EncodeToString([]byte(origin))
+}
+
+// sendBusinessParameters sends the ssb frame that configures the evaluation
+// category, language and reference text.
+func (s *ISEService) sendBusinessParameters(conn *websocket.Conn, req *model.ISERequest) error {
+	category := req.Category
+	if category == "" {
+		category = determineCategory(req.Text, req.Language)
+	}
+	ent := "en_vip"
+	if req.Language == "zh_cn" {
+		ent = "cn_vip"
+	}
+	msg := map[string]interface{}{
+		"common": map[string]interface{}{
+			"app_id": s.config.AppID,
+		},
+		"business": map[string]interface{}{
+			"category": category,
+			"sub":      "ise",
+			"ent":      ent,
+			"cmd":      "ssb",
+			"auf":      "audio/L16;rate=16000",
+			"aue":      "raw",
+			// iFlytek requires a UTF-8 BOM prefix on the reference text.
+			"text":          "\ufeff" + req.Text,
+			"tte":           "utf-8",
+			"ttp_skip":      true,
+			"rstcd":         "utf8",
+			"extra_ability": "syll_phone_err_msg;pitch;multi_dimension",
+		},
+		"data": map[string]interface{}{
+			"status": 0,
+		},
+	}
+	return s.sendJSONMessage(conn, msg)
+}
+
+// determineCategory picks the iFlytek evaluation category from the shape of
+// the reference text.
+func determineCategory(text, language string) string {
+	trimmed := strings.TrimSpace(text)
+	sentences := 0
+	for _, r := range trimmed {
+		if r == '.' || r == '!' || r == '?' || r == '。' || r == '！' || r == '？' {
+			sentences++
+		}
+	}
+	words := strings.Fields(trimmed)
+	runes := []rune(trimmed)
+	switch {
+	// Only an individual character is a syllable evaluation; a pinyin
+	// syllable like "hǎo" is scored as a word.
+	case language == "zh_cn" && len(runes) == 1 && unicode.Is(unicode.Han, runes[0]):
+		return "read_syllable"
+	case len(words) == 1 && sentences == 0:
+		return "read_word"
+	case sentences > 1:
+		return "read_chapter"
+	default:
+		return "read_sentence"
+	}
+}
+
+// sendAudioAndGetResults uploads the audio in auw frames and waits for the
+// final evaluation result.
+func (s *ISEService) sendAudioAndGetResults(ctx context.Context, conn *websocket.Conn, req *model.ISERequest) (*model.ISEResponse, error) {
+	// Chunk size is derived from the provider's message size limit; see
+	// ComputeMaxAudioBytesPerChunk for the math.
+	maxISEChunkSize := s.ComputeMaxAudioBytesPerChunk(s.limits)
+
+	// Drop silent chunks first: the evaluation ignores leading/trailing
+	// silence anyway and smaller uploads avoid provider-side timeouts.
+	chunks := filterSilentChunks(splitAudioData(req.AudioData, maxISEChunkSize), s.silenceConfigFor(req.Language))
+	if len(chunks) == 0 {
+		return nil, fmt.Errorf("audio contained only silence")
+	}
+
+	if len(chunks) == 1 {
+		if err := s.sendSingleAudioChunk(ctx, conn, chunks[0]); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := s.sendMultipleAudioChunks(ctx, conn, chunks); err != nil {
+			return nil, err
+		}
+	}
+	return s.receiveFinalResult(ctx, conn)
+}
+
+// sendSingleAudioChunk uploads one chunk marked as both first and last.
+func (s *ISEService) sendSingleAudioChunk(ctx context.Context, conn *websocket.Conn, chunk []byte) error {
+	if err := s.sendAudioFrame(conn, chunk, iseAudioFirst, 1); err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		s.abortEvaluation(conn)
+		return err
+	}
+	return s.sendAudioFrame(conn, nil, iseAudioLast, 2)
+}
+
+// sendMultipleAudioChunks uploads the chunks in sequence with the proper
+// first/middle/last status markers.
+func (s *ISEService) sendMultipleAudioChunks(ctx context.Context, conn *websocket.Conn, chunks [][]byte) error {
+	// The chunks can be sent as-is: splitAudioData only produces a short
+	// chunk in the last position, so after the silence filter every chunk but
+	// the final one is still full-size and the frame boundaries match what
+	// re-combining and re-splitting the buffer would yield, without the copy.
+	for i, chunk := range chunks {
+		if err := ctx.Err(); err != nil {
+			s.abortEvaluation(conn)
+			return err
+		}
+		aus := iseAudioMiddle
+		if i == 0 {
+			aus = iseAudioFirst
+		}
+		if err := s.sendAudioFrame(conn, chunk, aus, 1); err != nil {
+			return err
+		}
+	}
+	return s.sendAudioFrame(conn, nil, iseAudioLast, 2)
+}
+
+// sendAudioFrame sends one auw frame. status follows the iFlytek data.status
+// convention (1 = uploading, 2 = done).
+func (s *ISEService) sendAudioFrame(conn *websocket.Conn, chunk []byte, aus, status int) error {
+	msg := map[string]interface{}{
+		"business": map[string]interface{}{
+			"cmd": "auw",
+			"aus": aus,
+		},
+		"data": map[string]interface{}{
+			"status": status,
+			"data":   base64.StdEncoding.EncodeToString(chunk),
+		},
+	}
+	return s.sendJSONMessage(conn, msg)
+}
+
+// sendJSONMessage marshals and writes one JSON text frame.
+func (s *ISEService) sendJSONMessage(conn *websocket.Conn, msg map[string]interface{}) error {
+	raw, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	s.logger.Debugf("ISE send: %d bytes", len(raw))
+	s.protocol.Log("send", raw)
+	return conn.WriteMessage(websocket.TextMessage, raw)
+}
+
+// iseServerResponse mirrors the iFlytek response envelope.
+type iseServerResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Sid     string `json:"sid"`
+	Data    struct {
+		Status int    `json:"status"`
+		Data   string `json:"data"` // base64 XML
+	} `json:"data"`
+}
+
+// receiveFinalResult reads frames until the server reports status 2 and
+// parses the base64 XML result payload.
+func (s *ISEService) receiveFinalResult(ctx context.Context, conn *websocket.Conn) (*model.ISEResponse, error) {
+	for {
+		raw, err := s.readResponseWithTimeout(ctx, conn, 20*time.Second)
+		if err != nil {
+			return nil, err
+		}
+		var resp iseServerResponse
+		if err := json.Unmarshal(raw, &resp); err != nil {
+			return nil, fmt.Errorf("parse ise response: %w", err)
+		}
+		if resp.Code != 0 {
+			return nil, fmt.Errorf("ise error %d: %s (sid=%s)", resp.Code, resp.Message, resp.Sid)
+		}
+		if resp.Data.Status == 2 {
+			xmlData, err := base64.StdEncoding.DecodeString(resp.Data.Data)
+			if err != nil {
+				return nil, fmt.Errorf("decode ise result: %w", err)
+			}
+			return parseISEResultXML(xmlData)
+		}
+	}
+}
+
+// readResponseWithTimeout reads one frame with a deadline, aborting early if
+// ctx is cancelled while the read blocks.
+func (s *ISEService) readResponseWithTimeout(ctx context.Context, conn *websocket.Conn, timeout time.Duration) ([]byte, error) {
+	conn.SetReadDeadline(time.Now().Add(timeout))
+
+	type readResult struct {
+		raw []byte
+		err error
+	}
+	readCh := make(chan readResult, 1)
+	go func() {
+		_, raw, err := conn.ReadMessage()
+		readCh <- readResult{raw: raw, err: err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		// EvaluateSpeech closes the connection on return, which also
+		// unblocks the read goroutine.
+		s.abortEvaluation(conn)
+		return nil, ctx.Err()
+	case r := <-readCh:
+		if r.err != nil {
+			return nil, fmt.Errorf("read ise response: %w", r.err)
+		}
+		s.logger.Debugf("ISE recv: %d bytes", len(r.raw))
+		s.protocol.Log("recv", r.raw)
+		return r.raw, nil
+	}
+}
+
+// abortEvaluation tells the provider the evaluation is over before the
+// connection is torn down, so cancelled sessions don't keep consuming the
+// provider's processing quota.
+func (s *ISEService) abortEvaluation(conn *websocket.Conn) {
+	msg := websocket.FormatCloseMessage(websocket.CloseGoingAway, "evaluation cancelled")
+	if err := conn.WriteControl(websocket.CloseMessage, msg, time.Now().Add(time.Second)); err != nil {
+		s.logger.Debugf("ISE close message failed: %v", err)
+	}
+}
+
+// XML result structures. iFlytek nests the scored paper under rec_paper with
+// one element named after the category.
+type iseXMLResult struct {
+	XMLName  xml.Name `xml:"xml_result"`
+	RecPaper struct {
+		Entries []iseXMLPaper `xml:",any"`
+	} `xml:"read_sentence>rec_paper"`
+}
+
+type iseXMLPaper struct {
+	TotalScore     float64      `xml:"total_score,attr"`
+	AccuracyScore  float64      `xml:"accuracy_score,attr"`
+	FluencyScore   float64      `xml:"fluency_score,attr"`
+	IntegrityScore float64      `xml:"integrity_score,attr"`
+	Sentences      []iseXMLSent `xml:"sentence"`
+	Words          []iseXMLWord `xml:"word"`
+}
+
+type iseXMLSent struct {
+	Words []iseXMLWord `xml:"word"`
+}
+
+type iseXMLWord struct {
+	Content    string       `xml:"content,attr"`
+	TotalScore float64      `xml:"total_score,attr"`
+	DpMessage  int          `xml:"dp_message,attr"`
+	Sylls      []iseXMLSyll `xml:"syll"`
+}
+
+type iseXMLSyll struct {
+	Content string `xml:"content,attr"`
+	// Pinyin is the expected pronunciation of the syllable, present in
+	// Chinese evaluations.
+	Pinyin string        `xml:"pinyin,attr"`
+	Phones []iseXMLPhone `xml:"phone"`
+}
+
+type iseXMLPhone struct {
+	Content   string  `xml:"content,attr"`
+	DpMessage int     `xml:"dp_message,attr"`
+	Score     float64 `xml:"total_score,attr"`
+}
+
+// parseISEResultXML converts the provider XML into our response model.
+func parseISEResultXML(data []byte) (*model.ISEResponse, error) {
+	var parsed iseXMLResult
+	if err := xml.Unmarshal(data, &parsed); err != nil {
+		return nil, fmt.Errorf("parse ise xml: %w", err)
+	}
+	if len(parsed.RecPaper.Entries) == 0 {
+		return nil, fmt.Errorf("ise result contained no scored paper")
+	}
+	paper := parsed.RecPaper.Entries[0]
+
+	resp := &model.ISEResponse{
+		OverallScore:   paper.TotalScore,
+		AccuracyScore:  paper.AccuracyScore,
+		FluencyScore:   paper.FluencyScore,
+		IntegrityScore: paper.IntegrityScore,
+	}
+	words := paper.Words
+	for _, sent := range paper.Sentences {
+		words = append(words, sent.Words...)
+	}
+	resp.Words = convertXMLWordScores(words)
+	return resp, nil
+}
+
+// iseErrorDecoder interprets dp_message codes during result conversion.
+var iseErrorDecoder ISEErrorCodeDecoder
+
+// convertXMLWordScores maps provider word elements to model word scores,
+// skipping non-speech tokens like silence markers.
+func convertXMLWordScores(words []iseXMLWord) []model.WordScore {
+	var out []model.WordScore
+	for _, w := range words {
+		if isNonSpeechToken(w.Content) {
+			continue
+		}
+		decoded := iseErrorDecoder.Decode(w.DpMessage)
+		ws := model.WordScore{
+			Word:             w.Content,
+			Score:            w.TotalScore,
+			DpMessage:        w.DpMessage,
+			ErrorCode:        decoded.Category,
+			ErrorDescription: decoded.Description,
+		}
+		for _, syll := range w.Sylls {
+			ws.Phones = append(ws.Phones, convertXMLPhoneScores(syll.Pinyin, syll.Phones)...)
+		}
+		out = append(out, ws)
+	}
+	return out
+}
+
+// convertXMLPhoneScores maps provider phone elements to model phone scores.
+// When the enclosing syllable carries a pinyin attribute (Chinese results),
+// it is prefixed onto the phone label so clients see which syllable the
+// phone belongs to.
+func convertXMLPhoneScores(syllPinyin string, phones []iseXMLPhone) []model.PhoneScore {
+	var out []model.PhoneScore
+	for _, p := range phones {
+		if isNonSpeechToken(p.Content) {
+			continue
+		}
+		label := p.Content
+		if syllPinyin != "" {
+			label = syllPinyin + "/" + p.Content
+		}
+		decoded := iseErrorDecoder.Decode(p.DpMessage)
+		out = append(out, model.PhoneScore{
+			Phone:            label,
+			Score:            p.Score,
+			DpMessage:        p.DpMessage,
+			ErrorCode:        decoded.Category,
+			ErrorDescription: decoded.Description,
+		})
+	}
+	return out
+}
+
+// isNonSpeechToken reports whether a content token is a silence/noise marker
+// rather than a real word or phone.
+func isNonSpeechToken(content string) bool {
+	switch content {
+	case "", "sil", "silv", "fil", "SIL":
+		return true
+	}
+	for _, r := range content {
+		if unicode.IsLetter(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// defaultSilence holds the historical hardcoded thresholds, applied to any
+// language without a configured override.
+var defaultSilence = config.SilenceConfig{
+	StandardThreshold:     500,
+	AggressiveThreshold:   800,
+	LoudSampleRatioCutoff: 0.1,
+}
+
+// silenceConfigFor resolves the silence filter tuning for an evaluation
+// language, filling zero fields of a partial override with the defaults.
+func (s *ISEService) silenceConfigFor(language string) config.SilenceConfig {
+	cfg, ok := s.config.Silence[language]
+	if !ok {
+		return defaultSilence
+	}
+	if cfg.StandardThreshold == 0 {
+		cfg.StandardThreshold = defaultSilence.StandardThreshold
+	}
+	if cfg.AggressiveThreshold == 0 {
+		cfg.AggressiveThreshold = defaultSilence.AggressiveThreshold
+	}
+	if cfg.LoudSampleRatioCutoff == 0 {
+		cfg.LoudSampleRatioCutoff = defaultSilence.LoudSampleRatioCutoff
+	}
+	return cfg
+}
+
+// filterSilentChunks drops chunks that contain (almost) no audible samples.
+func filterSilentChunks(chunks [][]byte, cfg config.SilenceConfig) [][]byte {
+	var kept [][]byte
+	for _, c := range chunks {
+		if !isChunkSilent(c, cfg, false) {
+			kept = append(kept, c)
+		}
+	}
+	return kept
+}
+
+// filterSilentChunksAggressive is used when the audio would otherwise exceed
+// the provider's frame budget; it uses the higher aggressive threshold.
+func filterSilentChunksAggressive(chunks [][]byte, cfg config.SilenceConfig) [][]byte {
+	var kept [][]byte
+	for _, c := range chunks {
+		if !isChunkSilent(c, cfg, true) {
+			kept = append(kept, c)
+		}
+	}
+	return kept
+}
+
+// isChunkSilent reports whether fewer than the configured fraction of the
+// samples in the chunk exceed the amplitude threshold; aggressive selects the
+// higher threshold used when the audio is over the frame budget.
+func isChunkSilent(chunk []byte, cfg config.SilenceConfig, aggressive bool) bool {
+	if len(chunk) < 2 {
+		return true
+	}
+	threshold := cfg.StandardThreshold
+	if aggressive {
+		threshold = cfg.AggressiveThreshold
+	}
+	loud := 0
+	samples := len(chunk) / 2
+	for i := 0; i+1 < len(chunk); i += 2 {
+		sample := int16(binary.LittleEndian.Uint16(chunk[i : i+2]))
+		if sample > threshold || sample < -threshold {
+			loud++
+		}
+	}
+	return float64(loud)/float64(samples) < cfg.LoudSampleRatioCutoff
+}
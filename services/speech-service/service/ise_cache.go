@@ -0,0 +1,138 @@
+package service
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/logging"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+)
+
+var (
+	iseCacheHits = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "ise_cache_hits_total",
+		Help: "Pronunciation evaluations served from the ISE result cache.",
+	})
+	iseCacheMisses = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "ise_cache_misses_total",
+		Help: "Pronunciation evaluations that had to call the ISE provider.",
+	})
+)
+
+// iseCacheTTL bounds how long a cached evaluation stays servable. Repeated
+// practice of one sentence happens within a session, so a short TTL captures
+// the savings without letting stale entries linger.
+const iseCacheTTL = 10 * time.Minute
+
+// ISEResultCache stores pronunciation evaluations keyed by the utterance's
+// audio fingerprint plus the reference text and language, so repeating the
+// same recording against the same sentence doesn't re-bill the provider.
+type ISEResultCache interface {
+	Get(audioFingerprint uint64, text, lang string) (*model.ISEResponse, bool)
+	Put(audioFingerprint uint64, text, lang string, result *model.ISEResponse)
+}
+
+type iseCacheKey struct {
+	fingerprint uint64
+	text        string
+	lang        string
+}
+
+type iseCacheEntry struct {
+	key      iseCacheKey
+	result   *model.ISEResponse
+	storedAt time.Time
+}
+
+// MemoryISEResultCache is an in-process ISEResultCache with LRU eviction and
+// a fixed TTL; expired entries are dropped on lookup.
+type MemoryISEResultCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	ttl        time.Duration
+	entries    map[iseCacheKey]*list.Element
+	order      *list.List // front is most recently used
+	hits       uint64
+	misses     uint64
+	logger     *logrus.Logger
+}
+
+// NewMemoryISEResultCache creates a cache holding at most maxEntries results
+// for at most ttl each.
+func NewMemoryISEResultCache(maxEntries int, ttl time.Duration, logger *logrus.Logger) *MemoryISEResultCache {
+	return &MemoryISEResultCache{
+		maxEntries: maxEntries,
+		ttl:        ttl,
+		entries:    make(map[iseCacheKey]*list.Element),
+		order:      list.New(),
+		logger:     logger,
+	}
+}
+
+// Get returns the cached evaluation for the key, if present and fresh. Every
+// hundredth lookup logs the running hit ratio so cache effectiveness shows up
+// in the logs without per-request noise.
+func (c *MemoryISEResultCache) Get(audioFingerprint uint64, text, lang string) (*model.ISEResponse, bool) {
+	key := iseCacheKey{fingerprint: audioFingerprint, text: text, lang: lang}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*iseCacheEntry)
+		if time.Since(entry.storedAt) <= c.ttl {
+			c.order.MoveToFront(elem)
+			c.hits++
+			iseCacheHits.Inc()
+			c.maybeLogRatio()
+			return entry.result, true
+		}
+		c.order.Remove(elem)
+		delete(c.entries, key)
+	}
+	c.misses++
+	iseCacheMisses.Inc()
+	c.maybeLogRatio()
+	return nil, false
+}
+
+// Put stores an evaluation, evicting the least recently used entry once the
+// cache is full.
+func (c *MemoryISEResultCache) Put(audioFingerprint uint64, text, lang string, result *model.ISEResponse) {
+	key := iseCacheKey{fingerprint: audioFingerprint, text: text, lang: lang}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*iseCacheEntry)
+		entry.result = result
+		entry.storedAt = time.Now()
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&iseCacheEntry{key: key, result: result, storedAt: time.Now()})
+	for c.order.Len() > c.maxEntries {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*iseCacheEntry).key)
+	}
+}
+
+// maybeLogRatio logs the hit ratio once per 100 lookups; called with the
+// lock held.
+func (c *MemoryISEResultCache) maybeLogRatio() {
+	total := c.hits + c.misses
+	if total == 0 || total%100 != 0 {
+		return
+	}
+	c.logger.WithFields(logrus.Fields{
+		logging.LogFieldService: "ise",
+		"cache_hits":            c.hits,
+		"cache_misses":          c.misses,
+		"cache_hit_ratio":       float64(c.hits) / float64(total),
+	}).Info("ISE cache effectiveness")
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/config"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/testutil"
+)
+
+func quietCacheLogger() *logrus.Logger {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	return logger
+}
+
+func TestMemoryISECacheKeying(t *testing.T) {
+	cache := NewMemoryISEResultCache(10, time.Minute, quietCacheLogger())
+	result := &model.ISEResponse{OverallScore: 85.5}
+	cache.Put(1, "hello there.", "en_us", result)
+
+	if got, ok := cache.Get(1, "hello there.", "en_us"); !ok || got != result {
+		t.Fatalf("Get(same key) = %v, %v; want the stored result", got, ok)
+	}
+	if _, ok := cache.Get(2, "hello there.", "en_us"); ok {
+		t.Error("different fingerprint served the cached result")
+	}
+	if _, ok := cache.Get(1, "other text", "en_us"); ok {
+		t.Error("different reference text served the cached result")
+	}
+	if _, ok := cache.Get(1, "hello there.", "zh_cn"); ok {
+		t.Error("different language served the cached result")
+	}
+}
+
+func TestMemoryISECacheLRUEviction(t *testing.T) {
+	cache := NewMemoryISEResultCache(2, time.Minute, quietCacheLogger())
+	cache.Put(1, "a", "en_us", &model.ISEResponse{OverallScore: 1})
+	cache.Put(2, "b", "en_us", &model.ISEResponse{OverallScore: 2})
+
+	// Touch the first entry so the second is the least recently used.
+	if _, ok := cache.Get(1, "a", "en_us"); !ok {
+		t.Fatal("entry 1 missing before eviction")
+	}
+	cache.Put(3, "c", "en_us", &model.ISEResponse{OverallScore: 3})
+
+	if _, ok := cache.Get(1, "a", "en_us"); !ok {
+		t.Error("recently used entry evicted")
+	}
+	if _, ok := cache.Get(2, "b", "en_us"); ok {
+		t.Error("least recently used entry survived past the size cap")
+	}
+	if _, ok := cache.Get(3, "c", "en_us"); !ok {
+		t.Error("newest entry missing")
+	}
+}
+
+func TestMemoryISECacheTTLExpiry(t *testing.T) {
+	cache := NewMemoryISEResultCache(10, 10*time.Millisecond, quietCacheLogger())
+	cache.Put(1, "a", "en_us", &model.ISEResponse{OverallScore: 1})
+
+	time.Sleep(20 * time.Millisecond)
+	if _, ok := cache.Get(1, "a", "en_us"); ok {
+		t.Error("expired entry served")
+	}
+}
+
+func TestEvaluateSpeechServesRepeatFromCache(t *testing.T) {
+	server := testutil.NewMockISEServer(testutil.MockISEConfig{})
+	defer server.Close()
+
+	svc := NewISEService(config.ISEConfig{
+		AppID:        "test-app",
+		APIKey:       "test-key",
+		APISecret:    "test-secret",
+		BaseURL:      server.URL(),
+		CacheEnabled: true,
+		CacheSize:    10,
+	}, quietCacheLogger())
+
+	req := func() *model.ISERequest {
+		return &model.ISERequest{
+			AudioData: loudAudio(20000),
+			Text:      "hello there.",
+			Language:  "en_us",
+		}
+	}
+	first, err := svc.EvaluateSpeech(context.Background(), req())
+	if err != nil {
+		t.Fatalf("first EvaluateSpeech: %v", err)
+	}
+	framesAfterFirst := len(server.Frames())
+
+	second, err := svc.EvaluateSpeech(context.Background(), req())
+	if err != nil {
+		t.Fatalf("second EvaluateSpeech: %v", err)
+	}
+	if second != first {
+		t.Error("second evaluation did not come from the cache")
+	}
+	if got := len(server.Frames()); got != framesAfterFirst {
+		t.Errorf("provider saw %d frames after the repeat, want %d (no new upload)", got, framesAfterFirst)
+	}
+}
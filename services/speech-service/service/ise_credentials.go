@@ -0,0 +1,222 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/config"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/logging"
+)
+
+// ISECredentials is the rotating part of the iFlytek auth material. The app
+// ID identifies the application and never rotates, so it stays in the config.
+type ISECredentials struct {
+	APIKey    string
+	APISecret string
+}
+
+// CredentialRotator supplies the signing credentials for each new ISE
+// connection, so rotated secrets take effect without a restart.
+type CredentialRotator interface {
+	Current() ISECredentials
+	Rotate(newCreds ISECredentials) error
+}
+
+// newCredentialRotator picks the rotation strategy for the configured source.
+// The scheduled rotator keeps the static config values until its first fetch
+// succeeds.
+func newCredentialRotator(cfg config.ISEConfig, logger *logrus.Logger) CredentialRotator {
+	initial := ISECredentials{APIKey: cfg.APIKey, APISecret: cfg.APISecret}
+	if cfg.CredentialRefreshURL != "" {
+		rotator := NewScheduledRotator(cfg.CredentialRefreshURL, cfg.CredentialRefreshInterval, initial, logger)
+		rotator.Start()
+		return rotator
+	}
+	if cfg.CredentialSource == "env" {
+		return &EnvCredentialRotator{logger: logger}
+	}
+	return &staticRotator{creds: initial}
+}
+
+// credentialFingerprint identifies a key in logs without revealing it.
+func credentialFingerprint(apiKey string) string {
+	if len(apiKey) <= 4 {
+		return "****"
+	}
+	return apiKey[:4] + "..."
+}
+
+// staticRotator serves the credentials loaded at startup; the default when no
+// rotation source is configured. Rotate still works, for operators swapping
+// credentials through an admin path.
+type staticRotator struct {
+	mu    sync.RWMutex
+	creds ISECredentials
+}
+
+func (r *staticRotator) Current() ISECredentials {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.creds
+}
+
+func (r *staticRotator) Rotate(newCreds ISECredentials) error {
+	if newCreds.APIKey == "" || newCreds.APISecret == "" {
+		return fmt.Errorf("rotate ise credentials: api_key and api_secret must both be set")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.creds = newCreds
+	return nil
+}
+
+// EnvCredentialRotator re-reads ISE_API_KEY and ISE_API_SECRET on every call,
+// so container platforms that inject rotated values through a secret manager
+// need no restart or refresh endpoint.
+type EnvCredentialRotator struct {
+	logger *logrus.Logger
+
+	mu   sync.Mutex
+	last ISECredentials
+}
+
+func (r *EnvCredentialRotator) Current() ISECredentials {
+	creds := ISECredentials{
+		APIKey:    os.Getenv("ISE_API_KEY"),
+		APISecret: os.Getenv("ISE_API_SECRET"),
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if creds != r.last {
+		if r.last != (ISECredentials{}) {
+			r.logger.WithFields(logrus.Fields{
+				logging.LogFieldService: "ise",
+				"api_key":               credentialFingerprint(creds.APIKey),
+			}).Info("ISE credentials rotated via environment")
+		}
+		r.last = creds
+	}
+	return creds
+}
+
+// Rotate writes the credentials back into the environment, which the next
+// Current picks up.
+func (r *EnvCredentialRotator) Rotate(newCreds ISECredentials) error {
+	if newCreds.APIKey == "" || newCreds.APISecret == "" {
+		return fmt.Errorf("rotate ise credentials: api_key and api_secret must both be set")
+	}
+	if err := os.Setenv("ISE_API_KEY", newCreds.APIKey); err != nil {
+		return err
+	}
+	return os.Setenv("ISE_API_SECRET", newCreds.APISecret)
+}
+
+// ScheduledRotator polls a refresh endpoint for new signing credentials on a
+// fixed interval, holding the last good pair in between. The endpoint answers
+// with a JSON {"api_key": ..., "api_secret": ...} document.
+type ScheduledRotator struct {
+	url      string
+	interval time.Duration
+	client   *http.Client
+	logger   *logrus.Logger
+
+	mu    sync.RWMutex
+	creds ISECredentials
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewScheduledRotator creates the rotator; initial is served until the first
+// fetch succeeds. Call Start to begin polling.
+func NewScheduledRotator(url string, interval time.Duration, initial ISECredentials, logger *logrus.Logger) *ScheduledRotator {
+	if interval <= 0 {
+		interval = 15 * time.Minute
+	}
+	return &ScheduledRotator{
+		url:      url,
+		interval: interval,
+		client:   &http.Client{Timeout: 10 * time.Second},
+		logger:   logger,
+		creds:    initial,
+		stop:     make(chan struct{}),
+	}
+}
+
+// Start fetches once immediately and then polls until Stop.
+func (r *ScheduledRotator) Start() {
+	go func() {
+		r.refresh()
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-r.stop:
+				return
+			case <-ticker.C:
+				r.refresh()
+			}
+		}
+	}()
+}
+
+// Stop ends the polling goroutine.
+func (r *ScheduledRotator) Stop() {
+	r.stopOnce.Do(func() { close(r.stop) })
+}
+
+func (r *ScheduledRotator) refresh() {
+	resp, err := r.client.Get(r.url)
+	if err != nil {
+		r.logger.Warnf("ISE credential refresh failed: %v", err)
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		r.logger.Warnf("ISE credential refresh failed: endpoint returned %d", resp.StatusCode)
+		return
+	}
+	var payload struct {
+		APIKey    string `json:"api_key"`
+		APISecret string `json:"api_secret"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+		r.logger.Warnf("ISE credential refresh failed: %v", err)
+		return
+	}
+	newCreds := ISECredentials{APIKey: payload.APIKey, APISecret: payload.APISecret}
+	if newCreds == r.Current() {
+		return
+	}
+	if err := r.Rotate(newCreds); err != nil {
+		r.logger.Warnf("ISE credential refresh rejected: %v", err)
+	}
+}
+
+func (r *ScheduledRotator) Current() ISECredentials {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.creds
+}
+
+// Rotate installs new credentials immediately, logging the event without the
+// secret itself.
+func (r *ScheduledRotator) Rotate(newCreds ISECredentials) error {
+	if newCreds.APIKey == "" || newCreds.APISecret == "" {
+		return fmt.Errorf("rotate ise credentials: api_key and api_secret must both be set")
+	}
+	r.mu.Lock()
+	r.creds = newCreds
+	r.mu.Unlock()
+	r.logger.WithFields(logrus.Fields{
+		logging.LogFieldService: "ise",
+		"api_key":               credentialFingerprint(newCreds.APIKey),
+	}).Info("ISE credentials rotated")
+	return nil
+}
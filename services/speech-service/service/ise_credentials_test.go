@@ -0,0 +1,106 @@
+package service
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/config"
+)
+
+func quietCredentialLogger() *logrus.Logger {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	return logger
+}
+
+func TestEnvRotatorReReadsEnvironment(t *testing.T) {
+	t.Setenv("ISE_API_KEY", "key-one")
+	t.Setenv("ISE_API_SECRET", "secret-one")
+	rotator := &EnvCredentialRotator{logger: quietCredentialLogger()}
+
+	if creds := rotator.Current(); creds.APIKey != "key-one" || creds.APISecret != "secret-one" {
+		t.Fatalf("Current = %+v, want the injected environment values", creds)
+	}
+
+	// A secret manager swapping the values is visible without any restart.
+	t.Setenv("ISE_API_KEY", "key-two")
+	t.Setenv("ISE_API_SECRET", "secret-two")
+	if creds := rotator.Current(); creds.APIKey != "key-two" || creds.APISecret != "secret-two" {
+		t.Fatalf("Current = %+v, want the rotated environment values", creds)
+	}
+}
+
+func TestScheduledRotatorFetchesFromEndpoint(t *testing.T) {
+	var mu sync.Mutex
+	served := map[string]string{"api_key": "fetched-key", "api_secret": "fetched-secret"}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		json.NewEncoder(w).Encode(served)
+	}))
+	defer server.Close()
+
+	rotator := NewScheduledRotator(server.URL, 20*time.Millisecond,
+		ISECredentials{APIKey: "boot-key", APISecret: "boot-secret"}, quietCredentialLogger())
+	rotator.Start()
+	defer rotator.Stop()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if rotator.Current().APIKey == "fetched-key" {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	if creds := rotator.Current(); creds.APIKey != "fetched-key" || creds.APISecret != "fetched-secret" {
+		t.Fatalf("Current = %+v, want the fetched credentials", creds)
+	}
+
+	// The next poll picks up a rotation at the endpoint.
+	mu.Lock()
+	served["api_key"] = "rotated-key"
+	served["api_secret"] = "rotated-secret"
+	mu.Unlock()
+	deadline = time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if rotator.Current().APIKey == "rotated-key" {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("Current = %+v, rotation never picked up", rotator.Current())
+}
+
+func TestRotateRejectsIncompleteCredentials(t *testing.T) {
+	rotator := NewScheduledRotator("http://unused", time.Minute, ISECredentials{}, quietCredentialLogger())
+	if err := rotator.Rotate(ISECredentials{APIKey: "only-key"}); err == nil {
+		t.Error("Rotate accepted credentials without a secret")
+	}
+	if err := rotator.Rotate(ISECredentials{APISecret: "only-secret"}); err == nil {
+		t.Error("Rotate accepted credentials without a key")
+	}
+}
+
+func TestAuthorizationSignsWithCurrentCredentials(t *testing.T) {
+	svc := NewISEService(config.ISEConfig{
+		AppID:     "demo-app",
+		APIKey:    "old-key",
+		APISecret: "old-secret",
+	}, quietCredentialLogger())
+
+	before := svc.generateAuthorization("ise-api.xfyun.cn", "/v2/open-ise", "Mon, 02 Jan 2006 15:04:05 GMT")
+	if err := svc.rotator.Rotate(ISECredentials{APIKey: "new-key", APISecret: "new-secret"}); err != nil {
+		t.Fatalf("Rotate: %v", err)
+	}
+	after := svc.generateAuthorization("ise-api.xfyun.cn", "/v2/open-ise", "Mon, 02 Jan 2006 15:04:05 GMT")
+	if before == after {
+		t.Error("authorization unchanged after rotation; new connections would sign with the old secret")
+	}
+}
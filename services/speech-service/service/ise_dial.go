@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/logging"
+)
+
+// iseConnectionFailures counts failed ISE dials by failure class so an
+// unhealthy provider shows up in dashboards before users report it.
+var iseConnectionFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "ise_connection_failures_total",
+	Help: "Failed ISE WebSocket dial attempts by failure reason.",
+}, []string{"reason"})
+
+// Dial retry schedule: exponential backoff starting at 100ms, doubling up to
+// the cap, over at most three attempts.
+const (
+	iseDialMaxAttempts = 3
+	iseDialBaseDelay   = 100 * time.Millisecond
+	iseDialMaxDelay    = 800 * time.Millisecond
+)
+
+// Failure reasons for iseConnectionFailures and retry classification.
+const (
+	iseDialReasonDNS       = "dns"
+	iseDialReasonTLS       = "tls"
+	iseDialReasonTimeout   = "timeout"
+	iseDialReasonRateLimit = "rate_limit"
+)
+
+// dialWithRetry dials the ISE endpoint, retrying transient failures with
+// exponential backoff. DNS resolution failures are permanent (a typo'd
+// BaseURL never resolves); TLS handshake errors are retried because each
+// attempt re-signs the auth URL with a fresh date; HTTP 429 responses honor
+// the server's Retry-After header instead of the backoff schedule.
+func (s *ISEService) dialWithRetry(ctx context.Context) (*websocket.Conn, error) {
+	delay := iseDialBaseDelay
+	var lastErr error
+	for attempt := 1; attempt <= iseDialMaxAttempts; attempt++ {
+		conn, resp, err := s.createAuthenticatedConnection(ctx)
+		if err == nil {
+			return conn, nil
+		}
+		lastErr = err
+
+		reason, retryable, wait := classifyISEDialError(err, resp)
+		iseConnectionFailures.WithLabelValues(reason).Inc()
+		if !retryable {
+			return nil, fmt.Errorf("dial ise (%s): %w", reason, err)
+		}
+		if attempt == iseDialMaxAttempts {
+			break
+		}
+		if wait <= 0 {
+			wait = delay
+			delay *= 2
+			if delay > iseDialMaxDelay {
+				delay = iseDialMaxDelay
+			}
+		}
+		s.logger.WithFields(logrus.Fields{
+			logging.LogFieldService: "ise",
+			"attempt":               attempt,
+			"reason":                reason,
+			"retry_in":              wait.String(),
+		}).Warnf("ISE dial failed: %v", err)
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+	return nil, fmt.Errorf("dial ise after %d attempts: %w", iseDialMaxAttempts, lastErr)
+}
+
+// classifyISEDialError buckets a dial failure into a metric reason, whether a
+// retry can help, and an explicit wait overriding the backoff schedule (zero
+// means use the schedule). Transient errors without a more specific class
+// (refused connections, resets) count as timeouts.
+func classifyISEDialError(err error, resp *http.Response) (reason string, retryable bool, wait time.Duration) {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return iseDialReasonDNS, false, 0
+	}
+	if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		return iseDialReasonRateLimit, true, parseRetryAfter(resp.Header.Get("Retry-After"))
+	}
+	var recordErr tls.RecordHeaderError
+	var certInvalidErr x509.CertificateInvalidError
+	var unknownAuthErr x509.UnknownAuthorityError
+	if errors.As(err, &recordErr) || errors.As(err, &certInvalidErr) || errors.As(err, &unknownAuthErr) {
+		return iseDialReasonTLS, true, 0
+	}
+	return iseDialReasonTimeout, true, 0
+}
+
+// parseRetryAfter reads a Retry-After header in seconds form; malformed or
+// absent values fall back to the normal backoff schedule.
+func parseRetryAfter(header string) time.Duration {
+	if header == "" {
+		return 0
+	}
+	seconds, err := strconv.Atoi(header)
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// IsAvailable reports whether the ISE endpoint currently accepts signed
+// connections, bounded to two seconds so health checks stay fast.
+func (s *ISEService) IsAvailable(ctx context.Context) bool {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	conn, err := s.dialWithRetry(ctx)
+	if err != nil {
+		return false
+	}
+	conn.Close()
+	return true
+}
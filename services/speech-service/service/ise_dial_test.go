@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/testutil"
+)
+
+func TestClassifyISEDialError(t *testing.T) {
+	cases := []struct {
+		name          string
+		err           error
+		resp          *http.Response
+		wantReason    string
+		wantRetryable bool
+	}{
+		{
+			name:          "dns failure is permanent",
+			err:           &net.DNSError{Err: "no such host", Name: "ise.invalid"},
+			wantReason:    "dns",
+			wantRetryable: false,
+		},
+		{
+			name:          "tls record error retries",
+			err:           tls.RecordHeaderError{Msg: "first record does not look like a TLS handshake"},
+			wantReason:    "tls",
+			wantRetryable: true,
+		},
+		{
+			name: "429 honors retry-after",
+			err:  fmt.Errorf("bad handshake"),
+			resp: &http.Response{
+				StatusCode: http.StatusTooManyRequests,
+				Header:     http.Header{"Retry-After": []string{"3"}},
+			},
+			wantReason:    "rate_limit",
+			wantRetryable: true,
+		},
+		{
+			name:          "anything else counts as timeout",
+			err:           fmt.Errorf("connection refused"),
+			wantReason:    "timeout",
+			wantRetryable: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			reason, retryable, _ := classifyISEDialError(tc.err, tc.resp)
+			if reason != tc.wantReason || retryable != tc.wantRetryable {
+				t.Errorf("classify = (%q, %v), want (%q, %v)",
+					reason, retryable, tc.wantReason, tc.wantRetryable)
+			}
+		})
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	if got := parseRetryAfter("3"); got != 3*time.Second {
+		t.Errorf("parseRetryAfter(3) = %v, want 3s", got)
+	}
+	for _, header := range []string{"", "soon", "-1"} {
+		if got := parseRetryAfter(header); got != 0 {
+			t.Errorf("parseRetryAfter(%q) = %v, want 0 (use backoff)", header, got)
+		}
+	}
+}
+
+func TestIsAvailable(t *testing.T) {
+	server := testutil.NewMockISEServer(testutil.MockISEConfig{})
+	svc := newTestISEService(t, server.URL())
+	if !svc.IsAvailable(context.Background()) {
+		t.Error("IsAvailable = false against a live mock server")
+	}
+
+	server.Close()
+	if svc.IsAvailable(context.Background()) {
+		t.Error("IsAvailable = true after the server went away")
+	}
+}
@@ -0,0 +1,39 @@
+package service
+
+import "fmt"
+
+// PronunciationError is the decoded meaning of an iFlytek dp_message error
+// code attached to a word or phone.
+type PronunciationError struct {
+	Code        int    `json:"code"`
+	Category    string `json:"category"`
+	Description string `json:"description"`
+}
+
+// ISEErrorCodeDecoder interprets iFlytek dp_message codes. The provider
+// documents them as bit-style constants: 0 means the unit was read correctly,
+// the non-zero values each name one error type.
+type ISEErrorCodeDecoder struct{}
+
+// dpMessageTable maps the documented dp_message values to categories.
+var dpMessageTable = map[int]PronunciationError{
+	0:   {Code: 0, Category: "correct", Description: "read correctly"},
+	16:  {Code: 16, Category: "omission", Description: "expected sound was skipped"},
+	32:  {Code: 32, Category: "insertion", Description: "an extra sound was added"},
+	64:  {Code: 64, Category: "repetition", Description: "the sound was read back or repeated"},
+	128: {Code: 128, Category: "substitution", Description: "a different sound was used instead"},
+}
+
+// Decode returns the interpretation of one dp_message code. Undocumented
+// codes come back with the "unknown" category so callers can still render
+// something sensible.
+func (ISEErrorCodeDecoder) Decode(dpMessage int) PronunciationError {
+	if e, ok := dpMessageTable[dpMessage]; ok {
+		return e
+	}
+	return PronunciationError{
+		Code:        dpMessage,
+		Category:    "unknown",
+		Description: fmt.Sprintf("unrecognized pronunciation error code %d", dpMessage),
+	}
+}
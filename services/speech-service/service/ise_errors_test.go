@@ -0,0 +1,30 @@
+package service
+
+import "testing"
+
+func TestISEErrorCodeDecoder(t *testing.T) {
+	var decoder ISEErrorCodeDecoder
+	cases := []struct {
+		code int
+		want string
+	}{
+		{0, "correct"},
+		{16, "omission"},
+		{32, "insertion"},
+		{64, "repetition"},
+		{128, "substitution"},
+		{999, "unknown"},
+	}
+	for _, tc := range cases {
+		got := decoder.Decode(tc.code)
+		if got.Category != tc.want {
+			t.Errorf("Decode(%d).Category = %q, want %q", tc.code, got.Category, tc.want)
+		}
+		if got.Code != tc.code {
+			t.Errorf("Decode(%d).Code = %d", tc.code, got.Code)
+		}
+		if got.Description == "" {
+			t.Errorf("Decode(%d) has empty description", tc.code)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/testutil"
+)
+
+// TestISEResultXMLVariants runs the evaluator against each canned provider
+// response shape: a full word breakdown, paper-level scores only, and the
+// degenerate envelopes that must surface as errors instead of zero scores.
+func TestISEResultXMLVariants(t *testing.T) {
+	cases := []struct {
+		name      string
+		resultXML string
+		wantScore float64
+		wantWords int
+		wantErr   string
+	}{
+		{
+			name:      "word level",
+			resultXML: testutil.DefaultISEResultXML,
+			wantScore: 85.5,
+			wantWords: 2,
+		},
+		{
+			name:      "sentence level without words",
+			resultXML: testutil.SentenceLevelISEResultXML,
+			wantScore: 72.0,
+			wantWords: 0,
+		},
+		{
+			name:      "chapter level shape",
+			resultXML: testutil.ChapterLevelISEResultXML,
+			wantErr:   "no scored paper",
+		},
+		{
+			name:      "empty result",
+			resultXML: testutil.EmptyISEResultXML,
+			wantErr:   "no scored paper",
+		},
+		{
+			name:      "malformed xml",
+			resultXML: testutil.MalformedISEResultXML,
+			wantErr:   "parse ise xml",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := testutil.NewMockISEServer(testutil.MockISEConfig{ResultXML: tc.resultXML})
+			defer server.Close()
+
+			svc := newTestISEService(t, server.URL())
+			resp, err := svc.EvaluateSpeech(context.Background(), &model.ISERequest{
+				AudioData: loudAudio(4000),
+				Text:      "hello there.",
+				Language:  "en_us",
+			})
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("EvaluateSpeech error = %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("EvaluateSpeech: %v", err)
+			}
+			if resp.OverallScore != tc.wantScore {
+				t.Errorf("OverallScore = %v, want %v", resp.OverallScore, tc.wantScore)
+			}
+			if len(resp.Words) != tc.wantWords {
+				t.Errorf("len(Words) = %d, want %d", len(resp.Words), tc.wantWords)
+			}
+		})
+	}
+}
+
+// TestISEClientPassesProtocolValidation pins the client against the mock's
+// sequence rules: after a full evaluation the server must have recorded no
+// violations, so any future reordering of the ssb/auw frames fails loudly.
+func TestISEClientPassesProtocolValidation(t *testing.T) {
+	server := testutil.NewMockISEServer(testutil.MockISEConfig{})
+	defer server.Close()
+
+	svc := newTestISEService(t, server.URL())
+	if _, err := svc.EvaluateSpeech(context.Background(), &model.ISERequest{
+		AudioData: loudAudio(20000),
+		Text:      "hello there.",
+		Language:  "en_us",
+	}); err != nil {
+		t.Fatalf("EvaluateSpeech: %v", err)
+	}
+	if v := server.Violations(); len(v) != 0 {
+		t.Errorf("server recorded protocol violations: %v", v)
+	}
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ISEAPILimits captures the iFlytek endpoint's undocumented wire limits. The
+// server rejects any single JSON message larger than MaxMessageBytes;
+// JSONOverheadBytes is the size of the envelope (business/data fields, quotes
+// and keys) wrapped around the base64 audio payload.
+type ISEAPILimits struct {
+	MaxMessageBytes   int
+	JSONOverheadBytes int
+}
+
+// defaultISEAPILimits matches the observed behavior of the production
+// endpoint: messages over roughly 26000 bytes are rejected, and the auw
+// envelope stays well under 800 bytes.
+var defaultISEAPILimits = ISEAPILimits{
+	MaxMessageBytes:   26000,
+	JSONOverheadBytes: 800,
+}
+
+// iseMaxChunksPerUtterance bounds the auw frames per evaluation: beyond
+// around 20 round trips the provider starts timing out mid-upload.
+const iseMaxChunksPerUtterance = 20
+
+// ErrAudioExceedsISELimit reports audio too long to upload within the
+// provider's frame budget, even after aggressive silence filtering.
+var ErrAudioExceedsISELimit = errors.New("audio exceeds ise upload limits")
+
+// ComputeMaxAudioBytesPerChunk derives the largest raw audio chunk that fits
+// in one message: the base64 payload may occupy at most
+// MaxMessageBytes - JSONOverheadBytes, and base64 expands raw bytes by 4/3,
+// so the raw budget is floor((MaxMessageBytes - JSONOverheadBytes) * 3 / 4).
+func (s *ISEService) ComputeMaxAudioBytesPerChunk(limits ISEAPILimits) int {
+	return (limits.MaxMessageBytes - limits.JSONOverheadBytes) * 3 / 4
+}
+
+// ValidateAudioForISE checks up front whether the audio can be uploaded at
+// all: if even aggressive silence filtering leaves more than
+// iseMaxChunksPerUtterance chunks, the evaluation is doomed to time out and
+// ErrAudioExceedsISELimit is returned before a connection is dialed. language
+// selects the silence filter tuning, matching what the upload will use.
+func (s *ISEService) ValidateAudioForISE(audioData []byte, language string) error {
+	maxChunk := s.ComputeMaxAudioBytesPerChunk(s.limits)
+	kept := filterSilentChunksAggressive(splitAudioData(audioData, maxChunk), s.silenceConfigFor(language))
+	if len(kept) > iseMaxChunksPerUtterance {
+		return fmt.Errorf("%w: %d chunks after aggressive silence filtering, max %d",
+			ErrAudioExceedsISELimit, len(kept), iseMaxChunksPerUtterance)
+	}
+	return nil
+}
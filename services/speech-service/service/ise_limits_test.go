@@ -0,0 +1,83 @@
+package service
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/config"
+)
+
+func newLimitsTestService(t *testing.T, cfg config.ISEConfig) *ISEService {
+	t.Helper()
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	return NewISEService(cfg, logger)
+}
+
+// loudPCM builds len bytes of 16-bit samples well above the silence
+// thresholds, so no chunk is filtered out.
+func loudPCM(n int) []byte {
+	data := make([]byte, n)
+	for i := 0; i+1 < n; i += 2 {
+		binary.LittleEndian.PutUint16(data[i:], 4000)
+	}
+	return data
+}
+
+func TestComputeMaxAudioBytesPerChunk(t *testing.T) {
+	svc := newLimitsTestService(t, config.ISEConfig{})
+
+	cases := []struct {
+		limits ISEAPILimits
+		want   int
+	}{
+		{ISEAPILimits{MaxMessageBytes: 26000, JSONOverheadBytes: 800}, 18900},
+		{ISEAPILimits{MaxMessageBytes: 10000, JSONOverheadBytes: 1000}, 6750},
+		// floor division: (101-1) * 3 / 4 = 75
+		{ISEAPILimits{MaxMessageBytes: 101, JSONOverheadBytes: 1}, 75},
+	}
+	for _, tc := range cases {
+		if got := svc.ComputeMaxAudioBytesPerChunk(tc.limits); got != tc.want {
+			t.Errorf("ComputeMaxAudioBytesPerChunk(%+v) = %d, want %d", tc.limits, got, tc.want)
+		}
+	}
+}
+
+func TestValidateAudioForISE(t *testing.T) {
+	svc := newLimitsTestService(t, config.ISEConfig{})
+	maxChunk := svc.ComputeMaxAudioBytesPerChunk(defaultISEAPILimits)
+
+	// Within the frame budget.
+	if err := svc.ValidateAudioForISE(loudPCM(iseMaxChunksPerUtterance*maxChunk), "en_us"); err != nil {
+		t.Errorf("ValidateAudioForISE within budget: %v", err)
+	}
+
+	// One chunk over: too many round trips even with aggressive filtering.
+	err := svc.ValidateAudioForISE(loudPCM((iseMaxChunksPerUtterance+1)*maxChunk), "en_us")
+	if !errors.Is(err, ErrAudioExceedsISELimit) {
+		t.Errorf("ValidateAudioForISE over budget = %v, want ErrAudioExceedsISELimit", err)
+	}
+
+	// Long but mostly silent audio fits once filtering drops the silence.
+	silent := make([]byte, (iseMaxChunksPerUtterance+5)*maxChunk)
+	if err := svc.ValidateAudioForISE(silent, "en_us"); err != nil {
+		t.Errorf("ValidateAudioForISE for silent audio: %v", err)
+	}
+}
+
+func TestISEConfigOverridesAPILimits(t *testing.T) {
+	svc := newLimitsTestService(t, config.ISEConfig{MaxMessageBytes: 13000, JSONOverheadBytes: 1000})
+	if got := svc.ComputeMaxAudioBytesPerChunk(svc.limits); got != 9000 {
+		t.Errorf("configured chunk size = %d, want 9000", got)
+	}
+
+	// Zero values keep the defaults.
+	svc = newLimitsTestService(t, config.ISEConfig{})
+	if svc.limits != defaultISEAPILimits {
+		t.Errorf("limits = %+v, want the defaults", svc.limits)
+	}
+}
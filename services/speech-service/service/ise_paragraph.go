@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/audio"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+)
+
+// ParagraphEvaluationResult is the sentence-by-sentence evaluation of a
+// longer passage, with aggregate scores across all sentences.
+type ParagraphEvaluationResult struct {
+	// Sentences holds the per-sentence evaluations in reading order.
+	Sentences []*model.ISEResponse `json:"sentences"`
+	// OverallScore is the length-weighted average over all sentences.
+	OverallScore float64 `json:"overall_score"`
+	// WeakestSentenceIndex points at the sentence with the lowest score.
+	WeakestSentenceIndex int `json:"weakest_sentence_index"`
+	// ReadingTimeMs is the duration of the submitted audio.
+	ReadingTimeMs int64 `json:"reading_time_ms"`
+}
+
+// EvaluateParagraph evaluates a multi-sentence passage by scoring each
+// sentence independently with the read_sentence category and aggregating the
+// results. Compared to a single read_chapter call this stays under iFlytek's
+// text size limit and yields per-sentence feedback.
+//
+// The audio is apportioned to sentences by their share of the text length —
+// an approximation that holds up for steady read-aloud passages, which is
+// what this mode is used for.
+func (s *ISEService) EvaluateParagraph(ctx context.Context, req *model.ISERequest) (*ParagraphEvaluationResult, error) {
+	sentences := splitSentences(req.Text)
+	if len(sentences) == 0 {
+		return nil, fmt.Errorf("reference text contains no sentences")
+	}
+
+	readingTimeMs := int64(len(req.AudioData)) / int64(audio.DefaultSampleRate*audio.BytesPerSample/1000)
+
+	totalChars := 0
+	for _, sentence := range sentences {
+		totalChars += len(sentence)
+	}
+
+	result := &ParagraphEvaluationResult{
+		Sentences:     make([]*model.ISEResponse, 0, len(sentences)),
+		ReadingTimeMs: readingTimeMs,
+	}
+
+	offset := 0
+	weakestScore := 101.0
+	var weightedSum float64
+	for i, sentence := range sentences {
+		chunk := req.AudioData[offset:]
+		if i < len(sentences)-1 {
+			// Apportion by text share, aligned to whole 16-bit samples.
+			size := len(req.AudioData) * len(sentence) / totalChars
+			size -= size % audio.BytesPerSample
+			if size > len(chunk) {
+				size = len(chunk)
+			}
+			chunk = chunk[:size]
+		}
+		offset += len(chunk)
+
+		resp, err := s.EvaluateSpeech(ctx, &model.ISERequest{
+			AudioData: chunk,
+			Text:      sentence,
+			Language:  req.Language,
+			Category:  "read_sentence",
+		})
+		if err != nil {
+			return nil, fmt.Errorf("evaluate sentence %d: %w", i+1, err)
+		}
+		result.Sentences = append(result.Sentences, resp)
+
+		weightedSum += resp.OverallScore * float64(len(sentence))
+		if resp.OverallScore < weakestScore {
+			weakestScore = resp.OverallScore
+			result.WeakestSentenceIndex = i
+		}
+	}
+	result.OverallScore = weightedSum / float64(totalChars)
+	return result, nil
+}
+
+// splitSentences breaks a passage on terminal punctuation, keeping the
+// punctuation with its sentence. Abbreviation handling is deliberately
+// simple; reference texts are curated, not arbitrary prose.
+func splitSentences(text string) []string {
+	var sentences []string
+	var current strings.Builder
+	for _, r := range text {
+		current.WriteRune(r)
+		if r == '.' || r == '!' || r == '?' {
+			if s := strings.TrimSpace(current.String()); s != "" {
+				sentences = append(sentences, s)
+			}
+			current.Reset()
+		}
+	}
+	if s := strings.TrimSpace(current.String()); s != "" {
+		sentences = append(sentences, s)
+	}
+	return sentences
+}
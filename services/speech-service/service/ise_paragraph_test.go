@@ -0,0 +1,26 @@
+package service
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitSentences(t *testing.T) {
+	cases := []struct {
+		text string
+		want []string
+	}{
+		{"Hello.", []string{"Hello."}},
+		{"Hello there", []string{"Hello there"}},
+		{
+			"The sun is out. Shall we go for a walk? It looks lovely!",
+			[]string{"The sun is out.", "Shall we go for a walk?", "It looks lovely!"},
+		},
+		{"", nil},
+	}
+	for _, tc := range cases {
+		if got := splitSentences(tc.text); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("splitSentences(%q) = %v, want %v", tc.text, got, tc.want)
+		}
+	}
+}
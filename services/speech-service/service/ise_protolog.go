@@ -0,0 +1,77 @@
+package service
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"sync"
+	"time"
+)
+
+// MaxLogPayloadBytes caps how much of one frame the protocol log keeps;
+// longer payloads (base64 audio uploads, XML results) are cut and marked.
+const MaxLogPayloadBytes = 2048
+
+// ProtocolLogger captures the raw ISE WebSocket traffic to a side-channel
+// file for protocol debugging — the provider's framing is underdocumented and
+// the main logger only records frame sizes. Entries are JSON Lines and writes
+// are buffered, so FlushProtocolLog must run on shutdown. A nil or disabled
+// logger is a no-op.
+type ProtocolLogger struct {
+	enabled bool
+
+	mu     sync.Mutex
+	writer *bufio.Writer
+}
+
+// protocolLogEntry is one logged frame.
+type protocolLogEntry struct {
+	TS        string `json:"ts"`
+	Direction string `json:"direction"`
+	Size      int    `json:"size"`
+	Payload   string `json:"payload"`
+}
+
+// NewProtocolLogger opens (appending) the protocol log file at path.
+func NewProtocolLogger(path string) (*ProtocolLogger, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	return &ProtocolLogger{enabled: true, writer: bufio.NewWriter(f)}, nil
+}
+
+// Log records one frame. direction is "send" or "recv"; payload is the raw
+// frame before/after the wire.
+func (l *ProtocolLogger) Log(direction string, payload []byte) {
+	if l == nil || !l.enabled {
+		return
+	}
+	entry := protocolLogEntry{
+		TS:        time.Now().UTC().Format(time.RFC3339Nano),
+		Direction: direction,
+		Size:      len(payload),
+		Payload:   string(payload),
+	}
+	if len(payload) > MaxLogPayloadBytes {
+		entry.Payload = string(payload[:MaxLogPayloadBytes]) + "[TRUNCATED]"
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.writer.Write(line)
+	l.writer.WriteByte('\n')
+}
+
+// Flush drains the write buffer to the underlying file.
+func (l *ProtocolLogger) Flush() error {
+	if l == nil || !l.enabled {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.writer.Flush()
+}
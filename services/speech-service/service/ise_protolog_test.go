@@ -0,0 +1,55 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestProtocolLoggerWritesJSONLines(t *testing.T) {
+	var buf bytes.Buffer
+	l := &ProtocolLogger{enabled: true, writer: bufio.NewWriter(&buf)}
+
+	l.Log("send", []byte(`{"cmd":"ssb"}`))
+	l.Log("recv", bytes.Repeat([]byte{'x'}, MaxLogPayloadBytes+100))
+	if err := l.Flush(); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d log lines, want 2", len(lines))
+	}
+
+	var sent protocolLogEntry
+	if err := json.Unmarshal([]byte(lines[0]), &sent); err != nil {
+		t.Fatalf("parse send entry: %v", err)
+	}
+	if sent.Direction != "send" || sent.Size != 13 || sent.Payload != `{"cmd":"ssb"}` || sent.TS == "" {
+		t.Errorf("send entry = %+v, want the full payload with timestamp", sent)
+	}
+
+	var recv protocolLogEntry
+	if err := json.Unmarshal([]byte(lines[1]), &recv); err != nil {
+		t.Fatalf("parse recv entry: %v", err)
+	}
+	if recv.Size != MaxLogPayloadBytes+100 {
+		t.Errorf("Size = %d, want the untruncated frame size", recv.Size)
+	}
+	if !strings.HasSuffix(recv.Payload, "[TRUNCATED]") {
+		t.Error("oversized payload missing the [TRUNCATED] marker")
+	}
+	if len(recv.Payload) != MaxLogPayloadBytes+len("[TRUNCATED]") {
+		t.Errorf("payload kept %d bytes, want the cap", len(recv.Payload))
+	}
+}
+
+func TestProtocolLoggerDisabledIsNoOp(t *testing.T) {
+	var l *ProtocolLogger
+	l.Log("send", []byte("never written"))
+	if err := l.Flush(); err != nil {
+		t.Fatalf("flush on nil logger: %v", err)
+	}
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/logging"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+)
+
+var iseSchedulingLatency = promauto.NewHistogram(prometheus.HistogramOpts{
+	Name:    "ise_scheduling_latency_seconds",
+	Help:    "Time from ISE job submission to evaluation start.",
+	Buckets: []float64{0.001, 0.01, 0.05, 0.1, 0.25, 0.5, 1, 2, 5, 10, 30},
+})
+
+// ISE scheduling priorities: lower numbers run first.
+const (
+	// ISEPriorityInteractive is for turns a learner is actively waiting on.
+	ISEPriorityInteractive = 0
+	// ISEPriorityBatch is for background evaluations — long recordings and
+	// split-turn segments — that tolerate queueing behind interactive work.
+	ISEPriorityBatch = 10
+)
+
+// ISEJob is one evaluation queued with the scheduler. Jobs submitted through
+// the ISEService methods carry the caller's context; jobs submitted directly
+// run under the background context.
+type ISEJob struct {
+	// Priority orders the queue: lower numbers run first, ties in
+	// submission order.
+	Priority int
+	Request  *model.ISERequest
+	// SessionID labels the job in scheduler logs.
+	SessionID string
+
+	ctx       context.Context
+	submitted time.Time
+	seq       uint64
+	results   chan ISEResult
+}
+
+// ISEResult is the outcome of a scheduled evaluation.
+type ISEResult struct {
+	Response *model.ISEResponse
+	Err      error
+}
+
+// ISEScheduler orders pronunciation evaluations by priority, so a learner
+// mid-lesson gets results ahead of background batch work. A fixed pool of
+// workers pulls from a heap-based priority queue; within one priority, jobs
+// run in submission order. The lane schedulers already bound what the
+// provider sees — this decides who goes first once evaluations do queue.
+type ISEScheduler struct {
+	evaluate func(context.Context, *model.ISERequest) (*model.ISEResponse, error)
+	logger   *logrus.Logger
+
+	mu      sync.Mutex
+	cond    *sync.Cond
+	queue   iseJobQueue
+	seq     uint64
+	running int
+	closed  bool
+}
+
+// NewISEScheduler starts maxConcurrent workers (at least one) evaluating
+// queued jobs with the given function.
+func NewISEScheduler(maxConcurrent int, evaluate func(context.Context, *model.ISERequest) (*model.ISEResponse, error), logger *logrus.Logger) *ISEScheduler {
+	if maxConcurrent < 1 {
+		maxConcurrent = 1
+	}
+	s := &ISEScheduler{evaluate: evaluate, logger: logger}
+	s.cond = sync.NewCond(&s.mu)
+	for i := 0; i < maxConcurrent; i++ {
+		go s.worker()
+	}
+	return s
+}
+
+// Submit queues one evaluation and returns the channel its result is
+// delivered on. The channel is buffered, so an abandoned result never blocks
+// a worker.
+func (s *ISEScheduler) Submit(req *ISEJob) <-chan ISEResult {
+	req.results = make(chan ISEResult, 1)
+	if req.ctx == nil {
+		req.ctx = context.Background()
+	}
+	req.submitted = time.Now()
+
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		logging.SessionLogger(req.SessionID, s.logger).WithField(logging.LogFieldService, "ise").
+			Warn("ISE job rejected: scheduler is shut down")
+		req.results <- ISEResult{Err: fmt.Errorf("ise scheduler is shut down")}
+		return req.results
+	}
+	req.seq = s.seq
+	s.seq++
+	heap.Push(&s.queue, req)
+	s.mu.Unlock()
+	s.cond.Signal()
+	return req.results
+}
+
+// Close stops the workers once the queued jobs have drained. Submissions
+// after Close fail immediately.
+func (s *ISEScheduler) Close() {
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+	s.cond.Broadcast()
+}
+
+// ISEQueueStats is the snapshot served by the queue-stats endpoint.
+type ISEQueueStats struct {
+	// DepthByPriority counts the queued (not yet running) jobs per priority.
+	DepthByPriority map[int]int `json:"depth_by_priority"`
+	Queued          int         `json:"queued"`
+	Running         int         `json:"running"`
+}
+
+// QueueStats reports the queue depth by priority and the evaluations
+// currently running.
+func (s *ISEScheduler) QueueStats() ISEQueueStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stats := ISEQueueStats{
+		DepthByPriority: make(map[int]int),
+		Queued:          len(s.queue),
+		Running:         s.running,
+	}
+	for _, job := range s.queue {
+		stats.DepthByPriority[job.Priority]++
+	}
+	return stats
+}
+
+func (s *ISEScheduler) worker() {
+	for {
+		s.mu.Lock()
+		for len(s.queue) == 0 && !s.closed {
+			s.cond.Wait()
+		}
+		if len(s.queue) == 0 {
+			s.mu.Unlock()
+			return
+		}
+		job := heap.Pop(&s.queue).(*ISEJob)
+		s.running++
+		s.mu.Unlock()
+
+		iseSchedulingLatency.Observe(time.Since(job.submitted).Seconds())
+		resp, err := s.evaluate(job.ctx, job.Request)
+		job.results <- ISEResult{Response: resp, Err: err}
+
+		s.mu.Lock()
+		s.running--
+		s.mu.Unlock()
+	}
+}
+
+// iseJobQueue implements heap.Interface ordered by (Priority, seq).
+type iseJobQueue []*ISEJob
+
+func (q iseJobQueue) Len() int { return len(q) }
+
+func (q iseJobQueue) Less(i, j int) bool {
+	if q[i].Priority != q[j].Priority {
+		return q[i].Priority < q[j].Priority
+	}
+	return q[i].seq < q[j].seq
+}
+
+func (q iseJobQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *iseJobQueue) Push(x interface{}) { *q = append(*q, x.(*ISEJob)) }
+
+func (q *iseJobQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	job := old[n-1]
+	old[n-1] = nil
+	*q = old[:n-1]
+	return job
+}
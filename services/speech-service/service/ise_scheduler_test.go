@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/config"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/testutil"
+)
+
+func quietSchedulerLogger() *logrus.Logger {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	return logger
+}
+
+// awaitStart receives the next evaluation's reference text, failing the test
+// if none starts in time.
+func awaitStart(t *testing.T, started <-chan string) string {
+	t.Helper()
+	select {
+	case text := <-started:
+		return text
+	case <-time.After(2 * time.Second):
+		t.Fatal("no evaluation started within 2s")
+		return ""
+	}
+}
+
+// blockedScheduler builds a single-worker scheduler whose first job ("hold")
+// parks the worker until gate closes, so tests can stack up a queue behind
+// it deterministically.
+func blockedScheduler(t *testing.T) (sched *ISEScheduler, started chan string, gate chan struct{}) {
+	t.Helper()
+	started = make(chan string, 8)
+	gate = make(chan struct{})
+	evaluate := func(ctx context.Context, req *model.ISERequest) (*model.ISEResponse, error) {
+		started <- req.Text
+		if req.Text == "hold" {
+			<-gate
+		}
+		return &model.ISEResponse{ReferenceText: req.Text}, nil
+	}
+	sched = NewISEScheduler(1, evaluate, quietSchedulerLogger())
+	t.Cleanup(sched.Close)
+
+	sched.Submit(&ISEJob{Priority: ISEPriorityInteractive, Request: &model.ISERequest{Text: "hold"}})
+	if got := awaitStart(t, started); got != "hold" {
+		t.Fatalf("first evaluation = %q, want the hold job", got)
+	}
+	return sched, started, gate
+}
+
+func TestSchedulerRunsInteractiveAheadOfBatch(t *testing.T) {
+	sched, started, gate := blockedScheduler(t)
+
+	// Queued while the worker is parked: two batch jobs, then an
+	// interactive one submitted last.
+	sched.Submit(&ISEJob{Priority: ISEPriorityBatch, Request: &model.ISERequest{Text: "batch-1"}})
+	sched.Submit(&ISEJob{Priority: ISEPriorityBatch, Request: &model.ISERequest{Text: "batch-2"}})
+	sched.Submit(&ISEJob{Priority: ISEPriorityInteractive, Request: &model.ISERequest{Text: "interactive"}})
+	close(gate)
+
+	want := []string{"interactive", "batch-1", "batch-2"}
+	for _, text := range want {
+		if got := awaitStart(t, started); got != text {
+			t.Fatalf("evaluation started = %q, want %q (order %v)", got, text, want)
+		}
+	}
+}
+
+func TestSchedulerQueueStats(t *testing.T) {
+	sched, _, gate := blockedScheduler(t)
+	defer close(gate)
+
+	sched.Submit(&ISEJob{Priority: ISEPriorityBatch, Request: &model.ISERequest{Text: "batch-1"}})
+	sched.Submit(&ISEJob{Priority: ISEPriorityBatch, Request: &model.ISERequest{Text: "batch-2"}})
+	sched.Submit(&ISEJob{Priority: ISEPriorityInteractive, Request: &model.ISERequest{Text: "interactive"}})
+
+	stats := sched.QueueStats()
+	if stats.Queued != 3 || stats.Running != 1 {
+		t.Errorf("queued/running = %d/%d, want 3/1", stats.Queued, stats.Running)
+	}
+	if stats.DepthByPriority[ISEPriorityInteractive] != 1 || stats.DepthByPriority[ISEPriorityBatch] != 2 {
+		t.Errorf("depth by priority = %v, want 1 interactive and 2 batch", stats.DepthByPriority)
+	}
+}
+
+func TestSchedulerRejectsAfterClose(t *testing.T) {
+	sched := NewISEScheduler(1, func(ctx context.Context, req *model.ISERequest) (*model.ISEResponse, error) {
+		return &model.ISEResponse{}, nil
+	}, quietSchedulerLogger())
+	sched.Close()
+
+	select {
+	case res := <-sched.Submit(&ISEJob{Request: &model.ISERequest{Text: "late"}, SessionID: "s-1"}):
+		if res.Err == nil {
+			t.Fatal("submission after Close succeeded")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("submission after Close did not fail promptly")
+	}
+}
+
+// TestEvaluateSpeechThroughScheduler pins the transparent path: with
+// ISE_SCHEDULER_MAX_CONCURRENT set, the service methods queue through the
+// scheduler and still return provider results.
+func TestEvaluateSpeechThroughScheduler(t *testing.T) {
+	server := testutil.NewMockISEServer(testutil.MockISEConfig{})
+	defer server.Close()
+
+	svc := NewISEService(config.ISEConfig{
+		AppID:                  "test-app",
+		APIKey:                 "test-key",
+		APISecret:              "test-secret",
+		BaseURL:                server.URL(),
+		SchedulerMaxConcurrent: 2,
+	}, quietSchedulerLogger())
+	defer svc.Scheduler().Close()
+
+	resp, err := svc.EvaluateSpeech(context.Background(), &model.ISERequest{
+		AudioData: loudAudio(20000),
+		Text:      "hello there.",
+		Language:  "en_us",
+	})
+	if err != nil {
+		t.Fatalf("EvaluateSpeech: %v", err)
+	}
+	if resp == nil || resp.ReferenceText != "hello there." {
+		t.Errorf("resp = %+v, want the evaluated reference back", resp)
+	}
+}
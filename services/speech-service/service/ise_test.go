@@ -0,0 +1,287 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/config"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/testutil"
+)
+
+// loudAudio builds n bytes of 16-bit PCM that the silence filter keeps: every
+// sample is well above the amplitude threshold.
+func loudAudio(n int) []byte {
+	audio := make([]byte, n)
+	for i := 0; i+1 < n; i += 2 {
+		binary.LittleEndian.PutUint16(audio[i:i+2], uint16(int16(4000)))
+	}
+	return audio
+}
+
+func newTestISEService(t *testing.T, baseURL string) *ISEService {
+	t.Helper()
+	return NewISEService(config.ISEConfig{
+		AppID:     "test-app",
+		APIKey:    "test-key",
+		APISecret: "test-secret",
+		BaseURL:   baseURL,
+	}, logrus.New())
+}
+
+func TestISEEvaluateSpeech(t *testing.T) {
+	server := testutil.NewMockISEServer(testutil.MockISEConfig{})
+	defer server.Close()
+
+	svc := newTestISEService(t, server.URL())
+	// 40000 bytes splits into three chunks at the default 18900-byte audio
+	// budget; see ComputeMaxAudioBytesPerChunk.
+	resp, err := svc.EvaluateSpeech(context.Background(), &model.ISERequest{
+		AudioData: loudAudio(40000),
+		Text:      "hello there.",
+		Language:  "en_us",
+	})
+	if err != nil {
+		t.Fatalf("EvaluateSpeech: %v", err)
+	}
+
+	if resp.OverallScore != 85.5 {
+		t.Errorf("OverallScore = %v, want 85.5", resp.OverallScore)
+	}
+	if resp.AccuracyScore != 84.0 || resp.FluencyScore != 88.0 || resp.IntegrityScore != 90.0 {
+		t.Errorf("sub-scores = %v/%v/%v, want 84/88/90",
+			resp.AccuracyScore, resp.FluencyScore, resp.IntegrityScore)
+	}
+	if resp.ReferenceText != "hello there." {
+		t.Errorf("ReferenceText = %q", resp.ReferenceText)
+	}
+	if len(resp.Words) != 2 || resp.Words[0].Word != "hello" || resp.Words[1].Word != "there" {
+		t.Errorf("Words = %+v, want hello/there", resp.Words)
+	}
+
+	// The server must see the ssb frame, then auw frames with the
+	// first/middle/middle/last status sequence.
+	frames := server.Frames()
+	wantFrames := []testutil.ISEFrame{
+		{Cmd: "ssb", Aus: 0, Status: 0},
+		{Cmd: "auw", Aus: iseAudioFirst, Status: 1},
+		{Cmd: "auw", Aus: iseAudioMiddle, Status: 1},
+		{Cmd: "auw", Aus: iseAudioMiddle, Status: 1},
+		{Cmd: "auw", Aus: iseAudioLast, Status: 2},
+	}
+	if len(frames) != len(wantFrames) {
+		t.Fatalf("server received %d frames, want %d: %+v", len(frames), len(wantFrames), frames)
+	}
+	for i, want := range wantFrames {
+		if frames[i] != want {
+			t.Errorf("frame %d = %+v, want %+v", i, frames[i], want)
+		}
+	}
+}
+
+// TestISEPayloadIsRawSamplesNotWAV pins down the pipeline handoff: the audio
+// conversion yields canonical PCM, and the evaluator uploads exactly those
+// samples under its audio/L16 declaration — never a WAV header.
+func TestISEPayloadIsRawSamplesNotWAV(t *testing.T) {
+	server := testutil.NewMockISEServer(testutil.MockISEConfig{})
+	defer server.Close()
+
+	audioSvc := NewAudioService(config.AudioConfig{}, logrus.New())
+	samples := loudAudio(4000)
+	pcm, _, err := audioSvc.OptimizeAudio(audioSvc.Converter().ConvertPCMToWAV(samples), "wav")
+	if err != nil {
+		t.Fatalf("OptimizeAudio: %v", err)
+	}
+	if bytes.HasPrefix(pcm, []byte("RIFF")) {
+		t.Fatal("converted audio still carries a WAV header")
+	}
+
+	svc := newTestISEService(t, server.URL())
+	if _, err := svc.EvaluateSpeech(context.Background(), &model.ISERequest{
+		AudioData: pcm,
+		Text:      "hello there.",
+		Language:  "en_us",
+	}); err != nil {
+		t.Fatalf("EvaluateSpeech: %v", err)
+	}
+
+	payload := server.AudioPayload()
+	if len(payload) == 0 {
+		t.Fatal("server received no audio")
+	}
+	if bytes.HasPrefix(payload, []byte("RIFF")) {
+		t.Error("ISE payload starts with a WAV header, want raw samples")
+	}
+	if !bytes.Equal(payload, samples) {
+		t.Errorf("ISE payload is %d bytes, want the %d converted samples", len(payload), len(samples))
+	}
+}
+
+func TestISEEvaluateSpeechAppliesToneSandhi(t *testing.T) {
+	server := testutil.NewMockISEServer(testutil.MockISEConfig{})
+	defer server.Close()
+
+	svc := newTestISEService(t, server.URL())
+	resp, err := svc.EvaluateSpeech(context.Background(), &model.ISERequest{
+		AudioData:       loudAudio(4000),
+		Text:            "ni3 hao3",
+		Language:        "zh_cn",
+		ApplyToneSandhi: true,
+	})
+	if err != nil {
+		t.Fatalf("EvaluateSpeech: %v", err)
+	}
+
+	if got := server.ReferenceText(); got != "ni2 hao3" {
+		t.Errorf("evaluated reference text = %q, want the sandhi form %q", got, "ni2 hao3")
+	}
+	if resp.ReferenceText != "ni2 hao3" {
+		t.Errorf("ReferenceText = %q, want %q", resp.ReferenceText, "ni2 hao3")
+	}
+}
+
+func TestISEEvaluateSpeechServerError(t *testing.T) {
+	server := testutil.NewMockISEServer(testutil.MockISEConfig{ErrorCode: 10165})
+	defer server.Close()
+
+	svc := newTestISEService(t, server.URL())
+	_, err := svc.EvaluateSpeech(context.Background(), &model.ISERequest{
+		AudioData: loudAudio(4000),
+		Text:      "hello",
+		Language:  "en_us",
+	})
+	if err == nil || !strings.Contains(err.Error(), "10165") {
+		t.Fatalf("EvaluateSpeech error = %v, want ise error 10165", err)
+	}
+}
+
+func TestISEEvaluateSpeechSilentAudio(t *testing.T) {
+	server := testutil.NewMockISEServer(testutil.MockISEConfig{})
+	defer server.Close()
+
+	svc := newTestISEService(t, server.URL())
+	_, err := svc.EvaluateSpeech(context.Background(), &model.ISERequest{
+		AudioData: make([]byte, 8000), // all zero samples
+		Text:      "hello",
+		Language:  "en_us",
+	})
+	if err == nil || !strings.Contains(err.Error(), "silence") {
+		t.Fatalf("EvaluateSpeech error = %v, want silence error", err)
+	}
+}
+
+func TestISEEvaluateSpeechContextCancelled(t *testing.T) {
+	// The server sits on the final result long enough for the cancellation
+	// to land while the client is blocked reading.
+	server := testutil.NewMockISEServer(testutil.MockISEConfig{ResponseDelay: 5 * time.Second})
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() {
+		svc := newTestISEService(t, server.URL())
+		_, err := svc.EvaluateSpeech(ctx, &model.ISERequest{
+			AudioData: loudAudio(4000),
+			Text:      "hello",
+			Language:  "en_us",
+		})
+		errCh <- err
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("EvaluateSpeech error = %v, want context.Canceled", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("EvaluateSpeech did not return after cancellation")
+	}
+}
+
+func TestIsChunkSilent(t *testing.T) {
+	cases := []struct {
+		name  string
+		chunk []byte
+		want  bool
+	}{
+		{"empty", nil, true},
+		{"all zeros", make([]byte, 1000), true},
+		{"loud throughout", loudAudio(1000), false},
+		{"below threshold", func() []byte {
+			audio := make([]byte, 1000)
+			for i := 0; i+1 < len(audio); i += 2 {
+				binary.LittleEndian.PutUint16(audio[i:i+2], uint16(int16(300)))
+			}
+			return audio
+		}(), true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := isChunkSilent(tc.chunk, defaultSilence, false); got != tc.want {
+				t.Errorf("isChunkSilent = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+// TestSilenceConfigPerLanguage covers the per-language filter tuning: the
+// same chunk passes the default English thresholds but counts as silent under
+// a stricter Chinese override, while partial overrides keep default values.
+func TestSilenceConfigPerLanguage(t *testing.T) {
+	svc := newLimitsTestService(t, config.ISEConfig{
+		Silence: map[string]config.SilenceConfig{
+			"zh_cn": {StandardThreshold: 700},
+		},
+	})
+
+	// Samples at 600: above the default 500, below the zh_cn 700.
+	chunk := make([]byte, 1000)
+	for i := 0; i+1 < len(chunk); i += 2 {
+		binary.LittleEndian.PutUint16(chunk[i:], uint16(int16(600)))
+	}
+	if isChunkSilent(chunk, svc.silenceConfigFor("en_us"), false) {
+		t.Error("chunk above the default threshold counted as silent for en_us")
+	}
+	if !isChunkSilent(chunk, svc.silenceConfigFor("zh_cn"), false) {
+		t.Error("chunk below the zh_cn threshold counted as loud")
+	}
+
+	// The partial override keeps the default aggressive threshold and cutoff.
+	zh := svc.silenceConfigFor("zh_cn")
+	if zh.AggressiveThreshold != defaultSilence.AggressiveThreshold {
+		t.Errorf("aggressive threshold = %d, want the default %d", zh.AggressiveThreshold, defaultSilence.AggressiveThreshold)
+	}
+	if zh.LoudSampleRatioCutoff != defaultSilence.LoudSampleRatioCutoff {
+		t.Errorf("loud sample ratio cutoff = %v, want the default %v", zh.LoudSampleRatioCutoff, defaultSilence.LoudSampleRatioCutoff)
+	}
+}
+
+func TestDetermineCategory(t *testing.T) {
+	cases := []struct {
+		text     string
+		language string
+		want     string
+	}{
+		{"apple", "en_us", "read_word"},
+		{"The cat sat on the mat.", "en_us", "read_sentence"},
+		{"It rained. We stayed inside. The fire was warm.", "en_us", "read_chapter"},
+		{"好", "zh_cn", "read_syllable"},
+		{"hǎo", "zh_cn", "read_word"},
+		{"ǎ", "zh_cn", "read_word"},
+	}
+	for _, tc := range cases {
+		if got := determineCategory(tc.text, tc.language); got != tc.want {
+			t.Errorf("determineCategory(%q, %s) = %q, want %q", tc.text, tc.language, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package service
+
+import "unicode"
+
+// LanguageDetector classifies short text by script. It exists for routing
+// decisions (which TTS voice speaks this reply), not linguistics: kana means
+// Japanese, Han without kana means Chinese, anything else is treated as
+// English. Regional variants like en_gb are indistinguishable from text
+// alone, so callers rely on the voice catalog's family fallback for those.
+type LanguageDetector struct{}
+
+// NewLanguageDetector creates the detector.
+func NewLanguageDetector() *LanguageDetector {
+	return &LanguageDetector{}
+}
+
+// Detect returns the language code for the text: zh_cn, ja_jp or en_us.
+func (d *LanguageDetector) Detect(text string) string {
+	sawHan := false
+	for _, r := range text {
+		if unicode.In(r, unicode.Hiragana, unicode.Katakana) {
+			return "ja_jp"
+		}
+		if unicode.In(r, unicode.Han) {
+			sawHan = true
+		}
+	}
+	if sawHan {
+		return "zh_cn"
+	}
+	return "en_us"
+}
@@ -0,0 +1,318 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/config"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/logging"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+)
+
+// systemPrompt is the core tutor persona used for every conversation.
+const systemPrompt = `You are a friendly and patient English tutor having a spoken conversation with a learner.
+Keep replies short and conversational (1-3 sentences), encourage the learner,
+gently rephrase their mistakes, and end most turns with a question to keep the
+conversation going.`
+
+// LLMService generates tutor replies via an OpenAI-compatible chat
+// completion API.
+type LLMService struct {
+	config     config.LLMConfig
+	client     *http.Client
+	usage      *UsageTracker
+	cefrParams *CEFRAdaptiveParams
+	quality    *LLMQualityScorer
+	logger     *logrus.Logger
+	// deterministic pins every completion to temperature 0 regardless of the
+	// configured or per-CEFR parameters. Set via Deterministic.
+	deterministic bool
+}
+
+// NewLLMService creates the LLM client. usage may be nil to disable quota
+// enforcement.
+func NewLLMService(cfg config.LLMConfig, usage *UsageTracker, logger *logrus.Logger) *LLMService {
+	cefrParams, err := LoadCEFRAdaptiveParams(cfg.CEFRParamsPath)
+	if err != nil {
+		logger.Warnf("Falling back to default CEFR params: %v", err)
+		cefrParams = &defaultCEFRParams
+	}
+	return &LLMService{
+		config:     cfg,
+		client:     &http.Client{Timeout: 30 * time.Second},
+		usage:      usage,
+		cefrParams: cefrParams,
+		quality:    NewLLMQualityScorer(),
+		logger:     logger,
+	}
+}
+
+// Deterministic returns a view of the service that pins completions to
+// temperature 0, for reproducible test sessions. The copy shares the HTTP
+// client, usage tracker, and caches with the original.
+func (s *LLMService) Deterministic() *LLMService {
+	d := *s
+	d.deterministic = true
+	return &d
+}
+
+// IsAvailable reports whether the chat completion endpoint currently answers
+// HTTP requests, bounded to two seconds so health checks stay fast. Any
+// response below 500 counts as available: the probe hits the models listing,
+// which some OpenAI-compatible providers reject with a 4xx while still
+// serving completions.
+func (s *LLMService) IsAvailable(ctx context.Context) bool {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.config.BaseURL+"/models", nil)
+	if err != nil {
+		return false
+	}
+	req.Header.Set("Authorization", "Bearer "+s.config.APIKey)
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return false
+	}
+	resp.Body.Close()
+	return resp.StatusCode < http.StatusInternalServerError
+}
+
+// Warmup issues a one-token completion so the provider loads the model and
+// the HTTP connection pool is primed before the first learner turn.
+func (s *LLMService) Warmup() error {
+	_, err := s.completeWithParams([]model.ChatMessage{{Role: "user", Content: "Hi"}},
+		LLMCallParams{MaxTokens: 1})
+	return err
+}
+
+// GenerateResponse generates a reply to a single user message without any
+// conversation history.
+func (s *LLMService) GenerateResponse(userText string) (*model.LLMResponse, error) {
+	messages := []model.ChatMessage{
+		{Role: "system", Content: systemPrompt},
+		{Role: "user", Content: userText},
+	}
+	return s.complete(messages)
+}
+
+// GenerateResponseWithHistory generates a reply taking the session's full
+// conversation history into account, with generation parameters tuned to the
+// learner's CEFR estimate. The history is updated with both the user turn
+// and the generated reply.
+func (s *LLMService) GenerateResponseWithHistory(userText, cefrEstimate string, history *model.ConversationHistory) (*model.LLMResponse, error) {
+	return s.GeneratePracticeResponse(userText, cefrEstimate, ModeConversation, "", history)
+}
+
+// GeneratePracticeResponse is GenerateResponseWithHistory with a practice
+// mode: the mode's prompt fragment rides on the per-call system prompt suffix
+// alongside the CEFR adaptation, so switching modes mid-session never rewrites
+// the stored history.
+func (s *LLMService) GeneratePracticeResponse(userText, cefrEstimate string, mode PracticeMode, topic string, history *model.ConversationHistory) (*model.LLMResponse, error) {
+	if history.Len() == 0 {
+		history.Append("system", systemPrompt)
+	}
+	history.Append("user", userText)
+	messages := history.Snapshot()
+
+	params := s.cefrParams.ForLevel(cefrEstimate)
+	if fragment := mode.promptFragment(topic); fragment != "" {
+		if params.SystemPromptSuffix != "" {
+			params.SystemPromptSuffix += "\n"
+		}
+		params.SystemPromptSuffix += fragment
+	}
+	resp, err := s.completeWithParams(messages, params)
+	if err != nil {
+		// Roll back the user turn so a retry does not duplicate it.
+		history.DropLast()
+		return nil, err
+	}
+	if reason := s.regenerateReason(resp.Text); reason != "" {
+		llmBelowQuality.WithLabelValues(reason).Inc()
+		s.logger.WithField(logging.LogFieldService, "llm").Warnf("Reply below quality threshold (%s), regenerating once", reason)
+		if retry, retryErr := s.completeWithParams(messages, params); retryErr == nil {
+			resp = retry
+		}
+	}
+	if s.config.RequireQuestion && !strings.Contains(resp.Text, "?") {
+		llmBelowQuality.WithLabelValues("no_question").Inc()
+		s.logger.WithField(logging.LogFieldService, "llm").Warn("Reply contains no question; the conversation may stall")
+	}
+
+	metrics := s.quality.Score(resp.Text)
+	s.logger.WithFields(logrus.Fields{
+		logging.LogFieldService: "llm",
+		"avg_word_length":       metrics.AverageWordLength,
+		"sentence_complexity":   metrics.SentenceComplexity,
+		"vocab_diversity":       metrics.VocabDiversity,
+		"contains_correction":   metrics.ContainsCorrectionHint,
+	}).Debug("LLM response quality")
+
+	history.Append("assistant", resp.Text)
+	return resp, nil
+}
+
+// regenerateReason returns why a reply is worth one regeneration attempt:
+// "chinese_reply" when the model slipped into Chinese, "too_short" when it is
+// under the configured minimum word count, "" when it is acceptable.
+func (s *LLMService) regenerateReason(text string) string {
+	if containsChinese(text) {
+		return "chinese_reply"
+	}
+	if s.config.MinResponseWords > 0 && len(strings.Fields(text)) < s.config.MinResponseWords {
+		return "too_short"
+	}
+	return ""
+}
+
+// GenerateConversationStarter produces an opening line the tutor can use to
+// begin a session on the given topic.
+func (s *LLMService) GenerateConversationStarter(topic string) (*model.LLMResponse, error) {
+	prompt := "Start a friendly English practice conversation with the learner."
+	if topic != "" {
+		prompt = fmt.Sprintf("Start a friendly English practice conversation about %q with the learner.", topic)
+	}
+	messages := []model.ChatMessage{
+		{Role: "system", Content: systemPrompt},
+		{Role: "user", Content: prompt},
+	}
+	return s.complete(messages)
+}
+
+// GenerateCorrection asks the model to correct a learner utterance and
+// explain the fix briefly.
+func (s *LLMService) GenerateCorrection(userText string) (*model.LLMResponse, error) {
+	messages := []model.ChatMessage{
+		{Role: "system", Content: systemPrompt},
+		{Role: "user", Content: fmt.Sprintf(
+			"Correct this sentence if needed and explain the fix in one short sentence: %q", userText)},
+	}
+	return s.complete(messages)
+}
+
+// GenerateFeedback turns an evaluation result into short spoken coaching,
+// naming each mispronounced word by its error category so the model can give
+// targeted advice rather than generic encouragement.
+func (s *LLMService) GenerateFeedback(ise *model.ISEResponse) (*model.LLMResponse, error) {
+	var problems []string
+	for _, w := range ise.Words {
+		if w.DpMessage == 0 {
+			continue
+		}
+		problems = append(problems, fmt.Sprintf("%q (%s: %s)", w.Word, w.ErrorCode, w.ErrorDescription))
+	}
+
+	prompt := fmt.Sprintf(
+		"The learner read %q and scored %.0f/100. Give one short, encouraging sentence of feedback.",
+		ise.ReferenceText, ise.OverallScore)
+	if len(problems) > 0 {
+		prompt = fmt.Sprintf(
+			"The learner read %q and scored %.0f/100. Problem words: %s. "+
+				"In two short sentences, encourage the learner and give one concrete tip for the most important problem.",
+			ise.ReferenceText, ise.OverallScore, strings.Join(problems, ", "))
+	}
+
+	messages := []model.ChatMessage{
+		{Role: "system", Content: systemPrompt},
+		{Role: "user", Content: prompt},
+	}
+	return s.complete(messages)
+}
+
+// complete performs one chat completion round trip with the configured
+// default parameters.
+func (s *LLMService) complete(messages []model.ChatMessage) (*model.LLMResponse, error) {
+	return s.completeWithParams(messages, LLMCallParams{
+		MaxTokens:   s.config.MaxTokens,
+		Temperature: s.config.Temperature,
+	})
+}
+
+// completeWithParams performs one chat completion round trip with explicit
+// generation parameters. A prompt suffix is applied to a copy of the system
+// message so the stored history stays level-neutral.
+func (s *LLMService) completeWithParams(messages []model.ChatMessage, params LLMCallParams) (*model.LLMResponse, error) {
+	if params.MaxTokens == 0 {
+		params.MaxTokens = s.config.MaxTokens
+	}
+	if params.Temperature == 0 {
+		params.Temperature = s.config.Temperature
+	}
+	if s.deterministic {
+		params.Temperature = 0
+	}
+	if params.SystemPromptSuffix != "" && len(messages) > 0 && messages[0].Role == "system" {
+		amended := make([]model.ChatMessage, len(messages))
+		copy(amended, messages)
+		amended[0].Content += "\n" + params.SystemPromptSuffix
+		messages = amended
+	}
+
+	if s.usage != nil {
+		// The response cap is the best estimate we have before the call.
+		if err := s.usage.CheckQuota(params.MaxTokens); err != nil {
+			return nil, err
+		}
+	}
+
+	start := time.Now()
+	body, err := json.Marshal(map[string]interface{}{
+		"model":       s.config.Model,
+		"messages":    messages,
+		"max_tokens":  params.MaxTokens,
+		"temperature": params.Temperature,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, s.config.BaseURL+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+s.config.APIKey)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("llm request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("llm api returned %d: %s", resp.StatusCode, string(raw))
+	}
+
+	var apiResp model.LLMAPIResponse
+	if err := json.Unmarshal(raw, &apiResp); err != nil {
+		return nil, fmt.Errorf("parse llm response: %w", err)
+	}
+	if len(apiResp.Choices) == 0 {
+		return nil, fmt.Errorf("llm response contained no choices")
+	}
+	if s.usage != nil {
+		s.usage.Record(apiResp.Usage.TotalTokens)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		logging.LogFieldService:    "llm",
+		logging.LogFieldDurationMs: time.Since(start).Milliseconds(),
+		"total_tokens":             apiResp.Usage.TotalTokens,
+	}).Info("LLM complete")
+	return &model.LLMResponse{
+		Text:       apiResp.Choices[0].Message.Content,
+		TokensUsed: apiResp.Usage.TotalTokens,
+	}, nil
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// LLMCallParams are the per-call generation knobs tuned to a learner level.
+type LLMCallParams struct {
+	MaxTokens   int     `json:"max_tokens"`
+	Temperature float64 `json:"temperature"`
+	// SystemPromptSuffix is appended to the system prompt for the call only;
+	// it never enters the stored conversation history.
+	SystemPromptSuffix string `json:"system_prompt_suffix"`
+}
+
+// CEFRAdaptiveParams maps each CEFR level to its generation parameters, so
+// A1 learners get one short simple sentence while C2 learners get nuanced
+// multi-sentence replies.
+type CEFRAdaptiveParams struct {
+	A1 LLMCallParams `json:"A1"`
+	A2 LLMCallParams `json:"A2"`
+	B1 LLMCallParams `json:"B1"`
+	B2 LLMCallParams `json:"B2"`
+	C1 LLMCallParams `json:"C1"`
+	C2 LLMCallParams `json:"C2"`
+}
+
+// defaultCEFRParams is used when no params file is configured or it fails to
+// load.
+var defaultCEFRParams = CEFRAdaptiveParams{
+	A1: LLMCallParams{MaxTokens: 60, Temperature: 0.3,
+		SystemPromptSuffix: "Use only simple present and past tense. Maximum 30 words per response."},
+	A2: LLMCallParams{MaxTokens: 80, Temperature: 0.4,
+		SystemPromptSuffix: "Use common everyday vocabulary and short sentences. Maximum 50 words per response."},
+	B1: LLMCallParams{MaxTokens: 120, Temperature: 0.6,
+		SystemPromptSuffix: "Keep replies to two or three clear sentences."},
+	B2: LLMCallParams{MaxTokens: 150, Temperature: 0.7,
+		SystemPromptSuffix: "Replies may use idiomatic language; keep them to three sentences."},
+	C1: LLMCallParams{MaxTokens: 200, Temperature: 0.8,
+		SystemPromptSuffix: "Use natural, sophisticated language; up to four sentences."},
+	C2: LLMCallParams{MaxTokens: 250, Temperature: 0.9,
+		SystemPromptSuffix: "Converse as with a native speaker; nuanced replies up to four sentences."},
+}
+
+// LoadCEFRAdaptiveParams reads the per-level params from a JSON file.
+func LoadCEFRAdaptiveParams(path string) (*CEFRAdaptiveParams, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var params CEFRAdaptiveParams
+	if err := json.Unmarshal(raw, &params); err != nil {
+		return nil, fmt.Errorf("parse cefr params: %w", err)
+	}
+	return &params, nil
+}
+
+// cefrLevelOrder positions the levels for single-step adaptation.
+var cefrLevelOrder = []string{"A1", "A2", "B1", "B2", "C1", "C2"}
+
+// AdaptToLevel moves from current by step CEFR levels (negative steps
+// simplify, positive ones increase), clamped to the A1-C2 range. Unknown or
+// empty levels start from B1, matching ForLevel's fallback.
+func (s *LLMService) AdaptToLevel(current string, step int) string {
+	idx := 2 // B1
+	cur := strings.ToUpper(strings.TrimSpace(current))
+	for i, level := range cefrLevelOrder {
+		if level == cur {
+			idx = i
+			break
+		}
+	}
+	idx += step
+	if idx < 0 {
+		idx = 0
+	}
+	if idx > len(cefrLevelOrder)-1 {
+		idx = len(cefrLevelOrder) - 1
+	}
+	return cefrLevelOrder[idx]
+}
+
+// ForLevel selects the params for a CEFR estimate. Unknown or empty estimates
+// fall back to B1, the middle of the scale.
+func (p *CEFRAdaptiveParams) ForLevel(cefr string) LLMCallParams {
+	switch strings.ToUpper(strings.TrimSpace(cefr)) {
+	case "A1":
+		return p.A1
+	case "A2":
+		return p.A2
+	case "B1":
+		return p.B1
+	case "B2":
+		return p.B2
+	case "C1":
+		return p.C1
+	case "C2":
+		return p.C2
+	default:
+		return p.B1
+	}
+}
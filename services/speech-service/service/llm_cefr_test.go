@@ -0,0 +1,124 @@
+package service
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/config"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/testutil"
+)
+
+func TestCEFRForLevel(t *testing.T) {
+	p := &defaultCEFRParams
+	if got := p.ForLevel("A1").MaxTokens; got != 60 {
+		t.Errorf("A1 max tokens = %d, want 60", got)
+	}
+	if got := p.ForLevel("c2").MaxTokens; got != p.C2.MaxTokens {
+		t.Error("lowercase level not normalized")
+	}
+	for _, level := range []string{"", "unknown", "D1"} {
+		if got := p.ForLevel(level); got != p.B1 {
+			t.Errorf("ForLevel(%q) = %+v, want the B1 fallback", level, got)
+		}
+	}
+}
+
+func TestAdaptToLevel(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	svc := NewLLMService(config.LLMConfig{}, nil, logger)
+
+	cases := []struct {
+		current string
+		step    int
+		want    string
+	}{
+		{"B1", -1, "A2"},
+		{"B1", 1, "B2"},
+		{"A1", -1, "A1"}, // clamped at the bottom
+		{"C2", 1, "C2"},  // clamped at the top
+		{"c1", -1, "B2"}, // case-insensitive
+		{"", -1, "A2"},   // unknown starts from the B1 fallback
+		{"D9", 1, "B2"},
+	}
+	for _, tc := range cases {
+		if got := svc.AdaptToLevel(tc.current, tc.step); got != tc.want {
+			t.Errorf("AdaptToLevel(%q, %d) = %q, want %q", tc.current, tc.step, got, tc.want)
+		}
+	}
+}
+
+func TestLoadCEFRAdaptiveParams(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "params.json")
+	content := `{"A1": {"max_tokens": 42, "temperature": 0.2, "system_prompt_suffix": "short"}}`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	params, err := LoadCEFRAdaptiveParams(path)
+	if err != nil {
+		t.Fatalf("LoadCEFRAdaptiveParams: %v", err)
+	}
+	if params.A1.MaxTokens != 42 || params.A1.SystemPromptSuffix != "short" {
+		t.Errorf("A1 = %+v, want the values from the file", params.A1)
+	}
+
+	if _, err := LoadCEFRAdaptiveParams(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("no error for a missing params file")
+	}
+}
+
+func TestGenerateResponseWithHistoryAppliesCEFRParams(t *testing.T) {
+	mock := testutil.NewMockLLMServer(testutil.MockLLMConfig{ReplyText: "Good!"})
+	defer mock.Close()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	svc := NewLLMService(config.LLMConfig{
+		BaseURL:     mock.URL(),
+		Model:       "test-model",
+		MaxTokens:   150,
+		Temperature: 0.7,
+	}, nil, logger)
+
+	history := &model.ConversationHistory{}
+	if _, err := svc.GenerateResponseWithHistory("I goed to school.", "A1", history); err != nil {
+		t.Fatalf("GenerateResponseWithHistory: %v", err)
+	}
+
+	requests := mock.Requests()
+	if len(requests) != 1 {
+		t.Fatalf("llm requests = %d, want 1", len(requests))
+	}
+	var req struct {
+		MaxTokens   int     `json:"max_tokens"`
+		Temperature float64 `json:"temperature"`
+		Messages    []struct {
+			Role    string `json:"role"`
+			Content string `json:"content"`
+		} `json:"messages"`
+	}
+	if err := json.Unmarshal(requests[0], &req); err != nil {
+		t.Fatalf("parse request body: %v", err)
+	}
+	if req.MaxTokens != 60 {
+		t.Errorf("max_tokens = %d, want the A1 value 60", req.MaxTokens)
+	}
+	if req.Temperature != 0.3 {
+		t.Errorf("temperature = %v, want the A1 value 0.3", req.Temperature)
+	}
+	if !strings.Contains(req.Messages[0].Content, "Maximum 30 words per response.") {
+		t.Error("A1 system prompt suffix missing from the call")
+	}
+
+	// The suffix is per-call; the stored history stays level-neutral.
+	if strings.Contains(history.Messages[0].Content, "Maximum 30 words") {
+		t.Error("CEFR suffix leaked into the stored history")
+	}
+}
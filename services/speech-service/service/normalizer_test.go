@@ -0,0 +1,43 @@
+package service
+
+import "testing"
+
+func TestAnalyzeTextComplexity(t *testing.T) {
+	easy := AnalyzeTextComplexity("Hello.")
+	hard := AnalyzeTextComplexity("The meteorological conditions are exceptionally favorable today.")
+
+	if easy.SentenceCount != 1 || hard.SentenceCount != 1 {
+		t.Errorf("sentence counts = %d, %d; want 1, 1", easy.SentenceCount, hard.SentenceCount)
+	}
+	if easy.GradeLevel >= hard.GradeLevel {
+		t.Errorf("grade levels: easy=%.1f hard=%.1f; want easy < hard", easy.GradeLevel, hard.GradeLevel)
+	}
+	if easy.AvgWordLength >= hard.AvgWordLength {
+		t.Errorf("avg word lengths: easy=%.1f hard=%.1f; want easy < hard", easy.AvgWordLength, hard.AvgWordLength)
+	}
+}
+
+func TestCalibrateAdjustsByDifficulty(t *testing.T) {
+	n := &ISEScoringNormalizer{bands: defaultCalibrationBands}
+
+	easy := n.Calibrate(80, TextComplexity{GradeLevel: 1})
+	mid := n.Calibrate(80, TextComplexity{GradeLevel: 4})
+	hard := n.Calibrate(80, TextComplexity{GradeLevel: 12})
+
+	if easy >= mid || mid >= hard {
+		t.Errorf("calibrated scores easy=%.1f mid=%.1f hard=%.1f; want strictly increasing", easy, mid, hard)
+	}
+	if mid != 80 {
+		t.Errorf("mid-band calibration = %.1f, want unchanged 80", mid)
+	}
+}
+
+func TestCalibrateClampsToRange(t *testing.T) {
+	n := &ISEScoringNormalizer{bands: defaultCalibrationBands}
+	if got := n.Calibrate(98, TextComplexity{GradeLevel: 12}); got != 100 {
+		t.Errorf("Calibrate(98, hard) = %.1f, want clamped to 100", got)
+	}
+	if got := n.Calibrate(2, TextComplexity{GradeLevel: 1}); got != 0 {
+		t.Errorf("Calibrate(2, easy) = %.1f, want clamped to 0", got)
+	}
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"strings"
+	"unicode"
+)
+
+// ChinesePinyinPreprocessor rewrites pinyin reference text so the expected
+// tones match what a native speaker actually says. iFlytek scores against the
+// literal reference, so without the mandatory tone sandhi rules applied a
+// correct reading of e.g. "nǐ hǎo" as "ní hǎo" would be marked as a tone
+// error.
+type ChinesePinyinPreprocessor struct{}
+
+// NewChinesePinyinPreprocessor creates the preprocessor.
+func NewChinesePinyinPreprocessor() *ChinesePinyinPreprocessor {
+	return &ChinesePinyinPreprocessor{}
+}
+
+// ApplyToneSandhi applies the mandatory Mandarin tone sandhi rules to
+// whitespace-separated pinyin syllables, in both tone-number ("ni3 hao3") and
+// tone-mark ("nǐ hǎo") notation:
+//
+//   - third-tone sandhi: a 3rd tone before another 3rd tone becomes 2nd;
+//   - 不 (bù) becomes bú before a 4th tone;
+//   - 一 (yī) becomes yí before a 4th tone and yì before tones 1-3.
+//
+// Each rule looks at the following syllable's tone as written, so a run of
+// 3rd tones like "wo3 hen3 hao3" becomes "wo2 hen2 hao3". Syllables whose
+// tone cannot be determined are left untouched, as are ordinal uses of 一 we
+// cannot detect from text alone.
+func (p *ChinesePinyinPreprocessor) ApplyToneSandhi(text string) string {
+	fields := strings.Fields(text)
+	if len(fields) < 2 {
+		return text
+	}
+
+	type syllable struct {
+		lead, core, trail string
+		tone              int
+	}
+	sylls := make([]syllable, len(fields))
+	for i, field := range fields {
+		lead, core, trail := splitPinyinPunct(field)
+		sylls[i] = syllable{lead: lead, core: core, trail: trail, tone: pinyinTone(core)}
+	}
+
+	out := make([]string, len(fields))
+	for i := range sylls {
+		cur := &sylls[i]
+		if i+1 < len(sylls) {
+			next := sylls[i+1].tone
+			switch base := pinyinBase(cur.core); {
+			case base == "bu" && cur.tone == 4 && next == 4:
+				cur.core = pinyinWithTone(cur.core, 2)
+			case base == "yi" && cur.tone == 1 && next == 4:
+				cur.core = pinyinWithTone(cur.core, 2)
+			case base == "yi" && cur.tone == 1 && next >= 1 && next <= 3:
+				cur.core = pinyinWithTone(cur.core, 4)
+			case cur.tone == 3 && next == 3:
+				cur.core = pinyinWithTone(cur.core, 2)
+			}
+		}
+		out[i] = cur.lead + cur.core + cur.trail
+	}
+	return strings.Join(out, " ")
+}
+
+// toneMarkedVowels maps each base vowel to its tone 1-4 diacritic forms.
+var toneMarkedVowels = map[rune][4]rune{
+	'a': {'ā', 'á', 'ǎ', 'à'},
+	'e': {'ē', 'é', 'ě', 'è'},
+	'i': {'ī', 'í', 'ǐ', 'ì'},
+	'o': {'ō', 'ó', 'ǒ', 'ò'},
+	'u': {'ū', 'ú', 'ǔ', 'ù'},
+	'ü': {'ǖ', 'ǘ', 'ǚ', 'ǜ'},
+}
+
+// markedVowelInfo is the reverse lookup: diacritic rune to base vowel and
+// tone.
+var markedVowelInfo = func() map[rune]struct {
+	base rune
+	tone int
+} {
+	info := make(map[rune]struct {
+		base rune
+		tone int
+	})
+	for base, marked := range toneMarkedVowels {
+		for i, r := range marked {
+			info[r] = struct {
+				base rune
+				tone int
+			}{base: base, tone: i + 1}
+		}
+	}
+	return info
+}()
+
+// splitPinyinPunct splits a field into leading punctuation, the syllable core
+// (letters plus an optional trailing tone digit) and trailing punctuation.
+func splitPinyinPunct(field string) (lead, core, trail string) {
+	runes := []rune(field)
+	start := 0
+	for start < len(runes) && !unicode.IsLetter(runes[start]) {
+		start++
+	}
+	end := len(runes)
+	for end > start && !unicode.IsLetter(runes[end-1]) && !unicode.IsDigit(runes[end-1]) {
+		end--
+	}
+	return string(runes[:start]), string(runes[start:end]), string(runes[end:])
+}
+
+// pinyinTone extracts the tone of a syllable: a trailing 1-5 digit or a
+// diacritic vowel. 0 means the tone could not be determined.
+func pinyinTone(core string) int {
+	runes := []rune(core)
+	if len(runes) == 0 {
+		return 0
+	}
+	if last := runes[len(runes)-1]; last >= '1' && last <= '5' {
+		return int(last - '0')
+	}
+	for _, r := range runes {
+		if info, ok := markedVowelInfo[r]; ok {
+			return info.tone
+		}
+	}
+	return 0
+}
+
+// pinyinWithTone rewrites a syllable to the given tone in its own notation.
+func pinyinWithTone(core string, tone int) string {
+	runes := []rune(core)
+	if len(runes) == 0 {
+		return core
+	}
+	if last := runes[len(runes)-1]; last >= '1' && last <= '5' {
+		runes[len(runes)-1] = rune('0' + tone)
+		return string(runes)
+	}
+	for i, r := range runes {
+		if info, ok := markedVowelInfo[r]; ok {
+			runes[i] = toneMarkedVowels[info.base][tone-1]
+			return string(runes)
+		}
+	}
+	return core
+}
+
+// pinyinBase lowers a syllable to its bare letters, stripping the tone digit
+// and diacritics, for identifying 不 (bu) and 一 (yi).
+func pinyinBase(core string) string {
+	var b strings.Builder
+	for _, r := range core {
+		if info, ok := markedVowelInfo[r]; ok {
+			r = info.base
+		}
+		if unicode.IsLetter(r) {
+			b.WriteRune(unicode.ToLower(r))
+		}
+	}
+	return b.String()
+}
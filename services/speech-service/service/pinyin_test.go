@@ -0,0 +1,96 @@
+package service
+
+import (
+	"testing"
+)
+
+func TestApplyToneSandhi(t *testing.T) {
+	cases := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"third tone pair numbered", "ni3 hao3", "ni2 hao3"},
+		{"third tone run numbered", "wo3 hen3 hao3", "wo2 hen2 hao3"},
+		{"third tone pair marked", "nǐ hǎo", "ní hǎo"},
+		{"third tone before other tones", "hao3 ma1", "hao3 ma1"},
+		{"bu before fourth tone", "bu4 shi4", "bu2 shi4"},
+		{"bu before fourth tone marked", "bù shì", "bú shì"},
+		{"bu before third tone unchanged", "bu4 hao3", "bu4 hao3"},
+		{"yi before fourth tone", "yi1 ge4", "yi2 ge4"},
+		{"yi before fourth tone marked", "yī gè", "yí gè"},
+		{"yi before first tone", "yi1 tian1", "yi4 tian1"},
+		{"yi before third tone", "yi1 qi3", "yi4 qi3"},
+		{"single syllable unchanged", "hao3", "hao3"},
+		{"neutral tone untouched", "ma ma", "ma ma"},
+		{"punctuation preserved", "ni3 hao3!", "ni2 hao3!"},
+	}
+	p := NewChinesePinyinPreprocessor()
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := p.ApplyToneSandhi(tc.text); got != tc.want {
+				t.Errorf("ApplyToneSandhi(%q) = %q, want %q", tc.text, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestPinyinTone(t *testing.T) {
+	cases := []struct {
+		core string
+		want int
+	}{
+		{"hao3", 3},
+		{"ma5", 5},
+		{"hǎo", 3},
+		{"shì", 4},
+		{"ma", 0},
+		{"", 0},
+	}
+	for _, tc := range cases {
+		if got := pinyinTone(tc.core); got != tc.want {
+			t.Errorf("pinyinTone(%q) = %d, want %d", tc.core, got, tc.want)
+		}
+	}
+}
+
+func TestParseISEResultXMLSyllablePinyin(t *testing.T) {
+	xmlData := []byte(`<?xml version="1.0"?>
+<xml_result>
+  <read_sentence>
+    <rec_paper>
+      <read_sentence total_score="90" accuracy_score="89" fluency_score="92" integrity_score="100">
+        <sentence>
+          <word content="你好" total_score="90" dp_message="0">
+            <syll content="你" pinyin="ni2">
+              <phone content="n" dp_message="0" total_score="95"/>
+              <phone content="i2" dp_message="0" total_score="88"/>
+            </syll>
+            <syll content="好" pinyin="hao3">
+              <phone content="h" dp_message="0" total_score="91"/>
+            </syll>
+          </word>
+        </sentence>
+      </read_sentence>
+    </rec_paper>
+  </read_sentence>
+</xml_result>`)
+
+	resp, err := parseISEResultXML(xmlData)
+	if err != nil {
+		t.Fatalf("parseISEResultXML: %v", err)
+	}
+	if len(resp.Words) != 1 {
+		t.Fatalf("words = %d, want 1", len(resp.Words))
+	}
+	phones := resp.Words[0].Phones
+	want := []string{"ni2/n", "ni2/i2", "hao3/h"}
+	if len(phones) != len(want) {
+		t.Fatalf("phones = %d, want %d: %+v", len(phones), len(want), phones)
+	}
+	for i, w := range want {
+		if phones[i].Phone != w {
+			t.Errorf("phone %d = %q, want %q", i, phones[i].Phone, w)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package service
+
+// PracticeMode selects the turn structure the tutor follows for a session.
+// The default, ModeConversation, is the free conversation the service has
+// always run; the other modes layer a mode-specific strategy on top of the
+// core tutor persona via a per-call system prompt fragment, so the stored
+// conversation history stays mode-neutral.
+type PracticeMode string
+
+const (
+	ModeConversation PracticeMode = "conversation"
+	ModeRoleplay     PracticeMode = "roleplay"
+	ModeDebate       PracticeMode = "debate"
+	ModeStory        PracticeMode = "story"
+	ModeQA           PracticeMode = "qa"
+)
+
+// PracticeModeInfo describes one mode for the /practice-modes listing.
+type PracticeModeInfo struct {
+	Mode        PracticeMode `json:"mode"`
+	Description string       `json:"description"`
+}
+
+// PracticeModes lists every supported mode with a client-facing description,
+// in a stable order.
+func PracticeModes() []PracticeModeInfo {
+	return []PracticeModeInfo{
+		{ModeConversation, "Free conversation with the tutor."},
+		{ModeRoleplay, "Act out a scenario: the tutor and learner play distinct roles."},
+		{ModeDebate, "The tutor argues the position opposing the learner's."},
+		{ModeStory, "Build a story together, one sentence per turn."},
+		{ModeQA, "The tutor asks comprehension questions about a passage or topic."},
+	}
+}
+
+// ParsePracticeMode maps a client-supplied mode name onto a PracticeMode,
+// reporting whether the name is one we support. The empty string is not a
+// mode: callers keep their current (or default) mode when nothing was named.
+func ParsePracticeMode(s string) (PracticeMode, bool) {
+	switch PracticeMode(s) {
+	case ModeConversation, ModeRoleplay, ModeDebate, ModeStory, ModeQA:
+		return PracticeMode(s), true
+	}
+	return ModeConversation, false
+}
+
+// promptFragment returns the mode's addition to the system prompt. topic
+// seeds the scenario, motion, story or passage; each mode falls back to a
+// reasonable default when it is empty. Free conversation (and any
+// unrecognized value, including the zero value) adds nothing.
+func (m PracticeMode) promptFragment(topic string) string {
+	switch m {
+	case ModeRoleplay:
+		scenario := "a hotel: you are the receptionist and the learner is a guest checking in"
+		if topic != "" {
+			scenario = topic + "; pick a fitting role for yourself and a different one for the learner"
+		}
+		return "This is a roleplay session set in " + scenario + ". " +
+			"Stay in character, address the learner in their role, and keep the scene moving."
+	case ModeDebate:
+		motion := "a light everyday topic of the learner's choosing"
+		if topic != "" {
+			motion = `"` + topic + `"`
+		}
+		return "This is a debate practice session on " + motion + ". " +
+			"Take the position opposing the learner's, make one clear counter-argument per turn, " +
+			"and invite them to rebut it."
+	case ModeStory:
+		opening := "Open with the first sentence of a new story if none has started."
+		if topic != "" {
+			opening = `If no story has started, open with the first sentence of a story about "` + topic + `".`
+		}
+		return "This is a story-building session: you and the learner alternate, " +
+			"each adding exactly one sentence that continues the story. " + opening
+	case ModeQA:
+		source := "the passage the learner provides"
+		if topic != "" {
+			source = "this passage or topic: " + topic
+		}
+		return "This is a reading comprehension session based on " + source + ". " +
+			"Ask one comprehension question per turn, confirm or gently correct the learner's answer, " +
+			"then ask the next."
+	}
+	return ""
+}
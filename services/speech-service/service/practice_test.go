@@ -0,0 +1,106 @@
+package service
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/config"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/testutil"
+)
+
+func TestParsePracticeMode(t *testing.T) {
+	for _, name := range []string{"conversation", "roleplay", "debate", "story", "qa"} {
+		mode, ok := ParsePracticeMode(name)
+		if !ok || string(mode) != name {
+			t.Errorf("ParsePracticeMode(%q) = (%q, %v), want (%q, true)", name, mode, ok, name)
+		}
+	}
+	for _, name := range []string{"", "karaoke", "Roleplay"} {
+		if mode, ok := ParsePracticeMode(name); ok {
+			t.Errorf("ParsePracticeMode(%q) = (%q, true), want ok=false", name, mode)
+		}
+	}
+}
+
+func TestPracticeModesListsEveryMode(t *testing.T) {
+	modes := PracticeModes()
+	if len(modes) != 5 {
+		t.Fatalf("len(PracticeModes()) = %d, want 5", len(modes))
+	}
+	for _, info := range modes {
+		if _, ok := ParsePracticeMode(string(info.Mode)); !ok {
+			t.Errorf("listed mode %q does not parse", info.Mode)
+		}
+		if info.Description == "" {
+			t.Errorf("mode %q has no description", info.Mode)
+		}
+	}
+}
+
+func TestPromptFragmentUsesTopic(t *testing.T) {
+	for _, mode := range []PracticeMode{ModeRoleplay, ModeDebate, ModeStory, ModeQA} {
+		if mode.promptFragment("") == "" {
+			t.Errorf("%q fragment empty without a topic", mode)
+		}
+		if !strings.Contains(mode.promptFragment("airport security"), "airport security") {
+			t.Errorf("%q fragment ignores the topic", mode)
+		}
+	}
+	if got := ModeConversation.promptFragment("anything"); got != "" {
+		t.Errorf("conversation fragment = %q, want empty", got)
+	}
+	if got := PracticeMode("").promptFragment(""); got != "" {
+		t.Errorf("zero-value fragment = %q, want empty", got)
+	}
+}
+
+// TestGeneratePracticeResponseInjectsModePrompt checks the fragment rides on
+// the per-call system prompt next to the CEFR suffix and never reaches the
+// stored history.
+func TestGeneratePracticeResponseInjectsModePrompt(t *testing.T) {
+	mock := testutil.NewMockLLMServer(testutil.MockLLMConfig{ReplyText: "I disagree!"})
+	defer mock.Close()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	svc := NewLLMService(config.LLMConfig{
+		BaseURL:   mock.URL(),
+		Model:     "test-model",
+		MaxTokens: 150,
+	}, nil, logger)
+
+	history := &model.ConversationHistory{}
+	if _, err := svc.GeneratePracticeResponse("Uniforms are great.", "A1", ModeDebate, "school uniforms", history); err != nil {
+		t.Fatalf("GeneratePracticeResponse: %v", err)
+	}
+
+	requests := mock.Requests()
+	if len(requests) != 1 {
+		t.Fatalf("llm requests = %d, want 1", len(requests))
+	}
+	var req struct {
+		Messages []struct {
+			Role    string `json:"role"`
+			Content string `json:"content"`
+		} `json:"messages"`
+	}
+	if err := json.Unmarshal(requests[0], &req); err != nil {
+		t.Fatalf("parse request body: %v", err)
+	}
+	system := req.Messages[0].Content
+	if !strings.Contains(system, `"school uniforms"`) {
+		t.Error("debate motion missing from the call's system prompt")
+	}
+	// The mode fragment stacks on the CEFR suffix instead of replacing it.
+	if !strings.Contains(system, "Maximum 30 words per response.") {
+		t.Error("A1 system prompt suffix missing from the call")
+	}
+	if strings.Contains(history.Messages[0].Content, "school uniforms") {
+		t.Error("mode fragment leaked into the stored history")
+	}
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// llmBelowQuality counts replies that failed a quality check, labelled by
+// which check tripped ("too_short", "chinese_reply", "no_question").
+var llmBelowQuality = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "llm_response_below_quality_threshold_total",
+	Help: "LLM replies that failed a quality check, by reason.",
+}, []string{"reason"})
+
+// QualityMetrics summarizes the linguistic shape of one generated reply.
+type QualityMetrics struct {
+	// AverageWordLength is the mean letter count per word.
+	AverageWordLength float64
+	// SentenceComplexity is the mean word count per sentence.
+	SentenceComplexity float64
+	// VocabDiversity is the ratio of distinct words to total words.
+	VocabDiversity float64
+	// ContainsCorrectionHint reports whether the reply appears to correct the
+	// learner, based on common correction phrasings.
+	ContainsCorrectionHint bool
+}
+
+// correctionHints are phrasings the tutor persona uses when rephrasing a
+// learner mistake.
+var correctionHints = []string{
+	"instead of",
+	"you can say",
+	"you could say",
+	"we usually say",
+	"we say",
+	"the correct way",
+	"a better way to say",
+}
+
+// LLMQualityScorer computes QualityMetrics for generated replies. It is
+// stateless and cheap enough to run on every response.
+type LLMQualityScorer struct{}
+
+// NewLLMQualityScorer creates the scorer.
+func NewLLMQualityScorer() *LLMQualityScorer {
+	return &LLMQualityScorer{}
+}
+
+// Score computes the metrics for one reply. An empty reply scores zero
+// across the board.
+func (q *LLMQualityScorer) Score(text string) QualityMetrics {
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return QualityMetrics{}
+	}
+
+	var letters int
+	distinct := make(map[string]bool)
+	for _, word := range words {
+		normalized := strings.ToLower(strings.TrimFunc(word, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+		}))
+		distinct[normalized] = true
+		letters += len([]rune(normalized))
+	}
+
+	sentences := splitSentences(text)
+	sentenceCount := len(sentences)
+	if sentenceCount == 0 {
+		sentenceCount = 1
+	}
+
+	lower := strings.ToLower(text)
+	var hint bool
+	for _, phrase := range correctionHints {
+		if strings.Contains(lower, phrase) {
+			hint = true
+			break
+		}
+	}
+
+	return QualityMetrics{
+		AverageWordLength:      float64(letters) / float64(len(words)),
+		SentenceComplexity:     float64(len(words)) / float64(sentenceCount),
+		VocabDiversity:         float64(len(distinct)) / float64(len(words)),
+		ContainsCorrectionHint: hint,
+	}
+}
+
+// containsChinese reports whether the text contains Han characters, which in
+// an English lesson means the model slipped into Chinese.
+func containsChinese(text string) bool {
+	for _, r := range text {
+		if unicode.Is(unicode.Han, r) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"io"
+	"math"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/config"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/testutil"
+)
+
+func TestQualityScorerMetrics(t *testing.T) {
+	scorer := NewLLMQualityScorer()
+	m := scorer.Score("I like green apples. Do you like apples?")
+
+	// 8 words, 31 letters, 2 sentences, 6 distinct words.
+	if got, want := m.AverageWordLength, 31.0/8.0; math.Abs(got-want) > 1e-9 {
+		t.Errorf("AverageWordLength = %v, want %v", got, want)
+	}
+	if got, want := m.SentenceComplexity, 4.0; got != want {
+		t.Errorf("SentenceComplexity = %v, want %v", got, want)
+	}
+	if got, want := m.VocabDiversity, 6.0/8.0; math.Abs(got-want) > 1e-9 {
+		t.Errorf("VocabDiversity = %v, want %v", got, want)
+	}
+	if m.ContainsCorrectionHint {
+		t.Error("ContainsCorrectionHint = true for a reply without a correction")
+	}
+
+	corrected := scorer.Score(`You can say "I went to school" instead of "I goed". What did you do there?`)
+	if !corrected.ContainsCorrectionHint {
+		t.Error("ContainsCorrectionHint = false for a correcting reply")
+	}
+
+	if got := scorer.Score(""); got != (QualityMetrics{}) {
+		t.Errorf("empty reply scored %+v, want zeroes", got)
+	}
+}
+
+func TestContainsChinese(t *testing.T) {
+	if !containsChinese("好的, let's practice!") {
+		t.Error("Han characters not detected")
+	}
+	if containsChinese("Entirely English, with punctuation?!") {
+		t.Error("false positive on English text")
+	}
+}
+
+func TestShortReplyIsRegeneratedOnce(t *testing.T) {
+	mock := testutil.NewMockLLMServer(testutil.MockLLMConfig{ReplySequence: []string{
+		"Good job!",
+		"That was a really good answer, well done. What would you like to talk about next?",
+	}})
+	defer mock.Close()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	svc := NewLLMService(config.LLMConfig{
+		BaseURL:          mock.URL(),
+		Model:            "test-model",
+		MaxTokens:        100,
+		MinResponseWords: 8,
+	}, nil, logger)
+
+	history := &model.ConversationHistory{}
+	resp, err := svc.GenerateResponseWithHistory("I finished the exercise.", "", history)
+	if err != nil {
+		t.Fatalf("GenerateResponseWithHistory: %v", err)
+	}
+	if resp.Text == "Good job!" {
+		t.Error("short reply was not regenerated")
+	}
+	if got := len(mock.Requests()); got != 2 {
+		t.Errorf("llm requests = %d, want 2 (original + regeneration)", got)
+	}
+	if last := history.Messages[len(history.Messages)-1]; last.Content != resp.Text {
+		t.Errorf("history holds %q, want the regenerated reply", last.Content)
+	}
+}
+
+func TestShortReplyKeptWhenCheckDisabled(t *testing.T) {
+	mock := testutil.NewMockLLMServer(testutil.MockLLMConfig{ReplyText: "Good job!"})
+	defer mock.Close()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	svc := NewLLMService(config.LLMConfig{BaseURL: mock.URL(), Model: "test-model", MaxTokens: 100}, nil, logger)
+
+	history := &model.ConversationHistory{}
+	resp, err := svc.GenerateResponseWithHistory("I finished the exercise.", "", history)
+	if err != nil {
+		t.Fatalf("GenerateResponseWithHistory: %v", err)
+	}
+	if resp.Text != "Good job!" {
+		t.Errorf("reply = %q, want the single mock reply", resp.Text)
+	}
+	if got := len(mock.Requests()); got != 1 {
+		t.Errorf("llm requests = %d, want 1 with the check disabled", got)
+	}
+}
+
+func TestChineseReplyIsRegenerated(t *testing.T) {
+	mock := testutil.NewMockLLMServer(testutil.MockLLMConfig{ReplySequence: []string{
+		"你说得很好！",
+		"That was very well said! What happened after that?",
+	}})
+	defer mock.Close()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	svc := NewLLMService(config.LLMConfig{BaseURL: mock.URL(), Model: "test-model", MaxTokens: 100}, nil, logger)
+
+	history := &model.ConversationHistory{}
+	resp, err := svc.GenerateResponseWithHistory("I told a story.", "", history)
+	if err != nil {
+		t.Fatalf("GenerateResponseWithHistory: %v", err)
+	}
+	if containsChinese(resp.Text) {
+		t.Errorf("reply %q still contains Chinese after regeneration", resp.Text)
+	}
+}
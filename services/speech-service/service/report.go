@@ -0,0 +1,294 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+)
+
+// ReportGenerator turns the data accumulated over one session into a
+// SessionReport.
+type ReportGenerator struct{}
+
+// NewReportGenerator creates the generator.
+func NewReportGenerator() *ReportGenerator {
+	return &ReportGenerator{}
+}
+
+// SessionReportData is everything the generator needs from a finished
+// session.
+type SessionReportData struct {
+	SessionID    string
+	UserID       string
+	Duration     time.Duration
+	TotalTurns   int
+	CEFREstimate string
+	Transcript   []model.TranscriptEntry
+	// ISEResults are the pronunciation evaluations in session order.
+	ISEResults []*model.ISEResponse
+	// Vocabulary is the deduplicated review-word list extracted during the
+	// session.
+	Vocabulary []model.VocabularyEntry
+}
+
+// Thresholds classifying word-level pronunciation results, on iFlytek's
+// 0-100 scale.
+const (
+	reportStrengthScore = 85.0
+	reportWeaknessScore = 60.0
+)
+
+// reportListLimit caps the strength/weakness and vocabulary lists so the
+// report stays readable.
+const reportListLimit = 5
+
+// Generate builds the report from the accumulated session data.
+func (g *ReportGenerator) Generate(data SessionReportData) *model.SessionReport {
+	report := &model.SessionReport{
+		SessionID:    data.SessionID,
+		UserID:       data.UserID,
+		GeneratedAt:  time.Now(),
+		Duration:     data.Duration,
+		DurationMs:   data.Duration.Milliseconds(),
+		TotalTurns:   data.TotalTurns,
+		CEFREstimate: data.CEFREstimate,
+		Transcript:   data.Transcript,
+		VocabLearned: vocabFromTranscript(data.Transcript),
+		Vocabulary:   data.Vocabulary,
+	}
+	report.ISESummary = summarizeISE(data.ISEResults)
+	report.PronunciationStrengths, report.PronunciationWeaknesses = classifyWords(data.ISEResults)
+	return report
+}
+
+// FormatText renders the report as a plain-text document for download.
+func (g *ReportGenerator) FormatText(r *model.SessionReport) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Session report %s\n", r.SessionID)
+	fmt.Fprintf(&b, "Generated: %s\n", r.GeneratedAt.Format(time.RFC3339))
+	fmt.Fprintf(&b, "Duration: %s, turns: %d\n", r.Duration.Round(time.Second), r.TotalTurns)
+	if r.CEFREstimate != "" {
+		fmt.Fprintf(&b, "Level: %s\n", r.CEFREstimate)
+	}
+
+	if r.ISESummary.Evaluations > 0 {
+		fmt.Fprintf(&b, "\nPronunciation (%d evaluations)\n", r.ISESummary.Evaluations)
+		fmt.Fprintf(&b, "  average %.1f, best %.1f, worst %.1f, trend %+.2f per evaluation\n",
+			r.ISESummary.AverageScore, r.ISESummary.BestScore, r.ISESummary.WorstScore, r.ISESummary.TrendSlope)
+		if len(r.ISESummary.ImprovedPhonemes) > 0 {
+			fmt.Fprintf(&b, "  improved phonemes: %s\n", strings.Join(r.ISESummary.ImprovedPhonemes, ", "))
+		}
+		if len(r.ISESummary.TroubledPhonemes) > 0 {
+			fmt.Fprintf(&b, "  troubled phonemes: %s\n", strings.Join(r.ISESummary.TroubledPhonemes, ", "))
+		}
+		if len(r.PronunciationStrengths) > 0 {
+			fmt.Fprintf(&b, "  strong words: %s\n", strings.Join(r.PronunciationStrengths, ", "))
+		}
+		if len(r.PronunciationWeaknesses) > 0 {
+			fmt.Fprintf(&b, "  words to practice: %s\n", strings.Join(r.PronunciationWeaknesses, ", "))
+		}
+	}
+
+	if len(r.VocabLearned) > 0 {
+		b.WriteString("\nVocabulary\n")
+		for _, v := range r.VocabLearned {
+			fmt.Fprintf(&b, "  %s (%d)\n", v.Word, v.Count)
+		}
+	}
+
+	if len(r.Vocabulary) > 0 {
+		b.WriteString("\nWords to review\n")
+		for _, v := range r.Vocabulary {
+			if v.Example != "" {
+				fmt.Fprintf(&b, "  %s — %s\n", v.Word, v.Example)
+			} else {
+				fmt.Fprintf(&b, "  %s\n", v.Word)
+			}
+		}
+	}
+
+	if len(r.Transcript) > 0 {
+		b.WriteString("\nTranscript\n")
+		for _, entry := range r.Transcript {
+			fmt.Fprintf(&b, "  %s: %s\n", entry.Speaker, entry.Text)
+		}
+	}
+	return b.String()
+}
+
+// summarizeISE aggregates the evaluations: score statistics, the trend slope
+// and the phonemes that improved or stayed troubled.
+func summarizeISE(results []*model.ISEResponse) model.ISESessionSummary {
+	summary := model.ISESessionSummary{Evaluations: len(results)}
+	if len(results) == 0 {
+		return summary
+	}
+
+	scores := make([]float64, len(results))
+	sum := 0.0
+	summary.BestScore = results[0].OverallScore
+	summary.WorstScore = results[0].OverallScore
+	for i, r := range results {
+		scores[i] = r.OverallScore
+		sum += r.OverallScore
+		if r.OverallScore > summary.BestScore {
+			summary.BestScore = r.OverallScore
+		}
+		if r.OverallScore < summary.WorstScore {
+			summary.WorstScore = r.OverallScore
+		}
+	}
+	summary.AverageScore = sum / float64(len(results))
+	summary.TrendSlope = trendSlope(scores)
+	summary.ImprovedPhonemes, summary.TroubledPhonemes = phonemeTrends(results)
+	return summary
+}
+
+// trendSlope fits score = slope*index + intercept by least squares and
+// returns the slope; a single evaluation has no trend.
+func trendSlope(scores []float64) float64 {
+	n := float64(len(scores))
+	if n < 2 {
+		return 0
+	}
+	var sumX, sumY, sumXY, sumXX float64
+	for i, y := range scores {
+		x := float64(i)
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+	return (n*sumXY - sumX*sumY) / (n*sumXX - sumX*sumX)
+}
+
+// phonemeTrends compares each phoneme's average score in the first half of
+// the session against the second half. The three with the largest gains are
+// "improved"; the three with the lowest overall averages are "troubled".
+func phonemeTrends(results []*model.ISEResponse) (improved, troubled []string) {
+	type phoneStat struct {
+		firstSum, secondSum float64
+		firstN, secondN     int
+	}
+	stats := make(map[string]*phoneStat)
+	half := len(results) / 2
+	for i, r := range results {
+		for _, w := range r.Words {
+			for _, p := range w.Phones {
+				stat, ok := stats[p.Phone]
+				if !ok {
+					stat = &phoneStat{}
+					stats[p.Phone] = stat
+				}
+				if i < half {
+					stat.firstSum += p.Score
+					stat.firstN++
+				} else {
+					stat.secondSum += p.Score
+					stat.secondN++
+				}
+			}
+		}
+	}
+
+	type scored struct {
+		phone string
+		value float64
+	}
+	var gains, averages []scored
+	for phone, stat := range stats {
+		total := (stat.firstSum + stat.secondSum) / float64(stat.firstN+stat.secondN)
+		averages = append(averages, scored{phone, total})
+		// A gain needs samples on both sides of the midpoint.
+		if stat.firstN > 0 && stat.secondN > 0 {
+			gain := stat.secondSum/float64(stat.secondN) - stat.firstSum/float64(stat.firstN)
+			if gain > 0 {
+				gains = append(gains, scored{phone, gain})
+			}
+		}
+	}
+	sort.Slice(gains, func(i, j int) bool { return gains[i].value > gains[j].value })
+	sort.Slice(averages, func(i, j int) bool { return averages[i].value < averages[j].value })
+
+	for i := 0; i < len(gains) && i < 3; i++ {
+		improved = append(improved, gains[i].phone)
+	}
+	for i := 0; i < len(averages) && i < 3; i++ {
+		if averages[i].value < reportStrengthScore {
+			troubled = append(troubled, averages[i].phone)
+		}
+	}
+	return improved, troubled
+}
+
+// classifyWords splits the evaluated words into consistently strong and weak
+// lists by average score.
+func classifyWords(results []*model.ISEResponse) (strengths, weaknesses []string) {
+	type wordStat struct {
+		sum float64
+		n   int
+	}
+	stats := make(map[string]*wordStat)
+	var order []string
+	for _, r := range results {
+		for _, w := range r.Words {
+			word := strings.ToLower(w.Word)
+			stat, ok := stats[word]
+			if !ok {
+				stat = &wordStat{}
+				stats[word] = stat
+				order = append(order, word)
+			}
+			stat.sum += w.Score
+			stat.n++
+		}
+	}
+	for _, word := range order {
+		avg := stats[word].sum / float64(stats[word].n)
+		switch {
+		case avg >= reportStrengthScore && len(strengths) < reportListLimit:
+			strengths = append(strengths, word)
+		case avg < reportWeaknessScore && len(weaknesses) < reportListLimit:
+			weaknesses = append(weaknesses, word)
+		}
+	}
+	return strengths, weaknesses
+}
+
+// vocabFromTranscript counts the notable words (four letters or longer) the
+// tutor used, as a proxy for vocabulary the learner was exposed to.
+func vocabFromTranscript(transcript []model.TranscriptEntry) []model.VocabEntry {
+	counts := make(map[string]int)
+	for _, entry := range transcript {
+		if entry.Speaker != "tutor" {
+			continue
+		}
+		for _, field := range strings.Fields(entry.Text) {
+			word := strings.ToLower(strings.TrimFunc(field, func(r rune) bool {
+				return !unicode.IsLetter(r)
+			}))
+			if len([]rune(word)) >= 4 {
+				counts[word]++
+			}
+		}
+	}
+
+	vocab := make([]model.VocabEntry, 0, len(counts))
+	for word, count := range counts {
+		vocab = append(vocab, model.VocabEntry{Word: word, Count: count})
+	}
+	sort.Slice(vocab, func(i, j int) bool {
+		if vocab[i].Count != vocab[j].Count {
+			return vocab[i].Count > vocab[j].Count
+		}
+		return vocab[i].Word < vocab[j].Word
+	})
+	if len(vocab) > 2*reportListLimit {
+		vocab = vocab[:2*reportListLimit]
+	}
+	return vocab
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+)
+
+func TestTrendSlope(t *testing.T) {
+	cases := []struct {
+		scores []float64
+		want   float64
+	}{
+		{[]float64{60, 70, 80}, 10},
+		{[]float64{80, 70, 60}, -10},
+		{[]float64{75, 75, 75}, 0},
+		{[]float64{75}, 0},
+		{nil, 0},
+	}
+	for _, tc := range cases {
+		if got := trendSlope(tc.scores); got != tc.want {
+			t.Errorf("trendSlope(%v) = %v, want %v", tc.scores, got, tc.want)
+		}
+	}
+}
+
+func TestGenerateReportSummarizesSession(t *testing.T) {
+	gen := NewReportGenerator()
+	data := SessionReportData{
+		SessionID:    "report-session",
+		UserID:       "learner-1",
+		Duration:     3 * time.Minute,
+		TotalTurns:   2,
+		CEFREstimate: "B1",
+		Transcript: []model.TranscriptEntry{
+			{Speaker: "user", Text: "I visited the museum"},
+			{Speaker: "tutor", Text: "The museum sounds fascinating! Which exhibition did you enjoy?"},
+		},
+		ISEResults: []*model.ISEResponse{
+			{OverallScore: 60, Words: []model.WordScore{
+				{Word: "museum", Score: 90, Phones: []model.PhoneScore{{Phone: "m", Score: 50}, {Phone: "z", Score: 40}}},
+				{Word: "visited", Score: 50, Phones: []model.PhoneScore{{Phone: "v", Score: 55}}},
+			}},
+			{OverallScore: 80, Words: []model.WordScore{
+				{Word: "museum", Score: 95, Phones: []model.PhoneScore{{Phone: "m", Score: 85}, {Phone: "z", Score: 45}}},
+			}},
+		},
+	}
+
+	report := gen.Generate(data)
+	if report.TotalTurns != 2 || report.CEFREstimate != "B1" || report.DurationMs != 180000 {
+		t.Errorf("report header = %+v, want the session data carried over", report)
+	}
+
+	s := report.ISESummary
+	if s.Evaluations != 2 || s.AverageScore != 70 || s.BestScore != 80 || s.WorstScore != 60 {
+		t.Errorf("ise summary = %+v, want evaluations 2, avg 70, best 80, worst 60", s)
+	}
+	if s.TrendSlope != 20 {
+		t.Errorf("trend slope = %v, want 20", s.TrendSlope)
+	}
+	// "m" gained the most (35 points) between the session halves.
+	if len(s.ImprovedPhonemes) == 0 || s.ImprovedPhonemes[0] != "m" {
+		t.Errorf("improved phonemes = %v, want m first", s.ImprovedPhonemes)
+	}
+	if len(s.TroubledPhonemes) == 0 || s.TroubledPhonemes[0] != "z" {
+		t.Errorf("troubled phonemes = %v, want z first", s.TroubledPhonemes)
+	}
+
+	if len(report.PronunciationStrengths) != 1 || report.PronunciationStrengths[0] != "museum" {
+		t.Errorf("strengths = %v, want [museum]", report.PronunciationStrengths)
+	}
+	if len(report.PronunciationWeaknesses) != 1 || report.PronunciationWeaknesses[0] != "visited" {
+		t.Errorf("weaknesses = %v, want [visited]", report.PronunciationWeaknesses)
+	}
+
+	// Vocabulary comes from the tutor's side of the transcript only.
+	var words []string
+	for _, v := range report.VocabLearned {
+		words = append(words, v.Word)
+	}
+	vocab := strings.Join(words, " ")
+	if !strings.Contains(vocab, "fascinating") || !strings.Contains(vocab, "exhibition") {
+		t.Errorf("vocab = %v, want the tutor's notable words", words)
+	}
+	if strings.Contains(vocab, "visited") {
+		t.Errorf("vocab = %v includes the learner's own words", words)
+	}
+}
+
+func TestFormatTextReport(t *testing.T) {
+	gen := NewReportGenerator()
+	report := gen.Generate(SessionReportData{
+		SessionID:  "text-report",
+		Duration:   90 * time.Second,
+		TotalTurns: 1,
+		Transcript: []model.TranscriptEntry{{Speaker: "user", Text: "Hello"}},
+		ISEResults: []*model.ISEResponse{{OverallScore: 72}},
+	})
+
+	text := gen.FormatText(report)
+	for _, want := range []string{
+		"Session report text-report",
+		"Duration: 1m30s, turns: 1",
+		"Pronunciation (1 evaluations)",
+		"user: Hello",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("text report missing %q:\n%s", want, text)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/config"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/logging"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+)
+
+// TTSProvider is one synthesis backend. Providers perform the raw round trip
+// only; caching and result logging live in TTSService so every backend gets
+// them for free.
+type TTSProvider interface {
+	Synthesize(ctx context.Context, text string, params SpeechParams) (*model.TTSResponse, error)
+	// IsAvailable reports whether the provider's endpoint currently accepts
+	// connections, bounded so health checks stay fast.
+	IsAvailable(ctx context.Context) bool
+}
+
+// SpeechParams carries the per-request synthesis options. Zero values mean
+// the provider's configured defaults.
+type SpeechParams struct {
+	Voice string
+	Speed float64
+	// WithWordTimings asks for per-word boundaries where the provider
+	// supports them; providers without timing support return audio only.
+	WithWordTimings bool
+	// RequestID overrides the provider request id; empty mints a random one.
+	RequestID string
+}
+
+// TTSService synthesizes tutor replies through the configured provider,
+// serving repeated phrases from a small in-memory cache.
+type TTSService struct {
+	config   config.TTSConfig
+	provider TTSProvider
+	logger   *logrus.Logger
+
+	cacheMu sync.Mutex
+	cache   map[string]*model.TTSResponse
+}
+
+// NewTTSService creates the TTS client for the configured provider:
+// "bytedance" (the streaming binary protocol, the default) or "azure" (the
+// Cognitive Services REST endpoint).
+func NewTTSService(cfg config.TTSConfig, logger *logrus.Logger) *TTSService {
+	var provider TTSProvider
+	switch cfg.Provider {
+	case "azure":
+		provider = NewAzureTTSProvider(cfg.Azure, logger)
+	default:
+		provider = NewBytedanceTTSProvider(cfg, logger)
+	}
+	return &TTSService{
+		config:   cfg,
+		provider: provider,
+		logger:   logger,
+		cache:    make(map[string]*model.TTSResponse),
+	}
+}
+
+// IsAvailable reports whether the provider's TTS endpoint currently accepts
+// connections.
+func (s *TTSService) IsAvailable(ctx context.Context) bool {
+	return s.provider.IsAvailable(ctx)
+}
+
+// SynthesizeSpeech synthesizes text to MP3 audio, serving repeated phrases
+// from a small in-memory cache.
+func (s *TTSService) SynthesizeSpeech(req *model.TTSRequest) (*model.TTSResponse, error) {
+	return s.synthesize(req, false)
+}
+
+// SynthesizeSpeechWithWordTimings synthesizes like SynthesizeSpeech but also
+// requests per-word timing boundaries so clients can highlight words in step
+// with playback. Providers without timing support return the audio alone.
+func (s *TTSService) SynthesizeSpeechWithWordTimings(req *model.TTSRequest) (*model.TTSResponse, error) {
+	return s.synthesize(req, true)
+}
+
+// ResynthesizeSpeech synthesizes like SynthesizeSpeech but skips the cache
+// lookup and replaces any cached clip. Stage retries use it: a retry exists
+// because the delivered audio was bad, so serving the same cached bytes back
+// would defeat it.
+func (s *TTSService) ResynthesizeSpeech(req *model.TTSRequest) (*model.TTSResponse, error) {
+	s.cacheMu.Lock()
+	delete(s.cache, ttsCacheKey(req, false))
+	s.cacheMu.Unlock()
+	return s.synthesize(req, false)
+}
+
+// ttsCacheKey derives the cache key for one synthesis request.
+func ttsCacheKey(req *model.TTSRequest, withTimings bool) string {
+	return fmt.Sprintf("%s|%s|%.2f|%t", req.Voice, req.Text, req.Speed, withTimings)
+}
+
+// synthesize performs one cached synthesis round trip. Timed and untimed
+// responses are cached separately: a cached untimed clip has no boundaries to
+// serve a timed request with.
+func (s *TTSService) synthesize(req *model.TTSRequest, withTimings bool) (*model.TTSResponse, error) {
+	cacheKey := ttsCacheKey(req, withTimings)
+	s.cacheMu.Lock()
+	if cached, ok := s.cache[cacheKey]; ok {
+		s.cacheMu.Unlock()
+		return cached, nil
+	}
+	s.cacheMu.Unlock()
+
+	start := time.Now()
+	resp, err := s.provider.Synthesize(context.Background(), req.Text, SpeechParams{
+		Voice:           req.Voice,
+		Speed:           req.Speed,
+		WithWordTimings: withTimings,
+		RequestID:       req.RequestID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.logger.WithFields(logrus.Fields{
+		logging.LogFieldService:    "tts",
+		logging.LogFieldAudioBytes: len(resp.AudioData),
+		logging.LogFieldDurationMs: time.Since(start).Milliseconds(),
+		"text_chars":               len(req.Text),
+	}).Info("TTS complete")
+
+	s.cacheMu.Lock()
+	s.cache[cacheKey] = resp
+	s.cacheMu.Unlock()
+	return resp, nil
+}
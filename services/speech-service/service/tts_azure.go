@@ -0,0 +1,147 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/config"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/logging"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+)
+
+// azureOutputFormat is the MP3 encoding requested from Azure, matching the
+// format the ByteDance provider produces so downstream handling is identical.
+const azureOutputFormat = "audio-24khz-48kbitrate-mono-mp3"
+
+// AzureTTSProvider synthesizes through the Azure Cognitive Services TTS REST
+// endpoint, posting SSML and streaming back one MP3 body.
+type AzureTTSProvider struct {
+	config config.AzureTTSConfig
+	client *http.Client
+	logger *logrus.Logger
+}
+
+// NewAzureTTSProvider creates the Azure REST client.
+func NewAzureTTSProvider(cfg config.AzureTTSConfig, logger *logrus.Logger) *AzureTTSProvider {
+	return &AzureTTSProvider{
+		config: cfg,
+		client: &http.Client{Timeout: 30 * time.Second},
+		logger: logger,
+	}
+}
+
+// endpoint is the regional synthesis URL, overridable via BaseURL for tests.
+func (p *AzureTTSProvider) endpoint() string {
+	if p.config.BaseURL != "" {
+		return p.config.BaseURL
+	}
+	return fmt.Sprintf("https://%s.tts.speech.microsoft.com", p.config.Region)
+}
+
+// IsAvailable reports whether the Azure endpoint currently responds, bounded
+// to two seconds so health checks stay fast. Any HTTP response counts: an
+// auth failure still means the service is reachable.
+func (p *AzureTTSProvider) IsAvailable(ctx context.Context) bool {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.endpoint()+"/cognitiveservices/voices/list", nil)
+	if err != nil {
+		return false
+	}
+	req.Header.Set("Ocp-Apim-Subscription-Key", p.config.SubscriptionKey)
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return false
+	}
+	resp.Body.Close()
+	return true
+}
+
+// Synthesize posts SSML for the text and reads the MP3 response in chunks,
+// recording time to first byte the way the streaming provider does. The REST
+// endpoint carries no timing metadata, so WithWordTimings yields audio only.
+func (p *AzureTTSProvider) Synthesize(ctx context.Context, text string, params SpeechParams) (*model.TTSResponse, error) {
+	ssml, err := p.buildSSML(text, params)
+	if err != nil {
+		return nil, fmt.Errorf("build ssml: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		p.endpoint()+"/cognitiveservices/v1", bytes.NewReader(ssml))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Ocp-Apim-Subscription-Key", p.config.SubscriptionKey)
+	req.Header.Set("Content-Type", "application/ssml+xml")
+	req.Header.Set("X-Microsoft-OutputFormat", azureOutputFormat)
+
+	start := time.Now()
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("azure tts request: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		raw, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("azure tts returned %d: %s", resp.StatusCode, string(raw))
+	}
+
+	// Read the body chunk by chunk so FirstByteMs reflects when audio
+	// actually started arriving, not when the whole clip finished.
+	var accumulated []byte
+	var firstByteMs int64
+	buf := make([]byte, 4096)
+	for {
+		n, err := resp.Body.Read(buf)
+		if n > 0 {
+			if firstByteMs == 0 {
+				firstByteMs = time.Since(start).Milliseconds()
+			}
+			accumulated = append(accumulated, buf[:n]...)
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read azure tts response: %w", err)
+		}
+	}
+
+	if params.WithWordTimings {
+		p.logger.WithField(logging.LogFieldService, "tts").
+			Debug("Azure REST synthesis carries no word timings; returning audio only")
+	}
+	return &model.TTSResponse{
+		AudioData:   accumulated,
+		Format:      "mp3",
+		FirstByteMs: firstByteMs,
+	}, nil
+}
+
+// buildSSML renders the synthesis request document, escaping the text and
+// mapping the speed ratio onto a prosody rate percentage (1.0 is +0%).
+func (p *AzureTTSProvider) buildSSML(text string, params SpeechParams) ([]byte, error) {
+	voice := params.Voice
+	if voice == "" {
+		voice = p.config.Voice
+	}
+	speed := params.Speed
+	if speed == 0 {
+		speed = 1.0
+	}
+	var escaped strings.Builder
+	if err := xml.EscapeText(&escaped, []byte(text)); err != nil {
+		return nil, err
+	}
+	ssml := fmt.Sprintf(
+		`<speak version='1.0' xml:lang='%s'><voice name='%s'><prosody rate='%+.0f%%'>%s</prosody></voice></speak>`,
+		p.config.Language, voice, (speed-1)*100, escaped.String())
+	return []byte(ssml), nil
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/config"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+)
+
+func TestAzureSynthesizeSendsSSML(t *testing.T) {
+	var gotPath, gotKey, gotContentType, gotFormat, gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotKey = r.Header.Get("Ocp-Apim-Subscription-Key")
+		gotContentType = r.Header.Get("Content-Type")
+		gotFormat = r.Header.Get("X-Microsoft-OutputFormat")
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		w.Write([]byte("mp3-bytes"))
+	}))
+	defer server.Close()
+
+	provider := NewAzureTTSProvider(config.AzureTTSConfig{
+		BaseURL:         server.URL,
+		SubscriptionKey: "test-key",
+		Voice:           "en-US-JennyNeural",
+		Language:        "en-US",
+	}, logrus.New())
+
+	resp, err := provider.Synthesize(context.Background(), "apples & pears", SpeechParams{Speed: 1.2})
+	if err != nil {
+		t.Fatalf("Synthesize: %v", err)
+	}
+	if !bytes.Equal(resp.AudioData, []byte("mp3-bytes")) {
+		t.Errorf("AudioData = %q, want the response body", resp.AudioData)
+	}
+	if resp.Format != "mp3" {
+		t.Errorf("Format = %q, want mp3", resp.Format)
+	}
+	if gotPath != "/cognitiveservices/v1" {
+		t.Errorf("path = %q, want /cognitiveservices/v1", gotPath)
+	}
+	if gotKey != "test-key" {
+		t.Errorf("subscription key header = %q, want test-key", gotKey)
+	}
+	if gotContentType != "application/ssml+xml" {
+		t.Errorf("content type = %q, want application/ssml+xml", gotContentType)
+	}
+	if gotFormat != azureOutputFormat {
+		t.Errorf("output format header = %q, want %q", gotFormat, azureOutputFormat)
+	}
+	if !strings.Contains(gotBody, "<voice name='en-US-JennyNeural'>") {
+		t.Errorf("ssml = %q, want the configured voice", gotBody)
+	}
+	if !strings.Contains(gotBody, "rate='+20%'") {
+		t.Errorf("ssml = %q, want the speed as a prosody rate", gotBody)
+	}
+	if !strings.Contains(gotBody, "apples &amp; pears") {
+		t.Errorf("ssml = %q, want the text XML-escaped", gotBody)
+	}
+}
+
+func TestAzureSynthesizeServerError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid ssml", http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	provider := NewAzureTTSProvider(config.AzureTTSConfig{BaseURL: server.URL}, logrus.New())
+	_, err := provider.Synthesize(context.Background(), "hello", SpeechParams{})
+	if err == nil || !strings.Contains(err.Error(), "azure tts returned 400") {
+		t.Fatalf("Synthesize error = %v, want the status in the error", err)
+	}
+}
+
+func TestAzureProviderServesCachedResponses(t *testing.T) {
+	var hits atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		w.Write([]byte("audio"))
+	}))
+	defer server.Close()
+
+	svc := NewTTSService(config.TTSConfig{
+		Provider: "azure",
+		Azure:    config.AzureTTSConfig{BaseURL: server.URL, Voice: "en-US-JennyNeural"},
+	}, logrus.New())
+
+	req := &model.TTSRequest{Text: "good morning", Speed: 1.0}
+	first, err := svc.SynthesizeSpeech(req)
+	if err != nil {
+		t.Fatalf("first SynthesizeSpeech: %v", err)
+	}
+	second, err := svc.SynthesizeSpeech(req)
+	if err != nil {
+		t.Fatalf("second SynthesizeSpeech: %v", err)
+	}
+	if !bytes.Equal(first.AudioData, second.AudioData) {
+		t.Error("cached response differs from original")
+	}
+	if got := hits.Load(); got != 1 {
+		t.Errorf("server saw %d requests, want 1 (second call should hit the cache)", got)
+	}
+}
+
+func TestNewTTSServiceSelectsProvider(t *testing.T) {
+	cases := []struct {
+		provider string
+		want     string
+	}{
+		{"", "bytedance"},
+		{"bytedance", "bytedance"},
+		{"azure", "azure"},
+	}
+	for _, tc := range cases {
+		svc := NewTTSService(config.TTSConfig{Provider: tc.provider}, logrus.New())
+		var got string
+		switch svc.provider.(type) {
+		case *BytedanceTTSProvider:
+			got = "bytedance"
+		case *AzureTTSProvider:
+			got = "azure"
+		}
+		if got != tc.want {
+			t.Errorf("TTS_PROVIDER=%q wired %s, want %s", tc.provider, got, tc.want)
+		}
+	}
+}
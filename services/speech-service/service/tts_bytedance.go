@@ -0,0 +1,256 @@
+package service
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/audio"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/config"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/logging"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+)
+
+// Binary protocol constants for the ByteDance streaming TTS API.
+const (
+	ttsProtocolVersion   = 0x1
+	ttsHeaderSize        = 0x1
+	ttsFullClientRequest = 0x1
+	ttsAudioOnlyResponse = 0xB
+	ttsFrontendMessage   = 0xC
+	ttsErrorResponse     = 0xF
+	ttsJSONSerialization = 0x1
+	ttsGzipCompression   = 0x1
+)
+
+// BytedanceTTSProvider synthesizes over the ByteDance streaming TTS API's
+// binary WebSocket protocol.
+type BytedanceTTSProvider struct {
+	config config.TTSConfig
+	logger *logrus.Logger
+}
+
+// NewBytedanceTTSProvider creates the ByteDance protocol client.
+func NewBytedanceTTSProvider(cfg config.TTSConfig, logger *logrus.Logger) *BytedanceTTSProvider {
+	return &BytedanceTTSProvider{config: cfg, logger: logger}
+}
+
+// IsAvailable reports whether the TTS endpoint currently accepts
+// connections, bounded to two seconds so health checks stay fast.
+func (p *BytedanceTTSProvider) IsAvailable(ctx context.Context) bool {
+	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+	defer cancel()
+	header := http.Header{}
+	header.Set("Authorization", fmt.Sprintf("Bearer; %s", p.config.Token))
+	conn, _, err := providerDialer.DialContext(ctx, p.config.BaseURL, header)
+	if err != nil {
+		return false
+	}
+	conn.Close()
+	return true
+}
+
+// Synthesize performs the synthesis round trip, accumulating streamed audio
+// chunks until the server marks the last one. With WithWordTimings set the
+// request also asks for frontend messages, which are parsed into per-word
+// boundaries as they arrive.
+func (p *BytedanceTTSProvider) Synthesize(ctx context.Context, text string, params SpeechParams) (*model.TTSResponse, error) {
+	header := http.Header{}
+	header.Set("Authorization", fmt.Sprintf("Bearer; %s", p.config.Token))
+	conn, _, err := providerDialer.DialContext(ctx, p.config.BaseURL, header)
+	if err != nil {
+		return nil, fmt.Errorf("dial tts endpoint: %w", err)
+	}
+	defer conn.Close()
+
+	if err := p.sendSynthesisRequest(conn, text, params); err != nil {
+		return nil, fmt.Errorf("send tts request: %w", err)
+	}
+
+	// Chunk boundaries from the provider don't align with MP3 frame
+	// boundaries, so partial frames are held back until the bytes that
+	// complete them arrive. Whatever is left after the last chunk is a
+	// truncated frame that would trip up the client's decoder.
+	var accumulated, pending []byte
+	var boundaries []model.WordBoundary
+	var firstByteMs int64
+	start := time.Now()
+	deadline := start.Add(30 * time.Second)
+	for {
+		conn.SetReadDeadline(deadline)
+		_, raw, err := conn.ReadMessage()
+		if err != nil {
+			return nil, fmt.Errorf("read tts response: %w", err)
+		}
+		chunk, frontend, last, err := p.parseResponse(raw)
+		if err != nil {
+			return nil, err
+		}
+		if params.WithWordTimings && len(frontend) > 0 {
+			boundaries = append(boundaries, p.parseWordBoundaries(frontend)...)
+		}
+		if len(chunk) > 0 && firstByteMs == 0 {
+			firstByteMs = time.Since(start).Milliseconds()
+		}
+		pending = append(pending, chunk...)
+		complete, rest := audio.ExtractCompleteFrames(pending)
+		accumulated = append(accumulated, complete...)
+		pending = rest
+		if last {
+			break
+		}
+	}
+	if len(pending) > 0 {
+		if len(accumulated) == 0 {
+			// Nothing parsed as MP3 at all; pass the stream through rather
+			// than synthesizing silence over a framing assumption.
+			accumulated = pending
+		} else {
+			p.logger.WithFields(logrus.Fields{
+				logging.LogFieldService: "tts",
+				"dropped_bytes":         len(pending),
+			}).Warn("Dropping trailing partial MP3 frame")
+		}
+	}
+
+	return &model.TTSResponse{
+		AudioData:      accumulated,
+		Format:         "mp3",
+		FirstByteMs:    firstByteMs,
+		WordBoundaries: boundaries,
+	}, nil
+}
+
+// sendSynthesisRequest sends the full client request frame. WithWordTimings
+// asks the provider to interleave frontend messages carrying phoneme timings
+// with the audio chunks.
+func (p *BytedanceTTSProvider) sendSynthesisRequest(conn *websocket.Conn, text string, params SpeechParams) error {
+	voice := params.Voice
+	if voice == "" {
+		voice = p.config.VoiceType
+	}
+	speed := params.Speed
+	if speed == 0 {
+		speed = p.config.Speed
+	}
+	reqid := params.RequestID
+	if reqid == "" {
+		reqid = uuid.NewString()
+	}
+	request := map[string]interface{}{
+		"reqid":     reqid,
+		"text":      text,
+		"text_type": "plain",
+		"operation": "submit",
+	}
+	if params.WithWordTimings {
+		request["with_frontend"] = 1
+		request["frontend_type"] = "unitTson"
+	}
+	payload := map[string]interface{}{
+		"app": map[string]interface{}{
+			"appid":   p.config.AppID,
+			"token":   p.config.Token,
+			"cluster": p.config.Cluster,
+		},
+		"user": map[string]interface{}{
+			"uid": uuid.NewString(),
+		},
+		"audio": map[string]interface{}{
+			"voice_type":  voice,
+			"encoding":    "mp3",
+			"speed_ratio": speed,
+		},
+		"request": request,
+	}
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	compressed, err := compressData(raw)
+	if err != nil {
+		return err
+	}
+	// The TTS header bytes are identical to the ASR ones, so the shared frame
+	// builder produces the exact frame this function used to assemble inline.
+	frame := buildFrame(ttsFullClientRequest, 0, compressed)
+	return conn.WriteMessage(websocket.BinaryMessage, frame)
+}
+
+// parseResponse decodes one server frame, returning either an audio chunk or
+// a frontend payload, plus whether the stream is done (negative sequence
+// number on the audio chunk).
+func (p *BytedanceTTSProvider) parseResponse(raw []byte) (chunk, frontend []byte, last bool, err error) {
+	if len(raw) < 4 {
+		return nil, nil, false, fmt.Errorf("tts response frame too short: %d bytes", len(raw))
+	}
+	msgType := raw[1] >> 4
+	flags := raw[1] & 0x0F
+	compression := raw[2] & 0x0F
+	payload := raw[4:]
+
+	switch msgType {
+	case ttsErrorResponse:
+		if compression == ttsGzipCompression && len(payload) > 8 {
+			if d, err := decompressData(payload[8:]); err == nil {
+				return nil, nil, false, fmt.Errorf("tts server error: %s", string(d))
+			}
+		}
+		return nil, nil, false, fmt.Errorf("tts server error frame")
+	case ttsAudioOnlyResponse:
+		if len(payload) < 8 {
+			return nil, nil, false, fmt.Errorf("tts audio frame too short")
+		}
+		sequence := int32(binary.BigEndian.Uint32(payload[:4]))
+		audio := payload[8:]
+		// A negative sequence number marks the last chunk of the stream.
+		last = sequence < 0 || flags == 0x3
+		return audio, nil, last, nil
+	case ttsFrontendMessage:
+		if len(payload) < 4 {
+			return nil, nil, false, fmt.Errorf("tts frontend frame too short")
+		}
+		data := payload[4:]
+		if compression == ttsGzipCompression {
+			if data, err = decompressData(data); err != nil {
+				return nil, nil, false, fmt.Errorf("decompress tts frontend message: %w", err)
+			}
+		}
+		return nil, data, false, nil
+	default:
+		return nil, nil, false, fmt.Errorf("unexpected tts message type 0x%X", msgType)
+	}
+}
+
+// parseWordBoundaries extracts per-word timings from a frontend message. The
+// timings are an enhancement on top of the audio, so a malformed message logs
+// and yields nothing rather than failing the synthesis.
+func (p *BytedanceTTSProvider) parseWordBoundaries(frontend []byte) []model.WordBoundary {
+	var msg struct {
+		Words []struct {
+			Word      string `json:"word"`
+			StartTime int64  `json:"start_time"`
+			EndTime   int64  `json:"end_time"`
+		} `json:"words"`
+	}
+	if err := json.Unmarshal(frontend, &msg); err != nil {
+		p.logger.WithField(logging.LogFieldService, "tts").Warnf("Unparseable frontend message: %v", err)
+		return nil
+	}
+	boundaries := make([]model.WordBoundary, 0, len(msg.Words))
+	for _, w := range msg.Words {
+		boundaries = append(boundaries, model.WordBoundary{
+			Word:    w.Word,
+			StartMs: w.StartTime,
+			EndMs:   w.EndTime,
+		})
+	}
+	return boundaries
+}
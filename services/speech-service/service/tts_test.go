@@ -0,0 +1,189 @@
+package service
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/config"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/testutil"
+)
+
+func TestTTSParseResponse(t *testing.T) {
+	svc := NewBytedanceTTSProvider(config.TTSConfig{}, logrus.New())
+
+	errorFrame := func() []byte {
+		compressed, _ := compressData([]byte("synthesis failed"))
+		payload := make([]byte, 8, 8+len(compressed))
+		payload = append(payload, compressed...)
+		return buildServerFrame(ttsErrorResponse, 0, payload)
+	}()
+
+	cases := []struct {
+		name         string
+		raw          []byte
+		wantAudio    []byte
+		wantFrontend []byte
+		wantLast     bool
+		wantErr      string
+	}{
+		{
+			name:      "intermediate chunk",
+			raw:       testutil.BuildTTSAudioFrame([]byte("chunk-1"), 1),
+			wantAudio: []byte("chunk-1"),
+			wantLast:  false,
+		},
+		{
+			name:      "negative sequence marks last",
+			raw:       testutil.BuildTTSAudioFrame([]byte("chunk-2"), -2),
+			wantAudio: []byte("chunk-2"),
+			wantLast:  true,
+		},
+		{
+			name:         "frontend message",
+			raw:          testutil.BuildTTSFrontendFrame([]byte(`{"words":[]}`)),
+			wantFrontend: []byte(`{"words":[]}`),
+		},
+		{
+			name:    "error frame",
+			raw:     errorFrame,
+			wantErr: "tts server error",
+		},
+		{
+			name:    "unexpected message type",
+			raw:     buildServerFrame(0x7, 0, nil),
+			wantErr: "unexpected tts message type",
+		},
+		{
+			name:    "frame too short",
+			raw:     []byte{0x11, 0xB0},
+			wantErr: "too short",
+		},
+		{
+			name:    "audio frame too short",
+			raw:     buildServerFrame(ttsAudioOnlyResponse, 0, []byte{0x00, 0x01}),
+			wantErr: "audio frame too short",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			audio, frontend, last, err := svc.parseResponse(tc.raw)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("parseResponse error = %v, want containing %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseResponse: %v", err)
+			}
+			if !bytes.Equal(audio, tc.wantAudio) {
+				t.Errorf("audio = %q, want %q", audio, tc.wantAudio)
+			}
+			if !bytes.Equal(frontend, tc.wantFrontend) {
+				t.Errorf("frontend = %q, want %q", frontend, tc.wantFrontend)
+			}
+			if last != tc.wantLast {
+				t.Errorf("last = %v, want %v", last, tc.wantLast)
+			}
+		})
+	}
+}
+
+func TestTTSSynthesizeAccumulatesChunks(t *testing.T) {
+	server := testutil.NewMockTTSServer(testutil.MockTTSConfig{
+		AudioChunks: [][]byte{[]byte("first-"), []byte("second-"), []byte("third")},
+	})
+	defer server.Close()
+
+	svc := NewTTSService(config.TTSConfig{BaseURL: server.URL()}, logrus.New())
+	resp, err := svc.SynthesizeSpeech(&model.TTSRequest{Text: "hello"})
+	if err != nil {
+		t.Fatalf("SynthesizeSpeech: %v", err)
+	}
+	if want := []byte("first-second-third"); !bytes.Equal(resp.AudioData, want) {
+		t.Errorf("AudioData = %q, want %q", resp.AudioData, want)
+	}
+	if resp.Format != "mp3" {
+		t.Errorf("Format = %q, want mp3", resp.Format)
+	}
+}
+
+func TestTTSSynthesizeWithWordTimings(t *testing.T) {
+	server := testutil.NewMockTTSServer(testutil.MockTTSConfig{
+		AudioChunks:  [][]byte{[]byte("speech-audio")},
+		FrontendJSON: `{"words":[{"word":"hello","start_time":0,"end_time":250},{"word":"there","start_time":250,"end_time":600}]}`,
+	})
+	defer server.Close()
+
+	svc := NewTTSService(config.TTSConfig{BaseURL: server.URL()}, logrus.New())
+	resp, err := svc.SynthesizeSpeechWithWordTimings(&model.TTSRequest{Text: "hello there"})
+	if err != nil {
+		t.Fatalf("SynthesizeSpeechWithWordTimings: %v", err)
+	}
+	want := []model.WordBoundary{
+		{Word: "hello", StartMs: 0, EndMs: 250},
+		{Word: "there", StartMs: 250, EndMs: 600},
+	}
+	if len(resp.WordBoundaries) != len(want) {
+		t.Fatalf("WordBoundaries = %+v, want %+v", resp.WordBoundaries, want)
+	}
+	for i, b := range want {
+		if resp.WordBoundaries[i] != b {
+			t.Errorf("WordBoundaries[%d] = %+v, want %+v", i, resp.WordBoundaries[i], b)
+		}
+	}
+	if !bytes.Equal(resp.AudioData, []byte("speech-audio")) {
+		t.Errorf("AudioData = %q, want the audio untouched by the frontend frame", resp.AudioData)
+	}
+
+	// A plain synthesis of the same text must not serve the timed cache
+	// entry's boundaries.
+	plain, err := svc.SynthesizeSpeech(&model.TTSRequest{Text: "hello there"})
+	if err != nil {
+		t.Fatalf("SynthesizeSpeech: %v", err)
+	}
+	if len(plain.WordBoundaries) != 0 {
+		t.Errorf("plain synthesis carries %d boundaries, want none", len(plain.WordBoundaries))
+	}
+}
+
+func TestTTSSynthesizeCacheHit(t *testing.T) {
+	server := testutil.NewMockTTSServer(testutil.MockTTSConfig{
+		AudioChunks: [][]byte{[]byte("audio")},
+	})
+	defer server.Close()
+
+	svc := NewTTSService(config.TTSConfig{BaseURL: server.URL()}, logrus.New())
+	req := &model.TTSRequest{Text: "good morning", Voice: "tutor", Speed: 1.0}
+
+	first, err := svc.SynthesizeSpeech(req)
+	if err != nil {
+		t.Fatalf("first SynthesizeSpeech: %v", err)
+	}
+	second, err := svc.SynthesizeSpeech(req)
+	if err != nil {
+		t.Fatalf("second SynthesizeSpeech: %v", err)
+	}
+	if !bytes.Equal(first.AudioData, second.AudioData) {
+		t.Error("cached response differs from original")
+	}
+	if got := server.Dials(); got != 1 {
+		t.Errorf("server accepted %d dials, want 1 (second call should hit the cache)", got)
+	}
+}
+
+func TestTTSSynthesizeServerError(t *testing.T) {
+	server := testutil.NewMockTTSServer(testutil.MockTTSConfig{ErrorFrame: true})
+	defer server.Close()
+
+	svc := NewTTSService(config.TTSConfig{BaseURL: server.URL()}, logrus.New())
+	_, err := svc.SynthesizeSpeech(&model.TTSRequest{Text: "hello"})
+	if err == nil || !strings.Contains(err.Error(), "tts server error") {
+		t.Fatalf("SynthesizeSpeech error = %v, want tts server error", err)
+	}
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ErrQuotaExceeded is returned when a completion would push token consumption
+// past the daily limit.
+type ErrQuotaExceeded struct {
+	TokensUsed int64
+	DailyLimit int64
+}
+
+func (e ErrQuotaExceeded) Error() string {
+	return fmt.Sprintf("llm daily token quota exceeded: %d of %d tokens used", e.TokensUsed, e.DailyLimit)
+}
+
+// usageFile is the on-disk shape of one day's consumption.
+type usageFile struct {
+	Date     string `json:"date"`
+	Consumed int64  `json:"consumed"`
+}
+
+// UsageSnapshot is the read-only view served by the /llm/usage endpoint.
+type UsageSnapshot struct {
+	Consumed   int64     `json:"consumed"`
+	DailyLimit int64     `json:"daily_limit"`
+	ResetAt    time.Time `json:"reset_at"`
+}
+
+// UsageTracker enforces a daily LLM token budget. Consumption is persisted to
+// one JSON file per day so restarts don't reset the quota.
+type UsageTracker struct {
+	mu              sync.Mutex
+	maxTokensPerDay int64
+	consumed        int64
+	resetAt         time.Time
+
+	dir    string
+	logger *logrus.Logger
+}
+
+// NewUsageTracker creates a tracker rooted at dir, restoring today's
+// consumption if a usage file already exists.
+func NewUsageTracker(maxTokensPerDay int64, dir string, logger *logrus.Logger) (*UsageTracker, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create usage dir: %w", err)
+	}
+	t := &UsageTracker{
+		maxTokensPerDay: maxTokensPerDay,
+		resetAt:         nextMidnight(time.Now()),
+		dir:             dir,
+		logger:          logger,
+	}
+	t.restore()
+	return t, nil
+}
+
+// Record adds tokens actually consumed by a completed API call.
+func (t *UsageTracker) Record(tokens int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.rollOverLocked()
+	t.consumed += int64(tokens)
+	t.persistLocked()
+}
+
+// CheckQuota returns ErrQuotaExceeded if spending estimatedTokens would go
+// over the daily limit. A zero limit disables enforcement.
+func (t *UsageTracker) CheckQuota(estimatedTokens int) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.rollOverLocked()
+	if t.maxTokensPerDay <= 0 {
+		return nil
+	}
+	if t.consumed+int64(estimatedTokens) > t.maxTokensPerDay {
+		return ErrQuotaExceeded{TokensUsed: t.consumed, DailyLimit: t.maxTokensPerDay}
+	}
+	return nil
+}
+
+// Usage returns the current consumption for the admin endpoint.
+func (t *UsageTracker) Usage() UsageSnapshot {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.rollOverLocked()
+	return UsageSnapshot{
+		Consumed:   t.consumed,
+		DailyLimit: t.maxTokensPerDay,
+		ResetAt:    t.resetAt,
+	}
+}
+
+// rollOverLocked resets consumption once the day boundary passes.
+func (t *UsageTracker) rollOverLocked() {
+	now := time.Now()
+	if now.Before(t.resetAt) {
+		return
+	}
+	t.consumed = 0
+	t.resetAt = nextMidnight(now)
+}
+
+// restore loads today's usage file, if present.
+func (t *UsageTracker) restore() {
+	raw, err := os.ReadFile(t.filePath(time.Now()))
+	if err != nil {
+		return
+	}
+	var f usageFile
+	if err := json.Unmarshal(raw, &f); err != nil {
+		t.logger.Warnf("Ignoring corrupt llm usage file: %v", err)
+		return
+	}
+	t.consumed = f.Consumed
+}
+
+// persistLocked writes the current day's consumption.
+func (t *UsageTracker) persistLocked() {
+	now := time.Now()
+	raw, err := json.Marshal(usageFile{
+		Date:     now.Format("2006-01-02"),
+		Consumed: t.consumed,
+	})
+	if err != nil {
+		return
+	}
+	if err := os.WriteFile(t.filePath(now), raw, 0o644); err != nil {
+		t.logger.Warnf("Failed to persist llm usage: %v", err)
+	}
+}
+
+func (t *UsageTracker) filePath(now time.Time) string {
+	return filepath.Join(t.dir, fmt.Sprintf("llm_usage_%s.json", now.Format("2006-01-02")))
+}
+
+func nextMidnight(now time.Time) time.Time {
+	year, month, day := now.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, now.Location()).AddDate(0, 0, 1)
+}
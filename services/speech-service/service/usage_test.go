@@ -0,0 +1,47 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestUsageTrackerQuota(t *testing.T) {
+	tracker, err := NewUsageTracker(100, t.TempDir(), logrus.New())
+	if err != nil {
+		t.Fatalf("NewUsageTracker failed: %v", err)
+	}
+
+	if err := tracker.CheckQuota(50); err != nil {
+		t.Fatalf("CheckQuota under limit returned error: %v", err)
+	}
+	tracker.Record(80)
+
+	err = tracker.CheckQuota(50)
+	var quotaErr ErrQuotaExceeded
+	if !errors.As(err, &quotaErr) {
+		t.Fatalf("CheckQuota over limit returned %v, want ErrQuotaExceeded", err)
+	}
+	if quotaErr.TokensUsed != 80 || quotaErr.DailyLimit != 100 {
+		t.Errorf("ErrQuotaExceeded = %+v, want TokensUsed=80 DailyLimit=100", quotaErr)
+	}
+}
+
+func TestUsageTrackerPersistsAcrossRestart(t *testing.T) {
+	dir := t.TempDir()
+
+	tracker, err := NewUsageTracker(1000, dir, logrus.New())
+	if err != nil {
+		t.Fatalf("NewUsageTracker failed: %v", err)
+	}
+	tracker.Record(123)
+
+	restarted, err := NewUsageTracker(1000, dir, logrus.New())
+	if err != nil {
+		t.Fatalf("NewUsageTracker after restart failed: %v", err)
+	}
+	if got := restarted.Usage().Consumed; got != 123 {
+		t.Errorf("restored consumption = %d, want 123", got)
+	}
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/logging"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+)
+
+// commonWordsRaw is the bundled list of high-frequency English words that the
+// heuristic extractor treats as not worth reviewing.
+//
+//go:embed common_words.txt
+var commonWordsRaw string
+
+var commonWords = func() map[string]bool {
+	words := make(map[string]bool)
+	for _, line := range strings.Split(commonWordsRaw, "\n") {
+		if word := strings.TrimSpace(line); word != "" {
+			words[word] = true
+		}
+	}
+	return words
+}()
+
+// vocabListLimit caps how many words one turn can contribute, keeping both
+// prompts and the accumulated list bounded.
+const vocabListLimit = 5
+
+// ExtractVocabulary picks the words from one turn that are worth the learner
+// reviewing later, each with an example sentence. It asks the model for a
+// structured pick first and falls back to the frequency heuristic when the
+// call fails or returns something unparseable, so extraction never errors.
+func (s *LLMService) ExtractVocabulary(userText, assistantText string) []model.VocabularyEntry {
+	prompt := fmt.Sprintf(
+		"From this exchange in an English lesson, pick up to %d words the learner likely found new or difficult.\n"+
+			"Learner: %q\nTutor: %q\n"+
+			"Reply with only a JSON array like [{\"word\": \"...\", \"example\": \"a short example sentence\"}]. "+
+			"Reply with [] if nothing qualifies.",
+		vocabListLimit, userText, assistantText)
+	messages := []model.ChatMessage{
+		{Role: "system", Content: "You extract vocabulary for language learners. You reply with JSON only."},
+		{Role: "user", Content: prompt},
+	}
+
+	resp, err := s.complete(messages)
+	if err != nil {
+		s.logger.WithError(err).WithField(logging.LogFieldService, "llm").Debug("Vocabulary extraction falling back to heuristic")
+		return HeuristicVocabulary(assistantText, userText)
+	}
+	entries, err := parseVocabularyJSON(resp.Text)
+	if err != nil {
+		s.logger.WithError(err).WithField(logging.LogFieldService, "llm").Debug("Unparseable vocabulary extraction, falling back to heuristic")
+		return HeuristicVocabulary(assistantText, userText)
+	}
+	if len(entries) > vocabListLimit {
+		entries = entries[:vocabListLimit]
+	}
+	return entries
+}
+
+// parseVocabularyJSON pulls the JSON array out of a model reply, tolerating
+// prose or code fences around it.
+func parseVocabularyJSON(text string) ([]model.VocabularyEntry, error) {
+	start := strings.Index(text, "[")
+	end := strings.LastIndex(text, "]")
+	if start < 0 || end < start {
+		return nil, fmt.Errorf("no JSON array in reply")
+	}
+	var entries []model.VocabularyEntry
+	if err := json.Unmarshal([]byte(text[start:end+1]), &entries); err != nil {
+		return nil, err
+	}
+	filtered := entries[:0]
+	for _, e := range entries {
+		if strings.TrimSpace(e.Word) != "" {
+			e.Word = strings.ToLower(strings.TrimSpace(e.Word))
+			filtered = append(filtered, e)
+		}
+	}
+	return filtered, nil
+}
+
+// HeuristicVocabulary extracts review candidates without a model call: words
+// of four letters or more that are not on the bundled common-word list, with
+// the sentence they appeared in as the example.
+func HeuristicVocabulary(texts ...string) []model.VocabularyEntry {
+	var entries []model.VocabularyEntry
+	seen := make(map[string]bool)
+	for _, text := range texts {
+		for _, sentence := range splitSentences(text) {
+			for _, field := range strings.Fields(sentence) {
+				word := strings.ToLower(strings.TrimFunc(field, func(r rune) bool {
+					return !unicode.IsLetter(r)
+				}))
+				if len([]rune(word)) < 4 || commonWords[word] || seen[word] {
+					continue
+				}
+				seen[word] = true
+				entries = append(entries, model.VocabularyEntry{Word: word, Example: sentence})
+				if len(entries) == vocabListLimit {
+					return entries
+				}
+			}
+		}
+	}
+	return entries
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"io"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/config"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/testutil"
+)
+
+func TestHeuristicVocabularySkipsCommonWords(t *testing.T) {
+	entries := HeuristicVocabulary("I want to renovate the dilapidated house. It is very old.")
+	words := make(map[string]string)
+	for _, e := range entries {
+		words[e.Word] = e.Example
+	}
+	for _, want := range []string{"renovate", "dilapidated"} {
+		if _, ok := words[want]; !ok {
+			t.Errorf("heuristic missed %q: %+v", want, entries)
+		}
+	}
+	for _, common := range []string{"want", "house", "very"} {
+		if _, ok := words[common]; ok {
+			t.Errorf("heuristic kept common word %q", common)
+		}
+	}
+	if got := words["renovate"]; got != "I want to renovate the dilapidated house." {
+		t.Errorf("example = %q, want the containing sentence", got)
+	}
+}
+
+func TestHeuristicVocabularyDedupsAndCaps(t *testing.T) {
+	entries := HeuristicVocabulary(
+		"The magnificent magnificent aqueduct. Another magnificent aqueduct!",
+		"Vocabulary overflow: alpha bravado cascade delirium ephemeral fulcrum.")
+	seen := make(map[string]bool)
+	for _, e := range entries {
+		if seen[e.Word] {
+			t.Errorf("duplicate word %q in %+v", e.Word, entries)
+		}
+		seen[e.Word] = true
+	}
+	if len(entries) > vocabListLimit {
+		t.Errorf("got %d entries, want at most %d", len(entries), vocabListLimit)
+	}
+}
+
+func TestExtractVocabularyParsesLLMJSON(t *testing.T) {
+	mock := testutil.NewMockLLMServer(testutil.MockLLMConfig{
+		ReplyText: `Here you go: [{"word": "Itinerary", "example": "We planned our itinerary."}]`,
+	})
+	defer mock.Close()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	svc := NewLLMService(config.LLMConfig{BaseURL: mock.URL(), Model: "test-model", MaxTokens: 100}, nil, logger)
+
+	entries := svc.ExtractVocabulary("we planned our trip", "Did you make an itinerary?")
+	if len(entries) != 1 {
+		t.Fatalf("got %d entries, want 1: %+v", len(entries), entries)
+	}
+	if entries[0].Word != "itinerary" {
+		t.Errorf("word = %q, want itinerary (lowercased)", entries[0].Word)
+	}
+	if entries[0].Example != "We planned our itinerary." {
+		t.Errorf("example = %q", entries[0].Example)
+	}
+}
+
+func TestExtractVocabularyFallsBackToHeuristic(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	// An unroutable base URL makes every LLM call fail immediately.
+	svc := NewLLMService(config.LLMConfig{BaseURL: "http://127.0.0.1:1", Model: "test-model", MaxTokens: 100}, nil, logger)
+
+	entries := svc.ExtractVocabulary("i saw a peculiar bird", "Peculiar is a great word!")
+	var found bool
+	for _, e := range entries {
+		if e.Word == "peculiar" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("heuristic fallback missed %q: %+v", "peculiar", entries)
+	}
+}
+
+func TestExtractVocabularyFallsBackOnUnparseableReply(t *testing.T) {
+	mock := testutil.NewMockLLMServer(testutil.MockLLMConfig{ReplyText: "Sounds great, keep practicing!"})
+	defer mock.Close()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	svc := NewLLMService(config.LLMConfig{BaseURL: mock.URL(), Model: "test-model", MaxTokens: 100}, nil, logger)
+
+	entries := svc.ExtractVocabulary("the scenery was breathtaking", "It sounds wonderful!")
+	var found bool
+	for _, e := range entries {
+		if e.Word == "breathtaking" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("fallback after unparseable reply missed %q: %+v", "breathtaking", entries)
+	}
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/config"
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/logging"
+)
+
+//go:embed voices.json
+var embeddedVoices []byte
+
+// ttsVoiceSelected counts automatic voice selections so dashboards show which
+// voices (and therefore which reply languages) learners actually hear.
+var ttsVoiceSelected = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "tts_voice_selected_total",
+	Help: "Automatic TTS voice selections by voice and detected language.",
+}, []string{"voice_id", "language"})
+
+// VoiceGender narrows catalog matches to a speaker gender.
+type VoiceGender string
+
+// Gender values used in the catalog; VoiceGenderAny matches every entry.
+const (
+	VoiceGenderAny    VoiceGender = ""
+	VoiceGenderFemale VoiceGender = "female"
+	VoiceGenderMale   VoiceGender = "male"
+)
+
+// VoiceOption is one ByteDance voice the catalog can route a reply to. The
+// zero value means no suitable voice was found.
+type VoiceOption struct {
+	VoiceID  string      `json:"voice_id"`
+	Language string      `json:"language"`
+	Gender   VoiceGender `json:"gender"`
+	Name     string      `json:"name"`
+}
+
+// VoiceCatalog maps languages to the provider voices that speak them.
+type VoiceCatalog struct {
+	entries []VoiceOption
+}
+
+// NewVoiceCatalog loads the catalog embedded in the binary.
+func NewVoiceCatalog() (*VoiceCatalog, error) {
+	var parsed struct {
+		Voices []VoiceOption `json:"voices"`
+	}
+	if err := json.Unmarshal(embeddedVoices, &parsed); err != nil {
+		return nil, fmt.Errorf("parse embedded voice catalog: %w", err)
+	}
+	if len(parsed.Voices) == 0 {
+		return nil, fmt.Errorf("embedded voice catalog is empty")
+	}
+	return &VoiceCatalog{entries: parsed.Voices}, nil
+}
+
+// BestMatchForLanguage picks a voice for the language, preferring an exact
+// language and gender match, then the language in any gender, then another
+// variant of the same language family (en_gb falls back to en_us). The zero
+// VoiceOption is returned when nothing in the catalog comes close.
+func (c *VoiceCatalog) BestMatchForLanguage(lang string, gender VoiceGender) VoiceOption {
+	lang = strings.ToLower(lang)
+	for _, v := range c.entries {
+		if v.Language == lang && (gender == VoiceGenderAny || v.Gender == gender) {
+			return v
+		}
+	}
+	for _, v := range c.entries {
+		if v.Language == lang {
+			return v
+		}
+	}
+	family, _, _ := strings.Cut(lang, "_")
+	if family != "" {
+		for _, v := range c.entries {
+			if f, _, _ := strings.Cut(v.Language, "_"); f == family {
+				return v
+			}
+		}
+	}
+	return VoiceOption{}
+}
+
+// VoiceSelector routes LLM replies to a voice that speaks their language.
+// When disabled (TTS_LANGUAGE_AUTO_SELECT=false) Select never matches and
+// synthesis sticks to the configured default voice.
+type VoiceSelector struct {
+	enabled  bool
+	catalog  *VoiceCatalog
+	detector *LanguageDetector
+	logger   *logrus.Logger
+}
+
+// NewVoiceSelector creates the selector from the TTS config.
+func NewVoiceSelector(cfg config.TTSConfig, logger *logrus.Logger) (*VoiceSelector, error) {
+	catalog, err := NewVoiceCatalog()
+	if err != nil {
+		return nil, err
+	}
+	return &VoiceSelector{
+		enabled:  cfg.LanguageAutoSelect,
+		catalog:  catalog,
+		detector: NewLanguageDetector(),
+		logger:   logger,
+	}, nil
+}
+
+// Select detects the language of the reply text and returns the voice to
+// synthesize it with, plus the detected language. ok is false when selection
+// is disabled or the catalog has no voice for the language, in which case the
+// caller keeps its default voice.
+func (s *VoiceSelector) Select(text string) (voice VoiceOption, language string, ok bool) {
+	if !s.enabled {
+		return VoiceOption{}, "", false
+	}
+	language = s.detector.Detect(text)
+	voice = s.catalog.BestMatchForLanguage(language, VoiceGenderAny)
+	if voice.VoiceID == "" {
+		return VoiceOption{}, language, false
+	}
+	ttsVoiceSelected.WithLabelValues(voice.VoiceID, language).Inc()
+	s.logger.WithFields(logrus.Fields{
+		logging.LogFieldService: "tts",
+		"voice_id":              voice.VoiceID,
+		"language":              language,
+	}).Debug("Auto-selected TTS voice")
+	return voice, language, true
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"io"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/config"
+)
+
+func TestVoiceCatalogBestMatchForLanguage(t *testing.T) {
+	catalog, err := NewVoiceCatalog()
+	if err != nil {
+		t.Fatalf("NewVoiceCatalog: %v", err)
+	}
+
+	cases := []struct {
+		name   string
+		lang   string
+		gender VoiceGender
+		want   string
+	}{
+		{"exact language and gender", "zh_cn", VoiceGenderMale, "zh_cn_002"},
+		{"any gender takes first entry", "zh_cn", VoiceGenderAny, "zh_cn_001"},
+		{"missing gender falls back to language", "ja_jp", VoiceGenderMale, "ja_jp_001"},
+		{"family fallback for unlisted variant", "en_au", VoiceGenderAny, "en_us_001"},
+		{"no match at all", "fr_fr", VoiceGenderAny, ""},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := catalog.BestMatchForLanguage(tc.lang, tc.gender); got.VoiceID != tc.want {
+				t.Errorf("BestMatchForLanguage(%q, %q) = %q, want %q", tc.lang, tc.gender, got.VoiceID, tc.want)
+			}
+		})
+	}
+}
+
+func TestLanguageDetector(t *testing.T) {
+	d := NewLanguageDetector()
+	cases := []struct {
+		text string
+		want string
+	}{
+		{"The cat sat on the mat.", "en_us"},
+		{"你说得很好！", "zh_cn"},
+		{"Let's practice: 你好 means hello.", "zh_cn"},
+		{"すごいですね、漢字も読めます。", "ja_jp"},
+		{"", "en_us"},
+	}
+	for _, tc := range cases {
+		if got := d.Detect(tc.text); got != tc.want {
+			t.Errorf("Detect(%q) = %q, want %q", tc.text, got, tc.want)
+		}
+	}
+}
+
+func TestVoiceSelectorRoutesByReplyLanguage(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	selector, err := NewVoiceSelector(config.TTSConfig{LanguageAutoSelect: true}, logger)
+	if err != nil {
+		t.Fatalf("NewVoiceSelector: %v", err)
+	}
+
+	voice, lang, ok := selector.Select("非常好！我们继续练习。")
+	if !ok || voice.VoiceID != "zh_cn_001" || lang != "zh_cn" {
+		t.Errorf("Select(chinese) = %q/%q/%v, want zh_cn_001/zh_cn/true", voice.VoiceID, lang, ok)
+	}
+	voice, lang, ok = selector.Select("Great job, keep going!")
+	if !ok || voice.VoiceID != "en_us_001" || lang != "en_us" {
+		t.Errorf("Select(english) = %q/%q/%v, want en_us_001/en_us/true", voice.VoiceID, lang, ok)
+	}
+}
+
+func TestVoiceSelectorDisabled(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	selector, err := NewVoiceSelector(config.TTSConfig{}, logger)
+	if err != nil {
+		t.Fatalf("NewVoiceSelector: %v", err)
+	}
+	if _, _, ok := selector.Select("你好"); ok {
+		t.Error("disabled selector returned a voice; the default voice must win")
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/shared/web"
+)
+
+// The embedded practice page must serve without any files on disk, and must
+// carry all four Web Components the protocol's message types render through.
+func TestEmbeddedPracticePageServesWithoutDiskFiles(t *testing.T) {
+	site, err := web.NewStaticSite(embeddedStatic(), "")
+	if err != nil {
+		t.Fatalf("NewStaticSite: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	site.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET / = %d, want 200", rec.Code)
+	}
+
+	body := rec.Body.String()
+	for _, tag := range []string{
+		"<audio-recorder>",
+		"<transcript-display>",
+		"<tts-player>",
+		"<score-display>",
+	} {
+		if !strings.Contains(body, tag) {
+			t.Errorf("index is missing %s", tag)
+		}
+	}
+	// Every server-sent message type must be handled by the page.
+	for _, msgType := range []string{
+		"asr_result", "llm_response", "partial_llm_response", "tts_ready",
+		"word_boundary", "ise_result", "pronunciation_comparison",
+		"session_time_limit_warning", "session_expiring", "session_ended",
+	} {
+		if !strings.Contains(body, `case "`+msgType+`"`) {
+			t.Errorf("index does not handle message type %q", msgType)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package store
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DebugAudioStore persists per-session audio recordings so QA can replay a
+// session when diagnosing ASR or ISE failures.
+type DebugAudioStore interface {
+	// Save writes one recording file for the session.
+	Save(sessionID, filename string, data []byte) error
+	// List returns the recording filenames for the session, sorted by name.
+	// A session without recordings lists as empty rather than erroring.
+	List(sessionID string) ([]string, error)
+	// FilePath resolves a listed filename to a path suitable for serving.
+	FilePath(sessionID, filename string) (string, error)
+	// Purge deletes sessions whose newest recording is older than the cutoff
+	// and returns how many were removed.
+	Purge(olderThan time.Duration) (int, error)
+}
+
+// FileDebugAudioStore keeps recordings on local disk under
+// <dir>/sessions/<sessionID>/.
+type FileDebugAudioStore struct {
+	dir string
+}
+
+// NewFileDebugAudioStore creates the base directory if needed.
+func NewFileDebugAudioStore(dir string) (*FileDebugAudioStore, error) {
+	if err := os.MkdirAll(filepath.Join(dir, "sessions"), 0o755); err != nil {
+		return nil, err
+	}
+	return &FileDebugAudioStore{dir: dir}, nil
+}
+
+// Save implements DebugAudioStore.
+func (f *FileDebugAudioStore) Save(sessionID, filename string, data []byte) error {
+	dir, err := f.sessionDir(sessionID)
+	if err != nil {
+		return err
+	}
+	if err := validPathComponent(filename); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, filename), data, 0o644)
+}
+
+// List implements DebugAudioStore.
+func (f *FileDebugAudioStore) List(sessionID string) ([]string, error) {
+	dir, err := f.sessionDir(sessionID)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return []string{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if !e.IsDir() {
+			names = append(names, e.Name())
+		}
+	}
+	return names, nil
+}
+
+// FilePath implements DebugAudioStore.
+func (f *FileDebugAudioStore) FilePath(sessionID, filename string) (string, error) {
+	dir, err := f.sessionDir(sessionID)
+	if err != nil {
+		return "", err
+	}
+	if err := validPathComponent(filename); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, filename)
+	if _, err := os.Stat(path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// Purge implements DebugAudioStore.
+func (f *FileDebugAudioStore) Purge(olderThan time.Duration) (int, error) {
+	root := filepath.Join(f.dir, "sessions")
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	cutoff := time.Now().Add(-olderThan)
+	purged := 0
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		dir := filepath.Join(root, e.Name())
+		if newestModTime(dir).After(cutoff) {
+			continue
+		}
+		if err := os.RemoveAll(dir); err != nil {
+			return purged, err
+		}
+		purged++
+	}
+	return purged, nil
+}
+
+func (f *FileDebugAudioStore) sessionDir(sessionID string) (string, error) {
+	if err := validPathComponent(sessionID); err != nil {
+		return "", err
+	}
+	return filepath.Join(f.dir, "sessions", sessionID), nil
+}
+
+// validPathComponent rejects names that could escape the store directory.
+// Session IDs come from clients, so they get the same scrutiny as filenames.
+func validPathComponent(name string) error {
+	if name == "" || name == "." || name == ".." ||
+		strings.ContainsAny(name, `/\`) || name != filepath.Base(name) {
+		return fmt.Errorf("invalid path component %q", name)
+	}
+	return nil
+}
+
+// newestModTime returns the most recent modification time of any file in the
+// directory, falling back to the directory's own time when empty.
+func newestModTime(dir string) time.Time {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return time.Time{}
+	}
+	newest := info.ModTime()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return newest
+	}
+	for _, e := range entries {
+		if fi, err := e.Info(); err == nil && fi.ModTime().After(newest) {
+			newest = fi.ModTime()
+		}
+	}
+	return newest
+}
@@ -0,0 +1,95 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFileDebugAudioStoreRoundTrip(t *testing.T) {
+	s, err := NewFileDebugAudioStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileDebugAudioStore: %v", err)
+	}
+
+	if err := s.Save("sess-1", "audio_1.wav", []byte("wav-bytes")); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if err := s.Save("sess-1", "tts_1.mp3", []byte("mp3-bytes")); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	files, err := s.List("sess-1")
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if want := []string{"audio_1.wav", "tts_1.mp3"}; !reflect.DeepEqual(files, want) {
+		t.Errorf("List = %v, want %v", files, want)
+	}
+
+	path, err := s.FilePath("sess-1", "audio_1.wav")
+	if err != nil {
+		t.Fatalf("FilePath: %v", err)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "wav-bytes" {
+		t.Errorf("recording content = %q, want %q", data, "wav-bytes")
+	}
+
+	if files, err := s.List("no-such-session"); err != nil || len(files) != 0 {
+		t.Errorf("List(missing) = %v, %v; want empty, nil", files, err)
+	}
+}
+
+func TestFileDebugAudioStoreRejectsTraversal(t *testing.T) {
+	s, err := NewFileDebugAudioStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileDebugAudioStore: %v", err)
+	}
+	for _, name := range []string{"..", "../escape", "a/b", `a\b`, ""} {
+		if err := s.Save(name, "audio_1.wav", []byte("x")); err == nil {
+			t.Errorf("Save accepted session id %q", name)
+		}
+		if err := s.Save("sess-1", name, []byte("x")); err == nil {
+			t.Errorf("Save accepted filename %q", name)
+		}
+		if _, err := s.FilePath(name, "audio_1.wav"); err == nil {
+			t.Errorf("FilePath accepted session id %q", name)
+		}
+	}
+}
+
+func TestFileDebugAudioStorePurge(t *testing.T) {
+	dir := t.TempDir()
+	s, err := NewFileDebugAudioStore(dir)
+	if err != nil {
+		t.Fatalf("NewFileDebugAudioStore: %v", err)
+	}
+	if err := s.Save("old-session", "audio_1.wav", []byte("x")); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if err := s.Save("fresh-session", "audio_1.wav", []byte("x")); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	// Age the old session past the cutoff.
+	stale := time.Now().Add(-48 * time.Hour)
+	oldDir := filepath.Join(dir, "sessions", "old-session")
+	os.Chtimes(filepath.Join(oldDir, "audio_1.wav"), stale, stale)
+	os.Chtimes(oldDir, stale, stale)
+
+	purged, err := s.Purge(24 * time.Hour)
+	if err != nil {
+		t.Fatalf("Purge: %v", err)
+	}
+	if purged != 1 {
+		t.Errorf("purged = %d, want 1", purged)
+	}
+	if _, err := os.Stat(oldDir); !os.IsNotExist(err) {
+		t.Error("old session directory survived the purge")
+	}
+	if files, _ := s.List("fresh-session"); len(files) != 1 {
+		t.Error("fresh session was purged")
+	}
+}
@@ -0,0 +1,72 @@
+package store
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// SessionRecorder mirrors pipeline audio into a DebugAudioStore for QA
+// replay. Recording failures are logged, never returned: debugging aids must
+// not break a live conversation. With enabled=false every call is a no-op.
+type SessionRecorder struct {
+	enabled bool
+	store   DebugAudioStore
+	logger  *logrus.Logger
+}
+
+// NewSessionRecorder creates the recorder. store may be nil when disabled.
+func NewSessionRecorder(enabled bool, store DebugAudioStore, logger *logrus.Logger) *SessionRecorder {
+	return &SessionRecorder{enabled: enabled, store: store, logger: logger}
+}
+
+// Enabled reports whether recording is active.
+func (r *SessionRecorder) Enabled() bool { return r.enabled && r.store != nil }
+
+// RecordAudio stores the converted utterance audio as audio_<seq>.wav.
+func (r *SessionRecorder) RecordAudio(sessionID string, seq int, pcm []byte) {
+	r.save(sessionID, fmt.Sprintf("audio_%d.wav", seq), pcm)
+}
+
+// RecordRawAudio stores the utterance as uploaded, before conversion, so
+// conversion bugs themselves can be reproduced.
+func (r *SessionRecorder) RecordRawAudio(sessionID string, seq int, data []byte, format string) {
+	r.save(sessionID, fmt.Sprintf("raw_%d.%s", seq, format), data)
+}
+
+// RecordTTS stores the synthesized reply as tts_<seq>.mp3.
+func (r *SessionRecorder) RecordTTS(sessionID string, seq int, mp3 []byte) {
+	r.save(sessionID, fmt.Sprintf("tts_%d.mp3", seq), mp3)
+}
+
+func (r *SessionRecorder) save(sessionID, filename string, data []byte) {
+	if !r.Enabled() || len(data) == 0 {
+		return
+	}
+	if err := r.store.Save(sessionID, filename, data); err != nil {
+		r.logger.WithError(err).Warnf("Failed to record %s for session %s", filename, sessionID)
+	}
+}
+
+// StartCleanupRoutine purges recordings older than the retention window on
+// the given interval, keeping the store inside its GDPR retention promise.
+func (r *SessionRecorder) StartCleanupRoutine(interval, retention time.Duration) {
+	if !r.Enabled() || retention <= 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			purged, err := r.store.Purge(retention)
+			if err != nil {
+				r.logger.WithError(err).Warn("Recording cleanup failed")
+				continue
+			}
+			if purged > 0 {
+				r.logger.Infof("Purged %d expired session recordings", purged)
+			}
+		}
+	}()
+}
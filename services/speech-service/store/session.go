@@ -0,0 +1,134 @@
+// Package store persists session metadata so lesson progress survives
+// client reconnects and service restarts.
+package store
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+)
+
+// SessionStore loads and saves session snapshots.
+type SessionStore interface {
+	// Load returns the snapshot for the session, if one exists and has not
+	// expired.
+	Load(sessionID string) (*model.SessionSnapshot, bool)
+	// Save persists the snapshot, stamping UpdatedAt.
+	Save(snapshot *model.SessionSnapshot) error
+	// Delete removes the snapshot for the session.
+	Delete(sessionID string) error
+}
+
+// FileSessionStore keeps one JSON file per session under a directory.
+type FileSessionStore struct {
+	dir         string
+	snapshotTTL time.Duration
+}
+
+// NewFileSessionStore creates the directory if needed.
+func NewFileSessionStore(dir string, snapshotTTL time.Duration) (*FileSessionStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &FileSessionStore{dir: dir, snapshotTTL: snapshotTTL}, nil
+}
+
+// Load implements SessionStore.
+func (s *FileSessionStore) Load(sessionID string) (*model.SessionSnapshot, bool) {
+	raw, err := os.ReadFile(s.path(sessionID))
+	if err != nil {
+		return nil, false
+	}
+	var snap model.SessionSnapshot
+	if err := json.Unmarshal(raw, &snap); err != nil {
+		return nil, false
+	}
+	if s.snapshotTTL > 0 && time.Since(snap.UpdatedAt) > s.snapshotTTL {
+		os.Remove(s.path(sessionID))
+		return nil, false
+	}
+	return &snap, true
+}
+
+// Save implements SessionStore.
+func (s *FileSessionStore) Save(snapshot *model.SessionSnapshot) error {
+	snapshot.UpdatedAt = time.Now()
+	if snapshot.CreatedAt.IsZero() {
+		snapshot.CreatedAt = snapshot.UpdatedAt
+	}
+	raw, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.path(snapshot.SessionID), raw, 0o644)
+}
+
+// Delete implements SessionStore.
+func (s *FileSessionStore) Delete(sessionID string) error {
+	err := os.Remove(s.path(sessionID))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+func (s *FileSessionStore) path(sessionID string) string {
+	return filepath.Join(s.dir, sessionID+".json")
+}
+
+// MemorySessionStore is an in-memory store for tests and single-node
+// development.
+type MemorySessionStore struct {
+	mu          sync.Mutex
+	snapshots   map[string]*model.SessionSnapshot
+	snapshotTTL time.Duration
+}
+
+// NewMemorySessionStore creates an empty in-memory store.
+func NewMemorySessionStore(snapshotTTL time.Duration) *MemorySessionStore {
+	return &MemorySessionStore{
+		snapshots:   make(map[string]*model.SessionSnapshot),
+		snapshotTTL: snapshotTTL,
+	}
+}
+
+// Load implements SessionStore.
+func (s *MemorySessionStore) Load(sessionID string) (*model.SessionSnapshot, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	snap, ok := s.snapshots[sessionID]
+	if !ok {
+		return nil, false
+	}
+	if s.snapshotTTL > 0 && time.Since(snap.UpdatedAt) > s.snapshotTTL {
+		delete(s.snapshots, sessionID)
+		return nil, false
+	}
+	copied := *snap
+	return &copied, true
+}
+
+// Save implements SessionStore.
+func (s *MemorySessionStore) Save(snapshot *model.SessionSnapshot) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	snapshot.UpdatedAt = time.Now()
+	if snapshot.CreatedAt.IsZero() {
+		snapshot.CreatedAt = snapshot.UpdatedAt
+	}
+	copied := *snapshot
+	s.snapshots[snapshot.SessionID] = &copied
+	return nil
+}
+
+// Delete implements SessionStore.
+func (s *MemorySessionStore) Delete(sessionID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.snapshots, sessionID)
+	return nil
+}
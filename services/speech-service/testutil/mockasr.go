@@ -0,0 +1,177 @@
+package testutil
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	"github.com/gorilla/websocket"
+)
+
+// ASRUtterance scripts one utterance entry of a mock final result, with
+// timings in milliseconds from the start of the recording.
+type ASRUtterance struct {
+	Text    string
+	StartMs int64
+	EndMs   int64
+}
+
+// MockASRConfig scripts the behavior of a MockASRServer.
+type MockASRConfig struct {
+	// Transcript is returned in the final result frame.
+	Transcript string
+	// Confidence accompanies the transcript.
+	Confidence float64
+	// Utterances, when set, ride on the final result as the provider's
+	// per-utterance breakdown of the transcript.
+	Utterances []ASRUtterance
+	// ErrorFrame makes the server answer with a protocol error frame.
+	ErrorFrame bool
+	// CloseEarly makes the server drop the connection after the full client
+	// request, before any audio is acknowledged.
+	CloseEarly bool
+}
+
+// MockASRServer speaks the ByteDance streaming ASR binary protocol: it reads
+// the full client request and audio frames, then replies with a scripted
+// final result (negative sequence).
+type MockASRServer struct {
+	cfg    MockASRConfig
+	server *httptest.Server
+
+	mu          sync.Mutex
+	audioFrames int
+	sawLastFlag bool
+}
+
+// NewMockASRServer starts the scripted server.
+func NewMockASRServer(cfg MockASRConfig) *MockASRServer {
+	m := &MockASRServer{cfg: cfg}
+	m.server = httptest.NewServer(http.HandlerFunc(m.serve))
+	return m
+}
+
+// URL returns the ws:// endpoint to use as ASRConfig.BaseURL.
+func (m *MockASRServer) URL() string { return wsURL(m.server) }
+
+// Close shuts the server down.
+func (m *MockASRServer) Close() { m.server.Close() }
+
+// AudioFrames returns how many audio-only frames the server received.
+func (m *MockASRServer) AudioFrames() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.audioFrames
+}
+
+// SawLastFlag reports whether an audio frame carried the last-chunk flag.
+func (m *MockASRServer) SawLastFlag() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.sawLastFlag
+}
+
+func (m *MockASRServer) serve(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	for {
+		_, raw, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		if len(raw) < 4 {
+			continue
+		}
+		msgType := raw[1] >> 4
+		flags := raw[1] & 0x0F
+
+		switch msgType {
+		case 0x1: // full client request
+			if m.cfg.CloseEarly {
+				return
+			}
+		case 0x2: // audio-only request
+			m.mu.Lock()
+			m.audioFrames++
+			last := flags&0x2 != 0
+			if last {
+				m.sawLastFlag = true
+			}
+			m.mu.Unlock()
+			if last {
+				if m.cfg.ErrorFrame {
+					conn.WriteMessage(websocket.BinaryMessage, buildASRErrorFrame(1013, "invalid audio"))
+				} else {
+					conn.WriteMessage(websocket.BinaryMessage, buildASRResultFrame(m.cfg.Transcript, m.cfg.Confidence, -1, m.cfg.Utterances))
+				}
+				return
+			}
+		}
+	}
+}
+
+// BuildASRResultFrame assembles a full server response frame carrying one
+// result with the given sequence number (negative marks the final result).
+func BuildASRResultFrame(text string, confidence float64, sequence int) []byte {
+	return buildASRResultFrame(text, confidence, sequence, nil)
+}
+
+func buildASRResultFrame(text string, confidence float64, sequence int, utterances []ASRUtterance) []byte {
+	result := map[string]interface{}{"text": text, "confidence": confidence}
+	if len(utterances) > 0 {
+		entries := make([]map[string]interface{}, 0, len(utterances))
+		for _, u := range utterances {
+			entries = append(entries, map[string]interface{}{
+				"text":       u.Text,
+				"start_time": u.StartMs,
+				"end_time":   u.EndMs,
+				"definite":   true,
+			})
+		}
+		result["utterances"] = entries
+	}
+	body, _ := json.Marshal(map[string]interface{}{
+		"code":     1000,
+		"message":  "success",
+		"sequence": sequence,
+		"result":   []map[string]interface{}{result},
+	})
+	compressed := gzipBytes(body)
+
+	// Full server responses carry a 4-byte payload size before the body.
+	payload := make([]byte, 4, 4+len(compressed))
+	binary.BigEndian.PutUint32(payload, uint32(len(compressed)))
+	payload = append(payload, compressed...)
+
+	return buildBinaryFrame(0x9, 0, payload)
+}
+
+// buildASRErrorFrame assembles an error response frame.
+func buildASRErrorFrame(code uint32, message string) []byte {
+	compressed := gzipBytes([]byte(message))
+	payload := make([]byte, 8, 8+len(compressed))
+	binary.BigEndian.PutUint32(payload[:4], code)
+	binary.BigEndian.PutUint32(payload[4:8], uint32(len(compressed)))
+	payload = append(payload, compressed...)
+	return buildBinaryFrame(0xF, 0, payload)
+}
+
+// buildBinaryFrame frames a payload with the shared 4-byte header used by
+// both ByteDance protocols. The payload follows the header directly; any
+// per-message size words (sequence numbers, payload sizes) are part of the
+// payload itself, exactly as the real providers lay them out.
+func buildBinaryFrame(msgType, flags byte, payload []byte) []byte {
+	frame := make([]byte, 0, 4+len(payload))
+	frame = append(frame, 0x1<<4|0x1)       // version | header size
+	frame = append(frame, msgType<<4|flags) // message type | flags
+	frame = append(frame, 0x1<<4|0x1)       // JSON serialization | gzip
+	frame = append(frame, 0x00)             // reserved
+	frame = append(frame, payload...)
+	return frame
+}
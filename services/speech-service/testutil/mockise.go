@@ -0,0 +1,250 @@
+package testutil
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultISEResultXML is a minimal read_sentence result a MockISEServer
+// returns unless the test scripts its own.
+const DefaultISEResultXML = `<?xml version="1.0" encoding="utf-8"?>
+<xml_result>
+  <read_sentence>
+    <rec_paper>
+      <read_sentence total_score="85.5" accuracy_score="84.0" fluency_score="88.0" integrity_score="90.0">
+        <sentence>
+          <word content="hello" total_score="86.0" dp_message="0"/>
+          <word content="there" total_score="83.0" dp_message="0"/>
+        </sentence>
+      </read_sentence>
+    </rec_paper>
+  </read_sentence>
+</xml_result>`
+
+// SentenceLevelISEResultXML carries paper-level scores with no word
+// breakdown, the shape short evaluations sometimes come back with.
+const SentenceLevelISEResultXML = `<?xml version="1.0" encoding="utf-8"?>
+<xml_result>
+  <read_sentence>
+    <rec_paper>
+      <read_sentence total_score="72.0" accuracy_score="70.0" fluency_score="75.0" integrity_score="80.0"/>
+    </rec_paper>
+  </read_sentence>
+</xml_result>`
+
+// ChapterLevelISEResultXML nests the paper under read_chapter instead of
+// read_sentence; the client does not score chapters from this shape and must
+// surface a no-paper error rather than silently returning zeros.
+const ChapterLevelISEResultXML = `<?xml version="1.0" encoding="utf-8"?>
+<xml_result>
+  <read_chapter>
+    <rec_paper>
+      <read_chapter total_score="81.0" accuracy_score="79.0" fluency_score="83.0" integrity_score="85.0"/>
+    </rec_paper>
+  </read_chapter>
+</xml_result>`
+
+// EmptyISEResultXML is a well-formed envelope with no scored paper at all.
+const EmptyISEResultXML = `<?xml version="1.0" encoding="utf-8"?>
+<xml_result></xml_result>`
+
+// MalformedISEResultXML is truncated mid-element and must fail XML parsing.
+const MalformedISEResultXML = `<?xml version="1.0" encoding="utf-8"?>
+<xml_result><read_sentence><rec_paper>`
+
+// MockISEConfig scripts the behavior of a MockISEServer.
+type MockISEConfig struct {
+	// ResultXML is returned (base64-encoded) when the upload completes.
+	// Empty uses DefaultISEResultXML.
+	ResultXML string
+	// ErrorCode makes the server answer the first frame with an error.
+	ErrorCode int
+	// ResponseDelay holds the final result back, for timeout tests.
+	ResponseDelay time.Duration
+}
+
+// ISEFrame is the decoded shape of one received client message, retained for
+// assertions on the ssb/auw command sequence.
+type ISEFrame struct {
+	Cmd    string
+	Aus    int
+	Status int
+}
+
+// MockISEServer speaks the iFlytek ISE JSON protocol: it records the ssb and
+// auw frames it receives, rejects out-of-order uploads with an error frame,
+// and answers the final frame with a scripted XML result.
+type MockISEServer struct {
+	cfg    MockISEConfig
+	server *httptest.Server
+
+	mu         sync.Mutex
+	frames     []ISEFrame
+	audio      []byte
+	refText    string
+	violations []string
+}
+
+// NewMockISEServer starts the scripted server.
+func NewMockISEServer(cfg MockISEConfig) *MockISEServer {
+	m := &MockISEServer{cfg: cfg}
+	m.server = httptest.NewServer(http.HandlerFunc(m.serve))
+	return m
+}
+
+// URL returns the ws:// endpoint to use as ISEConfig.BaseURL.
+func (m *MockISEServer) URL() string { return wsURL(m.server) }
+
+// Close shuts the server down.
+func (m *MockISEServer) Close() { m.server.Close() }
+
+// Frames returns the decoded command sequence received so far.
+func (m *MockISEServer) Frames() []ISEFrame {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make([]ISEFrame, len(m.frames))
+	copy(out, m.frames)
+	return out
+}
+
+// AudioPayload returns the decoded audio bytes received across all auw
+// frames, in upload order, for assertions on what the client actually ships.
+func (m *MockISEServer) AudioPayload() []byte {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make([]byte, len(m.audio))
+	copy(out, m.audio)
+	return out
+}
+
+// ReferenceText returns the text parameter from the ssb frame, with the BOM
+// prefix the protocol requires stripped.
+func (m *MockISEServer) ReferenceText() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return strings.TrimPrefix(m.refText, "\ufeff")
+}
+
+// Violations returns every protocol-sequence rule the client broke: the
+// first frame must be ssb, every later frame must be auw, and the upload
+// must end with aus=4. Empty means the client spoke the protocol correctly.
+func (m *MockISEServer) Violations() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make([]string, len(m.violations))
+	copy(out, m.violations)
+	return out
+}
+
+// recordViolation notes a broken rule and builds the error frame the real
+// service answers malformed uploads with.
+func (m *MockISEServer) recordViolation(detail string) map[string]interface{} {
+	m.mu.Lock()
+	m.violations = append(m.violations, detail)
+	m.mu.Unlock()
+	return map[string]interface{}{
+		"code":    10106,
+		"message": "protocol violation: " + detail,
+		"sid":     "mock-sid",
+	}
+}
+
+func (m *MockISEServer) serve(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	sawSSB := false
+	for {
+		_, raw, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		var msg struct {
+			Business struct {
+				Cmd  string `json:"cmd"`
+				Aus  int    `json:"aus"`
+				Text string `json:"text"`
+			} `json:"business"`
+			Data struct {
+				Status int    `json:"status"`
+				Data   string `json:"data"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(raw, &msg); err != nil {
+			continue
+		}
+
+		m.mu.Lock()
+		m.frames = append(m.frames, ISEFrame{
+			Cmd:    msg.Business.Cmd,
+			Aus:    msg.Business.Aus,
+			Status: msg.Data.Status,
+		})
+		if msg.Business.Cmd == "ssb" {
+			m.refText = msg.Business.Text
+		}
+		if msg.Business.Cmd == "auw" {
+			if chunk, err := base64.StdEncoding.DecodeString(msg.Data.Data); err == nil {
+				m.audio = append(m.audio, chunk...)
+			}
+		}
+		m.mu.Unlock()
+
+		if m.cfg.ErrorCode != 0 {
+			conn.WriteJSON(map[string]interface{}{
+				"code":    m.cfg.ErrorCode,
+				"message": "scripted failure",
+				"sid":     "mock-sid",
+			})
+			return
+		}
+
+		// Enforce the ssb-then-auw ordering the real endpoint requires.
+		switch {
+		case !sawSSB && msg.Business.Cmd != "ssb":
+			conn.WriteJSON(m.recordViolation(
+				fmt.Sprintf("first frame cmd=%q, want ssb", msg.Business.Cmd)))
+			return
+		case sawSSB && msg.Business.Cmd != "auw":
+			conn.WriteJSON(m.recordViolation(
+				fmt.Sprintf("frame cmd=%q after ssb, want auw", msg.Business.Cmd)))
+			return
+		}
+		sawSSB = true
+
+		// The final auw frame (aus=4, status 2) triggers the result.
+		if msg.Business.Cmd == "auw" && msg.Data.Status == 2 {
+			if msg.Business.Aus != 4 {
+				conn.WriteJSON(m.recordViolation(
+					fmt.Sprintf("final frame aus=%d, want 4", msg.Business.Aus)))
+				return
+			}
+			if m.cfg.ResponseDelay > 0 {
+				time.Sleep(m.cfg.ResponseDelay)
+			}
+			xml := m.cfg.ResultXML
+			if xml == "" {
+				xml = DefaultISEResultXML
+			}
+			conn.WriteJSON(map[string]interface{}{
+				"code": 0,
+				"sid":  "mock-sid",
+				"data": map[string]interface{}{
+					"status": 2,
+					"data":   base64.StdEncoding.EncodeToString([]byte(xml)),
+				},
+			})
+			return
+		}
+	}
+}
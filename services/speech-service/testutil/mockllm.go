@@ -0,0 +1,86 @@
+package testutil
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+)
+
+// MockLLMConfig configures the canned chat completion behaviour.
+type MockLLMConfig struct {
+	// ReplyText is returned as the assistant message content.
+	ReplyText string
+	// ReplySequence, when non-empty, serves its entries as successive
+	// completions, repeating the last one; it takes precedence over ReplyText.
+	ReplySequence []string
+	// TokensUsed is reported as the completion's total token usage.
+	TokensUsed int
+	// StatusCode, when non-zero and not 200, makes every request fail with
+	// that status.
+	StatusCode int
+	// Delay holds every response back, to simulate a slow completion.
+	Delay time.Duration
+}
+
+// MockLLMServer is an in-process stand-in for the OpenAI-compatible chat
+// completion API the LLM service talks to.
+type MockLLMServer struct {
+	cfg    MockLLMConfig
+	server *httptest.Server
+
+	mu       sync.Mutex
+	requests [][]byte
+}
+
+// NewMockLLMServer starts the mock. Callers must Close it.
+func NewMockLLMServer(cfg MockLLMConfig) *MockLLMServer {
+	m := &MockLLMServer{cfg: cfg}
+	m.server = httptest.NewServer(http.HandlerFunc(m.serve))
+	return m
+}
+
+// URL returns the base URL to use as LLMConfig.BaseURL.
+func (m *MockLLMServer) URL() string { return m.server.URL }
+
+// Close shuts the mock down.
+func (m *MockLLMServer) Close() { m.server.Close() }
+
+// Requests returns the raw request bodies received so far.
+func (m *MockLLMServer) Requests() [][]byte {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([][]byte(nil), m.requests...)
+}
+
+func (m *MockLLMServer) serve(w http.ResponseWriter, r *http.Request) {
+	body := make([]byte, r.ContentLength)
+	r.Body.Read(body)
+	m.mu.Lock()
+	m.requests = append(m.requests, body)
+	reply := m.cfg.ReplyText
+	if n := len(m.cfg.ReplySequence); n > 0 {
+		i := len(m.requests) - 1
+		if i >= n {
+			i = n - 1
+		}
+		reply = m.cfg.ReplySequence[i]
+	}
+	m.mu.Unlock()
+
+	if m.cfg.Delay > 0 {
+		time.Sleep(m.cfg.Delay)
+	}
+	if m.cfg.StatusCode != 0 && m.cfg.StatusCode != http.StatusOK {
+		http.Error(w, "mock llm error", m.cfg.StatusCode)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"choices": []map[string]interface{}{
+			{"message": map[string]string{"role": "assistant", "content": reply}},
+		},
+		"usage": map[string]int{"total_tokens": m.cfg.TokensUsed},
+	})
+}
@@ -0,0 +1,107 @@
+package testutil
+
+import (
+	"encoding/binary"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	"github.com/gorilla/websocket"
+)
+
+// MockTTSConfig scripts the behavior of a MockTTSServer.
+type MockTTSConfig struct {
+	// AudioChunks are streamed back in order; the last one carries a
+	// negative sequence number.
+	AudioChunks [][]byte
+	// ErrorFrame makes the server answer with a protocol error frame
+	// instead of audio.
+	ErrorFrame bool
+	// FrontendJSON, when non-empty, is sent as a frontend message frame
+	// before the audio chunks, the way the provider interleaves timing info.
+	FrontendJSON string
+}
+
+// MockTTSServer speaks the ByteDance streaming TTS binary protocol: it reads
+// the synthesis request and streams back the scripted audio chunks.
+type MockTTSServer struct {
+	cfg    MockTTSConfig
+	server *httptest.Server
+
+	mu    sync.Mutex
+	dials int
+}
+
+// NewMockTTSServer starts the scripted server.
+func NewMockTTSServer(cfg MockTTSConfig) *MockTTSServer {
+	m := &MockTTSServer{cfg: cfg}
+	m.server = httptest.NewServer(http.HandlerFunc(m.serve))
+	return m
+}
+
+// URL returns the ws:// endpoint to use as TTSConfig.BaseURL.
+func (m *MockTTSServer) URL() string { return wsURL(m.server) }
+
+// Close shuts the server down.
+func (m *MockTTSServer) Close() { m.server.Close() }
+
+// Dials returns how many connections the server accepted.
+func (m *MockTTSServer) Dials() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.dials
+}
+
+func (m *MockTTSServer) serve(w http.ResponseWriter, r *http.Request) {
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	m.mu.Lock()
+	m.dials++
+	m.mu.Unlock()
+
+	// Wait for the synthesis request before answering.
+	if _, _, err := conn.ReadMessage(); err != nil {
+		return
+	}
+
+	if m.cfg.ErrorFrame {
+		payload := make([]byte, 8, 8+16)
+		payload = append(payload, gzipBytes([]byte("synthesis failed"))...)
+		conn.WriteMessage(websocket.BinaryMessage, buildBinaryFrame(0xF, 0, payload))
+		return
+	}
+
+	if m.cfg.FrontendJSON != "" {
+		conn.WriteMessage(websocket.BinaryMessage, BuildTTSFrontendFrame([]byte(m.cfg.FrontendJSON)))
+	}
+	for i, chunk := range m.cfg.AudioChunks {
+		sequence := int32(i + 1)
+		if i == len(m.cfg.AudioChunks)-1 {
+			sequence = -sequence
+		}
+		conn.WriteMessage(websocket.BinaryMessage, BuildTTSAudioFrame(chunk, sequence))
+	}
+}
+
+// BuildTTSAudioFrame assembles an audio-only response frame with the given
+// sequence number (negative marks the last chunk).
+func BuildTTSAudioFrame(audio []byte, sequence int32) []byte {
+	payload := make([]byte, 8, 8+len(audio))
+	binary.BigEndian.PutUint32(payload[:4], uint32(sequence))
+	binary.BigEndian.PutUint32(payload[4:8], uint32(len(audio)))
+	payload = append(payload, audio...)
+	return buildBinaryFrame(0xB, 0, payload)
+}
+
+// BuildTTSFrontendFrame assembles a frontend message frame carrying the given
+// (gzip-compressed on the wire) JSON timing payload.
+func BuildTTSFrontendFrame(frontendJSON []byte) []byte {
+	compressed := gzipBytes(frontendJSON)
+	payload := make([]byte, 4, 4+len(compressed))
+	binary.BigEndian.PutUint32(payload[:4], uint32(len(compressed)))
+	payload = append(payload, compressed...)
+	return buildBinaryFrame(0xC, 0, payload)
+}
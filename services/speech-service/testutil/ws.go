@@ -0,0 +1,32 @@
+// Package testutil provides in-process mock servers that speak the upstream
+// provider protocols (ByteDance ASR/TTS binary framing, iFlytek ISE JSON),
+// so the framing and parsing code can be tested without vendor credentials.
+package testutil
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/gorilla/websocket"
+)
+
+var upgrader = websocket.Upgrader{
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// wsURL rewrites an httptest server URL to the ws scheme.
+func wsURL(server *httptest.Server) string {
+	return "ws" + strings.TrimPrefix(server.URL, "http")
+}
+
+// gzipBytes compresses a payload the way the ByteDance protocols expect.
+func gzipBytes(data []byte) []byte {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	w.Write(data)
+	w.Close()
+	return buf.Bytes()
+}
@@ -0,0 +1,117 @@
+// Package validation checks inbound WebSocket messages against JSON Schemas.
+// A client sending {"type": "control", "data": 12345} used to cause a silent
+// type assertion failure deep in the handler; now the frame is rejected up
+// front with an error naming the offending field.
+package validation
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/qri-io/jsonschema"
+
+	"github.com/CurvatureX/ai-tutor-monorepo/services/speech-service/model"
+)
+
+//go:embed schemas/*.json
+var schemaFS embed.FS
+
+var validationErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "validation_errors_total",
+	Help: "Inbound WebSocket messages rejected by schema validation.",
+}, []string{"type"})
+
+// ValidationErrorDetail points at one offending field.
+type ValidationErrorDetail struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationError aggregates every violation found in one message.
+type ValidationError struct {
+	MessageType string
+	Details     []ValidationErrorDetail
+}
+
+func (e *ValidationError) Error() string {
+	parts := make([]string, 0, len(e.Details))
+	for _, d := range e.Details {
+		parts = append(parts, fmt.Sprintf("%s: %s", d.Field, d.Message))
+	}
+	return fmt.Sprintf("invalid %s message: %s", e.MessageType, strings.Join(parts, "; "))
+}
+
+// MessageValidator holds the compiled per-type schemas.
+type MessageValidator struct {
+	schemas map[string]*jsonschema.Schema
+}
+
+// NewMessageValidator compiles the embedded schemas.
+func NewMessageValidator() (*MessageValidator, error) {
+	v := &MessageValidator{schemas: make(map[string]*jsonschema.Schema)}
+	for _, msgType := range []string{model.MessageTypeControl, model.MessageTypeText} {
+		raw, err := schemaFS.ReadFile("schemas/" + msgType + ".json")
+		if err != nil {
+			return nil, fmt.Errorf("read schema for %q: %w", msgType, err)
+		}
+		schema := &jsonschema.Schema{}
+		if err := json.Unmarshal(raw, schema); err != nil {
+			return nil, fmt.Errorf("parse schema for %q: %w", msgType, err)
+		}
+		v.schemas[msgType] = schema
+	}
+	return v, nil
+}
+
+// Validate decodes one text frame and checks it against the schema for its
+// declared type. Types without a schema pass through so the handler can
+// reject them with its own message.
+func (v *MessageValidator) Validate(raw []byte) (*model.WebSocketMessage, error) {
+	var msg model.WebSocketMessage
+	if err := json.Unmarshal(raw, &msg); err != nil {
+		validationErrors.WithLabelValues("unknown").Inc()
+		return nil, &ValidationError{
+			MessageType: "unknown",
+			Details:     []ValidationErrorDetail{{Field: "(root)", Message: "not a JSON object: " + err.Error()}},
+		}
+	}
+	if msg.Type == "" {
+		validationErrors.WithLabelValues("unknown").Inc()
+		return nil, &ValidationError{
+			MessageType: "unknown",
+			Details:     []ValidationErrorDetail{{Field: "type", Message: "is required"}},
+		}
+	}
+
+	schema, ok := v.schemas[msg.Type]
+	if !ok {
+		return &msg, nil
+	}
+
+	keyErrors, err := schema.ValidateBytes(context.Background(), raw)
+	if err != nil {
+		validationErrors.WithLabelValues(msg.Type).Inc()
+		return nil, &ValidationError{
+			MessageType: msg.Type,
+			Details:     []ValidationErrorDetail{{Field: "(root)", Message: err.Error()}},
+		}
+	}
+	if len(keyErrors) > 0 {
+		validationErrors.WithLabelValues(msg.Type).Inc()
+		verr := &ValidationError{MessageType: msg.Type}
+		for _, ke := range keyErrors {
+			field := ke.PropertyPath
+			if field == "" || field == "/" {
+				field = "(root)"
+			}
+			verr.Details = append(verr.Details, ValidationErrorDetail{Field: field, Message: ke.Message})
+		}
+		return nil, verr
+	}
+	return &msg, nil
+}
@@ -0,0 +1,110 @@
+// Package warmup pre-establishes provider connections and models at startup,
+// so the first learner turn after a deploy doesn't pay every cold-start cost
+// at once.
+package warmup
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Result is the outcome of one warm-up target.
+type Result struct {
+	Name     string
+	Err      error
+	Duration time.Duration
+}
+
+// Detail renders the result for readiness endpoints.
+func (r Result) Detail() string {
+	if r.Err != nil {
+		return fmt.Sprintf("failed after %s: %v", r.Duration.Round(time.Millisecond), r.Err)
+	}
+	return fmt.Sprintf("ok (%s)", r.Duration.Round(time.Millisecond))
+}
+
+// Details maps target names to their rendered outcomes, the shape the HTTP
+// health endpoint reports.
+func Details(results []Result) map[string]string {
+	details := make(map[string]string, len(results))
+	for _, r := range results {
+		details[r.Name] = r.Detail()
+	}
+	return details
+}
+
+// target is one named warm-up action.
+type target struct {
+	name string
+	run  func(ctx context.Context) error
+}
+
+// Runner executes the registered warm-up targets in parallel under a shared
+// startup deadline. Failures are logged and reported, and fail startup only
+// when the warm-up is required.
+type Runner struct {
+	timeout  time.Duration
+	required bool
+	logger   *logrus.Logger
+	targets  []target
+}
+
+// NewRunner creates the runner. timeout bounds the whole warm-up phase;
+// required turns any target failure into a startup failure.
+func NewRunner(timeout time.Duration, required bool, logger *logrus.Logger) *Runner {
+	return &Runner{timeout: timeout, required: required, logger: logger}
+}
+
+// Add registers a warm-up target.
+func (r *Runner) Add(name string, run func(ctx context.Context) error) {
+	r.targets = append(r.targets, target{name: name, run: run})
+}
+
+// Run warms every target and returns once all attempts have finished or the
+// deadline passed, so readiness gating on it never reports ready before the
+// warm-up was attempted. The error is non-nil only when a target failed and
+// the warm-up is required.
+func (r *Runner) Run() ([]Result, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+	defer cancel()
+
+	results := make([]Result, len(r.targets))
+	var wg sync.WaitGroup
+	for i, t := range r.targets {
+		wg.Add(1)
+		go func(i int, t target) {
+			defer wg.Done()
+			start := time.Now()
+			done := make(chan error, 1)
+			go func() { done <- t.run(ctx) }()
+			var err error
+			select {
+			case err = <-done:
+			case <-ctx.Done():
+				// The target may still be running; its connection attempt
+				// keeps the ctx and gives up on its own.
+				err = fmt.Errorf("warm-up deadline passed: %w", ctx.Err())
+			}
+			results[i] = Result{Name: t.name, Err: err, Duration: time.Since(start)}
+		}(i, t)
+	}
+	wg.Wait()
+
+	var failed []string
+	for _, result := range results {
+		if result.Err != nil {
+			r.logger.Warnf("Warm-up of %s failed: %v", result.Name, result.Err)
+			failed = append(failed, result.Name)
+		} else {
+			r.logger.Infof("Warmed up %s in %s", result.Name, result.Duration.Round(time.Millisecond))
+		}
+	}
+	if r.required && len(failed) > 0 {
+		return results, fmt.Errorf("required warm-up failed for %v", failed)
+	}
+	return results, nil
+}
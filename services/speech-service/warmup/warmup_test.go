@@ -0,0 +1,108 @@
+package warmup
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+func quietWarmupLogger() *logrus.Logger {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	return logger
+}
+
+// Readiness is gated on Run returning, so Run must block until every target
+// has been attempted.
+func TestRunWaitsForAllTargets(t *testing.T) {
+	runner := NewRunner(time.Second, false, quietWarmupLogger())
+	gate := make(chan struct{})
+	var attempted atomic.Int32
+	runner.Add("fast", func(ctx context.Context) error {
+		attempted.Add(1)
+		return nil
+	})
+	runner.Add("slow", func(ctx context.Context) error {
+		<-gate
+		attempted.Add(1)
+		return nil
+	})
+
+	ready := make(chan []Result, 1)
+	go func() {
+		results, _ := runner.Run()
+		ready <- results
+	}()
+
+	select {
+	case <-ready:
+		t.Fatal("Run returned before the slow target was attempted")
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(gate)
+
+	select {
+	case results := <-ready:
+		if got := attempted.Load(); got != 2 {
+			t.Fatalf("attempted %d targets, want 2", got)
+		}
+		if len(results) != 2 {
+			t.Fatalf("got %d results, want 2", len(results))
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Run never returned after the slow target finished")
+	}
+}
+
+func TestOptionalWarmupReportsButDoesNotFail(t *testing.T) {
+	runner := NewRunner(time.Second, false, quietWarmupLogger())
+	runner.Add("ok", func(ctx context.Context) error { return nil })
+	runner.Add("broken", func(ctx context.Context) error { return errors.New("endpoint down") })
+
+	results, err := runner.Run()
+	if err != nil {
+		t.Fatalf("optional warm-up failed startup: %v", err)
+	}
+	details := Details(results)
+	if !strings.HasPrefix(details["ok"], "ok") {
+		t.Errorf("details[ok] = %q, want an ok detail", details["ok"])
+	}
+	if !strings.Contains(details["broken"], "endpoint down") {
+		t.Errorf("details[broken] = %q, want the target error surfaced", details["broken"])
+	}
+}
+
+func TestRequiredWarmupFailsStartup(t *testing.T) {
+	runner := NewRunner(time.Second, true, quietWarmupLogger())
+	runner.Add("broken", func(ctx context.Context) error { return errors.New("endpoint down") })
+
+	if _, err := runner.Run(); err == nil {
+		t.Fatal("required warm-up with a failing target returned no error")
+	}
+}
+
+func TestDeadlineBoundsBlockingTargets(t *testing.T) {
+	runner := NewRunner(50*time.Millisecond, false, quietWarmupLogger())
+	runner.Add("hung", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	start := time.Now()
+	results, err := runner.Run()
+	if err != nil {
+		t.Fatalf("optional warm-up failed startup: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("Run took %s, deadline did not bound the hung target", elapsed)
+	}
+	if results[0].Err == nil {
+		t.Error("hung target reported no error")
+	}
+}
@@ -0,0 +1,35 @@
+// Package models holds the WebSocket payload shapes that every backend a
+// frontend can connect to — the gateway and the speech-service's direct
+// endpoint — must emit identically. A field added to one backend's payload
+// belongs here first, so the other backend (and its conformance tests) picks
+// it up instead of the frontend discovering the divergence.
+package models
+
+// ISEPhone is one phoneme of an ise_result word breakdown. ErrorType is the
+// decoded evaluation category: "correct", "omission", "insertion", ...
+type ISEPhone struct {
+	Phone     string  `json:"phone"`
+	Score     float64 `json:"score"`
+	ErrorType string  `json:"error_type,omitempty"`
+}
+
+// ISEWord is one word of an ise_result breakdown, with its phoneme-level
+// detail when the evaluator reported any.
+type ISEWord struct {
+	Word      string     `json:"word"`
+	Score     float64    `json:"score"`
+	ErrorType string     `json:"error_type,omitempty"`
+	Phones    []ISEPhone `json:"phones,omitempty"`
+}
+
+// ISEResultData is the data payload of an ise_result message: the aggregate
+// pronunciation scores for one utterance and the word/phone breakdown
+// against the reference text.
+type ISEResultData struct {
+	OverallScore   float64   `json:"overall_score"`
+	AccuracyScore  float64   `json:"accuracy_score"`
+	FluencyScore   float64   `json:"fluency_score"`
+	IntegrityScore float64   `json:"integrity_score"`
+	ReferenceText  string    `json:"reference_text"`
+	Words          []ISEWord `json:"words,omitempty"`
+}
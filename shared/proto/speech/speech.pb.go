@@ -0,0 +1,3049 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.33.0
+// 	protoc        (unknown)
+// source: speech/speech.proto
+
+package speechv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ControlAction int32
+
+const (
+	ControlAction_CONTROL_ACTION_UNSPECIFIED        ControlAction = 0
+	ControlAction_CONTROL_ACTION_START_RECORDING    ControlAction = 1
+	ControlAction_CONTROL_ACTION_STOP_RECORDING     ControlAction = 2
+	ControlAction_CONTROL_ACTION_PAUSE_SESSION      ControlAction = 3
+	ControlAction_CONTROL_ACTION_RESUME_SESSION     ControlAction = 4
+	ControlAction_CONTROL_ACTION_END_SESSION        ControlAction = 5
+	ControlAction_CONTROL_ACTION_SET_REFERENCE_TEXT ControlAction = 6
+	ControlAction_CONTROL_ACTION_CONFIGURE_SESSION  ControlAction = 7
+	ControlAction_CONTROL_ACTION_START_SHADOWING    ControlAction = 8
+	ControlAction_CONTROL_ACTION_RETRY_TURN         ControlAction = 9
+	ControlAction_CONTROL_ACTION_REQUEST_CORRECTION ControlAction = 10
+)
+
+// Enum value maps for ControlAction.
+var (
+	ControlAction_name = map[int32]string{
+		0:  "CONTROL_ACTION_UNSPECIFIED",
+		1:  "CONTROL_ACTION_START_RECORDING",
+		2:  "CONTROL_ACTION_STOP_RECORDING",
+		3:  "CONTROL_ACTION_PAUSE_SESSION",
+		4:  "CONTROL_ACTION_RESUME_SESSION",
+		5:  "CONTROL_ACTION_END_SESSION",
+		6:  "CONTROL_ACTION_SET_REFERENCE_TEXT",
+		7:  "CONTROL_ACTION_CONFIGURE_SESSION",
+		8:  "CONTROL_ACTION_START_SHADOWING",
+		9:  "CONTROL_ACTION_RETRY_TURN",
+		10: "CONTROL_ACTION_REQUEST_CORRECTION",
+	}
+	ControlAction_value = map[string]int32{
+		"CONTROL_ACTION_UNSPECIFIED":        0,
+		"CONTROL_ACTION_START_RECORDING":    1,
+		"CONTROL_ACTION_STOP_RECORDING":     2,
+		"CONTROL_ACTION_PAUSE_SESSION":      3,
+		"CONTROL_ACTION_RESUME_SESSION":     4,
+		"CONTROL_ACTION_END_SESSION":        5,
+		"CONTROL_ACTION_SET_REFERENCE_TEXT": 6,
+		"CONTROL_ACTION_CONFIGURE_SESSION":  7,
+		"CONTROL_ACTION_START_SHADOWING":    8,
+		"CONTROL_ACTION_RETRY_TURN":         9,
+		"CONTROL_ACTION_REQUEST_CORRECTION": 10,
+	}
+)
+
+func (x ControlAction) Enum() *ControlAction {
+	p := new(ControlAction)
+	*p = x
+	return p
+}
+
+func (x ControlAction) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ControlAction) Descriptor() protoreflect.EnumDescriptor {
+	return file_speech_speech_proto_enumTypes[0].Descriptor()
+}
+
+func (ControlAction) Type() protoreflect.EnumType {
+	return &file_speech_speech_proto_enumTypes[0]
+}
+
+func (x ControlAction) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ControlAction.Descriptor instead.
+func (ControlAction) EnumDescriptor() ([]byte, []int) {
+	return file_speech_speech_proto_rawDescGZIP(), []int{0}
+}
+
+type LLMResultType int32
+
+const (
+	LLMResultType_LLM_RESULT_TYPE_UNSPECIFIED   LLMResultType = 0
+	LLMResultType_LLM_RESULT_TYPE_RESPONSE      LLMResultType = 1
+	LLMResultType_LLM_RESULT_TYPE_STARTER       LLMResultType = 2
+	LLMResultType_LLM_RESULT_TYPE_CLARIFICATION LLMResultType = 3
+	LLMResultType_LLM_RESULT_TYPE_CORRECTION    LLMResultType = 4
+)
+
+// Enum value maps for LLMResultType.
+var (
+	LLMResultType_name = map[int32]string{
+		0: "LLM_RESULT_TYPE_UNSPECIFIED",
+		1: "LLM_RESULT_TYPE_RESPONSE",
+		2: "LLM_RESULT_TYPE_STARTER",
+		3: "LLM_RESULT_TYPE_CLARIFICATION",
+		4: "LLM_RESULT_TYPE_CORRECTION",
+	}
+	LLMResultType_value = map[string]int32{
+		"LLM_RESULT_TYPE_UNSPECIFIED":   0,
+		"LLM_RESULT_TYPE_RESPONSE":      1,
+		"LLM_RESULT_TYPE_STARTER":       2,
+		"LLM_RESULT_TYPE_CLARIFICATION": 3,
+		"LLM_RESULT_TYPE_CORRECTION":    4,
+	}
+)
+
+func (x LLMResultType) Enum() *LLMResultType {
+	p := new(LLMResultType)
+	*p = x
+	return p
+}
+
+func (x LLMResultType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LLMResultType) Descriptor() protoreflect.EnumDescriptor {
+	return file_speech_speech_proto_enumTypes[1].Descriptor()
+}
+
+func (LLMResultType) Type() protoreflect.EnumType {
+	return &file_speech_speech_proto_enumTypes[1]
+}
+
+func (x LLMResultType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LLMResultType.Descriptor instead.
+func (LLMResultType) EnumDescriptor() ([]byte, []int) {
+	return file_speech_speech_proto_rawDescGZIP(), []int{1}
+}
+
+type ErrorCode int32
+
+const (
+	ErrorCode_ERROR_CODE_UNSPECIFIED        ErrorCode = 0
+	ErrorCode_ERROR_CODE_INVALID_REQUEST    ErrorCode = 1
+	ErrorCode_ERROR_CODE_ASR_FAILED         ErrorCode = 2
+	ErrorCode_ERROR_CODE_LLM_FAILED         ErrorCode = 3
+	ErrorCode_ERROR_CODE_TTS_FAILED         ErrorCode = 4
+	ErrorCode_ERROR_CODE_ISE_FAILED         ErrorCode = 5
+	ErrorCode_ERROR_CODE_INTERNAL           ErrorCode = 6
+	ErrorCode_ERROR_CODE_SERVICE_OVERLOADED ErrorCode = 7
+)
+
+// Enum value maps for ErrorCode.
+var (
+	ErrorCode_name = map[int32]string{
+		0: "ERROR_CODE_UNSPECIFIED",
+		1: "ERROR_CODE_INVALID_REQUEST",
+		2: "ERROR_CODE_ASR_FAILED",
+		3: "ERROR_CODE_LLM_FAILED",
+		4: "ERROR_CODE_TTS_FAILED",
+		5: "ERROR_CODE_ISE_FAILED",
+		6: "ERROR_CODE_INTERNAL",
+		7: "ERROR_CODE_SERVICE_OVERLOADED",
+	}
+	ErrorCode_value = map[string]int32{
+		"ERROR_CODE_UNSPECIFIED":        0,
+		"ERROR_CODE_INVALID_REQUEST":    1,
+		"ERROR_CODE_ASR_FAILED":         2,
+		"ERROR_CODE_LLM_FAILED":         3,
+		"ERROR_CODE_TTS_FAILED":         4,
+		"ERROR_CODE_ISE_FAILED":         5,
+		"ERROR_CODE_INTERNAL":           6,
+		"ERROR_CODE_SERVICE_OVERLOADED": 7,
+	}
+)
+
+func (x ErrorCode) Enum() *ErrorCode {
+	p := new(ErrorCode)
+	*p = x
+	return p
+}
+
+func (x ErrorCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ErrorCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_speech_speech_proto_enumTypes[2].Descriptor()
+}
+
+func (ErrorCode) Type() protoreflect.EnumType {
+	return &file_speech_speech_proto_enumTypes[2]
+}
+
+func (x ErrorCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ErrorCode.Descriptor instead.
+func (ErrorCode) EnumDescriptor() ([]byte, []int) {
+	return file_speech_speech_proto_rawDescGZIP(), []int{2}
+}
+
+type VoiceRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	UserId    string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// Types that are assignable to RequestType:
+	//
+	//	*VoiceRequest_AudioData
+	//	*VoiceRequest_Control
+	//	*VoiceRequest_TextInput
+	RequestType isVoiceRequest_RequestType `protobuf_oneof:"request_type"`
+}
+
+func (x *VoiceRequest) Reset() {
+	*x = VoiceRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_speech_speech_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VoiceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VoiceRequest) ProtoMessage() {}
+
+func (x *VoiceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_speech_speech_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VoiceRequest.ProtoReflect.Descriptor instead.
+func (*VoiceRequest) Descriptor() ([]byte, []int) {
+	return file_speech_speech_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *VoiceRequest) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *VoiceRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (m *VoiceRequest) GetRequestType() isVoiceRequest_RequestType {
+	if m != nil {
+		return m.RequestType
+	}
+	return nil
+}
+
+func (x *VoiceRequest) GetAudioData() *AudioChunk {
+	if x, ok := x.GetRequestType().(*VoiceRequest_AudioData); ok {
+		return x.AudioData
+	}
+	return nil
+}
+
+func (x *VoiceRequest) GetControl() *ControlMessage {
+	if x, ok := x.GetRequestType().(*VoiceRequest_Control); ok {
+		return x.Control
+	}
+	return nil
+}
+
+func (x *VoiceRequest) GetTextInput() *TextInput {
+	if x, ok := x.GetRequestType().(*VoiceRequest_TextInput); ok {
+		return x.TextInput
+	}
+	return nil
+}
+
+type isVoiceRequest_RequestType interface {
+	isVoiceRequest_RequestType()
+}
+
+type VoiceRequest_AudioData struct {
+	AudioData *AudioChunk `protobuf:"bytes,3,opt,name=audio_data,json=audioData,proto3,oneof"`
+}
+
+type VoiceRequest_Control struct {
+	Control *ControlMessage `protobuf:"bytes,4,opt,name=control,proto3,oneof"`
+}
+
+type VoiceRequest_TextInput struct {
+	TextInput *TextInput `protobuf:"bytes,5,opt,name=text_input,json=textInput,proto3,oneof"`
+}
+
+func (*VoiceRequest_AudioData) isVoiceRequest_RequestType() {}
+
+func (*VoiceRequest_Control) isVoiceRequest_RequestType() {}
+
+func (*VoiceRequest_TextInput) isVoiceRequest_RequestType() {}
+
+type TextInput struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Text string `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+}
+
+func (x *TextInput) Reset() {
+	*x = TextInput{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_speech_speech_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TextInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TextInput) ProtoMessage() {}
+
+func (x *TextInput) ProtoReflect() protoreflect.Message {
+	mi := &file_speech_speech_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TextInput.ProtoReflect.Descriptor instead.
+func (*TextInput) Descriptor() ([]byte, []int) {
+	return file_speech_speech_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TextInput) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+type AudioChunk struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Data       []byte `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	Format     string `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"`
+	SampleRate int32  `protobuf:"varint,3,opt,name=sample_rate,json=sampleRate,proto3" json:"sample_rate,omitempty"`
+	Sequence   int32  `protobuf:"varint,4,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	IsFinal    bool   `protobuf:"varint,5,opt,name=is_final,json=isFinal,proto3" json:"is_final,omitempty"`
+}
+
+func (x *AudioChunk) Reset() {
+	*x = AudioChunk{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_speech_speech_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AudioChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AudioChunk) ProtoMessage() {}
+
+func (x *AudioChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_speech_speech_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AudioChunk.ProtoReflect.Descriptor instead.
+func (*AudioChunk) Descriptor() ([]byte, []int) {
+	return file_speech_speech_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AudioChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *AudioChunk) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *AudioChunk) GetSampleRate() int32 {
+	if x != nil {
+		return x.SampleRate
+	}
+	return 0
+}
+
+func (x *AudioChunk) GetSequence() int32 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *AudioChunk) GetIsFinal() bool {
+	if x != nil {
+		return x.IsFinal
+	}
+	return false
+}
+
+type ControlMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Action     ControlAction     `protobuf:"varint,1,opt,name=action,proto3,enum=speech.v1.ControlAction" json:"action,omitempty"`
+	Parameters map[string]string `protobuf:"bytes,2,rep,name=parameters,proto3" json:"parameters,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *ControlMessage) Reset() {
+	*x = ControlMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_speech_speech_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ControlMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ControlMessage) ProtoMessage() {}
+
+func (x *ControlMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_speech_speech_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ControlMessage.ProtoReflect.Descriptor instead.
+func (*ControlMessage) Descriptor() ([]byte, []int) {
+	return file_speech_speech_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ControlMessage) GetAction() ControlAction {
+	if x != nil {
+		return x.Action
+	}
+	return ControlAction_CONTROL_ACTION_UNSPECIFIED
+}
+
+func (x *ControlMessage) GetParameters() map[string]string {
+	if x != nil {
+		return x.Parameters
+	}
+	return nil
+}
+
+type VoiceResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SessionId string `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// Types that are assignable to ResponseType:
+	//
+	//	*VoiceResponse_AsrResult
+	//	*VoiceResponse_LlmResult
+	//	*VoiceResponse_TtsResult
+	//	*VoiceResponse_IseResult
+	//	*VoiceResponse_Status
+	//	*VoiceResponse_Error
+	//	*VoiceResponse_PronunciationComparison
+	//	*VoiceResponse_ShadowingResult
+	ResponseType isVoiceResponse_ResponseType `protobuf_oneof:"response_type"`
+}
+
+func (x *VoiceResponse) Reset() {
+	*x = VoiceResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_speech_speech_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *VoiceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VoiceResponse) ProtoMessage() {}
+
+func (x *VoiceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_speech_speech_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VoiceResponse.ProtoReflect.Descriptor instead.
+func (*VoiceResponse) Descriptor() ([]byte, []int) {
+	return file_speech_speech_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *VoiceResponse) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (m *VoiceResponse) GetResponseType() isVoiceResponse_ResponseType {
+	if m != nil {
+		return m.ResponseType
+	}
+	return nil
+}
+
+func (x *VoiceResponse) GetAsrResult() *ASRResult {
+	if x, ok := x.GetResponseType().(*VoiceResponse_AsrResult); ok {
+		return x.AsrResult
+	}
+	return nil
+}
+
+func (x *VoiceResponse) GetLlmResult() *LLMResult {
+	if x, ok := x.GetResponseType().(*VoiceResponse_LlmResult); ok {
+		return x.LlmResult
+	}
+	return nil
+}
+
+func (x *VoiceResponse) GetTtsResult() *TTSResult {
+	if x, ok := x.GetResponseType().(*VoiceResponse_TtsResult); ok {
+		return x.TtsResult
+	}
+	return nil
+}
+
+func (x *VoiceResponse) GetIseResult() *ISEResult {
+	if x, ok := x.GetResponseType().(*VoiceResponse_IseResult); ok {
+		return x.IseResult
+	}
+	return nil
+}
+
+func (x *VoiceResponse) GetStatus() *StatusResult {
+	if x, ok := x.GetResponseType().(*VoiceResponse_Status); ok {
+		return x.Status
+	}
+	return nil
+}
+
+func (x *VoiceResponse) GetError() *ErrorResult {
+	if x, ok := x.GetResponseType().(*VoiceResponse_Error); ok {
+		return x.Error
+	}
+	return nil
+}
+
+func (x *VoiceResponse) GetPronunciationComparison() *PronunciationComparison {
+	if x, ok := x.GetResponseType().(*VoiceResponse_PronunciationComparison); ok {
+		return x.PronunciationComparison
+	}
+	return nil
+}
+
+func (x *VoiceResponse) GetShadowingResult() *ShadowingResult {
+	if x, ok := x.GetResponseType().(*VoiceResponse_ShadowingResult); ok {
+		return x.ShadowingResult
+	}
+	return nil
+}
+
+type isVoiceResponse_ResponseType interface {
+	isVoiceResponse_ResponseType()
+}
+
+type VoiceResponse_AsrResult struct {
+	AsrResult *ASRResult `protobuf:"bytes,2,opt,name=asr_result,json=asrResult,proto3,oneof"`
+}
+
+type VoiceResponse_LlmResult struct {
+	LlmResult *LLMResult `protobuf:"bytes,3,opt,name=llm_result,json=llmResult,proto3,oneof"`
+}
+
+type VoiceResponse_TtsResult struct {
+	TtsResult *TTSResult `protobuf:"bytes,4,opt,name=tts_result,json=ttsResult,proto3,oneof"`
+}
+
+type VoiceResponse_IseResult struct {
+	IseResult *ISEResult `protobuf:"bytes,5,opt,name=ise_result,json=iseResult,proto3,oneof"`
+}
+
+type VoiceResponse_Status struct {
+	Status *StatusResult `protobuf:"bytes,6,opt,name=status,proto3,oneof"`
+}
+
+type VoiceResponse_Error struct {
+	Error *ErrorResult `protobuf:"bytes,7,opt,name=error,proto3,oneof"`
+}
+
+type VoiceResponse_PronunciationComparison struct {
+	PronunciationComparison *PronunciationComparison `protobuf:"bytes,8,opt,name=pronunciation_comparison,json=pronunciationComparison,proto3,oneof"`
+}
+
+type VoiceResponse_ShadowingResult struct {
+	ShadowingResult *ShadowingResult `protobuf:"bytes,9,opt,name=shadowing_result,json=shadowingResult,proto3,oneof"`
+}
+
+func (*VoiceResponse_AsrResult) isVoiceResponse_ResponseType() {}
+
+func (*VoiceResponse_LlmResult) isVoiceResponse_ResponseType() {}
+
+func (*VoiceResponse_TtsResult) isVoiceResponse_ResponseType() {}
+
+func (*VoiceResponse_IseResult) isVoiceResponse_ResponseType() {}
+
+func (*VoiceResponse_Status) isVoiceResponse_ResponseType() {}
+
+func (*VoiceResponse_Error) isVoiceResponse_ResponseType() {}
+
+func (*VoiceResponse_PronunciationComparison) isVoiceResponse_ResponseType() {}
+
+func (*VoiceResponse_ShadowingResult) isVoiceResponse_ResponseType() {}
+
+type ASRResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Text                string  `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	Confidence          float64 `protobuf:"fixed64,2,opt,name=confidence,proto3" json:"confidence,omitempty"`
+	IsFinal             bool    `protobuf:"varint,3,opt,name=is_final,json=isFinal,proto3" json:"is_final,omitempty"`
+	Language            string  `protobuf:"bytes,4,opt,name=language,proto3" json:"language,omitempty"`
+	StartTimeMs         int64   `protobuf:"varint,5,opt,name=start_time_ms,json=startTimeMs,proto3" json:"start_time_ms,omitempty"`
+	EndTimeMs           int64   `protobuf:"varint,6,opt,name=end_time_ms,json=endTimeMs,proto3" json:"end_time_ms,omitempty"`
+	AudioQualityWarning string  `protobuf:"bytes,7,opt,name=audio_quality_warning,json=audioQualityWarning,proto3" json:"audio_quality_warning,omitempty"`
+	SegmentIndex        int32   `protobuf:"varint,8,opt,name=segment_index,json=segmentIndex,proto3" json:"segment_index,omitempty"`
+	SegmentCount        int32   `protobuf:"varint,9,opt,name=segment_count,json=segmentCount,proto3" json:"segment_count,omitempty"`
+}
+
+func (x *ASRResult) Reset() {
+	*x = ASRResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_speech_speech_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ASRResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ASRResult) ProtoMessage() {}
+
+func (x *ASRResult) ProtoReflect() protoreflect.Message {
+	mi := &file_speech_speech_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ASRResult.ProtoReflect.Descriptor instead.
+func (*ASRResult) Descriptor() ([]byte, []int) {
+	return file_speech_speech_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ASRResult) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *ASRResult) GetConfidence() float64 {
+	if x != nil {
+		return x.Confidence
+	}
+	return 0
+}
+
+func (x *ASRResult) GetIsFinal() bool {
+	if x != nil {
+		return x.IsFinal
+	}
+	return false
+}
+
+func (x *ASRResult) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *ASRResult) GetStartTimeMs() int64 {
+	if x != nil {
+		return x.StartTimeMs
+	}
+	return 0
+}
+
+func (x *ASRResult) GetEndTimeMs() int64 {
+	if x != nil {
+		return x.EndTimeMs
+	}
+	return 0
+}
+
+func (x *ASRResult) GetAudioQualityWarning() string {
+	if x != nil {
+		return x.AudioQualityWarning
+	}
+	return ""
+}
+
+func (x *ASRResult) GetSegmentIndex() int32 {
+	if x != nil {
+		return x.SegmentIndex
+	}
+	return 0
+}
+
+func (x *ASRResult) GetSegmentCount() int32 {
+	if x != nil {
+		return x.SegmentCount
+	}
+	return 0
+}
+
+type LLMResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Text         string        `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	Type         LLMResultType `protobuf:"varint,2,opt,name=type,proto3,enum=speech.v1.LLMResultType" json:"type,omitempty"`
+	TokensUsed   int32         `protobuf:"varint,3,opt,name=tokens_used,json=tokensUsed,proto3" json:"tokens_used,omitempty"`
+	SegmentIndex int32         `protobuf:"varint,4,opt,name=segment_index,json=segmentIndex,proto3" json:"segment_index,omitempty"`
+	SegmentCount int32         `protobuf:"varint,5,opt,name=segment_count,json=segmentCount,proto3" json:"segment_count,omitempty"`
+}
+
+func (x *LLMResult) Reset() {
+	*x = LLMResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_speech_speech_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LLMResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LLMResult) ProtoMessage() {}
+
+func (x *LLMResult) ProtoReflect() protoreflect.Message {
+	mi := &file_speech_speech_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LLMResult.ProtoReflect.Descriptor instead.
+func (*LLMResult) Descriptor() ([]byte, []int) {
+	return file_speech_speech_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *LLMResult) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *LLMResult) GetType() LLMResultType {
+	if x != nil {
+		return x.Type
+	}
+	return LLMResultType_LLM_RESULT_TYPE_UNSPECIFIED
+}
+
+func (x *LLMResult) GetTokensUsed() int32 {
+	if x != nil {
+		return x.TokensUsed
+	}
+	return 0
+}
+
+func (x *LLMResult) GetSegmentIndex() int32 {
+	if x != nil {
+		return x.SegmentIndex
+	}
+	return 0
+}
+
+func (x *LLMResult) GetSegmentCount() int32 {
+	if x != nil {
+		return x.SegmentCount
+	}
+	return 0
+}
+
+type TTSResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AudioData      []byte          `protobuf:"bytes,1,opt,name=audio_data,json=audioData,proto3" json:"audio_data,omitempty"`
+	Format         string          `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"`
+	DurationMs     int64           `protobuf:"varint,3,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	TurnId         int32           `protobuf:"varint,4,opt,name=turn_id,json=turnId,proto3" json:"turn_id,omitempty"`
+	ChunkIndex     int32           `protobuf:"varint,5,opt,name=chunk_index,json=chunkIndex,proto3" json:"chunk_index,omitempty"`
+	IsFinal        bool            `protobuf:"varint,6,opt,name=is_final,json=isFinal,proto3" json:"is_final,omitempty"`
+	WordBoundaries []*WordBoundary `protobuf:"bytes,7,rep,name=word_boundaries,json=wordBoundaries,proto3" json:"word_boundaries,omitempty"`
+}
+
+func (x *TTSResult) Reset() {
+	*x = TTSResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_speech_speech_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TTSResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TTSResult) ProtoMessage() {}
+
+func (x *TTSResult) ProtoReflect() protoreflect.Message {
+	mi := &file_speech_speech_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TTSResult.ProtoReflect.Descriptor instead.
+func (*TTSResult) Descriptor() ([]byte, []int) {
+	return file_speech_speech_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *TTSResult) GetAudioData() []byte {
+	if x != nil {
+		return x.AudioData
+	}
+	return nil
+}
+
+func (x *TTSResult) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *TTSResult) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *TTSResult) GetTurnId() int32 {
+	if x != nil {
+		return x.TurnId
+	}
+	return 0
+}
+
+func (x *TTSResult) GetChunkIndex() int32 {
+	if x != nil {
+		return x.ChunkIndex
+	}
+	return 0
+}
+
+func (x *TTSResult) GetIsFinal() bool {
+	if x != nil {
+		return x.IsFinal
+	}
+	return false
+}
+
+func (x *TTSResult) GetWordBoundaries() []*WordBoundary {
+	if x != nil {
+		return x.WordBoundaries
+	}
+	return nil
+}
+
+type WordBoundary struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Word    string `protobuf:"bytes,1,opt,name=word,proto3" json:"word,omitempty"`
+	StartMs int64  `protobuf:"varint,2,opt,name=start_ms,json=startMs,proto3" json:"start_ms,omitempty"`
+	EndMs   int64  `protobuf:"varint,3,opt,name=end_ms,json=endMs,proto3" json:"end_ms,omitempty"`
+}
+
+func (x *WordBoundary) Reset() {
+	*x = WordBoundary{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_speech_speech_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WordBoundary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WordBoundary) ProtoMessage() {}
+
+func (x *WordBoundary) ProtoReflect() protoreflect.Message {
+	mi := &file_speech_speech_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WordBoundary.ProtoReflect.Descriptor instead.
+func (*WordBoundary) Descriptor() ([]byte, []int) {
+	return file_speech_speech_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *WordBoundary) GetWord() string {
+	if x != nil {
+		return x.Word
+	}
+	return ""
+}
+
+func (x *WordBoundary) GetStartMs() int64 {
+	if x != nil {
+		return x.StartMs
+	}
+	return 0
+}
+
+func (x *WordBoundary) GetEndMs() int64 {
+	if x != nil {
+		return x.EndMs
+	}
+	return 0
+}
+
+type WordScore struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Word      string        `protobuf:"bytes,1,opt,name=word,proto3" json:"word,omitempty"`
+	Score     float64       `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	Phones    []*PhoneScore `protobuf:"bytes,3,rep,name=phones,proto3" json:"phones,omitempty"`
+	ErrorType string        `protobuf:"bytes,4,opt,name=error_type,json=errorType,proto3" json:"error_type,omitempty"`
+}
+
+func (x *WordScore) Reset() {
+	*x = WordScore{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_speech_speech_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WordScore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WordScore) ProtoMessage() {}
+
+func (x *WordScore) ProtoReflect() protoreflect.Message {
+	mi := &file_speech_speech_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WordScore.ProtoReflect.Descriptor instead.
+func (*WordScore) Descriptor() ([]byte, []int) {
+	return file_speech_speech_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *WordScore) GetWord() string {
+	if x != nil {
+		return x.Word
+	}
+	return ""
+}
+
+func (x *WordScore) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *WordScore) GetPhones() []*PhoneScore {
+	if x != nil {
+		return x.Phones
+	}
+	return nil
+}
+
+func (x *WordScore) GetErrorType() string {
+	if x != nil {
+		return x.ErrorType
+	}
+	return ""
+}
+
+type PhoneScore struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Phone     string  `protobuf:"bytes,1,opt,name=phone,proto3" json:"phone,omitempty"`
+	Score     float64 `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`
+	ErrorType string  `protobuf:"bytes,3,opt,name=error_type,json=errorType,proto3" json:"error_type,omitempty"`
+}
+
+func (x *PhoneScore) Reset() {
+	*x = PhoneScore{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_speech_speech_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PhoneScore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PhoneScore) ProtoMessage() {}
+
+func (x *PhoneScore) ProtoReflect() protoreflect.Message {
+	mi := &file_speech_speech_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PhoneScore.ProtoReflect.Descriptor instead.
+func (*PhoneScore) Descriptor() ([]byte, []int) {
+	return file_speech_speech_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *PhoneScore) GetPhone() string {
+	if x != nil {
+		return x.Phone
+	}
+	return ""
+}
+
+func (x *PhoneScore) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *PhoneScore) GetErrorType() string {
+	if x != nil {
+		return x.ErrorType
+	}
+	return ""
+}
+
+type ISEResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OverallScore    float64      `protobuf:"fixed64,1,opt,name=overall_score,json=overallScore,proto3" json:"overall_score,omitempty"`
+	AccuracyScore   float64      `protobuf:"fixed64,2,opt,name=accuracy_score,json=accuracyScore,proto3" json:"accuracy_score,omitempty"`
+	FluencyScore    float64      `protobuf:"fixed64,3,opt,name=fluency_score,json=fluencyScore,proto3" json:"fluency_score,omitempty"`
+	IntegrityScore  float64      `protobuf:"fixed64,4,opt,name=integrity_score,json=integrityScore,proto3" json:"integrity_score,omitempty"`
+	ReferenceText   string       `protobuf:"bytes,5,opt,name=reference_text,json=referenceText,proto3" json:"reference_text,omitempty"`
+	Words           []*WordScore `protobuf:"bytes,6,rep,name=words,proto3" json:"words,omitempty"`
+	NormalizedScore float64      `protobuf:"fixed64,7,opt,name=normalized_score,json=normalizedScore,proto3" json:"normalized_score,omitempty"`
+	Mode            string       `protobuf:"bytes,8,opt,name=mode,proto3" json:"mode,omitempty"`
+	SegmentIndex    int32        `protobuf:"varint,9,opt,name=segment_index,json=segmentIndex,proto3" json:"segment_index,omitempty"`
+	SegmentCount    int32        `protobuf:"varint,10,opt,name=segment_count,json=segmentCount,proto3" json:"segment_count,omitempty"`
+}
+
+func (x *ISEResult) Reset() {
+	*x = ISEResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_speech_speech_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ISEResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ISEResult) ProtoMessage() {}
+
+func (x *ISEResult) ProtoReflect() protoreflect.Message {
+	mi := &file_speech_speech_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ISEResult.ProtoReflect.Descriptor instead.
+func (*ISEResult) Descriptor() ([]byte, []int) {
+	return file_speech_speech_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ISEResult) GetOverallScore() float64 {
+	if x != nil {
+		return x.OverallScore
+	}
+	return 0
+}
+
+func (x *ISEResult) GetAccuracyScore() float64 {
+	if x != nil {
+		return x.AccuracyScore
+	}
+	return 0
+}
+
+func (x *ISEResult) GetFluencyScore() float64 {
+	if x != nil {
+		return x.FluencyScore
+	}
+	return 0
+}
+
+func (x *ISEResult) GetIntegrityScore() float64 {
+	if x != nil {
+		return x.IntegrityScore
+	}
+	return 0
+}
+
+func (x *ISEResult) GetReferenceText() string {
+	if x != nil {
+		return x.ReferenceText
+	}
+	return ""
+}
+
+func (x *ISEResult) GetWords() []*WordScore {
+	if x != nil {
+		return x.Words
+	}
+	return nil
+}
+
+func (x *ISEResult) GetNormalizedScore() float64 {
+	if x != nil {
+		return x.NormalizedScore
+	}
+	return 0
+}
+
+func (x *ISEResult) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *ISEResult) GetSegmentIndex() int32 {
+	if x != nil {
+		return x.SegmentIndex
+	}
+	return 0
+}
+
+func (x *ISEResult) GetSegmentCount() int32 {
+	if x != nil {
+		return x.SegmentCount
+	}
+	return 0
+}
+
+type PronunciationComparison struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PitchCorrelation  float64 `protobuf:"fixed64,1,opt,name=pitch_correlation,json=pitchCorrelation,proto3" json:"pitch_correlation,omitempty"`
+	RhythmCorrelation float64 `protobuf:"fixed64,2,opt,name=rhythm_correlation,json=rhythmCorrelation,proto3" json:"rhythm_correlation,omitempty"`
+	StressCorrelation float64 `protobuf:"fixed64,3,opt,name=stress_correlation,json=stressCorrelation,proto3" json:"stress_correlation,omitempty"`
+	ReferenceText     string  `protobuf:"bytes,4,opt,name=reference_text,json=referenceText,proto3" json:"reference_text,omitempty"`
+}
+
+func (x *PronunciationComparison) Reset() {
+	*x = PronunciationComparison{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_speech_speech_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PronunciationComparison) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PronunciationComparison) ProtoMessage() {}
+
+func (x *PronunciationComparison) ProtoReflect() protoreflect.Message {
+	mi := &file_speech_speech_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PronunciationComparison.ProtoReflect.Descriptor instead.
+func (*PronunciationComparison) Descriptor() ([]byte, []int) {
+	return file_speech_speech_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *PronunciationComparison) GetPitchCorrelation() float64 {
+	if x != nil {
+		return x.PitchCorrelation
+	}
+	return 0
+}
+
+func (x *PronunciationComparison) GetRhythmCorrelation() float64 {
+	if x != nil {
+		return x.RhythmCorrelation
+	}
+	return 0
+}
+
+func (x *PronunciationComparison) GetStressCorrelation() float64 {
+	if x != nil {
+		return x.StressCorrelation
+	}
+	return 0
+}
+
+func (x *PronunciationComparison) GetReferenceText() string {
+	if x != nil {
+		return x.ReferenceText
+	}
+	return ""
+}
+
+type WordAlignment struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TargetWord string `protobuf:"bytes,1,opt,name=target_word,json=targetWord,proto3" json:"target_word,omitempty"`
+	HeardWord  string `protobuf:"bytes,2,opt,name=heard_word,json=heardWord,proto3" json:"heard_word,omitempty"`
+	Op         string `protobuf:"bytes,3,opt,name=op,proto3" json:"op,omitempty"`
+}
+
+func (x *WordAlignment) Reset() {
+	*x = WordAlignment{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_speech_speech_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WordAlignment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WordAlignment) ProtoMessage() {}
+
+func (x *WordAlignment) ProtoReflect() protoreflect.Message {
+	mi := &file_speech_speech_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WordAlignment.ProtoReflect.Descriptor instead.
+func (*WordAlignment) Descriptor() ([]byte, []int) {
+	return file_speech_speech_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *WordAlignment) GetTargetWord() string {
+	if x != nil {
+		return x.TargetWord
+	}
+	return ""
+}
+
+func (x *WordAlignment) GetHeardWord() string {
+	if x != nil {
+		return x.HeardWord
+	}
+	return ""
+}
+
+func (x *WordAlignment) GetOp() string {
+	if x != nil {
+		return x.Op
+	}
+	return ""
+}
+
+type ShadowingResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TargetText string           `protobuf:"bytes,1,opt,name=target_text,json=targetText,proto3" json:"target_text,omitempty"`
+	Transcript string           `protobuf:"bytes,2,opt,name=transcript,proto3" json:"transcript,omitempty"`
+	Words      []*WordAlignment `protobuf:"bytes,3,rep,name=words,proto3" json:"words,omitempty"`
+}
+
+func (x *ShadowingResult) Reset() {
+	*x = ShadowingResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_speech_speech_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ShadowingResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShadowingResult) ProtoMessage() {}
+
+func (x *ShadowingResult) ProtoReflect() protoreflect.Message {
+	mi := &file_speech_speech_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShadowingResult.ProtoReflect.Descriptor instead.
+func (*ShadowingResult) Descriptor() ([]byte, []int) {
+	return file_speech_speech_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ShadowingResult) GetTargetText() string {
+	if x != nil {
+		return x.TargetText
+	}
+	return ""
+}
+
+func (x *ShadowingResult) GetTranscript() string {
+	if x != nil {
+		return x.Transcript
+	}
+	return ""
+}
+
+func (x *ShadowingResult) GetWords() []*WordAlignment {
+	if x != nil {
+		return x.Words
+	}
+	return nil
+}
+
+type StatusResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status  string        `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Message string        `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Timings *StageTimings `protobuf:"bytes,3,opt,name=timings,proto3" json:"timings,omitempty"`
+}
+
+func (x *StatusResult) Reset() {
+	*x = StatusResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_speech_speech_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StatusResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StatusResult) ProtoMessage() {}
+
+func (x *StatusResult) ProtoReflect() protoreflect.Message {
+	mi := &file_speech_speech_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StatusResult.ProtoReflect.Descriptor instead.
+func (*StatusResult) Descriptor() ([]byte, []int) {
+	return file_speech_speech_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *StatusResult) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *StatusResult) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *StatusResult) GetTimings() *StageTimings {
+	if x != nil {
+		return x.Timings
+	}
+	return nil
+}
+
+type StageTimings struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AsrMs          int64 `protobuf:"varint,1,opt,name=asr_ms,json=asrMs,proto3" json:"asr_ms,omitempty"`
+	LlmMs          int64 `protobuf:"varint,2,opt,name=llm_ms,json=llmMs,proto3" json:"llm_ms,omitempty"`
+	TtsFirstByteMs int64 `protobuf:"varint,3,opt,name=tts_first_byte_ms,json=ttsFirstByteMs,proto3" json:"tts_first_byte_ms,omitempty"`
+	TtsMs          int64 `protobuf:"varint,4,opt,name=tts_ms,json=ttsMs,proto3" json:"tts_ms,omitempty"`
+	IseMs          int64 `protobuf:"varint,5,opt,name=ise_ms,json=iseMs,proto3" json:"ise_ms,omitempty"`
+	TotalMs        int64 `protobuf:"varint,6,opt,name=total_ms,json=totalMs,proto3" json:"total_ms,omitempty"`
+}
+
+func (x *StageTimings) Reset() {
+	*x = StageTimings{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_speech_speech_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StageTimings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StageTimings) ProtoMessage() {}
+
+func (x *StageTimings) ProtoReflect() protoreflect.Message {
+	mi := &file_speech_speech_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StageTimings.ProtoReflect.Descriptor instead.
+func (*StageTimings) Descriptor() ([]byte, []int) {
+	return file_speech_speech_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *StageTimings) GetAsrMs() int64 {
+	if x != nil {
+		return x.AsrMs
+	}
+	return 0
+}
+
+func (x *StageTimings) GetLlmMs() int64 {
+	if x != nil {
+		return x.LlmMs
+	}
+	return 0
+}
+
+func (x *StageTimings) GetTtsFirstByteMs() int64 {
+	if x != nil {
+		return x.TtsFirstByteMs
+	}
+	return 0
+}
+
+func (x *StageTimings) GetTtsMs() int64 {
+	if x != nil {
+		return x.TtsMs
+	}
+	return 0
+}
+
+func (x *StageTimings) GetIseMs() int64 {
+	if x != nil {
+		return x.IseMs
+	}
+	return 0
+}
+
+func (x *StageTimings) GetTotalMs() int64 {
+	if x != nil {
+		return x.TotalMs
+	}
+	return 0
+}
+
+type ErrorResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Code    ErrorCode `protobuf:"varint,1,opt,name=code,proto3,enum=speech.v1.ErrorCode" json:"code,omitempty"`
+	Message string    `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *ErrorResult) Reset() {
+	*x = ErrorResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_speech_speech_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ErrorResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ErrorResult) ProtoMessage() {}
+
+func (x *ErrorResult) ProtoReflect() protoreflect.Message {
+	mi := &file_speech_speech_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ErrorResult.ProtoReflect.Descriptor instead.
+func (*ErrorResult) Descriptor() ([]byte, []int) {
+	return file_speech_speech_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ErrorResult) GetCode() ErrorCode {
+	if x != nil {
+		return x.Code
+	}
+	return ErrorCode_ERROR_CODE_UNSPECIFIED
+}
+
+func (x *ErrorResult) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type TranscribeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AudioData  []byte `protobuf:"bytes,1,opt,name=audio_data,json=audioData,proto3" json:"audio_data,omitempty"`
+	Format     string `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"`
+	SampleRate int32  `protobuf:"varint,3,opt,name=sample_rate,json=sampleRate,proto3" json:"sample_rate,omitempty"`
+	Language   string `protobuf:"bytes,4,opt,name=language,proto3" json:"language,omitempty"`
+}
+
+func (x *TranscribeRequest) Reset() {
+	*x = TranscribeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_speech_speech_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TranscribeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TranscribeRequest) ProtoMessage() {}
+
+func (x *TranscribeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_speech_speech_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TranscribeRequest.ProtoReflect.Descriptor instead.
+func (*TranscribeRequest) Descriptor() ([]byte, []int) {
+	return file_speech_speech_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *TranscribeRequest) GetAudioData() []byte {
+	if x != nil {
+		return x.AudioData
+	}
+	return nil
+}
+
+func (x *TranscribeRequest) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *TranscribeRequest) GetSampleRate() int32 {
+	if x != nil {
+		return x.SampleRate
+	}
+	return 0
+}
+
+func (x *TranscribeRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+type TranscribeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Text       string  `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	Confidence float64 `protobuf:"fixed64,2,opt,name=confidence,proto3" json:"confidence,omitempty"`
+	Language   string  `protobuf:"bytes,3,opt,name=language,proto3" json:"language,omitempty"`
+}
+
+func (x *TranscribeResponse) Reset() {
+	*x = TranscribeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_speech_speech_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TranscribeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TranscribeResponse) ProtoMessage() {}
+
+func (x *TranscribeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_speech_speech_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TranscribeResponse.ProtoReflect.Descriptor instead.
+func (*TranscribeResponse) Descriptor() ([]byte, []int) {
+	return file_speech_speech_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *TranscribeResponse) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *TranscribeResponse) GetConfidence() float64 {
+	if x != nil {
+		return x.Confidence
+	}
+	return 0
+}
+
+func (x *TranscribeResponse) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+type SynthesizeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Text     string  `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	Voice    string  `protobuf:"bytes,2,opt,name=voice,proto3" json:"voice,omitempty"`
+	Language string  `protobuf:"bytes,3,opt,name=language,proto3" json:"language,omitempty"`
+	Speed    float64 `protobuf:"fixed64,4,opt,name=speed,proto3" json:"speed,omitempty"`
+}
+
+func (x *SynthesizeRequest) Reset() {
+	*x = SynthesizeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_speech_speech_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SynthesizeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SynthesizeRequest) ProtoMessage() {}
+
+func (x *SynthesizeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_speech_speech_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SynthesizeRequest.ProtoReflect.Descriptor instead.
+func (*SynthesizeRequest) Descriptor() ([]byte, []int) {
+	return file_speech_speech_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SynthesizeRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *SynthesizeRequest) GetVoice() string {
+	if x != nil {
+		return x.Voice
+	}
+	return ""
+}
+
+func (x *SynthesizeRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *SynthesizeRequest) GetSpeed() float64 {
+	if x != nil {
+		return x.Speed
+	}
+	return 0
+}
+
+type SynthesizeResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AudioData  []byte `protobuf:"bytes,1,opt,name=audio_data,json=audioData,proto3" json:"audio_data,omitempty"`
+	Format     string `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"`
+	DurationMs int64  `protobuf:"varint,3,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+}
+
+func (x *SynthesizeResponse) Reset() {
+	*x = SynthesizeResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_speech_speech_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SynthesizeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SynthesizeResponse) ProtoMessage() {}
+
+func (x *SynthesizeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_speech_speech_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SynthesizeResponse.ProtoReflect.Descriptor instead.
+func (*SynthesizeResponse) Descriptor() ([]byte, []int) {
+	return file_speech_speech_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SynthesizeResponse) GetAudioData() []byte {
+	if x != nil {
+		return x.AudioData
+	}
+	return nil
+}
+
+func (x *SynthesizeResponse) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *SynthesizeResponse) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+type EvaluateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AudioData     []byte `protobuf:"bytes,1,opt,name=audio_data,json=audioData,proto3" json:"audio_data,omitempty"`
+	ReferenceText string `protobuf:"bytes,2,opt,name=reference_text,json=referenceText,proto3" json:"reference_text,omitempty"`
+	Language      string `protobuf:"bytes,3,opt,name=language,proto3" json:"language,omitempty"`
+}
+
+func (x *EvaluateRequest) Reset() {
+	*x = EvaluateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_speech_speech_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EvaluateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvaluateRequest) ProtoMessage() {}
+
+func (x *EvaluateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_speech_speech_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvaluateRequest.ProtoReflect.Descriptor instead.
+func (*EvaluateRequest) Descriptor() ([]byte, []int) {
+	return file_speech_speech_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *EvaluateRequest) GetAudioData() []byte {
+	if x != nil {
+		return x.AudioData
+	}
+	return nil
+}
+
+func (x *EvaluateRequest) GetReferenceText() string {
+	if x != nil {
+		return x.ReferenceText
+	}
+	return ""
+}
+
+func (x *EvaluateRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+type EvaluateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Result *ISEResult `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+}
+
+func (x *EvaluateResponse) Reset() {
+	*x = EvaluateResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_speech_speech_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *EvaluateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EvaluateResponse) ProtoMessage() {}
+
+func (x *EvaluateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_speech_speech_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EvaluateResponse.ProtoReflect.Descriptor instead.
+func (*EvaluateResponse) Descriptor() ([]byte, []int) {
+	return file_speech_speech_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *EvaluateResponse) GetResult() *ISEResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type ChatTurn struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Role    string `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`
+	Content string `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+}
+
+func (x *ChatTurn) Reset() {
+	*x = ChatTurn{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_speech_speech_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChatTurn) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatTurn) ProtoMessage() {}
+
+func (x *ChatTurn) ProtoReflect() protoreflect.Message {
+	mi := &file_speech_speech_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatTurn.ProtoReflect.Descriptor instead.
+func (*ChatTurn) Descriptor() ([]byte, []int) {
+	return file_speech_speech_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ChatTurn) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *ChatTurn) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+type GenerateReplyRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Text      string      `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	CefrLevel string      `protobuf:"bytes,2,opt,name=cefr_level,json=cefrLevel,proto3" json:"cefr_level,omitempty"`
+	History   []*ChatTurn `protobuf:"bytes,3,rep,name=history,proto3" json:"history,omitempty"`
+}
+
+func (x *GenerateReplyRequest) Reset() {
+	*x = GenerateReplyRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_speech_speech_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GenerateReplyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateReplyRequest) ProtoMessage() {}
+
+func (x *GenerateReplyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_speech_speech_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateReplyRequest.ProtoReflect.Descriptor instead.
+func (*GenerateReplyRequest) Descriptor() ([]byte, []int) {
+	return file_speech_speech_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GenerateReplyRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *GenerateReplyRequest) GetCefrLevel() string {
+	if x != nil {
+		return x.CefrLevel
+	}
+	return ""
+}
+
+func (x *GenerateReplyRequest) GetHistory() []*ChatTurn {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+type GenerateReplyResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Text       string `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	TokensUsed int32  `protobuf:"varint,2,opt,name=tokens_used,json=tokensUsed,proto3" json:"tokens_used,omitempty"`
+}
+
+func (x *GenerateReplyResponse) Reset() {
+	*x = GenerateReplyResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_speech_speech_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GenerateReplyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateReplyResponse) ProtoMessage() {}
+
+func (x *GenerateReplyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_speech_speech_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateReplyResponse.ProtoReflect.Descriptor instead.
+func (*GenerateReplyResponse) Descriptor() ([]byte, []int) {
+	return file_speech_speech_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GenerateReplyResponse) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *GenerateReplyResponse) GetTokensUsed() int32 {
+	if x != nil {
+		return x.TokensUsed
+	}
+	return 0
+}
+
+var File_speech_speech_proto protoreflect.FileDescriptor
+
+var file_speech_speech_proto_rawDesc = []byte{
+	0x0a, 0x13, 0x73, 0x70, 0x65, 0x65, 0x63, 0x68, 0x2f, 0x73, 0x70, 0x65, 0x65, 0x63, 0x68, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x09, 0x73, 0x70, 0x65, 0x65, 0x63, 0x68, 0x2e, 0x76, 0x31,
+	0x22, 0xfc, 0x01, 0x0a, 0x0c, 0x56, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x49, 0x64,
+	0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x36, 0x0a, 0x0a, 0x61, 0x75, 0x64,
+	0x69, 0x6f, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e,
+	0x73, 0x70, 0x65, 0x65, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x75, 0x64, 0x69, 0x6f, 0x43,
+	0x68, 0x75, 0x6e, 0x6b, 0x48, 0x00, 0x52, 0x09, 0x61, 0x75, 0x64, 0x69, 0x6f, 0x44, 0x61, 0x74,
+	0x61, 0x12, 0x35, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x73, 0x70, 0x65, 0x65, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x48, 0x00, 0x52,
+	0x07, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x12, 0x35, 0x0a, 0x0a, 0x74, 0x65, 0x78, 0x74,
+	0x5f, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x73,
+	0x70, 0x65, 0x65, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x65, 0x78, 0x74, 0x49, 0x6e, 0x70,
+	0x75, 0x74, 0x48, 0x00, 0x52, 0x09, 0x74, 0x65, 0x78, 0x74, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x42,
+	0x0e, 0x0a, 0x0c, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x22,
+	0x1f, 0x0a, 0x09, 0x54, 0x65, 0x78, 0x74, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74,
+	0x22, 0x90, 0x01, 0x0a, 0x0a, 0x41, 0x75, 0x64, 0x69, 0x6f, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12,
+	0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64,
+	0x61, 0x74, 0x61, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x73,
+	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x5f, 0x72, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0a, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x52, 0x61, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08,
+	0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x73, 0x5f, 0x66,
+	0x69, 0x6e, 0x61, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x69, 0x73, 0x46, 0x69,
+	0x6e, 0x61, 0x6c, 0x22, 0xcc, 0x01, 0x0a, 0x0e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x30, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x73, 0x70, 0x65, 0x65, 0x63, 0x68, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x49, 0x0a, 0x0a, 0x70, 0x61, 0x72, 0x61,
+	0x6d, 0x65, 0x74, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x73,
+	0x70, 0x65, 0x65, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65,
+	0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74,
+	0x65, 0x72, 0x73, 0x1a, 0x3d, 0x0a, 0x0f, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x65, 0x74, 0x65, 0x72,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x22, 0xa8, 0x04, 0x0a, 0x0d, 0x56, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x49, 0x64, 0x12, 0x35, 0x0a, 0x0a, 0x61, 0x73, 0x72, 0x5f, 0x72, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x73, 0x70, 0x65, 0x65, 0x63, 0x68,
+	0x2e, 0x76, 0x31, 0x2e, 0x41, 0x53, 0x52, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x48, 0x00, 0x52,
+	0x09, 0x61, 0x73, 0x72, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x35, 0x0a, 0x0a, 0x6c, 0x6c,
+	0x6d, 0x5f, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14,
+	0x2e, 0x73, 0x70, 0x65, 0x65, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x4c, 0x4d, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x48, 0x00, 0x52, 0x09, 0x6c, 0x6c, 0x6d, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x12, 0x35, 0x0a, 0x0a, 0x74, 0x74, 0x73, 0x5f, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x73, 0x70, 0x65, 0x65, 0x63, 0x68, 0x2e, 0x76,
+	0x31, 0x2e, 0x54, 0x54, 0x53, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x48, 0x00, 0x52, 0x09, 0x74,
+	0x74, 0x73, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x35, 0x0a, 0x0a, 0x69, 0x73, 0x65, 0x5f,
+	0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x73,
+	0x70, 0x65, 0x65, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x53, 0x45, 0x52, 0x65, 0x73, 0x75,
+	0x6c, 0x74, 0x48, 0x00, 0x52, 0x09, 0x69, 0x73, 0x65, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12,
+	0x31, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x17, 0x2e, 0x73, 0x70, 0x65, 0x65, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x48, 0x00, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x2e, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x16, 0x2e, 0x73, 0x70, 0x65, 0x65, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x72,
+	0x72, 0x6f, 0x72, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x48, 0x00, 0x52, 0x05, 0x65, 0x72, 0x72,
+	0x6f, 0x72, 0x12, 0x5f, 0x0a, 0x18, 0x70, 0x72, 0x6f, 0x6e, 0x75, 0x6e, 0x63, 0x69, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x69, 0x73, 0x6f, 0x6e, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x73, 0x70, 0x65, 0x65, 0x63, 0x68, 0x2e, 0x76, 0x31,
+	0x2e, 0x50, 0x72, 0x6f, 0x6e, 0x75, 0x6e, 0x63, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f,
+	0x6d, 0x70, 0x61, 0x72, 0x69, 0x73, 0x6f, 0x6e, 0x48, 0x00, 0x52, 0x17, 0x70, 0x72, 0x6f, 0x6e,
+	0x75, 0x6e, 0x63, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x72, 0x69,
+	0x73, 0x6f, 0x6e, 0x12, 0x47, 0x0a, 0x10, 0x73, 0x68, 0x61, 0x64, 0x6f, 0x77, 0x69, 0x6e, 0x67,
+	0x5f, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e,
+	0x73, 0x70, 0x65, 0x65, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x68, 0x61, 0x64, 0x6f, 0x77,
+	0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x48, 0x00, 0x52, 0x0f, 0x73, 0x68, 0x61,
+	0x64, 0x6f, 0x77, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x42, 0x0f, 0x0a, 0x0d,
+	0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x22, 0xb8, 0x02,
+	0x0a, 0x09, 0x41, 0x53, 0x52, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74,
+	0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12,
+	0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x12,
+	0x19, 0x0a, 0x08, 0x69, 0x73, 0x5f, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x07, 0x69, 0x73, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61,
+	0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61,
+	0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x12, 0x22, 0x0a, 0x0d, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f,
+	0x74, 0x69, 0x6d, 0x65, 0x5f, 0x6d, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x4d, 0x73, 0x12, 0x1e, 0x0a, 0x0b, 0x65, 0x6e,
+	0x64, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x5f, 0x6d, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x09, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x4d, 0x73, 0x12, 0x32, 0x0a, 0x15, 0x61, 0x75,
+	0x64, 0x69, 0x6f, 0x5f, 0x71, 0x75, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x5f, 0x77, 0x61, 0x72, 0x6e,
+	0x69, 0x6e, 0x67, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x13, 0x61, 0x75, 0x64, 0x69, 0x6f,
+	0x51, 0x75, 0x61, 0x6c, 0x69, 0x74, 0x79, 0x57, 0x61, 0x72, 0x6e, 0x69, 0x6e, 0x67, 0x12, 0x23,
+	0x0a, 0x0d, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x6e,
+	0x64, 0x65, 0x78, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x63,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x73, 0x65, 0x67, 0x6d,
+	0x65, 0x6e, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0xb8, 0x01, 0x0a, 0x09, 0x4c, 0x4c, 0x4d,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12, 0x2c, 0x0a, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x73, 0x70, 0x65, 0x65, 0x63,
+	0x68, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x4c, 0x4d, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x54, 0x79,
+	0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x6b, 0x65,
+	0x6e, 0x73, 0x5f, 0x75, 0x73, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x74,
+	0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x55, 0x73, 0x65, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65, 0x67,
+	0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x0c, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x23,
+	0x0a, 0x0d, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x22, 0xfa, 0x01, 0x0a, 0x09, 0x54, 0x54, 0x53, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x75, 0x64, 0x69, 0x6f, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x61, 0x75, 0x64, 0x69, 0x6f, 0x44, 0x61, 0x74, 0x61,
+	0x12, 0x16, 0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x64,
+	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x75, 0x72,
+	0x6e, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x74, 0x75, 0x72, 0x6e,
+	0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x5f, 0x69, 0x6e, 0x64, 0x65,
+	0x78, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x49, 0x6e,
+	0x64, 0x65, 0x78, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x73, 0x5f, 0x66, 0x69, 0x6e, 0x61, 0x6c, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x69, 0x73, 0x46, 0x69, 0x6e, 0x61, 0x6c, 0x12, 0x40,
+	0x0a, 0x0f, 0x77, 0x6f, 0x72, 0x64, 0x5f, 0x62, 0x6f, 0x75, 0x6e, 0x64, 0x61, 0x72, 0x69, 0x65,
+	0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x73, 0x70, 0x65, 0x65, 0x63, 0x68,
+	0x2e, 0x76, 0x31, 0x2e, 0x57, 0x6f, 0x72, 0x64, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x61, 0x72, 0x79,
+	0x52, 0x0e, 0x77, 0x6f, 0x72, 0x64, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x61, 0x72, 0x69, 0x65, 0x73,
+	0x22, 0x54, 0x0a, 0x0c, 0x57, 0x6f, 0x72, 0x64, 0x42, 0x6f, 0x75, 0x6e, 0x64, 0x61, 0x72, 0x79,
+	0x12, 0x12, 0x0a, 0x04, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x77, 0x6f, 0x72, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x6d, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x4d, 0x73, 0x12,
+	0x15, 0x0a, 0x06, 0x65, 0x6e, 0x64, 0x5f, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x05, 0x65, 0x6e, 0x64, 0x4d, 0x73, 0x22, 0x83, 0x01, 0x0a, 0x09, 0x57, 0x6f, 0x72, 0x64, 0x53,
+	0x63, 0x6f, 0x72, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x77, 0x6f, 0x72, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x2d,
+	0x0a, 0x06, 0x70, 0x68, 0x6f, 0x6e, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15,
+	0x2e, 0x73, 0x70, 0x65, 0x65, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x68, 0x6f, 0x6e, 0x65,
+	0x53, 0x63, 0x6f, 0x72, 0x65, 0x52, 0x06, 0x70, 0x68, 0x6f, 0x6e, 0x65, 0x73, 0x12, 0x1d, 0x0a,
+	0x0a, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x54, 0x79, 0x70, 0x65, 0x22, 0x57, 0x0a, 0x0a,
+	0x50, 0x68, 0x6f, 0x6e, 0x65, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x68,
+	0x6f, 0x6e, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x68, 0x6f, 0x6e, 0x65,
+	0x12, 0x14, 0x0a, 0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x05, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x5f,
+	0x74, 0x79, 0x70, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x54, 0x79, 0x70, 0x65, 0x22, 0x81, 0x03, 0x0a, 0x09, 0x49, 0x53, 0x45, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x6f, 0x76, 0x65, 0x72, 0x61, 0x6c, 0x6c, 0x5f, 0x73,
+	0x63, 0x6f, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x6f, 0x76, 0x65, 0x72,
+	0x61, 0x6c, 0x6c, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x61, 0x63, 0x63, 0x75,
+	0x72, 0x61, 0x63, 0x79, 0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x0d, 0x61, 0x63, 0x63, 0x75, 0x72, 0x61, 0x63, 0x79, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12,
+	0x23, 0x0a, 0x0d, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0c, 0x66, 0x6c, 0x75, 0x65, 0x6e, 0x63, 0x79, 0x53,
+	0x63, 0x6f, 0x72, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x69, 0x6e, 0x74, 0x65, 0x67, 0x72, 0x69, 0x74,
+	0x79, 0x5f, 0x73, 0x63, 0x6f, 0x72, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0e, 0x69,
+	0x6e, 0x74, 0x65, 0x67, 0x72, 0x69, 0x74, 0x79, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x25, 0x0a,
+	0x0e, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x74, 0x65, 0x78, 0x74, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65,
+	0x54, 0x65, 0x78, 0x74, 0x12, 0x2a, 0x0a, 0x05, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x06, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x73, 0x70, 0x65, 0x65, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e,
+	0x57, 0x6f, 0x72, 0x64, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x52, 0x05, 0x77, 0x6f, 0x72, 0x64, 0x73,
+	0x12, 0x29, 0x0a, 0x10, 0x6e, 0x6f, 0x72, 0x6d, 0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x5f, 0x73,
+	0x63, 0x6f, 0x72, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0f, 0x6e, 0x6f, 0x72, 0x6d,
+	0x61, 0x6c, 0x69, 0x7a, 0x65, 0x64, 0x53, 0x63, 0x6f, 0x72, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6d,
+	0x6f, 0x64, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x12,
+	0x23, 0x0a, 0x0d, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x49,
+	0x6e, 0x64, 0x65, 0x78, 0x12, 0x23, 0x0a, 0x0d, 0x73, 0x65, 0x67, 0x6d, 0x65, 0x6e, 0x74, 0x5f,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x73, 0x65, 0x67,
+	0x6d, 0x65, 0x6e, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0xcb, 0x01, 0x0a, 0x17, 0x50, 0x72,
+	0x6f, 0x6e, 0x75, 0x6e, 0x63, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x6d, 0x70, 0x61,
+	0x72, 0x69, 0x73, 0x6f, 0x6e, 0x12, 0x2b, 0x0a, 0x11, 0x70, 0x69, 0x74, 0x63, 0x68, 0x5f, 0x63,
+	0x6f, 0x72, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x10, 0x70, 0x69, 0x74, 0x63, 0x68, 0x43, 0x6f, 0x72, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x2d, 0x0a, 0x12, 0x72, 0x68, 0x79, 0x74, 0x68, 0x6d, 0x5f, 0x63, 0x6f, 0x72,
+	0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x11,
+	0x72, 0x68, 0x79, 0x74, 0x68, 0x6d, 0x43, 0x6f, 0x72, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x2d, 0x0a, 0x12, 0x73, 0x74, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x63, 0x6f, 0x72, 0x72,
+	0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x11, 0x73,
+	0x74, 0x72, 0x65, 0x73, 0x73, 0x43, 0x6f, 0x72, 0x72, 0x65, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x74, 0x65,
+	0x78, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65,
+	0x6e, 0x63, 0x65, 0x54, 0x65, 0x78, 0x74, 0x22, 0x5f, 0x0a, 0x0d, 0x57, 0x6f, 0x72, 0x64, 0x41,
+	0x6c, 0x69, 0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x61, 0x72, 0x67,
+	0x65, 0x74, 0x5f, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x74,
+	0x61, 0x72, 0x67, 0x65, 0x74, 0x57, 0x6f, 0x72, 0x64, 0x12, 0x1d, 0x0a, 0x0a, 0x68, 0x65, 0x61,
+	0x72, 0x64, 0x5f, 0x77, 0x6f, 0x72, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x68,
+	0x65, 0x61, 0x72, 0x64, 0x57, 0x6f, 0x72, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x70, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x6f, 0x70, 0x22, 0x82, 0x01, 0x0a, 0x0f, 0x53, 0x68, 0x61,
+	0x64, 0x6f, 0x77, 0x69, 0x6e, 0x67, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x1f, 0x0a, 0x0b,
+	0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x54, 0x65, 0x78, 0x74, 0x12, 0x1e, 0x0a,
+	0x0a, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x12, 0x2e, 0x0a,
+	0x05, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x73,
+	0x70, 0x65, 0x65, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x6f, 0x72, 0x64, 0x41, 0x6c, 0x69,
+	0x67, 0x6e, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x05, 0x77, 0x6f, 0x72, 0x64, 0x73, 0x22, 0x73, 0x0a,
+	0x0c, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12,
+	0x31, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x69, 0x6e, 0x67, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x17, 0x2e, 0x73, 0x70, 0x65, 0x65, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x61,
+	0x67, 0x65, 0x54, 0x69, 0x6d, 0x69, 0x6e, 0x67, 0x73, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x69, 0x6e,
+	0x67, 0x73, 0x22, 0xb0, 0x01, 0x0a, 0x0c, 0x53, 0x74, 0x61, 0x67, 0x65, 0x54, 0x69, 0x6d, 0x69,
+	0x6e, 0x67, 0x73, 0x12, 0x15, 0x0a, 0x06, 0x61, 0x73, 0x72, 0x5f, 0x6d, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x05, 0x61, 0x73, 0x72, 0x4d, 0x73, 0x12, 0x15, 0x0a, 0x06, 0x6c, 0x6c,
+	0x6d, 0x5f, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6c, 0x6c, 0x6d, 0x4d,
+	0x73, 0x12, 0x29, 0x0a, 0x11, 0x74, 0x74, 0x73, 0x5f, 0x66, 0x69, 0x72, 0x73, 0x74, 0x5f, 0x62,
+	0x79, 0x74, 0x65, 0x5f, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x74, 0x74,
+	0x73, 0x46, 0x69, 0x72, 0x73, 0x74, 0x42, 0x79, 0x74, 0x65, 0x4d, 0x73, 0x12, 0x15, 0x0a, 0x06,
+	0x74, 0x74, 0x73, 0x5f, 0x6d, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x74, 0x74,
+	0x73, 0x4d, 0x73, 0x12, 0x15, 0x0a, 0x06, 0x69, 0x73, 0x65, 0x5f, 0x6d, 0x73, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x05, 0x69, 0x73, 0x65, 0x4d, 0x73, 0x12, 0x19, 0x0a, 0x08, 0x74, 0x6f,
+	0x74, 0x61, 0x6c, 0x5f, 0x6d, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x74, 0x6f,
+	0x74, 0x61, 0x6c, 0x4d, 0x73, 0x22, 0x51, 0x0a, 0x0b, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65,
+	0x73, 0x75, 0x6c, 0x74, 0x12, 0x28, 0x0a, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0e, 0x32, 0x14, 0x2e, 0x73, 0x70, 0x65, 0x65, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x45,
+	0x72, 0x72, 0x6f, 0x72, 0x43, 0x6f, 0x64, 0x65, 0x52, 0x04, 0x63, 0x6f, 0x64, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x22, 0x87, 0x01, 0x0a, 0x11, 0x54, 0x72, 0x61,
+	0x6e, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d,
+	0x0a, 0x0a, 0x61, 0x75, 0x64, 0x69, 0x6f, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x09, 0x61, 0x75, 0x64, 0x69, 0x6f, 0x44, 0x61, 0x74, 0x61, 0x12, 0x16, 0x0a,
+	0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x66,
+	0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x65, 0x5f,
+	0x72, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x73, 0x61, 0x6d, 0x70,
+	0x6c, 0x65, 0x52, 0x61, 0x74, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61,
+	0x67, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61,
+	0x67, 0x65, 0x22, 0x64, 0x0a, 0x12, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12, 0x1e, 0x0a, 0x0a,
+	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x0a, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x64, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x22, 0x6f, 0x0a, 0x11, 0x53, 0x79, 0x6e, 0x74,
+	0x68, 0x65, 0x73, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78,
+	0x74, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x6f, 0x69, 0x63, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75,
+	0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75,
+	0x61, 0x67, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x05, 0x73, 0x70, 0x65, 0x65, 0x64, 0x22, 0x6c, 0x0a, 0x12, 0x53, 0x79, 0x6e,
+	0x74, 0x68, 0x65, 0x73, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x1d, 0x0a, 0x0a, 0x61, 0x75, 0x64, 0x69, 0x6f, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x09, 0x61, 0x75, 0x64, 0x69, 0x6f, 0x44, 0x61, 0x74, 0x61, 0x12, 0x16,
+	0x0a, 0x06, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x64, 0x75, 0x72,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x73, 0x22, 0x73, 0x0a, 0x0f, 0x45, 0x76, 0x61, 0x6c, 0x75,
+	0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x75,
+	0x64, 0x69, 0x6f, 0x5f, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09,
+	0x61, 0x75, 0x64, 0x69, 0x6f, 0x44, 0x61, 0x74, 0x61, 0x12, 0x25, 0x0a, 0x0e, 0x72, 0x65, 0x66,
+	0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x5f, 0x74, 0x65, 0x78, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0d, 0x72, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x54, 0x65, 0x78, 0x74,
+	0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x6c, 0x61, 0x6e, 0x67, 0x75, 0x61, 0x67, 0x65, 0x22, 0x40, 0x0a, 0x10,
+	0x45, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x2c, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x14, 0x2e, 0x73, 0x70, 0x65, 0x65, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x49, 0x53, 0x45,
+	0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x22, 0x38,
+	0x0a, 0x08, 0x43, 0x68, 0x61, 0x74, 0x54, 0x75, 0x72, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x6f,
+	0x6c, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x6f, 0x6c, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x63, 0x6f, 0x6e, 0x74, 0x65, 0x6e, 0x74, 0x22, 0x78, 0x0a, 0x14, 0x47, 0x65, 0x6e, 0x65,
+	0x72, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x74, 0x65, 0x78, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x65, 0x66, 0x72, 0x5f, 0x6c, 0x65, 0x76,
+	0x65, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x65, 0x66, 0x72, 0x4c, 0x65,
+	0x76, 0x65, 0x6c, 0x12, 0x2d, 0x0a, 0x07, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x73, 0x70, 0x65, 0x65, 0x63, 0x68, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x68, 0x61, 0x74, 0x54, 0x75, 0x72, 0x6e, 0x52, 0x07, 0x68, 0x69, 0x73, 0x74, 0x6f,
+	0x72, 0x79, 0x22, 0x4c, 0x0a, 0x15, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x52, 0x65,
+	0x70, 0x6c, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74,
+	0x65, 0x78, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x12,
+	0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x5f, 0x75, 0x73, 0x65, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x55, 0x73, 0x65, 0x64,
+	0x2a, 0x92, 0x03, 0x0a, 0x0d, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x41, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x1a, 0x43, 0x4f, 0x4e, 0x54, 0x52, 0x4f, 0x4c, 0x5f, 0x41, 0x43,
+	0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44,
+	0x10, 0x00, 0x12, 0x22, 0x0a, 0x1e, 0x43, 0x4f, 0x4e, 0x54, 0x52, 0x4f, 0x4c, 0x5f, 0x41, 0x43,
+	0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x54, 0x41, 0x52, 0x54, 0x5f, 0x52, 0x45, 0x43, 0x4f, 0x52,
+	0x44, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x21, 0x0a, 0x1d, 0x43, 0x4f, 0x4e, 0x54, 0x52, 0x4f,
+	0x4c, 0x5f, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x54, 0x4f, 0x50, 0x5f, 0x52, 0x45,
+	0x43, 0x4f, 0x52, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x02, 0x12, 0x20, 0x0a, 0x1c, 0x43, 0x4f, 0x4e,
+	0x54, 0x52, 0x4f, 0x4c, 0x5f, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x50, 0x41, 0x55, 0x53,
+	0x45, 0x5f, 0x53, 0x45, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x10, 0x03, 0x12, 0x21, 0x0a, 0x1d, 0x43,
+	0x4f, 0x4e, 0x54, 0x52, 0x4f, 0x4c, 0x5f, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x52, 0x45,
+	0x53, 0x55, 0x4d, 0x45, 0x5f, 0x53, 0x45, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x10, 0x04, 0x12, 0x1e,
+	0x0a, 0x1a, 0x43, 0x4f, 0x4e, 0x54, 0x52, 0x4f, 0x4c, 0x5f, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e,
+	0x5f, 0x45, 0x4e, 0x44, 0x5f, 0x53, 0x45, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x10, 0x05, 0x12, 0x25,
+	0x0a, 0x21, 0x43, 0x4f, 0x4e, 0x54, 0x52, 0x4f, 0x4c, 0x5f, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e,
+	0x5f, 0x53, 0x45, 0x54, 0x5f, 0x52, 0x45, 0x46, 0x45, 0x52, 0x45, 0x4e, 0x43, 0x45, 0x5f, 0x54,
+	0x45, 0x58, 0x54, 0x10, 0x06, 0x12, 0x24, 0x0a, 0x20, 0x43, 0x4f, 0x4e, 0x54, 0x52, 0x4f, 0x4c,
+	0x5f, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x43, 0x4f, 0x4e, 0x46, 0x49, 0x47, 0x55, 0x52,
+	0x45, 0x5f, 0x53, 0x45, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x10, 0x07, 0x12, 0x22, 0x0a, 0x1e, 0x43,
+	0x4f, 0x4e, 0x54, 0x52, 0x4f, 0x4c, 0x5f, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x53, 0x54,
+	0x41, 0x52, 0x54, 0x5f, 0x53, 0x48, 0x41, 0x44, 0x4f, 0x57, 0x49, 0x4e, 0x47, 0x10, 0x08, 0x12,
+	0x1d, 0x0a, 0x19, 0x43, 0x4f, 0x4e, 0x54, 0x52, 0x4f, 0x4c, 0x5f, 0x41, 0x43, 0x54, 0x49, 0x4f,
+	0x4e, 0x5f, 0x52, 0x45, 0x54, 0x52, 0x59, 0x5f, 0x54, 0x55, 0x52, 0x4e, 0x10, 0x09, 0x12, 0x25,
+	0x0a, 0x21, 0x43, 0x4f, 0x4e, 0x54, 0x52, 0x4f, 0x4c, 0x5f, 0x41, 0x43, 0x54, 0x49, 0x4f, 0x4e,
+	0x5f, 0x52, 0x45, 0x51, 0x55, 0x45, 0x53, 0x54, 0x5f, 0x43, 0x4f, 0x52, 0x52, 0x45, 0x43, 0x54,
+	0x49, 0x4f, 0x4e, 0x10, 0x0a, 0x2a, 0xae, 0x01, 0x0a, 0x0d, 0x4c, 0x4c, 0x4d, 0x52, 0x65, 0x73,
+	0x75, 0x6c, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1f, 0x0a, 0x1b, 0x4c, 0x4c, 0x4d, 0x5f, 0x52,
+	0x45, 0x53, 0x55, 0x4c, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45,
+	0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1c, 0x0a, 0x18, 0x4c, 0x4c, 0x4d, 0x5f,
+	0x52, 0x45, 0x53, 0x55, 0x4c, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x52, 0x45, 0x53, 0x50,
+	0x4f, 0x4e, 0x53, 0x45, 0x10, 0x01, 0x12, 0x1b, 0x0a, 0x17, 0x4c, 0x4c, 0x4d, 0x5f, 0x52, 0x45,
+	0x53, 0x55, 0x4c, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x52, 0x54, 0x45,
+	0x52, 0x10, 0x02, 0x12, 0x21, 0x0a, 0x1d, 0x4c, 0x4c, 0x4d, 0x5f, 0x52, 0x45, 0x53, 0x55, 0x4c,
+	0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x4c, 0x41, 0x52, 0x49, 0x46, 0x49, 0x43, 0x41,
+	0x54, 0x49, 0x4f, 0x4e, 0x10, 0x03, 0x12, 0x1e, 0x0a, 0x1a, 0x4c, 0x4c, 0x4d, 0x5f, 0x52, 0x45,
+	0x53, 0x55, 0x4c, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43, 0x4f, 0x52, 0x52, 0x45, 0x43,
+	0x54, 0x49, 0x4f, 0x4e, 0x10, 0x04, 0x2a, 0xef, 0x01, 0x0a, 0x09, 0x45, 0x72, 0x72, 0x6f, 0x72,
+	0x43, 0x6f, 0x64, 0x65, 0x12, 0x1a, 0x0a, 0x16, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x5f, 0x43, 0x4f,
+	0x44, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00,
+	0x12, 0x1e, 0x0a, 0x1a, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x5f, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x49,
+	0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x5f, 0x52, 0x45, 0x51, 0x55, 0x45, 0x53, 0x54, 0x10, 0x01,
+	0x12, 0x19, 0x0a, 0x15, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x5f, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x41,
+	0x53, 0x52, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x02, 0x12, 0x19, 0x0a, 0x15, 0x45,
+	0x52, 0x52, 0x4f, 0x52, 0x5f, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x4c, 0x4c, 0x4d, 0x5f, 0x46, 0x41,
+	0x49, 0x4c, 0x45, 0x44, 0x10, 0x03, 0x12, 0x19, 0x0a, 0x15, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x5f,
+	0x43, 0x4f, 0x44, 0x45, 0x5f, 0x54, 0x54, 0x53, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10,
+	0x04, 0x12, 0x19, 0x0a, 0x15, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x5f, 0x43, 0x4f, 0x44, 0x45, 0x5f,
+	0x49, 0x53, 0x45, 0x5f, 0x46, 0x41, 0x49, 0x4c, 0x45, 0x44, 0x10, 0x05, 0x12, 0x17, 0x0a, 0x13,
+	0x45, 0x52, 0x52, 0x4f, 0x52, 0x5f, 0x43, 0x4f, 0x44, 0x45, 0x5f, 0x49, 0x4e, 0x54, 0x45, 0x52,
+	0x4e, 0x41, 0x4c, 0x10, 0x06, 0x12, 0x21, 0x0a, 0x1d, 0x45, 0x52, 0x52, 0x4f, 0x52, 0x5f, 0x43,
+	0x4f, 0x44, 0x45, 0x5f, 0x53, 0x45, 0x52, 0x56, 0x49, 0x43, 0x45, 0x5f, 0x4f, 0x56, 0x45, 0x52,
+	0x4c, 0x4f, 0x41, 0x44, 0x45, 0x44, 0x10, 0x07, 0x32, 0x9e, 0x03, 0x0a, 0x0d, 0x53, 0x70, 0x65,
+	0x65, 0x63, 0x68, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x51, 0x0a, 0x18, 0x50, 0x72,
+	0x6f, 0x63, 0x65, 0x73, 0x73, 0x56, 0x6f, 0x69, 0x63, 0x65, 0x43, 0x6f, 0x6e, 0x76, 0x65, 0x72,
+	0x73, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x17, 0x2e, 0x73, 0x70, 0x65, 0x65, 0x63, 0x68, 0x2e,
+	0x76, 0x31, 0x2e, 0x56, 0x6f, 0x69, 0x63, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x18, 0x2e, 0x73, 0x70, 0x65, 0x65, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x6f, 0x69, 0x63,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x28, 0x01, 0x30, 0x01, 0x12, 0x49, 0x0a,
+	0x0a, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x12, 0x1c, 0x2e, 0x73, 0x70,
+	0x65, 0x65, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x63, 0x72, 0x69,
+	0x62, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x73, 0x70, 0x65, 0x65,
+	0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x49, 0x0a, 0x0a, 0x53, 0x79, 0x6e, 0x74,
+	0x68, 0x65, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x1c, 0x2e, 0x73, 0x70, 0x65, 0x65, 0x63, 0x68, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x79, 0x6e, 0x74, 0x68, 0x65, 0x73, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x73, 0x70, 0x65, 0x65, 0x63, 0x68, 0x2e, 0x76, 0x31,
+	0x2e, 0x53, 0x79, 0x6e, 0x74, 0x68, 0x65, 0x73, 0x69, 0x7a, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x50, 0x0a, 0x15, 0x45, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x65, 0x50,
+	0x72, 0x6f, 0x6e, 0x75, 0x6e, 0x63, 0x69, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1a, 0x2e, 0x73,
+	0x70, 0x65, 0x65, 0x63, 0x68, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x73, 0x70, 0x65, 0x65, 0x63,
+	0x68, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x76, 0x61, 0x6c, 0x75, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x52, 0x0a, 0x0d, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x1f, 0x2e, 0x73, 0x70, 0x65, 0x65, 0x63, 0x68, 0x2e,
+	0x76, 0x31, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c, 0x79,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x73, 0x70, 0x65, 0x65, 0x63, 0x68,
+	0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x6e, 0x65, 0x72, 0x61, 0x74, 0x65, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x46, 0x5a, 0x44, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x43, 0x75, 0x72, 0x76, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x58, 0x2f, 0x61, 0x69, 0x2d, 0x74, 0x75, 0x74, 0x6f, 0x72, 0x2d, 0x6d, 0x6f, 0x6e, 0x6f,
+	0x72, 0x65, 0x70, 0x6f, 0x2f, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2f, 0x73, 0x70, 0x65, 0x65, 0x63, 0x68, 0x3b, 0x73, 0x70, 0x65, 0x65, 0x63, 0x68, 0x76,
+	0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_speech_speech_proto_rawDescOnce sync.Once
+	file_speech_speech_proto_rawDescData = file_speech_speech_proto_rawDesc
+)
+
+func file_speech_speech_proto_rawDescGZIP() []byte {
+	file_speech_speech_proto_rawDescOnce.Do(func() {
+		file_speech_speech_proto_rawDescData = protoimpl.X.CompressGZIP(file_speech_speech_proto_rawDescData)
+	})
+	return file_speech_speech_proto_rawDescData
+}
+
+var file_speech_speech_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_speech_speech_proto_msgTypes = make([]protoimpl.MessageInfo, 28)
+var file_speech_speech_proto_goTypes = []interface{}{
+	(ControlAction)(0),              // 0: speech.v1.ControlAction
+	(LLMResultType)(0),              // 1: speech.v1.LLMResultType
+	(ErrorCode)(0),                  // 2: speech.v1.ErrorCode
+	(*VoiceRequest)(nil),            // 3: speech.v1.VoiceRequest
+	(*TextInput)(nil),               // 4: speech.v1.TextInput
+	(*AudioChunk)(nil),              // 5: speech.v1.AudioChunk
+	(*ControlMessage)(nil),          // 6: speech.v1.ControlMessage
+	(*VoiceResponse)(nil),           // 7: speech.v1.VoiceResponse
+	(*ASRResult)(nil),               // 8: speech.v1.ASRResult
+	(*LLMResult)(nil),               // 9: speech.v1.LLMResult
+	(*TTSResult)(nil),               // 10: speech.v1.TTSResult
+	(*WordBoundary)(nil),            // 11: speech.v1.WordBoundary
+	(*WordScore)(nil),               // 12: speech.v1.WordScore
+	(*PhoneScore)(nil),              // 13: speech.v1.PhoneScore
+	(*ISEResult)(nil),               // 14: speech.v1.ISEResult
+	(*PronunciationComparison)(nil), // 15: speech.v1.PronunciationComparison
+	(*WordAlignment)(nil),           // 16: speech.v1.WordAlignment
+	(*ShadowingResult)(nil),         // 17: speech.v1.ShadowingResult
+	(*StatusResult)(nil),            // 18: speech.v1.StatusResult
+	(*StageTimings)(nil),            // 19: speech.v1.StageTimings
+	(*ErrorResult)(nil),             // 20: speech.v1.ErrorResult
+	(*TranscribeRequest)(nil),       // 21: speech.v1.TranscribeRequest
+	(*TranscribeResponse)(nil),      // 22: speech.v1.TranscribeResponse
+	(*SynthesizeRequest)(nil),       // 23: speech.v1.SynthesizeRequest
+	(*SynthesizeResponse)(nil),      // 24: speech.v1.SynthesizeResponse
+	(*EvaluateRequest)(nil),         // 25: speech.v1.EvaluateRequest
+	(*EvaluateResponse)(nil),        // 26: speech.v1.EvaluateResponse
+	(*ChatTurn)(nil),                // 27: speech.v1.ChatTurn
+	(*GenerateReplyRequest)(nil),    // 28: speech.v1.GenerateReplyRequest
+	(*GenerateReplyResponse)(nil),   // 29: speech.v1.GenerateReplyResponse
+	nil,                             // 30: speech.v1.ControlMessage.ParametersEntry
+}
+var file_speech_speech_proto_depIdxs = []int32{
+	5,  // 0: speech.v1.VoiceRequest.audio_data:type_name -> speech.v1.AudioChunk
+	6,  // 1: speech.v1.VoiceRequest.control:type_name -> speech.v1.ControlMessage
+	4,  // 2: speech.v1.VoiceRequest.text_input:type_name -> speech.v1.TextInput
+	0,  // 3: speech.v1.ControlMessage.action:type_name -> speech.v1.ControlAction
+	30, // 4: speech.v1.ControlMessage.parameters:type_name -> speech.v1.ControlMessage.ParametersEntry
+	8,  // 5: speech.v1.VoiceResponse.asr_result:type_name -> speech.v1.ASRResult
+	9,  // 6: speech.v1.VoiceResponse.llm_result:type_name -> speech.v1.LLMResult
+	10, // 7: speech.v1.VoiceResponse.tts_result:type_name -> speech.v1.TTSResult
+	14, // 8: speech.v1.VoiceResponse.ise_result:type_name -> speech.v1.ISEResult
+	18, // 9: speech.v1.VoiceResponse.status:type_name -> speech.v1.StatusResult
+	20, // 10: speech.v1.VoiceResponse.error:type_name -> speech.v1.ErrorResult
+	15, // 11: speech.v1.VoiceResponse.pronunciation_comparison:type_name -> speech.v1.PronunciationComparison
+	17, // 12: speech.v1.VoiceResponse.shadowing_result:type_name -> speech.v1.ShadowingResult
+	1,  // 13: speech.v1.LLMResult.type:type_name -> speech.v1.LLMResultType
+	11, // 14: speech.v1.TTSResult.word_boundaries:type_name -> speech.v1.WordBoundary
+	13, // 15: speech.v1.WordScore.phones:type_name -> speech.v1.PhoneScore
+	12, // 16: speech.v1.ISEResult.words:type_name -> speech.v1.WordScore
+	16, // 17: speech.v1.ShadowingResult.words:type_name -> speech.v1.WordAlignment
+	19, // 18: speech.v1.StatusResult.timings:type_name -> speech.v1.StageTimings
+	2,  // 19: speech.v1.ErrorResult.code:type_name -> speech.v1.ErrorCode
+	14, // 20: speech.v1.EvaluateResponse.result:type_name -> speech.v1.ISEResult
+	27, // 21: speech.v1.GenerateReplyRequest.history:type_name -> speech.v1.ChatTurn
+	3,  // 22: speech.v1.SpeechService.ProcessVoiceConversation:input_type -> speech.v1.VoiceRequest
+	21, // 23: speech.v1.SpeechService.Transcribe:input_type -> speech.v1.TranscribeRequest
+	23, // 24: speech.v1.SpeechService.Synthesize:input_type -> speech.v1.SynthesizeRequest
+	25, // 25: speech.v1.SpeechService.EvaluatePronunciation:input_type -> speech.v1.EvaluateRequest
+	28, // 26: speech.v1.SpeechService.GenerateReply:input_type -> speech.v1.GenerateReplyRequest
+	7,  // 27: speech.v1.SpeechService.ProcessVoiceConversation:output_type -> speech.v1.VoiceResponse
+	22, // 28: speech.v1.SpeechService.Transcribe:output_type -> speech.v1.TranscribeResponse
+	24, // 29: speech.v1.SpeechService.Synthesize:output_type -> speech.v1.SynthesizeResponse
+	26, // 30: speech.v1.SpeechService.EvaluatePronunciation:output_type -> speech.v1.EvaluateResponse
+	29, // 31: speech.v1.SpeechService.GenerateReply:output_type -> speech.v1.GenerateReplyResponse
+	27, // [27:32] is the sub-list for method output_type
+	22, // [22:27] is the sub-list for method input_type
+	22, // [22:22] is the sub-list for extension type_name
+	22, // [22:22] is the sub-list for extension extendee
+	0,  // [0:22] is the sub-list for field type_name
+}
+
+func init() { file_speech_speech_proto_init() }
+func file_speech_speech_proto_init() {
+	if File_speech_speech_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_speech_speech_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VoiceRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_speech_speech_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TextInput); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_speech_speech_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AudioChunk); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_speech_speech_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ControlMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_speech_speech_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*VoiceResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_speech_speech_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ASRResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_speech_speech_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LLMResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_speech_speech_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TTSResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_speech_speech_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WordBoundary); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_speech_speech_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WordScore); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_speech_speech_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PhoneScore); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_speech_speech_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ISEResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_speech_speech_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PronunciationComparison); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_speech_speech_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WordAlignment); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_speech_speech_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ShadowingResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_speech_speech_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StatusResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_speech_speech_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*StageTimings); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_speech_speech_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ErrorResult); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_speech_speech_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TranscribeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_speech_speech_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TranscribeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_speech_speech_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SynthesizeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_speech_speech_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SynthesizeResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_speech_speech_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EvaluateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_speech_speech_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*EvaluateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_speech_speech_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ChatTurn); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_speech_speech_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GenerateReplyRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_speech_speech_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GenerateReplyResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_speech_speech_proto_msgTypes[0].OneofWrappers = []interface{}{
+		(*VoiceRequest_AudioData)(nil),
+		(*VoiceRequest_Control)(nil),
+		(*VoiceRequest_TextInput)(nil),
+	}
+	file_speech_speech_proto_msgTypes[4].OneofWrappers = []interface{}{
+		(*VoiceResponse_AsrResult)(nil),
+		(*VoiceResponse_LlmResult)(nil),
+		(*VoiceResponse_TtsResult)(nil),
+		(*VoiceResponse_IseResult)(nil),
+		(*VoiceResponse_Status)(nil),
+		(*VoiceResponse_Error)(nil),
+		(*VoiceResponse_PronunciationComparison)(nil),
+		(*VoiceResponse_ShadowingResult)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_speech_speech_proto_rawDesc,
+			NumEnums:      3,
+			NumMessages:   28,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_speech_speech_proto_goTypes,
+		DependencyIndexes: file_speech_speech_proto_depIdxs,
+		EnumInfos:         file_speech_speech_proto_enumTypes,
+		MessageInfos:      file_speech_speech_proto_msgTypes,
+	}.Build()
+	File_speech_speech_proto = out.File
+	file_speech_speech_proto_rawDesc = nil
+	file_speech_speech_proto_goTypes = nil
+	file_speech_speech_proto_depIdxs = nil
+}
@@ -0,0 +1,290 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: speech/speech.proto
+
+package speechv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	SpeechService_ProcessVoiceConversation_FullMethodName = "/speech.v1.SpeechService/ProcessVoiceConversation"
+	SpeechService_Transcribe_FullMethodName               = "/speech.v1.SpeechService/Transcribe"
+	SpeechService_Synthesize_FullMethodName               = "/speech.v1.SpeechService/Synthesize"
+	SpeechService_EvaluatePronunciation_FullMethodName    = "/speech.v1.SpeechService/EvaluatePronunciation"
+	SpeechService_GenerateReply_FullMethodName            = "/speech.v1.SpeechService/GenerateReply"
+)
+
+// SpeechServiceClient is the client API for SpeechService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SpeechServiceClient interface {
+	ProcessVoiceConversation(ctx context.Context, opts ...grpc.CallOption) (SpeechService_ProcessVoiceConversationClient, error)
+	Transcribe(ctx context.Context, in *TranscribeRequest, opts ...grpc.CallOption) (*TranscribeResponse, error)
+	Synthesize(ctx context.Context, in *SynthesizeRequest, opts ...grpc.CallOption) (*SynthesizeResponse, error)
+	EvaluatePronunciation(ctx context.Context, in *EvaluateRequest, opts ...grpc.CallOption) (*EvaluateResponse, error)
+	GenerateReply(ctx context.Context, in *GenerateReplyRequest, opts ...grpc.CallOption) (*GenerateReplyResponse, error)
+}
+
+type speechServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSpeechServiceClient(cc grpc.ClientConnInterface) SpeechServiceClient {
+	return &speechServiceClient{cc}
+}
+
+func (c *speechServiceClient) ProcessVoiceConversation(ctx context.Context, opts ...grpc.CallOption) (SpeechService_ProcessVoiceConversationClient, error) {
+	stream, err := c.cc.NewStream(ctx, &SpeechService_ServiceDesc.Streams[0], SpeechService_ProcessVoiceConversation_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &speechServiceProcessVoiceConversationClient{stream}
+	return x, nil
+}
+
+type SpeechService_ProcessVoiceConversationClient interface {
+	Send(*VoiceRequest) error
+	Recv() (*VoiceResponse, error)
+	grpc.ClientStream
+}
+
+type speechServiceProcessVoiceConversationClient struct {
+	grpc.ClientStream
+}
+
+func (x *speechServiceProcessVoiceConversationClient) Send(m *VoiceRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *speechServiceProcessVoiceConversationClient) Recv() (*VoiceResponse, error) {
+	m := new(VoiceResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *speechServiceClient) Transcribe(ctx context.Context, in *TranscribeRequest, opts ...grpc.CallOption) (*TranscribeResponse, error) {
+	out := new(TranscribeResponse)
+	err := c.cc.Invoke(ctx, SpeechService_Transcribe_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *speechServiceClient) Synthesize(ctx context.Context, in *SynthesizeRequest, opts ...grpc.CallOption) (*SynthesizeResponse, error) {
+	out := new(SynthesizeResponse)
+	err := c.cc.Invoke(ctx, SpeechService_Synthesize_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *speechServiceClient) EvaluatePronunciation(ctx context.Context, in *EvaluateRequest, opts ...grpc.CallOption) (*EvaluateResponse, error) {
+	out := new(EvaluateResponse)
+	err := c.cc.Invoke(ctx, SpeechService_EvaluatePronunciation_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *speechServiceClient) GenerateReply(ctx context.Context, in *GenerateReplyRequest, opts ...grpc.CallOption) (*GenerateReplyResponse, error) {
+	out := new(GenerateReplyResponse)
+	err := c.cc.Invoke(ctx, SpeechService_GenerateReply_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SpeechServiceServer is the server API for SpeechService service.
+// All implementations must embed UnimplementedSpeechServiceServer
+// for forward compatibility
+type SpeechServiceServer interface {
+	ProcessVoiceConversation(SpeechService_ProcessVoiceConversationServer) error
+	Transcribe(context.Context, *TranscribeRequest) (*TranscribeResponse, error)
+	Synthesize(context.Context, *SynthesizeRequest) (*SynthesizeResponse, error)
+	EvaluatePronunciation(context.Context, *EvaluateRequest) (*EvaluateResponse, error)
+	GenerateReply(context.Context, *GenerateReplyRequest) (*GenerateReplyResponse, error)
+	mustEmbedUnimplementedSpeechServiceServer()
+}
+
+// UnimplementedSpeechServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedSpeechServiceServer struct {
+}
+
+func (UnimplementedSpeechServiceServer) ProcessVoiceConversation(SpeechService_ProcessVoiceConversationServer) error {
+	return status.Errorf(codes.Unimplemented, "method ProcessVoiceConversation not implemented")
+}
+func (UnimplementedSpeechServiceServer) Transcribe(context.Context, *TranscribeRequest) (*TranscribeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Transcribe not implemented")
+}
+func (UnimplementedSpeechServiceServer) Synthesize(context.Context, *SynthesizeRequest) (*SynthesizeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Synthesize not implemented")
+}
+func (UnimplementedSpeechServiceServer) EvaluatePronunciation(context.Context, *EvaluateRequest) (*EvaluateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EvaluatePronunciation not implemented")
+}
+func (UnimplementedSpeechServiceServer) GenerateReply(context.Context, *GenerateReplyRequest) (*GenerateReplyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GenerateReply not implemented")
+}
+func (UnimplementedSpeechServiceServer) mustEmbedUnimplementedSpeechServiceServer() {}
+
+// UnsafeSpeechServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SpeechServiceServer will
+// result in compilation errors.
+type UnsafeSpeechServiceServer interface {
+	mustEmbedUnimplementedSpeechServiceServer()
+}
+
+func RegisterSpeechServiceServer(s grpc.ServiceRegistrar, srv SpeechServiceServer) {
+	s.RegisterService(&SpeechService_ServiceDesc, srv)
+}
+
+func _SpeechService_ProcessVoiceConversation_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(SpeechServiceServer).ProcessVoiceConversation(&speechServiceProcessVoiceConversationServer{stream})
+}
+
+type SpeechService_ProcessVoiceConversationServer interface {
+	Send(*VoiceResponse) error
+	Recv() (*VoiceRequest, error)
+	grpc.ServerStream
+}
+
+type speechServiceProcessVoiceConversationServer struct {
+	grpc.ServerStream
+}
+
+func (x *speechServiceProcessVoiceConversationServer) Send(m *VoiceResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *speechServiceProcessVoiceConversationServer) Recv() (*VoiceRequest, error) {
+	m := new(VoiceRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _SpeechService_Transcribe_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TranscribeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SpeechServiceServer).Transcribe(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SpeechService_Transcribe_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SpeechServiceServer).Transcribe(ctx, req.(*TranscribeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SpeechService_Synthesize_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SynthesizeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SpeechServiceServer).Synthesize(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SpeechService_Synthesize_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SpeechServiceServer).Synthesize(ctx, req.(*SynthesizeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SpeechService_EvaluatePronunciation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EvaluateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SpeechServiceServer).EvaluatePronunciation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SpeechService_EvaluatePronunciation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SpeechServiceServer).EvaluatePronunciation(ctx, req.(*EvaluateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SpeechService_GenerateReply_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateReplyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SpeechServiceServer).GenerateReply(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SpeechService_GenerateReply_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SpeechServiceServer).GenerateReply(ctx, req.(*GenerateReplyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SpeechService_ServiceDesc is the grpc.ServiceDesc for SpeechService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SpeechService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "speech.v1.SpeechService",
+	HandlerType: (*SpeechServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Transcribe",
+			Handler:    _SpeechService_Transcribe_Handler,
+		},
+		{
+			MethodName: "Synthesize",
+			Handler:    _SpeechService_Synthesize_Handler,
+		},
+		{
+			MethodName: "EvaluatePronunciation",
+			Handler:    _SpeechService_EvaluatePronunciation_Handler,
+		},
+		{
+			MethodName: "GenerateReply",
+			Handler:    _SpeechService_GenerateReply_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ProcessVoiceConversation",
+			Handler:       _SpeechService_ProcessVoiceConversation_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "speech/speech.proto",
+}
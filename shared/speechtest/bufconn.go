@@ -0,0 +1,50 @@
+package speechtest
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	speechv1 "github.com/CurvatureX/ai-tutor-monorepo/shared/proto/speech"
+)
+
+const bufconnSize = 1024 * 1024
+
+// NewBufconnClient runs srv on an in-memory listener and returns a real
+// client connected to it. Server and connection are torn down with the test.
+func NewBufconnClient(t *testing.T, srv speechv1.SpeechServiceServer) speechv1.SpeechServiceClient {
+	return NewBufconnClientWithOptions(t, srv, nil, nil)
+}
+
+// NewBufconnClientWithOptions is NewBufconnClient with explicit server and
+// dial options, for tests exercising non-default limits or credentials.
+func NewBufconnClientWithOptions(t *testing.T, srv speechv1.SpeechServiceServer, serverOpts []grpc.ServerOption, dialOpts []grpc.DialOption) speechv1.SpeechServiceClient {
+	t.Helper()
+
+	listener := bufconn.Listen(bufconnSize)
+	server := grpc.NewServer(serverOpts...)
+	speechv1.RegisterSpeechServiceServer(server, srv)
+	go server.Serve(listener)
+
+	opts := append([]grpc.DialOption{
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	}, dialOpts...)
+	conn, err := grpc.Dial("bufconn", opts...)
+	if err != nil {
+		t.Fatalf("dial bufconn: %v", err)
+	}
+
+	t.Cleanup(func() {
+		conn.Close()
+		server.Stop()
+		listener.Close()
+	})
+	return speechv1.NewSpeechServiceClient(conn)
+}
@@ -0,0 +1,59 @@
+package speechtest
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	speechv1 "github.com/CurvatureX/ai-tutor-monorepo/shared/proto/speech"
+)
+
+// echoTranscriber reports the size of the audio it received, so tests can
+// verify large payloads arrive intact.
+type echoTranscriber struct {
+	speechv1.UnimplementedSpeechServiceServer
+}
+
+func (echoTranscriber) Transcribe(ctx context.Context, req *speechv1.TranscribeRequest) (*speechv1.TranscribeResponse, error) {
+	return &speechv1.TranscribeResponse{Text: fmt.Sprintf("%d", len(req.AudioData))}, nil
+}
+
+func TestLargeAudioRoundTripsWithRaisedLimits(t *testing.T) {
+	const limit = 16 * 1024 * 1024
+	client := NewBufconnClientWithOptions(t, echoTranscriber{},
+		[]grpc.ServerOption{grpc.MaxRecvMsgSize(limit), grpc.MaxSendMsgSize(limit)},
+		[]grpc.DialOption{grpc.WithDefaultCallOptions(
+			grpc.MaxCallRecvMsgSize(limit),
+			grpc.MaxCallSendMsgSize(limit),
+		)},
+	)
+
+	// 6 MB is comfortably over the 4 MB gRPC default.
+	audio := make([]byte, 6*1024*1024)
+	resp, err := client.Transcribe(context.Background(), &speechv1.TranscribeRequest{
+		AudioData:  audio,
+		Format:     "wav",
+		SampleRate: 16000,
+	})
+	if err != nil {
+		t.Fatalf("Transcribe with raised limits: %v", err)
+	}
+	if want := fmt.Sprintf("%d", len(audio)); resp.Text != want {
+		t.Errorf("server saw %s bytes, want %s", resp.Text, want)
+	}
+}
+
+func TestLargeAudioRejectedAtDefaultLimits(t *testing.T) {
+	client := NewBufconnClient(t, echoTranscriber{})
+
+	_, err := client.Transcribe(context.Background(), &speechv1.TranscribeRequest{
+		AudioData: make([]byte, 6*1024*1024),
+	})
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("Transcribe error = %v, want ResourceExhausted at default limits", err)
+	}
+}
@@ -0,0 +1,154 @@
+// Package speechtest provides test doubles for the speech-service gRPC API:
+// a scripted in-memory fake client, and a bufconn-backed harness that runs a
+// real server in-process. Tests in the gateway and the speech service build
+// on these instead of needing a live speech-service.
+package speechtest
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	speechv1 "github.com/CurvatureX/ai-tutor-monorepo/shared/proto/speech"
+)
+
+// FakeSpeechClient implements speechv1.SpeechServiceClient. Tests enqueue
+// responses to be delivered on the conversation stream and inspect every
+// request the code under test sent.
+type FakeSpeechClient struct {
+	mu        sync.Mutex
+	requests  []*speechv1.VoiceRequest
+	streamCtx context.Context
+
+	responses chan *speechv1.VoiceResponse
+	recvErr   error
+	closed    bool
+	panicMsg  string
+}
+
+// NewFakeSpeechClient creates a fake with room for buffered responses.
+func NewFakeSpeechClient() *FakeSpeechClient {
+	return &FakeSpeechClient{
+		responses: make(chan *speechv1.VoiceResponse, 64),
+	}
+}
+
+// EnqueueResponse schedules a response for delivery via Recv.
+func (f *FakeSpeechClient) EnqueueResponse(resp *speechv1.VoiceResponse) {
+	f.responses <- resp
+}
+
+// CloseStream makes Recv return err (io.EOF if nil) once the queued
+// responses are drained.
+func (f *FakeSpeechClient) CloseStream(err error) {
+	f.mu.Lock()
+	if !f.closed {
+		f.closed = true
+		f.recvErr = err
+		close(f.responses)
+	}
+	f.mu.Unlock()
+}
+
+// PanicOnNextRecv makes the next Recv call on any stream panic with msg,
+// simulating a fault inside response handling.
+func (f *FakeSpeechClient) PanicOnNextRecv(msg string) {
+	f.mu.Lock()
+	f.panicMsg = msg
+	f.mu.Unlock()
+}
+
+// SentRequests returns a copy of every request sent on the stream so far.
+func (f *FakeSpeechClient) SentRequests() []*speechv1.VoiceRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	out := make([]*speechv1.VoiceRequest, len(f.requests))
+	copy(out, f.requests)
+	return out
+}
+
+// LastStreamContext returns the context the most recent conversation stream
+// was opened with, so tests can inspect its outgoing metadata.
+func (f *FakeSpeechClient) LastStreamContext() context.Context {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.streamCtx
+}
+
+// ProcessVoiceConversation returns the scripted stream.
+func (f *FakeSpeechClient) ProcessVoiceConversation(ctx context.Context, opts ...grpc.CallOption) (speechv1.SpeechService_ProcessVoiceConversationClient, error) {
+	f.mu.Lock()
+	f.streamCtx = ctx
+	f.mu.Unlock()
+	return &fakeStream{ctx: ctx, parent: f}, nil
+}
+
+// Transcribe is not scripted; tests needing unary RPCs should use the
+// bufconn harness with a real server implementation.
+func (f *FakeSpeechClient) Transcribe(ctx context.Context, in *speechv1.TranscribeRequest, opts ...grpc.CallOption) (*speechv1.TranscribeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "not scripted in FakeSpeechClient")
+}
+
+func (f *FakeSpeechClient) Synthesize(ctx context.Context, in *speechv1.SynthesizeRequest, opts ...grpc.CallOption) (*speechv1.SynthesizeResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "not scripted in FakeSpeechClient")
+}
+
+func (f *FakeSpeechClient) EvaluatePronunciation(ctx context.Context, in *speechv1.EvaluateRequest, opts ...grpc.CallOption) (*speechv1.EvaluateResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "not scripted in FakeSpeechClient")
+}
+
+func (f *FakeSpeechClient) GenerateReply(ctx context.Context, in *speechv1.GenerateReplyRequest, opts ...grpc.CallOption) (*speechv1.GenerateReplyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "not scripted in FakeSpeechClient")
+}
+
+// fakeStream records sends and replays enqueued responses. The embedded
+// grpc.ClientStream is nil; only the methods the gateway uses are
+// implemented.
+type fakeStream struct {
+	grpc.ClientStream
+	ctx    context.Context
+	parent *FakeSpeechClient
+}
+
+func (s *fakeStream) Send(req *speechv1.VoiceRequest) error {
+	s.parent.mu.Lock()
+	defer s.parent.mu.Unlock()
+	if s.parent.closed {
+		return io.EOF
+	}
+	s.parent.requests = append(s.parent.requests, req)
+	return nil
+}
+
+func (s *fakeStream) Recv() (*speechv1.VoiceResponse, error) {
+	s.parent.mu.Lock()
+	if msg := s.parent.panicMsg; msg != "" {
+		s.parent.panicMsg = ""
+		s.parent.mu.Unlock()
+		panic(msg)
+	}
+	s.parent.mu.Unlock()
+	resp, ok := <-s.parent.responses
+	if !ok {
+		s.parent.mu.Lock()
+		err := s.parent.recvErr
+		s.parent.mu.Unlock()
+		if err == nil {
+			err = io.EOF
+		}
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (s *fakeStream) CloseSend() error {
+	return nil
+}
+
+func (s *fakeStream) Context() context.Context {
+	return s.ctx
+}
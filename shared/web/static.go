@@ -0,0 +1,76 @@
+// Package web holds HTTP serving helpers shared by the Go services that
+// front a browser client, such as the gateway.
+package web
+
+import (
+	"bytes"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+// StaticSite serves a single-page frontend from an fs.FS, typically an
+// embed.FS compiled into the binary so deployments need no loose files next
+// to it. Assets resolve both at the root and under /static/ (the path the
+// pages historically used). Unknown extension-less paths fall back to
+// index.html so client-side routing works; paths under a registered API
+// prefix 404 instead, keeping JSON clients from receiving HTML.
+type StaticSite struct {
+	content     fs.FS
+	apiPrefixes []string
+}
+
+// NewStaticSite builds the site from embedded content, with overrideDir
+// substituting an on-disk directory for development when non-empty. The
+// content must contain an index.html at its root.
+func NewStaticSite(embedded fs.FS, overrideDir string, apiPrefixes ...string) (*StaticSite, error) {
+	content := embedded
+	if overrideDir != "" {
+		content = os.DirFS(overrideDir)
+	}
+	if _, err := fs.Stat(content, "index.html"); err != nil {
+		return nil, err
+	}
+	return &StaticSite{content: content, apiPrefixes: apiPrefixes}, nil
+}
+
+// ServeHTTP serves the requested asset, or index.html for unknown
+// extension-less paths outside the API prefixes.
+func (s *StaticSite) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(path.Clean(r.URL.Path), "/")
+	name = strings.TrimPrefix(name, "static/")
+	if name == "" || name == "." || name == "static" {
+		name = "index.html"
+	}
+
+	if info, err := fs.Stat(s.content, name); err != nil || info.IsDir() {
+		for _, prefix := range s.apiPrefixes {
+			if strings.HasPrefix(r.URL.Path, prefix) {
+				http.NotFound(w, r)
+				return
+			}
+		}
+		if path.Ext(name) != "" {
+			// A missing asset is a 404, not the SPA shell.
+			http.NotFound(w, r)
+			return
+		}
+		name = "index.html"
+	}
+
+	data, err := fs.ReadFile(s.content, name)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	if strings.HasSuffix(name, ".html") {
+		// The shell must revalidate so deploys take effect immediately.
+		w.Header().Set("Cache-Control", "no-cache")
+	} else {
+		w.Header().Set("Cache-Control", "public, max-age=86400")
+	}
+	http.ServeContent(w, r, name, time.Time{}, bytes.NewReader(data))
+}
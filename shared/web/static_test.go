@@ -0,0 +1,94 @@
+package web
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func testSite(t *testing.T) *StaticSite {
+	t.Helper()
+	site, err := NewStaticSite(fstest.MapFS{
+		"index.html": {Data: []byte("<html>shell</html>")},
+		"app.js":     {Data: []byte("console.log('hi')")},
+	}, "", "/api/", "/ws")
+	if err != nil {
+		t.Fatalf("NewStaticSite: %v", err)
+	}
+	return site
+}
+
+func get(t *testing.T, site *StaticSite, path string) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	site.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	return rec
+}
+
+func TestStaticSiteServesIndexAtRoot(t *testing.T) {
+	rec := get(t, testSite(t), "/")
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "shell") {
+		t.Errorf("GET / = %d %q, want the index shell", rec.Code, rec.Body.String())
+	}
+	if got := rec.Header().Get("Cache-Control"); got != "no-cache" {
+		t.Errorf("index Cache-Control = %q, want no-cache", got)
+	}
+}
+
+func TestStaticSiteServesAssetsWithCacheHeaders(t *testing.T) {
+	site := testSite(t)
+	for _, path := range []string{"/app.js", "/static/app.js"} {
+		rec := get(t, site, path)
+		if rec.Code != http.StatusOK {
+			t.Errorf("GET %s = %d, want 200", path, rec.Code)
+		}
+		if got := rec.Header().Get("Cache-Control"); !strings.Contains(got, "max-age") {
+			t.Errorf("GET %s Cache-Control = %q, want a max-age", path, got)
+		}
+	}
+}
+
+func TestStaticSiteSPAFallback(t *testing.T) {
+	rec := get(t, testSite(t), "/lessons/ordering-food")
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), "shell") {
+		t.Errorf("SPA fallback = %d %q, want index.html", rec.Code, rec.Body.String())
+	}
+}
+
+func TestStaticSiteMissingAssetIs404(t *testing.T) {
+	if rec := get(t, testSite(t), "/missing.js"); rec.Code != http.StatusNotFound {
+		t.Errorf("GET /missing.js = %d, want 404", rec.Code)
+	}
+}
+
+func TestStaticSiteAPIPrefixesNeverGetHTML(t *testing.T) {
+	site := testSite(t)
+	for _, path := range []string{"/api/sessions/nope", "/ws"} {
+		if rec := get(t, site, path); rec.Code != http.StatusNotFound {
+			t.Errorf("GET %s = %d, want 404 instead of the SPA shell", path, rec.Code)
+		}
+	}
+}
+
+func TestStaticSiteOverrideDir(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "index.html"), []byte("dev shell"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	site, err := NewStaticSite(fstest.MapFS{"index.html": {Data: []byte("embedded")}}, dir)
+	if err != nil {
+		t.Fatalf("NewStaticSite with override: %v", err)
+	}
+	if rec := get(t, site, "/"); !strings.Contains(rec.Body.String(), "dev shell") {
+		t.Errorf("override dir not served: got %q", rec.Body.String())
+	}
+
+	if _, err := NewStaticSite(fstest.MapFS{"index.html": {Data: []byte("embedded")}}, t.TempDir()); err == nil {
+		t.Error("expected error for override dir without index.html")
+	}
+}